@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"go-react-app/model"
 	"go-react-app/usecase"
 	"net/http"
@@ -15,6 +16,7 @@ type IArticleController interface {
 	CreateArticle(c echo.Context) error
 	UpdateArticle(c echo.Context) error
 	DeleteArticle(c echo.Context) error
+	GetArticleSEO(c echo.Context) error
 }
 
 type articleController struct {
@@ -31,16 +33,27 @@ func NewArticleController(au usecase.IArticleUsecase) IArticleController {
 // @Tags articles
 // @Accept json
 // @Produce json
+// @Param format query string false "本文の形式 (markdown または html)"
 // @Success 200 {array} model.ArticleResponse
 // @Failure 500 {object} map[string]string
 // @Router /articles [get]
 func (ac *articleController) GetAllArticles(c echo.Context) error {
 	userId := getUserIdFromToken(c)
 	
+	renderHTML, err := wantsHTML(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	
 	articlesRes, err := ac.au.GetAllArticles(userId)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
+	if renderHTML {
+		if articlesRes, err = ac.au.RenderArticles(articlesRes); err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+	}
 	return c.JSON(http.StatusOK, articlesRes)
 }
 
@@ -51,6 +64,7 @@ func (ac *articleController) GetAllArticles(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param articleId path int true "記事ID"
+// @Param format query string false "本文の形式 (markdown または html)"
 // @Success 200 {object} model.ArticleResponse
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -58,6 +72,11 @@ func (ac *articleController) GetAllArticles(c echo.Context) error {
 func (ac *articleController) GetArticleById(c echo.Context) error {
 	userId := getUserIdFromToken(c)
 	
+	renderHTML, err := wantsHTML(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	
 	id := c.Param("articleId")
 	articleId, err := strconv.ParseUint(id, 10, 32)
 	if err != nil {
@@ -68,6 +87,11 @@ func (ac *articleController) GetArticleById(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
+	if renderHTML {
+		if articleRes, err = ac.au.RenderArticle(articleRes); err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+	}
 	return c.JSON(http.StatusOK, articleRes)
 }
 
@@ -78,6 +102,7 @@ func (ac *articleController) GetArticleById(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param article body model.ArticleRequest true "記事情報"
+// @Param format query string false "本文の形式 (markdown または html)"
 // @Success 201 {object} model.ArticleResponse
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -85,6 +110,11 @@ func (ac *articleController) GetArticleById(c echo.Context) error {
 func (ac *articleController) CreateArticle(c echo.Context) error {
 	userId := getUserIdFromToken(c)
 	
+	renderHTML, err := wantsHTML(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	
 	var request model.ArticleRequest
 	if err := c.Bind(&request); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
@@ -92,9 +122,17 @@ func (ac *articleController) CreateArticle(c echo.Context) error {
 	
 	request.UserId = userId
 	articleRes, err := ac.au.CreateArticle(request)
+	if errors.Is(err, usecase.ErrInvalidArticleLayout) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
+	if renderHTML {
+		if articleRes, err = ac.au.RenderArticle(articleRes); err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+	}
 	return c.JSON(http.StatusCreated, articleRes)
 }
 
@@ -106,6 +144,7 @@ func (ac *articleController) CreateArticle(c echo.Context) error {
 // @Produce json
 // @Param articleId path int true "記事ID"
 // @Param article body model.ArticleRequest true "更新する記事情報"
+// @Param format query string false "本文の形式 (markdown または html)"
 // @Success 200 {object} model.ArticleResponse
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -113,6 +152,11 @@ func (ac *articleController) CreateArticle(c echo.Context) error {
 func (ac *articleController) UpdateArticle(c echo.Context) error {
 	userId := getUserIdFromToken(c)
 	
+	renderHTML, err := wantsHTML(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	
 	id := c.Param("articleId")
 	articleId, err := strconv.ParseUint(id, 10, 32)
 	if err != nil {
@@ -126,9 +170,17 @@ func (ac *articleController) UpdateArticle(c echo.Context) error {
 	
 	request.UserId = userId
 	articleRes, err := ac.au.UpdateArticle(request, userId, uint(articleId))
+	if errors.Is(err, usecase.ErrInvalidArticleLayout) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
+	if renderHTML {
+		if articleRes, err = ac.au.RenderArticle(articleRes); err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+	}
 	return c.JSON(http.StatusOK, articleRes)
 }
 
@@ -157,4 +209,31 @@ func (ac *articleController) DeleteArticle(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 	return c.NoContent(http.StatusNoContent)
-}
\ No newline at end of file
+}
+
+// GetArticleSEO 記事のSEOメタデータを取得
+// @Summary 記事のSEOメタデータを取得
+// @Description メタディスクリプション（未設定の場合は本文の抜粋）・OG画像・canonical URL・noindexと、公開前の警告を取得する
+// @Tags articles
+// @Accept json
+// @Produce json
+// @Param articleId path int true "記事ID"
+// @Success 200 {object} model.ArticleSEOResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /articles/{articleId}/seo [get]
+func (ac *articleController) GetArticleSEO(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+	
+	id := c.Param("articleId")
+	articleId, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効な記事IDです"})
+	}
+	
+	seoRes, err := ac.au.GetArticleSEO(userId, uint(articleId))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, seoRes)
+}
@@ -7,6 +7,7 @@ import (
 	"go-react-app/repository"
 	"go-react-app/testutils"
 	"go-react-app/usecase"
+	"go-react-app/utils/markdown"
 	"go-react-app/validator"
 	"net/http"
 	"net/http/httptest"
@@ -41,7 +42,7 @@ func setupArticleControllerTest() {
 		articleDB = testutils.SetupTestDB()
 		articleRepo = repository.NewArticleRepository(articleDB)
 		articleValidator = validator.NewArticleValidator()
-		articleUsecase = usecase.NewArticleUsecase(articleRepo, articleValidator)
+		articleUsecase = usecase.NewArticleUsecase(articleRepo, articleValidator, repository.NewSearchRepository(articleDB), markdown.NewRenderer(markdown.DefaultCacheSize), repository.NewLayoutRepository(articleDB))
 		ac = NewArticleController(articleUsecase)
 	}
 	
@@ -0,0 +1,77 @@
+package article_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestArticleController_Format(t *testing.T) {
+	setupArticleControllerTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("format=htmlでレンダリング済みのHTMLを返す", func(t *testing.T) {
+			article := createTestArticle("Markdown Article", "# 見出し\n\n**太字**", articleTestUser.ID)
+
+			_, c, rec := setupEchoWithArticleId(
+				articleTestUser.ID,
+				article.ID,
+				http.MethodGet,
+				fmt.Sprintf("/articles/%d?format=html", article.ID),
+				"",
+			)
+			if err := articleController.GetArticleById(c); err != nil {
+				t.Errorf("GetArticleById() error = %v", err)
+			}
+			if rec.Code != http.StatusOK {
+				t.Fatalf("GetArticleById() status code = %d, want %d", rec.Code, http.StatusOK)
+			}
+
+			response := parseArticleResponse(t, rec.Body.Bytes())
+			if response.Content != article.Content {
+				t.Errorf("GetArticleById() content = %s, want %s", response.Content, article.Content)
+			}
+			if !strings.Contains(response.ContentHTML, "<strong>太字</strong>") || !strings.Contains(response.ContentHTML, `id="見出し"`) {
+				t.Errorf("GetArticleById() content_html = %s", response.ContentHTML)
+			}
+		})
+
+		t.Run("format未指定の場合はHTMLを含めない", func(t *testing.T) {
+			createTestArticle("Plain Article", "**太字**", articleTestUser.ID)
+
+			_, c, rec := setupEchoWithJWTAndBody(articleTestUser.ID, http.MethodGet, "/articles", "")
+			if err := articleController.GetAllArticles(c); err != nil {
+				t.Errorf("GetAllArticles() error = %v", err)
+			}
+			if strings.Contains(rec.Body.String(), "content_html") {
+				t.Errorf("GetAllArticles() = %s, should not contain content_html", rec.Body.String())
+			}
+		})
+
+		t.Run("一覧でもformat=htmlを指定できる", func(t *testing.T) {
+			_, c, rec := setupEchoWithJWTAndBody(articleTestUser.ID, http.MethodGet, "/articles?format=html", "")
+			if err := articleController.GetAllArticles(c); err != nil {
+				t.Errorf("GetAllArticles() error = %v", err)
+			}
+
+			for _, res := range parseArticlesResponse(t, rec.Body.Bytes()) {
+				if res.ContentHTML == "" {
+					t.Errorf("GetAllArticles() article %d has empty content_html", res.ID)
+				}
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("不正なformatは400を返す", func(t *testing.T) {
+			_, c, rec := setupEchoWithJWTAndBody(articleTestUser.ID, http.MethodGet, "/articles?format=pdf", "")
+			if err := articleController.GetAllArticles(c); err != nil {
+				t.Errorf("GetAllArticles() error = %v", err)
+			}
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("GetAllArticles() status code = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+		})
+	})
+}
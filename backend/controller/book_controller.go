@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
 	"go-react-app/model"
 	"go-react-app/usecase"
 	"net/http"
@@ -15,6 +17,8 @@ type IBookController interface {
 	CreateBook(c echo.Context) error
 	UpdateBook(c echo.Context) error
 	DeleteBook(c echo.Context) error
+	UpdateBookReading(c echo.Context) error
+	GetReadingStats(c echo.Context) error
 }
 
 type bookController struct {
@@ -25,19 +29,27 @@ func NewBookController(bu usecase.IBookUsecase) IBookController {
 	return &bookController{bu}
 }
 
-// GetAllBooks ユーザーのすべての書籍を取得
+// GetAllBooks ユーザーの書籍を取得
 // @Summary ユーザーの書籍一覧を取得
-// @Description ログインユーザーのすべての書籍を取得する
+// @Description ログインユーザーの書籍を取得する。読書の状態・評価の下限・読了または中断した年で絞り込める
 // @Tags books
 // @Accept json
 // @Produce json
+// @Param status query string false "読書の状態（want-to-read, reading, finished, abandoned）"
+// @Param min_rating query int false "評価の下限（1〜5）"
+// @Param year query int false "読了・中断した年"
 // @Success 200 {array} model.BookResponse
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /books [get]
 func (bc *bookController) GetAllBooks(c echo.Context) error {
 	userId := getUserIdFromToken(c)
-	
-	booksRes, err := bc.bu.GetAllBooks(userId)
+
+	filter, err := bookFilterFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	booksRes, err := bc.bu.GetBooks(userId, filter)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -80,6 +92,7 @@ func (bc *bookController) GetBookById(c echo.Context) error {
 // @Param book body model.BookRequest true "書籍情報"
 // @Success 201 {object} model.BookResponse
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} model.DuplicateBookResponse "同じISBNの書籍が登録済み"
 // @Failure 500 {object} map[string]string
 // @Router /books [post]
 func (bc *bookController) CreateBook(c echo.Context) error {
@@ -93,7 +106,7 @@ func (bc *bookController) CreateBook(c echo.Context) error {
 	request.UserId = userId
 	bookRes, err := bc.bu.CreateBook(request)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return writeBookError(c, bookRes, err)
 	}
 	return c.JSON(http.StatusCreated, bookRes)
 }
@@ -108,6 +121,7 @@ func (bc *bookController) CreateBook(c echo.Context) error {
 // @Param book body model.BookRequest true "更新する書籍情報"
 // @Success 200 {object} model.BookResponse
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} model.DuplicateBookResponse "同じISBNの書籍が登録済み"
 // @Failure 500 {object} map[string]string
 // @Router /books/{bookId} [put]
 func (bc *bookController) UpdateBook(c echo.Context) error {
@@ -127,7 +141,7 @@ func (bc *bookController) UpdateBook(c echo.Context) error {
 	request.UserId = userId
 	bookRes, err := bc.bu.UpdateBook(request, userId, uint(bookId))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return writeBookError(c, bookRes, err)
 	}
 	return c.JSON(http.StatusOK, bookRes)
 }
@@ -158,3 +172,112 @@ func (bc *bookController) DeleteBook(c echo.Context) error {
 	}
 	return c.NoContent(http.StatusNoContent)
 }
+
+// UpdateBookReading 書籍の読書記録を更新
+// @Summary 書籍の読書記録を更新
+// @Description 読書の状態・開始日と終了日・進捗・評価・メモとハイライトを更新する。日付を省略して読書中・読了・中断にした場合は当日とする
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param bookId path int true "書籍ID"
+// @Param reading body model.BookReadingRequest true "読書記録"
+// @Success 200 {object} model.BookResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /books/{bookId}/reading [put]
+func (bc *bookController) UpdateBookReading(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	bookId, err := strconv.ParseUint(c.Param("bookId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid book ID"})
+	}
+
+	var request model.BookReadingRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	bookRes, err := bc.bu.UpdateBookReading(request, userId, uint(bookId))
+	switch {
+	case errors.Is(err, usecase.ErrInvalidBookReading):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, usecase.ErrBookNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "book not found"})
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, bookRes)
+}
+
+// GetReadingStats 読書の統計を取得
+// @Summary 読書の統計を取得
+// @Description 読書の状態ごとの冊数と、年ごとの読了・中断した冊数・読んだページ数・平均の評価・月ごとの読了した冊数を返す
+// @Tags books
+// @Produce json
+// @Param year query int false "集計する年（省略した場合はすべての年）"
+// @Success 200 {object} model.ReadingStatsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /books/stats [get]
+func (bc *bookController) GetReadingStats(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	year := 0
+	if value := c.QueryParam("year"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid year"})
+		}
+		year = parsed
+	}
+
+	stats, err := bc.bu.GetReadingStats(userId, year)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, stats)
+}
+
+// writeBookError は書籍の登録・更新のエラーをステータスコードに対応付けて返します
+// 同じISBNの書籍が登録済みの場合は登録済みの書籍を含める
+func writeBookError(c echo.Context, existing model.BookResponse, err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidBook):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, usecase.ErrDuplicateBook):
+		return c.JSON(http.StatusConflict, model.DuplicateBookResponse{Error: err.Error(), Book: existing})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+}
+
+// bookFilterFromQuery はクエリパラメータから書籍の絞り込み条件を読み取ります
+func bookFilterFromQuery(c echo.Context) (model.BookFilter, error) {
+	filter := model.BookFilter{Status: c.QueryParam("status")}
+	if filter.Status != "" {
+		valid := false
+		for _, status := range model.BookStatuses {
+			valid = valid || filter.Status == status
+		}
+		if !valid {
+			return model.BookFilter{}, fmt.Errorf("invalid status: %s", filter.Status)
+		}
+	}
+	if value := c.QueryParam("min_rating"); value != "" {
+		rating, err := strconv.Atoi(value)
+		if err != nil || rating < 1 || rating > model.BookRatingMax {
+			return model.BookFilter{}, fmt.Errorf("min_rating must be between 1 and %d", model.BookRatingMax)
+		}
+		filter.MinRating = rating
+	}
+	if value := c.QueryParam("year"); value != "" {
+		year, err := strconv.Atoi(value)
+		if err != nil || year < 1 {
+			return model.BookFilter{}, errors.New("invalid year")
+		}
+		filter.Year = year
+	}
+	return filter, nil
+}
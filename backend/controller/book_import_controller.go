@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IBookImportController interface {
+	GetAllImports(c echo.Context) error
+	GetImportById(c echo.Context) error
+	CreateISBNImport(c echo.Context) error
+	CreateCSVImport(c echo.Context) error
+}
+
+type bookImportController struct {
+	iu usecase.IBookImportUsecase
+}
+
+func NewBookImportController(iu usecase.IBookImportUsecase) IBookImportController {
+	return &bookImportController{iu}
+}
+
+// GetAllImports ユーザーの書籍の一括登録ジョブ一覧を取得
+// @Summary 書籍の一括登録ジョブ一覧を取得
+// @Description ログインユーザーの書籍の一括登録ジョブを新しい順に取得する
+// @Tags book-imports
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.BookImportJobResponse
+// @Failure 500 {object} map[string]string
+// @Router /book-imports [get]
+func (ic *bookImportController) GetAllImports(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	jobsRes, err := ic.iu.GetAllImports(userId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, jobsRes)
+}
+
+// GetImportById 指定されたIDの書籍の一括登録ジョブを取得
+// @Summary 書籍の一括登録ジョブの状態を取得
+// @Description 指定されたIDの一括登録ジョブの進捗と、1行ごとの結果（imported・duplicate・not_found・failed）を取得する
+// @Tags book-imports
+// @Accept json
+// @Produce json
+// @Param importId path int true "一括登録ジョブID"
+// @Success 200 {object} model.BookImportJobResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /book-imports/{importId} [get]
+func (ic *bookImportController) GetImportById(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	importId, err := strconv.ParseUint(c.Param("importId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効な一括登録ジョブIDです"})
+	}
+
+	jobRes, err := ic.iu.GetImportById(userId, uint(importId))
+	if errors.Is(err, usecase.ErrBookImportNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "一括登録ジョブが見つかりません"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, jobRes)
+}
+
+// CreateISBNImport ISBNの一覧から書籍を一括登録
+// @Summary ISBNの一覧から書籍を一括登録
+// @Description ISBNごとにGoogle Books APIから書籍の情報を取得して蔵書に追加するジョブを開始する。登録済みの書籍は追加しない
+// @Tags book-imports
+// @Accept json
+// @Produce json
+// @Param request body model.BookISBNImportRequest true "ISBNの一覧"
+// @Success 202 {object} model.BookImportJobResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /book-imports [post]
+func (ic *bookImportController) CreateISBNImport(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	var request model.BookISBNImportRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	request.UserId = userId
+	jobRes, err := ic.iu.CreateISBNImport(request)
+	if errors.Is(err, usecase.ErrInvalidBookImport) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if errors.Is(err, usecase.ErrBookImportInProgress) {
+		return c.JSON(http.StatusConflict, map[string]string{"error": "実行中の一括登録が完了してから開始してください"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusAccepted, jobRes)
+}
+
+// CreateCSVImport 読書管理サービスのCSVから書籍を一括登録
+// @Summary CSVから書籍を一括登録
+// @Description Goodreads・ブクログ・読書メーターなどから書き出したCSVの書籍を蔵書に追加するジョブを開始する。書籍の情報はISBNでGoogle Books APIから取得し、見つからない場合はCSVの値を使う。読書の状態・評価・読了日も登録する
+// @Tags book-imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSVファイル（UTF-8またはShift_JIS、2MBまで）"
+// @Success 202 {object} model.BookImportJobResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /book-imports/csv [post]
+func (ic *bookImportController) CreateCSVImport(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "ファイルが指定されていません"})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	defer file.Close()
+
+	jobRes, err := ic.iu.CreateCSVImport(userId, file)
+	if errors.Is(err, usecase.ErrInvalidBookImport) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if errors.Is(err, usecase.ErrBookImportInProgress) {
+		return c.JSON(http.StatusConflict, map[string]string{"error": "実行中の一括登録が完了してから開始してください"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusAccepted, jobRes)
+}
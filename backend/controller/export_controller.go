@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IExportController interface {
+	GetAllExports(c echo.Context) error
+	GetExportById(c echo.Context) error
+	CreateExport(c echo.Context) error
+	DownloadExport(c echo.Context) error
+}
+
+type exportController struct {
+	eu usecase.IExportUsecase
+}
+
+func NewExportController(eu usecase.IExportUsecase) IExportController {
+	return &exportController{eu}
+}
+
+// GetAllExports ユーザーの書き出しジョブ一覧を取得
+// @Summary 静的サイトの書き出しジョブ一覧を取得
+// @Description ログインユーザーの書き出しジョブを新しい順に取得する
+// @Tags exports
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.ExportJobResponse
+// @Failure 500 {object} map[string]string
+// @Router /exports [get]
+func (ec *exportController) GetAllExports(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	jobsRes, err := ec.eu.GetAllExports(userId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, jobsRes)
+}
+
+// GetExportById 指定されたIDの書き出しジョブを取得
+// @Summary 書き出しジョブの状態を取得
+// @Description 指定されたIDの書き出しジョブの状態と結果を取得する
+// @Tags exports
+// @Accept json
+// @Produce json
+// @Param exportId path int true "書き出しジョブID"
+// @Success 200 {object} model.ExportJobResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /exports/{exportId} [get]
+func (ec *exportController) GetExportById(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	exportId, err := strconv.ParseUint(c.Param("exportId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効な書き出しジョブIDです"})
+	}
+
+	jobRes, err := ec.eu.GetExportById(userId, uint(exportId))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, jobRes)
+}
+
+// CreateExport 静的サイトの書き出しを開始
+// @Summary 静的サイトの書き出しを開始
+// @Description 公開済みの記事を指定したレイアウトで静的サイトとして書き出すジョブを開始する。前回の書き出し以降に更新された記事のみ生成し直す
+// @Tags exports
+// @Accept json
+// @Produce json
+// @Param export body model.ExportJobRequest true "書き出し設定"
+// @Success 202 {object} model.ExportJobResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string "待機中または実行中の書き出しがある"
+// @Failure 500 {object} map[string]string
+// @Router /exports [post]
+func (ec *exportController) CreateExport(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	var request model.ExportJobRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	request.UserId = userId
+	jobRes, err := ec.eu.CreateExport(request)
+	if errors.Is(err, usecase.ErrInvalidExport) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if errors.Is(err, usecase.ErrExportInProgress) {
+		return c.JSON(http.StatusConflict, map[string]string{"error": "実行中の書き出しが完了してから開始してください"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusAccepted, jobRes)
+}
+
+// DownloadExport 書き出した静的サイトをダウンロード
+// @Summary 書き出した静的サイトをZIPでダウンロード
+// @Description 完了した書き出しジョブの静的サイトをZIPファイルとして取得する
+// @Tags exports
+// @Produce application/zip
+// @Param exportId path int true "書き出しジョブID"
+// @Success 200 {file} binary
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /exports/{exportId}/download [get]
+func (ec *exportController) DownloadExport(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	exportId, err := strconv.ParseUint(c.Param("exportId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効な書き出しジョブIDです"})
+	}
+
+	path, err := ec.eu.GetExportArchive(userId, uint(exportId))
+	if errors.Is(err, usecase.ErrExportNotReady) {
+		return c.JSON(http.StatusConflict, map[string]string{"error": "書き出しが完了していません"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.Attachment(path, fmt.Sprintf("site-export-%d.zip", exportId))
+}
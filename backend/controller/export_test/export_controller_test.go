@@ -0,0 +1,100 @@
+package export_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-react-app/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestExportController(t *testing.T) {
+	setupExportControllerTest()
+	exportDB.Create(&model.Article{Title: "公開記事", Content: "本文", Published: true, UserId: exportTestUser.ID})
+
+	var created model.ExportJobResponse
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("書き出しを開始すると202を返す", func(t *testing.T) {
+			body := fmt.Sprintf(`{"layout_id":%d,"base_url":"https://blog.example.com"}`, exportLayout.ID)
+			req := httptest.NewRequest(http.MethodPost, "/exports", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c, rec := newContext(exportTestUser.ID, req)
+
+			if err := exportController.CreateExport(c); err != nil {
+				t.Fatalf("CreateExport() error = %v", err)
+			}
+			if rec.Code != http.StatusAccepted {
+				t.Fatalf("CreateExport() status code = %d, want %d: %s", rec.Code, http.StatusAccepted, rec.Body.String())
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if created.Status != model.ExportStatusCompleted || created.DownloadURL != fmt.Sprintf("/exports/%d/download", created.ID) {
+				t.Errorf("CreateExport() = %+v", created)
+			}
+		})
+
+		t.Run("ジョブの状態を取得できる", func(t *testing.T) {
+			c, rec := newContextWithExportId(exportTestUser.ID, fmt.Sprintf("/exports/%d", created.ID), created.ID)
+			if err := exportController.GetExportById(c); err != nil {
+				t.Fatalf("GetExportById() error = %v", err)
+			}
+			if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"status":"completed"`) {
+				t.Errorf("GetExportById() status code = %d, body = %s", rec.Code, rec.Body.String())
+			}
+		})
+
+		t.Run("書き出したサイトをZIPでダウンロードできる", func(t *testing.T) {
+			c, rec := newContextWithExportId(exportTestUser.ID, created.DownloadURL, created.ID)
+			if err := exportController.DownloadExport(c); err != nil {
+				t.Fatalf("DownloadExport() error = %v", err)
+			}
+			if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Body.String(), "PK") {
+				t.Errorf("DownloadExport() status code = %d", rec.Code)
+			}
+			if disposition := rec.Header().Get(echo.HeaderContentDisposition); !strings.Contains(disposition, "site-export-") {
+				t.Errorf("Content-Disposition = %q", disposition)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("公開先のURLがない場合は400を返す", func(t *testing.T) {
+			body := fmt.Sprintf(`{"layout_id":%d}`, exportLayout.ID)
+			req := httptest.NewRequest(http.MethodPost, "/exports", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c, rec := newContext(exportTestUser.ID, req)
+
+			exportController.CreateExport(c)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("CreateExport() status code = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+		})
+
+		t.Run("完了していないジョブのダウンロードは409を返す", func(t *testing.T) {
+			job := model.ExportJob{Status: model.ExportStatusRunning, BaseURL: "https://blog.example.com", LayoutId: exportLayout.ID, UserId: exportTestUser.ID}
+			exportDB.Create(&job)
+
+			c, rec := newContextWithExportId(exportTestUser.ID, fmt.Sprintf("/exports/%d/download", job.ID), job.ID)
+			exportController.DownloadExport(c)
+			if rec.Code != http.StatusConflict {
+				t.Errorf("DownloadExport() status code = %d, want %d", rec.Code, http.StatusConflict)
+			}
+		})
+
+		t.Run("無効なIDは400を返す", func(t *testing.T) {
+			c, rec := newContext(exportTestUser.ID, httptest.NewRequest(http.MethodGet, "/exports/abc", nil))
+			c.SetParamNames("exportId")
+			c.SetParamValues("abc")
+			exportController.GetExportById(c)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("GetExportById() status code = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+		})
+	})
+}
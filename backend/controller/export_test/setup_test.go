@@ -0,0 +1,81 @@
+package export_test
+
+import (
+	"fmt"
+	"go-react-app/controller"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/utils/layoutrender"
+	"go-react-app/utils/markdown"
+	"go-react-app/utils/staticsite"
+	"go-react-app/validator"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// テスト用の共通変数
+var (
+	exportDB         *gorm.DB
+	exportController controller.IExportController
+	exportTestUser   model.User
+	exportLayout     model.Layout
+)
+
+// テストセットアップ関数
+func setupExportControllerTest() {
+	if exportDB != nil {
+		testutils.CleanupTestDB(exportDB)
+		exportDB.Exec("DELETE FROM articles")
+		exportDB.Exec("DELETE FROM layouts")
+		exportDB.Exec("DELETE FROM export_jobs")
+	} else {
+		exportDB = testutils.SetupTestDB()
+		dir, err := os.MkdirTemp("", "export_controller_test")
+		if err != nil {
+			log.Fatalf("一時ディレクトリの作成に失敗しました: %v", err)
+		}
+		exportUsecase := usecase.NewExportUsecase(
+			repository.NewExportRepository(exportDB),
+			validator.NewExportValidator(),
+			repository.NewUserRepository(exportDB),
+			repository.NewArticleRepository(exportDB),
+			repository.NewLayoutRepository(exportDB),
+			staticsite.NewBuilder(markdown.NewRenderer(markdown.DefaultCacheSize), layoutrender.NewSiteRenderer(layoutrender.NewRenderer()), nil, ""),
+			usecase.ExportConfig{Dir: dir, Synchronous: true},
+		)
+		exportController = controller.NewExportController(exportUsecase)
+	}
+
+	exportTestUser = testutils.CreateTestUser(exportDB)
+	exportLayout = model.Layout{Title: "ブログ", UserId: exportTestUser.ID}
+	exportDB.Create(&exportLayout)
+}
+
+// JWTクレームを設定したコンテキストを作成するヘルパー関数
+func newContext(userId uint, req *http.Request) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["user_id"] = float64(userId)
+	c.Set("user", token)
+	return c, rec
+}
+
+// ExportIDパラメータを持つコンテキストを作成するヘルパー関数
+func newContextWithExportId(userId uint, path string, exportId uint) (echo.Context, *httptest.ResponseRecorder) {
+	c, rec := newContext(userId, httptest.NewRequest(http.MethodGet, path, nil))
+	c.SetParamNames("exportId")
+	c.SetParamValues(fmt.Sprintf("%d", exportId))
+	return c, rec
+}
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
 	"go-react-app/model"
 	"go-react-app/usecase"
 	"net/http"
@@ -16,14 +18,17 @@ type IExternalAPIController interface {
 	CreateExternalAPI(c echo.Context) error
 	UpdateExternalAPI(c echo.Context) error
 	DeleteExternalAPI(c echo.Context) error
+	CallExternalAPI(c echo.Context) error
+	GetExternalAPIStatus(c echo.Context) error
 }
 
 type externalAPIController struct {
 	au usecase.IExternalAPIUsecase
+	hu usecase.IExternalAPIHealthUsecase
 }
 
-func NewExternalAPIController(au usecase.IExternalAPIUsecase) IExternalAPIController {
-	return &externalAPIController{au}
+func NewExternalAPIController(au usecase.IExternalAPIUsecase, hu usecase.IExternalAPIHealthUsecase) IExternalAPIController {
+	return &externalAPIController{au, hu}
 }
 
 func (ac *externalAPIController) GetAllExternalAPIs(c echo.Context) error {
@@ -62,6 +67,10 @@ func (ac *externalAPIController) CreateExternalAPI(c echo.Context) error {
 	}
 	api.UserId = uint(userId.(float64))
 	apiRes, err := ac.au.CreateExternalAPI(api)
+	if errors.Is(err, usecase.ErrSecretStoreDisabled) {
+		// 認証情報を暗号化できないため保存しない
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, err.Error())
 	}
@@ -80,6 +89,10 @@ func (ac *externalAPIController) UpdateExternalAPI(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, err.Error())
 	}
 	apiRes, err := ac.au.UpdateExternalAPI(api, uint(userId.(float64)), uint(apiId))
+	if errors.Is(err, usecase.ErrSecretStoreDisabled) {
+		// 認証情報を暗号化できないため保存しない
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, err.Error())
 	}
@@ -99,3 +112,95 @@ func (ac *externalAPIController) DeleteExternalAPI(c echo.Context) error {
 	}
 	return c.NoContent(http.StatusNoContent)
 }
+
+// CallExternalAPI 登録した外部APIを呼び出す
+// @Summary 外部APIを呼び出す
+// @Description 登録した外部APIを、保存された認証情報・メソッド・パス・クエリパラメータでサーバーから呼び出す。外部APIのステータスコードと本文をそのまま返す。内部ネットワークへの接続は拒否する
+// @Tags external-apis
+// @Accept json
+// @Produce json
+// @Param apiId path int true "外部APIのID"
+// @Param request body model.ExternalAPICallRequest false "パスとクエリパラメータに埋め込む値と送信する本文"
+// @Success 200 {object} model.ExternalAPICallResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Failure 504 {object} map[string]string
+// @Router /external-apis/{apiId}/call [post]
+func (ac *externalAPIController) CallExternalAPI(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	apiId, err := strconv.ParseUint(c.Param("apiId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効な外部APIのIDです"})
+	}
+
+	var request model.ExternalAPICallRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	callRes, err := ac.au.CallExternalAPI(userId, uint(apiId), request)
+	if err != nil {
+		return c.JSON(externalAPICallErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, callRes)
+}
+
+// GetExternalAPIStatus 外部APIの稼働状況を取得する
+// @Summary 外部APIの稼働状況を取得
+// @Description バックグラウンドで実行したヘルスチェックの結果から、直近の稼働状況・稼働率・平均の応答時間・最後の異常を返す
+// @Tags external-apis
+// @Produce json
+// @Param apiId path int true "外部APIのID"
+// @Param days query int false "稼働率を集計する期間（日）。既定値は7、最大30"
+// @Success 200 {object} model.ExternalAPIStatusResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /external-apis/{apiId}/status [get]
+func (ac *externalAPIController) GetExternalAPIStatus(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	apiId, err := strconv.ParseUint(c.Param("apiId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効な外部APIのIDです"})
+	}
+	days := 0
+	if value := c.QueryParam("days"); value != "" {
+		days, err = strconv.Atoi(value)
+		if err != nil || days < 1 || days > model.MaxStatusWindowDays {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("daysは1から%dの整数で指定してください", model.MaxStatusWindowDays)})
+		}
+	}
+
+	statusRes, err := ac.hu.GetExternalAPIStatus(userId, uint(apiId), days)
+	if err != nil {
+		if errors.Is(err, usecase.ErrExternalAPINotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "外部APIが見つかりません"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, statusRes)
+}
+
+// externalAPICallErrorStatus は外部APIの呼び出しのエラーに対応するステータスコードを返します
+func externalAPICallErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidExternalAPICall):
+		return http.StatusBadRequest
+	case errors.Is(err, usecase.ErrExternalAPIBlocked):
+		return http.StatusForbidden
+	case errors.Is(err, usecase.ErrExternalAPITimeout):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, usecase.ErrExternalAPIUnavailable):
+		return http.StatusBadGateway
+	case errors.Is(err, usecase.ErrSecretStoreDisabled):
+		// 認証情報を復号できないため、認証なしでは呼び出さない
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
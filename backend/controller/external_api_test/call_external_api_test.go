@@ -0,0 +1,63 @@
+package external_api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-react-app/controller"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallExternalAPI(t *testing.T) {
+	mockUsecase := new(MockExternalAPIUsecase)
+	externalAPIController := controller.NewExternalAPIController(mockUsecase, new(MockExternalAPIHealthUsecase))
+
+	request := model.ExternalAPICallRequest{Params: map[string]string{"id": "42"}}
+
+	tests := []struct {
+		name     string
+		response model.ExternalAPICallResponse
+		err      error
+		want     int
+	}{
+		{"正常系", model.ExternalAPICallResponse{StatusCode: http.StatusOK, Body: json.RawMessage(`{"ok":true}`)}, nil, http.StatusOK},
+		{"外部APIのエラーもそのまま200で返す", model.ExternalAPICallResponse{StatusCode: http.StatusNotFound}, nil, http.StatusOK},
+		{"パラメータの不足は400を返す", model.ExternalAPICallResponse{}, fmt.Errorf("%w: missing params: id", usecase.ErrInvalidExternalAPICall), http.StatusBadRequest},
+		{"内部ネットワークへの接続は403を返す", model.ExternalAPICallResponse{}, fmt.Errorf("%w: 127.0.0.1", usecase.ErrExternalAPIBlocked), http.StatusForbidden},
+		{"接続できない場合は502を返す", model.ExternalAPICallResponse{}, fmt.Errorf("%w: connection refused", usecase.ErrExternalAPIUnavailable), http.StatusBadGateway},
+		{"タイムアウトは504を返す", model.ExternalAPICallResponse{}, fmt.Errorf("%w: no response within 10 seconds", usecase.ErrExternalAPITimeout), http.StatusGatewayTimeout},
+		{"認証情報を復号できない場合は503を返す", model.ExternalAPICallResponse{}, usecase.ErrSecretStoreDisabled, http.StatusServiceUnavailable},
+		{"その他のエラーは500を返す", model.ExternalAPICallResponse{}, fmt.Errorf("database is locked"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.On("CallExternalAPI", uint(1), uint(5), request).Return(tt.response, tt.err).Once()
+
+			c, rec := setupContext(http.MethodPost, "/external-apis/5/call", `{"params":{"id":"42"}}`)
+			c.SetParamNames("apiId")
+			c.SetParamValues("5")
+
+			if assert.NoError(t, externalAPIController.CallExternalAPI(c)) {
+				assert.Equal(t, tt.want, rec.Code)
+			}
+		})
+	}
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("不正なIDは400を返す", func(t *testing.T) {
+			c, rec := setupContext(http.MethodPost, "/external-apis/abc/call", `{}`)
+			c.SetParamNames("apiId")
+			c.SetParamValues("abc")
+
+			if assert.NoError(t, externalAPIController.CallExternalAPI(c)) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			}
+		})
+	})
+
+	mockUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,76 @@
+package external_api_test
+
+import (
+	"go-react-app/model"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock for external api usecase
+type MockExternalAPIUsecase struct {
+	mock.Mock
+}
+
+func (m *MockExternalAPIUsecase) GetAllExternalAPIs(userId uint) ([]model.ExternalAPIResponse, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]model.ExternalAPIResponse), args.Error(1)
+}
+
+func (m *MockExternalAPIUsecase) GetExternalAPIById(userId uint, apiId uint) (model.ExternalAPIResponse, error) {
+	args := m.Called(userId, apiId)
+	return args.Get(0).(model.ExternalAPIResponse), args.Error(1)
+}
+
+func (m *MockExternalAPIUsecase) CreateExternalAPI(api model.ExternalAPI) (model.ExternalAPIResponse, error) {
+	args := m.Called(api)
+	return args.Get(0).(model.ExternalAPIResponse), args.Error(1)
+}
+
+func (m *MockExternalAPIUsecase) UpdateExternalAPI(api model.ExternalAPI, userId uint, apiId uint) (model.ExternalAPIResponse, error) {
+	args := m.Called(api, userId, apiId)
+	return args.Get(0).(model.ExternalAPIResponse), args.Error(1)
+}
+
+func (m *MockExternalAPIUsecase) DeleteExternalAPI(userId uint, apiId uint) error {
+	args := m.Called(userId, apiId)
+	return args.Error(0)
+}
+
+func (m *MockExternalAPIUsecase) CallExternalAPI(userId uint, apiId uint, request model.ExternalAPICallRequest) (model.ExternalAPICallResponse, error) {
+	args := m.Called(userId, apiId, request)
+	return args.Get(0).(model.ExternalAPICallResponse), args.Error(1)
+}
+
+// Mock for external api health usecase
+type MockExternalAPIHealthUsecase struct {
+	mock.Mock
+}
+
+func (m *MockExternalAPIHealthUsecase) GetExternalAPIStatus(userId uint, apiId uint, windowDays int) (model.ExternalAPIStatusResponse, error) {
+	args := m.Called(userId, apiId, windowDays)
+	return args.Get(0).(model.ExternalAPIStatusResponse), args.Error(1)
+}
+
+func (m *MockExternalAPIHealthUsecase) RunDueHealthChecks(now time.Time) (int, error) {
+	args := m.Called(now)
+	return args.Int(0), args.Error(1)
+}
+
+// Helper function to setup the Echo context with a JWT token
+func setupContext(method, url string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, url, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	token := jwt.New(jwt.SigningMethodHS256)
+	token.Claims.(jwt.MapClaims)["user_id"] = float64(1)
+	c.Set("user", token)
+	return c, rec
+}
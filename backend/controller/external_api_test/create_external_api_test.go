@@ -0,0 +1,77 @@
+package external_api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-react-app/controller"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateExternalAPI(t *testing.T) {
+	mockUsecase := new(MockExternalAPIUsecase)
+	externalAPIController := controller.NewExternalAPIController(mockUsecase, new(MockExternalAPIHealthUsecase))
+	body := `{"name":"天気","base_url":"https://api.example.com","auth_type":"bearer","auth_credential":"secret-token"}`
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("作成した外部APIを返す", func(t *testing.T) {
+			mockUsecase.On("CreateExternalAPI", mock.AnythingOfType("model.ExternalAPI")).Return(model.ExternalAPIResponse{ID: 3, Name: "天気"}, nil).Once()
+
+			c, rec := setupContext(http.MethodPost, "/external-apis", body)
+
+			if assert.NoError(t, externalAPIController.CreateExternalAPI(c)) {
+				assert.Equal(t, http.StatusCreated, rec.Code)
+				var res model.ExternalAPIResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				assert.Equal(t, uint(3), res.ID)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("秘密情報の保存が無効な場合は503を返す", func(t *testing.T) {
+			mockUsecase.On("CreateExternalAPI", mock.AnythingOfType("model.ExternalAPI")).Return(model.ExternalAPIResponse{}, usecase.ErrSecretStoreDisabled).Once()
+
+			c, rec := setupContext(http.MethodPost, "/external-apis", body)
+
+			if assert.NoError(t, externalAPIController.CreateExternalAPI(c)) {
+				assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+			}
+		})
+
+		t.Run("その他のエラーは500を返す", func(t *testing.T) {
+			mockUsecase.On("CreateExternalAPI", mock.AnythingOfType("model.ExternalAPI")).Return(model.ExternalAPIResponse{}, fmt.Errorf("database is locked")).Once()
+
+			c, rec := setupContext(http.MethodPost, "/external-apis", body)
+
+			if assert.NoError(t, externalAPIController.CreateExternalAPI(c)) {
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			}
+		})
+	})
+}
+
+func TestUpdateExternalAPI(t *testing.T) {
+	mockUsecase := new(MockExternalAPIUsecase)
+	externalAPIController := controller.NewExternalAPIController(mockUsecase, new(MockExternalAPIHealthUsecase))
+	body := `{"name":"天気","base_url":"https://api.example.com","auth_type":"bearer","auth_credential":"new-token"}`
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("秘密情報の保存が無効な場合は503を返す", func(t *testing.T) {
+			mockUsecase.On("UpdateExternalAPI", mock.AnythingOfType("model.ExternalAPI"), uint(1), uint(3)).Return(model.ExternalAPIResponse{}, usecase.ErrSecretStoreDisabled).Once()
+
+			c, rec := setupContext(http.MethodPut, "/external-apis/3", body)
+			c.SetParamNames("apiId")
+			c.SetParamValues("3")
+
+			if assert.NoError(t, externalAPIController.UpdateExternalAPI(c)) {
+				assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+			}
+		})
+	})
+}
@@ -0,0 +1,86 @@
+package external_api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-react-app/controller"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExternalAPIStatus(t *testing.T) {
+	mockHealthUsecase := new(MockExternalAPIHealthUsecase)
+	externalAPIController := controller.NewExternalAPIController(new(MockExternalAPIUsecase), mockHealthUsecase)
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("稼働状況を返す", func(t *testing.T) {
+			uptime := 99.5
+			status := model.ExternalAPIStatusResponse{ExternalAPIId: 5, Enabled: true, Status: model.ExternalAPIStatusUp, WindowDays: 3, Checks: 200, UptimePercent: &uptime}
+			mockHealthUsecase.On("GetExternalAPIStatus", uint(1), uint(5), 3).Return(status, nil).Once()
+
+			c, rec := setupContext(http.MethodGet, "/external-apis/5/status?days=3", "")
+			c.SetParamNames("apiId")
+			c.SetParamValues("5")
+
+			if assert.NoError(t, externalAPIController.GetExternalAPIStatus(c)) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				var res model.ExternalAPIStatusResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				assert.Equal(t, model.ExternalAPIStatusUp, res.Status)
+				assert.Equal(t, 99.5, *res.UptimePercent)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		tests := []struct {
+			name string
+			url  string
+			id   string
+			want int
+		}{
+			{"不正なIDは400を返す", "/external-apis/abc/status", "abc", http.StatusBadRequest},
+			{"期間が0日の場合は400を返す", "/external-apis/5/status?days=0", "5", http.StatusBadRequest},
+			{"期間が上限を超える場合は400を返す", "/external-apis/5/status?days=31", "5", http.StatusBadRequest},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				c, rec := setupContext(http.MethodGet, tt.url, "")
+				c.SetParamNames("apiId")
+				c.SetParamValues(tt.id)
+
+				if assert.NoError(t, externalAPIController.GetExternalAPIStatus(c)) {
+					assert.Equal(t, tt.want, rec.Code)
+				}
+			})
+		}
+
+		t.Run("存在しない外部APIは404を返す", func(t *testing.T) {
+			mockHealthUsecase.On("GetExternalAPIStatus", uint(1), uint(9), 0).Return(model.ExternalAPIStatusResponse{}, usecase.ErrExternalAPINotFound).Once()
+
+			c, rec := setupContext(http.MethodGet, "/external-apis/9/status", "")
+			c.SetParamNames("apiId")
+			c.SetParamValues("9")
+
+			if assert.NoError(t, externalAPIController.GetExternalAPIStatus(c)) {
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			}
+		})
+
+		t.Run("その他のエラーは500を返す", func(t *testing.T) {
+			mockHealthUsecase.On("GetExternalAPIStatus", uint(1), uint(5), 0).Return(model.ExternalAPIStatusResponse{}, fmt.Errorf("database is locked")).Once()
+
+			c, rec := setupContext(http.MethodGet, "/external-apis/5/status", "")
+			c.SetParamNames("apiId")
+			c.SetParamValues("5")
+
+			if assert.NoError(t, externalAPIController.GetExternalAPIStatus(c)) {
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			}
+		})
+	})
+}
@@ -39,7 +39,7 @@ func setupFeedArticleControllerTest() {
 		feedArticleDB = testutils.SetupTestDB()
 		articleFeedRepo = repository.NewFeedRepository(feedArticleDB)
 		articleFeedArticleRepo = repository.NewFeedArticleRepository(articleFeedRepo)
-		articleFeedArticleUcase = usecase.NewFeedArticleUsecase(articleFeedArticleRepo)
+		articleFeedArticleUcase = usecase.NewFeedArticleUsecase(articleFeedArticleRepo, articleFeedRepo, repository.NewSearchRepository(feedArticleDB))
 		feedArticleCtrl = NewFeedArticleController(articleFeedArticleUcase) // 変数名を変更
 	}
 	
@@ -41,7 +41,7 @@ func setupFeedControllerTest() {
 		feedDB = testutils.SetupTestDB()
 		feedRepo = repository.NewFeedRepository(feedDB)
 		feedValidator = validator.NewFeedValidator()
-		feedUsecase = usecase.NewFeedUsecase(feedRepo, feedValidator)
+		feedUsecase = usecase.NewFeedUsecase(feedRepo, feedValidator, repository.NewSearchRepository(feedDB))
 		fc = NewFeedController(feedUsecase)
 	}
 	
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"go-react-app/model"
 	"go-react-app/usecase"
 	"net/http"
@@ -35,12 +36,14 @@ func NewGoogleBookController(gbu usecase.IGoogleBookUsecase, bu usecase.IBookUse
 // @Failure 500 {object} map[string]string
 // @Router /google-books/search [post]
 func (gbc *googleBookController) SearchBooks(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
 	var request model.GoogleBookSearchRequest
 	if err := c.Bind(&request); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 	
-	result, err := gbc.gbu.SearchBooks(request)
+	result, err := gbc.gbu.SearchBooks(userId, request)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -60,12 +63,14 @@ func (gbc *googleBookController) SearchBooks(c echo.Context) error {
 // @Failure 500 {object} map[string]string
 // @Router /google-books/{id} [get]
 func (gbc *googleBookController) GetBookByID(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
 	id := c.Param("id")
 	if id == "" {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "book ID is required"})
 	}
 	
-	book, err := gbc.gbu.GetBookByID(id)
+	book, err := gbc.gbu.GetBookByID(userId, id)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -75,11 +80,12 @@ func (gbc *googleBookController) GetBookByID(c echo.Context) error {
 
 // ImportBookFromGoogle Google Books APIから書籍をインポート
 // @Summary 書籍をインポート
-// @Description Google Books APIから取得した書籍をユーザーの蔵書に追加する
+// @Description Google Books APIから取得した書籍をユーザーの蔵書に追加する。同じISBNの書籍が登録済みの場合は追加せず、登録済みの書籍を200で返す
 // @Tags google-books
 // @Accept json
 // @Produce json
 // @Param id path string true "Google Books ID"
+// @Success 200 {object} model.BookResponse "登録済みの書籍"
 // @Success 201 {object} model.BookResponse
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -93,7 +99,7 @@ func (gbc *googleBookController) ImportBookFromGoogle(c echo.Context) error {
 	}
 	
 	// Google Books APIから書籍情報を取得
-	googleBook, err := gbc.gbu.GetBookByID(id)
+	googleBook, err := gbc.gbu.GetBookByID(userId, id)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -102,8 +108,11 @@ func (gbc *googleBookController) ImportBookFromGoogle(c echo.Context) error {
 	bookRequest := googleBook.ToBookRequest()
 	bookRequest.UserId = userId
 	
-	// 書籍を作成
+	// 書籍を作成（同じISBNの書籍が登録済みの場合はその書籍を返す）
 	bookRes, err := gbc.bu.CreateBook(bookRequest)
+	if errors.Is(err, usecase.ErrDuplicateBook) {
+		return c.JSON(http.StatusOK, bookRes)
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
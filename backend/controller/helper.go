@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"errors"
+
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/labstack/echo/v4"
 )
@@ -11,3 +13,16 @@ func getUserIdFromToken(c echo.Context) uint {
 	claims := user.Claims.(jwt.MapClaims)
 	return uint(claims["user_id"].(float64))
 }
+
+// wantsHTML はクエリパラメータformatでHTML形式が指定されているかを判定するヘルパー関数
+// 未指定または"markdown"の場合はfalse、"html"の場合はtrue、それ以外はエラーを返す
+func wantsHTML(c echo.Context) (bool, error) {
+	switch c.QueryParam("format") {
+	case "", "markdown":
+		return false, nil
+	case "html":
+		return true, nil
+	default:
+		return false, errors.New("formatにはmarkdownまたはhtmlを指定してください")
+	}
+}
@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ILayoutAssignmentController interface {
+	GetAssignments(c echo.Context) error
+	SetDefaultLayout(c echo.Context) error
+	DeleteDefaultLayout(c echo.Context) error
+	SetTagLayout(c echo.Context) error
+	DeleteTagLayout(c echo.Context) error
+}
+
+type layoutAssignmentController struct {
+	lau usecase.ILayoutAssignmentUsecase
+}
+
+func NewLayoutAssignmentController(lau usecase.ILayoutAssignmentUsecase) ILayoutAssignmentController {
+	return &layoutAssignmentController{lau}
+}
+
+// GetAssignments 記事のページに使うレイアウトの指定を取得
+// @Summary レイアウトの指定を取得
+// @Description ログインユーザーの既定のレイアウトと、タグのページに使うレイアウトの指定を取得する
+// @Tags layout-assignments
+// @Produce json
+// @Success 200 {object} model.LayoutAssignmentsResponse
+// @Failure 500 {object} map[string]string
+// @Router /layout-assignments [get]
+func (lac *layoutAssignmentController) GetAssignments(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	assignmentsRes, err := lac.lau.GetAssignments(userId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, assignmentsRes)
+}
+
+// SetDefaultLayout 既定のレイアウトを設定
+// @Summary 既定のレイアウトを設定
+// @Description 記事とタグにレイアウトの指定がない場合に、記事のページに使うレイアウトを設定する
+// @Tags layout-assignments
+// @Accept json
+// @Produce json
+// @Param request body model.LayoutAssignmentRequest true "使用するレイアウト"
+// @Success 200 {object} model.LayoutAssignmentResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layout-assignments/default [put]
+func (lac *layoutAssignmentController) SetDefaultLayout(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	var request model.LayoutAssignmentRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	assignmentRes, err := lac.lau.SetDefaultLayout(userId, request)
+	return lac.writeAssignment(c, assignmentRes, err)
+}
+
+// DeleteDefaultLayout 既定のレイアウトを解除
+// @Summary 既定のレイアウトを解除
+// @Description 既定のレイアウトの指定を解除する。レイアウト自体は削除しない
+// @Tags layout-assignments
+// @Success 204 "No Content"
+// @Failure 500 {object} map[string]string
+// @Router /layout-assignments/default [delete]
+func (lac *layoutAssignmentController) DeleteDefaultLayout(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	if err := lac.lau.DeleteDefaultLayout(userId); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// SetTagLayout タグのページに使うレイアウトを設定
+// @Summary タグのレイアウトを設定
+// @Description 指定したタグを持つ記事のページに使うレイアウトを設定する。記事にレイアウトの指定がある場合はそちらを優先する
+// @Tags layout-assignments
+// @Accept json
+// @Produce json
+// @Param tag path string true "タグ"
+// @Param request body model.LayoutAssignmentRequest true "使用するレイアウト"
+// @Success 200 {object} model.LayoutAssignmentResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layout-assignments/tags/{tag} [put]
+func (lac *layoutAssignmentController) SetTagLayout(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	tag, err := url.PathUnescape(c.Param("tag"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なタグです"})
+	}
+
+	var request model.LayoutAssignmentRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	assignmentRes, err := lac.lau.SetTagLayout(userId, tag, request)
+	return lac.writeAssignment(c, assignmentRes, err)
+}
+
+// DeleteTagLayout タグのページに使うレイアウトを解除
+// @Summary タグのレイアウトを解除
+// @Description 指定したタグのレイアウトの指定を解除する。レイアウト自体は削除しない
+// @Tags layout-assignments
+// @Param tag path string true "タグ"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layout-assignments/tags/{tag} [delete]
+func (lac *layoutAssignmentController) DeleteTagLayout(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	tag, err := url.PathUnescape(c.Param("tag"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なタグです"})
+	}
+
+	err = lac.lau.DeleteTagLayout(userId, tag)
+	if errors.Is(err, usecase.ErrInvalidLayoutAssignment) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// writeAssignment は保存したレイアウトの指定を出力し、不正な指定の場合は400を返します
+func (lac *layoutAssignmentController) writeAssignment(c echo.Context, assignmentRes model.LayoutAssignmentResponse, err error) error {
+	if errors.Is(err, usecase.ErrInvalidLayoutAssignment) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, assignmentRes)
+}
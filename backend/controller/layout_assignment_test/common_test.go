@@ -0,0 +1,60 @@
+package layout_assignment_test
+
+import (
+	"go-react-app/model"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock for layout assignment usecase
+type MockLayoutAssignmentUsecase struct {
+	mock.Mock
+}
+
+func (m *MockLayoutAssignmentUsecase) GetAssignments(userId uint) (model.LayoutAssignmentsResponse, error) {
+	args := m.Called(userId)
+	return args.Get(0).(model.LayoutAssignmentsResponse), args.Error(1)
+}
+
+func (m *MockLayoutAssignmentUsecase) SetDefaultLayout(userId uint, request model.LayoutAssignmentRequest) (model.LayoutAssignmentResponse, error) {
+	args := m.Called(userId, request)
+	return args.Get(0).(model.LayoutAssignmentResponse), args.Error(1)
+}
+
+func (m *MockLayoutAssignmentUsecase) DeleteDefaultLayout(userId uint) error {
+	args := m.Called(userId)
+	return args.Error(0)
+}
+
+func (m *MockLayoutAssignmentUsecase) SetTagLayout(userId uint, tag string, request model.LayoutAssignmentRequest) (model.LayoutAssignmentResponse, error) {
+	args := m.Called(userId, tag, request)
+	return args.Get(0).(model.LayoutAssignmentResponse), args.Error(1)
+}
+
+func (m *MockLayoutAssignmentUsecase) DeleteTagLayout(userId uint, tag string) error {
+	args := m.Called(userId, tag)
+	return args.Error(0)
+}
+
+func (m *MockLayoutAssignmentUsecase) ResolveArticleLayout(userId uint, article model.Article) (model.ResolvedLayout, error) {
+	args := m.Called(userId, article)
+	return args.Get(0).(model.ResolvedLayout), args.Error(1)
+}
+
+// Helper function to setup the Echo context with a JWT token
+func setupContext(method, url string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, url, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	token := jwt.New(jwt.SigningMethodHS256)
+	token.Claims.(jwt.MapClaims)["user_id"] = float64(1)
+	c.Set("user", token)
+	return c, rec
+}
@@ -0,0 +1,43 @@
+package layout_assignment_test
+
+import (
+	"fmt"
+	"go-react-app/controller"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetDefaultLayout(t *testing.T) {
+	mockUsecase := new(MockLayoutAssignmentUsecase)
+	assignmentController := controller.NewLayoutAssignmentController(mockUsecase)
+
+	request := model.LayoutAssignmentRequest{LayoutId: 3}
+
+	tests := []struct {
+		name     string
+		response model.LayoutAssignmentResponse
+		err      error
+		want     int
+	}{
+		{"正常系", model.LayoutAssignmentResponse{LayoutId: 3}, nil, http.StatusOK},
+		{"他のユーザーのレイアウトは400を返す", model.LayoutAssignmentResponse{}, fmt.Errorf("%w: layout does not exist", usecase.ErrInvalidLayoutAssignment), http.StatusBadRequest},
+		{"その他のエラーは500を返す", model.LayoutAssignmentResponse{}, fmt.Errorf("database is locked"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.On("SetDefaultLayout", uint(1), request).Return(tt.response, tt.err).Once()
+
+			c, rec := setupContext(http.MethodPut, "/layout-assignments/default", `{"layout_id":3}`)
+
+			if assert.NoError(t, assignmentController.SetDefaultLayout(c)) {
+				assert.Equal(t, tt.want, rec.Code)
+			}
+		})
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
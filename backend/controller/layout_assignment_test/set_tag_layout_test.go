@@ -0,0 +1,46 @@
+package layout_assignment_test
+
+import (
+	"fmt"
+	"go-react-app/controller"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetTagLayout(t *testing.T) {
+	mockUsecase := new(MockLayoutAssignmentUsecase)
+	assignmentController := controller.NewLayoutAssignmentController(mockUsecase)
+
+	request := model.LayoutAssignmentRequest{LayoutId: 3}
+
+	tests := []struct {
+		name     string
+		response model.LayoutAssignmentResponse
+		err      error
+		want     int
+	}{
+		{"正常系", model.LayoutAssignmentResponse{Tag: "入門", LayoutId: 3}, nil, http.StatusOK},
+		{"不正な指定は400を返す", model.LayoutAssignmentResponse{}, fmt.Errorf("%w: layout does not exist", usecase.ErrInvalidLayoutAssignment), http.StatusBadRequest},
+		{"その他のエラーは500を返す", model.LayoutAssignmentResponse{}, fmt.Errorf("database is locked"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// パスのタグはデコードしてから渡す
+			mockUsecase.On("SetTagLayout", uint(1), "入門", request).Return(tt.response, tt.err).Once()
+
+			c, rec := setupContext(http.MethodPut, "/layout-assignments/tags/%E5%85%A5%E9%96%80", `{"layout_id":3}`)
+			c.SetParamNames("tag")
+			c.SetParamValues("%E5%85%A5%E9%96%80")
+
+			if assert.NoError(t, assignmentController.SetTagLayout(c)) {
+				assert.Equal(t, tt.want, rec.Code)
+			}
+		})
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
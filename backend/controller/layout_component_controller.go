@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"go-react-app/model"
 	"go-react-app/usecase"
 	"net/http"
@@ -20,6 +21,9 @@ type ILayoutComponentController interface {
 	AssignToLayout(c echo.Context) error
 	RemoveFromLayout(c echo.Context) error
 	UpdatePosition(c echo.Context) error
+	SetParent(c echo.Context) error
+	GetComponentData(c echo.Context) error
+	GetComponentTypes(c echo.Context) error
 }
 
 type layoutComponentController struct {
@@ -97,6 +101,9 @@ func (lcc *layoutComponentController) CreateLayoutComponent(c echo.Context) erro
 	
 	request.UserId = userId
 	componentRes, err := lcc.lcu.CreateLayoutComponent(request)
+	if errors.Is(err, usecase.ErrInvalidLayoutComponent) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -131,6 +138,9 @@ func (lcc *layoutComponentController) UpdateLayoutComponent(c echo.Context) erro
 	
 	request.UserId = userId
 	componentRes, err := lcc.lcu.UpdateLayoutComponent(request, userId, uint(componentId))
+	if errors.Is(err, usecase.ErrInvalidLayoutComponent) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -139,11 +149,12 @@ func (lcc *layoutComponentController) UpdateLayoutComponent(c echo.Context) erro
 
 // DeleteLayoutComponent レイアウトコンポーネントを削除
 // @Summary レイアウトコンポーネントを削除
-// @Description 指定されたIDのレイアウトコンポーネントを削除する
+// @Description 指定されたIDのレイアウトコンポーネントを削除する。子コンポーネントは、childrenがdeleteの場合は一緒に削除し、省略またはreparentの場合は削除するコンポーネントの親に付け替える
 // @Tags layout-components
 // @Accept json
 // @Produce json
 // @Param componentId path int true "コンポーネントID"
+// @Param children query string false "子コンポーネントの扱い（reparent, delete）"
 // @Success 204 "No Content"
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -157,7 +168,10 @@ func (lcc *layoutComponentController) DeleteLayoutComponent(c echo.Context) erro
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なコンポーネントIDです"})
 	}
 	
-	err = lcc.lcu.DeleteLayoutComponent(userId, uint(componentId))
+	err = lcc.lcu.DeleteLayoutComponent(userId, uint(componentId), c.QueryParam("children"))
+	if errors.Is(err, usecase.ErrInvalidLayoutComponent) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -175,6 +189,7 @@ func (lcc *layoutComponentController) DeleteLayoutComponent(c echo.Context) erro
 // @Param position body model.AssignLayoutRequest true "割り当て情報"
 // @Success 200 "OK"
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string "重なりを拒否するレイアウトで他のコンポーネントと重なる場合"
 // @Failure 500 {object} map[string]string
 // @Router /layout-components/{componentId}/assign/{layoutId} [post]
 func (lcc *layoutComponentController) AssignToLayout(c echo.Context) error {
@@ -191,6 +206,12 @@ func (lcc *layoutComponentController) AssignToLayout(c echo.Context) error {
 	}
 	
 	err = lcc.lcu.AssignToLayout(userId, uint(componentId), request)
+	if errors.Is(err, usecase.ErrInvalidPosition) || errors.Is(err, usecase.ErrInvalidParent) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if errors.Is(err, usecase.ErrPositionConflict) {
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -227,7 +248,7 @@ func (lcc *layoutComponentController) RemoveFromLayout(c echo.Context) error {
 
 // UpdatePosition レイアウトコンポーネントの位置を更新
 // @Summary コンポーネントの位置を更新
-// @Description 指定されたコンポーネントの位置情報を更新する
+// @Description 指定されたコンポーネントの位置情報を更新する。レイアウトにグリッドが設定されている場合は位置をグリッドに合わせ、重なったコンポーネントはレイアウトの設定に従って下に押し出すか拒否する
 // @Tags layout-components
 // @Accept json
 // @Produce json
@@ -235,6 +256,7 @@ func (lcc *layoutComponentController) RemoveFromLayout(c echo.Context) error {
 // @Param position body model.PositionRequest true "位置情報"
 // @Success 200 "OK"
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string "重なりを拒否するレイアウトで他のコンポーネントと重なる場合"
 // @Failure 500 {object} map[string]string
 // @Router /layout-components/{componentId}/position [put]
 func (lcc *layoutComponentController) UpdatePosition(c echo.Context) error {
@@ -251,9 +273,94 @@ func (lcc *layoutComponentController) UpdatePosition(c echo.Context) error {
 	}
 	
 	err = lcc.lcu.UpdatePosition(userId, uint(componentId), position)
+	if errors.Is(err, usecase.ErrInvalidPosition) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if errors.Is(err, usecase.ErrPositionConflict) {
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 	
 	return c.NoContent(http.StatusOK)
-}
\ No newline at end of file
+}
+
+// SetParent レイアウトコンポーネントの親を変更
+// @Summary コンポーネントの親を変更
+// @Description 指定されたコンポーネントを、同じレイアウトの子を持てるコンポーネント（ヘッダー・サイドバー・フッター）の子にする。parent_idがnullの場合はレイアウトの直下に移動する。レイアウト上の位置は保ったまま、新しい親を基準にした位置に変換する
+// @Tags layout-components
+// @Accept json
+// @Produce json
+// @Param componentId path int true "コンポーネントID"
+// @Param parent body model.ParentRequest true "親コンポーネント"
+// @Success 200 "OK"
+// @Failure 400 {object} map[string]string "別のレイアウトのコンポーネント・自身の子孫・子を持てない種類を指定した場合、または入れ子が深すぎる場合"
+// @Failure 409 {object} map[string]string "重なりを拒否するレイアウトで新しい親の他の子と重なる場合"
+// @Failure 500 {object} map[string]string
+// @Router /layout-components/{componentId}/parent [put]
+func (lcc *layoutComponentController) SetParent(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	componentId, err := strconv.ParseUint(c.Param("componentId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なコンポーネントIDです"})
+	}
+
+	var request model.ParentRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	err = lcc.lcu.SetParent(userId, uint(componentId), request)
+	if errors.Is(err, usecase.ErrInvalidParent) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if errors.Is(err, usecase.ErrPositionConflict) {
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// GetComponentData コンポーネントに表示するデータを取得
+// @Summary コンポーネントに表示するデータを取得
+// @Description 最新記事・フィード・Qiitaのトレンド・本棚など、データを取得する種類のコンポーネントに表示する項目を、propertiesの設定に従って取得する。取得結果はコンポーネントと設定ごとに一定時間キャッシュする
+// @Tags layout-components
+// @Produce json
+// @Param componentId path int true "コンポーネントID"
+// @Success 200 {object} model.ComponentDataResponse
+// @Failure 400 {object} map[string]string "データを取得しない種類のコンポーネントの場合"
+// @Failure 500 {object} map[string]string
+// @Router /layout-components/{componentId}/data [get]
+func (lcc *layoutComponentController) GetComponentData(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	componentId, err := strconv.ParseUint(c.Param("componentId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なコンポーネントIDです"})
+	}
+
+	dataRes, err := lcc.lcu.GetComponentData(userId, uint(componentId))
+	if errors.Is(err, usecase.ErrNoDataSource) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, dataRes)
+}
+
+// GetComponentTypes 利用できるコンポーネントの種類を取得
+// @Summary コンポーネントの種類の一覧を取得
+// @Description 作成できるコンポーネントの種類と、既定のサイズ・サイズの上下限・プロパティのJSON Schemaを取得する
+// @Tags layout-components
+// @Produce json
+// @Success 200 {array} model.ComponentType
+// @Router /layout-components/types [get]
+func (lcc *layoutComponentController) GetComponentTypes(c echo.Context) error {
+	return c.JSON(http.StatusOK, lcc.lcu.GetComponentTypes())
+}
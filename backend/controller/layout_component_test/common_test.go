@@ -1,6 +1,7 @@
 package layout_component_test
 
 import (
+	"errors"
 	"go-react-app/model"
 	"go-react-app/usecase"
 	"net/http"
@@ -35,8 +36,8 @@ func (m *MockLayoutComponentUsecase) UpdateLayoutComponent(request model.LayoutC
 	return args.Get(0).(model.LayoutComponentResponse), args.Error(1)
 }
 
-func (m *MockLayoutComponentUsecase) DeleteLayoutComponent(userId uint, componentId uint) error {
-	args := m.Called(userId, componentId)
+func (m *MockLayoutComponentUsecase) DeleteLayoutComponent(userId uint, componentId uint, children string) error {
+	args := m.Called(userId, componentId, children)
 	return args.Error(0)
 }
 
@@ -55,6 +56,21 @@ func (m *MockLayoutComponentUsecase) UpdatePosition(userId uint, componentId uin
 	return args.Error(0)
 }
 
+func (m *MockLayoutComponentUsecase) SetParent(userId uint, componentId uint, request model.ParentRequest) error {
+	args := m.Called(userId, componentId, request)
+	return args.Error(0)
+}
+
+func (m *MockLayoutComponentUsecase) GetComponentData(userId uint, componentId uint) (model.ComponentDataResponse, error) {
+	args := m.Called(userId, componentId)
+	return args.Get(0).(model.ComponentDataResponse), args.Error(1)
+}
+
+func (m *MockLayoutComponentUsecase) GetComponentTypes() []model.ComponentType {
+	args := m.Called()
+	return args.Get(0).([]model.ComponentType)
+}
+
 // Mock the getUserIdFromToken function for testing
 type mockLayoutComponentController struct {
 	lcu usecase.ILayoutComponentUsecase
@@ -100,6 +116,9 @@ func (lcc *mockLayoutComponentController) CreateLayoutComponent(c echo.Context)
 	
 	request.UserId = userId
 	componentRes, err := lcc.lcu.CreateLayoutComponent(request)
+	if errors.Is(err, usecase.ErrInvalidLayoutComponent) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -122,6 +141,9 @@ func (lcc *mockLayoutComponentController) UpdateLayoutComponent(c echo.Context)
 	
 	request.UserId = userId
 	componentRes, err := lcc.lcu.UpdateLayoutComponent(request, userId, uint(componentId))
+	if errors.Is(err, usecase.ErrInvalidLayoutComponent) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -137,7 +159,10 @@ func (lcc *mockLayoutComponentController) DeleteLayoutComponent(c echo.Context)
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なコンポーネントIDです"})
 	}
 	
-	err = lcc.lcu.DeleteLayoutComponent(userId, uint(componentId))
+	err = lcc.lcu.DeleteLayoutComponent(userId, uint(componentId), c.QueryParam("children"))
+	if errors.Is(err, usecase.ErrInvalidLayoutComponent) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -159,6 +184,12 @@ func (lcc *mockLayoutComponentController) AssignToLayout(c echo.Context) error {
 	}
 	
 	err = lcc.lcu.AssignToLayout(userId, uint(componentId), request)
+	if errors.Is(err, usecase.ErrInvalidPosition) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if errors.Is(err, usecase.ErrPositionConflict) {
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -196,8 +227,62 @@ func (lcc *mockLayoutComponentController) UpdatePosition(c echo.Context) error {
 	}
 	
 	err = lcc.lcu.UpdatePosition(userId, uint(componentId), position)
+	if errors.Is(err, usecase.ErrInvalidPosition) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if errors.Is(err, usecase.ErrPositionConflict) {
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+func (lcc *mockLayoutComponentController) GetComponentTypes(c echo.Context) error {
+	return c.JSON(http.StatusOK, lcc.lcu.GetComponentTypes())
+}
+
+func (lcc *mockLayoutComponentController) SetParent(c echo.Context) error {
+	userId := uint(1) // Hardcoded for testing
+
+	componentId, err := strconv.ParseUint(c.Param("componentId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なコンポーネントIDです"})
+	}
+
+	var request model.ParentRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	err = lcc.lcu.SetParent(userId, uint(componentId), request)
+	if errors.Is(err, usecase.ErrInvalidParent) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if errors.Is(err, usecase.ErrPositionConflict) {
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 	return c.NoContent(http.StatusOK)
 }
+
+func (lcc *mockLayoutComponentController) GetComponentData(c echo.Context) error {
+	userId := uint(1) // Hardcoded for testing
+
+	componentId, err := strconv.ParseUint(c.Param("componentId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なコンポーネントIDです"})
+	}
+
+	dataRes, err := lcc.lcu.GetComponentData(userId, uint(componentId))
+	if errors.Is(err, usecase.ErrNoDataSource) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, dataRes)
+}
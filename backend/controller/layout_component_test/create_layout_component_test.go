@@ -2,7 +2,9 @@ package layout_component_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"go-react-app/model"
+	"go-react-app/usecase"
 	"net/http"
 	"testing"
 	"time"
@@ -55,3 +57,30 @@ func TestCreateLayoutComponent(t *testing.T) {
 	
 	mockUsecase.AssertExpectations(t)
 }
+
+func TestCreateLayoutComponent_InvalidComponent(t *testing.T) {
+	// Setup
+	mockUsecase := new(MockLayoutComponentUsecase)
+	controller := newMockLayoutComponentController(mockUsecase)
+
+	componentRequest := model.LayoutComponentRequest{
+		Name:   "Component",
+		Type:   "unknown",
+		UserId: 1,
+	}
+
+	// Expectations
+	mockUsecase.On("CreateLayoutComponent", componentRequest).
+		Return(model.LayoutComponentResponse{}, fmt.Errorf("%w: type: 対応していないタイプです: unknown", usecase.ErrInvalidLayoutComponent))
+
+	// Test
+	body, _ := json.Marshal(componentRequest)
+	c, rec := setupContext(http.MethodPost, "/layout-components", string(body))
+
+	// Assertions
+	if assert.NoError(t, controller.CreateLayoutComponent(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
@@ -14,7 +14,7 @@ func TestDeleteLayoutComponent(t *testing.T) {
 	controller := newMockLayoutComponentController(mockUsecase)
 	
 	// Expectations
-	mockUsecase.On("DeleteLayoutComponent", uint(1), uint(1)).Return(nil)
+	mockUsecase.On("DeleteLayoutComponent", uint(1), uint(1), "").Return(nil)
 	
 	// Test
 	c, rec := setupContext(http.MethodDelete, "/components/1", "")
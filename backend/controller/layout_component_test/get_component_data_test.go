@@ -0,0 +1,56 @@
+package layout_component_test
+
+import (
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetComponentData(t *testing.T) {
+	// Setup
+	mockUsecase := new(MockLayoutComponentUsecase)
+	controller := newMockLayoutComponentController(mockUsecase)
+
+	mockUsecase.On("GetComponentData", uint(1), uint(1)).Return(model.ComponentDataResponse{
+		ComponentId: 1,
+		Source:      model.DataSourceBookshelf,
+		Items:       []model.ComponentDataItem{{ID: "1", Title: "プログラミング言語Go"}},
+	}, nil)
+
+	// Test
+	c, rec := setupContext(http.MethodGet, "/layout-components/1/data", "")
+	c.SetParamNames("componentId")
+	c.SetParamValues("1")
+
+	// Assertions
+	if assert.NoError(t, controller.GetComponentData(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "プログラミング言語Go")
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestGetComponentDataNoDataSource(t *testing.T) {
+	// Setup
+	mockUsecase := new(MockLayoutComponentUsecase)
+	controller := newMockLayoutComponentController(mockUsecase)
+
+	mockUsecase.On("GetComponentData", uint(1), uint(1)).Return(model.ComponentDataResponse{}, fmt.Errorf("%w: text", usecase.ErrNoDataSource))
+
+	// Test
+	c, rec := setupContext(http.MethodGet, "/layout-components/1/data", "")
+	c.SetParamNames("componentId")
+	c.SetParamValues("1")
+
+	// Assertions
+	if assert.NoError(t, controller.GetComponentData(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
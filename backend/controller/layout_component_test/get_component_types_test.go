@@ -0,0 +1,36 @@
+package layout_component_test
+
+import (
+	"encoding/json"
+	"go-react-app/model"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetComponentTypes(t *testing.T) {
+	// Setup
+	mockUsecase := new(MockLayoutComponentUsecase)
+	controller := newMockLayoutComponentController(mockUsecase)
+
+	// Expectations
+	mockUsecase.On("GetComponentTypes").Return(model.ComponentTypes)
+
+	// Test
+	c, rec := setupContext(http.MethodGet, "/layout-components/types", "")
+
+	// Assertions
+	if assert.NoError(t, controller.GetComponentTypes(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response []model.ComponentType
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, len(model.ComponentTypes), len(response))
+		assert.Equal(t, "header", response[0].Type)
+		assert.NotEmpty(t, response[0].Schema)
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
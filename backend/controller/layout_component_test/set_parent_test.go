@@ -0,0 +1,60 @@
+package layout_component_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetParent(t *testing.T) {
+	// Setup
+	mockUsecase := new(MockLayoutComponentUsecase)
+	controller := newMockLayoutComponentController(mockUsecase)
+
+	parentId := uint(2)
+	mockUsecase.On("SetParent", uint(1), uint(1), model.ParentRequest{ParentId: &parentId}).Return(nil)
+
+	// Test
+	c, rec := setupContext(http.MethodPut, "/layout-components/1/parent", `{"parent_id":2}`)
+	c.SetParamNames("componentId")
+	c.SetParamValues("1")
+
+	// Assertions
+	if assert.NoError(t, controller.SetParent(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestSetParentInvalidParent(t *testing.T) {
+	// Setup
+	mockUsecase := new(MockLayoutComponentUsecase)
+	controller := newMockLayoutComponentController(mockUsecase)
+
+	parentId := uint(1)
+	mockUsecase.On("SetParent", uint(1), uint(1), model.ParentRequest{ParentId: &parentId}).
+		Return(fmt.Errorf("%w: 自身または子孫のコンポーネントは親にできません", usecase.ErrInvalidParent))
+
+	// Test
+	c, rec := setupContext(http.MethodPut, "/layout-components/1/parent", `{"parent_id":1}`)
+	c.SetParamNames("componentId")
+	c.SetParamValues("1")
+
+	// Assertions
+	if assert.NoError(t, controller.SetParent(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var response map[string]string
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Contains(t, response["error"], "自身または子孫のコンポーネントは親にできません")
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
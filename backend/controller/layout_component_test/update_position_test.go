@@ -2,7 +2,9 @@ package layout_component_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"go-react-app/model"
+	"go-react-app/usecase"
 	"net/http"
 	"testing"
 
@@ -60,3 +62,51 @@ func TestUpdatePositionInvalidId(t *testing.T) {
 		assert.Equal(t, "無効なコンポーネントIDです", response["error"])
 	}
 }
+
+func TestUpdatePositionConflict(t *testing.T) {
+	// Setup
+	mockUsecase := new(MockLayoutComponentUsecase)
+	controller := newMockLayoutComponentController(mockUsecase)
+
+	positionRequest := model.PositionRequest{X: 0, Y: 0, Width: 4, Height: 2}
+
+	// Expectations
+	mockUsecase.On("UpdatePosition", uint(1), uint(1), positionRequest).
+		Return(fmt.Errorf("%w: ヘッダー", usecase.ErrPositionConflict))
+
+	// Test
+	c, rec := setupContext(http.MethodPut, "/components/1/position", `{"x":0,"y":0,"width":4,"height":2}`)
+	c.SetParamNames("componentId")
+	c.SetParamValues("1")
+
+	// Assertions
+	if assert.NoError(t, controller.UpdatePosition(c)) {
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestUpdatePositionInvalidPosition(t *testing.T) {
+	// Setup
+	mockUsecase := new(MockLayoutComponentUsecase)
+	controller := newMockLayoutComponentController(mockUsecase)
+
+	positionRequest := model.PositionRequest{X: -1, Y: 0}
+
+	// Expectations
+	mockUsecase.On("UpdatePosition", uint(1), uint(1), positionRequest).
+		Return(fmt.Errorf("%w: x: X座標は0以上にしてください.", usecase.ErrInvalidPosition))
+
+	// Test
+	c, rec := setupContext(http.MethodPut, "/components/1/position", `{"x":-1,"y":0}`)
+	c.SetParamNames("componentId")
+	c.SetParamValues("1")
+
+	// Assertions
+	if assert.NoError(t, controller.UpdatePosition(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
 	"go-react-app/model"
 	"go-react-app/usecase"
 	"net/http"
@@ -15,6 +17,11 @@ type ILayoutController interface {
 	CreateLayout(c echo.Context) error
 	UpdateLayout(c echo.Context) error
 	DeleteLayout(c echo.Context) error
+	RenderLayout(c echo.Context) error
+	UpdateArrangement(c echo.Context) error
+	DuplicateLayout(c echo.Context) error
+	ExportLayout(c echo.Context) error
+	ImportLayout(c echo.Context) error
 }
 
 type layoutController struct {
@@ -46,11 +53,12 @@ func (lc *layoutController) GetAllLayouts(c echo.Context) error {
 
 // GetLayoutById 指定されたIDのレイアウトを取得
 // @Summary 特定のレイアウトを取得
-// @Description 指定されたIDのレイアウトを取得する
+// @Description 指定されたIDのレイアウトを取得する。breakpointを指定した場合は、そのブレークポイントでのコンポーネントの位置を返す
 // @Tags layouts
 // @Accept json
 // @Produce json
 // @Param layoutId path int true "レイアウトID"
+// @Param breakpoint query string false "ブレークポイント（desktop, tablet, mobile）"
 // @Success 200 {object} model.LayoutResponse
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -64,7 +72,10 @@ func (lc *layoutController) GetLayoutById(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なレイアウトIDです"})
 	}
 	
-	layoutRes, err := lc.lu.GetLayoutById(userId, uint(layoutId))
+	layoutRes, err := lc.lu.GetLayoutById(userId, uint(layoutId), c.QueryParam("breakpoint"))
+	if errors.Is(err, usecase.ErrInvalidBreakpoint) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -157,4 +168,156 @@ func (lc *layoutController) DeleteLayout(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 	return c.NoContent(http.StatusNoContent)
-}
\ No newline at end of file
+}
+
+// RenderLayout レイアウトをHTMLとして描画
+// @Summary レイアウトのプレビューを取得
+// @Description 指定されたレイアウトをコンポーネントの種類ごとのテンプレートで描画したHTMLを取得する。breakpointを指定した場合は、そのブレークポイントでの配置で描画する
+// @Tags layouts
+// @Produce html
+// @Param layoutId path int true "レイアウトID"
+// @Param breakpoint query string false "ブレークポイント（desktop, tablet, mobile）"
+// @Success 200 {string} string "HTML"
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layouts/{layoutId}/render [get]
+func (lc *layoutController) RenderLayout(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	layoutId, err := strconv.ParseUint(c.Param("layoutId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なレイアウトIDです"})
+	}
+
+	html, err := lc.lu.RenderLayout(userId, uint(layoutId), c.QueryParam("breakpoint"))
+	if errors.Is(err, usecase.ErrInvalidBreakpoint) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	// プレビューはAPIと同じオリジンで表示されるため、スクリプトの実行と外部への送信を禁止する
+	c.Response().Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; img-src * data:")
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	return c.HTMLBlob(http.StatusOK, html)
+}
+
+// UpdateArrangement レイアウトのコンポーネントの位置を一括で更新
+// @Summary レイアウトの配置を一括で更新
+// @Description レイアウトのコンポーネントの位置とサイズを1つのトランザクションで更新する。取得したときのバージョンが現在のバージョンと異なる場合は409を返す
+// @Tags layouts
+// @Accept json
+// @Produce json
+// @Param layoutId path int true "レイアウトID"
+// @Param arrangement body model.ArrangementRequest true "バージョンとコンポーネントの位置"
+// @Success 200 {object} model.LayoutResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string "他の編集で更新されていた場合、または重なりを拒否するレイアウトで重なる場合"
+// @Failure 500 {object} map[string]string
+// @Router /layouts/{layoutId}/arrangement [put]
+func (lc *layoutController) UpdateArrangement(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	layoutId, err := strconv.ParseUint(c.Param("layoutId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なレイアウトIDです"})
+	}
+
+	var request model.ArrangementRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	layoutRes, err := lc.lu.UpdateArrangement(userId, uint(layoutId), request)
+	if errors.Is(err, usecase.ErrInvalidArrangement) || errors.Is(err, usecase.ErrInvalidBreakpoint) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if errors.Is(err, usecase.ErrLayoutVersionConflict) || errors.Is(err, usecase.ErrPositionConflict) {
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, layoutRes)
+}
+
+// DuplicateLayout レイアウトを複製
+// @Summary レイアウトを複製
+// @Description レイアウトとそのすべてのコンポーネントを複製した新しいレイアウトを作成する
+// @Tags layouts
+// @Produce json
+// @Param layoutId path int true "レイアウトID"
+// @Success 201 {object} model.LayoutResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layouts/{layoutId}/duplicate [post]
+func (lc *layoutController) DuplicateLayout(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	layoutId, err := strconv.ParseUint(c.Param("layoutId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なレイアウトIDです"})
+	}
+
+	layoutRes, err := lc.lu.DuplicateLayout(userId, uint(layoutId))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, layoutRes)
+}
+
+// ExportLayout レイアウトをJSONとして書き出す
+// @Summary レイアウトを書き出す
+// @Description レイアウトとそのコンポーネントを、他の環境で読み込めるバージョン付きのJSONとして書き出す
+// @Tags layouts
+// @Produce json
+// @Param layoutId path int true "レイアウトID"
+// @Success 200 {object} model.LayoutDocument
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layouts/{layoutId}/export [get]
+func (lc *layoutController) ExportLayout(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	layoutId, err := strconv.ParseUint(c.Param("layoutId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なレイアウトIDです"})
+	}
+
+	document, err := lc.lu.ExportLayout(userId, uint(layoutId))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	// gitで差分を確認しやすいよう整形して返す
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="layout-%d.json"`, layoutId))
+	return c.JSONPretty(http.StatusOK, document, "  ")
+}
+
+// ImportLayout JSONからレイアウトを読み込む
+// @Summary レイアウトを読み込む
+// @Description 書き出したJSONから新しいレイアウトを作成する。不正なコンポーネントや重なるコンポーネントは読み込まずにconflictsで報告する
+// @Tags layouts
+// @Accept json
+// @Produce json
+// @Param document body model.LayoutDocument true "書き出したレイアウトのJSON"
+// @Success 201 {object} model.LayoutImportResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layouts/import [post]
+func (lc *layoutController) ImportLayout(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	var document model.LayoutDocument
+	if err := c.Bind(&document); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	importRes, err := lc.lu.ImportLayout(userId, document)
+	if errors.Is(err, usecase.ErrInvalidLayoutDocument) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, importRes)
+}
@@ -0,0 +1,242 @@
+package controller
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ILayoutSnapshotController interface {
+	GetSnapshots(c echo.Context) error
+	GetSnapshotById(c echo.Context) error
+	CreateSnapshot(c echo.Context) error
+	DiffSnapshot(c echo.Context) error
+	RollbackSnapshot(c echo.Context) error
+	SetLiveSnapshot(c echo.Context) error
+	ClearLiveSnapshot(c echo.Context) error
+}
+
+type layoutSnapshotController struct {
+	su usecase.ILayoutSnapshotUsecase
+}
+
+func NewLayoutSnapshotController(su usecase.ILayoutSnapshotUsecase) ILayoutSnapshotController {
+	return &layoutSnapshotController{su}
+}
+
+// GetSnapshots レイアウトのスナップショットの一覧を取得
+// @Summary レイアウトのスナップショット一覧を取得
+// @Description 指定されたレイアウトのスナップショットを新しい順に取得する（保存された状態は含まない）
+// @Tags layout-snapshots
+// @Produce json
+// @Param layoutId path int true "レイアウトID"
+// @Success 200 {array} model.LayoutSnapshotResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layouts/{layoutId}/snapshots [get]
+func (sc *layoutSnapshotController) GetSnapshots(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	layoutId, err := strconv.ParseUint(c.Param("layoutId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なレイアウトIDです"})
+	}
+
+	snapshotsRes, err := sc.su.GetSnapshots(userId, uint(layoutId))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, snapshotsRes)
+}
+
+// GetSnapshotById スナップショットを取得
+// @Summary スナップショットを取得
+// @Description 指定されたスナップショットを、保存されたタイトルとコンポーネントの状態とともに取得する
+// @Tags layout-snapshots
+// @Produce json
+// @Param layoutId path int true "レイアウトID"
+// @Param snapshotId path int true "スナップショットID"
+// @Success 200 {object} model.LayoutSnapshotResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layouts/{layoutId}/snapshots/{snapshotId} [get]
+func (sc *layoutSnapshotController) GetSnapshotById(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	layoutId, snapshotId, err := snapshotParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	snapshotRes, err := sc.su.GetSnapshotById(userId, layoutId, snapshotId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, snapshotRes)
+}
+
+// CreateSnapshot レイアウトの現在の状態をスナップショットとして保存
+// @Summary スナップショットを作成
+// @Description レイアウトのタイトル・グリッドの設定・すべてのコンポーネントの現在の状態をスナップショットとして保存する
+// @Tags layout-snapshots
+// @Accept json
+// @Produce json
+// @Param layoutId path int true "レイアウトID"
+// @Param snapshot body model.LayoutSnapshotRequest false "スナップショットのラベル"
+// @Success 201 {object} model.LayoutSnapshotResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layouts/{layoutId}/snapshots [post]
+func (sc *layoutSnapshotController) CreateSnapshot(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	layoutId, err := strconv.ParseUint(c.Param("layoutId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なレイアウトIDです"})
+	}
+
+	var request model.LayoutSnapshotRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	snapshotRes, err := sc.su.CreateSnapshot(userId, uint(layoutId), request)
+	if errors.Is(err, usecase.ErrInvalidSnapshot) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, snapshotRes)
+}
+
+// DiffSnapshot スナップショットとの差分を取得
+// @Summary スナップショットの差分を取得
+// @Description スナップショットから、別のスナップショット（toを省略した場合は現在のレイアウト）への変更を取得する
+// @Tags layout-snapshots
+// @Produce json
+// @Param layoutId path int true "レイアウトID"
+// @Param snapshotId path int true "スナップショットID"
+// @Param to query int false "比較先のスナップショットID"
+// @Success 200 {object} model.LayoutDiffResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layouts/{layoutId}/snapshots/{snapshotId}/diff [get]
+func (sc *layoutSnapshotController) DiffSnapshot(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	layoutId, snapshotId, err := snapshotParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	var toSnapshotId *uint
+	if to := c.QueryParam("to"); to != "" {
+		id, err := strconv.ParseUint(to, 10, 32)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効な比較先のスナップショットIDです"})
+		}
+		toId := uint(id)
+		toSnapshotId = &toId
+	}
+
+	diffRes, err := sc.su.DiffSnapshot(userId, layoutId, snapshotId, toSnapshotId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, diffRes)
+}
+
+// RollbackSnapshot レイアウトをスナップショットの状態に戻す
+// @Summary スナップショットにロールバック
+// @Description レイアウトのタイトル・グリッドの設定・コンポーネントをスナップショットの状態に戻す。戻す前の状態は自動でスナップショットとして保存される
+// @Tags layout-snapshots
+// @Produce json
+// @Param layoutId path int true "レイアウトID"
+// @Param snapshotId path int true "スナップショットID"
+// @Success 200 {object} model.LayoutResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layouts/{layoutId}/snapshots/{snapshotId}/rollback [post]
+func (sc *layoutSnapshotController) RollbackSnapshot(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	layoutId, snapshotId, err := snapshotParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	layoutRes, err := sc.su.RollbackSnapshot(userId, layoutId, snapshotId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, layoutRes)
+}
+
+// SetLiveSnapshot スナップショットを公開中のバージョンにする
+// @Summary スナップショットを公開
+// @Description 指定されたスナップショットを公開中のバージョンにする。公開中は、レイアウトを編集しても公開されるページは変わらない
+// @Tags layout-snapshots
+// @Produce json
+// @Param layoutId path int true "レイアウトID"
+// @Param snapshotId path int true "スナップショットID"
+// @Success 200 {object} model.LayoutResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layouts/{layoutId}/snapshots/{snapshotId}/live [put]
+func (sc *layoutSnapshotController) SetLiveSnapshot(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	layoutId, snapshotId, err := snapshotParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	layoutRes, err := sc.su.SetLiveSnapshot(userId, layoutId, snapshotId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, layoutRes)
+}
+
+// ClearLiveSnapshot 公開中のスナップショットを解除
+// @Summary スナップショットの公開を解除
+// @Description 公開中のスナップショットを解除し、編集中の状態を公開する
+// @Tags layout-snapshots
+// @Produce json
+// @Param layoutId path int true "レイアウトID"
+// @Success 200 {object} model.LayoutResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layouts/{layoutId}/snapshots/live [delete]
+func (sc *layoutSnapshotController) ClearLiveSnapshot(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	layoutId, err := strconv.ParseUint(c.Param("layoutId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なレイアウトIDです"})
+	}
+
+	layoutRes, err := sc.su.ClearLiveSnapshot(userId, uint(layoutId))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, layoutRes)
+}
+
+// snapshotParams はパスからレイアウトIDとスナップショットIDを取得します
+func snapshotParams(c echo.Context) (uint, uint, error) {
+	layoutId, err := strconv.ParseUint(c.Param("layoutId"), 10, 32)
+	if err != nil {
+		return 0, 0, errors.New("無効なレイアウトIDです")
+	}
+	snapshotId, err := strconv.ParseUint(c.Param("snapshotId"), 10, 32)
+	if err != nil {
+		return 0, 0, errors.New("無効なスナップショットIDです")
+	}
+	return uint(layoutId), uint(snapshotId), nil
+}
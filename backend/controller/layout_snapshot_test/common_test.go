@@ -0,0 +1,65 @@
+package layout_snapshot_test
+
+import (
+	"go-react-app/model"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock for layout snapshot usecase
+type MockLayoutSnapshotUsecase struct {
+	mock.Mock
+}
+
+func (m *MockLayoutSnapshotUsecase) GetSnapshots(userId uint, layoutId uint) ([]model.LayoutSnapshotResponse, error) {
+	args := m.Called(userId, layoutId)
+	return args.Get(0).([]model.LayoutSnapshotResponse), args.Error(1)
+}
+
+func (m *MockLayoutSnapshotUsecase) GetSnapshotById(userId uint, layoutId uint, snapshotId uint) (model.LayoutSnapshotResponse, error) {
+	args := m.Called(userId, layoutId, snapshotId)
+	return args.Get(0).(model.LayoutSnapshotResponse), args.Error(1)
+}
+
+func (m *MockLayoutSnapshotUsecase) CreateSnapshot(userId uint, layoutId uint, request model.LayoutSnapshotRequest) (model.LayoutSnapshotResponse, error) {
+	args := m.Called(userId, layoutId, request)
+	return args.Get(0).(model.LayoutSnapshotResponse), args.Error(1)
+}
+
+func (m *MockLayoutSnapshotUsecase) DiffSnapshot(userId uint, layoutId uint, snapshotId uint, toSnapshotId *uint) (model.LayoutDiffResponse, error) {
+	args := m.Called(userId, layoutId, snapshotId, toSnapshotId)
+	return args.Get(0).(model.LayoutDiffResponse), args.Error(1)
+}
+
+func (m *MockLayoutSnapshotUsecase) RollbackSnapshot(userId uint, layoutId uint, snapshotId uint) (model.LayoutResponse, error) {
+	args := m.Called(userId, layoutId, snapshotId)
+	return args.Get(0).(model.LayoutResponse), args.Error(1)
+}
+
+func (m *MockLayoutSnapshotUsecase) SetLiveSnapshot(userId uint, layoutId uint, snapshotId uint) (model.LayoutResponse, error) {
+	args := m.Called(userId, layoutId, snapshotId)
+	return args.Get(0).(model.LayoutResponse), args.Error(1)
+}
+
+func (m *MockLayoutSnapshotUsecase) ClearLiveSnapshot(userId uint, layoutId uint) (model.LayoutResponse, error) {
+	args := m.Called(userId, layoutId)
+	return args.Get(0).(model.LayoutResponse), args.Error(1)
+}
+
+// Helper function to setup the Echo context with a JWT token
+func setupContext(method, url string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, url, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	token := jwt.New(jwt.SigningMethodHS256)
+	token.Claims.(jwt.MapClaims)["user_id"] = float64(1)
+	c.Set("user", token)
+	return c, rec
+}
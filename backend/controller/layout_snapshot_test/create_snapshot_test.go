@@ -0,0 +1,53 @@
+package layout_snapshot_test
+
+import (
+	"fmt"
+	"go-react-app/controller"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSnapshot(t *testing.T) {
+	mockUsecase := new(MockLayoutSnapshotUsecase)
+	snapshotController := controller.NewLayoutSnapshotController(mockUsecase)
+
+	tests := []struct {
+		name     string
+		response model.LayoutSnapshotResponse
+		err      error
+		want     int
+	}{
+		{"正常系", model.LayoutSnapshotResponse{ID: 1, LayoutId: 1, Label: "初版"}, nil, http.StatusCreated},
+		{"不正なリクエストは400を返す", model.LayoutSnapshotResponse{}, fmt.Errorf("%w: label: the length must be no more than 100.", usecase.ErrInvalidSnapshot), http.StatusBadRequest},
+		{"その他のエラーは500を返す", model.LayoutSnapshotResponse{}, fmt.Errorf("layout does not exist"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.On("CreateSnapshot", uint(1), uint(1), model.LayoutSnapshotRequest{Label: "初版"}).Return(tt.response, tt.err).Once()
+
+			c, rec := setupContext(http.MethodPost, "/layouts/1/snapshots", `{"label":"初版"}`)
+			c.SetParamNames("layoutId")
+			c.SetParamValues("1")
+
+			if assert.NoError(t, snapshotController.CreateSnapshot(c)) {
+				assert.Equal(t, tt.want, rec.Code)
+			}
+		})
+	}
+
+	t.Run("無効なレイアウトIDは400を返す", func(t *testing.T) {
+		c, rec := setupContext(http.MethodPost, "/layouts/abc/snapshots", `{}`)
+		c.SetParamNames("layoutId")
+		c.SetParamValues("abc")
+
+		if assert.NoError(t, snapshotController.CreateSnapshot(c)) {
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	mockUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,66 @@
+package layout_snapshot_test
+
+import (
+	"go-react-app/controller"
+	"go-react-app/model"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSnapshot(t *testing.T) {
+	mockUsecase := new(MockLayoutSnapshotUsecase)
+	snapshotController := controller.NewLayoutSnapshotController(mockUsecase)
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("比較先を省略すると現在のレイアウトと比較する", func(t *testing.T) {
+			mockUsecase.On("DiffSnapshot", uint(1), uint(1), uint(2), (*uint)(nil)).Return(model.LayoutDiffResponse{FromSnapshotId: 2}, nil).Once()
+
+			c, rec := setupContext(http.MethodGet, "/layouts/1/snapshots/2/diff", "")
+			c.SetParamNames("layoutId", "snapshotId")
+			c.SetParamValues("1", "2")
+
+			if assert.NoError(t, snapshotController.DiffSnapshot(c)) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+			}
+		})
+
+		t.Run("比較先のスナップショットを指定できる", func(t *testing.T) {
+			to := uint(3)
+			mockUsecase.On("DiffSnapshot", uint(1), uint(1), uint(2), &to).Return(model.LayoutDiffResponse{FromSnapshotId: 2, ToSnapshotId: &to}, nil).Once()
+
+			c, rec := setupContext(http.MethodGet, "/layouts/1/snapshots/2/diff?to=3", "")
+			c.SetParamNames("layoutId", "snapshotId")
+			c.SetParamValues("1", "2")
+
+			if assert.NoError(t, snapshotController.DiffSnapshot(c)) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("無効な比較先は400を返す", func(t *testing.T) {
+			c, rec := setupContext(http.MethodGet, "/layouts/1/snapshots/2/diff?to=abc", "")
+			c.SetParamNames("layoutId", "snapshotId")
+			c.SetParamValues("1", "2")
+
+			if assert.NoError(t, snapshotController.DiffSnapshot(c)) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			}
+		})
+
+		t.Run("無効なスナップショットIDは400を返す", func(t *testing.T) {
+			c, rec := setupContext(http.MethodGet, "/layouts/1/snapshots/abc/diff", "")
+			c.SetParamNames("layoutId", "snapshotId")
+			c.SetParamValues("1", "abc")
+
+			if assert.NoError(t, snapshotController.DiffSnapshot(c)) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			}
+		})
+	})
+
+	mockUsecase.AssertExpectations(t)
+}
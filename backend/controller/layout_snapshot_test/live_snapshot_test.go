@@ -0,0 +1,51 @@
+package layout_snapshot_test
+
+import (
+	"go-react-app/controller"
+	"go-react-app/model"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetLiveSnapshot(t *testing.T) {
+	mockUsecase := new(MockLayoutSnapshotUsecase)
+	snapshotController := controller.NewLayoutSnapshotController(mockUsecase)
+
+	t.Run("正常系", func(t *testing.T) {
+		snapshotId := uint(2)
+		mockUsecase.On("SetLiveSnapshot", uint(1), uint(1), uint(2)).Return(model.LayoutResponse{ID: 1, LiveSnapshotId: &snapshotId}, nil).Once()
+
+		c, rec := setupContext(http.MethodPut, "/layouts/1/snapshots/2/live", "")
+		c.SetParamNames("layoutId", "snapshotId")
+		c.SetParamValues("1", "2")
+
+		if assert.NoError(t, snapshotController.SetLiveSnapshot(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"live_snapshot_id":2`)
+		}
+	})
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestClearLiveSnapshot(t *testing.T) {
+	mockUsecase := new(MockLayoutSnapshotUsecase)
+	snapshotController := controller.NewLayoutSnapshotController(mockUsecase)
+
+	t.Run("正常系", func(t *testing.T) {
+		mockUsecase.On("ClearLiveSnapshot", uint(1), uint(1)).Return(model.LayoutResponse{ID: 1}, nil).Once()
+
+		c, rec := setupContext(http.MethodDelete, "/layouts/1/snapshots/live", "")
+		c.SetParamNames("layoutId")
+		c.SetParamValues("1")
+
+		if assert.NoError(t, snapshotController.ClearLiveSnapshot(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.NotContains(t, rec.Body.String(), "live_snapshot_id")
+		}
+	})
+
+	mockUsecase.AssertExpectations(t)
+}
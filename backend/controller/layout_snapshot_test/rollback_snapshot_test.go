@@ -0,0 +1,41 @@
+package layout_snapshot_test
+
+import (
+	"fmt"
+	"go-react-app/controller"
+	"go-react-app/model"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackSnapshot(t *testing.T) {
+	mockUsecase := new(MockLayoutSnapshotUsecase)
+	snapshotController := controller.NewLayoutSnapshotController(mockUsecase)
+
+	tests := []struct {
+		name     string
+		response model.LayoutResponse
+		err      error
+		want     int
+	}{
+		{"正常系", model.LayoutResponse{ID: 1, Version: 5}, nil, http.StatusOK},
+		{"異常系", model.LayoutResponse{}, fmt.Errorf("record not found"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.On("RollbackSnapshot", uint(1), uint(1), uint(2)).Return(tt.response, tt.err).Once()
+
+			c, rec := setupContext(http.MethodPost, "/layouts/1/snapshots/2/rollback", "")
+			c.SetParamNames("layoutId", "snapshotId")
+			c.SetParamValues("1", "2")
+
+			if assert.NoError(t, snapshotController.RollbackSnapshot(c)) {
+				assert.Equal(t, tt.want, rec.Code)
+			}
+		})
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ILayoutTemplateController interface {
+	GetAllTemplates(c echo.Context) error
+	GetTemplateById(c echo.Context) error
+	CreateTemplate(c echo.Context) error
+	DeleteTemplate(c echo.Context) error
+	InstantiateTemplate(c echo.Context) error
+}
+
+type layoutTemplateController struct {
+	tu usecase.ILayoutTemplateUsecase
+}
+
+func NewLayoutTemplateController(tu usecase.ILayoutTemplateUsecase) ILayoutTemplateController {
+	return &layoutTemplateController{tu}
+}
+
+// GetAllTemplates テンプレートのギャラリーを取得
+// @Summary テンプレートの一覧を取得
+// @Description すべてのユーザーが公開したレイアウトのテンプレートを新しい順に取得する（構成は含まない）
+// @Tags layout-templates
+// @Produce json
+// @Success 200 {array} model.LayoutTemplateResponse
+// @Failure 500 {object} map[string]string
+// @Router /layout-templates [get]
+func (tc *layoutTemplateController) GetAllTemplates(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	templatesRes, err := tc.tu.GetAllTemplates(userId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, templatesRes)
+}
+
+// GetTemplateById テンプレートを取得
+// @Summary テンプレートを取得
+// @Description 指定されたテンプレートを、コンポーネントの構成とともに取得する
+// @Tags layout-templates
+// @Produce json
+// @Param templateId path int true "テンプレートID"
+// @Success 200 {object} model.LayoutTemplateResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layout-templates/{templateId} [get]
+func (tc *layoutTemplateController) GetTemplateById(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	templateId, err := strconv.ParseUint(c.Param("templateId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なテンプレートIDです"})
+	}
+
+	templateRes, err := tc.tu.GetTemplateById(userId, uint(templateId))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, templateRes)
+}
+
+// CreateTemplate レイアウトをテンプレートとして公開
+// @Summary テンプレートを公開
+// @Description ログインユーザーのレイアウトの構成をテンプレートとして公開する。コンポーネントの本文は公開されず、空のプレースホルダーになる
+// @Tags layout-templates
+// @Accept json
+// @Produce json
+// @Param template body model.LayoutTemplateRequest true "公開するレイアウトとテンプレートの情報"
+// @Success 201 {object} model.LayoutTemplateResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layout-templates [post]
+func (tc *layoutTemplateController) CreateTemplate(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	var request model.LayoutTemplateRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	request.UserId = userId
+
+	templateRes, err := tc.tu.CreateTemplate(request)
+	if errors.Is(err, usecase.ErrInvalidLayoutTemplate) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, templateRes)
+}
+
+// DeleteTemplate テンプレートを削除
+// @Summary テンプレートを削除
+// @Description ログインユーザーが公開したテンプレートを削除する。作成済みのレイアウトには影響しない
+// @Tags layout-templates
+// @Param templateId path int true "テンプレートID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layout-templates/{templateId} [delete]
+func (tc *layoutTemplateController) DeleteTemplate(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	templateId, err := strconv.ParseUint(c.Param("templateId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なテンプレートIDです"})
+	}
+
+	if err := tc.tu.DeleteTemplate(userId, uint(templateId)); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// InstantiateTemplate テンプレートからレイアウトを作成
+// @Summary テンプレートからレイアウトを作成
+// @Description テンプレートの構成からログインユーザーの新しいレイアウトを作成する。コンポーネントの本文は空のプレースホルダーで作成される
+// @Tags layout-templates
+// @Accept json
+// @Produce json
+// @Param templateId path int true "テンプレートID"
+// @Param request body model.InstantiateTemplateRequest false "作成するレイアウトのタイトル"
+// @Success 201 {object} model.LayoutResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /layout-templates/{templateId}/instantiate [post]
+func (tc *layoutTemplateController) InstantiateTemplate(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	templateId, err := strconv.ParseUint(c.Param("templateId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なテンプレートIDです"})
+	}
+
+	var request model.InstantiateTemplateRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	layoutRes, err := tc.tu.InstantiateTemplate(userId, uint(templateId), request)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, layoutRes)
+}
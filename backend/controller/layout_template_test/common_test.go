@@ -0,0 +1,55 @@
+package layout_template_test
+
+import (
+	"go-react-app/model"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock for layout template usecase
+type MockLayoutTemplateUsecase struct {
+	mock.Mock
+}
+
+func (m *MockLayoutTemplateUsecase) GetAllTemplates(userId uint) ([]model.LayoutTemplateResponse, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]model.LayoutTemplateResponse), args.Error(1)
+}
+
+func (m *MockLayoutTemplateUsecase) GetTemplateById(userId uint, templateId uint) (model.LayoutTemplateResponse, error) {
+	args := m.Called(userId, templateId)
+	return args.Get(0).(model.LayoutTemplateResponse), args.Error(1)
+}
+
+func (m *MockLayoutTemplateUsecase) CreateTemplate(request model.LayoutTemplateRequest) (model.LayoutTemplateResponse, error) {
+	args := m.Called(request)
+	return args.Get(0).(model.LayoutTemplateResponse), args.Error(1)
+}
+
+func (m *MockLayoutTemplateUsecase) DeleteTemplate(userId uint, templateId uint) error {
+	args := m.Called(userId, templateId)
+	return args.Error(0)
+}
+
+func (m *MockLayoutTemplateUsecase) InstantiateTemplate(userId uint, templateId uint, request model.InstantiateTemplateRequest) (model.LayoutResponse, error) {
+	args := m.Called(userId, templateId, request)
+	return args.Get(0).(model.LayoutResponse), args.Error(1)
+}
+
+// Helper function to setup the Echo context with a JWT token
+func setupContext(method, url string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, url, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	token := jwt.New(jwt.SigningMethodHS256)
+	token.Claims.(jwt.MapClaims)["user_id"] = float64(1)
+	c.Set("user", token)
+	return c, rec
+}
@@ -0,0 +1,44 @@
+package layout_template_test
+
+import (
+	"fmt"
+	"go-react-app/controller"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTemplate(t *testing.T) {
+	mockUsecase := new(MockLayoutTemplateUsecase)
+	templateController := controller.NewLayoutTemplateController(mockUsecase)
+
+	// ユーザーIDはJWTから設定される
+	request := model.LayoutTemplateRequest{LayoutId: 3, Name: "2カラム", UserId: 1}
+
+	tests := []struct {
+		name     string
+		response model.LayoutTemplateResponse
+		err      error
+		want     int
+	}{
+		{"正常系", model.LayoutTemplateResponse{ID: 1, Name: "2カラム", Own: true}, nil, http.StatusCreated},
+		{"不正なリクエストは400を返す", model.LayoutTemplateResponse{}, fmt.Errorf("%w: name: テンプレート名は必須です.", usecase.ErrInvalidLayoutTemplate), http.StatusBadRequest},
+		{"その他のエラーは500を返す", model.LayoutTemplateResponse{}, fmt.Errorf("record not found"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.On("CreateTemplate", request).Return(tt.response, tt.err).Once()
+
+			c, rec := setupContext(http.MethodPost, "/layout-templates", `{"layout_id":3,"name":"2カラム"}`)
+
+			if assert.NoError(t, templateController.CreateTemplate(c)) {
+				assert.Equal(t, tt.want, rec.Code)
+			}
+		})
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,40 @@
+package layout_template_test
+
+import (
+	"go-react-app/controller"
+	"go-react-app/model"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstantiateTemplate(t *testing.T) {
+	mockUsecase := new(MockLayoutTemplateUsecase)
+	templateController := controller.NewLayoutTemplateController(mockUsecase)
+
+	t.Run("正常系", func(t *testing.T) {
+		request := model.InstantiateTemplateRequest{Title: "新しいブログ"}
+		mockUsecase.On("InstantiateTemplate", uint(1), uint(2), request).Return(model.LayoutResponse{ID: 5, Title: "新しいブログ"}, nil).Once()
+
+		c, rec := setupContext(http.MethodPost, "/layout-templates/2/instantiate", `{"title":"新しいブログ"}`)
+		c.SetParamNames("templateId")
+		c.SetParamValues("2")
+
+		if assert.NoError(t, templateController.InstantiateTemplate(c)) {
+			assert.Equal(t, http.StatusCreated, rec.Code)
+		}
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		c, rec := setupContext(http.MethodPost, "/layout-templates/abc/instantiate", `{}`)
+		c.SetParamNames("templateId")
+		c.SetParamValues("abc")
+
+		if assert.NoError(t, templateController.InstantiateTemplate(c)) {
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	mockUsecase.AssertExpectations(t)
+}
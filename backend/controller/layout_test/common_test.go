@@ -20,8 +20,8 @@ func (m *MockLayoutUsecase) GetAllLayouts(userId uint) ([]model.LayoutResponse,
 	return args.Get(0).([]model.LayoutResponse), args.Error(1)
 }
 
-func (m *MockLayoutUsecase) GetLayoutById(userId uint, layoutId uint) (model.LayoutResponse, error) {
-	args := m.Called(userId, layoutId)
+func (m *MockLayoutUsecase) GetLayoutById(userId uint, layoutId uint, breakpoint string) (model.LayoutResponse, error) {
+	args := m.Called(userId, layoutId, breakpoint)
 	return args.Get(0).(model.LayoutResponse), args.Error(1)
 }
 
@@ -40,6 +40,34 @@ func (m *MockLayoutUsecase) DeleteLayout(userId uint, layoutId uint) error {
 	return args.Error(0)
 }
 
+func (m *MockLayoutUsecase) RenderLayout(userId uint, layoutId uint, breakpoint string) ([]byte, error) {
+	args := m.Called(userId, layoutId, breakpoint)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockLayoutUsecase) UpdateArrangement(userId uint, layoutId uint, request model.ArrangementRequest) (model.LayoutResponse, error) {
+	args := m.Called(userId, layoutId, request)
+	return args.Get(0).(model.LayoutResponse), args.Error(1)
+}
+
+func (m *MockLayoutUsecase) DuplicateLayout(userId uint, layoutId uint) (model.LayoutResponse, error) {
+	args := m.Called(userId, layoutId)
+	return args.Get(0).(model.LayoutResponse), args.Error(1)
+}
+
+func (m *MockLayoutUsecase) ExportLayout(userId uint, layoutId uint) (model.LayoutDocument, error) {
+	args := m.Called(userId, layoutId)
+	return args.Get(0).(model.LayoutDocument), args.Error(1)
+}
+
+func (m *MockLayoutUsecase) ImportLayout(userId uint, document model.LayoutDocument) (model.LayoutImportResponse, error) {
+	args := m.Called(userId, document)
+	return args.Get(0).(model.LayoutImportResponse), args.Error(1)
+}
+
 // Mock the getUserIdFromToken function for testing
 type mockLayoutController struct {
 	lu usecase.ILayoutUsecase
@@ -68,7 +96,7 @@ func (lc *mockLayoutController) GetLayoutById(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なレイアウトIDです"})
 	}
 	
-	layoutRes, err := lc.lu.GetLayoutById(userId, uint(layoutId))
+	layoutRes, err := lc.lu.GetLayoutById(userId, uint(layoutId), c.QueryParam("breakpoint"))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -0,0 +1,57 @@
+package layout_test
+
+import (
+	"fmt"
+	"go-react-app/controller"
+	"go-react-app/model"
+	"net/http"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicateLayout(t *testing.T) {
+	// Setup
+	mockUsecase := new(MockLayoutUsecase)
+	layoutController := controller.NewLayoutController(mockUsecase)
+	token := jwt.New(jwt.SigningMethodHS256)
+	token.Claims.(jwt.MapClaims)["user_id"] = float64(1)
+
+	tests := []struct {
+		name     string
+		response model.LayoutResponse
+		err      error
+		want     int
+	}{
+		{"正常系", model.LayoutResponse{ID: 2, Title: "ブログ のコピー", Version: 1}, nil, http.StatusCreated},
+		{"異常系", model.LayoutResponse{}, fmt.Errorf("record not found"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.On("DuplicateLayout", uint(1), uint(1)).Return(tt.response, tt.err).Once()
+
+			c, rec := setupContext(http.MethodPost, "/layouts/1/duplicate", "")
+			c.Set("user", token)
+			c.SetParamNames("layoutId")
+			c.SetParamValues("1")
+
+			if assert.NoError(t, layoutController.DuplicateLayout(c)) {
+				assert.Equal(t, tt.want, rec.Code)
+			}
+		})
+	}
+
+	t.Run("無効なレイアウトIDは400を返す", func(t *testing.T) {
+		c, rec := setupContext(http.MethodPost, "/layouts/abc/duplicate", "")
+		c.Set("user", token)
+		c.SetParamNames("layoutId")
+		c.SetParamValues("abc")
+
+		if assert.NoError(t, layoutController.DuplicateLayout(c)) {
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	mockUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,73 @@
+package layout_test
+
+import (
+	"fmt"
+	"go-react-app/controller"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportLayout(t *testing.T) {
+	// Setup
+	mockUsecase := new(MockLayoutUsecase)
+	layoutController := controller.NewLayoutController(mockUsecase)
+	token := jwt.New(jwt.SigningMethodHS256)
+	token.Claims.(jwt.MapClaims)["user_id"] = float64(1)
+
+	t.Run("正常系", func(t *testing.T) {
+		document := model.LayoutDocument{SchemaVersion: model.LayoutDocumentVersion, Layout: model.SnapshotData{Title: "ブログ"}}
+		mockUsecase.On("ExportLayout", uint(1), uint(3)).Return(document, nil).Once()
+
+		c, rec := setupContext(http.MethodGet, "/layouts/3/export", "")
+		c.Set("user", token)
+		c.SetParamNames("layoutId")
+		c.SetParamValues("3")
+
+		if assert.NoError(t, layoutController.ExportLayout(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, `attachment; filename="layout-3.json"`, rec.Header().Get(echo.HeaderContentDisposition))
+			assert.Contains(t, rec.Body.String(), `"schema_version": 1`)
+		}
+	})
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestImportLayout(t *testing.T) {
+	// Setup
+	mockUsecase := new(MockLayoutUsecase)
+	layoutController := controller.NewLayoutController(mockUsecase)
+	token := jwt.New(jwt.SigningMethodHS256)
+	token.Claims.(jwt.MapClaims)["user_id"] = float64(1)
+
+	tests := []struct {
+		name     string
+		response model.LayoutImportResponse
+		err      error
+		want     int
+	}{
+		{"正常系", model.LayoutImportResponse{Layout: model.LayoutResponse{ID: 4}, IdMap: map[uint]uint{10: 20}}, nil, http.StatusCreated},
+		{"不正なJSONの形式は400を返す", model.LayoutImportResponse{}, fmt.Errorf("%w: schema_version: 対応していないschema_versionです.", usecase.ErrInvalidLayoutDocument), http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.On("ImportLayout", uint(1), mock.AnythingOfType("model.LayoutDocument")).Return(tt.response, tt.err).Once()
+
+			c, rec := setupContext(http.MethodPost, "/layouts/import", `{"schema_version":1,"layout":{"title":"ブログ","components":[{"id":10,"name":"ヘッダー","type":"header"}]}}`)
+			c.Set("user", token)
+
+			if assert.NoError(t, layoutController.ImportLayout(c)) {
+				assert.Equal(t, tt.want, rec.Code)
+			}
+		})
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
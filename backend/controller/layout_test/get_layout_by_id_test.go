@@ -2,11 +2,15 @@ package layout_test
 
 import (
 	"encoding/json"
+	"fmt"
+	"go-react-app/controller"
 	"go-react-app/model"
+	"go-react-app/usecase"
 	"net/http"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,7 +28,7 @@ func TestGetLayoutById(t *testing.T) {
 	}
 	
 	// Expectations
-	mockUsecase.On("GetLayoutById", uint(1), uint(1)).Return(layout, nil)
+	mockUsecase.On("GetLayoutById", uint(1), uint(1), "").Return(layout, nil)
 	
 	// Test
 	c, rec := setupContext(http.MethodGet, "/layouts/1", "")
@@ -65,3 +69,26 @@ func TestGetLayoutByIdInvalidId(t *testing.T) {
 		assert.Equal(t, "無効なレイアウトIDです", response["error"])
 	}
 }
+
+func TestGetLayoutByIdInvalidBreakpoint(t *testing.T) {
+	// Setup
+	mockUsecase := new(MockLayoutUsecase)
+	layoutController := controller.NewLayoutController(mockUsecase)
+	token := jwt.New(jwt.SigningMethodHS256)
+	token.Claims.(jwt.MapClaims)["user_id"] = float64(1)
+
+	mockUsecase.On("GetLayoutById", uint(1), uint(1), "watch").Return(model.LayoutResponse{}, fmt.Errorf("%w: ブレークポイントはdesktop・tablet・mobileのいずれかを指定してください", usecase.ErrInvalidBreakpoint))
+
+	// Test
+	c, rec := setupContext(http.MethodGet, "/layouts/1?breakpoint=watch", "")
+	c.Set("user", token)
+	c.SetParamNames("layoutId")
+	c.SetParamValues("1")
+
+	// Assertions
+	if assert.NoError(t, layoutController.GetLayoutById(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
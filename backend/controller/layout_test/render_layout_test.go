@@ -0,0 +1,59 @@
+package layout_test
+
+import (
+	"errors"
+	"go-react-app/controller"
+	"net/http"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderLayout(t *testing.T) {
+	// Setup
+	mockUsecase := new(MockLayoutUsecase)
+	layoutController := controller.NewLayoutController(mockUsecase)
+	token := jwt.New(jwt.SigningMethodHS256)
+	token.Claims.(jwt.MapClaims)["user_id"] = float64(1)
+
+	t.Run("正常系", func(t *testing.T) {
+		mockUsecase.On("RenderLayout", uint(1), uint(1), "").Return([]byte("<!DOCTYPE html><html></html>"), nil).Once()
+
+		c, rec := setupContext(http.MethodGet, "/layouts/1/render", "")
+		c.Set("user", token)
+		c.SetParamNames("layoutId")
+		c.SetParamValues("1")
+
+		if assert.NoError(t, layoutController.RenderLayout(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+			assert.Contains(t, rec.Header().Get("Content-Security-Policy"), "default-src 'none'")
+			assert.Equal(t, "<!DOCTYPE html><html></html>", rec.Body.String())
+		}
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		mockUsecase.On("RenderLayout", uint(1), uint(2), "").Return(nil, errors.New("layout not found")).Once()
+
+		c, rec := setupContext(http.MethodGet, "/layouts/2/render", "")
+		c.Set("user", token)
+		c.SetParamNames("layoutId")
+		c.SetParamValues("2")
+
+		if assert.NoError(t, layoutController.RenderLayout(c)) {
+			assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		}
+
+		c, rec = setupContext(http.MethodGet, "/layouts/invalid/render", "")
+		c.Set("user", token)
+		c.SetParamNames("layoutId")
+		c.SetParamValues("invalid")
+
+		if assert.NoError(t, layoutController.RenderLayout(c)) {
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	mockUsecase.AssertExpectations(t)
+}
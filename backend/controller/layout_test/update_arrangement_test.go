@@ -0,0 +1,57 @@
+package layout_test
+
+import (
+	"fmt"
+	"go-react-app/controller"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateArrangement(t *testing.T) {
+	// Setup
+	mockUsecase := new(MockLayoutUsecase)
+	layoutController := controller.NewLayoutController(mockUsecase)
+	token := jwt.New(jwt.SigningMethodHS256)
+	token.Claims.(jwt.MapClaims)["user_id"] = float64(1)
+
+	requestJSON := `{"version":3,"components":[{"component_id":5,"x":0,"y":2,"width":4,"height":6}]}`
+	arrangementRequest := model.ArrangementRequest{
+		Version: 3,
+		Components: []model.ComponentPosition{
+			{ComponentId: 5, PositionRequest: model.PositionRequest{X: 0, Y: 2, Width: 4, Height: 6}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		response model.LayoutResponse
+		err      error
+		want     int
+	}{
+		{"正常系", model.LayoutResponse{ID: 1, Version: 4}, nil, http.StatusOK},
+		{"不正な配置は400を返す", model.LayoutResponse{}, fmt.Errorf("%w: version: バージョンは必須です.", usecase.ErrInvalidArrangement), http.StatusBadRequest},
+		{"バージョンの競合は409を返す", model.LayoutResponse{}, fmt.Errorf("%w: current version is 4", usecase.ErrLayoutVersionConflict), http.StatusConflict},
+		{"重なりの拒否は409を返す", model.LayoutResponse{}, fmt.Errorf("%w: 本文, サイドバー", usecase.ErrPositionConflict), http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.On("UpdateArrangement", uint(1), uint(1), arrangementRequest).Return(tt.response, tt.err).Once()
+
+			c, rec := setupContext(http.MethodPut, "/layouts/1/arrangement", requestJSON)
+			c.Set("user", token)
+			c.SetParamNames("layoutId")
+			c.SetParamValues("1")
+
+			if assert.NoError(t, layoutController.UpdateArrangement(c)) {
+				assert.Equal(t, tt.want, rec.Code)
+			}
+		})
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,211 @@
+package controller
+
+import (
+	"errors"
+	"go-react-app/usecase"
+	"go-react-app/utils/storage"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IMediaController interface {
+	GetAllMedia(c echo.Context) error
+	GetMediaById(c echo.Context) error
+	UploadMedia(c echo.Context) error
+	GetReferences(c echo.Context) error
+	DeleteMedia(c echo.Context) error
+	ServeFile(c echo.Context) error
+}
+
+// mediaMultipartOverhead はアップロードのリクエストで、ファイル以外のmultipartの境界やヘッダーに許容するサイズ
+const mediaMultipartOverhead = 64 << 10
+
+type mediaController struct {
+	mu            usecase.IMediaUsecase
+	maxUploadSize int64
+}
+
+// NewMediaController はmaxUploadSizeバイトまでのファイルのアップロードを受け付けるコントローラーを作成します
+func NewMediaController(mu usecase.IMediaUsecase, maxUploadSize int64) IMediaController {
+	return &mediaController{mu, maxUploadSize}
+}
+
+// GetAllMedia ユーザーのメディア一覧を取得
+// @Summary メディア一覧を取得
+// @Description ログインユーザーがアップロードした画像の一覧と容量の使用状況を取得する
+// @Tags media
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.MediaListResponse
+// @Failure 500 {object} map[string]string
+// @Router /media [get]
+func (mc *mediaController) GetAllMedia(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	mediaRes, err := mc.mu.GetAllMedia(userId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, mediaRes)
+}
+
+// GetMediaById 指定されたIDのメディアを取得
+// @Summary 特定のメディアを取得
+// @Description 指定されたIDのメディアを取得する
+// @Tags media
+// @Accept json
+// @Produce json
+// @Param mediaId path int true "メディアID"
+// @Success 200 {object} model.MediaResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /media/{mediaId} [get]
+func (mc *mediaController) GetMediaById(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	mediaId, err := strconv.ParseUint(c.Param("mediaId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なメディアIDです"})
+	}
+
+	mediaRes, err := mc.mu.GetMediaById(userId, uint(mediaId))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, mediaRes)
+}
+
+// UploadMedia 画像をアップロード
+// @Summary 画像をアップロード
+// @Description JPEG・PNG・GIF・WebPの画像をアップロードし、サムネイルを生成する
+// @Tags media
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "画像ファイル"
+// @Success 201 {object} model.MediaResponse
+// @Failure 400 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /media [post]
+func (mc *mediaController) UploadMedia(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	// multipartを解析してファイルを一時保存する前に、リクエストの大きさを制限する
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, mc.maxUploadSize+mediaMultipartOverhead)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": "ファイルサイズが上限を超えています"})
+		}
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "ファイルが指定されていません"})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	defer file.Close()
+
+	mediaRes, err := mc.mu.UploadMedia(userId, fileHeader.Filename, file)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidMedia):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case errors.Is(err, usecase.ErrMediaTooLarge), errors.Is(err, usecase.ErrMediaQuotaExceeded):
+			return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, mediaRes)
+}
+
+// GetReferences メディアの参照元を取得
+// @Summary メディアの参照元を取得
+// @Description 指定されたメディアを使用している記事・レイアウトコンポーネント・書籍・レイアウトのスナップショット・テンプレートを取得する
+// @Tags media
+// @Accept json
+// @Produce json
+// @Param mediaId path int true "メディアID"
+// @Success 200 {array} model.MediaReference
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /media/{mediaId}/references [get]
+func (mc *mediaController) GetReferences(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	mediaId, err := strconv.ParseUint(c.Param("mediaId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なメディアIDです"})
+	}
+
+	references, err := mc.mu.GetReferences(userId, uint(mediaId))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, references)
+}
+
+// DeleteMedia メディアを削除
+// @Summary メディアを削除
+// @Description 指定されたメディアを削除する。記事などから参照されている場合は削除できない
+// @Tags media
+// @Accept json
+// @Produce json
+// @Param mediaId path int true "メディアID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /media/{mediaId} [delete]
+func (mc *mediaController) DeleteMedia(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	mediaId, err := strconv.ParseUint(c.Param("mediaId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効なメディアIDです"})
+	}
+
+	references, err := mc.mu.DeleteMedia(userId, uint(mediaId))
+	if errors.Is(err, usecase.ErrMediaInUse) {
+		return c.JSON(http.StatusConflict, map[string]interface{}{
+			"error":      "メディアは使用中のため削除できません",
+			"references": references,
+		})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ServeFile アップロードされたファイルを配信
+// @Summary アップロードされたファイルを取得
+// @Description アップロードされた画像・サムネイルを配信する（認証不要）
+// @Tags media
+// @Produce octet-stream
+// @Param key path string true "ファイルのキー"
+// @Success 200 {file} binary
+// @Failure 404 {object} map[string]string
+// @Router /uploads/{key} [get]
+func (mc *mediaController) ServeFile(c echo.Context) error {
+	key := c.Param("*")
+
+	file, err := mc.mu.OpenFile(key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "ファイルが見つかりません"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+	defer file.Close()
+
+	// キーはアップロードごとにランダムに生成され内容が変わらないため、長期間キャッシュさせる
+	c.Response().Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	return c.Stream(http.StatusOK, mime.TypeByExtension(filepath.Ext(key)), file)
+}
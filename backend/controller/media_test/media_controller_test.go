@@ -0,0 +1,106 @@
+package media_test
+
+import (
+	"encoding/json"
+	"go-react-app/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMediaController(t *testing.T) {
+	setupMediaControllerTest()
+
+	var uploaded model.MediaResponse
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("画像をアップロードすると201を返す", func(t *testing.T) {
+			c, rec := newContext(mediaTestUser.ID, newUploadRequest(t, "photo.png", createTestPNG(t)))
+			if err := mediaController.UploadMedia(c); err != nil {
+				t.Fatalf("UploadMedia() error = %v", err)
+			}
+			if rec.Code != http.StatusCreated {
+				t.Fatalf("UploadMedia() status code = %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body.String())
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &uploaded); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if uploaded.Width != 100 || uploaded.Height != 50 {
+				t.Errorf("UploadMedia() = %+v", uploaded)
+			}
+		})
+
+		t.Run("アップロードしたファイルを認証なしで取得できる", func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := newPublicContext(rec, uploaded.URL)
+			if err := mediaController.ServeFile(c); err != nil {
+				t.Fatalf("ServeFile() error = %v", err)
+			}
+			if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "image/png" {
+				t.Errorf("ServeFile() status code = %d, content type = %s", rec.Code, rec.Header().Get("Content-Type"))
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("画像以外のファイルは400を返す", func(t *testing.T) {
+			c, rec := newContext(mediaTestUser.ID, newUploadRequest(t, "note.png", []byte("plain text")))
+			mediaController.UploadMedia(c)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("UploadMedia() status code = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+		})
+
+		t.Run("ファイルがない場合は400を返す", func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/media", strings.NewReader(""))
+			c, rec := newContext(mediaTestUser.ID, req)
+			mediaController.UploadMedia(c)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("UploadMedia() status code = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+		})
+
+		t.Run("サイズ上限を超えるファイルは413を返す", func(t *testing.T) {
+			tests := []struct {
+				name string
+				size int
+			}{
+				{"上限をわずかに超える", 1<<20 + 1},
+				// multipartを解析する前にリクエストの読み込みを打ち切る
+				{"上限を大きく超える", 4 << 20},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					c, rec := newContext(mediaTestUser.ID, newUploadRequest(t, "large.png", make([]byte, tt.size)))
+					mediaController.UploadMedia(c)
+					if rec.Code != http.StatusRequestEntityTooLarge {
+						t.Errorf("UploadMedia() status code = %d, want %d", rec.Code, http.StatusRequestEntityTooLarge)
+					}
+				})
+			}
+		})
+
+		t.Run("記事から参照されているメディアの削除は409を返す", func(t *testing.T) {
+			mediaDB.Create(&model.Article{Title: "記事", Content: "![](" + uploaded.URL + ")", UserId: mediaTestUser.ID})
+
+			c, rec := newContextWithMediaId(mediaTestUser.ID, http.MethodDelete, uploaded.ID)
+			mediaController.DeleteMedia(c)
+			if rec.Code != http.StatusConflict {
+				t.Fatalf("DeleteMedia() status code = %d, want %d", rec.Code, http.StatusConflict)
+			}
+			if !strings.Contains(rec.Body.String(), `"references"`) {
+				t.Errorf("DeleteMedia() body = %s, want references", rec.Body.String())
+			}
+		})
+
+		t.Run("存在しないファイルは404を返す", func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := newPublicContext(rec, "/uploads/1/missing.png")
+			mediaController.ServeFile(c)
+			if rec.Code != http.StatusNotFound {
+				t.Errorf("ServeFile() status code = %d, want %d", rec.Code, http.StatusNotFound)
+			}
+		})
+	})
+}
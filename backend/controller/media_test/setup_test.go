@@ -0,0 +1,111 @@
+package media_test
+
+import (
+	"bytes"
+	"fmt"
+	"go-react-app/controller"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/utils/storage"
+	"go-react-app/validator"
+	"image"
+	"image/png"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// テスト用の共通変数
+var (
+	mediaDB         *gorm.DB
+	mediaController controller.IMediaController
+	mediaTestUser   model.User
+)
+
+// テストセットアップ関数
+func setupMediaControllerTest() {
+	if mediaDB != nil {
+		testutils.CleanupTestDB(mediaDB)
+	} else {
+		mediaDB = testutils.SetupTestDB()
+		dir, err := os.MkdirTemp("", "media_controller_test")
+		if err != nil {
+			log.Fatalf("一時ディレクトリの作成に失敗しました: %v", err)
+		}
+		mediaUsecase := usecase.NewMediaUsecase(
+			repository.NewMediaRepository(mediaDB),
+			validator.NewMediaValidator(1<<20),
+			storage.NewLocalStorage(dir, "/uploads"),
+			usecase.MediaConfig{MaxUploadSize: 1 << 20, UserQuota: 10 << 20},
+		)
+		mediaController = controller.NewMediaController(mediaUsecase, 1<<20)
+	}
+
+	mediaTestUser = testutils.CreateTestUser(mediaDB)
+}
+
+// JWTクレームを設定したコンテキストを作成するヘルパー関数
+func newContext(userId uint, req *http.Request) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["user_id"] = float64(userId)
+	c.Set("user", token)
+	return c, rec
+}
+
+// ファイルをmultipart/form-dataで送信するリクエストを作成するヘルパー関数
+func newUploadRequest(t *testing.T, fileName string, data []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		t.Fatalf("multipartの作成に失敗しました: %v", err)
+	}
+	part.Write(data)
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/media", &body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	return req
+}
+
+// テスト用のPNG画像を作成するヘルパー関数
+func createTestPNG(t *testing.T) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 100, 50))); err != nil {
+		t.Fatalf("テスト画像の作成に失敗しました: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// MediaIDパラメータを持つコンテキストを作成するヘルパー関数
+func newContextWithMediaId(userId uint, method string, mediaId uint) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, fmt.Sprintf("/media/%d", mediaId), nil)
+	c, rec := newContext(userId, req)
+	c.SetParamNames("mediaId")
+	c.SetParamValues(fmt.Sprintf("%d", mediaId))
+	return c, rec
+}
+
+// 認証なしで公開ファイルを取得するコンテキストを作成するヘルパー関数
+func newPublicContext(rec *httptest.ResponseRecorder, url string) echo.Context {
+	e := echo.New()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, url, nil), rec)
+	c.SetParamNames("*")
+	c.SetParamValues(strings.TrimPrefix(url, "/uploads/"))
+	return c
+}
@@ -0,0 +1,290 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-react-app/usecase"
+	"go-react-app/utils/feedgen"
+	"go-react-app/utils/sitemap"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IPublicController interface {
+	GetAtomFeed(c echo.Context) error
+	GetRSSFeed(c echo.Context) error
+	GetJSONFeed(c echo.Context) error
+	GetSitemapIndex(c echo.Context) error
+	GetSitemap(c echo.Context) error
+	GetRobots(c echo.Context) error
+	GetArticle(c echo.Context) error
+}
+
+type publicController struct {
+	pu usecase.IPublicUsecase
+}
+
+func NewPublicController(pu usecase.IPublicUsecase) IPublicController {
+	return &publicController{pu}
+}
+
+// GetAtomFeed 公開記事のAtomフィードを取得
+// @Summary Atomフィードを取得
+// @Description 指定されたユーザーの公開済み記事をAtom形式で取得する（認証不要）
+// @Tags public
+// @Produce application/atom+xml
+// @Param username path string true "ユーザー名"
+// @Param tag query string false "タグで絞り込む"
+// @Success 200 {string} string "Atomフィード"
+// @Success 304 "Not Modified"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string "PUBLIC_BASE_URLまたはPUBLIC_API_BASE_URLが未設定"
+// @Router /public/{username}/feed.atom [get]
+func (pc *publicController) GetAtomFeed(c echo.Context) error {
+	return pc.writeFeed(c, "application/atom+xml; charset=utf-8", (*feedgen.Feed).Atom)
+}
+
+// GetRSSFeed 公開記事のRSSフィードを取得
+// @Summary RSSフィードを取得
+// @Description 指定されたユーザーの公開済み記事をRSS 2.0形式で取得する（認証不要）
+// @Tags public
+// @Produce application/rss+xml
+// @Param username path string true "ユーザー名"
+// @Param tag query string false "タグで絞り込む"
+// @Success 200 {string} string "RSSフィード"
+// @Success 304 "Not Modified"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string "PUBLIC_BASE_URLまたはPUBLIC_API_BASE_URLが未設定"
+// @Router /public/{username}/feed.rss [get]
+func (pc *publicController) GetRSSFeed(c echo.Context) error {
+	return pc.writeFeed(c, "application/rss+xml; charset=utf-8", (*feedgen.Feed).RSS)
+}
+
+// GetJSONFeed 公開記事のJSON Feedを取得
+// @Summary JSON Feedを取得
+// @Description 指定されたユーザーの公開済み記事をJSON Feed 1.1形式で取得する（認証不要）
+// @Tags public
+// @Produce application/feed+json
+// @Param username path string true "ユーザー名"
+// @Param tag query string false "タグで絞り込む"
+// @Success 200 {string} string "JSON Feed"
+// @Success 304 "Not Modified"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string "PUBLIC_BASE_URLまたはPUBLIC_API_BASE_URLが未設定"
+// @Router /public/{username}/feed.json [get]
+func (pc *publicController) GetJSONFeed(c echo.Context) error {
+	return pc.writeFeed(c, "application/feed+json; charset=utf-8", (*feedgen.Feed).JSON)
+}
+
+// writeFeed はフィードを指定された形式で出力します
+func (pc *publicController) writeFeed(c echo.Context, contentType string, encode func(*feedgen.Feed) ([]byte, error)) error {
+	siteURL, ok := publicURL(envPublicBaseURL)
+	if !ok {
+		return publicURLNotConfigured(c, envPublicBaseURL)
+	}
+	apiURL, ok := publicURL(envPublicAPIBaseURL)
+	if !ok {
+		return publicURLNotConfigured(c, envPublicAPIBaseURL)
+	}
+	feed, err := pc.pu.GetFeed(c.Param("username"), c.QueryParam("tag"), siteURL)
+	if errors.Is(err, usecase.ErrPublicArticleNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "ユーザーが見つかりません"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	feed.FeedURL = apiURL + c.Request().URL.RequestURI()
+
+	body, err := encode(&feed)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return writeCacheable(c, contentType, body, feed.LastModified())
+}
+
+// GetSitemapIndex サイトマップインデックスを取得
+// @Summary サイトマップインデックスを取得
+// @Description 指定されたユーザーのブログのサイトマップインデックスを取得する（認証不要）
+// @Tags public
+// @Produce application/xml
+// @Param username path string true "ユーザー名"
+// @Success 200 {string} string "サイトマップインデックス"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string "PUBLIC_BASE_URLまたはPUBLIC_API_BASE_URLが未設定"
+// @Router /public/{username}/sitemap.xml [get]
+func (pc *publicController) GetSitemapIndex(c echo.Context) error {
+	siteURL, ok := publicURL(envPublicBaseURL)
+	if !ok {
+		return publicURLNotConfigured(c, envPublicBaseURL)
+	}
+	apiURL, ok := publicURL(envPublicAPIBaseURL)
+	if !ok {
+		return publicURLNotConfigured(c, envPublicAPIBaseURL)
+	}
+	sitemaps, err := pc.pu.GetSitemapIndex(c.Param("username"), siteURL, apiURL)
+	if errors.Is(err, usecase.ErrPublicArticleNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "ユーザーが見つかりません"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	body, err := sitemap.EncodeIndex(sitemaps)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return writeCacheable(c, "application/xml; charset=utf-8", body, sitemap.IndexLastModified(sitemaps))
+}
+
+// GetSitemap サイトマップを取得
+// @Summary サイトマップを取得
+// @Description 指定されたユーザーのブログの公開記事のサイトマップを取得する（認証不要）
+// @Tags public
+// @Produce application/xml
+// @Param username path string true "ユーザー名"
+// @Param page path string true "ページ番号（例: 1.xml）"
+// @Success 200 {string} string "サイトマップ"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string "PUBLIC_BASE_URLが未設定"
+// @Router /public/{username}/sitemaps/{page} [get]
+func (pc *publicController) GetSitemap(c echo.Context) error {
+	page, err := strconv.Atoi(strings.TrimSuffix(c.Param("page"), ".xml"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "サイトマップが見つかりません"})
+	}
+
+	siteURL, ok := publicURL(envPublicBaseURL)
+	if !ok {
+		return publicURLNotConfigured(c, envPublicBaseURL)
+	}
+	urls, err := pc.pu.GetSitemap(c.Param("username"), page, siteURL)
+	if errors.Is(err, usecase.ErrPublicArticleNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "サイトマップが見つかりません"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	body, err := sitemap.EncodeURLSet(urls)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return writeCacheable(c, "application/xml; charset=utf-8", body, sitemap.LastModified(urls))
+}
+
+// GetRobots robots.txtを取得
+// @Summary robots.txtを取得
+// @Description サイトのルートのrobots.txtを取得する。公開記事のあるユーザーごとのサイトマップの場所を含む（認証不要）
+// @Tags public
+// @Produce plain
+// @Success 200 {string} string "robots.txt"
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string "PUBLIC_API_BASE_URLが未設定"
+// @Router /robots.txt [get]
+func (pc *publicController) GetRobots(c echo.Context) error {
+	apiURL, ok := publicURL(envPublicAPIBaseURL)
+	if !ok {
+		return publicURLNotConfigured(c, envPublicAPIBaseURL)
+	}
+	robots, err := pc.pu.GetRobots(apiURL)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.Blob(http.StatusOK, "text/plain; charset=utf-8", []byte(robots))
+}
+
+// GetArticle 公開記事を取得
+// @Summary 公開記事を取得
+// @Description 指定されたユーザーの公開済み記事を、レンダリングした本文と記事のページに使うレイアウトとともに取得する（認証不要）。レイアウトは記事の指定 → タグの指定 → ユーザーの既定の順に決定し、公開中のスナップショットがある場合はその状態を返す
+// @Tags public
+// @Produce json
+// @Param username path string true "ユーザー名"
+// @Param articleId path int true "記事ID"
+// @Success 200 {object} model.PublicArticleResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /public/{username}/articles/{articleId} [get]
+func (pc *publicController) GetArticle(c echo.Context) error {
+	articleId, err := strconv.ParseUint(c.Param("articleId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "記事が見つかりません"})
+	}
+
+	articleRes, err := pc.pu.GetArticle(c.Param("username"), uint(articleId))
+	if errors.Is(err, usecase.ErrPublicArticleNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "記事が見つかりません"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, articleRes)
+}
+
+// writeCacheable はLast-Modified・ETagを付与してレスポンスを出力し、条件付きリクエストには304を返します
+func writeCacheable(c echo.Context, contentType string, body []byte, modified time.Time) error {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	lastModified := modified.UTC().Truncate(time.Second)
+
+	header := c.Response().Header()
+	header.Set("ETag", etag)
+	header.Set("Last-Modified", lastModified.Format(http.TimeFormat))
+	header.Set("Cache-Control", "public, max-age=300")
+
+	if notModified(c.Request(), etag, lastModified) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.Blob(http.StatusOK, contentType, body)
+}
+
+// notModified は条件付きリクエストに対して304を返すべきかを判定します
+// If-None-Matchが指定されている場合はIf-Modified-Sinceより優先します
+func notModified(req *http.Request, etag string, lastModified time.Time) bool {
+	if inm := req.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := req.Header.Get("If-Modified-Since"); ims != "" {
+		if since, err := http.ParseTime(ims); err == nil {
+			return !lastModified.After(since)
+		}
+	}
+	return false
+}
+
+// 公開URLを設定する環境変数
+const (
+	// envPublicBaseURL は記事のページ（/{username}/articles/{id}）を配信する公開サイトのURL。このAPIは記事のページを配信しない
+	envPublicBaseURL = "PUBLIC_BASE_URL"
+	// envPublicAPIBaseURL はフィード・サイトマップを配信するこのAPIの公開URL
+	// レスポンスは共有キャッシュに保存されるため、リクエストのHostヘッダーからは生成しない
+	envPublicAPIBaseURL = "PUBLIC_API_BASE_URL"
+)
+
+// publicURL は環境変数nameに設定された公開URLを末尾のスラッシュを除いて返します。未設定の場合はfalseを返す
+func publicURL(name string) (string, bool) {
+	baseURL := strings.TrimRight(os.Getenv(name), "/")
+	return baseURL, baseURL != ""
+}
+
+// publicURLNotConfigured は公開URLが未設定の場合のレスポンスを返します
+func publicURLNotConfigured(c echo.Context, name string) error {
+	return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": fmt.Sprintf("公開URL（%s）が設定されていません", name)})
+}
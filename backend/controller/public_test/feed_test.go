@@ -0,0 +1,107 @@
+package public_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestPublicController_Feed(t *testing.T) {
+	setupPublicControllerTest()
+	t.Setenv("PUBLIC_BASE_URL", "https://blog.example.com/")
+	t.Setenv("PUBLIC_API_BASE_URL", "https://api.example.com/")
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("各形式のContent-Typeで返す", func(t *testing.T) {
+			tests := []struct {
+				path        string
+				handler     func(c echo.Context) error
+				contentType string
+			}{
+				{"/public/feed-user/feed.atom", publicController.GetAtomFeed, "application/atom+xml"},
+				{"/public/feed-user/feed.rss", publicController.GetRSSFeed, "application/rss+xml"},
+				{"/public/feed-user/feed.json", publicController.GetJSONFeed, "application/feed+json"},
+			}
+			for _, tt := range tests {
+				c, rec := newFeedContext("feed-user", tt.path, nil)
+				if err := tt.handler(c); err != nil {
+					t.Fatalf("%s error = %v", tt.path, err)
+				}
+				if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), tt.contentType) {
+					t.Errorf("%s status code = %d, content type = %s", tt.path, rec.Code, rec.Header().Get("Content-Type"))
+				}
+				if rec.Header().Get("ETag") == "" || rec.Header().Get("Last-Modified") == "" {
+					t.Errorf("%s should set ETag and Last-Modified", tt.path)
+				}
+				if !strings.Contains(rec.Body.String(), "公開記事") {
+					t.Errorf("%s body = %s", tt.path, rec.Body.String())
+				}
+			}
+		})
+
+		t.Run("フィード自身のURLはHostヘッダーではなく設定から生成する", func(t *testing.T) {
+			c, rec := newFeedContext("feed-user", "/public/feed-user/feed.atom?tag=Go", nil)
+			c.Request().Host = "evil.example.net"
+			publicController.GetAtomFeed(c)
+			body := rec.Body.String()
+			if strings.Contains(body, "evil.example.net") || !strings.Contains(body, "https://api.example.com/public/feed-user/feed.atom?tag=Go") {
+				t.Errorf("GetAtomFeed() body = %s", body)
+			}
+		})
+
+		t.Run("ETagが一致する場合は304を返す", func(t *testing.T) {
+			c, rec := newFeedContext("feed-user", "/public/feed-user/feed.atom", nil)
+			publicController.GetAtomFeed(c)
+			etag := rec.Header().Get("ETag")
+
+			c, rec = newFeedContext("feed-user", "/public/feed-user/feed.atom", map[string]string{"If-None-Match": etag})
+			publicController.GetAtomFeed(c)
+			if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+				t.Errorf("GetAtomFeed() status code = %d, want %d", rec.Code, http.StatusNotModified)
+			}
+		})
+
+		t.Run("更新がない場合はIf-Modified-Sinceに304を返す", func(t *testing.T) {
+			c, rec := newFeedContext("feed-user", "/public/feed-user/feed.rss", nil)
+			publicController.GetRSSFeed(c)
+			lastModified := rec.Header().Get("Last-Modified")
+
+			c, rec = newFeedContext("feed-user", "/public/feed-user/feed.rss", map[string]string{"If-Modified-Since": lastModified})
+			publicController.GetRSSFeed(c)
+			if rec.Code != http.StatusNotModified {
+				t.Errorf("GetRSSFeed() status code = %d, want %d", rec.Code, http.StatusNotModified)
+			}
+		})
+
+		t.Run("ETagが異なる場合は本文を返す", func(t *testing.T) {
+			c, rec := newFeedContext("feed-user", "/public/feed-user/feed.json", map[string]string{"If-None-Match": `"stale"`})
+			publicController.GetJSONFeed(c)
+			if rec.Code != http.StatusOK {
+				t.Errorf("GetJSONFeed() status code = %d, want %d", rec.Code, http.StatusOK)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("存在しないユーザーは404を返す", func(t *testing.T) {
+			c, rec := newFeedContext("nobody", "/public/nobody/feed.atom", nil)
+			publicController.GetAtomFeed(c)
+			if rec.Code != http.StatusNotFound {
+				t.Errorf("GetAtomFeed() status code = %d, want %d", rec.Code, http.StatusNotFound)
+			}
+		})
+
+		for _, name := range []string{"PUBLIC_BASE_URL", "PUBLIC_API_BASE_URL"} {
+			t.Run(name+"が未設定の場合は503を返す", func(t *testing.T) {
+				t.Setenv(name, "")
+				c, rec := newFeedContext("feed-user", "/public/feed-user/feed.atom", nil)
+				publicController.GetAtomFeed(c)
+				if rec.Code != http.StatusServiceUnavailable {
+					t.Errorf("GetAtomFeed() status code = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package public_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-react-app/model"
+	"net/http"
+	"testing"
+)
+
+// 公開記事へのリクエストを作成するヘルパー関数
+func getPublicArticle(t *testing.T, username string, articleId string) (int, model.PublicArticleResponse) {
+	c, rec := newFeedContext(username, fmt.Sprintf("/public/%s/articles/%s", username, articleId), nil)
+	c.SetParamNames("username", "articleId")
+	c.SetParamValues(username, articleId)
+	if err := publicController.GetArticle(c); err != nil {
+		t.Fatalf("GetArticle() error = %v", err)
+	}
+	var res model.PublicArticleResponse
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+			t.Fatalf("レスポンスの解析に失敗しました: %v", err)
+		}
+	}
+	return rec.Code, res
+}
+
+func TestPublicController_GetArticle(t *testing.T) {
+	setupPublicControllerTest()
+
+	layout := model.Layout{Title: "既定のレイアウト", UserId: publicTestUser.ID}
+	publicDB.Create(&layout)
+	publicDB.Create(&model.LayoutComponent{Name: "ヘッダー", Type: "header", Width: 12, Height: 2, UserId: publicTestUser.ID, LayoutId: &layout.ID})
+	publicDB.Create(&model.LayoutAssignment{UserId: publicTestUser.ID, LayoutId: layout.ID})
+	article := model.Article{Title: "レイアウトの記事", Content: "# 見出し", Published: true, UserId: publicTestUser.ID}
+	publicDB.Create(&article)
+	draft := model.Article{Title: "下書き", Published: false, UserId: publicTestUser.ID}
+	publicDB.Create(&draft)
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("記事とユーザーの既定のレイアウトを返す", func(t *testing.T) {
+			code, res := getPublicArticle(t, "feed-user", fmt.Sprint(article.ID))
+			if code != http.StatusOK {
+				t.Fatalf("GetArticle() status code = %d, want %d", code, http.StatusOK)
+			}
+			if res.Article.ContentHTML == "" || res.Layout.Source != model.LayoutSourceDefault || res.Layout.Layout == nil || len(res.Layout.Layout.Components) != 1 {
+				t.Errorf("GetArticle() = %+v", res)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			username  string
+			articleId string
+		}{
+			{"非公開の記事は404を返す", "feed-user", fmt.Sprint(draft.ID)},
+			{"存在しないユーザー名は404を返す", "unknown", fmt.Sprint(article.ID)},
+			{"不正な記事IDは404を返す", "feed-user", "abc"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if code, _ := getPublicArticle(t, tt.username, tt.articleId); code != http.StatusNotFound {
+					t.Errorf("GetArticle() status code = %d, want %d", code, http.StatusNotFound)
+				}
+			})
+		}
+	})
+}
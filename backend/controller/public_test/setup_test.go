@@ -0,0 +1,61 @@
+package public_test
+
+import (
+	"go-react-app/controller"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/utils/markdown"
+	"go-react-app/validator"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// テスト用の共通変数
+var (
+	publicDB         *gorm.DB
+	publicController controller.IPublicController
+	publicTestUser   model.User
+)
+
+// テストセットアップ関数
+func setupPublicControllerTest() {
+	if publicDB != nil {
+		testutils.CleanupTestDB(publicDB)
+	} else {
+		publicDB = testutils.SetupTestDB()
+		publicUsecase := usecase.NewPublicUsecase(
+			repository.NewUserRepository(publicDB),
+			repository.NewArticleRepository(publicDB),
+			markdown.NewRenderer(markdown.DefaultCacheSize),
+			usecase.NewLayoutAssignmentUsecase(
+				repository.NewLayoutAssignmentRepository(publicDB),
+				repository.NewLayoutRepository(publicDB),
+				validator.NewLayoutAssignmentValidator(),
+			),
+		)
+		publicController = controller.NewPublicController(publicUsecase)
+	}
+
+	publicTestUser = testutils.CreateTestUser(publicDB)
+	publicDB.Model(&publicTestUser).Update("username", "feed-user")
+	publicDB.Create(&model.Article{Title: "公開記事", Content: "本文", Published: true, UserId: publicTestUser.ID})
+}
+
+// 公開フィードへのリクエストを作成するヘルパー関数
+func newFeedContext(username string, path string, header map[string]string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("username")
+	c.SetParamValues(username)
+	return c, rec
+}
@@ -0,0 +1,103 @@
+package public_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPublicController_Sitemap(t *testing.T) {
+	setupPublicControllerTest()
+	t.Setenv("PUBLIC_BASE_URL", "https://blog.example.com")
+	t.Setenv("PUBLIC_API_BASE_URL", "https://api.example.com")
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("サイトマップインデックスを返す", func(t *testing.T) {
+			c, rec := newFeedContext("feed-user", "/public/feed-user/sitemap.xml", nil)
+			// サイトマップの場所はHostヘッダーではなく設定から生成する
+			c.Request().Host = "evil.example.net"
+			if err := publicController.GetSitemapIndex(c); err != nil {
+				t.Fatalf("GetSitemapIndex() error = %v", err)
+			}
+			if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "<sitemapindex") ||
+				!strings.Contains(rec.Body.String(), "https://api.example.com/public/feed-user/sitemaps/1.xml") || strings.Contains(rec.Body.String(), "evil.example.net") {
+				t.Errorf("GetSitemapIndex() status code = %d, body = %s", rec.Code, rec.Body.String())
+			}
+		})
+
+		t.Run("サイトマップを返す", func(t *testing.T) {
+			c, rec := newFeedContext("feed-user", "/public/feed-user/sitemaps/1.xml", nil)
+			c.SetParamNames("username", "page")
+			c.SetParamValues("feed-user", "1.xml")
+			if err := publicController.GetSitemap(c); err != nil {
+				t.Fatalf("GetSitemap() error = %v", err)
+			}
+			if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "<urlset") || !strings.Contains(rec.Body.String(), "https://blog.example.com/feed-user/articles/") {
+				t.Errorf("GetSitemap() status code = %d, body = %s", rec.Code, rec.Body.String())
+			}
+		})
+
+		t.Run("robots.txtを返す", func(t *testing.T) {
+			c, rec := newFeedContext("", "/robots.txt", nil)
+			// クローラーが信頼するサイトマップの場所はHostヘッダーではなく設定から生成する
+			c.Request().Host = "evil.example.net"
+			if err := publicController.GetRobots(c); err != nil {
+				t.Fatalf("GetRobots() error = %v", err)
+			}
+			if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Body.String(), "User-agent: *") ||
+				!strings.Contains(rec.Body.String(), "Sitemap: https://api.example.com/public/feed-user/sitemap.xml") || strings.Contains(rec.Body.String(), "evil.example.net") {
+				t.Errorf("GetRobots() status code = %d, body = %s", rec.Code, rec.Body.String())
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		for _, name := range []string{"PUBLIC_BASE_URL", "PUBLIC_API_BASE_URL"} {
+			t.Run(name+"が未設定の場合は503を返す", func(t *testing.T) {
+				t.Setenv(name, "")
+				c, rec := newFeedContext("feed-user", "/public/feed-user/sitemap.xml", nil)
+				publicController.GetSitemapIndex(c)
+				if rec.Code != http.StatusServiceUnavailable {
+					t.Errorf("GetSitemapIndex() status code = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+				}
+			})
+		}
+
+		t.Run("PUBLIC_API_BASE_URLが未設定の場合はrobots.txtも503を返す", func(t *testing.T) {
+			t.Setenv("PUBLIC_API_BASE_URL", "")
+			c, rec := newFeedContext("", "/robots.txt", nil)
+			publicController.GetRobots(c)
+			if rec.Code != http.StatusServiceUnavailable {
+				t.Errorf("GetRobots() status code = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+			}
+		})
+
+		t.Run("存在しないユーザーのサイトマップインデックスは404を返す", func(t *testing.T) {
+			c, rec := newFeedContext("nobody", "/public/nobody/sitemap.xml", nil)
+			publicController.GetSitemapIndex(c)
+			if rec.Code != http.StatusNotFound {
+				t.Errorf("GetSitemapIndex() status code = %d, want %d", rec.Code, http.StatusNotFound)
+			}
+		})
+
+		t.Run("範囲外のページは404を返す", func(t *testing.T) {
+			c, rec := newFeedContext("feed-user", "/public/feed-user/sitemaps/2.xml", nil)
+			c.SetParamNames("username", "page")
+			c.SetParamValues("feed-user", "2.xml")
+			publicController.GetSitemap(c)
+			if rec.Code != http.StatusNotFound {
+				t.Errorf("GetSitemap() status code = %d, want %d", rec.Code, http.StatusNotFound)
+			}
+		})
+
+		t.Run("存在しないページは404を返す", func(t *testing.T) {
+			c, rec := newFeedContext("feed-user", "/public/feed-user/sitemaps/abc", nil)
+			c.SetParamNames("username", "page")
+			c.SetParamValues("feed-user", "abc")
+			publicController.GetSitemap(c)
+			if rec.Code != http.StatusNotFound {
+				t.Errorf("GetSitemap() status code = %d, want %d", rec.Code, http.StatusNotFound)
+			}
+		})
+	})
+}
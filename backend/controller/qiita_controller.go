@@ -21,7 +21,8 @@ func NewQiitaController(qu usecase.IQiitaUsecase) IQiitaController {
 }
 
 func (qc *qiitaController) GetQiitaArticles(c echo.Context) error {
-	articles, err := qc.qu.GetQiitaArticles()
+	userId := getUserIdFromToken(c)
+	articles, err := qc.qu.GetQiitaArticles(userId)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"message": err.Error(),
@@ -31,8 +32,9 @@ func (qc *qiitaController) GetQiitaArticles(c echo.Context) error {
 }
 
 func (qc *qiitaController) GetQiitaArticleByID(c echo.Context) error {
+	userId := getUserIdFromToken(c)
 	id := c.Param("id")
-	article, err := qc.qu.GetQiitaArticleByID(id)
+	article, err := qc.qu.GetQiitaArticleByID(userId, id)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"message": err.Error(),
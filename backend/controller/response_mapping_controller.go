@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IResponseMappingController interface {
+	GetAllResponseMappings(c echo.Context) error
+	GetResponseMappingById(c echo.Context) error
+	CreateResponseMapping(c echo.Context) error
+	UpdateResponseMapping(c echo.Context) error
+	DeleteResponseMapping(c echo.Context) error
+	PreviewResponseMapping(c echo.Context) error
+}
+
+type responseMappingController struct {
+	rmu usecase.IResponseMappingUsecase
+}
+
+func NewResponseMappingController(rmu usecase.IResponseMappingUsecase) IResponseMappingController {
+	return &responseMappingController{rmu}
+}
+
+// GetAllResponseMappings 外部APIの応答の変換の定義の一覧を取得
+// @Summary 変換の定義の一覧を取得
+// @Description ログインユーザーの、外部APIの応答をカードの一覧に変換する定義を作成順に取得する
+// @Tags response-mappings
+// @Produce json
+// @Success 200 {array} model.ResponseMappingResponse
+// @Failure 500 {object} map[string]string
+// @Router /response-mappings [get]
+func (rmc *responseMappingController) GetAllResponseMappings(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	mappingsRes, err := rmc.rmu.GetAllResponseMappings(userId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, mappingsRes)
+}
+
+// GetResponseMappingById 外部APIの応答の変換の定義を取得
+// @Summary 変換の定義を取得
+// @Description 指定したIDの変換の定義を取得する
+// @Tags response-mappings
+// @Produce json
+// @Param mappingId path int true "変換の定義のID"
+// @Success 200 {object} model.ResponseMappingResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /response-mappings/{mappingId} [get]
+func (rmc *responseMappingController) GetResponseMappingById(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	mappingId, err := strconv.ParseUint(c.Param("mappingId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効な変換の定義のIDです"})
+	}
+
+	mappingRes, err := rmc.rmu.GetResponseMappingById(userId, uint(mappingId))
+	return rmc.writeMapping(c, http.StatusOK, mappingRes, err)
+}
+
+// CreateResponseMapping 外部APIの応答の変換の定義を作成
+// @Summary 変換の定義を作成
+// @Description 外部APIの応答から項目の配列を取り出すパス式と、項目ごとにカードの各項目を作るテンプレートを保存する
+// @Tags response-mappings
+// @Accept json
+// @Produce json
+// @Param request body model.ResponseMappingRequest true "変換の定義"
+// @Success 201 {object} model.ResponseMappingResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /response-mappings [post]
+func (rmc *responseMappingController) CreateResponseMapping(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	var request model.ResponseMappingRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	request.UserId = userId
+
+	mappingRes, err := rmc.rmu.CreateResponseMapping(request)
+	return rmc.writeMapping(c, http.StatusCreated, mappingRes, err)
+}
+
+// UpdateResponseMapping 外部APIの応答の変換の定義を更新
+// @Summary 変換の定義を更新
+// @Description 指定したIDの変換の定義を更新する
+// @Tags response-mappings
+// @Accept json
+// @Produce json
+// @Param mappingId path int true "変換の定義のID"
+// @Param request body model.ResponseMappingRequest true "変換の定義"
+// @Success 200 {object} model.ResponseMappingResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /response-mappings/{mappingId} [put]
+func (rmc *responseMappingController) UpdateResponseMapping(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	mappingId, err := strconv.ParseUint(c.Param("mappingId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効な変換の定義のIDです"})
+	}
+
+	var request model.ResponseMappingRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	mappingRes, err := rmc.rmu.UpdateResponseMapping(request, userId, uint(mappingId))
+	return rmc.writeMapping(c, http.StatusOK, mappingRes, err)
+}
+
+// DeleteResponseMapping 外部APIの応答の変換の定義を削除
+// @Summary 変換の定義を削除
+// @Description 指定したIDの変換の定義を削除する
+// @Tags response-mappings
+// @Param mappingId path int true "変換の定義のID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /response-mappings/{mappingId} [delete]
+func (rmc *responseMappingController) DeleteResponseMapping(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	mappingId, err := strconv.ParseUint(c.Param("mappingId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効な変換の定義のIDです"})
+	}
+
+	err = rmc.rmu.DeleteResponseMapping(userId, uint(mappingId))
+	if errors.Is(err, usecase.ErrResponseMappingNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "変換の定義が見つかりません"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PreviewResponseMapping 外部APIの応答の変換をプレビュー
+// @Summary 変換をプレビュー
+// @Description 外部APIを呼び出し、保存前の変換の定義で作ったカードと、値の型が合わないなど変換できなかった箇所を返す
+// @Tags response-mappings
+// @Accept json
+// @Produce json
+// @Param request body model.ResponseMappingRequest true "変換の定義（nameは省略可）"
+// @Success 200 {object} model.ResponseMappingPreviewResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Failure 504 {object} map[string]string
+// @Router /response-mappings/preview [post]
+func (rmc *responseMappingController) PreviewResponseMapping(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	var request model.ResponseMappingRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	request.UserId = userId
+
+	previewRes, err := rmc.rmu.PreviewResponseMapping(request)
+	if errors.Is(err, usecase.ErrInvalidResponseMapping) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(externalAPICallErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, previewRes)
+}
+
+// writeMapping は変換の定義を出力し、不正な定義の場合は400、見つからない場合は404を返します
+func (rmc *responseMappingController) writeMapping(c echo.Context, status int, mappingRes model.ResponseMappingResponse, err error) error {
+	if errors.Is(err, usecase.ErrInvalidResponseMapping) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if errors.Is(err, usecase.ErrResponseMappingNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "変換の定義が見つかりません"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(status, mappingRes)
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"errors"
+	"go-react-app/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ISearchController interface {
+	Search(c echo.Context) error
+	Reindex(c echo.Context) error
+}
+
+type searchController struct {
+	su usecase.ISearchUsecase
+}
+
+func NewSearchController(su usecase.ISearchUsecase) ISearchController {
+	return &searchController{su}
+}
+
+// Search 記事・書籍・フィード記事を全文検索
+// @Summary 全文検索
+// @Description 記事のタイトル・本文・タグ、書籍のタイトル・著者・説明、保存済みのフィード記事を横断検索する
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "検索キーワード"
+// @Param limit query int false "取得件数（既定20、最大100）"
+// @Success 200 {object} model.SearchResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /search [get]
+func (sc *searchController) Search(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	limit := 0
+	if l := c.QueryParam("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "無効な取得件数です"})
+		}
+		limit = parsed
+	}
+
+	result, err := sc.su.Search(userId, c.QueryParam("q"), limit)
+	if errors.Is(err, usecase.ErrInvalidSearchQuery) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// Reindex 検索インデックスを再構築
+// @Summary 検索インデックスを再構築
+// @Description ログインユーザーの記事・書籍・フィード記事を検索インデックスに登録し直す
+// @Tags search
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]int
+// @Failure 500 {object} map[string]string
+// @Router /search/reindex [post]
+func (sc *searchController) Reindex(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	count, err := sc.su.Reindex(userId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]int{"indexed": count})
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ISecretController interface {
+	GetSecrets(c echo.Context) error
+	PutSecret(c echo.Context) error
+	DeleteSecret(c echo.Context) error
+}
+
+type secretController struct {
+	su usecase.ISecretUsecase
+}
+
+func NewSecretController(su usecase.ISecretUsecase) ISecretController {
+	return &secretController{su}
+}
+
+// GetSecrets 保存されている秘密情報の一覧を取得
+// @Summary 秘密情報の一覧を取得
+// @Description ログインユーザーが保存した秘密情報の名前と更新日時の一覧を取得する。値は返さない
+// @Tags secrets
+// @Produce json
+// @Success 200 {array} model.SecretResponse
+// @Failure 500 {object} map[string]string
+// @Router /secrets [get]
+func (sc *secretController) GetSecrets(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	secretsRes, err := sc.su.GetSecrets(userId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, secretsRes)
+}
+
+// PutSecret 秘密情報を保存
+// @Summary 秘密情報を保存
+// @Description 連携サービスのAPIキーやアクセストークンを暗号化して保存する。同じ名前の秘密情報がある場合は置き換える。qiita_access_tokenとgoogle_books_api_keyはQiitaとGoogle Booksの呼び出しに使われる
+// @Tags secrets
+// @Accept json
+// @Produce json
+// @Param name path string true "秘密情報の名前"
+// @Param request body model.SecretRequest true "保存する値"
+// @Success 200 {object} model.SecretResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /secrets/{name} [put]
+func (sc *secretController) PutSecret(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	var request model.SecretRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	secretRes, err := sc.su.PutSecret(userId, c.Param("name"), request)
+	switch {
+	case err == nil:
+		return c.JSON(http.StatusOK, secretRes)
+	case errors.Is(err, usecase.ErrInvalidSecret):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, usecase.ErrSecretStoreDisabled):
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+}
+
+// DeleteSecret 秘密情報を削除
+// @Summary 秘密情報を削除
+// @Description 指定した名前の秘密情報を削除する
+// @Tags secrets
+// @Param name path string true "秘密情報の名前"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /secrets/{name} [delete]
+func (sc *secretController) DeleteSecret(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	err := sc.su.DeleteSecret(userId, c.Param("name"))
+	switch {
+	case err == nil:
+		return c.NoContent(http.StatusNoContent)
+	case errors.Is(err, usecase.ErrInvalidSecret):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, usecase.ErrSecretNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "秘密情報が見つかりません"})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+}
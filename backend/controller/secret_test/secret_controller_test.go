@@ -0,0 +1,117 @@
+package secret_test
+
+import (
+	"fmt"
+	"go-react-app/controller"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock for secret usecase
+type MockSecretUsecase struct {
+	mock.Mock
+}
+
+func (m *MockSecretUsecase) GetSecrets(userId uint) ([]model.SecretResponse, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]model.SecretResponse), args.Error(1)
+}
+
+func (m *MockSecretUsecase) PutSecret(userId uint, name string, request model.SecretRequest) (model.SecretResponse, error) {
+	args := m.Called(userId, name, request)
+	return args.Get(0).(model.SecretResponse), args.Error(1)
+}
+
+func (m *MockSecretUsecase) DeleteSecret(userId uint, name string) error {
+	args := m.Called(userId, name)
+	return args.Error(0)
+}
+
+func (m *MockSecretUsecase) RotateSecrets() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+// Helper function to setup the Echo context with a JWT token
+func setupContext(method, url string, body string, name string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, url, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("name")
+	c.SetParamValues(name)
+
+	token := jwt.New(jwt.SigningMethodHS256)
+	token.Claims.(jwt.MapClaims)["user_id"] = float64(1)
+	c.Set("user", token)
+	return c, rec
+}
+
+func TestPutSecret(t *testing.T) {
+	mockUsecase := new(MockSecretUsecase)
+	secretController := controller.NewSecretController(mockUsecase)
+
+	request := model.SecretRequest{Value: "token"}
+
+	tests := []struct {
+		name     string
+		response model.SecretResponse
+		err      error
+		want     int
+	}{
+		{"正常系", model.SecretResponse{Name: model.SecretNameQiitaAccessToken}, nil, http.StatusOK},
+		{"不正な名前や値は400を返す", model.SecretResponse{}, fmt.Errorf("%w: value is required", usecase.ErrInvalidSecret), http.StatusBadRequest},
+		{"マスターキーが未設定の場合は503を返す", model.SecretResponse{}, usecase.ErrSecretStoreDisabled, http.StatusServiceUnavailable},
+		{"その他のエラーは500を返す", model.SecretResponse{}, fmt.Errorf("database is locked"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.On("PutSecret", uint(1), model.SecretNameQiitaAccessToken, request).Return(tt.response, tt.err).Once()
+
+			c, rec := setupContext(http.MethodPut, "/secrets/qiita_access_token", `{"value":"token"}`, model.SecretNameQiitaAccessToken)
+
+			if assert.NoError(t, secretController.PutSecret(c)) {
+				assert.Equal(t, tt.want, rec.Code)
+			}
+		})
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestDeleteSecret(t *testing.T) {
+	mockUsecase := new(MockSecretUsecase)
+	secretController := controller.NewSecretController(mockUsecase)
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"正常系", nil, http.StatusNoContent},
+		{"保存されていない場合は404を返す", fmt.Errorf("%w: qiita_access_token", usecase.ErrSecretNotFound), http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.On("DeleteSecret", uint(1), model.SecretNameQiitaAccessToken).Return(tt.err).Once()
+
+			c, rec := setupContext(http.MethodDelete, "/secrets/qiita_access_token", "", model.SecretNameQiitaAccessToken)
+
+			if assert.NoError(t, secretController.DeleteSecret(c)) {
+				assert.Equal(t, tt.want, rec.Code)
+			}
+		})
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
@@ -15,6 +15,7 @@ type IUserController interface {
 	LogIn(c echo.Context) error
 	LogOut(c echo.Context) error
 	CsrfToken(c echo.Context) error
+	UpdateUsername(c echo.Context) error
 }
 
 type userController struct {
@@ -122,4 +123,29 @@ func (uc *userController) CsrfToken(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]string{
 		"csrf_token": token,
 	})
-}
\ No newline at end of file
+}
+
+// UpdateUsername ユーザー名を変更
+// @Summary ユーザー名を変更
+// @Description 公開フィードなどのURLに使用するユーザー名を設定・変更する
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user body model.UsernameRequest true "ユーザー名"
+// @Success 200 {object} model.UserResponse
+// @Failure 400 {object} map[string]string
+// @Router /users/me/username [put]
+func (uc *userController) UpdateUsername(c echo.Context) error {
+	userId := getUserIdFromToken(c)
+
+	req := model.UsernameRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	userRes, err := uc.uu.UpdateUsername(userId, req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, userRes)
+}
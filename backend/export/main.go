@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go-react-app/db"
+	"go-react-app/repository"
+	"go-react-app/usecase"
+	"go-react-app/utils/layoutrender"
+	"go-react-app/utils/markdown"
+	"go-react-app/utils/staticsite"
+	"go-react-app/utils/storage"
+	"go-react-app/validator"
+	"log"
+	"os"
+)
+
+// 公開済みの記事をレイアウトに当てはめて静的サイトとして書き出します
+//
+//	go run ./export -user gopher -layout 1 -base-url https://blog.example.com -out ./public [-zip site.zip] [-full]
+//
+// -outに前回の書き出し結果がある場合は、UpdatedAtが変わった記事のページのみ生成し直します
+func main() {
+	username := flag.String("user", "", "書き出すユーザーのユーザー名")
+	layoutId := flag.Uint("layout", 0, "ページに使用するレイアウトのID")
+	baseURL := flag.String("base-url", os.Getenv("PUBLIC_BASE_URL"), "書き出したサイトの公開先のURL")
+	title := flag.String("title", "", "サイトのタイトル（省略時はユーザー名）")
+	out := flag.String("out", "./public", "書き出し先のディレクトリ")
+	zipPath := flag.String("zip", "", "指定した場合は書き出し結果をZIPファイルにも保存する")
+	full := flag.Bool("full", false, "前回の結果を使わずにすべてのページを生成し直す")
+	flag.Parse()
+
+	if *username == "" || *layoutId == 0 || *baseURL == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dbConn := db.NewDB()
+	defer db.CloseDB(dbConn)
+
+	userRepository := repository.NewUserRepository(dbConn)
+	user, err := userRepository.GetUserByUsername(*username)
+	if err != nil {
+		log.Fatalf("ユーザーが見つかりません (%s): %v", *username, err)
+	}
+
+	mediaBaseURL := envOrDefault("MEDIA_BASE_URL", "/uploads")
+	mediaStorage := storage.NewLocalStorage(envOrDefault("MEDIA_DIR", "./uploads"), mediaBaseURL)
+	builder := staticsite.NewBuilder(markdown.NewRenderer(markdown.DefaultCacheSize), layoutrender.NewSiteRenderer(layoutrender.NewRenderer()), mediaStorage, mediaBaseURL)
+	exportUsecase := usecase.NewExportUsecase(
+		repository.NewExportRepository(dbConn),
+		validator.NewExportValidator(),
+		userRepository,
+		repository.NewArticleRepository(dbConn),
+		repository.NewLayoutRepository(dbConn),
+		builder,
+		usecase.ExportConfig{},
+	)
+
+	result, err := exportUsecase.BuildSite(user.ID, *layoutId, *title, *baseURL, *out, *full)
+	if err != nil {
+		log.Fatalf("静的サイトの書き出しに失敗しました: %v", err)
+	}
+	fmt.Printf("%sに書き出しました（記事%d件: 生成%d件, 変更なし%d件, 削除%d件）\n",
+		*out, result.Articles, result.Rendered, result.Skipped, result.Removed)
+
+	if *zipPath != "" {
+		file, err := os.Create(*zipPath)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if err := staticsite.WriteZip(*out, file); err != nil {
+			file.Close()
+			log.Fatalf("ZIPファイルの作成に失敗しました: %v", err)
+		}
+		if err := file.Close(); err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Printf("%sを作成しました\n", *zipPath)
+	}
+}
+
+func envOrDefault(key string, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
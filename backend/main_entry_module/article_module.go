@@ -12,6 +12,7 @@ import (
 func (m *MainEntryPackage) initArticleModule(db *gorm.DB) {
 	articleValidator := validator.NewArticleValidator()
 	articleRepository := repository.NewArticleRepository(db)
-	articleUsecase := usecase.NewArticleUsecase(articleRepository, articleValidator)
+	searchRepository := repository.NewSearchRepository(db)
+	articleUsecase := usecase.NewArticleUsecase(articleRepository, articleValidator, searchRepository, m.markdownRenderer, repository.NewLayoutRepository(db))
 	m.ArticleController = controller.NewArticleController(articleUsecase)
 }
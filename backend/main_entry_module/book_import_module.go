@@ -0,0 +1,35 @@
+package main_entry_module
+
+import (
+	"log"
+
+	"gorm.io/gorm"
+
+	"go-react-app/controller"
+	"go-react-app/repository"
+	"go-react-app/usecase"
+	"go-react-app/validator"
+)
+
+// initBookImportModule は書籍の一括登録関連のモジュールを初期化します
+// 書籍の情報はopenBD・国立国会図書館サーチ・Google Books APIから取得します
+func (m *MainEntryPackage) initBookImportModule(db *gorm.DB) {
+	bookValidator := validator.NewBookValidator()
+	bookRepository := repository.NewBookRepository(db)
+	bookUsecase := usecase.NewBookUsecase(bookRepository, bookValidator, repository.NewSearchRepository(db))
+	bookImportUsecase := usecase.NewBookImportUsecase(
+		repository.NewBookImportRepository(db),
+		bookRepository,
+		newBookMetadataUsecase(m.secrets),
+		bookUsecase,
+		bookValidator,
+		usecase.BookImportConfig{},
+	)
+	// 前回の起動時に実行中だったジョブは再開されないため、失敗として記録する
+	if count, err := bookImportUsecase.FailInterruptedImports(); err != nil {
+		log.Printf("中断された書籍の一括登録ジョブの更新に失敗しました: %v", err)
+	} else if count > 0 {
+		log.Printf("中断された書籍の一括登録ジョブ%d件を失敗にしました", count)
+	}
+	m.BookImportController = controller.NewBookImportController(bookImportUsecase)
+}
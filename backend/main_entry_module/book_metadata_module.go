@@ -0,0 +1,34 @@
+package main_entry_module
+
+import (
+	"log"
+	"strings"
+
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/usecase"
+)
+
+// defaultBookMetadataProviders 日本の書籍の情報が多いopenBD・国立国会図書館サーチを優先する
+const defaultBookMetadataProviders = "openbd,ndl,google"
+
+// newBookMetadataUsecase は書籍の情報のプロバイダーを優先する順に並べたユースケースを作成します
+// 使うプロバイダーと順番は環境変数 BOOK_METADATA_PROVIDERS（カンマ区切り）で変更できます
+// Google Books APIはユーザーごとに保存したAPIキーで呼び出します
+func newBookMetadataUsecase(secrets repository.ISecretRepository) usecase.IBookMetadataUsecase {
+	providers := []repository.IBookMetadataProvider{}
+	for _, name := range strings.Split(envString("BOOK_METADATA_PROVIDERS", defaultBookMetadataProviders), ",") {
+		switch name = strings.TrimSpace(name); name {
+		case model.BookMetadataProviderOpenBD:
+			providers = append(providers, repository.NewOpenBDMetadataProvider(envString("OPENBD_BASE_URL", repository.DefaultOpenBDBaseURL)))
+		case model.BookMetadataProviderNDL:
+			providers = append(providers, repository.NewNDLMetadataProvider(envString("NDL_SEARCH_BASE_URL", repository.DefaultNDLSearchBaseURL)))
+		case model.BookMetadataProviderGoogle:
+			providers = append(providers, repository.NewGoogleBookMetadataProvider(repository.NewGoogleBookRepository(secrets)))
+		case "":
+		default:
+			log.Printf("不明な書籍の情報のプロバイダー %q を無視します", name)
+		}
+	}
+	return usecase.NewBookMetadataUsecase(providers...)
+}
@@ -15,6 +15,7 @@ import (
 func (m *MainEntryPackage) initBookModule(db *gorm.DB) {
 	bookValidator := validator.NewBookValidator()
 	bookRepository := repository.NewBookRepository(db)
-	bookUsecase := usecase.NewBookUsecase(bookRepository, bookValidator)
+	searchRepository := repository.NewSearchRepository(db)
+	bookUsecase := usecase.NewBookUsecase(bookRepository, bookValidator, searchRepository)
 	m.BookController = controller.NewBookController(bookUsecase)
 }
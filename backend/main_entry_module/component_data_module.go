@@ -0,0 +1,22 @@
+package main_entry_module
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"go-react-app/repository"
+	"go-react-app/usecase"
+	"go-react-app/validator"
+)
+
+// newComponentDataUsecase はデータを取得するコンポーネントの取得元を、既存のユースケースで作成します
+// キャッシュする期間は環境変数COMPONENT_DATA_TTL_SECONDSで変更できる
+func newComponentDataUsecase(db *gorm.DB, secrets repository.ISecretRepository) usecase.IComponentDataUsecase {
+	feedArticleUsecase := usecase.NewFeedArticleUsecase(repository.NewFeedArticleRepository(repository.NewFeedRepository(db)), repository.NewFeedRepository(db), repository.NewSearchRepository(db))
+	qiitaUsecase := usecase.NewQiitaUsecase(repository.NewQiitaRepository(secrets))
+	bookUsecase := usecase.NewBookUsecase(repository.NewBookRepository(db), validator.NewBookValidator(), repository.NewSearchRepository(db))
+	ttl := time.Duration(envInt64("COMPONENT_DATA_TTL_SECONDS", int64(usecase.DefaultComponentDataTTL/time.Second))) * time.Second
+	responseMappingUsecase := newResponseMappingUsecase(db, secrets)
+	return usecase.NewComponentDataUsecase(repository.NewArticleRepository(db), feedArticleUsecase, qiitaUsecase, bookUsecase, responseMappingUsecase, ttl)
+}
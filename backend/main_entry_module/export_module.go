@@ -0,0 +1,38 @@
+package main_entry_module
+
+import (
+	"log"
+
+	"gorm.io/gorm"
+
+	"go-react-app/controller"
+	"go-react-app/repository"
+	"go-react-app/usecase"
+	"go-react-app/utils/layoutrender"
+	"go-react-app/utils/staticsite"
+	"go-react-app/validator"
+)
+
+const defaultExportDir = "./exports"
+
+// initExportModule は静的サイトの書き出し関連のモジュールを初期化します
+// 書き出し先は環境変数 EXPORT_DIR で変更できます
+func (m *MainEntryPackage) initExportModule(db *gorm.DB) {
+	builder := staticsite.NewBuilder(m.markdownRenderer, layoutrender.NewSiteRenderer(m.layoutRenderer), newMediaStorage(), envString("MEDIA_BASE_URL", defaultMediaBaseURL))
+	exportUsecase := usecase.NewExportUsecase(
+		repository.NewExportRepository(db),
+		validator.NewExportValidator(),
+		repository.NewUserRepository(db),
+		repository.NewArticleRepository(db),
+		repository.NewLayoutRepository(db),
+		builder,
+		usecase.ExportConfig{Dir: envString("EXPORT_DIR", defaultExportDir)},
+	)
+	// 前回の起動時に実行中だったジョブは再開されないため、失敗として記録する
+	if count, err := exportUsecase.FailInterruptedExports(); err != nil {
+		log.Printf("中断された書き出しジョブの更新に失敗しました: %v", err)
+	} else if count > 0 {
+		log.Printf("中断された書き出しジョブ%d件を失敗にしました", count)
+	}
+	m.ExportController = controller.NewExportController(exportUsecase)
+}
@@ -1,17 +1,45 @@
 package main_entry_module
 
 import (
+	"log"
+	"time"
+
 	"gorm.io/gorm"
 
 	"go-react-app/controller"
 	"go-react-app/repository"
 	"go-react-app/usecase"
+	"go-react-app/utils/netguard"
 	"go-react-app/validator"
 )
 
 func (m *MainEntryPackage) initExternalAPIModule(db *gorm.DB) {
+	// ヘルスチェックも外部APIの呼び出しと同じく、内部ネットワークへの接続を拒否する
+	externalAPICallRepository := repository.NewExternalAPICallRepository(netguard.Guard{})
+	m.externalAPIHealth = usecase.NewExternalAPIHealthUsecase(repository.NewExternalAPIRepository(db), repository.NewExternalAPIHealthRepository(db), externalAPICallRepository, m.secrets)
+	m.ExternalAPIController = controller.NewExternalAPIController(newExternalAPIUsecase(db, m.secrets), m.externalAPIHealth)
+}
+
+// newExternalAPIUsecase は外部APIのユースケースを作成します（応答の変換の定義でも使う）
+func newExternalAPIUsecase(db *gorm.DB, secrets repository.ISecretRepository) usecase.IExternalAPIUsecase {
 	externalAPIValidator := validator.NewExternalAPIValidator()
 	externalAPIRepository := repository.NewExternalAPIRepository(db)
-	externalAPIUsecase := usecase.NewExternalAPIUsecase(externalAPIRepository, externalAPIValidator)
-	m.ExternalAPIController = controller.NewExternalAPIController(externalAPIUsecase)
+	// 外部APIの呼び出しでは内部ネットワークへの接続を常に拒否する
+	externalAPICallRepository := repository.NewExternalAPICallRepository(netguard.Guard{})
+	return usecase.NewExternalAPIUsecase(externalAPIRepository, externalAPIValidator, externalAPICallRepository, secrets)
+}
+
+// startExternalAPIHealthChecker は外部APIのヘルスチェックをバックグラウンドで定期的に実行します
+// 間隔が経過した外部APIがあるかを環境変数EXTERNAL_API_HEALTH_CHECK_TICK_SECONDS（既定値は30秒）ごとに確認する
+func (m *MainEntryPackage) startExternalAPIHealthChecker() {
+	tick := time.Duration(envInt64("EXTERNAL_API_HEALTH_CHECK_TICK_SECONDS", 30)) * time.Second
+	go func() {
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			if _, err := m.externalAPIHealth.RunDueHealthChecks(now); err != nil {
+				log.Printf("外部APIのヘルスチェックに失敗しました: %v", err)
+			}
+		}
+	}()
 }
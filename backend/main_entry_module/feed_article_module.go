@@ -11,6 +11,7 @@ import (
 func (m *MainEntryPackage) initFeedArticleModule(db *gorm.DB) {
 	feedRepository := repository.NewFeedRepository(db)
 	feedArticleRepository := repository.NewFeedArticleRepository(feedRepository)
-	feedArticleUsecase := usecase.NewFeedArticleUsecase(feedArticleRepository)
+	searchRepository := repository.NewSearchRepository(db)
+	feedArticleUsecase := usecase.NewFeedArticleUsecase(feedArticleRepository, feedRepository, searchRepository)
 	m.FeedArticleController = controller.NewFeedArticleController(feedArticleUsecase)
 }
@@ -12,6 +12,6 @@ import (
 func (m *MainEntryPackage) initFeedModule(db *gorm.DB) {
 	feedValidator := validator.NewFeedValidator()
 	feedRepository := repository.NewFeedRepository(db)
-	feedUsecase := usecase.NewFeedUsecase(feedRepository, feedValidator)
+	feedUsecase := usecase.NewFeedUsecase(feedRepository, feedValidator, repository.NewSearchRepository(db))
 	m.FeedController = controller.NewFeedController(feedUsecase)
 }
@@ -14,9 +14,10 @@ import (
 // @Description Google Books API関連のリポジトリ、ユースケース、コントローラーを初期化します
 func (m *MainEntryPackage) initGoogleBookModule(db *gorm.DB) {
 	bookValidator := validator.NewBookValidator()
-	googleBookRepository := repository.NewGoogleBookRepository()
+	googleBookRepository := repository.NewGoogleBookRepository(m.secrets)
 	bookRepository := repository.NewBookRepository(db)
-	bookUsecase := usecase.NewBookUsecase(bookRepository, bookValidator)
+	searchRepository := repository.NewSearchRepository(db)
+	bookUsecase := usecase.NewBookUsecase(bookRepository, bookValidator, searchRepository)
 	googleBookUsecase := usecase.NewGoogleBookUsecase(googleBookRepository, bookValidator)
 	m.GoogleBookController = controller.NewGoogleBookController(googleBookUsecase, bookUsecase)
 }
@@ -12,6 +12,7 @@ import (
 func (m *MainEntryPackage) initLayoutComponentModule(db *gorm.DB) {
 	layoutComponentValidator := validator.NewLayoutComponentValidator()
 	layoutComponentRepository := repository.NewLayoutComponentRepository(db)
-	layoutComponentUsecase := usecase.NewLayoutComponentUsecase(layoutComponentRepository, layoutComponentValidator)
+	layoutRepository := repository.NewLayoutRepository(db)
+	layoutComponentUsecase := usecase.NewLayoutComponentUsecase(layoutComponentRepository, layoutRepository, layoutComponentValidator, m.componentData)
 	m.LayoutComponentController = controller.NewLayoutComponentController(layoutComponentUsecase)
 }
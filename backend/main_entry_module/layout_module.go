@@ -12,6 +12,29 @@ import (
 func (m *MainEntryPackage) initLayoutModule(db *gorm.DB) {
 	layoutValidator := validator.NewLayoutValidator()
 	layoutRepository := repository.NewLayoutRepository(db)
-	layoutUsecase := usecase.NewLayoutUsecase(layoutRepository, layoutValidator)
+	articleRepository := repository.NewArticleRepository(db)
+	layoutSnapshotRepository := repository.NewLayoutSnapshotRepository(db)
+	layoutUsecase := usecase.NewLayoutUsecase(layoutRepository, layoutSnapshotRepository, layoutValidator, validator.NewLayoutComponentValidator(), articleRepository, m.markdownRenderer, m.layoutRenderer, m.componentData)
 	m.LayoutController = controller.NewLayoutController(layoutUsecase)
+
+	layoutSnapshotValidator := validator.NewLayoutSnapshotValidator()
+	layoutSnapshotUsecase := usecase.NewLayoutSnapshotUsecase(layoutSnapshotRepository, layoutRepository, layoutSnapshotValidator)
+	m.LayoutSnapshotController = controller.NewLayoutSnapshotController(layoutSnapshotUsecase)
+
+	layoutTemplateValidator := validator.NewLayoutTemplateValidator()
+	layoutTemplateRepository := repository.NewLayoutTemplateRepository(db)
+	layoutTemplateUsecase := usecase.NewLayoutTemplateUsecase(layoutTemplateRepository, layoutRepository, layoutTemplateValidator)
+	m.LayoutTemplateController = controller.NewLayoutTemplateController(layoutTemplateUsecase)
+
+	m.LayoutAssignmentController = controller.NewLayoutAssignmentController(newLayoutAssignmentUsecase(db))
+}
+
+// newLayoutAssignmentUsecase は記事のページに使うレイアウトの指定のユースケースを作成します
+// レイアウトの指定の設定と、公開記事のページのレイアウトの決定で使う
+func newLayoutAssignmentUsecase(db *gorm.DB) usecase.ILayoutAssignmentUsecase {
+	return usecase.NewLayoutAssignmentUsecase(
+		repository.NewLayoutAssignmentRepository(db),
+		repository.NewLayoutRepository(db),
+		validator.NewLayoutAssignmentValidator(),
+	)
 }
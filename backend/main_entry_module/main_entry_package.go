@@ -2,6 +2,10 @@ package main_entry_module
 
 import (
 	"go-react-app/controller"
+	"go-react-app/repository"
+	"go-react-app/usecase"
+	"go-react-app/utils/layoutrender"
+	"go-react-app/utils/markdown"
 	"gorm.io/gorm"
 )
 
@@ -14,20 +18,46 @@ type MainEntryPackage struct {
 	ArticleController         controller.IArticleController
 	LayoutController          controller.ILayoutController
 	LayoutComponentController controller.ILayoutComponentController
+	LayoutSnapshotController  controller.ILayoutSnapshotController
+	LayoutTemplateController  controller.ILayoutTemplateController
+	LayoutAssignmentController controller.ILayoutAssignmentController
 	QiitaController           controller.IQiitaController
 	HatenaController          controller.IHatenaController
 	FeedArticleController     controller.IFeedArticleController
 	BookController            controller.IBookController
 	GoogleBookController      controller.IGoogleBookController
+	BookImportController      controller.IBookImportController
+	SearchController          controller.ISearchController
+	MediaController           controller.IMediaController
+	PublicController          controller.IPublicController
+	ExportController          controller.IExportController
+	SecretController          controller.ISecretController
+	ResponseMappingController controller.IResponseMappingController
 	
 	// Swaggerハンドラーを追加（オプション）
 	SwaggerEnabled            bool
+
+	// モジュール間で共有するMarkdownレンダラー（レンダリング結果のキャッシュを共有するため）
+	markdownRenderer          markdown.IRenderer
+	// レイアウトのプレビューと静的サイトの書き出しで共有するレイアウトレンダラー
+	layoutRenderer            layoutrender.IRenderer
+	// レイアウトのプレビューとコンポーネントのデータの取得で共有する取得元（取得結果のキャッシュを共有するため）
+	componentData             usecase.IComponentDataUsecase
+	// 連携サービスの認証情報をリクエストごとに取得する秘密情報のリポジトリ
+	secrets                   repository.ISecretRepository
+	// サーバーの起動後にバックグラウンドで実行する外部APIのヘルスチェック
+	externalAPIHealth         usecase.IExternalAPIHealthUsecase
 }
 
 // NewMainEntryPackage は新しいMainEntryPackageインスタンスを作成する
 func NewMainEntryPackage(db *gorm.DB) *MainEntryPackage {
+	secrets := newSecretRepository(db)
 	entry := &MainEntryPackage{
-		SwaggerEnabled: true, // デフォルトで有効
+		SwaggerEnabled:   true, // デフォルトで有効
+		markdownRenderer: markdown.NewRenderer(markdown.DefaultCacheSize),
+		layoutRenderer:   layoutrender.NewRenderer(),
+		componentData:    newComponentDataUsecase(db, secrets),
+		secrets:          secrets,
 	}
 	
 	// 各モジュールの初期化
@@ -35,6 +65,7 @@ func NewMainEntryPackage(db *gorm.DB) *MainEntryPackage {
 	entry.initTaskModule(db)
 	entry.initFeedModule(db)
 	entry.initExternalAPIModule(db)
+	entry.initResponseMappingModule(db)
 	entry.initArticleModule(db)
 	entry.initLayoutModule(db)
 	entry.initLayoutComponentModule(db)
@@ -43,6 +74,12 @@ func NewMainEntryPackage(db *gorm.DB) *MainEntryPackage {
 	entry.initFeedArticleModule(db)
 	entry.initBookModule(db)
 	entry.initGoogleBookModule(db)
+	entry.initBookImportModule(db)
+	entry.initSearchModule(db)
+	entry.initMediaModule(db)
+	entry.initPublicModule(db)
+	entry.initExportModule(db)
+	entry.initSecretModule()
 
 	return entry
 }
\ No newline at end of file
@@ -0,0 +1,63 @@
+package main_entry_module
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	"gorm.io/gorm"
+
+	"go-react-app/controller"
+	"go-react-app/repository"
+	"go-react-app/usecase"
+	"go-react-app/utils/storage"
+	"go-react-app/validator"
+)
+
+const (
+	defaultMediaDir           = "./uploads"
+	defaultMediaBaseURL       = "/uploads"
+	defaultMediaMaxUploadSize = 10 << 20  // 10MB
+	defaultMediaUserQuota     = 500 << 20 // 500MB
+)
+
+// initMediaModule はメディアライブラリ関連のモジュールを初期化します
+// 保存先や上限値は環境変数 MEDIA_DIR, MEDIA_BASE_URL, MEDIA_MAX_UPLOAD_SIZE, MEDIA_USER_QUOTA で変更できます
+func (m *MainEntryPackage) initMediaModule(db *gorm.DB) {
+	cfg := usecase.MediaConfig{
+		MaxUploadSize: envInt64("MEDIA_MAX_UPLOAD_SIZE", defaultMediaMaxUploadSize),
+		UserQuota:     envInt64("MEDIA_USER_QUOTA", defaultMediaUserQuota),
+	}
+	mediaStorage := newMediaStorage()
+	mediaValidator := validator.NewMediaValidator(cfg.MaxUploadSize)
+	mediaRepository := repository.NewMediaRepository(db)
+	mediaUsecase := usecase.NewMediaUsecase(mediaRepository, mediaValidator, mediaStorage, cfg)
+	m.MediaController = controller.NewMediaController(mediaUsecase, cfg.MaxUploadSize)
+}
+
+// newMediaStorage はアップロードされた画像の保存先を作成します
+func newMediaStorage() storage.IStorage {
+	return storage.NewLocalStorage(envString("MEDIA_DIR", defaultMediaDir), envString("MEDIA_BASE_URL", defaultMediaBaseURL))
+}
+
+// envString は環境変数の値を返し、未設定の場合は既定値を返します
+func envString(key string, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// envInt64 は環境変数の値を整数として返し、未設定または不正な場合は既定値を返します
+func envInt64(key string, defaultValue int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed <= 0 {
+		log.Printf("環境変数%sの値が不正なため既定値を使用します: %s", key, value)
+		return defaultValue
+	}
+	return parsed
+}
@@ -0,0 +1,17 @@
+package main_entry_module
+
+import (
+	"gorm.io/gorm"
+
+	"go-react-app/controller"
+	"go-react-app/repository"
+	"go-react-app/usecase"
+)
+
+// initPublicModule は認証不要の公開ページ（フィードなど）関連のモジュールを初期化します
+func (m *MainEntryPackage) initPublicModule(db *gorm.DB) {
+	userRepository := repository.NewUserRepository(db)
+	articleRepository := repository.NewArticleRepository(db)
+	publicUsecase := usecase.NewPublicUsecase(userRepository, articleRepository, m.markdownRenderer, newLayoutAssignmentUsecase(db))
+	m.PublicController = controller.NewPublicController(publicUsecase)
+}
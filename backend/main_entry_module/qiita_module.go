@@ -7,7 +7,7 @@ import (
 )
 
 func (m *MainEntryPackage) initQiitaModule() {
-	qiitaRepository := repository.NewQiitaRepository()
+	qiitaRepository := repository.NewQiitaRepository(m.secrets)
 	qiitaUsecase := usecase.NewQiitaUsecase(qiitaRepository)
 	m.QiitaController = controller.NewQiitaController(qiitaUsecase)
 }
@@ -0,0 +1,24 @@
+package main_entry_module
+
+import (
+	"gorm.io/gorm"
+
+	"go-react-app/controller"
+	"go-react-app/repository"
+	"go-react-app/usecase"
+	"go-react-app/validator"
+)
+
+func (m *MainEntryPackage) initResponseMappingModule(db *gorm.DB) {
+	m.ResponseMappingController = controller.NewResponseMappingController(newResponseMappingUsecase(db, m.secrets))
+}
+
+// newResponseMappingUsecase は外部APIの応答をカードに変換するユースケースを作成します
+func newResponseMappingUsecase(db *gorm.DB, secrets repository.ISecretRepository) usecase.IResponseMappingUsecase {
+	return usecase.NewResponseMappingUsecase(
+		repository.NewResponseMappingRepository(db),
+		repository.NewExternalAPIRepository(db),
+		newExternalAPIUsecase(db, secrets),
+		validator.NewResponseMappingValidator(),
+	)
+}
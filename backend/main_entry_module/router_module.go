@@ -21,8 +21,18 @@ func (m *MainEntryPackage) InitRouter() *echo.Echo {
 		m.FeedArticleController,
 		m.LayoutController,
 		m.LayoutComponentController,
+		m.LayoutSnapshotController,
+		m.LayoutTemplateController,
+		m.LayoutAssignmentController,
 		m.BookController,
 		m.GoogleBookController,
+		m.BookImportController,
+		m.SearchController,
+		m.MediaController,
+		m.PublicController,
+		m.ExportController,
+		m.SecretController,
+		m.ResponseMappingController,
 	)
 	
 	// Swaggerのエンドポイントを追加
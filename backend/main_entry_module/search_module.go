@@ -0,0 +1,20 @@
+package main_entry_module
+
+import (
+	"gorm.io/gorm"
+
+	"go-react-app/controller"
+	"go-react-app/repository"
+	"go-react-app/usecase"
+	"go-react-app/validator"
+)
+
+func (m *MainEntryPackage) initSearchModule(db *gorm.DB) {
+	searchValidator := validator.NewSearchValidator()
+	searchRepository := repository.NewSearchRepository(db)
+	articleRepository := repository.NewArticleRepository(db)
+	bookRepository := repository.NewBookRepository(db)
+	feedArticleRepository := repository.NewFeedArticleRepository(repository.NewFeedRepository(db))
+	searchUsecase := usecase.NewSearchUsecase(searchRepository, articleRepository, bookRepository, feedArticleRepository, searchValidator)
+	m.SearchController = controller.NewSearchController(searchUsecase)
+}
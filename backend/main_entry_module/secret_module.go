@@ -0,0 +1,41 @@
+package main_entry_module
+
+import (
+	"log"
+	"os"
+
+	"gorm.io/gorm"
+
+	"go-react-app/controller"
+	"go-react-app/repository"
+	"go-react-app/usecase"
+	"go-react-app/utils/secretbox"
+	"go-react-app/validator"
+)
+
+// initSecretModule は秘密情報関連のモジュールを初期化します
+func (m *MainEntryPackage) initSecretModule() {
+	secretUsecase := usecase.NewSecretUsecase(m.secrets, validator.NewSecretValidator())
+	m.SecretController = controller.NewSecretController(secretUsecase)
+}
+
+// newSecretRepository は環境変数SECRETS_MASTER_KEYSのマスターキーで秘密情報を暗号化するリポジトリを作成します
+// 未設定の場合は秘密情報を保存できず、連携サービスの認証情報は環境変数のみから取得する
+func newSecretRepository(db *gorm.DB) repository.ISecretRepository {
+	return repository.NewSecretRepository(db, loadKeyring())
+}
+
+// loadKeyring は環境変数SECRETS_MASTER_KEYSからマスターキーのキーリングを読み込みます
+// 設定が不正な場合は保存済みの秘密情報を復号できなくなるため起動を中止する
+func loadKeyring() *secretbox.Keyring {
+	spec := os.Getenv("SECRETS_MASTER_KEYS")
+	if spec == "" {
+		log.Println("環境変数SECRETS_MASTER_KEYSが設定されていないため、秘密情報を保存できません")
+		return nil
+	}
+	keyring, err := secretbox.ParseKeyring(spec)
+	if err != nil {
+		log.Fatalf("環境変数SECRETS_MASTER_KEYSが不正です: %v", err)
+	}
+	return keyring
+}
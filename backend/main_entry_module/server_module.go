@@ -29,6 +29,9 @@ func (m *MainEntryPackage) StartServer(port ...string) error {
 		log.Printf("Swagger UI available at http://localhost:%s/swagger/index.html", serverPort)
 	}
 	
+	// 外部APIのヘルスチェックを開始
+	m.startExternalAPIHealthChecker()
+	
 	// サーバー起動
 	return e.Start(fmt.Sprintf(":%s", serverPort))
 }
@@ -4,12 +4,20 @@ import (
 	"fmt"
 	"go-react-app/db"
 	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/utils/secretbox"
+	"log"
+	"os"
 )
 
 func main() {
 	dbConn := db.NewDB()
 	defer fmt.Println("Successfully Migrated")
 	defer db.CloseDB(dbConn)
+	// 書籍のISBNの一意制約を作成できるよう、先に既存のISBNを正規化する
+	if err := repository.NormalizeBookISBNs(dbConn); err != nil {
+		log.Fatalln(err)
+	}
 	dbConn.AutoMigrate(
 		&model.User{},
 		&model.Task{},
@@ -18,6 +26,37 @@ func main() {
 		&model.Article{},
 		&model.Layout{},
 		&model.LayoutComponent{},
+		&model.LayoutSnapshot{},
+		&model.LayoutTemplate{},
+		&model.LayoutAssignment{},
 		&model.Book{},
+		&model.SearchDocument{},
+		&model.Media{},
+		&model.ExportJob{},
+		&model.Secret{},
+		&model.ExternalAPIHealthCheck{},
+		&model.ResponseMapping{},
+		&model.BookImportJob{},
 	)
-}
\ No newline at end of file
+	if err := repository.SetupSearchIndex(dbConn); err != nil {
+		log.Fatalln(err)
+	}
+	// 平文で保存されていた外部APIの認証情報を秘密情報に移す（秘密情報のテーブルを作成した後に実行する）
+	if err := repository.MigrateExternalAPICredentials(dbConn, repository.NewSecretRepository(dbConn, loadKeyring())); err != nil {
+		log.Fatalf("外部APIの認証情報を秘密情報に移せませんでした。環境変数SECRETS_MASTER_KEYSを設定して再実行してください: %v", err)
+	}
+}
+
+// loadKeyring は環境変数SECRETS_MASTER_KEYSからマスターキーのキーリングを読み込みます
+// 未設定の場合はnilを返す（移す認証情報がなければマスターキーは不要）
+func loadKeyring() *secretbox.Keyring {
+	spec := os.Getenv("SECRETS_MASTER_KEYS")
+	if spec == "" {
+		return nil
+	}
+	keyring, err := secretbox.ParseKeyring(spec)
+	if err != nil {
+		log.Fatalf("環境変数SECRETS_MASTER_KEYSが不正です: %v", err)
+	}
+	return keyring
+}
@@ -1,59 +1,114 @@
 package model
 
-import "time"
+import (
+	"strconv"
+	"time"
+)
 
 // データベースモデル
 type Article struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Title     string    `json:"title" gorm:"not null"`
-	Content   string    `json:"content" gorm:"type:text"`
-	Published bool      `json:"published" gorm:"default:false"`
-	Tags      string    `json:"tags"`
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
-	User      User      `json:"-" gorm:"foreignKey:UserId;constraint:OnDelete:CASCADE"`
-	UserId    uint      `json:"user_id" gorm:"not null"`
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	Title           string    `json:"title" gorm:"not null"`
+	Content         string    `json:"content" gorm:"type:text"`
+	Published       bool      `json:"published" gorm:"default:false"`
+	Tags            string    `json:"tags"`
+	MetaDescription string    `json:"meta_description" gorm:"type:text"`
+	OGImage         string    `json:"og_image"`
+	CanonicalURL    string    `json:"canonical_url"`
+	NoIndex         bool      `json:"no_index" gorm:"default:false"`
+	LayoutId        *uint     `json:"layout_id" gorm:"index"` // 記事のページに使うレイアウト（nullの場合はタグ・ユーザーの既定の指定に従う）
+	Layout          *Layout   `json:"-" gorm:"foreignKey:LayoutId;constraint:OnDelete:SET NULL"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	User            User      `json:"-" gorm:"foreignKey:UserId;constraint:OnDelete:CASCADE"`
+	UserId          uint      `json:"user_id" gorm:"not null"`
 }
 
 // ArticleRequest 記事作成・更新リクエスト
 type ArticleRequest struct {
-	Title     string `json:"title" validate:"required" example:"Goプログラミングの基礎"`
-	Content   string `json:"content" example:"Goは静的型付け言語です..."`
-	Published bool   `json:"published" example:"true"`
-	Tags      string `json:"tags" example:"Go,プログラミング,チュートリアル"`
-	UserId    uint   `json:"-"` // クライアントからは送信されず、JWTから取得
+	Title           string `json:"title" validate:"required" example:"Goプログラミングの基礎"`
+	Content         string `json:"content" example:"Goは静的型付け言語です..."`
+	Published       bool   `json:"published" example:"true"`
+	Tags            string `json:"tags" example:"Go,プログラミング,チュートリアル"`
+	MetaDescription string `json:"meta_description" example:"Goの基本的な文法を解説します"`
+	OGImage         string `json:"og_image" example:"https://example.com/uploads/1/cover.png"`
+	CanonicalURL    string `json:"canonical_url" example:"https://example.com/gopher/articles/1"`
+	NoIndex         bool   `json:"no_index" example:"false"`
+	LayoutId        *uint  `json:"layout_id,omitempty" example:"1"` // 記事のページに使うレイアウト（省略した場合はタグ・ユーザーの既定の指定に従う）
+	UserId          uint   `json:"-"`                               // クライアントからは送信されず、JWTから取得
 }
 
 // ArticleResponse 記事のレスポンス
 type ArticleResponse struct {
-	ID        uint      `json:"id" example:"1"`
-	Title     string    `json:"title" example:"Goプログラミングの基礎"`
-	Content   string    `json:"content" example:"Goは静的型付け言語です..."`
-	Published bool      `json:"published" example:"true"`
-	Tags      string    `json:"tags" example:"Go,プログラミング,チュートリアル"`
-	CreatedAt time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
-	UpdatedAt time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+	ID              uint      `json:"id" example:"1"`
+	Title           string    `json:"title" example:"Goプログラミングの基礎"`
+	Content         string    `json:"content" example:"Goは静的型付け言語です..."`
+	ContentHTML     string    `json:"content_html,omitempty" example:"<p>Goは静的型付け言語です...</p>"` // format=html指定時のみ設定される、サニタイズ済みのHTML
+	Published       bool      `json:"published" example:"true"`
+	Tags            string    `json:"tags" example:"Go,プログラミング,チュートリアル"`
+	MetaDescription string    `json:"meta_description" example:"Goの基本的な文法を解説します"`
+	OGImage         string    `json:"og_image" example:"https://example.com/uploads/1/cover.png"`
+	CanonicalURL    string    `json:"canonical_url" example:"https://example.com/gopher/articles/1"`
+	NoIndex         bool      `json:"no_index" example:"false"`
+	LayoutId        *uint     `json:"layout_id,omitempty" example:"1"`
+	SEOWarnings     []string  `json:"seo_warnings,omitempty" example:"OG画像が設定されていません"` // 公開する記事で不足しているSEO項目の警告
+	CreatedAt       time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt       time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
 }
+
+// ArticleSEOResponse 記事のSEOメタデータのレスポンス
+// メタディスクリプションが未設定の場合は本文の抜粋が設定されます
+type ArticleSEOResponse struct {
+	Title           string   `json:"title" example:"Goプログラミングの基礎"`
+	MetaDescription string   `json:"meta_description" example:"Goの基本的な文法を解説します"`
+	OGImage         string   `json:"og_image" example:"https://example.com/uploads/1/cover.png"`
+	CanonicalURL    string   `json:"canonical_url" example:"https://example.com/gopher/articles/1"`
+	NoIndex         bool     `json:"no_index" example:"false"`
+	Warnings        []string `json:"warnings" example:"OG画像が設定されていません"`
+}
+
 // ArticleからArticleResponseへの変換メソッド
 func (a *Article) ToResponse() ArticleResponse {
 	return ArticleResponse{
-		ID:        a.ID,
-		Title:     a.Title,
-		Content:   a.Content,
-		Published: a.Published,
-		Tags:      a.Tags,
-		CreatedAt: a.CreatedAt,
-		UpdatedAt: a.UpdatedAt,
+		ID:              a.ID,
+		Title:           a.Title,
+		Content:         a.Content,
+		Published:       a.Published,
+		Tags:            a.Tags,
+		MetaDescription: a.MetaDescription,
+		OGImage:         a.OGImage,
+		CanonicalURL:    a.CanonicalURL,
+		NoIndex:         a.NoIndex,
+		LayoutId:        a.LayoutId,
+		CreatedAt:       a.CreatedAt,
+		UpdatedAt:       a.UpdatedAt,
 	}
 }
 
 // ArticleRequestからArticleへの変換メソッド
 func (ar *ArticleRequest) ToModel() Article {
 	return Article{
-		Title:     ar.Title,
-		Content:   ar.Content,
-		Published: ar.Published,
-		Tags:      ar.Tags,
-		UserId:    ar.UserId,
+		Title:           ar.Title,
+		Content:         ar.Content,
+		Published:       ar.Published,
+		Tags:            ar.Tags,
+		MetaDescription: ar.MetaDescription,
+		OGImage:         ar.OGImage,
+		CanonicalURL:    ar.CanonicalURL,
+		NoIndex:         ar.NoIndex,
+		LayoutId:        ar.LayoutId,
+		UserId:          ar.UserId,
+	}
+}
+
+// ToSearchDocument 記事を全文検索インデックスのドキュメントに変換します
+func (a *Article) ToSearchDocument() SearchDocument {
+	return SearchDocument{
+		SourceType: SearchSourceArticle,
+		SourceID:   strconv.FormatUint(uint64(a.ID), 10),
+		Title:      a.Title,
+		Body:       a.Content,
+		Tags:       a.Tags,
+		UserId:     a.UserId,
 	}
 }
@@ -1,75 +1,160 @@
 package model
 
-import "time"
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	BookTitleMaxLength     = 200
+	BookAuthorMaxLength    = 100
+	BookPublisherMaxLength = 100
+)
 
+// 読書の状態
 const (
-	BookTitleMaxLength = 200
-	BookAuthorMaxLength = 100
+	BookStatusWantToRead = "want-to-read" // 読みたい
+	BookStatusReading    = "reading"      // 読書中
+	BookStatusFinished   = "finished"     // 読了
+	BookStatusAbandoned  = "abandoned"    // 中断
 )
 
+// BookStatuses 読書の状態の一覧
+var BookStatuses = []string{BookStatusWantToRead, BookStatusReading, BookStatusFinished, BookStatusAbandoned}
+
+// BookDateLayout 読書を開始・終了した日の形式
+const BookDateLayout = "2006-01-02"
+
 // Book データベースの書籍モデル
 type Book struct {
-	ID          uint      `json:"id" gorm:"primaryKey" example:"1"`
-	Title       string    `json:"title" gorm:"not null" example:"Go言語による並行処理"`
-	Author      string    `json:"author" gorm:"not null" example:"Katherine Cox-Buday"`
-	Description string    `json:"description" example:"Go言語の並行処理について解説した書籍"`
-	ISBN        string    `json:"isbn" gorm:"index" example:"9784873118468"`
-	ImageURL    string    `json:"image_url" example:"http://books.google.com/books/content?id=..."`
-	PublishedDate string  `json:"published_date" example:"2018-06-15"`
-	CreatedAt   time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
-	UpdatedAt   time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
-	User        User      `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
-	UserId      uint      `json:"user_id" gorm:"not null" example:"1"`
+	ID            uint   `json:"id" gorm:"primaryKey" example:"1"`
+	Title         string `json:"title" gorm:"not null" example:"Go言語による並行処理"`
+	Author        string `json:"author" gorm:"not null" example:"Katherine Cox-Buday"`
+	Description   string `json:"description" example:"Go言語の並行処理について解説した書籍"`
+	ISBN          string `json:"isbn" gorm:"index;uniqueIndex:idx_books_user_isbn,priority:2,where:isbn <> ''" example:"9784873118468"` // ハイフンを除いたISBN-13。ユーザーごとに一意
+	ImageURL      string `json:"image_url" example:"http://books.google.com/books/content?id=..."`
+	PublishedDate string `json:"published_date" example:"2018-06-15"`
+	Publisher     string `json:"publisher" example:"オライリー・ジャパン"`
+	// 読書記録（書籍の情報の更新では変更せず、PUT /books/:bookId/readingで更新する）
+	Status          string     `json:"status" gorm:"size:20;not null;default:'want-to-read';index"`
+	StartedAt       *time.Time `json:"started_at"`
+	FinishedAt      *time.Time `json:"finished_at" gorm:"index"`
+	PageCount       int        `json:"page_count" gorm:"not null;default:0"`
+	CurrentPage     int        `json:"current_page" gorm:"not null;default:0"`
+	ProgressPercent int        `json:"progress_percent" gorm:"not null;default:0"`
+	Rating          int        `json:"rating" gorm:"not null;default:0"`            // 1〜5。0は未評価
+	Notes           string     `json:"notes" gorm:"type:text"`                      // 本人のみが参照できるメモ
+	Highlights      []string   `json:"highlights" gorm:"serializer:json;type:text"` // 本人のみが参照できる引用・ハイライト
+	CreatedAt       time.Time  `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt       time.Time  `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+	User            User       `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	UserId          uint       `json:"user_id" gorm:"not null;uniqueIndex:idx_books_user_isbn,priority:1,where:isbn <> ''" example:"1"`
 }
 
 // BookRequest 書籍作成・更新リクエスト
 type BookRequest struct {
-	Title        string `json:"title" validate:"required,max=200" example:"Go言語による並行処理"`
-	Author       string `json:"author" validate:"required,max=100" example:"Katherine Cox-Buday"`
-	Description  string `json:"description" example:"Go言語の並行処理について解説した書籍"`
-	ISBN         string `json:"isbn" example:"9784873118468"`
-	ImageURL     string `json:"image_url" example:"http://books.google.com/books/content?id=..."`
+	Title         string `json:"title" validate:"required,max=200" example:"Go言語による並行処理"`
+	Author        string `json:"author" validate:"required,max=100" example:"Katherine Cox-Buday"`
+	Description   string `json:"description" example:"Go言語の並行処理について解説した書籍"`
+	ISBN          string `json:"isbn" example:"978-4-87311-846-8"` // ISBN-10またはISBN-13（ハイフン可）。ISBN-13に正規化して保存する
+	ImageURL      string `json:"image_url" example:"http://books.google.com/books/content?id=..."`
 	PublishedDate string `json:"published_date" example:"2018-06-15"`
-	UserId       uint   `json:"-"` // クライアントからは送信されず、JWTから取得
+	Publisher     string `json:"publisher" example:"オライリー・ジャパン"`
+	PageCount     int    `json:"page_count" example:"238"` // 登録時のみ使う。登録後はPUT /books/:bookId/readingで更新する
+	UserId        uint   `json:"-"`                        // クライアントからは送信されず、JWTから取得
 }
 
 // BookResponse 書籍のレスポンス
 type BookResponse struct {
-	ID           uint      `json:"id" example:"1"`
-	Title        string    `json:"title" example:"Go言語による並行処理"`
-	Author       string    `json:"author" example:"Katherine Cox-Buday"`
-	Description  string    `json:"description" example:"Go言語の並行処理について解説した書籍"`
-	ISBN         string    `json:"isbn" example:"9784873118468"`
-	ImageURL     string    `json:"image_url" example:"http://books.google.com/books/content?id=..."`
-	PublishedDate string    `json:"published_date" example:"2018-06-15"`
-	CreatedAt    time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
-	UpdatedAt    time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+	ID              uint      `json:"id" example:"1"`
+	Title           string    `json:"title" example:"Go言語による並行処理"`
+	Author          string    `json:"author" example:"Katherine Cox-Buday"`
+	Description     string    `json:"description" example:"Go言語の並行処理について解説した書籍"`
+	ISBN            string    `json:"isbn" example:"9784873118468"`
+	ImageURL        string    `json:"image_url" example:"http://books.google.com/books/content?id=..."`
+	PublishedDate   string    `json:"published_date" example:"2018-06-15"`
+	Publisher       string    `json:"publisher" example:"オライリー・ジャパン"`
+	Status          string    `json:"status" example:"reading"`
+	StartedAt       string    `json:"started_at,omitempty" example:"2024-01-15"`
+	FinishedAt      string    `json:"finished_at,omitempty" example:"2024-02-01"`
+	PageCount       int       `json:"page_count" example:"320"`
+	CurrentPage     int       `json:"current_page" example:"120"`
+	ProgressPercent int       `json:"progress_percent" example:"37"`
+	Rating          int       `json:"rating" example:"4"`
+	Notes           string    `json:"notes,omitempty" example:"第3章のパイプラインの例が分かりやすい"`
+	Highlights      []string  `json:"highlights"`
+	CreatedAt       time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt       time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
 }
 
 // ToResponse BookからBookResponseへの変換メソッド
 func (b *Book) ToResponse() BookResponse {
+	highlights := b.Highlights
+	if highlights == nil {
+		highlights = []string{}
+	}
 	return BookResponse{
-		ID:           b.ID,
-		Title:        b.Title,
-		Author:       b.Author,
-		Description:  b.Description,
-		ISBN:         b.ISBN,
-		ImageURL:     b.ImageURL,
-		PublishedDate: b.PublishedDate,
-		CreatedAt:    b.CreatedAt,
-		UpdatedAt:    b.UpdatedAt,
+		ID:              b.ID,
+		Title:           b.Title,
+		Author:          b.Author,
+		Description:     b.Description,
+		ISBN:            b.ISBN,
+		ImageURL:        b.ImageURL,
+		PublishedDate:   b.PublishedDate,
+		Publisher:       b.Publisher,
+		Status:          b.Status,
+		StartedAt:       formatBookDate(b.StartedAt),
+		FinishedAt:      formatBookDate(b.FinishedAt),
+		PageCount:       b.PageCount,
+		CurrentPage:     b.CurrentPage,
+		ProgressPercent: b.ProgressPercent,
+		Rating:          b.Rating,
+		Notes:           b.Notes,
+		Highlights:      highlights,
+		CreatedAt:       b.CreatedAt,
+		UpdatedAt:       b.UpdatedAt,
 	}
 }
 
+// formatBookDate は読書を開始・終了した日をYYYY-MM-DDの形式にします。日付がない場合は空文字列
+func formatBookDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(BookDateLayout)
+}
+
 // ToModel BookRequestからBookへの変換メソッド
+// 読書記録は含まないため、作成した書籍は「読みたい」の状態になる
 func (br *BookRequest) ToModel() Book {
 	return Book{
-		Title:        br.Title,
-		Author:       br.Author,
-		Description:  br.Description,
-		ISBN:         br.ISBN,
-		ImageURL:     br.ImageURL,
+		Title:         br.Title,
+		Author:        br.Author,
+		Description:   br.Description,
+		ISBN:          br.ISBN,
+		ImageURL:      br.ImageURL,
 		PublishedDate: br.PublishedDate,
-		UserId:       br.UserId,
+		Publisher:     br.Publisher,
+		PageCount:     br.PageCount,
+		Status:        BookStatusWantToRead,
+		UserId:        br.UserId,
+	}
+}
+
+// ToSearchDocument 書籍を全文検索インデックスのドキュメントに変換します
+func (b *Book) ToSearchDocument() SearchDocument {
+	return SearchDocument{
+		SourceType: SearchSourceBook,
+		SourceID:   strconv.FormatUint(uint64(b.ID), 10),
+		Title:      b.Title,
+		Body:       b.Description,
+		Tags:       b.Author, // 著者はタグと同じ重みで検索する
+		UserId:     b.UserId,
 	}
 }
+
+// DuplicateBookResponse 同じISBNの書籍が登録済みの場合のレスポンス
+type DuplicateBookResponse struct {
+	Error string       `json:"error" example:"book with the same ISBN already exists"`
+	Book  BookResponse `json:"book"` // 登録済みの書籍
+}
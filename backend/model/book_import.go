@@ -0,0 +1,147 @@
+package model
+
+import "time"
+
+// 書籍の一括登録ジョブの状態
+const (
+	BookImportStatusPending   = "pending"
+	BookImportStatusRunning   = "running"
+	BookImportStatusCompleted = "completed"
+	BookImportStatusFailed    = "failed" // サーバーの再起動や予期しないエラーで中断した
+)
+
+// 書籍の一括登録の入力の種類
+const (
+	BookImportSourceISBN = "isbn" // ISBNの一覧
+	BookImportSourceCSV  = "csv"  // 読書管理サービスから書き出したCSV
+)
+
+// 1行ごとの一括登録の結果
+const (
+	BookImportOutcomeImported  = "imported"  // 登録した
+	BookImportOutcomeDuplicate = "duplicate" // 同じ書籍が登録済み
+	BookImportOutcomeNotFound  = "not_found" // 書籍の情報が見つからない
+	BookImportOutcomeFailed    = "failed"    // ISBNが不正、または登録に失敗した
+)
+
+// BookImportLookupCSV CSVの値で登録した場合の書籍の情報の取得元
+const BookImportLookupCSV = "csv"
+
+// 一括登録の入力の上限
+const (
+	BookImportMaxRows    = 500
+	BookImportMaxCSVSize = 2 << 20 // 2MB
+)
+
+// BookImportJob 書籍の一括登録ジョブのデータベースモデル
+type BookImportJob struct {
+	ID         uint            `json:"id" gorm:"primaryKey" example:"1"`
+	Status     string          `json:"status" gorm:"not null;default:pending" example:"completed"`
+	Source     string          `json:"source" gorm:"size:10;not null" example:"csv"`
+	Format     string          `json:"format" gorm:"size:20" example:"goodreads"` // CSVの形式
+	Total      int             `json:"total" example:"30"`
+	Processed  int             `json:"processed" example:"30"`
+	Imported   int             `json:"imported" example:"25"`
+	Duplicates int             `json:"duplicates" example:"3"`
+	NotFound   int             `json:"not_found" example:"1"`
+	Failed     int             `json:"failed" example:"1"`
+	Error      string          `json:"error" example:""`
+	Rows       []BookImportRow `json:"rows" gorm:"serializer:json;type:text"`
+	StartedAt  *time.Time      `json:"started_at"`
+	FinishedAt *time.Time      `json:"finished_at"`
+	CreatedAt  time.Time       `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt  time.Time       `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+	User       User            `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	UserId     uint            `json:"user_id" gorm:"not null;index" example:"1"`
+}
+
+// BookImportRow 一括登録の1行の結果
+type BookImportRow struct {
+	Row     int    `json:"row" example:"1"`                        // ISBNの一覧の順番、またはCSVのデータ行の番号（1始まり）
+	Input   string `json:"input" example:"4-87311-846-8"`          // 入力されたISBN
+	ISBN    string `json:"isbn,omitempty" example:"9784873118468"` // 正規化したISBN-13
+	Title   string `json:"title,omitempty" example:"Go言語による並行処理"`
+	Outcome string `json:"outcome" example:"imported"`
+	Lookup  string `json:"lookup,omitempty" example:"openbd,google"` // 登録した書籍の情報の取得元（プロバイダーの名前のカンマ区切り、またはcsv）
+	BookId  uint   `json:"book_id,omitempty" example:"1"`            // 登録した書籍、または登録済みの書籍のID
+	Error   string `json:"error,omitempty" example:""`
+}
+
+// BookImportEntry 一括登録する1冊分の入力。CSVの場合は書籍の情報が見つからない場合に使う値を含む
+type BookImportEntry struct {
+	Row        int
+	ISBN       string
+	Title      string
+	Author     string
+	Status     string // 読書の状態。空の場合は読みたい
+	Rating     int    // 1〜5。0は未評価
+	FinishedAt string // YYYY-MM-DD
+}
+
+// BookISBNImportRequest ISBNの一覧による一括登録のリクエスト
+type BookISBNImportRequest struct {
+	ISBNs  []string `json:"isbns" example:"9784873118468,4-87311-846-8"` // ISBN-10またはISBN-13（ハイフン可）
+	UserId uint     `json:"-"`
+}
+
+// BookImportJobResponse 書籍の一括登録ジョブのレスポンス
+type BookImportJobResponse struct {
+	ID         uint            `json:"id" example:"1"`
+	Status     string          `json:"status" example:"completed"`
+	Source     string          `json:"source" example:"csv"`
+	Format     string          `json:"format,omitempty" example:"goodreads"`
+	Total      int             `json:"total" example:"30"`
+	Processed  int             `json:"processed" example:"30"`
+	Imported   int             `json:"imported" example:"25"`
+	Duplicates int             `json:"duplicates" example:"3"`
+	NotFound   int             `json:"not_found" example:"1"`
+	Failed     int             `json:"failed" example:"1"`
+	Error      string          `json:"error,omitempty" example:""`
+	Rows       []BookImportRow `json:"rows"`
+	StartedAt  *time.Time      `json:"started_at,omitempty"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+	CreatedAt  time.Time       `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt  time.Time       `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// ToResponse BookImportJobからBookImportJobResponseへの変換メソッド
+func (j *BookImportJob) ToResponse() BookImportJobResponse {
+	rows := j.Rows
+	if rows == nil {
+		rows = []BookImportRow{}
+	}
+	return BookImportJobResponse{
+		ID:         j.ID,
+		Status:     j.Status,
+		Source:     j.Source,
+		Format:     j.Format,
+		Total:      j.Total,
+		Processed:  j.Processed,
+		Imported:   j.Imported,
+		Duplicates: j.Duplicates,
+		NotFound:   j.NotFound,
+		Failed:     j.Failed,
+		Error:      j.Error,
+		Rows:       rows,
+		StartedAt:  j.StartedAt,
+		FinishedAt: j.FinishedAt,
+		CreatedAt:  j.CreatedAt,
+		UpdatedAt:  j.UpdatedAt,
+	}
+}
+
+// AddRow は1行の結果を記録し、結果ごとの件数を更新します
+func (j *BookImportJob) AddRow(row BookImportRow) {
+	j.Rows = append(j.Rows, row)
+	j.Processed++
+	switch row.Outcome {
+	case BookImportOutcomeImported:
+		j.Imported++
+	case BookImportOutcomeDuplicate:
+		j.Duplicates++
+	case BookImportOutcomeNotFound:
+		j.NotFound++
+	default:
+		j.Failed++
+	}
+}
@@ -0,0 +1,74 @@
+package model
+
+import "strings"
+
+// 書籍の情報の取得元（プロバイダー）の名前
+const (
+	BookMetadataProviderGoogle = "google" // Google Books API
+	BookMetadataProviderOpenBD = "openbd" // openBD（日本の出版社の書誌情報）
+	BookMetadataProviderNDL    = "ndl"    // 国立国会図書館サーチ
+)
+
+// BookMetadata ISBNで取得した書籍の情報
+type BookMetadata struct {
+	ISBN          string   `json:"isbn" example:"9784873118468"`
+	Title         string   `json:"title" example:"Go言語による並行処理"`
+	Authors       []string `json:"authors" example:"Katherine Cox-Buday"`
+	Publisher     string   `json:"publisher" example:"オライリー・ジャパン"`
+	PublishedDate string   `json:"published_date" example:"2018-10"`
+	Description   string   `json:"description" example:"Go言語の並行処理について解説した書籍"`
+	ImageURL      string   `json:"image_url" example:"https://cover.openbd.jp/9784873118468.jpg"`
+	PageCount     int      `json:"page_count" example:"238"`
+	Sources       []string `json:"sources" example:"openbd,google"` // 値を使ったプロバイダー
+}
+
+// Merge は空の項目をotherの値で補います。補った項目がある場合はotherの取得元をSourcesに加える
+func (m *BookMetadata) Merge(other BookMetadata) {
+	filled := false
+	fill := func(field *string, value string) {
+		if *field == "" && value != "" {
+			*field = value
+			filled = true
+		}
+	}
+	fill(&m.ISBN, other.ISBN)
+	fill(&m.Title, other.Title)
+	fill(&m.Publisher, other.Publisher)
+	fill(&m.PublishedDate, other.PublishedDate)
+	fill(&m.Description, other.Description)
+	fill(&m.ImageURL, other.ImageURL)
+	if len(m.Authors) == 0 && len(other.Authors) > 0 {
+		m.Authors = other.Authors
+		filled = true
+	}
+	if m.PageCount == 0 && other.PageCount > 0 {
+		m.PageCount = other.PageCount
+		filled = true
+	}
+	if filled {
+		m.Sources = append(m.Sources, other.Sources...)
+	}
+}
+
+// ToBookRequest BookMetadataからBookRequestへの変換メソッド。著者は先頭の1名のみ
+func (m *BookMetadata) ToBookRequest() BookRequest {
+	author := ""
+	if len(m.Authors) > 0 {
+		author = m.Authors[0]
+	}
+	return BookRequest{
+		Title:         m.Title,
+		Author:        author,
+		Description:   m.Description,
+		ISBN:          m.ISBN,
+		ImageURL:      m.ImageURL,
+		PublishedDate: m.PublishedDate,
+		Publisher:     m.Publisher,
+		PageCount:     m.PageCount,
+	}
+}
+
+// SourceNames は値を使ったプロバイダーをカンマ区切りで返します
+func (m *BookMetadata) SourceNames() string {
+	return strings.Join(m.Sources, ",")
+}
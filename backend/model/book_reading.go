@@ -0,0 +1,45 @@
+package model
+
+// 読書記録の入力の上限
+const (
+	BookNotesMaxLength     = 10000
+	BookHighlightsMaxCount = 100
+	BookHighlightMaxLength = 1000
+	BookRatingMax          = 5
+)
+
+// BookReadingRequest 書籍の読書記録を更新するリクエスト
+type BookReadingRequest struct {
+	Status          string   `json:"status" example:"reading"`
+	StartedAt       string   `json:"started_at" example:"2024-01-15"` // YYYY-MM-DD。空で読書中・読了にした場合は当日
+	FinishedAt      string   `json:"finished_at" example:""`          // YYYY-MM-DD。読了・中断のみ。空で読了・中断にした場合は当日
+	PageCount       int      `json:"page_count" example:"320"`
+	CurrentPage     int      `json:"current_page" example:"120"`
+	ProgressPercent int      `json:"progress_percent" example:"0"` // ページ数が分からない場合の進捗。page_countとcurrent_pageがある場合は計算した値を使う
+	Rating          int      `json:"rating" example:"4"`           // 1〜5。0は未評価
+	Notes           string   `json:"notes" example:"第3章のパイプラインの例が分かりやすい"`
+	Highlights      []string `json:"highlights"`
+}
+
+// BookFilter 書籍の一覧の絞り込み条件（ゼロ値の条件は使わない）
+type BookFilter struct {
+	Status    string // 読書の状態
+	MinRating int    // 評価の下限
+	Year      int    // 読了・中断した年
+}
+
+// YearlyReadingStats 1年間の読書の集計（読了・中断した日の年で集計する）
+type YearlyReadingStats struct {
+	Year          int      `json:"year" example:"2024"`
+	Finished      int      `json:"finished" example:"12"`
+	Abandoned     int      `json:"abandoned" example:"2"`
+	PagesRead     int      `json:"pages_read" example:"3600"`                // 読了した本のページ数の合計
+	AverageRating *float64 `json:"average_rating" example:"3.8"`             // 読了した本のうち評価した本の平均。ない場合はnull
+	Months        []int    `json:"months" example:"1,0,2,1,0,1,3,0,1,2,0,1"` // 1月から12月までの読了した冊数
+}
+
+// ReadingStatsResponse 読書の統計
+type ReadingStatsResponse struct {
+	StatusCounts map[string]int       `json:"status_counts"` // 現在の読書の状態ごとの冊数
+	Years        []YearlyReadingStats `json:"years"`         // 新しい年から
+}
@@ -0,0 +1,142 @@
+package model
+
+import "encoding/json"
+
+// 画面幅ごとのコンポーネントの位置とサイズの区分
+// デスクトップの値はLayoutComponentのX・Y・Width・Heightに保存し、
+// タブレット・モバイルは指定された場合のみ上書きとして保存する（未指定の場合は1つ大きい区分の値を引き継ぐ）
+const (
+	BreakpointDesktop = "desktop"
+	BreakpointTablet  = "tablet"
+	BreakpointMobile  = "mobile"
+)
+
+// Breakpoints は大きい順のブレークポイント
+var Breakpoints = []string{BreakpointDesktop, BreakpointTablet, BreakpointMobile}
+
+// BreakpointCanvasWidths はブレークポイントごとのキャンバスの幅の上限（px）
+// ピクセルで指定された位置をグリッドに変換する場合とプレビューに使う
+var BreakpointCanvasWidths = map[string]int{
+	BreakpointTablet: 768,
+	BreakpointMobile: 375,
+}
+
+// Geometry コンポーネントの位置とサイズ
+type Geometry struct {
+	X      int `json:"x" example:"0"`
+	Y      int `json:"y" example:"0"`
+	Width  int `json:"width" example:"12"`
+	Height int `json:"height" example:"2"`
+}
+
+// BreakpointGeometry タブレット・モバイルで上書きする位置とサイズ（nullの場合は1つ大きい区分の値を引き継ぐ）
+type BreakpointGeometry struct {
+	Tablet *Geometry `json:"tablet,omitempty"`
+	Mobile *Geometry `json:"mobile,omitempty"`
+}
+
+// IsEmpty はどのブレークポイントも上書きしていないかを返します
+func (bg BreakpointGeometry) IsEmpty() bool {
+	return bg.Tablet == nil && bg.Mobile == nil
+}
+
+// BreakpointOverrides はタブレット・モバイルで上書きしている位置とサイズを返します
+func (lc *LayoutComponent) BreakpointOverrides() BreakpointGeometry {
+	var overrides BreakpointGeometry
+	if lc.Breakpoints != "" {
+		// 保存時に作成したJSONのため、読めない場合は上書きなしとして扱う
+		_ = json.Unmarshal([]byte(lc.Breakpoints), &overrides)
+	}
+	return overrides
+}
+
+// SetBreakpointOverrides はタブレット・モバイルで上書きする位置とサイズを設定します
+func (lc *LayoutComponent) SetBreakpointOverrides(overrides BreakpointGeometry) {
+	if overrides.IsEmpty() {
+		lc.Breakpoints = ""
+		return
+	}
+	encoded, _ := json.Marshal(overrides)
+	lc.Breakpoints = string(encoded)
+}
+
+// GeometryAt はブレークポイントでの位置とサイズを返します
+// タブレット・モバイルで上書きしていない場合は、1つ大きいブレークポイントの値を引き継ぐ
+func (lc *LayoutComponent) GeometryAt(breakpoint string) Geometry {
+	geometry := Geometry{X: lc.X, Y: lc.Y, Width: lc.Width, Height: lc.Height}
+	if breakpoint == "" || breakpoint == BreakpointDesktop {
+		return geometry
+	}
+	overrides := lc.BreakpointOverrides()
+	for _, name := range Breakpoints[1:] {
+		if override := overrides.At(name); override != nil {
+			geometry = *override
+		}
+		if name == breakpoint {
+			break
+		}
+	}
+	return geometry
+}
+
+// SetGeometry はブレークポイントでの位置とサイズを設定します（デスクトップの場合はX・Y・Width・Heightを更新する）
+func (lc *LayoutComponent) SetGeometry(breakpoint string, geometry Geometry) {
+	if breakpoint == "" || breakpoint == BreakpointDesktop {
+		lc.X, lc.Y, lc.Width, lc.Height = geometry.X, geometry.Y, geometry.Width, geometry.Height
+		return
+	}
+	overrides := lc.BreakpointOverrides()
+	switch breakpoint {
+	case BreakpointTablet:
+		overrides.Tablet = &geometry
+	case BreakpointMobile:
+		overrides.Mobile = &geometry
+	}
+	lc.SetBreakpointOverrides(overrides)
+}
+
+// AtBreakpoint はX・Y・Width・Heightをブレークポイントでの位置とサイズにしたコピーを返します
+func (lc *LayoutComponent) AtBreakpoint(breakpoint string) LayoutComponent {
+	component := *lc
+	geometry := lc.GeometryAt(breakpoint)
+	component.X, component.Y, component.Width, component.Height = geometry.X, geometry.Y, geometry.Width, geometry.Height
+	return component
+}
+
+// AtBreakpoint はコンポーネントの位置とサイズをブレークポイントでの値にしたコピーを返します
+// キャンバスの幅もブレークポイントの上限に合わせる
+func (l *Layout) AtBreakpoint(breakpoint string) Layout {
+	layout := *l
+	if breakpoint == "" || breakpoint == BreakpointDesktop {
+		return layout
+	}
+	if limit, ok := BreakpointCanvasWidths[breakpoint]; ok {
+		layout.CanvasWidth = min(l.GridSettings().CanvasWidth, limit)
+	}
+	layout.Components = make([]LayoutComponent, len(l.Components))
+	for i, component := range l.Components {
+		layout.Components[i] = component.AtBreakpoint(breakpoint)
+	}
+	return layout
+}
+
+// HasBreakpointOverrides はタブレット・モバイルの位置とサイズを上書きしているコンポーネントがあるかを返します
+func (l *Layout) HasBreakpointOverrides() bool {
+	for _, component := range l.Components {
+		if component.Breakpoints != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// At はブレークポイントで上書きしている位置とサイズを返します（上書きしていない場合はnil）
+func (bg BreakpointGeometry) At(breakpoint string) *Geometry {
+	switch breakpoint {
+	case BreakpointTablet:
+		return bg.Tablet
+	case BreakpointMobile:
+		return bg.Mobile
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// コンポーネントに表示するデータの取得元
+const (
+	DataSourceLatestArticles = "latest-articles" // ユーザーの公開済みの記事（タグで絞り込み可）
+	DataSourceFeed           = "feed"            // 登録したフィードの記事
+	DataSourceQiitaTrending  = "qiita-trending"  // Qiitaでいいねの多い記事
+	DataSourceBookshelf      = "bookshelf"       // ユーザーの本棚
+	DataSourceExternalAPI    = "external-api"    // 外部APIの応答を変換の定義でカードにしたもの
+)
+
+// 取得する件数の既定値と上限
+const (
+	DefaultDataLimit = 5
+	MaxDataLimit     = 20
+)
+
+// DataSourceConfig データを取得するコンポーネントのpropertiesのうち、取得元の指定に使う項目
+type DataSourceConfig struct {
+	Limit     int    `json:"limit"`
+	Tag       string `json:"tag"`
+	FeedId    uint   `json:"feedId"`
+	MappingId uint   `json:"mappingId"`
+}
+
+// ComponentDataItem データを取得するコンポーネントに表示する1件分の項目
+type ComponentDataItem struct {
+	ID       string     `json:"id" example:"1"`
+	Title    string     `json:"title" example:"Goプログラミングの基礎"`
+	URL      string     `json:"url,omitempty" example:"https://qiita.com/items/abc"`
+	Excerpt  string     `json:"excerpt,omitempty" example:"Goは静的型付け言語です"`
+	ImageURL string     `json:"image_url,omitempty" example:"https://example.com/cover.png"`
+	Date     *time.Time `json:"date,omitempty" example:"2023-01-01T00:00:00Z"`
+	Tags     []string   `json:"tags,omitempty"`
+}
+
+// ComponentDataResponse コンポーネントに表示するデータ
+type ComponentDataResponse struct {
+	ComponentId uint                `json:"component_id" example:"1"`
+	Source      string              `json:"source" example:"latest-articles"`
+	Items       []ComponentDataItem `json:"items"`
+	FetchedAt   time.Time           `json:"fetched_at" example:"2023-01-01T00:00:00Z"` // 取得した日時（キャッシュした結果の場合はキャッシュした日時）
+}
+
+// DataSource はコンポーネントの種類に設定されたデータの取得元を返します。取得元がない種類の場合は空文字列
+func (lc *LayoutComponent) DataSource() string {
+	componentType, _ := FindComponentType(lc.Type)
+	return componentType.DataSource
+}
+
+// DataSourceConfig はpropertiesから取得元の指定を読み取り、件数を上限の範囲に収めて返します
+func (lc *LayoutComponent) DataSourceConfig() DataSourceConfig {
+	var config DataSourceConfig
+	if lc.Properties != "" {
+		// propertiesは保存時に検証済みのため、読み取れない場合は既定値を使う
+		_ = json.Unmarshal([]byte(lc.Properties), &config)
+	}
+	if config.Limit <= 0 {
+		config.Limit = DefaultDataLimit
+	}
+	config.Limit = min(config.Limit, MaxDataLimit)
+	return config
+}
@@ -0,0 +1,138 @@
+package model
+
+import "sort"
+
+// MaxComponentDepth はコンポーネントを入れ子にできる深さ（レイアウトの直下を1とする）
+const MaxComponentDepth = 4
+
+// BuildComponentTree は親を持つコンポーネントを親のChildrenに入れ、レイアウトの直下のコンポーネントを返します
+// 同じ親を持つコンポーネントは重なり順（奥から手前）に並べる。親が一覧に含まれない場合は直下のコンポーネントとして扱う
+func BuildComponentTree(components []LayoutComponentResponse) []LayoutComponentResponse {
+	ids := make(map[uint]bool, len(components))
+	for _, component := range components {
+		ids[component.ID] = true
+	}
+	children := make(map[uint][]LayoutComponentResponse)
+	var roots []LayoutComponentResponse
+	for _, component := range components {
+		if component.ParentId != nil && *component.ParentId != component.ID && ids[*component.ParentId] {
+			children[*component.ParentId] = append(children[*component.ParentId], component)
+			continue
+		}
+		roots = append(roots, component)
+	}
+
+	var attach func(nodes []LayoutComponentResponse) []LayoutComponentResponse
+	attach = func(nodes []LayoutComponentResponse) []LayoutComponentResponse {
+		sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].ZIndex < nodes[j].ZIndex })
+		for i := range nodes {
+			nodes[i].Children = attach(children[nodes[i].ID])
+		}
+		return nodes
+	}
+	return attach(roots)
+}
+
+// Origin はparentIdの子コンポーネントの位置の基準（親の左上のレイアウト上の位置）をブレークポイントごとに返します
+// parentIdがnilの場合はレイアウトの左上（0, 0）を返す
+func (l *Layout) Origin(parentId *uint, breakpoint string) Geometry {
+	byId := make(map[uint]LayoutComponent, len(l.Components))
+	for _, component := range l.Components {
+		byId[component.ID] = component
+	}
+	var origin Geometry
+	// 親子関係が循環している場合に止まるよう、たどる回数をコンポーネントの数までにする
+	for steps := 0; parentId != nil && steps < len(l.Components); steps++ {
+		parent, ok := byId[*parentId]
+		if !ok {
+			break
+		}
+		geometry := parent.GeometryAt(breakpoint)
+		origin.X += geometry.X
+		origin.Y += geometry.Y
+		parentId = parent.ParentId
+	}
+	return origin
+}
+
+// Depth はコンポーネントの深さ（レイアウトの直下を1とする）を返します
+func (l *Layout) Depth(componentId uint) int {
+	parents := make(map[uint]*uint, len(l.Components))
+	for _, component := range l.Components {
+		parents[component.ID] = component.ParentId
+	}
+	depth := 1
+	for parentId := parents[componentId]; parentId != nil && depth <= len(l.Components); parentId = parents[*parentId] {
+		depth++
+	}
+	return depth
+}
+
+// SubtreeHeight はコンポーネントとその子孫の深さの差に1を加えた値（子を持たない場合は1）を返します
+func (l *Layout) SubtreeHeight(componentId uint) int {
+	children := make(map[uint][]uint)
+	for _, component := range l.Components {
+		if component.ParentId != nil {
+			children[*component.ParentId] = append(children[*component.ParentId], component.ID)
+		}
+	}
+	height := 0
+	for level := []uint{componentId}; len(level) > 0 && height <= len(l.Components); height++ {
+		var next []uint
+		for _, id := range level {
+			next = append(next, children[id]...)
+		}
+		level = next
+	}
+	return height
+}
+
+// Descendants はコンポーネントの子孫のIDを返します
+func (l *Layout) Descendants(componentId uint) []uint {
+	children := make(map[uint][]uint)
+	for _, component := range l.Components {
+		if component.ParentId != nil {
+			children[*component.ParentId] = append(children[*component.ParentId], component.ID)
+		}
+	}
+	var descendants []uint
+	visited := map[uint]bool{componentId: true}
+	for queue := children[componentId]; len(queue) > 0; queue = queue[1:] {
+		if visited[queue[0]] {
+			continue
+		}
+		visited[queue[0]] = true
+		descendants = append(descendants, queue[0])
+		queue = append(queue, children[queue[0]]...)
+	}
+	return descendants
+}
+
+// MoveOrigin は位置の基準をfromからtoに変更します（レイアウト上の位置は変わらない）
+// ブレークポイントごとに変換し、タブレット・モバイルは変換後も1つ大きい区分の値を引き継げる場合は上書きしない
+func (lc *LayoutComponent) MoveOrigin(from func(breakpoint string) Geometry, to func(breakpoint string) Geometry) {
+	desired := make(map[string]Geometry, len(Breakpoints))
+	for _, breakpoint := range Breakpoints {
+		geometry := lc.GeometryAt(breakpoint)
+		source, target := from(breakpoint), to(breakpoint)
+		geometry.X = max(geometry.X+source.X-target.X, 0)
+		geometry.Y = max(geometry.Y+source.Y-target.Y, 0)
+		desired[breakpoint] = geometry
+	}
+	overrides := lc.BreakpointOverrides()
+	for _, breakpoint := range Breakpoints {
+		if breakpoint == BreakpointDesktop || overrides.At(breakpoint) != nil || lc.GeometryAt(breakpoint) != desired[breakpoint] {
+			lc.SetGeometry(breakpoint, desired[breakpoint])
+		}
+	}
+}
+
+// LiftOut は親コンポーネントを削除またはレイアウトから外すときに、子コンポーネントを親の親に付け替えます
+func (lc *LayoutComponent) LiftOut(parent LayoutComponent) {
+	lc.MoveOrigin(func(breakpoint string) Geometry {
+		return parent.GeometryAt(breakpoint)
+	}, func(string) Geometry {
+		return Geometry{}
+	})
+	lc.ParentId = parent.ParentId
+}
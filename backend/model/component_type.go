@@ -0,0 +1,321 @@
+package model
+
+import "encoding/json"
+
+// ComponentType レイアウトコンポーネントの種類の定義
+// サイズはレイアウトエディタのグリッドの単位（12列・1行30px）で表し、最大値が0の場合は上限なし
+type ComponentType struct {
+	Type          string          `json:"type" example:"header"`
+	Name          string          `json:"name" example:"ヘッダー"`
+	Description   string          `json:"description" example:"サイトのタイトルとナビゲーションリンク"`
+	DefaultWidth  int             `json:"default_width" example:"12"`
+	DefaultHeight int             `json:"default_height" example:"2"`
+	MinWidth      int             `json:"min_width" example:"4"`
+	MinHeight     int             `json:"min_height" example:"1"`
+	MaxWidth      int             `json:"max_width" example:"12"`
+	MaxHeight     int             `json:"max_height" example:"6"`
+	Container     bool            `json:"container" example:"true"`                        // 他のコンポーネントを子として入れられるか
+	DataSource    string          `json:"data_source,omitempty" example:"latest-articles"` // 描画時にデータを取得して表示する種類の取得元
+	Schema        json.RawMessage `json:"schema" swaggertype:"object"`                     // propertiesを検証するJSON Schema
+	// ContentProperties はユーザーの内容やデータの取得元のIDを含むpropertiesの項目
+	// レイアウトをテンプレートとして公開するときに取り除く
+	ContentProperties []string `json:"-"`
+}
+
+const (
+	colorSchema = `{"type": "string", "pattern": "^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$"}`
+	linkSchema  = `{
+		"type": "object",
+		"properties": {
+			"label": {"type": "string", "minLength": 1, "maxLength": 50},
+			"url": {"type": "string", "maxLength": 2048}
+		},
+		"required": ["label", "url"],
+		"additionalProperties": false
+	}`
+)
+
+// ComponentTypes 利用できるレイアウトコンポーネントの種類
+// propertiesの項目はフロントエンドの既定のコンポーネント（default-components）のpropsに合わせている
+var ComponentTypes = []ComponentType{
+	{
+		Type:              "header",
+		Container:         true,
+		Name:              "ヘッダー",
+		Description:       "サイトのタイトルとナビゲーションリンク",
+		DefaultWidth:      12,
+		DefaultHeight:     2,
+		MinWidth:          4,
+		MinHeight:         1,
+		MaxWidth:          12,
+		MaxHeight:         6,
+		ContentProperties: []string{"title", "links"},
+		Schema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"title": {"type": "string", "maxLength": 100},
+				"links": {"type": "array", "maxItems": 10, "items": ` + linkSchema + `},
+				"backgroundColor": ` + colorSchema + `,
+				"textColor": ` + colorSchema + `
+			},
+			"additionalProperties": false
+		}`),
+	},
+	{
+		Type:              "sidebar",
+		Container:         true,
+		Name:              "サイドバー",
+		Description:       "最新の記事やカテゴリーへのリンク",
+		DefaultWidth:      3,
+		DefaultHeight:     8,
+		MinWidth:          2,
+		MinHeight:         2,
+		MaxWidth:          6,
+		ContentProperties: []string{"title", "items"},
+		Schema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"title": {"type": "string", "maxLength": 100},
+				"items": {"type": "array", "maxItems": 30, "items": ` + linkSchema + `},
+				"backgroundColor": ` + colorSchema + `,
+				"textColor": ` + colorSchema + `
+			},
+			"additionalProperties": false
+		}`),
+	},
+	{
+		Type:              "footer",
+		Container:         true,
+		Name:              "フッター",
+		Description:       "リンクのセクションと著作権表示",
+		DefaultWidth:      12,
+		DefaultHeight:     3,
+		MinWidth:          4,
+		MinHeight:         1,
+		MaxWidth:          12,
+		MaxHeight:         10,
+		ContentProperties: []string{"title", "description", "links", "copyright"},
+		Schema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"title": {"type": "string", "maxLength": 100},
+				"description": {"type": "string", "maxLength": 500},
+				"links": {
+					"type": "array",
+					"maxItems": 6,
+					"items": {
+						"type": "object",
+						"properties": {
+							"section": {"type": "string", "minLength": 1, "maxLength": 50},
+							"items": {"type": "array", "maxItems": 20, "items": ` + linkSchema + `}
+						},
+						"required": ["section", "items"],
+						"additionalProperties": false
+					}
+				},
+				"copyright": {"type": "string", "maxLength": 200},
+				"backgroundColor": ` + colorSchema + `,
+				"textColor": ` + colorSchema + `
+			},
+			"additionalProperties": false
+		}`),
+	},
+	{
+		Type:          "main",
+		Name:          "記事カード",
+		Description:   "記事の本文、または記事カードの一覧を表示するメイン領域",
+		DefaultWidth:  9,
+		DefaultHeight: 10,
+		MinWidth:      4,
+		MinHeight:     2,
+		MaxWidth:      12,
+		Schema:        articleCardSchema,
+	},
+	{
+		Type:          "article-card",
+		Name:          "記事カード一覧",
+		Description:   "最近の記事をカード形式で表示",
+		DefaultWidth:  6,
+		DefaultHeight: 6,
+		MinWidth:      3,
+		MinHeight:     2,
+		MaxWidth:      12,
+		Schema:        articleCardSchema,
+	},
+	{
+		Type:              "calendar",
+		Name:              "カレンダー",
+		Description:       "記事の投稿日をハイライトした月間カレンダー",
+		DefaultWidth:      3,
+		DefaultHeight:     6,
+		MinWidth:          2,
+		MinHeight:         4,
+		MaxWidth:          6,
+		MaxHeight:         10,
+		ContentProperties: []string{"highlightedDates"},
+		Schema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"highlightedDates": {"type": "array", "maxItems": 366, "items": {"type": "string"}},
+				"primaryColor": ` + colorSchema + `,
+				"secondaryColor": ` + colorSchema + `
+			},
+			"additionalProperties": false
+		}`),
+	},
+	{
+		Type:          "text",
+		Name:          "テキスト",
+		Description:   "自由に入力したHTMLを表示",
+		DefaultWidth:  4,
+		DefaultHeight: 2,
+		MinWidth:      1,
+		MinHeight:     1,
+		Schema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"align": {"enum": ["left", "center", "right"]},
+				"backgroundColor": ` + colorSchema + `,
+				"textColor": ` + colorSchema + `
+			},
+			"additionalProperties": false
+		}`),
+	},
+	{
+		Type:              "image",
+		Name:              "画像",
+		Description:       "メディアライブラリなどの画像を表示",
+		DefaultWidth:      4,
+		DefaultHeight:     4,
+		MinWidth:          1,
+		MinHeight:         1,
+		ContentProperties: []string{"url", "alt", "link"},
+		Schema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"url": {"type": "string", "maxLength": 2048},
+				"alt": {"type": "string", "maxLength": 200},
+				"link": {"type": "string", "maxLength": 2048}
+			},
+			"additionalProperties": false
+		}`),
+	},
+	{
+		Type:              "latest-articles",
+		DataSource:        DataSourceLatestArticles,
+		Name:              "最新記事",
+		Description:       "公開済みの最新の記事（タグで絞り込み可）",
+		DefaultWidth:      3,
+		DefaultHeight:     6,
+		MinWidth:          2,
+		MinHeight:         2,
+		ContentProperties: []string{"tag"},
+		Schema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"limit": ` + dataLimitSchema + `,
+				"tag": {"type": "string", "maxLength": 50}
+			},
+			"additionalProperties": false
+		}`),
+	},
+	{
+		Type:              "feed",
+		DataSource:        DataSourceFeed,
+		Name:              "フィード",
+		Description:       "登録したフィードの記事",
+		DefaultWidth:      3,
+		DefaultHeight:     6,
+		MinWidth:          2,
+		MinHeight:         2,
+		ContentProperties: []string{"feedId"},
+		Schema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"feedId": {"type": "integer", "minimum": 1},
+				"limit": ` + dataLimitSchema + `
+			},
+			"required": ["feedId"],
+			"additionalProperties": false
+		}`),
+	},
+	{
+		Type:          "qiita-trending",
+		DataSource:    DataSourceQiitaTrending,
+		Name:          "Qiitaのトレンド",
+		Description:   "Qiitaでいいねの多い記事",
+		DefaultWidth:  3,
+		DefaultHeight: 6,
+		MinWidth:      2,
+		MinHeight:     2,
+		Schema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"limit": ` + dataLimitSchema + `
+			},
+			"additionalProperties": false
+		}`),
+	},
+	{
+		Type:              "external-api",
+		DataSource:        DataSourceExternalAPI,
+		Name:              "外部API",
+		Description:       "登録した外部APIの応答を変換の定義でカードにして表示",
+		DefaultWidth:      3,
+		DefaultHeight:     6,
+		MinWidth:          2,
+		MinHeight:         2,
+		ContentProperties: []string{"mappingId"},
+		Schema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"mappingId": {"type": "integer", "minimum": 1},
+				"limit": ` + dataLimitSchema + `
+			},
+			"required": ["mappingId"],
+			"additionalProperties": false
+		}`),
+	},
+	{
+		Type:          "bookshelf",
+		DataSource:    DataSourceBookshelf,
+		Name:          "本棚",
+		Description:   "登録した本の表紙とタイトル",
+		DefaultWidth:  4,
+		DefaultHeight: 6,
+		MinWidth:      2,
+		MinHeight:     2,
+		Schema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"limit": ` + dataLimitSchema + `
+			},
+			"additionalProperties": false
+		}`),
+	},
+}
+
+// dataLimitSchema はデータを取得するコンポーネントに表示する件数（上限はMaxDataLimit）
+const dataLimitSchema = `{"type": "integer", "minimum": 1, "maximum": 20}`
+
+var articleCardSchema = json.RawMessage(`{
+	"type": "object",
+	"properties": {
+		"limit": {"type": "integer", "minimum": 1, "maximum": 50},
+		"showImage": {"type": "boolean"},
+		"showTags": {"type": "boolean"},
+		"primaryColor": ` + colorSchema + `,
+		"secondaryColor": ` + colorSchema + `
+	},
+	"additionalProperties": false
+}`)
+
+// FindComponentType は種類の名前から定義を取得します
+func FindComponentType(name string) (ComponentType, bool) {
+	for _, componentType := range ComponentTypes {
+		if componentType.Type == name {
+			return componentType, true
+		}
+	}
+	return ComponentType{}, false
+}
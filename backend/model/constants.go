@@ -16,4 +16,19 @@ const (
     
     // UserPasswordMaxLength パスワードの最大文字数
     UserPasswordMaxLength = 30
+    
+    // UserUsernameMinLength ユーザー名の最小文字数
+    UserUsernameMinLength = 3
+    
+    // UserUsernameMaxLength ユーザー名の最大文字数
+    UserUsernameMaxLength = 30
+    
+    // ArticleMetaDescriptionMaxLength メタディスクリプションの最大文字数
+    ArticleMetaDescriptionMaxLength = 300
+    
+    // ArticleMetaDescriptionRecommendedLength 検索結果に表示されるメタディスクリプションの推奨最大文字数
+    ArticleMetaDescriptionRecommendedLength = 120
+    
+    // ArticleTitleRecommendedLength 検索結果に表示されるタイトルの推奨最大文字数
+    ArticleTitleRecommendedLength = 60
 )
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	ExportStatusPending   = "pending"
+	ExportStatusRunning   = "running"
+	ExportStatusCompleted = "completed"
+	ExportStatusFailed    = "failed"
+)
+
+// ExportJob 静的サイトの書き出しジョブのデータベースモデル
+type ExportJob struct {
+	ID         uint       `json:"id" gorm:"primaryKey" example:"1"`
+	Status     string     `json:"status" gorm:"not null;default:pending" example:"completed"`
+	Title      string     `json:"title" example:"gopherのブログ"`
+	BaseURL    string     `json:"base_url" gorm:"not null" example:"https://blog.example.com"`
+	Full       bool       `json:"full" gorm:"default:false" example:"false"`
+	Articles   int        `json:"articles" example:"12"`
+	Rendered   int        `json:"rendered" example:"2"`
+	Skipped    int        `json:"skipped" example:"10"`
+	Removed    int        `json:"removed" example:"0"`
+	Error      string     `json:"error" example:""`
+	FilePath   string     `json:"-"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	CreatedAt  time.Time  `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt  time.Time  `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+	LayoutId   uint       `json:"layout_id" gorm:"not null" example:"1"`
+	Layout     Layout     `json:"-" gorm:"foreignKey:LayoutId; constraint:OnDelete:CASCADE"`
+	User       User       `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	UserId     uint       `json:"user_id" gorm:"not null;index" example:"1"`
+}
+
+// ExportJobRequest 書き出しジョブの作成リクエスト
+type ExportJobRequest struct {
+	LayoutId uint   `json:"layout_id" example:"1"`
+	Title    string `json:"title" example:"gopherのブログ"`                  // 省略時はユーザー名
+	BaseURL  string `json:"base_url" example:"https://blog.example.com"` // 書き出したサイトの公開先のURL
+	Full     bool   `json:"full" example:"false"`                        // trueの場合は前回の結果を使わずにすべて生成し直す
+	UserId   uint   `json:"-"`
+}
+
+// ExportJobResponse 書き出しジョブのレスポンス
+type ExportJobResponse struct {
+	ID          uint       `json:"id" example:"1"`
+	Status      string     `json:"status" example:"completed"`
+	LayoutId    uint       `json:"layout_id" example:"1"`
+	Title       string     `json:"title" example:"gopherのブログ"`
+	BaseURL     string     `json:"base_url" example:"https://blog.example.com"`
+	Full        bool       `json:"full" example:"false"`
+	Articles    int        `json:"articles" example:"12"`
+	Rendered    int        `json:"rendered" example:"2"`
+	Skipped     int        `json:"skipped" example:"10"`
+	Removed     int        `json:"removed" example:"0"`
+	Error       string     `json:"error,omitempty" example:""`
+	DownloadURL string     `json:"download_url,omitempty" example:"/exports/1/download"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt   time.Time  `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// ToResponse ExportJobからExportJobResponseへの変換メソッド
+func (j *ExportJob) ToResponse() ExportJobResponse {
+	res := ExportJobResponse{
+		ID:         j.ID,
+		Status:     j.Status,
+		LayoutId:   j.LayoutId,
+		Title:      j.Title,
+		BaseURL:    j.BaseURL,
+		Full:       j.Full,
+		Articles:   j.Articles,
+		Rendered:   j.Rendered,
+		Skipped:    j.Skipped,
+		Removed:    j.Removed,
+		Error:      j.Error,
+		StartedAt:  j.StartedAt,
+		FinishedAt: j.FinishedAt,
+		CreatedAt:  j.CreatedAt,
+		UpdatedAt:  j.UpdatedAt,
+	}
+	if j.Status == ExportStatusCompleted && j.FilePath != "" {
+		res.DownloadURL = fmt.Sprintf("/exports/%d/download", j.ID)
+	}
+	return res
+}
@@ -1,23 +1,151 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
+
+// 外部APIの認証方式
+const (
+	ExternalAPIAuthNone   = ""        // 認証なし
+	ExternalAPIAuthAPIKey = "api_key" // AuthHeaderで指定したヘッダーにAPIキーを設定
+	ExternalAPIAuthBearer = "bearer"  // Authorization: Bearer <credential>
+	ExternalAPIAuthBasic  = "basic"   // Authorization: Basic（credentialは"ユーザー名:パスワード"）
+)
+
+// 外部APIの呼び出しの設定の既定値と上限
+const (
+	DefaultExternalAPIMethod     = "GET"
+	DefaultExternalAPIAuthHeader = "X-API-Key"
+	DefaultExternalAPITimeout    = 10 // 秒
+	MaxExternalAPITimeout        = 30 // 秒
+)
+
+// ヘルスチェックの間隔の既定値と範囲（秒）
+const (
+	DefaultHealthCheckInterval = 300
+	MinHealthCheckInterval     = 30
+	MaxHealthCheckInterval     = 86400
+)
 
 type ExternalAPI struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`
-	Name        string    `json:"name" gorm:"not null"`
-	BaseURL     string    `json:"base_url" gorm:"not null"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	User        User      `json:"user" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
-	UserId      uint      `json:"user_id" gorm:"not null"`
+	ID             uint              `json:"id" gorm:"primaryKey"`
+	Name           string            `json:"name" gorm:"not null"`
+	BaseURL        string            `json:"base_url" gorm:"not null"`
+	Description    string            `json:"description"`
+	Method         string            `json:"method" gorm:"size:10;not null;default:''"`     // 空の場合はGET
+	PathTemplate   string            `json:"path_template"`                                 // BaseURLに続けるパス。{name}は呼び出し時のparamsで置き換える
+	QueryParams    map[string]string `json:"query_params" gorm:"serializer:json;type:text"` // 常に付けるクエリパラメータ。値の{name}は呼び出し時のparamsで置き換える
+	TimeoutSeconds int               `json:"timeout_seconds" gorm:"not null;default:0"`     // 0の場合はDefaultExternalAPITimeout
+	AuthType       string            `json:"auth_type" gorm:"size:20;not null;default:''"`
+	AuthHeader     string            `json:"auth_header"`                        // api_keyの場合にAPIキーを設定するヘッダー（空の場合はX-API-Key）
+	AuthCredential string            `json:"auth_credential,omitempty" gorm:"-"` // 認証情報。秘密情報として暗号化して保存し、レスポンスには含めない
+	// ヘルスチェックの設定。パスが空の場合はヘルスチェックしない
+	HealthCheckPath            string    `json:"health_check_path" gorm:"size:500;not null;default:''"`   // BaseURLに続けるパス（GETで呼び出す）
+	HealthCheckStatus          int       `json:"health_check_status" gorm:"not null;default:0"`           // 正常とみなすステータスコード。0の場合は2xx
+	HealthCheckIntervalSeconds int       `json:"health_check_interval_seconds" gorm:"not null;default:0"` // 0の場合はDefaultHealthCheckInterval
+	CreatedAt                  time.Time `json:"created_at"`
+	UpdatedAt                  time.Time `json:"updated_at"`
+	User                       User      `json:"user" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	UserId                     uint      `json:"user_id" gorm:"not null"`
 }
 
 type ExternalAPIResponse struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`
-	Name        string    `json:"name" gorm:"not null"`
-	BaseURL     string    `json:"base_url" gorm:"not null"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                         uint              `json:"id" gorm:"primaryKey"`
+	Name                       string            `json:"name" gorm:"not null"`
+	BaseURL                    string            `json:"base_url" gorm:"not null"`
+	Description                string            `json:"description"`
+	Method                     string            `json:"method"`
+	PathTemplate               string            `json:"path_template"`
+	QueryParams                map[string]string `json:"query_params,omitempty"`
+	TimeoutSeconds             int               `json:"timeout_seconds"`
+	AuthType                   string            `json:"auth_type"`
+	AuthHeader                 string            `json:"auth_header,omitempty"`
+	HasCredential              bool              `json:"has_credential"` // 認証情報が保存されているか（認証情報そのものは返さない）
+	HealthCheckPath            string            `json:"health_check_path,omitempty"`
+	HealthCheckStatus          int               `json:"health_check_status,omitempty"`
+	HealthCheckIntervalSeconds int               `json:"health_check_interval_seconds,omitempty"`
+	CreatedAt                  time.Time         `json:"created_at"`
+	UpdatedAt                  time.Time         `json:"updated_at"`
+}
+
+// ExternalAPICallRequest 外部APIを呼び出すリクエスト
+type ExternalAPICallRequest struct {
+	Params map[string]string `json:"params,omitempty"`                    // パスとクエリパラメータの{name}を置き換える値
+	Query  map[string]string `json:"query,omitempty"`                     // 追加・上書きするクエリパラメータ
+	Body   json.RawMessage   `json:"body,omitempty" swaggertype:"object"` // GET以外の場合に送信するJSON
+}
+
+// ExternalAPICallResponse 外部APIの応答
+// 外部APIがエラーを返した場合も、そのステータスコードと本文をそのまま返す
+type ExternalAPICallResponse struct {
+	StatusCode  int             `json:"status_code" example:"200"`
+	ContentType string          `json:"content_type" example:"application/json"`
+	Body        json.RawMessage `json:"body,omitempty" swaggertype:"object"` // JSONの場合の本文
+	Text        string          `json:"text,omitempty"`                      // JSON以外の場合の本文
+	Truncated   bool            `json:"truncated,omitempty"`                 // 本文が上限を超えたため切り詰めたか
+	DurationMs  int64           `json:"duration_ms" example:"120"`
+}
+
+// ExternalAPIからExternalAPIResponseへの変換メソッド
+// 認証情報は秘密情報として別に保存するため、保存されているかを引数で受け取る
+func (a *ExternalAPI) ToResponse(hasCredential bool) ExternalAPIResponse {
+	res := ExternalAPIResponse{
+		ID:             a.ID,
+		Name:           a.Name,
+		BaseURL:        a.BaseURL,
+		Description:    a.Description,
+		Method:         a.CallMethod(),
+		PathTemplate:   a.PathTemplate,
+		QueryParams:    a.QueryParams,
+		TimeoutSeconds: a.Timeout(),
+		AuthType:       a.AuthType,
+		AuthHeader:     a.AuthHeader,
+		HasCredential:  hasCredential,
+		CreatedAt:      a.CreatedAt,
+		UpdatedAt:      a.UpdatedAt,
+	}
+	if a.HealthCheckEnabled() {
+		res.HealthCheckPath = a.HealthCheckPath
+		res.HealthCheckStatus = a.HealthCheckStatus
+		res.HealthCheckIntervalSeconds = a.HealthCheckInterval()
+	}
+	return res
+}
+
+// CallMethod は既定値を補った呼び出しのHTTPメソッドを返します
+func (a *ExternalAPI) CallMethod() string {
+	if a.Method == "" {
+		return DefaultExternalAPIMethod
+	}
+	return a.Method
+}
+
+// Timeout は既定値を補った呼び出しのタイムアウト（秒）を返します
+func (a *ExternalAPI) Timeout() int {
+	if a.TimeoutSeconds <= 0 {
+		return DefaultExternalAPITimeout
+	}
+	return min(a.TimeoutSeconds, MaxExternalAPITimeout)
+}
+
+// HealthCheckEnabled はヘルスチェックが設定されているかを返します
+func (a *ExternalAPI) HealthCheckEnabled() bool {
+	return a.HealthCheckPath != ""
+}
+
+// HealthCheckInterval は既定値を補ったヘルスチェックの間隔（秒）を返します
+func (a *ExternalAPI) HealthCheckInterval() int {
+	if a.HealthCheckIntervalSeconds <= 0 {
+		return DefaultHealthCheckInterval
+	}
+	return max(a.HealthCheckIntervalSeconds, MinHealthCheckInterval)
+}
+
+// IsHealthyStatus はヘルスチェックの応答のステータスコードが正常かを返します
+func (a *ExternalAPI) IsHealthyStatus(statusCode int) bool {
+	if a.HealthCheckStatus == 0 {
+		return statusCode >= 200 && statusCode < 300
+	}
+	return statusCode == a.HealthCheckStatus
 }
@@ -0,0 +1,70 @@
+package model
+
+import "time"
+
+// 外部APIの稼働状況
+const (
+	ExternalAPIStatusUp      = "up"      // 直近のヘルスチェックが正常
+	ExternalAPIStatusDown    = "down"    // 直近のヘルスチェックが異常
+	ExternalAPIStatusUnknown = "unknown" // ヘルスチェックが未設定、またはまだ実行していない
+)
+
+// 稼働率を集計する期間（日）と、稼働状況に含める履歴の件数
+const (
+	DefaultStatusWindowDays = 7
+	MaxStatusWindowDays     = 30 // ヘルスチェックの履歴はこの期間を過ぎると削除する
+	StatusHistoryLimit      = 20
+)
+
+// ExternalAPIHealthCheck 外部APIのヘルスチェックの結果（1回分）
+type ExternalAPIHealthCheck struct {
+	ID            uint        `json:"id" gorm:"primaryKey"`
+	ExternalAPIId uint        `json:"external_api_id" gorm:"not null;index:idx_health_checks_api_checked_at,priority:1"`
+	ExternalAPI   ExternalAPI `json:"-" gorm:"foreignKey:ExternalAPIId; constraint:OnDelete:CASCADE"`
+	Up            bool        `json:"up" gorm:"not null"`
+	StatusCode    int         `json:"status_code" gorm:"not null;default:0"` // 応答がなかった場合は0
+	LatencyMs     int64       `json:"latency_ms" gorm:"not null;default:0"`
+	Error         string      `json:"error" gorm:"size:500"` // 異常の理由（期待と異なるステータスコード、タイムアウトなど）
+	CheckedAt     time.Time   `json:"checked_at" gorm:"not null;index;index:idx_health_checks_api_checked_at,priority:2"`
+}
+
+// ExternalAPIHealthSummary 期間内のヘルスチェックの集計
+type ExternalAPIHealthSummary struct {
+	Checks       int64
+	UpChecks     int64
+	AvgLatencyMs float64
+}
+
+// ExternalAPIHealthCheckResponse ヘルスチェックの結果
+type ExternalAPIHealthCheckResponse struct {
+	Up         bool      `json:"up" example:"false"`
+	StatusCode int       `json:"status_code" example:"503"`
+	LatencyMs  int64     `json:"latency_ms" example:"120"`
+	Error      string    `json:"error,omitempty" example:"unexpected status 503"`
+	CheckedAt  time.Time `json:"checked_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// ExternalAPIStatusResponse 外部APIの稼働状況
+type ExternalAPIStatusResponse struct {
+	ExternalAPIId uint                             `json:"external_api_id" example:"1"`
+	Enabled       bool                             `json:"enabled" example:"true"` // ヘルスチェックが設定されているか
+	Status        string                           `json:"status" example:"up"`
+	WindowDays    int                              `json:"window_days" example:"7"`        // 稼働率を集計した期間（日）
+	Checks        int64                            `json:"checks" example:"2016"`          // 期間内のヘルスチェックの回数
+	UptimePercent *float64                         `json:"uptime_percent" example:"99.95"` // 期間内にヘルスチェックがない場合はnull
+	AvgLatencyMs  *int64                           `json:"avg_latency_ms" example:"120"`   // 期間内にヘルスチェックがない場合はnull
+	LastCheckedAt *time.Time                       `json:"last_checked_at" example:"2023-01-01T00:00:00Z"`
+	LastFailure   *ExternalAPIHealthCheckResponse  `json:"last_failure"` // 保存されている履歴の中で最後の異常。ない場合はnull
+	History       []ExternalAPIHealthCheckResponse `json:"history"`      // 新しい順に最大StatusHistoryLimit件
+}
+
+// ExternalAPIHealthCheckからExternalAPIHealthCheckResponseへの変換メソッド
+func (h *ExternalAPIHealthCheck) ToResponse() ExternalAPIHealthCheckResponse {
+	return ExternalAPIHealthCheckResponse{
+		Up:         h.Up,
+		StatusCode: h.StatusCode,
+		LatencyMs:  h.LatencyMs,
+		Error:      h.Error,
+		CheckedAt:  h.CheckedAt,
+	}
+}
@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 type FeedArticle struct {
 	ID          string    `json:"id"`
@@ -25,3 +29,25 @@ type FeedArticleResponse struct {
 	PublishedAt time.Time `json:"published_at"`
 	Author      string    `json:"author"`
 }
+
+// SearchSourceID フィード記事の検索ドキュメントIDを返します（フィードIDと記事IDの組）
+func (fa *FeedArticle) SearchSourceID() string {
+	return fmt.Sprintf("%d:%s", fa.FeedID, fa.ID)
+}
+
+// ToSearchDocument フィード記事を全文検索インデックスのドキュメントに変換します
+func (fa *FeedArticle) ToSearchDocument(userId uint) SearchDocument {
+	body := fa.Content
+	if body == "" {
+		body = fa.Summary
+	}
+	return SearchDocument{
+		SourceType: SearchSourceFeedArticle,
+		SourceID:   fa.SearchSourceID(),
+		Title:      fa.Title,
+		Body:       body,
+		Tags:       strings.Join(fa.Categories, ","),
+		URL:        fa.URL,
+		UserId:     userId,
+	}
+}
@@ -9,6 +9,8 @@ type GoogleBook struct {
 	ISBN         string   `json:"isbn" example:"9784873118468"`
 	ImageURL     string   `json:"image_url" example:"http://books.google.com/books/content?id=..."`
 	PublishedDate string   `json:"published_date" example:"2018-06-15"`
+	Publisher    string   `json:"publisher" example:"オライリー・ジャパン"`
+	PageCount    int      `json:"page_count" example:"238"`
 }
 
 // GoogleBookSearchRequest 検索リクエスト
@@ -37,5 +39,7 @@ func (gb *GoogleBook) ToBookRequest() BookRequest {
 		ISBN:         gb.ISBN,
 		ImageURL:     gb.ImageURL,
 		PublishedDate: gb.PublishedDate,
+		Publisher:    gb.Publisher,
+		PageCount:    gb.PageCount,
 	}
 }
@@ -2,40 +2,116 @@ package model
 
 import "time"
 
+// グリッドの設定の既定値（レイアウトエディタのキャンバスに合わせている）
+const (
+	DefaultGridColumns = 12
+	DefaultRowHeight   = 30
+	DefaultCanvasWidth = 1000
+)
+
+// コンポーネントが重なった場合の扱い
+const (
+	CollisionModePush   = "push"   // 重なったコンポーネントを下に押し出す
+	CollisionModeReject = "reject" // 重なる位置への配置を拒否する
+)
+
 // データベースモデル
 type Layout struct {
-	ID         uint              `json:"id" gorm:"primaryKey" example:"1"`
-	Title      string            `json:"title" gorm:"not null" example:"ブログのメインレイアウト"`
-	UserId     uint              `json:"user_id" gorm:"not null" example:"1"`
-	User       User              `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
-	Components []LayoutComponent `json:"-" gorm:"foreignKey:LayoutId"`
-	CreatedAt  time.Time         `json:"created_at" example:"2023-01-01T00:00:00Z"`
-	UpdatedAt  time.Time         `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+	ID             uint              `json:"id" gorm:"primaryKey" example:"1"`
+	Title          string            `json:"title" gorm:"not null" example:"ブログのメインレイアウト"`
+	GridColumns    int               `json:"grid_columns" gorm:"not null;default:0" example:"12"`
+	RowHeight      int               `json:"row_height" gorm:"not null;default:0" example:"30"`
+	CanvasWidth    int               `json:"canvas_width" gorm:"not null;default:0" example:"1000"`
+	CollisionMode  string            `json:"collision_mode" gorm:"size:10;not null;default:''" example:"push"`
+	Version        int               `json:"version" gorm:"not null;default:1" example:"1"` // 配置を変更するたびに増える楽観的排他制御用のバージョン
+	LiveSnapshotId *uint             `json:"live_snapshot_id" example:"1"`                  // 公開中のスナップショット（nullの場合は編集中の状態を公開する）
+	UserId         uint              `json:"user_id" gorm:"not null" example:"1"`
+	User           User              `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	Components     []LayoutComponent `json:"-" gorm:"foreignKey:LayoutId"`
+	CreatedAt      time.Time         `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt      time.Time         `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// GridSettings レイアウトのグリッドの設定
+// Columnsが0の場合はグリッドを使わず、指定された位置とサイズをそのまま保存する
+type GridSettings struct {
+	Columns       int    `json:"columns" example:"12"`
+	RowHeight     int    `json:"row_height" example:"30"`
+	CanvasWidth   int    `json:"canvas_width" example:"1000"`
+	CollisionMode string `json:"collision_mode" example:"push"`
 }
 
 // リクエスト用の構造体
+// グリッドの設定は省略した場合に既定値が使われる（grid_columnsを省略した場合はグリッドを使わない）
 type LayoutRequest struct {
-	Title  string `json:"title" validate:"required" example:"ブログのメインレイアウト"`
-	UserId uint   `json:"-"` // クライアントからは送信されず、JWTから取得
+	Title         string `json:"title" validate:"required" example:"ブログのメインレイアウト"`
+	GridColumns   int    `json:"grid_columns,omitempty" example:"12"`
+	RowHeight     int    `json:"row_height,omitempty" example:"30"`
+	CanvasWidth   int    `json:"canvas_width,omitempty" example:"1000"`
+	CollisionMode string `json:"collision_mode,omitempty" example:"push"`
+	UserId        uint   `json:"-"` // クライアントからは送信されず、JWTから取得
 }
 
 // レスポンス用の構造体
 type LayoutResponse struct {
-	ID         uint                     `json:"id" example:"1"`
-	Title      string                   `json:"title" example:"ブログのメインレイアウト"`
-	Components []LayoutComponentResponse `json:"components,omitempty"`
-	CreatedAt  time.Time                `json:"created_at" example:"2023-01-01T00:00:00Z"`
-	UpdatedAt  time.Time                `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+	ID             uint                      `json:"id" example:"1"`
+	Title          string                    `json:"title" example:"ブログのメインレイアウト"`
+	Grid           GridSettings              `json:"grid"`
+	Version        int                       `json:"version" example:"1"`
+	LiveSnapshotId *uint                     `json:"live_snapshot_id,omitempty" example:"1"`
+	Breakpoint     string                    `json:"breakpoint,omitempty" example:"desktop"` // componentsの位置とサイズのブレークポイント
+	Components     []LayoutComponentResponse `json:"components,omitempty"`
+	CreatedAt      time.Time                 `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt      time.Time                 `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// ArrangementRequest レイアウト全体の配置を一括で更新するリクエスト
+type ArrangementRequest struct {
+	Version       int                 `json:"version" example:"1"` // 取得したときのレイアウトのバージョン
+	Components    []ComponentPosition `json:"components"`
+	Breakpoint    string              `json:"breakpoint,omitempty" example:"mobile"` // 位置を更新するブレークポイント（省略した場合はdesktop）
+	Snapshot      bool                `json:"snapshot,omitempty" example:"true"`     // 更新後の状態をスナップショットとして保存するか
+	SnapshotLabel string              `json:"snapshot_label,omitempty" example:"ヘッダーを拡大"`
+}
+
+// ComponentPosition 一括更新するコンポーネントの位置
+type ComponentPosition struct {
+	ComponentId uint `json:"component_id" example:"1"`
+	PositionRequest
+}
+
+// GridSettings は既定値を補ったグリッドの設定を返します
+func (l *Layout) GridSettings() GridSettings {
+	settings := GridSettings{
+		Columns:       l.GridColumns,
+		RowHeight:     l.RowHeight,
+		CanvasWidth:   l.CanvasWidth,
+		CollisionMode: l.CollisionMode,
+	}
+	if settings.RowHeight == 0 {
+		settings.RowHeight = DefaultRowHeight
+	}
+	if settings.CanvasWidth == 0 {
+		settings.CanvasWidth = DefaultCanvasWidth
+	}
+	if settings.CollisionMode == "" {
+		settings.CollisionMode = CollisionModePush
+	}
+	return settings
 }
+
 // LayoutからLayoutResponseへの変換メソッド
 func (l *Layout) ToResponse() LayoutResponse {
 	response := LayoutResponse{
-		ID:        l.ID,
-		Title:     l.Title,
-		CreatedAt: l.CreatedAt,
-		UpdatedAt: l.UpdatedAt,
+		ID:             l.ID,
+		Title:          l.Title,
+		Grid:           l.GridSettings(),
+		Version:        l.Version,
+		LiveSnapshotId: l.LiveSnapshotId,
+		CreatedAt:      l.CreatedAt,
+		UpdatedAt:      l.UpdatedAt,
 	}
-	
+
 	// Componentsがロードされている場合のみ変換
 	if len(l.Components) > 0 {
 		components := make([]LayoutComponentResponse, len(l.Components))
@@ -44,14 +120,18 @@ func (l *Layout) ToResponse() LayoutResponse {
 		}
 		response.Components = components
 	}
-	
+
 	return response
 }
 
 // LayoutRequestからLayoutへの変換メソッド
 func (lr *LayoutRequest) ToModel() Layout {
 	return Layout{
-		Title:  lr.Title,
-		UserId: lr.UserId,
+		Title:         lr.Title,
+		GridColumns:   lr.GridColumns,
+		RowHeight:     lr.RowHeight,
+		CanvasWidth:   lr.CanvasWidth,
+		CollisionMode: lr.CollisionMode,
+		UserId:        lr.UserId,
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+// 記事のページに使うレイアウトの決定元（記事の指定 → タグの指定 → ユーザーの既定の順に優先する）
+const (
+	LayoutSourceArticle = "article"
+	LayoutSourceTag     = "tag"
+	LayoutSourceDefault = "default"
+)
+
+// LayoutAssignment ユーザーの既定のレイアウト、またはタグのページに使うレイアウトの指定
+// Tagが空文字の場合はユーザーの既定のレイアウト
+type LayoutAssignment struct {
+	ID        uint      `json:"id" gorm:"primaryKey" example:"1"`
+	Tag       string    `json:"tag" gorm:"size:100;not null;default:'';uniqueIndex:idx_layout_assignments_user_tag" example:"Go"`
+	LayoutId  uint      `json:"layout_id" gorm:"not null;index" example:"1"`
+	Layout    Layout    `json:"-" gorm:"foreignKey:LayoutId; constraint:OnDelete:CASCADE"`
+	UserId    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_layout_assignments_user_tag" example:"1"`
+	User      User      `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// LayoutAssignmentRequest 既定またはタグのページに使うレイアウトを指定するリクエスト
+type LayoutAssignmentRequest struct {
+	LayoutId uint `json:"layout_id" validate:"required" example:"1"`
+}
+
+// LayoutAssignmentResponse タグのページに使うレイアウトの指定
+type LayoutAssignmentResponse struct {
+	Tag       string    `json:"tag,omitempty" example:"Go"`
+	LayoutId  uint      `json:"layout_id" example:"1"`
+	UpdatedAt time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// LayoutAssignmentsResponse ユーザーのレイアウトの指定の一覧
+type LayoutAssignmentsResponse struct {
+	Default *LayoutAssignmentResponse  `json:"default"` // 既定のレイアウト（未設定の場合はnull）
+	Tags    []LayoutAssignmentResponse `json:"tags"`    // タグのページに使うレイアウト（タグの名前順）
+}
+
+// ResolvedLayout 記事のページに使うレイアウトと、その決定元
+type ResolvedLayout struct {
+	Source string          `json:"source,omitempty" example:"tag"` // article / tag / default（該当するレイアウトがない場合は空）
+	Tag    string          `json:"tag,omitempty" example:"Go"`     // sourceがtagの場合に一致したタグ
+	Layout *LayoutResponse `json:"layout"`                         // 公開中の状態のレイアウト（該当するレイアウトがない場合はnull）
+}
+
+// PublicArticleResponse 公開記事のページのレスポンス
+type PublicArticleResponse struct {
+	Article ArticleResponse `json:"article"`
+	Layout  ResolvedLayout  `json:"layout"`
+}
+
+// LayoutAssignmentからLayoutAssignmentResponseへの変換メソッド
+func (la *LayoutAssignment) ToResponse() LayoutAssignmentResponse {
+	return LayoutAssignmentResponse{
+		Tag:       la.Tag,
+		LayoutId:  la.LayoutId,
+		UpdatedAt: la.UpdatedAt,
+	}
+}
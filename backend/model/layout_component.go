@@ -1,92 +1,142 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // データベースモデル
 type LayoutComponent struct {
-	ID        uint      `json:"id" gorm:"primaryKey" example:"1"`
-	Name      string    `json:"name" gorm:"not null" example:"ヘッダーコンポーネント"`
-	Type      string    `json:"type" gorm:"not null" example:"header"`
-	Content   string    `json:"content" example:"<h1>ブログタイトル</h1>"`
-	X         int       `json:"x" gorm:"default:0" example:"0"`
-	Y         int       `json:"y" gorm:"default:0" example:"0"`
-	Width     int       `json:"width" gorm:"default:100" example:"100"`
-	Height    int       `json:"height" gorm:"default:100" example:"50"`
-	UserId    uint      `json:"user_id" gorm:"not null" example:"1"`
-	User      User      `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
-	LayoutId  *uint     `json:"layout_id" example:"1"`
-	Layout    *Layout   `json:"-" gorm:"foreignKey:LayoutId"`
-	CreatedAt time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
-	UpdatedAt time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+	ID          uint      `json:"id" gorm:"primaryKey" example:"1"`
+	Name        string    `json:"name" gorm:"not null" example:"ヘッダーコンポーネント"`
+	Type        string    `json:"type" gorm:"not null" example:"header"`
+	Content     string    `json:"content" example:"<h1>ブログタイトル</h1>"`
+	Properties  string    `json:"-" gorm:"type:text"` // 種類ごとのJSON Schemaで検証済みのJSON
+	X           int       `json:"x" gorm:"default:0" example:"0"`
+	Y           int       `json:"y" gorm:"default:0" example:"0"`
+	Width       int       `json:"width" gorm:"default:100" example:"100"`
+	Height      int       `json:"height" gorm:"default:100" example:"50"`
+	Breakpoints string    `json:"-" gorm:"type:text"`                   // タブレット・モバイルで上書きする位置とサイズ（BreakpointGeometryのJSON）
+	ParentId    *uint     `json:"parent_id" gorm:"index" example:"1"`   // 親のコンポーネント。親がある場合、位置は親の左上を基準にする
+	ZIndex      int       `json:"z_index" gorm:"default:0" example:"0"` // 同じ親を持つコンポーネントの重なり順（大きいほど手前）
+	UserId      uint      `json:"user_id" gorm:"not null" example:"1"`
+	User        User      `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	LayoutId    *uint     `json:"layout_id" example:"1"`
+	Layout      *Layout   `json:"-" gorm:"foreignKey:LayoutId"`
+	CreatedAt   time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
 }
 
 // リクエスト用の構造体
 type LayoutComponentRequest struct {
-	Name    string `json:"name" validate:"required" example:"ヘッダーコンポーネント"`
-	Type    string `json:"type" validate:"required" example:"header"`
-	Content string `json:"content" example:"<h1>ブログタイトル</h1>"`
-	X       int    `json:"x" example:"0"`
-	Y       int    `json:"y" example:"0"`
-	Width   int    `json:"width" example:"100"`
-	Height  int    `json:"height" example:"50"`
-	UserId  uint   `json:"-"` // クライアントからは送信されず、JWTから取得
+	Name       string          `json:"name" validate:"required" example:"ヘッダーコンポーネント"`
+	Type       string          `json:"type" validate:"required" example:"header"`
+	Content    string          `json:"content" example:"<h1>ブログタイトル</h1>"`
+	Properties json.RawMessage `json:"properties,omitempty" swaggertype:"object"` // 種類ごとの設定（GET /layout-components/types のschemaで検証）
+	X          int             `json:"x" example:"0"`
+	Y          int             `json:"y" example:"0"`
+	Width      int             `json:"width" example:"100"`
+	Height     int             `json:"height" example:"50"`
+	ZIndex     int             `json:"z_index" example:"0"`
+	UserId     uint            `json:"-"` // クライアントからは送信されず、JWTから取得
 }
 
 // 位置情報更新用のリクエスト構造体
 type PositionRequest struct {
-	X      int `json:"x" example:"10"`
-	Y      int `json:"y" example:"20"`
-	Width  int `json:"width,omitempty" example:"150"`
-	Height int `json:"height,omitempty" example:"75"`
+	X      int    `json:"x" example:"10"`
+	Y      int    `json:"y" example:"20"`
+	Width  int    `json:"width,omitempty" example:"150"`
+	Height int    `json:"height,omitempty" example:"75"`
+	Unit   string `json:"unit,omitempty" example:"grid"` // grid（既定）またはpx。pxの場合はレイアウトのグリッドに合わせて変換する
 }
 
+// 位置情報の単位
+const (
+	PositionUnitGrid  = "grid"
+	PositionUnitPixel = "px"
+)
+
 // レイアウト割り当て用のリクエスト構造体
 type AssignLayoutRequest struct {
-	LayoutId uint           `json:"layout_id" validate:"required" example:"1"`
+	LayoutId uint            `json:"layout_id" validate:"required" example:"1"`
 	Position PositionRequest `json:"position"`
 }
 
+// 親コンポーネント変更用のリクエスト構造体
+type ParentRequest struct {
+	ParentId *uint `json:"parent_id" example:"1"` // nullの場合はレイアウトの直下に移動する
+}
+
+// 親コンポーネントを削除するときの子コンポーネントの扱い
+const (
+	ChildrenReparent = "reparent" // 削除するコンポーネントの親に付け替える（既定）
+	ChildrenDelete   = "delete"   // 子孫のコンポーネントもすべて削除する
+)
+
 // レスポンス用の構造体
 type LayoutComponentResponse struct {
-	ID        uint      `json:"id" example:"1"`
-	Name      string    `json:"name" example:"ヘッダーコンポーネント"`
-	Type      string    `json:"type" example:"header"`
-	Content   string    `json:"content" example:"<h1>ブログタイトル</h1>"`
-	X         int       `json:"x" example:"0"`
-	Y         int       `json:"y" example:"0"`
-	Width     int       `json:"width" example:"100"`
-	Height    int       `json:"height" example:"50"`
-	LayoutId  *uint     `json:"layout_id,omitempty" example:"1"`
-	CreatedAt time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
-	UpdatedAt time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+	ID          uint                      `json:"id" example:"1"`
+	Name        string                    `json:"name" example:"ヘッダーコンポーネント"`
+	Type        string                    `json:"type" example:"header"`
+	Content     string                    `json:"content" example:"<h1>ブログタイトル</h1>"`
+	Properties  json.RawMessage           `json:"properties,omitempty" swaggertype:"object"`
+	X           int                       `json:"x" example:"0"`
+	Y           int                       `json:"y" example:"0"`
+	Width       int                       `json:"width" example:"100"`
+	Height      int                       `json:"height" example:"50"`
+	Breakpoints *BreakpointGeometry       `json:"breakpoints,omitempty"` // タブレット・モバイルで上書きしている位置とサイズ
+	LayoutId    *uint                     `json:"layout_id,omitempty" example:"1"`
+	ParentId    *uint                     `json:"parent_id,omitempty" example:"1"`
+	ZIndex      int                       `json:"z_index" example:"0"`
+	Children    []LayoutComponentResponse `json:"children,omitempty"` // レイアウトを取得した場合のみ、子コンポーネントを入れ子にして返す
+	CreatedAt   time.Time                 `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt   time.Time                 `json:"updated_at" example:"2023-01-01T00:00:00Z"`
 }
+
 // LayoutComponentからLayoutComponentResponseへの変換メソッド
 func (lc *LayoutComponent) ToResponse() LayoutComponentResponse {
-	return LayoutComponentResponse{
-		ID:        lc.ID,
-		Name:      lc.Name,
-		Type:      lc.Type,
-		Content:   lc.Content,
-		X:         lc.X,
-		Y:         lc.Y,
-		Width:     lc.Width,
-		Height:    lc.Height,
-		LayoutId:  lc.LayoutId,
-		CreatedAt: lc.CreatedAt,
-		UpdatedAt: lc.UpdatedAt,
+	response := LayoutComponentResponse{
+		ID:         lc.ID,
+		Name:       lc.Name,
+		Type:       lc.Type,
+		Content:    lc.Content,
+		Properties: lc.PropertiesJSON(),
+		X:          lc.X,
+		Y:          lc.Y,
+		Width:      lc.Width,
+		Height:     lc.Height,
+		LayoutId:   lc.LayoutId,
+		ParentId:   lc.ParentId,
+		ZIndex:     lc.ZIndex,
+		CreatedAt:  lc.CreatedAt,
+		UpdatedAt:  lc.UpdatedAt,
 	}
+	if overrides := lc.BreakpointOverrides(); !overrides.IsEmpty() {
+		response.Breakpoints = &overrides
+	}
+	return response
 }
 
 // LayoutComponentRequestからLayoutComponentへの変換メソッド
 func (lcr *LayoutComponentRequest) ToModel() LayoutComponent {
 	return LayoutComponent{
-		Name:    lcr.Name,
-		Type:    lcr.Type,
-		Content: lcr.Content,
-		X:       lcr.X,
-		Y:       lcr.Y,
-		Width:   lcr.Width,
-		Height:  lcr.Height,
-		UserId:  lcr.UserId,
+		Name:       lcr.Name,
+		Type:       lcr.Type,
+		Content:    lcr.Content,
+		Properties: string(lcr.Properties),
+		X:          lcr.X,
+		Y:          lcr.Y,
+		Width:      lcr.Width,
+		Height:     lcr.Height,
+		ZIndex:     lcr.ZIndex,
+		UserId:     lcr.UserId,
 	}
-}
\ No newline at end of file
+}
+
+// PropertiesJSON はpropertiesをJSONとして返します。未設定の場合はnil
+func (lc *LayoutComponent) PropertiesJSON() json.RawMessage {
+	if lc.Properties == "" {
+		return nil
+	}
+	return json.RawMessage(lc.Properties)
+}
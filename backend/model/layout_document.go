@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// LayoutDocumentVersion 書き出すレイアウトのJSONの形式のバージョン（互換性のない変更をした場合に上げる）
+const LayoutDocumentVersion = 1
+
+// 読み込み時に問題のあったコンポーネントの扱い
+const (
+	ImportActionSkipped  = "skipped"  // 読み込まなかった
+	ImportActionMoved    = "moved"    // 重ならない位置に移動した
+	ImportActionRenamed  = "renamed"  // 名前を変更した（同じタイトルのレイアウトがある場合）
+	ImportActionDetached = "detached" // 親コンポーネントを外し、レイアウトの直下に読み込んだ（位置はそのまま）
+)
+
+// LayoutDocument 環境間の移動やgitでの管理のために書き出すレイアウトのJSON
+// コンポーネントのIDは書き出し元の環境のもので、読み込み時に新しいIDに置き換えられる
+type LayoutDocument struct {
+	SchemaVersion int          `json:"schema_version" example:"1"`
+	ExportedAt    time.Time    `json:"exported_at" example:"2023-01-01T00:00:00Z"`
+	Layout        SnapshotData `json:"layout"`
+}
+
+// LayoutImportResponse レイアウトの読み込み結果
+type LayoutImportResponse struct {
+	Layout    LayoutResponse   `json:"layout"`
+	IdMap     map[uint]uint    `json:"id_map"`    // 書き出し元のコンポーネントIDから作成したコンポーネントのIDへの対応
+	Conflicts []ImportConflict `json:"conflicts"` // 読み込めなかった、または変更して読み込んだ項目
+}
+
+// ImportConflict 読み込み時に問題のあった項目
+type ImportConflict struct {
+	Index       int    `json:"index" example:"2"` // layout.componentsの位置（0始まり）。レイアウト自体の場合は-1
+	ComponentId uint   `json:"component_id,omitempty" example:"5"`
+	Action      string `json:"action" example:"skipped"`
+	Message     string `json:"message" example:"type: 対応していないタイプです: carousel."`
+}
+
+// NewLayoutDocument はレイアウトとそのコンポーネントを書き出すJSONを作成します
+func NewLayoutDocument(layout Layout, exportedAt time.Time) LayoutDocument {
+	return LayoutDocument{
+		SchemaVersion: LayoutDocumentVersion,
+		ExportedAt:    exportedAt,
+		Layout:        NewSnapshotData(layout),
+	}
+}
@@ -0,0 +1,232 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// データベースモデル
+// レイアウトのタイトル・グリッドの設定・コンポーネントの状態をJSONとして保存したスナップショット
+type LayoutSnapshot struct {
+	ID        uint      `json:"id" gorm:"primaryKey" example:"1"`
+	LayoutId  uint      `json:"layout_id" gorm:"not null;index" example:"1"`
+	Layout    Layout    `json:"-" gorm:"foreignKey:LayoutId; constraint:OnDelete:CASCADE"`
+	Version   int       `json:"version" gorm:"not null" example:"3"` // スナップショットを作成したときのレイアウトのバージョン
+	Label     string    `json:"label" gorm:"size:100" example:"公開前の調整"`
+	Data      string    `json:"-" gorm:"type:text;not null"` // SnapshotDataのJSON
+	UserId    uint      `json:"user_id" gorm:"not null" example:"1"`
+	User      User      `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// SnapshotData スナップショットに保存するレイアウトの状態
+type SnapshotData struct {
+	Title      string              `json:"title" example:"ブログのメインレイアウト"`
+	Grid       GridSettings        `json:"grid"`
+	Components []SnapshotComponent `json:"components"`
+}
+
+// SnapshotComponent スナップショットに保存するコンポーネントの状態
+type SnapshotComponent struct {
+	ID          uint                `json:"id" example:"1"`
+	Name        string              `json:"name" example:"ヘッダーコンポーネント"`
+	Type        string              `json:"type" example:"header"`
+	Content     string              `json:"content" example:"<h1>ブログタイトル</h1>"`
+	Properties  json.RawMessage     `json:"properties,omitempty" swaggertype:"object"`
+	X           int                 `json:"x" example:"0"`
+	Y           int                 `json:"y" example:"0"`
+	Width       int                 `json:"width" example:"12"`
+	Height      int                 `json:"height" example:"2"`
+	Breakpoints *BreakpointGeometry `json:"breakpoints,omitempty"`           // タブレット・モバイルで上書きしている位置とサイズ
+	ParentId    *uint               `json:"parent_id,omitempty" example:"1"` // 同じデータ内の親コンポーネントのID
+	ZIndex      int                 `json:"z_index,omitempty" example:"0"`
+}
+
+// リクエスト用の構造体
+type LayoutSnapshotRequest struct {
+	Label string `json:"label" example:"公開前の調整"`
+}
+
+// レスポンス用の構造体
+type LayoutSnapshotResponse struct {
+	ID             uint          `json:"id" example:"1"`
+	LayoutId       uint          `json:"layout_id" example:"1"`
+	Version        int           `json:"version" example:"3"`
+	Label          string        `json:"label" example:"公開前の調整"`
+	Title          string        `json:"title" example:"ブログのメインレイアウト"`
+	ComponentCount int           `json:"component_count" example:"5"`
+	Live           bool          `json:"live" example:"false"` // 公開中のバージョンか
+	Data           *SnapshotData `json:"data,omitempty"`       // 一覧では省略する
+	CreatedAt      time.Time     `json:"created_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// LayoutDiffResponse 2つの状態の差分
+type LayoutDiffResponse struct {
+	FromSnapshotId uint                `json:"from_snapshot_id" example:"1"`
+	ToSnapshotId   *uint               `json:"to_snapshot_id" example:"2"` // nullの場合は現在のレイアウトと比較
+	Changes        []FieldChange       `json:"changes"`                    // タイトルとグリッドの設定の変更
+	Added          []SnapshotComponent `json:"added"`
+	Removed        []SnapshotComponent `json:"removed"`
+	Changed        []ComponentChange   `json:"changed"`
+}
+
+// FieldChange 項目の変更前後の値
+type FieldChange struct {
+	Field string      `json:"field" example:"x"`
+	From  interface{} `json:"from" swaggertype:"string" example:"0"`
+	To    interface{} `json:"to" swaggertype:"string" example:"4"`
+}
+
+// ComponentChange コンポーネントごとの変更
+type ComponentChange struct {
+	ComponentId uint          `json:"component_id" example:"1"`
+	Name        string        `json:"name" example:"ヘッダーコンポーネント"`
+	Changes     []FieldChange `json:"changes"`
+}
+
+// NewSnapshotData はレイアウトとそのコンポーネントの現在の状態からスナップショットのデータを作成します
+func NewSnapshotData(layout Layout) SnapshotData {
+	data := SnapshotData{
+		Title: layout.Title,
+		// 既定値を補わずに保存し、ロールバックで元の設定に戻せるようにする
+		Grid: GridSettings{
+			Columns:       layout.GridColumns,
+			RowHeight:     layout.RowHeight,
+			CanvasWidth:   layout.CanvasWidth,
+			CollisionMode: layout.CollisionMode,
+		},
+		Components: make([]SnapshotComponent, len(layout.Components)),
+	}
+	for i, component := range layout.Components {
+		data.Components[i] = SnapshotComponent{
+			ID:         component.ID,
+			Name:       component.Name,
+			Type:       component.Type,
+			Content:    component.Content,
+			Properties: component.PropertiesJSON(),
+			X:          component.X,
+			Y:          component.Y,
+			Width:      component.Width,
+			Height:     component.Height,
+			ParentId:   component.ParentId,
+			ZIndex:     component.ZIndex,
+		}
+		if overrides := component.BreakpointOverrides(); !overrides.IsEmpty() {
+			data.Components[i].Breakpoints = &overrides
+		}
+	}
+	return data
+}
+
+// NewLayoutSnapshot はレイアウトの現在の状態を保存するスナップショットを作成します
+func NewLayoutSnapshot(layout Layout, label string) (LayoutSnapshot, error) {
+	data, err := json.Marshal(NewSnapshotData(layout))
+	if err != nil {
+		return LayoutSnapshot{}, err
+	}
+	return LayoutSnapshot{
+		LayoutId: layout.ID,
+		Version:  layout.Version,
+		Label:    label,
+		Data:     string(data),
+		UserId:   layout.UserId,
+	}, nil
+}
+
+// ParseData は保存されているレイアウトの状態を返します
+func (s *LayoutSnapshot) ParseData() (SnapshotData, error) {
+	var data SnapshotData
+	err := json.Unmarshal([]byte(s.Data), &data)
+	return data, err
+}
+
+// SnapshotDataからLayoutへの変換メソッド（スナップショットの状態を描画するため）
+func (d *SnapshotData) ToLayout(layoutId uint, userId uint) Layout {
+	layout := Layout{
+		ID:            layoutId,
+		Title:         d.Title,
+		GridColumns:   d.Grid.Columns,
+		RowHeight:     d.Grid.RowHeight,
+		CanvasWidth:   d.Grid.CanvasWidth,
+		CollisionMode: d.Grid.CollisionMode,
+		UserId:        userId,
+		Components:    make([]LayoutComponent, len(d.Components)),
+	}
+	for i, component := range d.Components {
+		layout.Components[i] = LayoutComponent{
+			ID:         component.ID,
+			Name:       component.Name,
+			Type:       component.Type,
+			Content:    component.Content,
+			Properties: string(component.Properties),
+			X:          component.X,
+			Y:          component.Y,
+			Width:      component.Width,
+			Height:     component.Height,
+			ParentId:   component.ParentId,
+			ZIndex:     component.ZIndex,
+			UserId:     userId,
+			LayoutId:   &layout.ID,
+		}
+		if component.Breakpoints != nil {
+			layout.Components[i].SetBreakpointOverrides(*component.Breakpoints)
+		}
+	}
+	return layout
+}
+
+// LayoutSnapshotからLayoutSnapshotResponseへの変換メソッド
+func (s *LayoutSnapshot) ToResponse(live bool, withData bool) (LayoutSnapshotResponse, error) {
+	data, err := s.ParseData()
+	if err != nil {
+		return LayoutSnapshotResponse{}, err
+	}
+	response := LayoutSnapshotResponse{
+		ID:             s.ID,
+		LayoutId:       s.LayoutId,
+		Version:        s.Version,
+		Label:          s.Label,
+		Title:          data.Title,
+		ComponentCount: len(data.Components),
+		Live:           live,
+		CreatedAt:      s.CreatedAt,
+	}
+	if withData {
+		response.Data = &data
+	}
+	return response, nil
+}
+
+// ParentIndexes はコンポーネントの親子関係を、Components内の位置で子から親への対応として返します
+// 新しいIDで保存するときに親子関係を設定し直すために使う。データ内に親が見つからない場合は親を持たないものとして扱う
+func (d *SnapshotData) ParentIndexes() map[int]int {
+	indexes := make(map[uint]int, len(d.Components))
+	for i, component := range d.Components {
+		if component.ID != 0 {
+			indexes[component.ID] = i
+		}
+	}
+	parents := make(map[int]int)
+	for i, component := range d.Components {
+		if component.ParentId == nil {
+			continue
+		}
+		if parent, ok := indexes[*component.ParentId]; ok && parent != i {
+			parents[i] = parent
+		}
+	}
+	return parents
+}
+
+// NewLayout はスナップショットの状態から新しいレイアウトを作成します（IDを持たないため、保存すると別のレイアウトとコンポーネントになる）
+// 親子関係は保存後にParentIndexesで設定する
+func (d *SnapshotData) NewLayout(title string, userId uint) Layout {
+	layout := d.ToLayout(0, userId)
+	layout.Title = title
+	for i := range layout.Components {
+		layout.Components[i].ID = 0
+		layout.Components[i].LayoutId = nil
+		layout.Components[i].ParentId = nil
+	}
+	return layout
+}
@@ -0,0 +1,162 @@
+package model
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// データベースモデル
+// 他のユーザーも使えるように公開したレイアウトの構成（コンポーネントの種類・位置・見た目の設定）
+type LayoutTemplate struct {
+	ID          uint      `json:"id" gorm:"primaryKey" example:"1"`
+	Name        string    `json:"name" gorm:"size:100;not null" example:"2カラムのブログ"`
+	Description string    `json:"description" gorm:"size:500" example:"ヘッダー・サイドバー・フッターのある基本的な構成"`
+	Data        string    `json:"-" gorm:"type:text;not null"` // SnapshotDataのJSON
+	UserId      uint      `json:"user_id" gorm:"not null;index" example:"1"`
+	User        User      `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// リクエスト用の構造体
+type LayoutTemplateRequest struct {
+	LayoutId    uint   `json:"layout_id" example:"1"` // テンプレートとして公開するレイアウト
+	Name        string `json:"name" example:"2カラムのブログ"`
+	Description string `json:"description" example:"ヘッダー・サイドバー・フッターのある基本的な構成"`
+	UserId      uint   `json:"-"` // クライアントからは送信されず、JWTから取得
+}
+
+// InstantiateTemplateRequest テンプレートからレイアウトを作成するリクエスト
+type InstantiateTemplateRequest struct {
+	Title string `json:"title" example:"新しいブログのレイアウト"` // 省略した場合はテンプレートの名前
+}
+
+// レスポンス用の構造体
+type LayoutTemplateResponse struct {
+	ID             uint          `json:"id" example:"1"`
+	Name           string        `json:"name" example:"2カラムのブログ"`
+	Description    string        `json:"description" example:"ヘッダー・サイドバー・フッターのある基本的な構成"`
+	Author         string        `json:"author" example:"gopher"` // 公開したユーザーのユーザー名（未設定の場合は空文字）
+	Own            bool          `json:"own" example:"true"`      // ログインユーザーが公開したテンプレートか
+	Grid           GridSettings  `json:"grid"`
+	ComponentCount int           `json:"component_count" example:"4"`
+	Data           *SnapshotData `json:"data,omitempty"` // 一覧では省略する
+	CreatedAt      time.Time     `json:"created_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// NewLayoutTemplate はレイアウトの構成をテンプレートとして作成します
+// コンポーネントの本文（content）と、propertiesのうちタイトル・リンク・画像・データの取得元のIDなど
+// 公開したユーザーの内容（ComponentType.ContentProperties）は取り除き、種類・位置・サイズ・見た目の設定だけを引き継ぐ
+// 取り除く項目が必須の種類（フィードなど、取得元を選び直す必要があるもの）は、テンプレートから作成したレイアウトが
+// 種類の定義に適合しなくなるためテンプレートに含めない
+func NewLayoutTemplate(layout Layout, name string, description string) (LayoutTemplate, error) {
+	data := NewSnapshotData(layout)
+	components := make([]SnapshotComponent, 0, len(data.Components))
+	for _, component := range data.Components {
+		if definition, ok := FindComponentType(component.Type); ok && requiresContentProperty(definition) {
+			continue
+		}
+		components = append(components, component)
+	}
+	data.Components = components
+
+	// IDはテンプレート内の親子関係を表すための連番に置き換える（含めなかった親の子はレイアウトの直下に置く）
+	renumbered := make(map[uint]uint, len(data.Components))
+	for i, component := range data.Components {
+		renumbered[component.ID] = uint(i + 1)
+	}
+	for i, component := range data.Components {
+		data.Components[i].ID = uint(i + 1)
+		data.Components[i].Content = ""
+		properties, err := templateProperties(component.Type, component.Properties)
+		if err != nil {
+			return LayoutTemplate{}, err
+		}
+		data.Components[i].Properties = properties
+		if component.ParentId != nil {
+			if parentId, ok := renumbered[*component.ParentId]; ok {
+				data.Components[i].ParentId = &parentId
+			} else {
+				data.Components[i].ParentId = nil
+			}
+		}
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return LayoutTemplate{}, err
+	}
+	return LayoutTemplate{
+		Name:        name,
+		Description: description,
+		Data:        string(encoded),
+		UserId:      layout.UserId,
+	}, nil
+}
+
+// templateProperties はpropertiesから種類ごとのユーザーの内容の項目を取り除きます
+// 定義のない種類の場合や、残る項目がない場合は空にする
+func templateProperties(componentType string, properties json.RawMessage) (json.RawMessage, error) {
+	definition, ok := FindComponentType(componentType)
+	if !ok || len(properties) == 0 {
+		return nil, nil
+	}
+	values := map[string]json.RawMessage{}
+	if err := json.Unmarshal(properties, &values); err != nil {
+		return nil, err
+	}
+	for _, key := range definition.ContentProperties {
+		delete(values, key)
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(values)
+}
+
+// requiresContentProperty は種類のJSON Schemaで、ユーザーの内容の項目のいずれかが必須かを返します
+func requiresContentProperty(definition ComponentType) bool {
+	var schema struct {
+		Required []string `json:"required"`
+	}
+	if err := json.Unmarshal(definition.Schema, &schema); err != nil {
+		return false
+	}
+	for _, key := range definition.ContentProperties {
+		if slices.Contains(schema.Required, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseData は保存されているレイアウトの構成を返します
+func (t *LayoutTemplate) ParseData() (SnapshotData, error) {
+	var data SnapshotData
+	err := json.Unmarshal([]byte(t.Data), &data)
+	return data, err
+}
+
+// LayoutTemplateからLayoutTemplateResponseへの変換メソッド
+// Userがロードされている場合のみ公開したユーザーの名前を含める
+func (t *LayoutTemplate) ToResponse(userId uint, withData bool) (LayoutTemplateResponse, error) {
+	data, err := t.ParseData()
+	if err != nil {
+		return LayoutTemplateResponse{}, err
+	}
+	grid := data.ToLayout(0, t.UserId)
+	response := LayoutTemplateResponse{
+		ID:             t.ID,
+		Name:           t.Name,
+		Description:    t.Description,
+		Author:         t.User.Username,
+		Own:            t.UserId == userId,
+		Grid:           grid.GridSettings(),
+		ComponentCount: len(data.Components),
+		CreatedAt:      t.CreatedAt,
+	}
+	if withData {
+		response.Data = &data
+	}
+	return response, nil
+}
@@ -0,0 +1,81 @@
+package model
+
+import "time"
+
+const (
+	MediaReferenceArticle         = "article"
+	MediaReferenceLayoutComponent = "layout_component"
+	MediaReferenceBook            = "book"
+	MediaReferenceLayoutSnapshot  = "layout_snapshot"
+	MediaReferenceLayoutTemplate  = "layout_template"
+)
+
+// Media アップロードされた画像のデータベースモデル
+type Media struct {
+	ID           uint      `json:"id" gorm:"primaryKey" example:"1"`
+	FileName     string    `json:"file_name" gorm:"not null" example:"cover.png"`
+	MimeType     string    `json:"mime_type" gorm:"not null" example:"image/png"`
+	Size         int64     `json:"size" gorm:"not null" example:"102400"`
+	Width        int       `json:"width" example:"1200"`
+	Height       int       `json:"height" example:"630"`
+	StorageKey   string    `json:"-" gorm:"not null;uniqueIndex"`
+	ThumbnailKey string    `json:"-"`
+	URL          string    `json:"url" gorm:"not null;index" example:"/media/files/1/3f2a9c.png"`
+	ThumbnailURL string    `json:"thumbnail_url" example:"/media/files/1/3f2a9c_thumb.png"`
+	CreatedAt    time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt    time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+	User         User      `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	UserId       uint      `json:"user_id" gorm:"not null;index" example:"1"`
+}
+
+// MediaResponse メディアのレスポンス
+type MediaResponse struct {
+	ID           uint      `json:"id" example:"1"`
+	FileName     string    `json:"file_name" example:"cover.png"`
+	MimeType     string    `json:"mime_type" example:"image/png"`
+	Size         int64     `json:"size" example:"102400"`
+	Width        int       `json:"width" example:"1200"`
+	Height       int       `json:"height" example:"630"`
+	URL          string    `json:"url" example:"/media/files/1/3f2a9c.png"`
+	ThumbnailURL string    `json:"thumbnail_url" example:"/media/files/1/3f2a9c_thumb.png"`
+	CreatedAt    time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt    time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// MediaListResponse メディア一覧と容量の使用状況のレスポンス
+type MediaListResponse struct {
+	Items     []MediaResponse `json:"items"`
+	UsedBytes int64           `json:"used_bytes" example:"1048576"`
+	Quota     int64           `json:"quota" example:"104857600"`
+}
+
+// MediaReference メディアを参照しているリソース
+type MediaReference struct {
+	Type  string `json:"type" example:"article"`
+	ID    uint   `json:"id" example:"1"`
+	Title string `json:"title" example:"Goプログラミングの基礎"`
+}
+
+// ToResponse MediaからMediaResponseへの変換メソッド
+func (m *Media) ToResponse() MediaResponse {
+	return MediaResponse{
+		ID:           m.ID,
+		FileName:     m.FileName,
+		MimeType:     m.MimeType,
+		Size:         m.Size,
+		Width:        m.Width,
+		Height:       m.Height,
+		URL:          m.URL,
+		ThumbnailURL: m.ThumbnailURL,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+// MediaUploadRequest アップロードされたファイルの検証用リクエスト
+type MediaUploadRequest struct {
+	FileName string
+	MimeType string // ファイルの内容から判定したMIMEタイプ
+	Size     int64
+	UserId   uint
+}
@@ -0,0 +1,95 @@
+package model
+
+import "time"
+
+// ResponseMapping 外部APIの応答（JSON）からカードの一覧を作る変換の定義
+// ItemsPathで応答から項目の配列を取り出し、項目ごとにFieldsのテンプレートでカードの各項目を作る
+type ResponseMapping struct {
+	ID            uint              `json:"id" gorm:"primaryKey" example:"1"`
+	Name          string            `json:"name" gorm:"size:100;not null" example:"GitHubのリポジトリ"`
+	ExternalAPIId uint              `json:"external_api_id" gorm:"not null;index" example:"1"`
+	ExternalAPI   ExternalAPI       `json:"-" gorm:"foreignKey:ExternalAPIId; constraint:OnDelete:CASCADE"`
+	Params        map[string]string `json:"params" gorm:"serializer:json;type:text"` // 外部APIの呼び出しでパスとクエリパラメータの{name}を置き換える値
+	ItemsPath     string            `json:"items_path" gorm:"size:500;not null" example:"$.items[*]"`
+	Fields        CardTemplate      `json:"fields" gorm:"serializer:json;type:text"`
+	UserId        uint              `json:"user_id" gorm:"not null;index"`
+	User          User              `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+// CardTemplate カードの項目ごとのテンプレート（{{ パス式 }}を埋め込んだ文字列）
+// パス式は項目を起点とし、"$"で始めると応答全体を起点とする
+type CardTemplate struct {
+	ID       string `json:"id,omitempty" example:"{{ id }}"`
+	Title    string `json:"title" example:"{{ full_name }}"`
+	URL      string `json:"url,omitempty" example:"{{ html_url }}"`
+	Excerpt  string `json:"excerpt,omitempty" example:"{{ description }}"`
+	ImageURL string `json:"image_url,omitempty" example:"{{ owner.avatar_url }}"`
+	Date     string `json:"date,omitempty" example:"{{ updated_at }}"` // RFC3339・YYYY-MM-DDの文字列、またはUNIX時間（秒）
+	Tags     string `json:"tags,omitempty" example:"{{ topics }}"`     // 1つのパス式のみ。一致したすべての値（配列は要素）をタグにする
+}
+
+// ResponseMappingRequest 変換の定義を作成・更新、またはプレビューするリクエスト
+type ResponseMappingRequest struct {
+	Name          string            `json:"name" example:"GitHubのリポジトリ"`
+	ExternalAPIId uint              `json:"external_api_id" example:"1"`
+	Params        map[string]string `json:"params,omitempty"`
+	ItemsPath     string            `json:"items_path" example:"$.items[*]"`
+	Fields        CardTemplate      `json:"fields"`
+	UserId        uint              `json:"-"` // クライアントからは送信されず、JWTから取得
+}
+
+// ResponseMappingResponse 変換の定義
+type ResponseMappingResponse struct {
+	ID            uint              `json:"id" example:"1"`
+	Name          string            `json:"name" example:"GitHubのリポジトリ"`
+	ExternalAPIId uint              `json:"external_api_id" example:"1"`
+	Params        map[string]string `json:"params,omitempty"`
+	ItemsPath     string            `json:"items_path" example:"$.items[*]"`
+	Fields        CardTemplate      `json:"fields"`
+	CreatedAt     time.Time         `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt     time.Time         `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// MappingError 変換できなかった箇所（値の型が合わないなど）
+type MappingError struct {
+	Item    *int   `json:"item,omitempty" example:"0"` // 項目の番号（0始まり）。応答全体の問題の場合は省略
+	Field   string `json:"field,omitempty" example:"date"`
+	Message string `json:"message" example:"cannot parse \"yesterday\" as a date"`
+}
+
+// ResponseMappingPreviewResponse 変換のプレビュー
+// 変換できなかった項目はErrorsに含め、タイトルを作れなかった項目はカードに含めない
+type ResponseMappingPreviewResponse struct {
+	StatusCode int                 `json:"status_code" example:"200"` // 外部APIのステータスコード
+	Total      int                 `json:"total" example:"30"`        // ItemsPathに一致した項目の数
+	Cards      []ComponentDataItem `json:"cards"`
+	Errors     []MappingError      `json:"errors"`
+}
+
+// ResponseMappingRequestからResponseMappingへの変換メソッド
+func (r *ResponseMappingRequest) ToModel() ResponseMapping {
+	return ResponseMapping{
+		Name:          r.Name,
+		ExternalAPIId: r.ExternalAPIId,
+		Params:        r.Params,
+		ItemsPath:     r.ItemsPath,
+		Fields:        r.Fields,
+		UserId:        r.UserId,
+	}
+}
+
+// ResponseMappingからResponseMappingResponseへの変換メソッド
+func (m *ResponseMapping) ToResponse() ResponseMappingResponse {
+	return ResponseMappingResponse{
+		ID:            m.ID,
+		Name:          m.Name,
+		ExternalAPIId: m.ExternalAPIId,
+		Params:        m.Params,
+		ItemsPath:     m.ItemsPath,
+		Fields:        m.Fields,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
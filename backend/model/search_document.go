@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// 検索対象の種類
+const (
+	SearchSourceArticle     = "article"
+	SearchSourceBook        = "book"
+	SearchSourceFeedArticle = "feed_article"
+)
+
+// SearchDocument 全文検索インデックスのドキュメント
+type SearchDocument struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	SourceType string    `json:"source_type" gorm:"not null;uniqueIndex:idx_search_documents_source"`
+	SourceID   string    `json:"source_id" gorm:"not null;uniqueIndex:idx_search_documents_source"`
+	Title      string    `json:"title"`
+	Body       string    `json:"body" gorm:"type:text"`
+	Tags       string    `json:"tags"`
+	URL        string    `json:"url"`
+	Tokens     string    `json:"-" gorm:"type:text"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	User       User      `json:"-" gorm:"foreignKey:UserId;constraint:OnDelete:CASCADE"`
+	UserId     uint      `json:"user_id" gorm:"not null;index;uniqueIndex:idx_search_documents_source"`
+}
+
+// SearchResult 検索結果の1件
+type SearchResult struct {
+	SourceType string    `json:"source_type" example:"article"`
+	SourceID   string    `json:"source_id" example:"1"`
+	Title      string    `json:"title" example:"<mark>Go</mark>プログラミングの基礎"`
+	Snippet    string    `json:"snippet" example:"…<mark>Go</mark>は静的型付け言語です…"`
+	URL        string    `json:"url,omitempty" example:"https://example.com/entry/1"`
+	Score      float64   `json:"score" example:"3.5"`
+	UpdatedAt  time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// SearchResponse 検索レスポンス
+type SearchResponse struct {
+	Query string         `json:"query" example:"Go"`
+	Total int            `json:"total" example:"1"`
+	Items []SearchResult `json:"items"`
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// 連携サービスの認証情報を保存する秘密情報の名前
+const (
+	SecretNameQiitaAccessToken  = "qiita_access_token"
+	SecretNameGoogleBooksAPIKey = "google_books_api_key"
+)
+
+// ExternalAPISecretName は外部APIの認証情報を保存する秘密情報の名前を返します
+// 秘密情報のAPIで指定できる名前と重ならないよう":"を含める
+func ExternalAPISecretName(apiId uint) string {
+	return fmt.Sprintf("external_api:%d", apiId)
+}
+
+// Secret ユーザーごとの秘密情報（APIキーやアクセストークン）
+// 値はエンベロープ暗号化して保存し、APIからは読み出せない
+type Secret struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	Name       string    `json:"name" gorm:"size:100;not null;uniqueIndex:idx_secrets_user_name"`
+	KeyId      string    `json:"-" gorm:"size:64;not null;index"` // データキーの暗号化に使ったマスターキーのID
+	WrappedKey []byte    `json:"-" gorm:"not null"`               // マスターキーで暗号化したデータキー
+	Ciphertext []byte    `json:"-" gorm:"not null"`               // データキーで暗号化した値
+	UserId     uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_secrets_user_name"`
+	User       User      `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// SecretRequest 秘密情報の値を保存するリクエスト
+type SecretRequest struct {
+	Value string `json:"value" validate:"required" example:"qiita-access-token"`
+}
+
+// SecretResponse 保存されている秘密情報（値は含めない）
+type SecretResponse struct {
+	Name      string    `json:"name" example:"qiita_access_token"`
+	CreatedAt time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// SecretからSecretResponseへの変換メソッド
+func (s *Secret) ToResponse() SecretResponse {
+	return SecretResponse{
+		Name:      s.Name,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
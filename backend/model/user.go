@@ -7,14 +7,17 @@ type User struct {
 	ID        uint      `json:"id" gorm:"primaryKey" example:"1"`
 	Email     string    `json:"email" gorm:"unique" example:"user@example.com"`
 	Password  string    `json:"password" example:"password123"`
+	// Username は公開URL（/public/:username/...）で使用する名前。未設定の場合は空文字
+	Username  string    `json:"username" gorm:"uniqueIndex:idx_users_username,where:username <> ''" example:"gopher"`
 	CreatedAt time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
 	UpdatedAt time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
 }
 
 // UserResponse はクライアントに返すユーザー情報
 type UserResponse struct {
-	ID    uint   `json:"id" example:"1"`
-	Email string `json:"email" example:"user@example.com"`
+	ID       uint   `json:"id" example:"1"`
+	Email    string `json:"email" example:"user@example.com"`
+	Username string `json:"username,omitempty" example:"gopher"`
 }
 
 // UserLoginRequest はログインリクエスト用の構造体
@@ -27,6 +30,12 @@ type UserLoginRequest struct {
 type UserSignupRequest struct {
 	Email    string `json:"email" validate:"required,email" example:"user@example.com"`
 	Password string `json:"password" validate:"required" example:"password123"`
+	Username string `json:"username,omitempty" example:"gopher"`
+}
+
+// UsernameRequest はユーザー名の変更リクエスト用の構造体
+type UsernameRequest struct {
+	Username string `json:"username" validate:"required" example:"gopher"`
 }
 
 // ToUser はUserSignupRequestからUserへの変換メソッド
@@ -34,6 +43,7 @@ func (r *UserSignupRequest) ToUser() User {
 	return User{
 		Email:    r.Email,
 		Password: r.Password,
+		Username: r.Username,
 	}
 }
 
@@ -45,7 +55,8 @@ type CsrfTokenResponse struct {
 // ToUserResponse はUserからUserResponseへの変換メソッド
 func (u *User) ToUserResponse() UserResponse {
 	return UserResponse{
-		ID:    u.ID,
-		Email: u.Email,
+		ID:       u.ID,
+		Email:    u.Email,
+		Username: u.Username,
 	}
 }
\ No newline at end of file
@@ -3,6 +3,7 @@ package repository
 import (
 	"fmt"
 	"go-react-app/model"
+	"strings"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -14,6 +15,9 @@ type IArticleRepository interface {
 	CreateArticle(article *model.Article) error
 	UpdateArticle(article *model.Article, userId uint, articleId uint) error
 	DeleteArticle(userId uint, articleId uint) error
+	GetPublishedArticles(articles *[]model.Article, userId uint, tag string, limit int) error
+	GetIndexableArticles(articles *[]model.Article, userId uint) error
+	GetPublishedArticleById(article *model.Article, userId uint, articleId uint) error
 }
 
 type articleRepository struct {
@@ -47,10 +51,15 @@ func (ar *articleRepository) CreateArticle(article *model.Article) error {
 
 func (ar *articleRepository) UpdateArticle(article *model.Article, userId uint, articleId uint) error {
 	result := ar.db.Model(article).Clauses(clause.Returning{}).Where("id=? AND user_id=?", articleId, userId).Updates(map[string]interface{}{
-		"title":     article.Title,
-		"content":   article.Content,
-		"published": article.Published,
-		"tags":      article.Tags,
+		"title":            article.Title,
+		"content":          article.Content,
+		"published":        article.Published,
+		"tags":             article.Tags,
+		"meta_description": article.MetaDescription,
+		"og_image":         article.OGImage,
+		"canonical_url":    article.CanonicalURL,
+		"no_index":         article.NoIndex,
+		"layout_id":        article.LayoutId,
 	})
 	if result.Error != nil {
 		return result.Error
@@ -71,3 +80,31 @@ func (ar *articleRepository) DeleteArticle(userId uint, articleId uint) error {
 	}
 	return nil
 }
+
+// GetPublishedArticles は公開済みの記事を更新日時の新しい順に取得します
+// tagが指定された場合は、カンマ区切りのタグにtagを含む記事のみを返します
+func (ar *articleRepository) GetPublishedArticles(articles *[]model.Article, userId uint, tag string, limit int) error {
+	query := ar.db.Where("user_id=? AND published=?", userId, true)
+	if tag != "" {
+		// "Go, Web" のような空白を含む区切りにも対応するため、空白を除いた上で前後にカンマを付けて比較する
+		query = query.Where("(',' || REPLACE(tags, ' ', '') || ',') LIKE ? ESCAPE '\\'", "%,"+escapeLike(strings.ReplaceAll(tag, " ", ""))+",%")
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	return query.Order("updated_at DESC").Find(articles).Error
+}
+
+// GetIndexableArticles はサイトマップに含める記事（公開済みかつnoindexでない記事）をID順に取得します
+// サイトマップの生成に必要な列のみを取得します
+func (ar *articleRepository) GetIndexableArticles(articles *[]model.Article, userId uint) error {
+	return ar.db.Select("id", "canonical_url", "updated_at").
+		Where("user_id=? AND published=? AND no_index=?", userId, true, false).
+		Order("id").
+		Find(articles).Error
+}
+
+// GetPublishedArticleById は公開済みの記事のみを取得します
+func (ar *articleRepository) GetPublishedArticleById(article *model.Article, userId uint, articleId uint) error {
+	return ar.db.Where("user_id=? AND published=?", userId, true).First(article, articleId).Error
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"go-react-app/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IBookImportRepository interface {
+	GetAllImports(userId uint) ([]model.BookImportJob, error)
+	GetImportById(userId uint, importId uint) (model.BookImportJob, error)
+	CreateImport(job *model.BookImportJob) error
+	UpdateImport(job *model.BookImportJob) error
+	HasActiveImport(userId uint) (bool, error)
+	FailInterruptedImports(message string, finishedAt time.Time) (int64, error)
+}
+
+type bookImportRepository struct {
+	db *gorm.DB
+}
+
+func NewBookImportRepository(db *gorm.DB) IBookImportRepository {
+	return &bookImportRepository{db}
+}
+
+func (ir *bookImportRepository) GetAllImports(userId uint) ([]model.BookImportJob, error) {
+	var jobs []model.BookImportJob
+	if err := ir.db.Where("user_id=?", userId).Order("created_at DESC, id DESC").Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (ir *bookImportRepository) GetImportById(userId uint, importId uint) (model.BookImportJob, error) {
+	var job model.BookImportJob
+	if err := ir.db.Where("user_id=?", userId).First(&job, importId).Error; err != nil {
+		return model.BookImportJob{}, err
+	}
+	return job, nil
+}
+
+func (ir *bookImportRepository) CreateImport(job *model.BookImportJob) error {
+	return ir.db.Create(job).Error
+}
+
+// UpdateImport はジョブの進捗と1行ごとの結果を保存します
+func (ir *bookImportRepository) UpdateImport(job *model.BookImportJob) error {
+	return ir.db.Model(job).Select(
+		"Status", "Processed", "Imported", "Duplicates", "NotFound", "Failed", "Error", "Rows", "StartedAt", "FinishedAt",
+	).Updates(job).Error
+}
+
+// HasActiveImport はユーザーに待機中または実行中のジョブがあるかを返します
+func (ir *bookImportRepository) HasActiveImport(userId uint) (bool, error) {
+	var count int64
+	err := ir.db.Model(&model.BookImportJob{}).
+		Where("user_id=? AND status IN ?", userId, []string{model.BookImportStatusPending, model.BookImportStatusRunning}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FailInterruptedImports は待機中または実行中のまま残っているすべてのジョブを失敗にし、更新した件数を返します
+func (ir *bookImportRepository) FailInterruptedImports(message string, finishedAt time.Time) (int64, error) {
+	result := ir.db.Model(&model.BookImportJob{}).
+		Where("status IN ?", []string{model.BookImportStatusPending, model.BookImportStatusRunning}).
+		Updates(map[string]interface{}{
+			"status":      model.BookImportStatusFailed,
+			"error":       message,
+			"finished_at": finishedAt,
+		})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"errors"
+	"go-react-app/model"
+	"net/http"
+	"time"
+)
+
+// ErrBookMetadataNotFound はプロバイダーにISBNの書籍がない場合のエラー
+var ErrBookMetadataNotFound = errors.New("book metadata not found")
+
+// bookMetadataTimeout 1つのプロバイダーの呼び出しの上限時間
+const bookMetadataTimeout = 10 * time.Second
+
+// metadataClient openBD・国立国会図書館サーチ・Google Books APIの呼び出しに使うHTTPクライアント
+var metadataClient = &http.Client{Timeout: bookMetadataTimeout}
+
+// IBookMetadataProvider はISBNから書籍の情報を取得する取得元です
+type IBookMetadataProvider interface {
+	// Name はmodel.BookMetadataProviderGoogleなどのプロバイダーの名前を返します
+	Name() string
+	// LookupISBN はハイフンのないISBN-13の書籍の情報を取得します。ない場合はErrBookMetadataNotFound
+	LookupISBN(userId uint, isbn string) (model.BookMetadata, error)
+}
+
+type googleBookMetadataProvider struct {
+	gbr IGoogleBookRepository
+}
+
+// NewGoogleBookMetadataProvider はGoogle Books APIの検索で書籍の情報を取得するプロバイダーを作成します
+func NewGoogleBookMetadataProvider(gbr IGoogleBookRepository) IBookMetadataProvider {
+	return &googleBookMetadataProvider{gbr}
+}
+
+func (p *googleBookMetadataProvider) Name() string {
+	return model.BookMetadataProviderGoogle
+}
+
+func (p *googleBookMetadataProvider) LookupISBN(userId uint, isbn string) (model.BookMetadata, error) {
+	result, err := p.gbr.SearchBooks(userId, "isbn:"+isbn, 1)
+	if err != nil {
+		return model.BookMetadata{}, err
+	}
+	if len(result.Items) == 0 {
+		return model.BookMetadata{}, ErrBookMetadataNotFound
+	}
+	book := result.Items[0]
+	return model.BookMetadata{
+		ISBN:          isbn,
+		Title:         book.Title,
+		Authors:       book.Authors,
+		Publisher:     book.Publisher,
+		PublishedDate: book.PublishedDate,
+		Description:   book.Description,
+		ImageURL:      book.ImageURL,
+		PageCount:     book.PageCount,
+		Sources:       []string{model.BookMetadataProviderGoogle},
+	}, nil
+}
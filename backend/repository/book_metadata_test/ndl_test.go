@@ -0,0 +1,65 @@
+package book_metadata_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"reflect"
+	"testing"
+)
+
+const ndlResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<rss xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:dcndl="http://ndl.go.jp/dcndl/terms/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" version="2.0">
+  <channel>
+    <title>Go言語による並行処理 - 国立国会図書館サーチ OpenSearch</title>
+    <item>
+      <title>Go言語による並行処理</title>
+      <author>Katherine Cox-Buday 著,山口能迪 訳</author>
+      <dc:title>Go言語による並行処理</dc:title>
+      <dc:creator>Cox-Buday, Katherine</dc:creator>
+      <dc:creator>山口, 能迪</dc:creator>
+      <dc:publisher>オライリー・ジャパン</dc:publisher>
+      <dc:publisher>オーム社 (発売)</dc:publisher>
+      <dcterms:issued xsi:type="dcterms:W3CDTF">2018.10</dcterms:issued>
+      <dc:extent>xvi, 238p ; 24cm</dc:extent>
+      <dc:identifier xsi:type="dcndl:ISBN">9784873118468</dc:identifier>
+    </item>
+  </channel>
+</rss>`
+
+const ndlEmptyResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"><channel><title>国立国会図書館サーチ OpenSearch</title></channel></rss>`
+
+func TestNDLMetadataProvider_LookupISBN(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("Dublin Coreの項目から書籍の情報を取得する", func(t *testing.T) {
+			server := newMetadataServer(t, "/opensearch", "application/xml", map[string]string{testISBN: ndlResponse}, ndlEmptyResponse)
+
+			got, err := repository.NewNDLMetadataProvider(server.URL).LookupISBN(1, testISBN)
+			if err != nil {
+				t.Fatalf("LookupISBN() error = %v", err)
+			}
+			want := model.BookMetadata{
+				ISBN:          testISBN,
+				Title:         "Go言語による並行処理",
+				Authors:       []string{"Cox-Buday, Katherine", "山口, 能迪"},
+				Publisher:     "オライリー・ジャパン",
+				PublishedDate: "2018-10",
+				PageCount:     238,
+				Sources:       []string{model.BookMetadataProviderNDL},
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("LookupISBN() = %+v, want %+v", got, want)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("検索結果がない場合はErrBookMetadataNotFound", func(t *testing.T) {
+			server := newMetadataServer(t, "/opensearch", "application/xml", nil, ndlEmptyResponse)
+			if _, err := repository.NewNDLMetadataProvider(server.URL).LookupISBN(1, "9784000000000"); !errors.Is(err, repository.ErrBookMetadataNotFound) {
+				t.Errorf("LookupISBN() error = %v, want ErrBookMetadataNotFound", err)
+			}
+		})
+	})
+}
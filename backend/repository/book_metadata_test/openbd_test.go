@@ -0,0 +1,78 @@
+package book_metadata_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const openBDResponse = `[{
+  "onix": {
+    "DescriptiveDetail": {"Extent": [{"ExtentType": "11", "ExtentValue": "238", "ExtentUnit": "03"}]},
+    "CollateralDetail": {"TextContent": [
+      {"TextType": "02", "ContentAudience": "00", "Text": "並行処理の入門書"},
+      {"TextType": "03", "ContentAudience": "00", "Text": "Go言語の並行処理について解説した書籍"}
+    ]}
+  },
+  "summary": {
+    "isbn": "9784873118468",
+    "title": "Go言語による並行処理",
+    "volume": "",
+    "series": "",
+    "publisher": "オライリー・ジャパン",
+    "pubdate": "20181026",
+    "cover": "https://cover.openbd.jp/9784873118468.jpg",
+    "author": "Cox-Buday,Katherine／著 山口能迪／翻訳"
+  }
+}]`
+
+func TestOpenBDMetadataProvider_LookupISBN(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("概要とONIXから書籍の情報を取得する", func(t *testing.T) {
+			server := newMetadataServer(t, "/get", "application/json", map[string]string{testISBN: openBDResponse}, "[null]")
+
+			got, err := repository.NewOpenBDMetadataProvider(server.URL).LookupISBN(1, testISBN)
+			if err != nil {
+				t.Fatalf("LookupISBN() error = %v", err)
+			}
+			want := model.BookMetadata{
+				ISBN:          testISBN,
+				Title:         "Go言語による並行処理",
+				Authors:       []string{"Cox-Buday,Katherine", "山口能迪"},
+				Publisher:     "オライリー・ジャパン",
+				PublishedDate: "2018-10-26",
+				Description:   "Go言語の並行処理について解説した書籍",
+				ImageURL:      "https://cover.openbd.jp/9784873118468.jpg",
+				PageCount:     238,
+				Sources:       []string{model.BookMetadataProviderOpenBD},
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("LookupISBN() = %+v, want %+v", got, want)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("登録されていないISBNはErrBookMetadataNotFound", func(t *testing.T) {
+			server := newMetadataServer(t, "/get", "application/json", nil, "[null]")
+			if _, err := repository.NewOpenBDMetadataProvider(server.URL).LookupISBN(1, "9784000000000"); !errors.Is(err, repository.ErrBookMetadataNotFound) {
+				t.Errorf("LookupISBN() error = %v, want ErrBookMetadataNotFound", err)
+			}
+		})
+
+		t.Run("エラーのステータスコードはエラー", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+			_, err := repository.NewOpenBDMetadataProvider(server.URL).LookupISBN(1, testISBN)
+			if err == nil || errors.Is(err, repository.ErrBookMetadataNotFound) {
+				t.Errorf("LookupISBN() error = %v, want request error", err)
+			}
+		})
+	})
+}
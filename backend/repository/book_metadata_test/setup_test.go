@@ -0,0 +1,28 @@
+package book_metadata_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testISBN = "9784873118468"
+
+// newMetadataServer はパスとクエリパラメータisbnごとに応答を返すテスト用のサーバーを起動します
+// 登録していないISBNにはnotFoundを返す
+func newMetadataServer(t *testing.T, path string, contentType string, bodies map[string]string, notFound string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		body, ok := bodies[r.URL.Query().Get("isbn")]
+		if !ok {
+			body = notFound
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
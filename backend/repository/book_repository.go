@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"encoding/json"
 	"fmt"
 	"go-react-app/model"
+	"go-react-app/utils/isbn"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -14,6 +18,14 @@ type IBookRepository interface {
 	CreateBook(book *model.Book) error
 	UpdateBook(book *model.Book, userId uint, bookId uint) error
 	DeleteBook(userId uint, bookId uint) error
+	// GetBookByISBN はISBN-13が一致する書籍を取得します。ない場合はgorm.ErrRecordNotFound
+	GetBookByISBN(userId uint, isbn string) (model.Book, error)
+	// GetBookByTitle はISBNのない、タイトルと著者が一致する書籍を取得します。ない場合はgorm.ErrRecordNotFound
+	GetBookByTitle(userId uint, title string, author string) (model.Book, error)
+	// FindBooks は条件に一致する書籍を取得します。読了・中断した年で絞り込む場合は読了・中断した日の順
+	FindBooks(userId uint, filter model.BookFilter) ([]model.Book, error)
+	// UpdateBookReading は書籍の読書記録を更新します。書籍がない場合はgorm.ErrRecordNotFound
+	UpdateBookReading(book *model.Book, userId uint, bookId uint) error
 }
 
 type bookRepository struct {
@@ -58,6 +70,7 @@ func (br *bookRepository) UpdateBook(book *model.Book, userId uint, bookId uint)
 			"isbn":          book.ISBN,
 			"image_url":     book.ImageURL,
 			"published_date": book.PublishedDate,
+			"publisher":     book.Publisher,
 		}).First(book)
 	
 	if result.Error != nil {
@@ -79,3 +92,111 @@ func (br *bookRepository) DeleteBook(userId uint, bookId uint) error {
 	}
 	return nil
 }
+
+func (br *bookRepository) FindBooks(userId uint, filter model.BookFilter) ([]model.Book, error) {
+	query := br.db.Where("user_id=?", userId)
+	if filter.Status != "" {
+		query = query.Where("status=?", filter.Status)
+	}
+	if filter.MinRating > 0 {
+		query = query.Where("rating>=?", filter.MinRating)
+	}
+	if filter.Year > 0 {
+		start := time.Date(filter.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		query = query.Where("finished_at>=? AND finished_at<?", start, start.AddDate(1, 0, 0)).Order("finished_at")
+	}
+	var books []model.Book
+	if err := query.Order("created_at").Find(&books).Error; err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+func (br *bookRepository) UpdateBookReading(book *model.Book, userId uint, bookId uint) error {
+	// mapでの更新ではserializerが使われないため、JSONにしてから保存する
+	highlights, err := json.Marshal(book.Highlights)
+	if err != nil {
+		return err
+	}
+	result := br.db.Model(&model.Book{}).
+		Where("id=? AND user_id=?", bookId, userId).
+		Updates(map[string]interface{}{
+			"status":           book.Status,
+			"started_at":       book.StartedAt,
+			"finished_at":      book.FinishedAt,
+			"page_count":       book.PageCount,
+			"current_page":     book.CurrentPage,
+			"progress_percent": book.ProgressPercent,
+			"rating":           book.Rating,
+			"notes":            book.Notes,
+			"highlights":       string(highlights),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return br.db.Where("user_id=?", userId).First(book, bookId).Error
+}
+
+func (br *bookRepository) GetBookByISBN(userId uint, isbn string) (model.Book, error) {
+	var book model.Book
+	if err := br.db.Where("user_id=? AND isbn=?", userId, isbn).First(&book).Error; err != nil {
+		return model.Book{}, err
+	}
+	return book, nil
+}
+
+func (br *bookRepository) GetBookByTitle(userId uint, title string, author string) (model.Book, error) {
+	var book model.Book
+	if err := br.db.Where("user_id=? AND isbn='' AND title=? AND author=?", userId, title, author).First(&book).Error; err != nil {
+		return model.Book{}, err
+	}
+	return book, nil
+}
+
+// NormalizeBookISBNs は保存済みの書籍のISBNをISBN-13に正規化します
+// ユーザーごとのISBNの一意制約を作成する前に実行する。正規化すると同じISBNになる書籍がある場合は、
+// ISBNを失わないよう何も変更せずに重複している書籍の一覧をエラーとして返す
+// ISBNとして不正な値は変更しない
+func NormalizeBookISBNs(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&model.Book{}) {
+		return nil
+	}
+	var books []model.Book
+	if err := db.Select("id", "isbn", "user_id").Where("isbn <> ''").Order("id").Find(&books).Error; err != nil {
+		return err
+	}
+	normalized := make([]string, len(books))
+	first := map[string]uint{}
+	conflicts := []string{}
+	for i, book := range books {
+		value, err := isbn.Normalize(book.ISBN)
+		if err != nil {
+			value = book.ISBN
+		}
+		normalized[i] = value
+		key := fmt.Sprintf("%d:%s", book.UserId, value)
+		if id, ok := first[key]; ok {
+			conflicts = append(conflicts, fmt.Sprintf("book %d and book %d (%s)", id, book.ID, value))
+			continue
+		}
+		first[key] = book.ID
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("cannot normalize ISBNs, duplicate ISBNs for the same user: %s", strings.Join(conflicts, ", "))
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i, book := range books {
+			if normalized[i] == book.ISBN {
+				continue
+			}
+			if err := tx.Model(&model.Book{}).Where("id=?", book.ID).Update("isbn", normalized[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,81 @@
+package book_test
+
+import (
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeBookISBNs(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("ISBN-10やハイフン付きのISBNをISBN-13に正規化する", func(t *testing.T) {
+			db := testutils.SetupTestDB()
+			user := testutils.CreateTestUser(db)
+			books := []model.Book{
+				{Title: "ISBN-10", ISBN: "4873118468", UserId: user.ID},
+				{Title: "ハイフン付き", ISBN: "978-4-7741-9744-9", UserId: user.ID},
+				{Title: "不正なISBN", ISBN: "not-an-isbn", UserId: user.ID},
+			}
+			db.Create(&books)
+
+			if err := repository.NormalizeBookISBNs(db); err != nil {
+				t.Fatalf("NormalizeBookISBNs() error = %v", err)
+			}
+
+			expected := []string{"9784873118468", "9784774197449", "not-an-isbn"}
+			for i, book := range books {
+				var stored model.Book
+				db.First(&stored, book.ID)
+				if stored.ISBN != expected[i] {
+					t.Errorf("書籍%dのISBN = %q, want %q", book.ID, stored.ISBN, expected[i])
+				}
+			}
+		})
+
+		t.Run("他のユーザーと同じISBNは重複としない", func(t *testing.T) {
+			db := testutils.SetupTestDB()
+			user := testutils.CreateTestUser(db)
+			other := testutils.CreateOtherUser(db)
+			db.Create(&[]model.Book{
+				{Title: "自分の本", ISBN: "4873118468", UserId: user.ID},
+				{Title: "他人の本", ISBN: "978-4-87311-846-8", UserId: other.ID},
+			})
+
+			if err := repository.NormalizeBookISBNs(db); err != nil {
+				t.Errorf("NormalizeBookISBNs() error = %v", err)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("正規化すると重複する書籍がある場合は何も変更せずにエラー", func(t *testing.T) {
+			db := testutils.SetupTestDB()
+			user := testutils.CreateTestUser(db)
+			books := []model.Book{
+				{Title: "ISBN-10で登録", ISBN: "4873118468", UserId: user.ID},
+				{Title: "ISBN-13で登録", ISBN: "978-4-87311-846-8", UserId: user.ID},
+				{Title: "正規化が必要な別の本", ISBN: "978-4-7741-9744-9", UserId: user.ID},
+			}
+			db.Create(&books)
+
+			err := repository.NormalizeBookISBNs(db)
+			if err == nil {
+				t.Fatal("NormalizeBookISBNs() エラーが返されませんでした")
+			}
+			// 重複している書籍を報告する
+			if !strings.Contains(err.Error(), "9784873118468") {
+				t.Errorf("NormalizeBookISBNs() error = %v, want the duplicated ISBN", err)
+			}
+			// ISBNを空にしたり、一部だけ正規化したりしない
+			for _, book := range books {
+				var stored model.Book
+				db.First(&stored, book.ID)
+				if stored.ISBN != book.ISBN {
+					t.Errorf("書籍%dのISBN = %q, want %q", book.ID, stored.ISBN, book.ISBN)
+				}
+			}
+		})
+	})
+}
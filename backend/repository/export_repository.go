@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"go-react-app/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IExportRepository interface {
+	GetAllExports(userId uint) ([]model.ExportJob, error)
+	GetExportById(userId uint, exportId uint) (model.ExportJob, error)
+	CreateExport(job *model.ExportJob) error
+	UpdateExport(job *model.ExportJob) error
+	HasActiveExport(userId uint) (bool, error)
+	FailInterruptedExports(message string, finishedAt time.Time) (int64, error)
+}
+
+type exportRepository struct {
+	db *gorm.DB
+}
+
+func NewExportRepository(db *gorm.DB) IExportRepository {
+	return &exportRepository{db}
+}
+
+func (er *exportRepository) GetAllExports(userId uint) ([]model.ExportJob, error) {
+	var jobs []model.ExportJob
+	if err := er.db.Where("user_id=?", userId).Order("created_at DESC, id DESC").Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (er *exportRepository) GetExportById(userId uint, exportId uint) (model.ExportJob, error) {
+	var job model.ExportJob
+	if err := er.db.Where("user_id=?", userId).First(&job, exportId).Error; err != nil {
+		return model.ExportJob{}, err
+	}
+	return job, nil
+}
+
+func (er *exportRepository) CreateExport(job *model.ExportJob) error {
+	return er.db.Create(job).Error
+}
+
+// UpdateExport はジョブの進捗と結果を保存します
+func (er *exportRepository) UpdateExport(job *model.ExportJob) error {
+	return er.db.Model(job).Select(
+		"Status", "Articles", "Rendered", "Skipped", "Removed", "Error", "FilePath", "StartedAt", "FinishedAt",
+	).Updates(job).Error
+}
+
+// HasActiveExport はユーザーに待機中または実行中のジョブがあるかを返します
+func (er *exportRepository) HasActiveExport(userId uint) (bool, error) {
+	var count int64
+	err := er.db.Model(&model.ExportJob{}).
+		Where("user_id=? AND status IN ?", userId, []string{model.ExportStatusPending, model.ExportStatusRunning}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FailInterruptedExports は待機中または実行中のまま残っているすべてのジョブを失敗にし、更新した件数を返します
+func (er *exportRepository) FailInterruptedExports(message string, finishedAt time.Time) (int64, error) {
+	result := er.db.Model(&model.ExportJob{}).
+		Where("status IN ?", []string{model.ExportStatusPending, model.ExportStatusRunning}).
+		Updates(map[string]interface{}{
+			"status":      model.ExportStatusFailed,
+			"error":       message,
+			"finished_at": finishedAt,
+		})
+	return result.RowsAffected, result.Error
+}
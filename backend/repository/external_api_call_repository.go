@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"encoding/json"
+	"go-react-app/model"
+	"go-react-app/utils/netguard"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxExternalAPIResponseSize 外部APIの応答の本文として読み込む最大バイト数
+const maxExternalAPIResponseSize = 1 << 20
+
+type IExternalAPICallRepository interface {
+	// Call は接続先を検証してからリクエストを送信し、応答を返します
+	// 外部APIがエラーのステータスコードを返した場合もエラーにはしない
+	Call(req *http.Request) (model.ExternalAPICallResponse, error)
+}
+
+type externalAPICallRepository struct {
+	guard  netguard.Guard
+	client *http.Client
+}
+
+func NewExternalAPICallRepository(guard netguard.Guard) IExternalAPICallRepository {
+	return &externalAPICallRepository{guard, guard.Client()}
+}
+
+func (cr *externalAPICallRepository) Call(req *http.Request) (model.ExternalAPICallResponse, error) {
+	if err := cr.guard.CheckURL(req.URL); err != nil {
+		return model.ExternalAPICallResponse{}, err
+	}
+
+	start := time.Now()
+	resp, err := cr.client.Do(req)
+	if err != nil {
+		return model.ExternalAPICallResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	// 上限を1バイト超えて読み込み、超えた場合は切り詰める
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxExternalAPIResponseSize+1))
+	if err != nil {
+		return model.ExternalAPICallResponse{}, err
+	}
+	response := model.ExternalAPICallResponse{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		DurationMs:  time.Since(start).Milliseconds(),
+	}
+	if len(body) > maxExternalAPIResponseSize {
+		body = body[:maxExternalAPIResponseSize]
+		response.Truncated = true
+	}
+
+	if isJSONContentType(response.ContentType) && !response.Truncated && json.Valid(body) {
+		response.Body = body
+	} else {
+		response.Text = string(body)
+	}
+	return response, nil
+}
+
+// isJSONContentType はContent-TypeがJSON（application/jsonや+json）かを返します
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
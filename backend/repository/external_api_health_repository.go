@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"go-react-app/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IExternalAPIHealthRepository interface {
+	CreateHealthCheck(check *model.ExternalAPIHealthCheck) error
+	// GetRecentHealthChecks は外部APIのヘルスチェックの結果を新しい順にlimit件取得します
+	GetRecentHealthChecks(checks *[]model.ExternalAPIHealthCheck, apiId uint, limit int) error
+	// GetHealthSummary はsince以降のヘルスチェックの回数・正常だった回数・平均の応答時間を集計します
+	GetHealthSummary(apiId uint, since time.Time) (model.ExternalAPIHealthSummary, error)
+	// GetLastFailure は最後に異常だったヘルスチェックの結果を取得します。ない場合はgorm.ErrRecordNotFound
+	GetLastFailure(check *model.ExternalAPIHealthCheck, apiId uint) error
+	// GetLastCheckedTimes は外部APIごとに最後にヘルスチェックした日時を返します
+	GetLastCheckedTimes() (map[uint]time.Time, error)
+	// DeleteHealthChecksBefore はbeforeより前のヘルスチェックの結果を削除し、削除した件数を返します
+	DeleteHealthChecksBefore(before time.Time) (int64, error)
+}
+
+type externalAPIHealthRepository struct {
+	db *gorm.DB
+}
+
+func NewExternalAPIHealthRepository(db *gorm.DB) IExternalAPIHealthRepository {
+	return &externalAPIHealthRepository{db}
+}
+
+func (hr *externalAPIHealthRepository) CreateHealthCheck(check *model.ExternalAPIHealthCheck) error {
+	return hr.db.Create(check).Error
+}
+
+func (hr *externalAPIHealthRepository) GetRecentHealthChecks(checks *[]model.ExternalAPIHealthCheck, apiId uint, limit int) error {
+	return hr.db.Where("external_api_id = ?", apiId).Order("checked_at DESC, id DESC").Limit(limit).Find(checks).Error
+}
+
+func (hr *externalAPIHealthRepository) GetHealthSummary(apiId uint, since time.Time) (model.ExternalAPIHealthSummary, error) {
+	var summary struct {
+		Checks       int64
+		UpChecks     int64
+		AvgLatencyMs float64
+	}
+	err := hr.db.Model(&model.ExternalAPIHealthCheck{}).
+		Select("COUNT(*) AS checks, COALESCE(SUM(CASE WHEN up THEN 1 ELSE 0 END), 0) AS up_checks, COALESCE(AVG(latency_ms), 0) AS avg_latency_ms").
+		Where("external_api_id = ? AND checked_at >= ?", apiId, since).
+		Scan(&summary).Error
+	if err != nil {
+		return model.ExternalAPIHealthSummary{}, err
+	}
+	return model.ExternalAPIHealthSummary(summary), nil
+}
+
+func (hr *externalAPIHealthRepository) GetLastFailure(check *model.ExternalAPIHealthCheck, apiId uint) error {
+	return hr.db.Where("external_api_id = ? AND up = ?", apiId, false).Order("checked_at DESC, id DESC").First(check).Error
+}
+
+func (hr *externalAPIHealthRepository) GetLastCheckedTimes() (map[uint]time.Time, error) {
+	// 結果は実行した順に追加するため、外部APIごとにIDが最大の結果を最後の結果とする
+	// （MAX(checked_at)はSQLiteで日時として読み取れない）
+	var checks []model.ExternalAPIHealthCheck
+	latest := hr.db.Model(&model.ExternalAPIHealthCheck{}).Select("MAX(id)").Group("external_api_id")
+	if err := hr.db.Select("external_api_id", "checked_at").Where("id IN (?)", latest).Find(&checks).Error; err != nil {
+		return nil, err
+	}
+	times := make(map[uint]time.Time, len(checks))
+	for _, check := range checks {
+		times[check.ExternalAPIId] = check.CheckedAt
+	}
+	return times, nil
+}
+
+func (hr *externalAPIHealthRepository) DeleteHealthChecksBefore(before time.Time) (int64, error) {
+	result := hr.db.Where("checked_at < ?", before).Delete(&model.ExternalAPIHealthCheck{})
+	return result.RowsAffected, result.Error
+}
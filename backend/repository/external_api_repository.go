@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"encoding/json"
 	"fmt"
 	"go-react-app/model"
 
@@ -14,6 +15,8 @@ type IExternalAPIRepository interface {
 	CreateExternalAPI(api *model.ExternalAPI) error
 	UpdateExternalAPI(api *model.ExternalAPI, userId uint, apiId uint) error
 	DeleteExternalAPI(userId uint, apiId uint) error
+	// GetHealthCheckTargets はすべてのユーザーの、ヘルスチェックが設定された外部APIを取得します
+	GetHealthCheckTargets(apis *[]model.ExternalAPI) error
 }
 
 type externalAPIRepository struct {
@@ -46,11 +49,26 @@ func (ar *externalAPIRepository) CreateExternalAPI(api *model.ExternalAPI) error
 }
 
 func (ar *externalAPIRepository) UpdateExternalAPI(api *model.ExternalAPI, userId uint, apiId uint) error {
-	result := ar.db.Model(api).Clauses(clause.Returning{}).Where("id=? AND user_id=?", apiId, userId).Updates(map[string]interface{}{
-		"name":        api.Name,
-		"base_url":    api.BaseURL,
-		"description": api.Description,
-	})
+	queryParams, err := json.Marshal(api.QueryParams)
+	if err != nil {
+		return err
+	}
+	values := map[string]interface{}{
+		"name":            api.Name,
+		"base_url":        api.BaseURL,
+		"description":     api.Description,
+		"method":          api.Method,
+		"path_template":   api.PathTemplate,
+		"query_params":    string(queryParams),
+		"timeout_seconds": api.TimeoutSeconds,
+		"auth_type":       api.AuthType,
+		"auth_header":     api.AuthHeader,
+
+		"health_check_path":             api.HealthCheckPath,
+		"health_check_status":           api.HealthCheckStatus,
+		"health_check_interval_seconds": api.HealthCheckIntervalSeconds,
+	}
+	result := ar.db.Model(api).Clauses(clause.Returning{}).Where("id=? AND user_id=?", apiId, userId).Updates(values)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -70,3 +88,41 @@ func (ar *externalAPIRepository) DeleteExternalAPI(userId uint, apiId uint) erro
 	}
 	return nil
 }
+
+func (ar *externalAPIRepository) GetHealthCheckTargets(apis *[]model.ExternalAPI) error {
+	if err := ar.db.Where("health_check_path <> ''").Order("id").Find(apis).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// MigrateExternalAPICredentials は外部APIのテーブルに平文で保存されていた認証情報を秘密情報に移し、auth_credentialの列を削除します
+// 秘密情報が保存済みの外部APIは、保存済みの値のほうが新しいため平文の値を破棄する
+// マスターキーが設定されていないなど秘密情報を保存できない場合は、認証情報を失わないよう列を残してエラーを返す
+func MigrateExternalAPICredentials(db *gorm.DB, secrets ISecretRepository) error {
+	if !db.Migrator().HasColumn(&model.ExternalAPI{}, "auth_credential") {
+		return nil
+	}
+	var rows []struct {
+		ID             uint
+		UserId         uint
+		AuthCredential string
+	}
+	if err := db.Model(&model.ExternalAPI{}).Select("id", "user_id", "auth_credential").Where("auth_credential <> ''").Order("id").Scan(&rows).Error; err != nil {
+		return err
+	}
+	for _, row := range rows {
+		name := model.ExternalAPISecretName(row.ID)
+		var stored int64
+		if err := db.Model(&model.Secret{}).Where("user_id=? AND name=?", row.UserId, name).Count(&stored).Error; err != nil {
+			return err
+		}
+		if stored > 0 {
+			continue
+		}
+		if _, err := secrets.PutSecret(row.UserId, name, row.AuthCredential); err != nil {
+			return fmt.Errorf("external api %d: %w", row.ID, err)
+		}
+	}
+	return db.Migrator().DropColumn(&model.ExternalAPI{}, "auth_credential")
+}
@@ -0,0 +1,108 @@
+package external_api_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// setupLegacyCredentialDB は認証情報を平文で保存していた頃の列を持つデータベースを作成します
+func setupLegacyCredentialDB(t *testing.T) (*gorm.DB, model.User) {
+	db := testutils.SetupTestDB()
+	// AutoMigrateで作成した場合と同じく列名を引用符で囲む
+	if err := db.Exec("ALTER TABLE external_apis ADD COLUMN `auth_credential` text NOT NULL DEFAULT ''").Error; err != nil {
+		t.Fatalf("auth_credentialの列の追加に失敗しました: %v", err)
+	}
+	return db, testutils.CreateTestUser(db)
+}
+
+// createLegacyExternalAPI は平文の認証情報を持つ外部APIを登録します
+func createLegacyExternalAPI(t *testing.T, db *gorm.DB, userId uint, credential string) model.ExternalAPI {
+	api := model.ExternalAPI{Name: "旧API", BaseURL: "https://api.example.com", AuthType: model.ExternalAPIAuthBearer, UserId: userId}
+	if err := db.Create(&api).Error; err != nil {
+		t.Fatalf("外部APIの作成に失敗しました: %v", err)
+	}
+	db.Exec("UPDATE external_apis SET auth_credential=? WHERE id=?", credential, api.ID)
+	return api
+}
+
+func TestMigrateExternalAPICredentials(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("平文の認証情報を秘密情報に移して列を削除する", func(t *testing.T) {
+			db, user := setupLegacyCredentialDB(t)
+			secrets := repository.NewSecretRepository(db, testutils.NewTestKeyring())
+			api := createLegacyExternalAPI(t, db, user.ID, "legacy-token")
+			withoutCredential := createLegacyExternalAPI(t, db, user.ID, "")
+
+			if err := repository.MigrateExternalAPICredentials(db, secrets); err != nil {
+				t.Fatalf("MigrateExternalAPICredentials() error = %v", err)
+			}
+
+			if value, _ := secrets.ResolveSecret(user.ID, model.ExternalAPISecretName(api.ID)); value != "legacy-token" {
+				t.Errorf("ResolveSecret() = %q, want %q", value, "legacy-token")
+			}
+			if value, _ := secrets.ResolveSecret(user.ID, model.ExternalAPISecretName(withoutCredential.ID)); value != "" {
+				t.Errorf("認証情報のない外部APIの秘密情報 = %q, want empty", value)
+			}
+			if db.Migrator().HasColumn(&model.ExternalAPI{}, "auth_credential") {
+				t.Error("auth_credentialの列が残っています")
+			}
+		})
+
+		t.Run("保存済みの秘密情報は平文の値で上書きしない", func(t *testing.T) {
+			db, user := setupLegacyCredentialDB(t)
+			secrets := repository.NewSecretRepository(db, testutils.NewTestKeyring())
+			api := createLegacyExternalAPI(t, db, user.ID, "old-token")
+			if _, err := secrets.PutSecret(user.ID, model.ExternalAPISecretName(api.ID), "new-token"); err != nil {
+				t.Fatalf("PutSecret() error = %v", err)
+			}
+
+			if err := repository.MigrateExternalAPICredentials(db, secrets); err != nil {
+				t.Fatalf("MigrateExternalAPICredentials() error = %v", err)
+			}
+			if value, _ := secrets.ResolveSecret(user.ID, model.ExternalAPISecretName(api.ID)); value != "new-token" {
+				t.Errorf("ResolveSecret() = %q, want %q", value, "new-token")
+			}
+		})
+
+		t.Run("列がない場合は何もしない", func(t *testing.T) {
+			db := testutils.SetupTestDB()
+			if err := repository.MigrateExternalAPICredentials(db, repository.NewSecretRepository(db, nil)); err != nil {
+				t.Errorf("MigrateExternalAPICredentials() error = %v", err)
+			}
+		})
+
+		t.Run("移す認証情報がなければマスターキーがなくても列を削除する", func(t *testing.T) {
+			db, user := setupLegacyCredentialDB(t)
+			createLegacyExternalAPI(t, db, user.ID, "")
+
+			if err := repository.MigrateExternalAPICredentials(db, repository.NewSecretRepository(db, nil)); err != nil {
+				t.Fatalf("MigrateExternalAPICredentials() error = %v", err)
+			}
+			if db.Migrator().HasColumn(&model.ExternalAPI{}, "auth_credential") {
+				t.Error("auth_credentialの列が残っています")
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("マスターキーがない場合は認証情報を残してエラー", func(t *testing.T) {
+			db, user := setupLegacyCredentialDB(t)
+			api := createLegacyExternalAPI(t, db, user.ID, "legacy-token")
+
+			err := repository.MigrateExternalAPICredentials(db, repository.NewSecretRepository(db, nil))
+			if !errors.Is(err, repository.ErrSecretStoreDisabled) {
+				t.Fatalf("MigrateExternalAPICredentials() error = %v, want ErrSecretStoreDisabled", err)
+			}
+			var credential string
+			db.Raw("SELECT auth_credential FROM external_apis WHERE id=?", api.ID).Scan(&credential)
+			if credential != "legacy-token" {
+				t.Errorf("auth_credential = %q, want %q", credential, "legacy-token")
+			}
+		})
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"go-react-app/model"
 	"go-react-app/repository"
 	"go-react-app/testutils"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
@@ -56,6 +57,11 @@ func (m *MockFeedRepository) DeleteFeed(userId uint, feedId uint) error {
 	return args.Error(0)
 }
 
+func (m *MockFeedRepository) MarkFeedFetched(userId uint, feedId uint, fetchedAt time.Time, interval time.Duration) (bool, error) {
+	args := m.Called(userId, feedId, fetchedAt, interval)
+	return args.Bool(0), args.Error(1)
+}
+
 // RSSフィードのモックレスポンス
 const mockRSSXML = `<?xml version="1.0" encoding="UTF-8"?>
 <feed xmlns="http://www.w3.org/2005/Atom">
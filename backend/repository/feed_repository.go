@@ -3,6 +3,7 @@ package repository
 import (
 	"fmt"
 	"go-react-app/model"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -14,6 +15,7 @@ type IFeedRepository interface {
 	CreateFeed(feed *model.Feed) error
 	UpdateFeed(feed *model.Feed, userId uint, feedId uint) error
 	DeleteFeed(userId uint, feedId uint) error
+	MarkFeedFetched(userId uint, feedId uint, fetchedAt time.Time, interval time.Duration) (bool, error)
 }
 
 type feedRepository struct {
@@ -71,3 +73,15 @@ func (fr *feedRepository) DeleteFeed(userId uint, feedId uint) error {
 	}
 	return nil
 }
+
+// MarkFeedFetched は前回の取得からinterval以上経過している場合のみ、フィードの最終取得日時をfetchedAtに更新します
+// 更新した場合はtrueを返す。条件付きの更新にすることで、同時に取得したリクエストのうち1つだけがtrueになる
+func (fr *feedRepository) MarkFeedFetched(userId uint, feedId uint, fetchedAt time.Time, interval time.Duration) (bool, error) {
+	result := fr.db.Model(&model.Feed{}).
+		Where("id=? AND user_id=? AND (last_fetched_at IS NULL OR last_fetched_at <= ?)", feedId, userId, fetchedAt.Add(-interval)).
+		UpdateColumn("last_fetched_at", fetchedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
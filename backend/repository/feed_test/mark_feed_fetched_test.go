@@ -0,0 +1,69 @@
+package feed_test
+
+import (
+	"go-react-app/model"
+	"testing"
+	"time"
+)
+
+func TestFeedRepository_MarkFeedFetched(t *testing.T) {
+	setupFeedTest()
+
+	feed := model.Feed{
+		Title:  "Feed to Fetch",
+		URL:    "https://example.com/fetch",
+		UserId: feedTestUser.ID,
+	}
+	feedDB.Create(&feed)
+	now := time.Now()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("初回の取得では最終取得日時を更新する", func(t *testing.T) {
+			marked, err := feedRepo.MarkFeedFetched(feedTestUser.ID, feed.ID, now, time.Hour)
+			if err != nil {
+				t.Fatalf("MarkFeedFetched() error = %v", err)
+			}
+			if !marked {
+				t.Error("MarkFeedFetched() = false, want true")
+			}
+
+			var stored model.Feed
+			feedDB.First(&stored, feed.ID)
+			if stored.LastFetchedAt == nil || !stored.LastFetchedAt.Equal(now) {
+				t.Errorf("LastFetchedAt = %v, want %v", stored.LastFetchedAt, now)
+			}
+		})
+
+		t.Run("間隔内の取得では更新しない", func(t *testing.T) {
+			marked, err := feedRepo.MarkFeedFetched(feedTestUser.ID, feed.ID, now.Add(30*time.Minute), time.Hour)
+			if err != nil {
+				t.Fatalf("MarkFeedFetched() error = %v", err)
+			}
+			if marked {
+				t.Error("MarkFeedFetched() = true, want false")
+			}
+		})
+
+		t.Run("間隔を過ぎた取得では更新する", func(t *testing.T) {
+			marked, err := feedRepo.MarkFeedFetched(feedTestUser.ID, feed.ID, now.Add(time.Hour), time.Hour)
+			if err != nil {
+				t.Fatalf("MarkFeedFetched() error = %v", err)
+			}
+			if !marked {
+				t.Error("MarkFeedFetched() = false, want true")
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("他のユーザーのフィードは更新しない", func(t *testing.T) {
+			marked, err := feedRepo.MarkFeedFetched(feedOtherUser.ID, feed.ID, now.Add(24*time.Hour), time.Hour)
+			if err != nil {
+				t.Fatalf("MarkFeedFetched() error = %v", err)
+			}
+			if marked {
+				t.Error("MarkFeedFetched() = true for other user's feed, want false")
+			}
+		})
+	})
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go-react-app/model"
 	"net/http"
@@ -11,20 +12,36 @@ import (
 )
 
 type IGoogleBookRepository interface {
-	SearchBooks(query string, maxResults int) (model.GoogleBookSearchResponse, error)
-	GetBookByID(id string) (model.GoogleBook, error)
+	SearchBooks(userId uint, query string, maxResults int) (model.GoogleBookSearchResponse, error)
+	GetBookByID(userId uint, id string) (model.GoogleBook, error)
 }
 
-type googleBookRepository struct{}
+type googleBookRepository struct {
+	sr ISecretRepository
+}
 
-func NewGoogleBookRepository() IGoogleBookRepository {
-	return &googleBookRepository{}
+func NewGoogleBookRepository(sr ISecretRepository) IGoogleBookRepository {
+	return &googleBookRepository{sr}
 }
 
-func (gbr *googleBookRepository) SearchBooks(query string, maxResults int) (model.GoogleBookSearchResponse, error) {
-	apiKey := os.Getenv("GOOGLE_BOOKS_API_KEY")
+// apiKey はユーザーが保存したAPIキー、なければ環境変数GOOGLE_BOOKS_API_KEYを返します
+// どちらもない場合は空文字列を返し、APIキーなし（利用回数の上限が低い）で呼び出す
+// マスターキーが設定されていない場合は保存済みのAPIキーを復号できないため、環境変数のみから取得する
+func (gbr *googleBookRepository) apiKey(userId uint) (string, error) {
+	apiKey, err := gbr.sr.ResolveSecret(userId, model.SecretNameGoogleBooksAPIKey)
+	if err != nil && !errors.Is(err, ErrSecretStoreDisabled) {
+		return "", err
+	}
 	if apiKey == "" {
-		return model.GoogleBookSearchResponse{}, fmt.Errorf("GOOGLE_BOOKS_API_KEY is not set")
+		apiKey = os.Getenv("GOOGLE_BOOKS_API_KEY")
+	}
+	return apiKey, nil
+}
+
+func (gbr *googleBookRepository) SearchBooks(userId uint, query string, maxResults int) (model.GoogleBookSearchResponse, error) {
+	apiKey, err := gbr.apiKey(userId)
+	if err != nil {
+		return model.GoogleBookSearchResponse{}, err
 	}
 
 	if maxResults <= 0 {
@@ -35,9 +52,11 @@ func (gbr *googleBookRepository) SearchBooks(query string, maxResults int) (mode
 	params := url.Values{}
 	params.Add("q", query)
 	params.Add("maxResults", fmt.Sprintf("%d", maxResults))
-	params.Add("key", apiKey)
+	if apiKey != "" {
+		params.Add("key", apiKey)
+	}
 
-	resp, err := http.Get(baseURL + "?" + params.Encode())
+	resp, err := metadataClient.Get(baseURL + "?" + params.Encode())
 	if err != nil {
 		return model.GoogleBookSearchResponse{}, err
 	}
@@ -56,6 +75,8 @@ func (gbr *googleBookRepository) SearchBooks(query string, maxResults int) (mode
 				Authors       []string `json:"authors"`
 				Description   string   `json:"description"`
 				PublishedDate string   `json:"publishedDate"`
+				Publisher     string   `json:"publisher"`
+				PageCount     int      `json:"pageCount"`
 				ImageLinks    struct {
 					Thumbnail string `json:"thumbnail"`
 				} `json:"imageLinks"`
@@ -84,6 +105,8 @@ func (gbr *googleBookRepository) SearchBooks(query string, maxResults int) (mode
 			Description:  item.VolumeInfo.Description,
 			ImageURL:     item.VolumeInfo.ImageLinks.Thumbnail,
 			PublishedDate: item.VolumeInfo.PublishedDate,
+			Publisher:    item.VolumeInfo.Publisher,
+			PageCount:    item.VolumeInfo.PageCount,
 		}
 
 		// ISBNを取得
@@ -100,16 +123,19 @@ func (gbr *googleBookRepository) SearchBooks(query string, maxResults int) (mode
 	return result, nil
 }
 
-func (gbr *googleBookRepository) GetBookByID(id string) (model.GoogleBook, error) {
-	apiKey := os.Getenv("GOOGLE_BOOKS_API_KEY")
-	if apiKey == "" {
-		return model.GoogleBook{}, fmt.Errorf("GOOGLE_BOOKS_API_KEY is not set")
+func (gbr *googleBookRepository) GetBookByID(userId uint, id string) (model.GoogleBook, error) {
+	apiKey, err := gbr.apiKey(userId)
+	if err != nil {
+		return model.GoogleBook{}, err
 	}
 
 	baseURL := "https://www.googleapis.com/books/v1/volumes"
-	url := fmt.Sprintf("%s/%s?key=%s", baseURL, id, apiKey)
+	requestURL := fmt.Sprintf("%s/%s", baseURL, url.PathEscape(id))
+	if apiKey != "" {
+		requestURL += "?key=" + url.QueryEscape(apiKey)
+	}
 
-	resp, err := http.Get(url)
+	resp, err := metadataClient.Get(requestURL)
 	if err != nil {
 		return model.GoogleBook{}, err
 	}
@@ -126,6 +152,8 @@ func (gbr *googleBookRepository) GetBookByID(id string) (model.GoogleBook, error
 			Authors       []string `json:"authors"`
 			Description   string   `json:"description"`
 			PublishedDate string   `json:"publishedDate"`
+			Publisher     string   `json:"publisher"`
+			PageCount     int      `json:"pageCount"`
 			ImageLinks    struct {
 				Thumbnail string `json:"thumbnail"`
 			} `json:"imageLinks"`
@@ -147,6 +175,8 @@ func (gbr *googleBookRepository) GetBookByID(id string) (model.GoogleBook, error
 		Description:  apiResp.VolumeInfo.Description,
 		ImageURL:     apiResp.VolumeInfo.ImageLinks.Thumbnail,
 		PublishedDate: apiResp.VolumeInfo.PublishedDate,
+		Publisher:    apiResp.VolumeInfo.Publisher,
+		PageCount:    apiResp.VolumeInfo.PageCount,
 	}
 
 	// ISBNを取得
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"fmt"
+	"go-react-app/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ILayoutAssignmentRepository interface {
+	GetAssignments(userId uint) ([]model.LayoutAssignment, error)
+	SaveAssignment(assignment *model.LayoutAssignment) error
+	DeleteAssignment(userId uint, tag string) error
+}
+
+type layoutAssignmentRepository struct {
+	db *gorm.DB
+}
+
+func NewLayoutAssignmentRepository(db *gorm.DB) ILayoutAssignmentRepository {
+	return &layoutAssignmentRepository{db}
+}
+
+// GetAssignments はユーザーの既定とタグのレイアウトの指定をタグの名前順に返します（既定の指定はタグが空文字のため先頭になる）
+func (lar *layoutAssignmentRepository) GetAssignments(userId uint) ([]model.LayoutAssignment, error) {
+	var assignments []model.LayoutAssignment
+	if err := lar.db.Where("user_id=?", userId).Order("tag").Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// SaveAssignment は同じユーザー・タグの指定がある場合はレイアウトを置き換え、ない場合は作成します
+func (lar *layoutAssignmentRepository) SaveAssignment(assignment *model.LayoutAssignment) error {
+	return lar.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "tag"}},
+			DoUpdates: clause.AssignmentColumns([]string{"layout_id", "updated_at"}),
+		}).Create(assignment).Error
+		if err != nil {
+			return err
+		}
+		// ON CONFLICTで更新された場合は作成日時とIDが返らないため取得し直す
+		return tx.Where("user_id=? AND tag=?", assignment.UserId, assignment.Tag).First(assignment).Error
+	})
+}
+
+func (lar *layoutAssignmentRepository) DeleteAssignment(userId uint, tag string) error {
+	result := lar.db.Where("user_id=? AND tag=?", userId, tag).Delete(&model.LayoutAssignment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return fmt.Errorf("layout assignment does not exist")
+	}
+	return nil
+}
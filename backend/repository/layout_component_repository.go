@@ -14,9 +14,9 @@ type ILayoutComponentRepository interface {
 	CreateLayoutComponent(component *model.LayoutComponent) error
 	UpdateLayoutComponent(component *model.LayoutComponent, userId uint, componentId uint) error
 	DeleteLayoutComponent(userId uint, componentId uint) error
-	AssignToLayout(componentId uint, layoutId uint, userId uint, position model.PositionRequest) error
+	DeleteLayoutComponentTree(userId uint, componentId uint) error
 	RemoveFromLayout(componentId uint, userId uint) error
-	UpdatePosition(componentId uint, userId uint, position model.PositionRequest) error
+	SavePositions(userId uint, layoutId uint, components []model.LayoutComponent) error
 }
 
 type layoutComponentRepository struct {
@@ -54,9 +54,11 @@ func (lcr *layoutComponentRepository) UpdateLayoutComponent(component *model.Lay
 	result := lcr.db.Model(&model.LayoutComponent{}).Clauses(clause.Returning{}).
 		Where("id=? AND user_id=?", componentId, userId).
 		Updates(map[string]interface{}{
-			"name":    component.Name,
-			"type":    component.Type,
-			"content": component.Content,
+			"name":       component.Name,
+			"type":       component.Type,
+			"content":    component.Content,
+			"properties": component.Properties,
+			"z_index":    component.ZIndex,
 		}).First(component)
 	
 	if result.Error != nil {
@@ -68,113 +70,131 @@ func (lcr *layoutComponentRepository) UpdateLayoutComponent(component *model.Lay
 	return nil
 }
 
+// DeleteLayoutComponent はコンポーネントを削除し、子コンポーネントを削除したコンポーネントの親に付け替えます
 func (lcr *layoutComponentRepository) DeleteLayoutComponent(userId uint, componentId uint) error {
-	result := lcr.db.Where("id=? AND user_id=?", componentId, userId).Delete(&model.LayoutComponent{})
-	if result.Error != nil {
-		return result.Error
+	return lcr.db.Transaction(func(tx *gorm.DB) error {
+		var component model.LayoutComponent
+		if err := tx.Where("id=? AND user_id=?", componentId, userId).First(&component).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("layout component does not exist")
+			}
+			return err
+		}
+		if err := liftChildren(tx, component); err != nil {
+			return err
+		}
+		return tx.Delete(&component).Error
+	})
+}
+
+// DeleteLayoutComponentTree はコンポーネントとその子孫のコンポーネントを1つのトランザクションで削除します
+func (lcr *layoutComponentRepository) DeleteLayoutComponentTree(userId uint, componentId uint) error {
+	return lcr.db.Transaction(func(tx *gorm.DB) error {
+		var component model.LayoutComponent
+		if err := tx.Where("id=? AND user_id=?", componentId, userId).First(&component).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("layout component does not exist")
+			}
+			return err
+		}
+
+		ids := []uint{component.ID}
+		visited := map[uint]bool{component.ID: true}
+		for level := ids; len(level) > 0; {
+			var children []uint
+			if err := tx.Model(&model.LayoutComponent{}).Where("parent_id IN ? AND user_id=?", level, userId).Pluck("id", &children).Error; err != nil {
+				return err
+			}
+			level = nil
+			for _, id := range children {
+				if !visited[id] {
+					visited[id] = true
+					level = append(level, id)
+				}
+			}
+			ids = append(ids, level...)
+		}
+		return tx.Where("id IN ? AND user_id=?", ids, userId).Delete(&model.LayoutComponent{}).Error
+	})
+}
+
+// liftChildren はparentの子コンポーネントをparentの親に付け替えます（レイアウト上の位置は変わらない）
+func liftChildren(tx *gorm.DB, parent model.LayoutComponent) error {
+	var children []model.LayoutComponent
+	if err := tx.Where("parent_id=? AND user_id=?", parent.ID, parent.UserId).Find(&children).Error; err != nil {
+		return err
 	}
-	if result.RowsAffected < 1 {
-		return fmt.Errorf("layout component does not exist")
+	for _, child := range children {
+		child.LiftOut(parent)
+		if err := tx.Model(&model.LayoutComponent{}).Where("id=?", child.ID).Updates(map[string]interface{}{
+			"parent_id":   child.ParentId,
+			"x":           child.X,
+			"y":           child.Y,
+			"breakpoints": child.Breakpoints,
+		}).Error; err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-func (lcr *layoutComponentRepository) AssignToLayout(componentId uint, layoutId uint, userId uint, position model.PositionRequest) error {
-    // まずコンポーネントを取得
-    var component model.LayoutComponent
-    if err := lcr.db.Where("id = ? AND user_id = ?", componentId, userId).First(&component).Error; err != nil {
-        if err == gorm.ErrRecordNotFound {
-            return errors.New("layout component does not exist")
-        }
-        return err
-    }
-    
-    // 次にレイアウトが存在するか確認
-    var layout model.Layout
-    if err := lcr.db.Where("id = ? AND user_id = ?", layoutId, userId).First(&layout).Error; err != nil {
-        if err == gorm.ErrRecordNotFound {
-            return errors.New("layout does not exist")
-        }
-        return err
-    }
-    
-    // レイアウトに割り当て
-    component.LayoutId = &layoutId
-    
-    // 位置情報を設定
-    component.X = position.X
-    component.Y = position.Y
-    
-    // 幅と高さを設定（もし提供されていれば）
-    if position.Width != 0 {
-        component.Width = position.Width
-    }
-    if position.Height != 0 {
-        component.Height = position.Height
-    }
-    
-    // 保存
-    if err := lcr.db.Save(&component).Error; err != nil {
-        return err
-    }
-    
-    return nil
-}
-
 func (lcr *layoutComponentRepository) RemoveFromLayout(componentId uint, userId uint) error {
-    var component model.LayoutComponent
-    if err := lcr.db.Where("id = ? AND user_id = ?", componentId, userId).First(&component).Error; err != nil {
-        if err == gorm.ErrRecordNotFound {
-            return errors.New("layout component does not exist")
+    return lcr.db.Transaction(func(tx *gorm.DB) error {
+        var component model.LayoutComponent
+        if err := tx.Where("id = ? AND user_id = ?", componentId, userId).First(&component).Error; err != nil {
+            if err == gorm.ErrRecordNotFound {
+                return errors.New("layout component does not exist")
+            }
+            return err
         }
-        return err
-    }
-    
-    // レイアウトIDをnilに設定
-    component.LayoutId = nil
-    
-    // 位置情報をリセット
-    component.X = 0
-    component.Y = 0
-    
-    // 保存
-    if err := lcr.db.Save(&component).Error; err != nil {
-        return err
-    }
-    
-    return nil
+        
+        // 子コンポーネントはレイアウトに残し、外すコンポーネントの親に付け替える
+        if err := liftChildren(tx, component); err != nil {
+            return err
+        }
+        
+        // レイアウトIDと親をnilに設定
+        component.LayoutId = nil
+        component.ParentId = nil
+        
+        // 位置情報をリセット（ブレークポイントごとの位置はレイアウトに合わせたものなので破棄する）
+        component.X = 0
+        component.Y = 0
+        component.Breakpoints = ""
+        
+        // 保存
+        return tx.Save(&component).Error
+    })
 }
 
-func (lcr *layoutComponentRepository) UpdatePosition(componentId uint, userId uint, position model.PositionRequest) error {
-    var component model.LayoutComponent
-    if err := lcr.db.Where("id = ? AND user_id = ?", componentId, userId).First(&component).Error; err != nil {
-        if err == gorm.ErrRecordNotFound {
-            return errors.New("layout component does not exist")
-        }
-        return err
-    }
-    
-    // レイアウトに割り当てられていない場合はエラー
-    if component.LayoutId == nil {
-        return errors.New("component is not assigned to any layout")
-    }
-    
-    // 位置情報を更新
-    component.X = position.X
-    component.Y = position.Y
-    
-    // 幅と高さを更新（もし提供されていれば）
-    if position.Width != 0 {
-        component.Width = position.Width
-    }
-    if position.Height != 0 {
-        component.Height = position.Height
-    }
-    
-    // 保存
-    if err := lcr.db.Save(&component).Error; err != nil {
-        return err
-    }
-    
-    return nil
+// SavePositions はコンポーネントをレイアウトに割り当て、親・位置・サイズを1つのトランザクションで保存します
+// 配置が変わるためレイアウトのバージョンも更新する
+func (lcr *layoutComponentRepository) SavePositions(userId uint, layoutId uint, components []model.LayoutComponent) error {
+	return lcr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Layout{}).
+			Where("id=? AND user_id=?", layoutId, userId).
+			Update("version", gorm.Expr("version + 1")).Error; err != nil {
+			return err
+		}
+		for _, component := range components {
+			result := tx.Model(&model.LayoutComponent{}).
+				Where("id=? AND user_id=?", component.ID, userId).
+				Updates(map[string]interface{}{
+					"layout_id":   layoutId,
+					"parent_id":   component.ParentId,
+					"x":           component.X,
+					"y":           component.Y,
+					"width":       component.Width,
+					"height":      component.Height,
+					"breakpoints": component.Breakpoints,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected < 1 {
+				return errors.New("layout component does not exist")
+			}
+		}
+		return nil
+	})
 }
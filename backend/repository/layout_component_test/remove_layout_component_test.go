@@ -15,8 +15,9 @@ func TestLayoutComponentRepository_RemoveFromLayout(t *testing.T) {
 		component, err := createTestLayoutComponent()
 		assert.NoError(t, err)
 		
-		position := model.PositionRequest{X: 10, Y: 20}
-		err = layoutComponentRepo.AssignToLayout(component.ID, testLayoutData.ID, testUserData.ID, position)
+		component.X = 10
+		component.Y = 20
+		err = layoutComponentRepo.SavePositions(testUserData.ID, testLayoutData.ID, []model.LayoutComponent{*component})
 		assert.NoError(t, err)
 		
 		// レイアウトから削除
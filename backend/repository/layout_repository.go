@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
 	"go-react-app/model"
 
@@ -8,12 +9,18 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+// ErrLayoutVersionConflict はレイアウトのバージョンが一致しない（他の編集で更新された）場合のエラー
+var ErrLayoutVersionConflict = errors.New("layout has been modified by another request")
+
 type ILayoutRepository interface {
 	GetAllLayouts(userId uint) ([]model.Layout, error)
 	GetLayoutById(userId uint, layoutId uint) (model.Layout, error)
 	CreateLayout(layout *model.Layout) error
+	CreateLayoutTree(layout *model.Layout, parents map[int]int) error
 	UpdateLayout(layout *model.Layout, userId uint, layoutId uint) error
 	DeleteLayout(userId uint, layoutId uint) error
+	UpdateArrangement(userId uint, layoutId uint, version int, components []model.LayoutComponent) error
+	GetPublishedLayout(userId uint, layoutId uint) (model.Layout, error)
 }
 
 type layoutRepository struct {
@@ -40,6 +47,29 @@ func (lr *layoutRepository) GetLayoutById(userId uint, layoutId uint) (model.Lay
 	return layout, nil
 }
 
+// GetPublishedLayout は公開するレイアウトを返します
+// 公開中のスナップショットが設定されている場合は、編集中の状態ではなくスナップショットの状態を返す
+func (lr *layoutRepository) GetPublishedLayout(userId uint, layoutId uint) (model.Layout, error) {
+	layout, err := lr.GetLayoutById(userId, layoutId)
+	if err != nil || layout.LiveSnapshotId == nil {
+		return layout, err
+	}
+	var snapshot model.LayoutSnapshot
+	if err := lr.db.Where("user_id=? AND layout_id=?", userId, layoutId).First(&snapshot, *layout.LiveSnapshotId).Error; err != nil {
+		return model.Layout{}, err
+	}
+	data, err := snapshot.ParseData()
+	if err != nil {
+		return model.Layout{}, err
+	}
+	published := data.ToLayout(layout.ID, userId)
+	published.Version = layout.Version
+	published.LiveSnapshotId = layout.LiveSnapshotId
+	published.CreatedAt = layout.CreatedAt
+	published.UpdatedAt = layout.UpdatedAt
+	return published, nil
+}
+
 func (lr *layoutRepository) CreateLayout(layout *model.Layout) error {
 	if err := lr.db.Create(layout).Error; err != nil {
 		return err
@@ -47,6 +77,24 @@ func (lr *layoutRepository) CreateLayout(layout *model.Layout) error {
 	return nil
 }
 
+// CreateLayoutTree はレイアウトとコンポーネントを作成し、コンポーネントの親子関係を1つのトランザクションで設定します
+// parentsはlayout.Components内の位置で表した子から親への対応（コンポーネントのIDは作成するまで決まらないため）
+func (lr *layoutRepository) CreateLayoutTree(layout *model.Layout, parents map[int]int) error {
+	return lr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(layout).Error; err != nil {
+			return err
+		}
+		for child, parent := range parents {
+			parentId := layout.Components[parent].ID
+			if err := tx.Model(&model.LayoutComponent{}).Where("id=?", layout.Components[child].ID).Update("parent_id", parentId).Error; err != nil {
+				return err
+			}
+			layout.Components[child].ParentId = &parentId
+		}
+		return nil
+	})
+}
+
 func (lr *layoutRepository) UpdateLayout(layout *model.Layout, userId uint, layoutId uint) error {
 	// まず、レイアウトが存在するか確認
 	var existingLayout model.Layout
@@ -62,7 +110,12 @@ func (lr *layoutRepository) UpdateLayout(layout *model.Layout, userId uint, layo
 	result = lr.db.Model(&model.Layout{}).Clauses(clause.Returning{}).
 		Where("id=? AND user_id=?", layoutId, userId).
 		Updates(map[string]interface{}{
-			"title": layout.Title,
+			"title":          layout.Title,
+			"grid_columns":   layout.GridColumns,
+			"row_height":     layout.RowHeight,
+			"canvas_width":   layout.CanvasWidth,
+			"collision_mode": layout.CollisionMode,
+			"version":        gorm.Expr("version + 1"),
 		}).First(layout)
 	
 	if result.Error != nil {
@@ -82,4 +135,38 @@ func (lr *layoutRepository) DeleteLayout(userId uint, layoutId uint) error {
 		return fmt.Errorf("layout does not exist")
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// UpdateArrangement はバージョンが一致する場合のみ、レイアウトのコンポーネントの位置とサイズを1つのトランザクションで保存します
+func (lr *layoutRepository) UpdateArrangement(userId uint, layoutId uint, version int, components []model.LayoutComponent) error {
+	return lr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Layout{}).
+			Where("id=? AND user_id=? AND version=?", layoutId, userId, version).
+			Update("version", gorm.Expr("version + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected < 1 {
+			return ErrLayoutVersionConflict
+		}
+
+		for _, component := range components {
+			result := tx.Model(&model.LayoutComponent{}).
+				Where("id=? AND user_id=? AND layout_id=?", component.ID, userId, layoutId).
+				Updates(map[string]interface{}{
+					"x":           component.X,
+					"y":           component.Y,
+					"width":       component.Width,
+					"height":      component.Height,
+					"breakpoints": component.Breakpoints,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected < 1 {
+				return fmt.Errorf("layout component %d does not exist in layout", component.ID)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"go-react-app/model"
+
+	"gorm.io/gorm"
+)
+
+type ILayoutSnapshotRepository interface {
+	GetSnapshots(userId uint, layoutId uint) ([]model.LayoutSnapshot, error)
+	GetSnapshotById(userId uint, layoutId uint, snapshotId uint) (model.LayoutSnapshot, error)
+	CreateSnapshot(snapshot *model.LayoutSnapshot) error
+	SetLiveSnapshot(userId uint, layoutId uint, snapshotId *uint) error
+	RestoreSnapshot(userId uint, layoutId uint, data model.SnapshotData) error
+}
+
+type layoutSnapshotRepository struct {
+	db *gorm.DB
+}
+
+func NewLayoutSnapshotRepository(db *gorm.DB) ILayoutSnapshotRepository {
+	return &layoutSnapshotRepository{db}
+}
+
+func (sr *layoutSnapshotRepository) GetSnapshots(userId uint, layoutId uint) ([]model.LayoutSnapshot, error) {
+	var snapshots []model.LayoutSnapshot
+	if err := sr.db.Where("user_id=? AND layout_id=?", userId, layoutId).Order("id DESC").Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func (sr *layoutSnapshotRepository) GetSnapshotById(userId uint, layoutId uint, snapshotId uint) (model.LayoutSnapshot, error) {
+	var snapshot model.LayoutSnapshot
+	if err := sr.db.Where("user_id=? AND layout_id=?", userId, layoutId).First(&snapshot, snapshotId).Error; err != nil {
+		return model.LayoutSnapshot{}, err
+	}
+	return snapshot, nil
+}
+
+func (sr *layoutSnapshotRepository) CreateSnapshot(snapshot *model.LayoutSnapshot) error {
+	return sr.db.Create(snapshot).Error
+}
+
+// SetLiveSnapshot は公開中のスナップショットを設定します（nilの場合は解除して編集中の状態を公開する）
+func (sr *layoutSnapshotRepository) SetLiveSnapshot(userId uint, layoutId uint, snapshotId *uint) error {
+	result := sr.db.Model(&model.Layout{}).
+		Where("id=? AND user_id=?", layoutId, userId).
+		Update("live_snapshot_id", snapshotId)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return fmt.Errorf("layout does not exist")
+	}
+	return nil
+}
+
+// RestoreSnapshot はレイアウトのタイトル・グリッドの設定・コンポーネントをスナップショットの状態に戻します
+// 削除済みのコンポーネントや他のレイアウトに移動したコンポーネントは複製して戻し、
+// スナップショットにないコンポーネントはレイアウトから外す（削除はしない）
+// 親子関係は、複製して戻したコンポーネントの新しいIDに合わせて設定し直す
+func (sr *layoutSnapshotRepository) RestoreSnapshot(userId uint, layoutId uint, data model.SnapshotData) error {
+	return sr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Layout{}).
+			Where("id=? AND user_id=?", layoutId, userId).
+			Updates(map[string]interface{}{
+				"title":          data.Title,
+				"grid_columns":   data.Grid.Columns,
+				"row_height":     data.Grid.RowHeight,
+				"canvas_width":   data.Grid.CanvasWidth,
+				"collision_mode": data.Grid.CollisionMode,
+				"version":        gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected < 1 {
+			return fmt.Errorf("layout does not exist")
+		}
+
+		restored := make([]uint, 0, len(data.Components))
+		for _, snapshotComponent := range data.Components {
+			component := model.LayoutComponent{
+				Name:       snapshotComponent.Name,
+				Type:       snapshotComponent.Type,
+				Content:    snapshotComponent.Content,
+				Properties: string(snapshotComponent.Properties),
+				X:          snapshotComponent.X,
+				Y:          snapshotComponent.Y,
+				Width:      snapshotComponent.Width,
+				Height:     snapshotComponent.Height,
+				ZIndex:     snapshotComponent.ZIndex,
+				UserId:     userId,
+				LayoutId:   &layoutId,
+			}
+			if snapshotComponent.Breakpoints != nil {
+				component.SetBreakpointOverrides(*snapshotComponent.Breakpoints)
+			}
+
+			var existing model.LayoutComponent
+			err := tx.Where("id=? AND user_id=?", snapshotComponent.ID, userId).First(&existing).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if err == nil && (existing.LayoutId == nil || *existing.LayoutId == layoutId) {
+				if err := tx.Model(&existing).Updates(map[string]interface{}{
+					"name":        component.Name,
+					"type":        component.Type,
+					"content":     component.Content,
+					"properties":  component.Properties,
+					"x":           component.X,
+					"y":           component.Y,
+					"width":       component.Width,
+					"height":      component.Height,
+					"breakpoints": component.Breakpoints,
+					"z_index":     component.ZIndex,
+					"layout_id":   layoutId,
+				}).Error; err != nil {
+					return err
+				}
+				restored = append(restored, existing.ID)
+				continue
+			}
+
+			if err := tx.Create(&component).Error; err != nil {
+				return err
+			}
+			restored = append(restored, component.ID)
+		}
+
+		// スナップショットのIDから戻したコンポーネントのIDへの対応で親を設定する
+		restoredIds := make(map[uint]uint, len(restored))
+		for i, snapshotComponent := range data.Components {
+			restoredIds[snapshotComponent.ID] = restored[i]
+		}
+		for i, snapshotComponent := range data.Components {
+			var parentId *uint
+			if snapshotComponent.ParentId != nil {
+				if id, ok := restoredIds[*snapshotComponent.ParentId]; ok && id != restored[i] {
+					parentId = &id
+				}
+			}
+			if err := tx.Model(&model.LayoutComponent{}).Where("id=?", restored[i]).Update("parent_id", parentId).Error; err != nil {
+				return err
+			}
+		}
+
+		detach := tx.Model(&model.LayoutComponent{}).Where("layout_id=? AND user_id=?", layoutId, userId)
+		if len(restored) > 0 {
+			detach = detach.Where("id NOT IN ?", restored)
+		}
+		return detach.Updates(map[string]interface{}{"layout_id": nil, "parent_id": nil, "x": 0, "y": 0, "breakpoints": ""}).Error
+	})
+}
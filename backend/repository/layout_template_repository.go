@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"fmt"
+	"go-react-app/model"
+
+	"gorm.io/gorm"
+)
+
+type ILayoutTemplateRepository interface {
+	GetAllTemplates() ([]model.LayoutTemplate, error)
+	GetTemplateById(templateId uint) (model.LayoutTemplate, error)
+	CreateTemplate(template *model.LayoutTemplate) error
+	DeleteTemplate(userId uint, templateId uint) error
+}
+
+type layoutTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewLayoutTemplateRepository(db *gorm.DB) ILayoutTemplateRepository {
+	return &layoutTemplateRepository{db}
+}
+
+// GetAllTemplates はすべてのユーザーが公開したテンプレートを新しい順に返します
+func (tr *layoutTemplateRepository) GetAllTemplates() ([]model.LayoutTemplate, error) {
+	var templates []model.LayoutTemplate
+	if err := tr.db.Preload("User").Order("created_at DESC, id DESC").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (tr *layoutTemplateRepository) GetTemplateById(templateId uint) (model.LayoutTemplate, error) {
+	var template model.LayoutTemplate
+	if err := tr.db.Preload("User").First(&template, templateId).Error; err != nil {
+		return model.LayoutTemplate{}, err
+	}
+	return template, nil
+}
+
+func (tr *layoutTemplateRepository) CreateTemplate(template *model.LayoutTemplate) error {
+	return tr.db.Create(template).Error
+}
+
+// DeleteTemplate は公開したユーザー本人のテンプレートのみ削除します
+func (tr *layoutTemplateRepository) DeleteTemplate(userId uint, templateId uint) error {
+	result := tr.db.Where("id=? AND user_id=?", templateId, userId).Delete(&model.LayoutTemplate{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return fmt.Errorf("layout template does not exist")
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"fmt"
+	"go-react-app/model"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type IMediaRepository interface {
+	GetAllMedia(userId uint) ([]model.Media, error)
+	GetMediaById(userId uint, mediaId uint) (model.Media, error)
+	CreateMedia(media *model.Media) error
+	DeleteMedia(userId uint, mediaId uint) error
+	GetTotalSize(userId uint) (int64, error)
+	FindReferences(userId uint, key string) ([]model.MediaReference, error)
+}
+
+type mediaRepository struct {
+	db *gorm.DB
+}
+
+func NewMediaRepository(db *gorm.DB) IMediaRepository {
+	return &mediaRepository{db}
+}
+
+func (mr *mediaRepository) GetAllMedia(userId uint) ([]model.Media, error) {
+	var media []model.Media
+	if err := mr.db.Where("user_id=?", userId).Order("created_at DESC").Find(&media).Error; err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+func (mr *mediaRepository) GetMediaById(userId uint, mediaId uint) (model.Media, error) {
+	var media model.Media
+	if err := mr.db.Where("user_id=?", userId).First(&media, mediaId).Error; err != nil {
+		return model.Media{}, err
+	}
+	return media, nil
+}
+
+func (mr *mediaRepository) CreateMedia(media *model.Media) error {
+	return mr.db.Create(media).Error
+}
+
+func (mr *mediaRepository) DeleteMedia(userId uint, mediaId uint) error {
+	result := mr.db.Where("id=? AND user_id=?", mediaId, userId).Delete(&model.Media{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return fmt.Errorf("object does not exist")
+	}
+	return nil
+}
+
+// GetTotalSize はユーザーがアップロードしたメディアの合計サイズ（バイト）を返します
+func (mr *mediaRepository) GetTotalSize(userId uint) (int64, error) {
+	var total int64
+	err := mr.db.Model(&model.Media{}).
+		Where("user_id=?", userId).
+		Select("COALESCE(SUM(size), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// FindReferences はメディアのキーを本文や画像URLに含む記事・レイアウトコンポーネント・書籍・レイアウトのスナップショット・テンプレートを返します
+// 記事はOGP画像、レイアウトコンポーネントは種類ごとの設定（画像コンポーネントのURLなど）に指定されている場合も参照として扱う
+// スナップショットは公開中のものや復元できるもの、テンプレートは他のユーザーが使うものが残るため、保存したJSONに含む場合も参照として扱う
+func (mr *mediaRepository) FindReferences(userId uint, key string) ([]model.MediaReference, error) {
+	pattern := "%" + escapeLike(key) + "%"
+	references := []model.MediaReference{}
+
+	var articles []model.Article
+	if err := mr.db.Select("id", "title").
+		Where("user_id=? AND (content LIKE ? ESCAPE '\\' OR og_image LIKE ? ESCAPE '\\')", userId, pattern, pattern).
+		Find(&articles).Error; err != nil {
+		return nil, err
+	}
+	for _, article := range articles {
+		references = append(references, model.MediaReference{Type: model.MediaReferenceArticle, ID: article.ID, Title: article.Title})
+	}
+
+	var components []model.LayoutComponent
+	if err := mr.db.Select("id", "name").
+		Where("user_id=? AND (content LIKE ? ESCAPE '\\' OR properties LIKE ? ESCAPE '\\')", userId, pattern, pattern).
+		Find(&components).Error; err != nil {
+		return nil, err
+	}
+	for _, component := range components {
+		references = append(references, model.MediaReference{Type: model.MediaReferenceLayoutComponent, ID: component.ID, Title: component.Name})
+	}
+
+	var books []model.Book
+	if err := mr.db.Select("id", "title").
+		Where("user_id=? AND image_url LIKE ? ESCAPE '\\'", userId, pattern).
+		Find(&books).Error; err != nil {
+		return nil, err
+	}
+	for _, book := range books {
+		references = append(references, model.MediaReference{Type: model.MediaReferenceBook, ID: book.ID, Title: book.Title})
+	}
+
+	var snapshots []model.LayoutSnapshot
+	if err := mr.db.Select("id", "label", "version").
+		Where("user_id=? AND data LIKE ? ESCAPE '\\'", userId, pattern).
+		Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+	for _, snapshot := range snapshots {
+		title := snapshot.Label
+		if title == "" {
+			title = fmt.Sprintf("バージョン%d", snapshot.Version)
+		}
+		references = append(references, model.MediaReference{Type: model.MediaReferenceLayoutSnapshot, ID: snapshot.ID, Title: title})
+	}
+
+	var templates []model.LayoutTemplate
+	if err := mr.db.Select("id", "name").
+		Where("user_id=? AND data LIKE ? ESCAPE '\\'", userId, pattern).
+		Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	for _, template := range templates {
+		references = append(references, model.MediaReference{Type: model.MediaReferenceLayoutTemplate, ID: template.ID, Title: template.Name})
+	}
+
+	return references, nil
+}
+
+// escapeLike はLIKE検索で特別な意味を持つ文字をエスケープします
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
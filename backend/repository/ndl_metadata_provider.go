@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"encoding/xml"
+	"fmt"
+	"go-react-app/model"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultNDLSearchBaseURL 国立国会図書館サーチのAPIのURL
+const DefaultNDLSearchBaseURL = "https://ndlsearch.ndl.go.jp/api"
+
+// ndlPagesPattern 大きさ・ページ数（例: 238p ; 24cm）からページ数を取り出すパターン
+var ndlPagesPattern = regexp.MustCompile(`(\d+)\s*p`)
+
+type ndlMetadataProvider struct {
+	baseURL string
+}
+
+// NewNDLMetadataProvider は国立国会図書館サーチのOpenSearch APIから書籍の情報を取得するプロバイダーを作成します
+// baseURLが空の場合はDefaultNDLSearchBaseURLを使う
+func NewNDLMetadataProvider(baseURL string) IBookMetadataProvider {
+	if baseURL == "" {
+		baseURL = DefaultNDLSearchBaseURL
+	}
+	return &ndlMetadataProvider{strings.TrimRight(baseURL, "/")}
+}
+
+func (p *ndlMetadataProvider) Name() string {
+	return model.BookMetadataProviderNDL
+}
+
+// ndlSearchResult OpenSearch APIのRSSのうち使う項目
+type ndlSearchResult struct {
+	Items []struct {
+		Title      string   `xml:"http://purl.org/dc/elements/1.1/ title"`
+		Creators   []string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+		Publishers []string `xml:"http://purl.org/dc/elements/1.1/ publisher"`
+		Issued     string   `xml:"http://purl.org/dc/terms/ issued"`
+		Extents    []string `xml:"http://purl.org/dc/elements/1.1/ extent"`
+	} `xml:"channel>item"`
+}
+
+func (p *ndlMetadataProvider) LookupISBN(userId uint, isbn string) (model.BookMetadata, error) {
+	params := url.Values{}
+	params.Add("isbn", isbn)
+	params.Add("cnt", "1")
+	resp, err := metadataClient.Get(p.baseURL + "/opensearch?" + params.Encode())
+	if err != nil {
+		return model.BookMetadata{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return model.BookMetadata{}, fmt.Errorf("NDL Search request failed with status code: %d", resp.StatusCode)
+	}
+
+	var result ndlSearchResult
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return model.BookMetadata{}, err
+	}
+	if len(result.Items) == 0 {
+		return model.BookMetadata{}, ErrBookMetadataNotFound
+	}
+
+	item := result.Items[0]
+	metadata := model.BookMetadata{
+		ISBN:          isbn,
+		Title:         strings.TrimSpace(item.Title),
+		Authors:       []string{},
+		PublishedDate: strings.ReplaceAll(strings.TrimSpace(item.Issued), ".", "-"), // 2018.10の形式をYYYY-MMにする
+		Sources:       []string{model.BookMetadataProviderNDL},
+	}
+	for _, creator := range item.Creators {
+		if creator = strings.TrimSpace(creator); creator != "" {
+			metadata.Authors = append(metadata.Authors, creator)
+		}
+	}
+	if len(item.Publishers) > 0 {
+		metadata.Publisher = strings.TrimSpace(item.Publishers[0])
+	}
+	for _, extent := range item.Extents {
+		if match := ndlPagesPattern.FindStringSubmatch(extent); match != nil {
+			metadata.PageCount, _ = strconv.Atoi(match[1])
+			break
+		}
+	}
+	return metadata, nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-react-app/model"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultOpenBDBaseURL openBDのAPIのURL
+const DefaultOpenBDBaseURL = "https://api.openbd.jp/v1"
+
+type openBDMetadataProvider struct {
+	baseURL string
+}
+
+// NewOpenBDMetadataProvider はopenBDから書籍の情報を取得するプロバイダーを作成します
+// baseURLが空の場合はDefaultOpenBDBaseURLを使う
+func NewOpenBDMetadataProvider(baseURL string) IBookMetadataProvider {
+	if baseURL == "" {
+		baseURL = DefaultOpenBDBaseURL
+	}
+	return &openBDMetadataProvider{strings.TrimRight(baseURL, "/")}
+}
+
+func (p *openBDMetadataProvider) Name() string {
+	return model.BookMetadataProviderOpenBD
+}
+
+// openBDBook openBDのレスポンスのうち使う項目（ONIXの項目はページ数と内容紹介のみ）
+type openBDBook struct {
+	Summary struct {
+		ISBN      string `json:"isbn"`
+		Title     string `json:"title"`
+		Volume    string `json:"volume"`
+		Publisher string `json:"publisher"`
+		PubDate   string `json:"pubdate"`
+		Cover     string `json:"cover"`
+		Author    string `json:"author"`
+	} `json:"summary"`
+	Onix struct {
+		DescriptiveDetail struct {
+			Extent []struct {
+				ExtentType  string `json:"ExtentType"`
+				ExtentValue string `json:"ExtentValue"`
+			} `json:"Extent"`
+		} `json:"DescriptiveDetail"`
+		CollateralDetail struct {
+			TextContent []struct {
+				TextType string `json:"TextType"`
+				Text     string `json:"Text"`
+			} `json:"TextContent"`
+		} `json:"CollateralDetail"`
+	} `json:"onix"`
+}
+
+func (p *openBDMetadataProvider) LookupISBN(userId uint, isbn string) (model.BookMetadata, error) {
+	resp, err := metadataClient.Get(p.baseURL + "/get?isbn=" + url.QueryEscape(isbn))
+	if err != nil {
+		return model.BookMetadata{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return model.BookMetadata{}, fmt.Errorf("openBD request failed with status code: %d", resp.StatusCode)
+	}
+
+	// 見つからないISBNはnullになる
+	var books []*openBDBook
+	if err := json.NewDecoder(resp.Body).Decode(&books); err != nil {
+		return model.BookMetadata{}, err
+	}
+	if len(books) == 0 || books[0] == nil {
+		return model.BookMetadata{}, ErrBookMetadataNotFound
+	}
+
+	book := books[0]
+	metadata := model.BookMetadata{
+		ISBN:          isbn,
+		Title:         strings.TrimSpace(strings.Join([]string{book.Summary.Title, book.Summary.Volume}, " ")),
+		Authors:       openBDAuthors(book.Summary.Author),
+		Publisher:     book.Summary.Publisher,
+		PublishedDate: openBDDate(book.Summary.PubDate),
+		ImageURL:      book.Summary.Cover,
+		Sources:       []string{model.BookMetadataProviderOpenBD},
+	}
+	// ExtentType 11はページ数、TextType 03は内容紹介（ONIXのコードリスト）
+	for _, extent := range book.Onix.DescriptiveDetail.Extent {
+		if extent.ExtentType == "11" {
+			metadata.PageCount, _ = strconv.Atoi(extent.ExtentValue)
+		}
+	}
+	for _, text := range book.Onix.CollateralDetail.TextContent {
+		if text.TextType == "03" {
+			metadata.Description = text.Text
+		}
+	}
+	return metadata, nil
+}
+
+// openBDAuthors は「名前／著 名前／翻訳」の形式の著者から役割を除き、著者ごとに分けます
+func openBDAuthors(value string) []string {
+	authors := []string{}
+	for _, field := range strings.Fields(strings.ReplaceAll(value, "　", " ")) {
+		name, _, _ := strings.Cut(field, "／")
+		if name = strings.TrimSpace(name); name != "" {
+			authors = append(authors, name)
+		}
+	}
+	return authors
+}
+
+// openBDDate は出版年月日（YYYYMMDD・YYYYMMなど）をYYYY-MM-DD・YYYY-MMの形式にします
+func openBDDate(value string) string {
+	switch digits := strings.ReplaceAll(value, "-", ""); {
+	case len(digits) == 8:
+		return digits[:4] + "-" + digits[4:6] + "-" + digits[6:]
+	case len(digits) == 6:
+		return digits[:4] + "-" + digits[4:]
+	default:
+		return value
+	}
+}
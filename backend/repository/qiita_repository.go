@@ -2,6 +2,7 @@ package repository
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -10,30 +11,49 @@ import (
 )
 
 type IQiitaRepository interface {
-	GetQiitaArticles() ([]model.QiitaArticle, error)
-	GetQiitaArticleByID(id string) (model.QiitaArticle, error)
+	GetQiitaArticles(userId uint) ([]model.QiitaArticle, error)
+	GetQiitaArticleByID(userId uint, id string) (model.QiitaArticle, error)
 }
 
 type qiitaRepository struct {
 	baseURL string
-	token   string
+	sr      ISecretRepository
 }
 
-func NewQiitaRepository() IQiitaRepository {
+func NewQiitaRepository(sr ISecretRepository) IQiitaRepository {
 	return &qiitaRepository{
 		baseURL: "https://qiita.com/api/v2",
-		token:   os.Getenv("QIITA_ACCESS_TOKEN"),
+		sr:      sr,
 	}
 }
 
-func (qr *qiitaRepository) GetQiitaArticles() ([]model.QiitaArticle, error) {
+// setAuthorization はユーザーが保存したアクセストークン、なければ環境変数QIITA_ACCESS_TOKENを設定します
+// どちらもない場合は認証なしで呼び出す（Qiitaの認証なしの利用制限が適用される）
+// マスターキーが設定されていない場合は保存済みのアクセストークンを復号できないため、環境変数のみから取得する
+func (qr *qiitaRepository) setAuthorization(req *http.Request, userId uint) error {
+	token, err := qr.sr.ResolveSecret(userId, model.SecretNameQiitaAccessToken)
+	if err != nil && !errors.Is(err, ErrSecretStoreDisabled) {
+		return err
+	}
+	if token == "" {
+		token = os.Getenv("QIITA_ACCESS_TOKEN")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return nil
+}
+
+func (qr *qiitaRepository) GetQiitaArticles(userId uint) ([]model.QiitaArticle, error) {
 	url := fmt.Sprintf("%s/items", qr.baseURL)
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+qr.token)
+	if err := qr.setAuthorization(req, userId); err != nil {
+		return nil, err
+	}
 	
 	client := &http.Client{}
 	resp, err := client.Do(req)
@@ -54,14 +74,16 @@ func (qr *qiitaRepository) GetQiitaArticles() ([]model.QiitaArticle, error) {
 	return articles, nil
 }
 
-func (qr *qiitaRepository) GetQiitaArticleByID(id string) (model.QiitaArticle, error) {
+func (qr *qiitaRepository) GetQiitaArticleByID(userId uint, id string) (model.QiitaArticle, error) {
 	url := fmt.Sprintf("%s/items/%s", qr.baseURL, id)
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return model.QiitaArticle{}, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+qr.token)
+	if err := qr.setAuthorization(req, userId); err != nil {
+		return model.QiitaArticle{}, err
+	}
 	
 	client := &http.Client{}
 	resp, err := client.Do(req)
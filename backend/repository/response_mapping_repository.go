@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"encoding/json"
+	"go-react-app/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IResponseMappingRepository interface {
+	GetAllResponseMappings(mappings *[]model.ResponseMapping, userId uint) error
+	GetResponseMappingById(mapping *model.ResponseMapping, userId uint, mappingId uint) error
+	CreateResponseMapping(mapping *model.ResponseMapping) error
+	UpdateResponseMapping(mapping *model.ResponseMapping, userId uint, mappingId uint) error
+	DeleteResponseMapping(userId uint, mappingId uint) error
+}
+
+type responseMappingRepository struct {
+	db *gorm.DB
+}
+
+func NewResponseMappingRepository(db *gorm.DB) IResponseMappingRepository {
+	return &responseMappingRepository{db}
+}
+
+func (rmr *responseMappingRepository) GetAllResponseMappings(mappings *[]model.ResponseMapping, userId uint) error {
+	return rmr.db.Where("user_id=?", userId).Order("created_at").Find(mappings).Error
+}
+
+func (rmr *responseMappingRepository) GetResponseMappingById(mapping *model.ResponseMapping, userId uint, mappingId uint) error {
+	return rmr.db.Where("user_id=?", userId).First(mapping, mappingId).Error
+}
+
+func (rmr *responseMappingRepository) CreateResponseMapping(mapping *model.ResponseMapping) error {
+	return rmr.db.Create(mapping).Error
+}
+
+func (rmr *responseMappingRepository) UpdateResponseMapping(mapping *model.ResponseMapping, userId uint, mappingId uint) error {
+	// マップでの更新ではserializerが使われないため、JSONの列は文字列にしてから渡す
+	params, err := json.Marshal(mapping.Params)
+	if err != nil {
+		return err
+	}
+	fields, err := json.Marshal(mapping.Fields)
+	if err != nil {
+		return err
+	}
+	result := rmr.db.Model(mapping).Clauses(clause.Returning{}).Where("id=? AND user_id=?", mappingId, userId).Updates(map[string]interface{}{
+		"name":            mapping.Name,
+		"external_api_id": mapping.ExternalAPIId,
+		"params":          string(params),
+		"items_path":      mapping.ItemsPath,
+		"fields":          string(fields),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (rmr *responseMappingRepository) DeleteResponseMapping(userId uint, mappingId uint) error {
+	result := rmr.db.Where("id=? AND user_id=?", mappingId, userId).Delete(&model.ResponseMapping{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
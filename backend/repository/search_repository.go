@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/utils/search"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// searchFTSTable はSQLiteで使用する全文検索用仮想テーブル名
+const searchFTSTable = "search_documents_fts"
+
+type ISearchRepository interface {
+	UpsertDocument(document *model.SearchDocument) error
+	UpsertDocuments(documents []model.SearchDocument) error
+	DeleteDocument(userId uint, sourceType string, sourceId string) error
+	// DeleteFeedDocuments はフィードのフィード記事のドキュメントをすべて削除します
+	DeleteFeedDocuments(userId uint, feedId uint) error
+	SearchDocuments(userId uint, phrases []search.Phrase, limit int) ([]model.SearchDocument, int64, error)
+}
+
+type searchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) ISearchRepository {
+	return &searchRepository{db}
+}
+
+// SetupSearchIndex は全文検索用のインデックスを作成します
+// PostgreSQLではtsvectorのGINインデックスとpg_trgmインデックスを、
+// SQLiteではFTS5（利用できない場合はFTS4）の仮想テーブルを作成します
+func SetupSearchIndex(db *gorm.DB) error {
+	switch db.Dialector.Name() {
+	case "postgres":
+		statements := []string{
+			"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+			"CREATE INDEX IF NOT EXISTS idx_search_documents_tokens ON search_documents USING GIN (to_tsvector('simple', tokens))",
+			"CREATE INDEX IF NOT EXISTS idx_search_documents_title_trgm ON search_documents USING GIN (title gin_trgm_ops)",
+		}
+		for _, stmt := range statements {
+			if err := db.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	case "sqlite":
+		err := db.Exec("CREATE VIRTUAL TABLE IF NOT EXISTS " + searchFTSTable + " USING fts5(tokens)").Error
+		if err != nil {
+			// FTS5が組み込まれていないビルドではFTS4を使用する
+			return db.Exec("CREATE VIRTUAL TABLE IF NOT EXISTS " + searchFTSTable + " USING fts4(tokens)").Error
+		}
+		return nil
+	}
+	return nil
+}
+
+func (sr *searchRepository) UpsertDocument(document *model.SearchDocument) error {
+	return sr.db.Transaction(func(tx *gorm.DB) error {
+		return upsertDocument(tx, document)
+	})
+}
+
+// UpsertDocuments は複数のドキュメントを1つのトランザクションで登録・更新します
+func (sr *searchRepository) UpsertDocuments(documents []model.SearchDocument) error {
+	if len(documents) == 0 {
+		return nil
+	}
+	return sr.db.Transaction(func(tx *gorm.DB) error {
+		for i := range documents {
+			if err := upsertDocument(tx, &documents[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func upsertDocument(tx *gorm.DB, document *model.SearchDocument) error {
+	document.Tokens = search.TokenString(document.Title + " " + document.Tags + " " + search.PlainText(document.Body))
+
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "source_type"}, {Name: "source_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "body", "tags", "url", "tokens", "updated_at"}),
+	}).Create(document).Error
+	if err != nil {
+		return err
+	}
+
+	if tx.Dialector.Name() != "sqlite" {
+		return nil
+	}
+
+	// ON CONFLICTで更新された場合はIDが返らないため取得し直す
+	var stored model.SearchDocument
+	if err := tx.Where("user_id=? AND source_type=? AND source_id=?",
+		document.UserId, document.SourceType, document.SourceID).First(&stored).Error; err != nil {
+		return err
+	}
+	document.ID = stored.ID
+
+	if err := tx.Exec("DELETE FROM "+searchFTSTable+" WHERE rowid = ?", stored.ID).Error; err != nil {
+		return err
+	}
+	return tx.Exec("INSERT INTO "+searchFTSTable+"(rowid, tokens) VALUES (?, ?)", stored.ID, document.Tokens).Error
+}
+
+func (sr *searchRepository) DeleteDocument(userId uint, sourceType string, sourceId string) error {
+	return sr.db.Transaction(func(tx *gorm.DB) error {
+		var document model.SearchDocument
+		result := tx.Where("user_id=? AND source_type=? AND source_id=?", userId, sourceType, sourceId).Limit(1).Find(&document)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected < 1 {
+			return nil
+		}
+
+		if tx.Dialector.Name() == "sqlite" {
+			if err := tx.Exec("DELETE FROM "+searchFTSTable+" WHERE rowid = ?", document.ID).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&document).Error
+	})
+}
+
+func (sr *searchRepository) DeleteFeedDocuments(userId uint, feedId uint) error {
+	return sr.db.Transaction(func(tx *gorm.DB) error {
+		// フィード記事のソースIDは"{フィードID}:{記事ID}"
+		query := tx.Where("user_id=? AND source_type=? AND source_id LIKE ?", userId, model.SearchSourceFeedArticle, fmt.Sprintf("%d:%%", feedId))
+		var ids []uint
+		if err := query.Session(&gorm.Session{}).Model(&model.SearchDocument{}).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if tx.Dialector.Name() == "sqlite" {
+			if err := tx.Exec("DELETE FROM "+searchFTSTable+" WHERE rowid IN ?", ids).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Where("id IN ?", ids).Delete(&model.SearchDocument{}).Error
+	})
+}
+
+// SearchDocuments はクエリにマッチするドキュメントを関連度の高い順にlimit件取得し、マッチした総件数とともに返します
+// PostgreSQLではts_rankで、それ以外ではタイトル・タグ・本文に検索語を含むかの重み付けで関連度を計算する
+func (sr *searchRepository) SearchDocuments(userId uint, phrases []search.Phrase, limit int) ([]model.SearchDocument, int64, error) {
+	var documents []model.SearchDocument
+	if len(phrases) == 0 {
+		return documents, 0, nil
+	}
+
+	query := sr.db.Model(&model.SearchDocument{}).Where("search_documents.user_id = ?", userId)
+	var rank clause.Expr
+	switch sr.db.Dialector.Name() {
+	case "postgres":
+		tsquery := search.TSQueryExpression(phrases)
+		condition := "to_tsvector('simple', search_documents.tokens) @@ to_tsquery('simple', ?)"
+		args := []interface{}{tsquery}
+		// 単一語の検索ではpg_trgmインデックスを使ったタイトルの部分一致も候補に含める
+		if term := joinPhrase(phrases); term != "" {
+			condition += " OR search_documents.title ILIKE ?"
+			args = append(args, "%"+term+"%")
+		}
+		query = query.Where(condition, args...)
+		rank = clause.Expr{
+			SQL:  "ts_rank(to_tsvector('simple', search_documents.tokens), to_tsquery('simple', ?))",
+			Vars: []interface{}{tsquery},
+		}
+	case "sqlite":
+		query = query.
+			Joins("JOIN "+searchFTSTable+" ON "+searchFTSTable+".rowid = search_documents.id").
+			Where(searchFTSTable+" MATCH ?", search.MatchExpression(phrases))
+		rank = weightedRank(phrases)
+	default:
+		for _, p := range phrases {
+			for _, token := range p.Tokens {
+				query = query.Where("search_documents.tokens LIKE ?", "%"+token+"%")
+			}
+		}
+		rank = weightedRank(phrases)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 更新日時ではなく関連度で絞り込み、同点の場合のみ更新日時の新しい順にする
+	err := query.
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: "? DESC, search_documents.updated_at DESC", Vars: []interface{}{rank}, WithoutParentheses: true}}).
+		Limit(limit).
+		Find(&documents).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return documents, total, nil
+}
+
+// weightedRank はフレーズごとにタイトル・タグ・本文に含まれるかを3:2:1で重み付けした関連度の式を返します
+func weightedRank(phrases []search.Phrase) clause.Expr {
+	parts := make([]string, 0, len(phrases))
+	var vars []interface{}
+	for _, p := range phrases {
+		pattern := "%" + phraseTerm(p) + "%"
+		parts = append(parts, "(CASE WHEN search_documents.title LIKE ? THEN 3 ELSE 0 END"+
+			" + CASE WHEN search_documents.tags LIKE ? THEN 2 ELSE 0 END"+
+			" + CASE WHEN search_documents.body LIKE ? THEN 1 ELSE 0 END)")
+		vars = append(vars, pattern, pattern, pattern)
+	}
+	return clause.Expr{SQL: strings.Join(parts, " + "), Vars: vars}
+}
+
+// joinPhrase は単一フレーズのクエリを元の語へ戻します（複数フレーズの場合は空文字）
+func joinPhrase(phrases []search.Phrase) string {
+	if len(phrases) != 1 {
+		return ""
+	}
+	return phraseTerm(phrases[0])
+}
+
+// phraseTerm はフレーズのトークン列を元の語へ戻します
+func phraseTerm(phrase search.Phrase) string {
+	tokens := phrase.Tokens
+	if phrase.Prefix || len(tokens) == 1 {
+		return tokens[0]
+	}
+	runes := []rune(tokens[0])
+	for _, token := range tokens[1:] {
+		r := []rune(token)
+		runes = append(runes, r[len(r)-1])
+	}
+	return string(runes)
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/utils/secretbox"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrSecretStoreDisabled はマスターキーが設定されていないため秘密情報を保存できない場合のエラー
+var ErrSecretStoreDisabled = errors.New("secret store is not configured")
+
+type ISecretRepository interface {
+	// GetSecrets はユーザーの秘密情報の一覧を名前順に返します（値は復号しない）
+	GetSecrets(userId uint) ([]model.Secret, error)
+	// PutSecret は値を暗号化して保存します。同じ名前の秘密情報がある場合は置き換える
+	PutSecret(userId uint, name string, value string) (model.Secret, error)
+	// ResolveSecret は秘密情報を復号して返します。保存されていない場合は空文字を返す
+	// マスターキーが設定されていないため保存済みの秘密情報を復号できない場合はErrSecretStoreDisabledを返す
+	ResolveSecret(userId uint, name string) (string, error)
+	// DeleteSecret は秘密情報を削除します。保存されていない場合はgorm.ErrRecordNotFoundを返す
+	DeleteSecret(userId uint, name string) error
+	// RotateSecrets は現在のマスターキー以外で暗号化されたデータキーを、現在のマスターキーで暗号化し直します
+	// batchSize件ずつ処理し、暗号化し直した件数を返す
+	RotateSecrets(batchSize int) (int, error)
+	// Enabled はマスターキーが設定され、秘密情報を保存・復号できる場合にtrueを返します
+	Enabled() bool
+}
+
+type secretRepository struct {
+	db      *gorm.DB
+	keyring *secretbox.Keyring
+}
+
+// NewSecretRepository は秘密情報のリポジトリを作成します
+// keyringがnilの場合は秘密情報を保存・復号できない
+func NewSecretRepository(db *gorm.DB, keyring *secretbox.Keyring) ISecretRepository {
+	return &secretRepository{db, keyring}
+}
+
+func (sr *secretRepository) GetSecrets(userId uint) ([]model.Secret, error) {
+	var secrets []model.Secret
+	if err := sr.db.Select("id", "name", "user_id", "created_at", "updated_at").Where("user_id=?", userId).Order("name").Find(&secrets).Error; err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
+
+func (sr *secretRepository) PutSecret(userId uint, name string, value string) (model.Secret, error) {
+	if sr.keyring == nil {
+		return model.Secret{}, ErrSecretStoreDisabled
+	}
+	sealed, err := sr.keyring.Seal([]byte(value), secretAAD(userId, name))
+	if err != nil {
+		return model.Secret{}, err
+	}
+	secret := model.Secret{Name: name, UserId: userId, KeyId: sealed.KeyId, WrappedKey: sealed.WrappedKey, Ciphertext: sealed.Ciphertext}
+	err = sr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"key_id", "wrapped_key", "ciphertext", "updated_at"}),
+		}).Create(&secret).Error
+		if err != nil {
+			return err
+		}
+		// ON CONFLICTで更新された場合は作成日時とIDが返らないため取得し直す
+		return tx.Where("user_id=? AND name=?", userId, name).First(&secret).Error
+	})
+	if err != nil {
+		return model.Secret{}, err
+	}
+	return secret, nil
+}
+
+func (sr *secretRepository) Enabled() bool {
+	return sr.keyring != nil
+}
+
+func (sr *secretRepository) ResolveSecret(userId uint, name string) (string, error) {
+	var secret model.Secret
+	err := sr.db.Where("user_id=? AND name=?", userId, name).Limit(1).Find(&secret).Error
+	if err != nil {
+		return "", err
+	}
+	if secret.ID == 0 {
+		return "", nil
+	}
+	if sr.keyring == nil {
+		// 保存済みの認証情報を使わずに黙って呼び出さないよう、エラーにして知らせる
+		return "", fmt.Errorf("secret %s: %w", name, ErrSecretStoreDisabled)
+	}
+	value, err := sr.keyring.Open(sealedOf(secret), secretAAD(userId, name))
+	if err != nil {
+		return "", fmt.Errorf("secret %s: %w", name, err)
+	}
+	return string(value), nil
+}
+
+func (sr *secretRepository) DeleteSecret(userId uint, name string) error {
+	result := sr.db.Where("user_id=? AND name=?", userId, name).Delete(&model.Secret{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (sr *secretRepository) RotateSecrets(batchSize int) (int, error) {
+	if sr.keyring == nil {
+		return 0, ErrSecretStoreDisabled
+	}
+	current := sr.keyring.CurrentKeyId()
+	rotated := 0
+	for {
+		var secrets []model.Secret
+		if err := sr.db.Where("key_id<>?", current).Order("id").Limit(batchSize).Find(&secrets).Error; err != nil {
+			return rotated, err
+		}
+		if len(secrets) == 0 {
+			return rotated, nil
+		}
+		for _, secret := range secrets {
+			// 復号できないデータキーを残したまま繰り返さないよう、1件でも失敗した場合は中断する
+			sealed, err := sr.keyring.Rewrap(sealedOf(secret))
+			if err != nil {
+				return rotated, fmt.Errorf("secret %d: %w", secret.ID, err)
+			}
+			err = sr.db.Model(&model.Secret{}).Where("id=? AND key_id=?", secret.ID, secret.KeyId).
+				Updates(map[string]interface{}{"key_id": sealed.KeyId, "wrapped_key": sealed.WrappedKey}).Error
+			if err != nil {
+				return rotated, err
+			}
+			rotated++
+		}
+	}
+}
+
+// secretAAD は暗号文を秘密情報の持ち主と名前に結び付ける追加認証データを返します
+// 別のユーザー・別の名前の行に暗号文を移しても復号できない
+func secretAAD(userId uint, name string) []byte {
+	return []byte(fmt.Sprintf("%d:%s", userId, name))
+}
+
+func sealedOf(secret model.Secret) secretbox.Sealed {
+	return secretbox.Sealed{KeyId: secret.KeyId, WrappedKey: secret.WrappedKey, Ciphertext: secret.Ciphertext}
+}
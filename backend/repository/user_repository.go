@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"fmt"
 	"go-react-app/model"
 
 	"gorm.io/gorm"
@@ -9,6 +10,10 @@ import (
 type IUserRepository interface {
 	GetUserByEmail(email string) (*model.User, error)
 	CreateUser(user *model.User) error
+	GetUserById(userId uint) (*model.User, error)
+	GetUserByUsername(username string) (*model.User, error)
+	UpdateUsername(userId uint, username string) (*model.User, error)
+	GetPublishingUsernames() ([]string, error)
 }
 
 type userRepository struct {
@@ -29,4 +34,48 @@ func (ur *userRepository) GetUserByEmail(email string) (*model.User, error) {
 
 func (ur *userRepository) CreateUser(user *model.User) error {
 	return ur.db.Create(user).Error
-}
\ No newline at end of file
+}
+
+func (ur *userRepository) GetUserById(userId uint) (*model.User, error) {
+	user := &model.User{}
+	if err := ur.db.First(user, userId).Error; err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (ur *userRepository) GetUserByUsername(username string) (*model.User, error) {
+	user := &model.User{}
+	if err := ur.db.Where("username = ?", username).First(user).Error; err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (ur *userRepository) UpdateUsername(userId uint, username string) (*model.User, error) {
+	result := ur.db.Model(&model.User{}).Where("id = ?", userId).Update("username", username)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected < 1 {
+		return nil, fmt.Errorf("user does not exist")
+	}
+
+	user := &model.User{}
+	if err := ur.db.First(user, userId).Error; err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// GetPublishingUsernames は検索エンジンのインデックス対象の公開記事があるユーザーのユーザー名を返します
+func (ur *userRepository) GetPublishingUsernames() ([]string, error) {
+	var usernames []string
+	err := ur.db.Model(&model.User{}).
+		Distinct("users.username").
+		Joins("JOIN articles ON articles.user_id = users.id").
+		Where("users.username <> '' AND articles.published = ? AND articles.no_index = ?", true, false).
+		Order("users.username").
+		Pluck("users.username", &usernames).Error
+	return usernames, err
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"go-react-app/db"
+	"go-react-app/repository"
+	"go-react-app/usecase"
+	"go-react-app/utils/secretbox"
+	"go-react-app/validator"
+	"log"
+	"os"
+)
+
+// 秘密情報のデータキーを現在のマスターキーで暗号化し直します
+//
+//	SECRETS_MASTER_KEYS=v2:<新しいキー>,v1:<古いキー> go run ./rotate_secrets
+//
+// 新しいマスターキーをSECRETS_MASTER_KEYSの先頭に追加してサーバーを再起動した後に実行する
+// 完了後は古いマスターキーをSECRETS_MASTER_KEYSから削除できる
+func main() {
+	keyring, err := secretbox.ParseKeyring(os.Getenv("SECRETS_MASTER_KEYS"))
+	if err != nil {
+		log.Fatalf("環境変数SECRETS_MASTER_KEYSが不正です: %v", err)
+	}
+
+	dbConn := db.NewDB()
+	defer db.CloseDB(dbConn)
+
+	secretUsecase := usecase.NewSecretUsecase(repository.NewSecretRepository(dbConn, keyring), validator.NewSecretValidator())
+	rotated, err := secretUsecase.RotateSecrets()
+	if err != nil {
+		log.Fatalf("秘密情報のローテーションに失敗しました（%d件は完了）: %v", rotated, err)
+	}
+	fmt.Printf("%d件の秘密情報をマスターキー%sで暗号化し直しました\n", rotated, keyring.CurrentKeyId())
+}
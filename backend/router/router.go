@@ -17,8 +17,18 @@ func NewRouter(
 	fac controller.IFeedArticleController,
 	lc controller.ILayoutController,
 	lcc controller.ILayoutComponentController,
+	lsc controller.ILayoutSnapshotController,
+	ltc controller.ILayoutTemplateController,
+	lac controller.ILayoutAssignmentController,
 	bc controller.IBookController,
-	gbc controller.IGoogleBookController) *echo.Echo {
+	gbc controller.IGoogleBookController,
+	bic controller.IBookImportController,
+	sc controller.ISearchController,
+	mc controller.IMediaController,
+	pc controller.IPublicController,
+	ec controller.IExportController,
+	secc controller.ISecretController,
+	rmc controller.IResponseMappingController) *echo.Echo {
 	
 	e := echo.New()
 	
@@ -36,8 +46,18 @@ func NewRouter(
 	routes.SetupFeedArticleRoutes(e, fac)
 	routes.SetupLayoutRoutes(e, lc)
 	routes.SetupLayoutComponentRoutes(e, lcc)
+	routes.SetupLayoutSnapshotRoutes(e, lsc)
+	routes.SetupLayoutTemplateRoutes(e, ltc)
+	routes.SetupLayoutAssignmentRoutes(e, lac)
 	routes.SetupBookRoutes(e, bc)
 	routes.SetupGoogleBookRoutes(e, gbc)
+	routes.SetupBookImportRoutes(e, bic)
+	routes.SetupSearchRoutes(e, sc)
+	routes.SetupMediaRoutes(e, mc)
+	routes.SetupPublicRoutes(e, pc)
+	routes.SetupExportRoutes(e, ec)
+	routes.SetupSecretRoutes(e, secc)
+	routes.SetupResponseMappingRoutes(e, rmc)
 	
 	return e
 }
\ No newline at end of file
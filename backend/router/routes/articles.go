@@ -12,6 +12,7 @@ func SetupArticleRoutes(e *echo.Echo, artc controller.IArticleController) {
 	art.Use(middleware.GetJWTMiddleware())
 	art.GET("", artc.GetAllArticles)
 	art.GET("/:articleId", artc.GetArticleById)
+	art.GET("/:articleId/seo", artc.GetArticleSEO)
 	art.POST("", artc.CreateArticle)
 	art.PUT("/:articleId", artc.UpdateArticle)
 	art.DELETE("/:articleId", artc.DeleteArticle)
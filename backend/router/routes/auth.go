@@ -2,6 +2,7 @@ package routes
 
 import (
 	"go-react-app/controller"
+	"go-react-app/utils/middleware"
 	"github.com/labstack/echo/v4"
 )
 
@@ -11,4 +12,8 @@ func SetupAuthRoutes(e *echo.Echo, uc controller.IUserController) {
 	e.POST("/login", uc.LogIn)
 	e.POST("/logout", uc.LogOut)
 	e.GET("/csrf-token", uc.CsrfToken)
+
+	u := e.Group("/users")
+	u.Use(middleware.GetJWTMiddleware())
+	u.PUT("/me/username", uc.UpdateUsername)
 }
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"go-react-app/controller"
+	"go-react-app/utils/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// SetupBookImportRoutes は書籍の一括登録関連のルートを設定します
+func SetupBookImportRoutes(e *echo.Echo, ic controller.IBookImportController) {
+	bi := e.Group("/book-imports")
+	bi.Use(middleware.GetJWTMiddleware())
+	bi.GET("", ic.GetAllImports)
+	bi.GET("/:importId", ic.GetImportById)
+	bi.POST("", ic.CreateISBNImport)
+	bi.POST("/csv", ic.CreateCSVImport)
+}
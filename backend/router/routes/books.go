@@ -11,8 +11,10 @@ func SetupBookRoutes(e *echo.Echo, bc controller.IBookController) {
 	b := e.Group("/books")
 	b.Use(middleware.GetJWTMiddleware())
 	b.GET("", bc.GetAllBooks)
+	b.GET("/stats", bc.GetReadingStats)
 	b.GET("/:bookId", bc.GetBookById)
 	b.POST("", bc.CreateBook)
 	b.PUT("/:bookId", bc.UpdateBook)
 	b.DELETE("/:bookId", bc.DeleteBook)
+	b.PUT("/:bookId/reading", bc.UpdateBookReading)
 }
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"go-react-app/controller"
+	"go-react-app/utils/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// SetupExportRoutes は静的サイトの書き出し関連のルートを設定します
+func SetupExportRoutes(e *echo.Echo, ec controller.IExportController) {
+	ex := e.Group("/exports")
+	ex.Use(middleware.GetJWTMiddleware())
+	ex.GET("", ec.GetAllExports)
+	ex.GET("/:exportId", ec.GetExportById)
+	ex.GET("/:exportId/download", ec.DownloadExport)
+	ex.POST("", ec.CreateExport)
+}
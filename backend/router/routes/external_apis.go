@@ -15,4 +15,6 @@ func SetupExternalAPIRoutes(e *echo.Echo, ac controller.IExternalAPIController)
 	a.POST("", ac.CreateExternalAPI)
 	a.PUT("/:apiId", ac.UpdateExternalAPI)
 	a.DELETE("/:apiId", ac.DeleteExternalAPI)
+	a.POST("/:apiId/call", ac.CallExternalAPI)
+	a.GET("/:apiId/status", ac.GetExternalAPIStatus)
 }
\ No newline at end of file
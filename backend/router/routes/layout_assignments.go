@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"go-react-app/controller"
+	"go-react-app/utils/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// SetupLayoutAssignmentRoutes は記事のページに使うレイアウトの指定関連のルートを設定します
+func SetupLayoutAssignmentRoutes(e *echo.Echo, lac controller.ILayoutAssignmentController) {
+	a := e.Group("/layout-assignments")
+	a.Use(middleware.GetJWTMiddleware())
+	a.GET("", lac.GetAssignments)
+	a.PUT("/default", lac.SetDefaultLayout)
+	a.DELETE("/default", lac.DeleteDefaultLayout)
+	a.PUT("/tags/:tag", lac.SetTagLayout)
+	a.DELETE("/tags/:tag", lac.DeleteTagLayout)
+}
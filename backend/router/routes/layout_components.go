@@ -12,6 +12,7 @@ func SetupLayoutComponentRoutes(e *echo.Echo, lcc controller.ILayoutComponentCon
 	
 	// 既存のルート
 	lc.GET("", lcc.GetAllLayoutComponents)
+	lc.GET("/types", lcc.GetComponentTypes)
 	lc.GET("/:componentId", lcc.GetLayoutComponentById)
 	lc.POST("", lcc.CreateLayoutComponent)
 	lc.PUT("/:componentId", lcc.UpdateLayoutComponent)
@@ -20,4 +21,6 @@ func SetupLayoutComponentRoutes(e *echo.Echo, lcc controller.ILayoutComponentCon
 	lc.POST("/:componentId/assign/:layoutId", lcc.AssignToLayout)
 	lc.DELETE("/:componentId/assign", lcc.RemoveFromLayout)
 	lc.PUT("/:componentId/position", lcc.UpdatePosition)
+	lc.PUT("/:componentId/parent", lcc.SetParent)
+	lc.GET("/:componentId/data", lcc.GetComponentData)
 }
\ No newline at end of file
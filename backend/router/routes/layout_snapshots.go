@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"go-react-app/controller"
+	"go-react-app/utils/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// SetupLayoutSnapshotRoutes はレイアウトのスナップショット関連のルートを設定します
+func SetupLayoutSnapshotRoutes(e *echo.Echo, lsc controller.ILayoutSnapshotController) {
+	s := e.Group("/layouts/:layoutId/snapshots")
+	s.Use(middleware.GetJWTMiddleware())
+	s.GET("", lsc.GetSnapshots)
+	s.POST("", lsc.CreateSnapshot)
+	s.DELETE("/live", lsc.ClearLiveSnapshot)
+	s.GET("/:snapshotId", lsc.GetSnapshotById)
+	s.GET("/:snapshotId/diff", lsc.DiffSnapshot)
+	s.POST("/:snapshotId/rollback", lsc.RollbackSnapshot)
+	s.PUT("/:snapshotId/live", lsc.SetLiveSnapshot)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"go-react-app/controller"
+	"go-react-app/utils/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// SetupLayoutTemplateRoutes はレイアウトのテンプレート関連のルートを設定します
+func SetupLayoutTemplateRoutes(e *echo.Echo, ltc controller.ILayoutTemplateController) {
+	t := e.Group("/layout-templates")
+	t.Use(middleware.GetJWTMiddleware())
+	t.GET("", ltc.GetAllTemplates)
+	t.GET("/:templateId", ltc.GetTemplateById)
+	t.POST("", ltc.CreateTemplate)
+	t.POST("/:templateId/instantiate", ltc.InstantiateTemplate)
+	t.DELETE("/:templateId", ltc.DeleteTemplate)
+}
@@ -12,7 +12,12 @@ func SetupLayoutRoutes(e *echo.Echo, lc controller.ILayoutController) {
 	l.Use(middleware.GetJWTMiddleware())
 	l.GET("", lc.GetAllLayouts)
 	l.GET("/:layoutId", lc.GetLayoutById)
+	l.GET("/:layoutId/render", lc.RenderLayout)
+	l.GET("/:layoutId/export", lc.ExportLayout)
 	l.POST("", lc.CreateLayout)
+	l.POST("/import", lc.ImportLayout)
 	l.PUT("/:layoutId", lc.UpdateLayout)
+	l.PUT("/:layoutId/arrangement", lc.UpdateArrangement)
+	l.POST("/:layoutId/duplicate", lc.DuplicateLayout)
 	l.DELETE("/:layoutId", lc.DeleteLayout)
 }
\ No newline at end of file
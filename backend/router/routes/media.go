@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"go-react-app/controller"
+	"go-react-app/utils/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// SetupMediaRoutes はメディアライブラリ関連のルートを設定します
+func SetupMediaRoutes(e *echo.Echo, mc controller.IMediaController) {
+	m := e.Group("/media")
+	m.Use(middleware.GetJWTMiddleware())
+	m.GET("", mc.GetAllMedia)
+	m.GET("/:mediaId", mc.GetMediaById)
+	m.GET("/:mediaId/references", mc.GetReferences)
+	m.POST("", mc.UploadMedia)
+	m.DELETE("/:mediaId", mc.DeleteMedia)
+
+	// アップロードされたファイルは記事やレイアウトから直接参照されるため認証不要で配信する
+	e.GET("/uploads/*", mc.ServeFile)
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"go-react-app/controller"
+	"github.com/labstack/echo/v4"
+)
+
+// SetupPublicRoutes は認証不要の公開ルートを設定します
+func SetupPublicRoutes(e *echo.Echo, pc controller.IPublicController) {
+	p := e.Group("/public")
+	p.GET("/:username/feed.atom", pc.GetAtomFeed)
+	p.GET("/:username/feed.rss", pc.GetRSSFeed)
+	p.GET("/:username/feed.json", pc.GetJSONFeed)
+	p.GET("/:username/sitemap.xml", pc.GetSitemapIndex)
+	p.GET("/:username/sitemaps/:page", pc.GetSitemap)
+	p.GET("/:username/articles/:articleId", pc.GetArticle)
+
+	// クローラーはホストのルートのrobots.txtのみを参照する
+	e.GET("/robots.txt", pc.GetRobots)
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"go-react-app/controller"
+	"go-react-app/utils/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// SetupResponseMappingRoutes は外部APIの応答の変換の定義関連のルートを設定します
+func SetupResponseMappingRoutes(e *echo.Echo, rmc controller.IResponseMappingController) {
+	m := e.Group("/response-mappings")
+	m.Use(middleware.GetJWTMiddleware())
+	m.GET("", rmc.GetAllResponseMappings)
+	m.POST("", rmc.CreateResponseMapping)
+	m.POST("/preview", rmc.PreviewResponseMapping)
+	m.GET("/:mappingId", rmc.GetResponseMappingById)
+	m.PUT("/:mappingId", rmc.UpdateResponseMapping)
+	m.DELETE("/:mappingId", rmc.DeleteResponseMapping)
+}
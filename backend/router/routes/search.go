@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"go-react-app/controller"
+	"go-react-app/utils/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// SetupSearchRoutes は全文検索関連のルートを設定します
+func SetupSearchRoutes(e *echo.Echo, sc controller.ISearchController) {
+	s := e.Group("/search")
+	s.Use(middleware.GetJWTMiddleware())
+	s.GET("", sc.Search)
+	s.POST("/reindex", sc.Reindex)
+}
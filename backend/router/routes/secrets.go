@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"go-react-app/controller"
+	"go-react-app/utils/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// SetupSecretRoutes は秘密情報関連のルートを設定します
+// 値は保存のみでき、読み出すルートは設けない
+func SetupSecretRoutes(e *echo.Echo, sc controller.ISecretController) {
+	s := e.Group("/secrets")
+	s.Use(middleware.GetJWTMiddleware())
+	s.GET("", sc.GetSecrets)
+	s.PUT("/:name", sc.PutSecret)
+	s.DELETE("/:name", sc.DeleteSecret)
+}
@@ -0,0 +1,55 @@
+package testutils
+
+import (
+	"go-react-app/model"
+	"go-react-app/repository"
+	"sync"
+)
+
+// FakeBookMetadataProvider は登録したISBNの書籍の情報を返すプロバイダー（外部APIに接続しないテスト用）
+type FakeBookMetadataProvider struct {
+	ProviderName string
+	Books        map[string]model.BookMetadata // ISBN-13ごとの書籍の情報。Sourcesは自動で設定する
+	Err          error                         // 設定した場合はすべての取得でエラーを返す
+
+	mu      sync.Mutex
+	lookups []string
+}
+
+// NewFakeBookMetadataProvider は書籍の情報を登録したプロバイダーを作成します
+func NewFakeBookMetadataProvider(name string, books ...model.BookMetadata) *FakeBookMetadataProvider {
+	provider := &FakeBookMetadataProvider{ProviderName: name, Books: map[string]model.BookMetadata{}}
+	for _, book := range books {
+		provider.Books[book.ISBN] = book
+	}
+	return provider
+}
+
+// Name はプロバイダーの名前を返します
+func (p *FakeBookMetadataProvider) Name() string {
+	return p.ProviderName
+}
+
+// LookupISBN は登録した書籍の情報を返します。ない場合はrepository.ErrBookMetadataNotFound
+func (p *FakeBookMetadataProvider) LookupISBN(userId uint, isbn string) (model.BookMetadata, error) {
+	p.mu.Lock()
+	p.lookups = append(p.lookups, isbn)
+	p.mu.Unlock()
+
+	if p.Err != nil {
+		return model.BookMetadata{}, p.Err
+	}
+	book, ok := p.Books[isbn]
+	if !ok {
+		return model.BookMetadata{}, repository.ErrBookMetadataNotFound
+	}
+	book.Sources = []string{p.ProviderName}
+	return book, nil
+}
+
+// Lookups は取得したISBNを呼び出した順に返します
+func (p *FakeBookMetadataProvider) Lookups() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string{}, p.lookups...)
+}
@@ -3,6 +3,7 @@ package testutils
 import (
 	"fmt"
 	"go-react-app/model"
+	"go-react-app/repository"
 	"log"
 	"math/rand"
 	"time"
@@ -31,10 +32,25 @@ func SetupTestDB() *gorm.DB {
 		&model.User{}, 
 		&model.Task{},
 		&model.Feed{},
+		&model.ExternalAPI{},
 		&model.Article{},
 		&model.Layout{},
 		&model.LayoutComponent{},
+		&model.LayoutSnapshot{},
+		&model.LayoutTemplate{},
+		&model.LayoutAssignment{},
+		&model.Book{},
+		&model.SearchDocument{},
+		&model.Media{},
+		&model.ExportJob{},
+		&model.Secret{},
+		&model.ExternalAPIHealthCheck{},
+		&model.ResponseMapping{},
+		&model.BookImportJob{},
 	)
+	if err := repository.SetupSearchIndex(db); err != nil {
+		log.Fatalf("検索インデックスの作成に失敗しました: %v", err)
+	}
 
 	return db
 }
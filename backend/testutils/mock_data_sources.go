@@ -0,0 +1,84 @@
+package testutils
+
+import (
+	"go-react-app/model"
+)
+
+// MockQiitaUsecase はQiitaの記事を取得するユースケースのモック（外部APIに接続しないテスト用）
+type MockQiitaUsecase struct {
+	GetQiitaArticlesFunc    func(userId uint) ([]model.QiitaArticleResponse, error)
+	GetQiitaArticleByIDFunc func(userId uint, id string) (model.QiitaArticleResponse, error)
+}
+
+// GetQiitaArticles はモックメソッド
+func (m *MockQiitaUsecase) GetQiitaArticles(userId uint) ([]model.QiitaArticleResponse, error) {
+	return m.GetQiitaArticlesFunc(userId)
+}
+
+// GetQiitaArticleByID はモックメソッド
+func (m *MockQiitaUsecase) GetQiitaArticleByID(userId uint, id string) (model.QiitaArticleResponse, error) {
+	return m.GetQiitaArticleByIDFunc(userId, id)
+}
+
+// MockFeedArticleUsecase はフィードの記事を取得するユースケースのモック（外部のフィードに接続しないテスト用）
+type MockFeedArticleUsecase struct {
+	GetArticlesByFeedIDFunc func(userId uint, feedID uint) ([]model.FeedArticleResponse, error)
+	GetArticleByIDFunc      func(userId uint, feedID uint, articleID string) (model.FeedArticleResponse, error)
+	GetAllArticlesFunc      func(userId uint) ([]model.FeedArticleResponse, error)
+}
+
+// GetArticlesByFeedID はモックメソッド
+func (m *MockFeedArticleUsecase) GetArticlesByFeedID(userId uint, feedID uint) ([]model.FeedArticleResponse, error) {
+	return m.GetArticlesByFeedIDFunc(userId, feedID)
+}
+
+// GetArticleByID はモックメソッド
+func (m *MockFeedArticleUsecase) GetArticleByID(userId uint, feedID uint, articleID string) (model.FeedArticleResponse, error) {
+	return m.GetArticleByIDFunc(userId, feedID, articleID)
+}
+
+// GetAllArticles はモックメソッド
+func (m *MockFeedArticleUsecase) GetAllArticles(userId uint) ([]model.FeedArticleResponse, error) {
+	return m.GetAllArticlesFunc(userId)
+}
+
+// MockResponseMappingUsecase は外部APIの応答をカードに変換するユースケースのモック（外部APIに接続しないテスト用）
+// コンポーネントのデータの取得に使うResolveCards以外は空の結果を返す
+type MockResponseMappingUsecase struct {
+	ResolveCardsFunc func(userId uint, mappingId uint, limit int) ([]model.ComponentDataItem, error)
+}
+
+// GetAllResponseMappings はモックメソッド
+func (m *MockResponseMappingUsecase) GetAllResponseMappings(userId uint) ([]model.ResponseMappingResponse, error) {
+	return []model.ResponseMappingResponse{}, nil
+}
+
+// GetResponseMappingById はモックメソッド
+func (m *MockResponseMappingUsecase) GetResponseMappingById(userId uint, mappingId uint) (model.ResponseMappingResponse, error) {
+	return model.ResponseMappingResponse{}, nil
+}
+
+// CreateResponseMapping はモックメソッド
+func (m *MockResponseMappingUsecase) CreateResponseMapping(request model.ResponseMappingRequest) (model.ResponseMappingResponse, error) {
+	return model.ResponseMappingResponse{}, nil
+}
+
+// UpdateResponseMapping はモックメソッド
+func (m *MockResponseMappingUsecase) UpdateResponseMapping(request model.ResponseMappingRequest, userId uint, mappingId uint) (model.ResponseMappingResponse, error) {
+	return model.ResponseMappingResponse{}, nil
+}
+
+// DeleteResponseMapping はモックメソッド
+func (m *MockResponseMappingUsecase) DeleteResponseMapping(userId uint, mappingId uint) error {
+	return nil
+}
+
+// PreviewResponseMapping はモックメソッド
+func (m *MockResponseMappingUsecase) PreviewResponseMapping(request model.ResponseMappingRequest) (model.ResponseMappingPreviewResponse, error) {
+	return model.ResponseMappingPreviewResponse{}, nil
+}
+
+// ResolveCards はモックメソッド
+func (m *MockResponseMappingUsecase) ResolveCards(userId uint, mappingId uint, limit int) ([]model.ComponentDataItem, error) {
+	return m.ResolveCardsFunc(userId, mappingId, limit)
+}
@@ -7,11 +7,16 @@ import (
 // MockLayoutUsecase はレイアウトユースケースのモック
 type MockLayoutUsecase struct {
 	// モックメソッドの呼び出し結果を保存
-	GetAllLayoutsFunc      func(userId uint) ([]model.LayoutResponse, error)
-	GetLayoutByIdFunc      func(userId uint, layoutId uint) (model.LayoutResponse, error)
-	CreateLayoutFunc       func(request model.LayoutRequest) (model.LayoutResponse, error)
-	UpdateLayoutFunc       func(request model.LayoutRequest, userId uint, layoutId uint) (model.LayoutResponse, error)
-	DeleteLayoutFunc       func(userId uint, layoutId uint) error
+	GetAllLayoutsFunc     func(userId uint) ([]model.LayoutResponse, error)
+	GetLayoutByIdFunc     func(userId uint, layoutId uint, breakpoint string) (model.LayoutResponse, error)
+	CreateLayoutFunc      func(request model.LayoutRequest) (model.LayoutResponse, error)
+	UpdateLayoutFunc      func(request model.LayoutRequest, userId uint, layoutId uint) (model.LayoutResponse, error)
+	DeleteLayoutFunc      func(userId uint, layoutId uint) error
+	RenderLayoutFunc      func(userId uint, layoutId uint, breakpoint string) ([]byte, error)
+	UpdateArrangementFunc func(userId uint, layoutId uint, request model.ArrangementRequest) (model.LayoutResponse, error)
+	DuplicateLayoutFunc   func(userId uint, layoutId uint) (model.LayoutResponse, error)
+	ExportLayoutFunc      func(userId uint, layoutId uint) (model.LayoutDocument, error)
+	ImportLayoutFunc      func(userId uint, document model.LayoutDocument) (model.LayoutImportResponse, error)
 }
 
 // GetAllLayouts はモックメソッド
@@ -20,8 +25,8 @@ func (m *MockLayoutUsecase) GetAllLayouts(userId uint) ([]model.LayoutResponse,
 }
 
 // GetLayoutById はモックメソッド
-func (m *MockLayoutUsecase) GetLayoutById(userId uint, layoutId uint) (model.LayoutResponse, error) {
-	return m.GetLayoutByIdFunc(userId, layoutId)
+func (m *MockLayoutUsecase) GetLayoutById(userId uint, layoutId uint, breakpoint string) (model.LayoutResponse, error) {
+	return m.GetLayoutByIdFunc(userId, layoutId, breakpoint)
 }
 
 // CreateLayout はモックメソッド
@@ -38,3 +43,28 @@ func (m *MockLayoutUsecase) UpdateLayout(request model.LayoutRequest, userId uin
 func (m *MockLayoutUsecase) DeleteLayout(userId uint, layoutId uint) error {
 	return m.DeleteLayoutFunc(userId, layoutId)
 }
+
+// RenderLayout はモックメソッド
+func (m *MockLayoutUsecase) RenderLayout(userId uint, layoutId uint, breakpoint string) ([]byte, error) {
+	return m.RenderLayoutFunc(userId, layoutId, breakpoint)
+}
+
+// UpdateArrangement はモックメソッド
+func (m *MockLayoutUsecase) UpdateArrangement(userId uint, layoutId uint, request model.ArrangementRequest) (model.LayoutResponse, error) {
+	return m.UpdateArrangementFunc(userId, layoutId, request)
+}
+
+// DuplicateLayout はモックメソッド
+func (m *MockLayoutUsecase) DuplicateLayout(userId uint, layoutId uint) (model.LayoutResponse, error) {
+	return m.DuplicateLayoutFunc(userId, layoutId)
+}
+
+// ExportLayout はモックメソッド
+func (m *MockLayoutUsecase) ExportLayout(userId uint, layoutId uint) (model.LayoutDocument, error) {
+	return m.ExportLayoutFunc(userId, layoutId)
+}
+
+// ImportLayout はモックメソッド
+func (m *MockLayoutUsecase) ImportLayout(userId uint, document model.LayoutDocument) (model.LayoutImportResponse, error) {
+	return m.ImportLayoutFunc(userId, document)
+}
@@ -0,0 +1,26 @@
+package testutils
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"go-react-app/utils/secretbox"
+	"strings"
+)
+
+// NewTestKeyring はIDから求めた固定のマスターキーでキーリングを作成します
+// 先頭のIDが現在のマスターキーになる。IDを省略した場合は"test"のみ
+func NewTestKeyring(ids ...string) *secretbox.Keyring {
+	if len(ids) == 0 {
+		ids = []string{"test"}
+	}
+	entries := make([]string, len(ids))
+	for i, id := range ids {
+		key := sha256.Sum256([]byte(id))
+		entries[i] = id + ":" + base64.StdEncoding.EncodeToString(key[:])
+	}
+	keyring, err := secretbox.ParseKeyring(strings.Join(entries, ","))
+	if err != nil {
+		panic(err)
+	}
+	return keyring
+}
@@ -0,0 +1,56 @@
+package article_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"testing"
+)
+
+// テスト用のレイアウトを作成するヘルパー関数
+func createArticleLayout(t *testing.T, userId uint) model.Layout {
+	layout := model.Layout{Title: "記事のレイアウト", UserId: userId}
+	if err := articleDb.Create(&layout).Error; err != nil {
+		t.Fatalf("テストレイアウトの作成に失敗しました: %v", err)
+	}
+	return layout
+}
+
+func TestArticleUsecase_ArticleLayout(t *testing.T) {
+	setupArticleUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("記事のページに使うレイアウトを指定・解除できる", func(t *testing.T) {
+			layout := createArticleLayout(t, articleTestUser.ID)
+
+			created, err := articleUsecase.CreateArticle(model.ArticleRequest{Title: "レイアウト付き", LayoutId: &layout.ID, UserId: articleTestUser.ID})
+			if err != nil {
+				t.Fatalf("CreateArticle() error = %v", err)
+			}
+			if created.LayoutId == nil || *created.LayoutId != layout.ID {
+				t.Errorf("CreateArticle() layout_id = %v, want %d", created.LayoutId, layout.ID)
+			}
+
+			// 省略して更新すると指定を解除する
+			if _, err := articleUsecase.UpdateArticle(model.ArticleRequest{Title: "レイアウトなし", UserId: articleTestUser.ID}, articleTestUser.ID, created.ID); err != nil {
+				t.Fatalf("UpdateArticle() error = %v", err)
+			}
+			var saved model.Article
+			articleDb.First(&saved, created.ID)
+			if saved.LayoutId != nil {
+				t.Errorf("UpdateArticle() layout_id = %d, want nil", *saved.LayoutId)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("他のユーザーのレイアウトはErrInvalidArticleLayoutを返す", func(t *testing.T) {
+			layout := createArticleLayout(t, articleOtherUser.ID)
+
+			_, err := articleUsecase.CreateArticle(model.ArticleRequest{Title: "他人のレイアウト", LayoutId: &layout.ID, UserId: articleTestUser.ID})
+			if !errors.Is(err, usecase.ErrInvalidArticleLayout) {
+				t.Errorf("CreateArticle() error = %v, want ErrInvalidArticleLayout", err)
+			}
+		})
+	})
+}
@@ -0,0 +1,99 @@
+package article_test
+
+import (
+	"go-react-app/model"
+	"testing"
+)
+
+func TestArticleUsecase_SEO(t *testing.T) {
+	setupArticleUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("公開する記事には不足しているSEO項目の警告を返す", func(t *testing.T) {
+			res, err := articleUsecase.CreateArticle(model.ArticleRequest{
+				Title:     "公開記事",
+				Content:   "本文",
+				Published: true,
+				UserId:    articleTestUser.ID,
+			})
+			if err != nil {
+				t.Fatalf("CreateArticle() error = %v", err)
+			}
+			if len(res.SEOWarnings) == 0 {
+				t.Error("CreateArticle() should return SEO warnings for published article")
+			}
+		})
+
+		t.Run("下書きには警告を返さない", func(t *testing.T) {
+			res, err := articleUsecase.CreateArticle(model.ArticleRequest{
+				Title:   "下書き",
+				Content: "本文",
+				UserId:  articleTestUser.ID,
+			})
+			if err != nil {
+				t.Fatalf("CreateArticle() error = %v", err)
+			}
+			if len(res.SEOWarnings) != 0 {
+				t.Errorf("CreateArticle() SEOWarnings = %v, want none", res.SEOWarnings)
+			}
+		})
+
+		t.Run("SEO項目を更新できる", func(t *testing.T) {
+			article := createTestArticle(t, "SEO記事", "本文", articleTestUser.ID)
+			res, err := articleUsecase.UpdateArticle(model.ArticleRequest{
+				Title:           "SEO記事",
+				Content:         "本文",
+				Tags:            "Go",
+				MetaDescription: "記事の概要",
+				OGImage:         "https://example.com/cover.png",
+				CanonicalURL:    "https://example.com/gopher/articles/1",
+				NoIndex:         true,
+				UserId:          articleTestUser.ID,
+			}, articleTestUser.ID, article.ID)
+			if err != nil {
+				t.Fatalf("UpdateArticle() error = %v", err)
+			}
+
+			stored := model.Article{}
+			articleDb.First(&stored, article.ID)
+			if stored.MetaDescription != "記事の概要" || stored.OGImage != res.OGImage || stored.CanonicalURL != res.CanonicalURL || !stored.NoIndex {
+				t.Errorf("UpdateArticle() stored = %+v", stored)
+			}
+		})
+
+		t.Run("メタディスクリプションが未設定の場合は本文の抜粋を返す", func(t *testing.T) {
+			article := createTestArticle(t, "抜粋記事", "# 見出し\n\n**Go**の*基本*を解説します。", articleTestUser.ID)
+
+			seo, err := articleUsecase.GetArticleSEO(articleTestUser.ID, article.ID)
+			if err != nil {
+				t.Fatalf("GetArticleSEO() error = %v", err)
+			}
+			if seo.MetaDescription != "見出し Goの基本を解説します。" {
+				t.Errorf("GetArticleSEO() MetaDescription = %q", seo.MetaDescription)
+			}
+			if len(seo.Warnings) == 0 {
+				t.Error("GetArticleSEO() should return warnings")
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("不正なcanonical URLは保存できない", func(t *testing.T) {
+			_, err := articleUsecase.CreateArticle(model.ArticleRequest{
+				Title:        "不正なURL",
+				CanonicalURL: "ftp://example.com/article",
+				UserId:       articleTestUser.ID,
+			})
+			if err == nil {
+				t.Error("CreateArticle() should fail for invalid canonical URL")
+			}
+		})
+
+		t.Run("他のユーザーの記事のSEO情報は取得できない", func(t *testing.T) {
+			article := createTestArticle(t, "他人の記事", "本文", articleOtherUser.ID)
+			if _, err := articleUsecase.GetArticleSEO(articleTestUser.ID, article.ID); err == nil {
+				t.Error("GetArticleSEO() should fail for other user's article")
+			}
+		})
+	})
+}
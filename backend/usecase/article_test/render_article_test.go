@@ -0,0 +1,83 @@
+package article_test
+
+import (
+	"go-react-app/model"
+	"strings"
+	"testing"
+)
+
+func TestArticleUsecase_RenderArticle(t *testing.T) {
+	setupArticleUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("GFMの記法をHTMLに変換する", func(t *testing.T) {
+			content := "## はじめに\n\n| 言語 | 型 |\n|---|---|\n| Go | 静的 |\n\n- [x] 完了\n- [ ] 未完了\n\n本文[^1]\n\n[^1]: 脚注\n\n```go\nfunc main() {}\n```\n"
+			res, err := articleUsecase.RenderArticle(model.ArticleResponse{Content: content})
+			if err != nil {
+				t.Fatalf("RenderArticle() error = %v", err)
+			}
+
+			expected := []string{
+				`<h2 id="はじめに">`,
+				`class="heading-anchor"`,
+				"<table>",
+				`<input checked="" disabled="" type="checkbox">`,
+				`class="footnote-ref"`,
+				`<pre class="chroma">`,
+			}
+			for _, want := range expected {
+				if !strings.Contains(res.ContentHTML, want) {
+					t.Errorf("RenderArticle() = %s, want to contain %s", res.ContentHTML, want)
+				}
+			}
+			if res.Content != content {
+				t.Errorf("RenderArticle() Content = %s, want unchanged", res.Content)
+			}
+		})
+
+		t.Run("重複する見出しには連番のIDを付ける", func(t *testing.T) {
+			res, err := articleUsecase.RenderArticle(model.ArticleResponse{Content: "# Go 入門\n\n# Go 入門\n"})
+			if err != nil {
+				t.Fatalf("RenderArticle() error = %v", err)
+			}
+			if !strings.Contains(res.ContentHTML, `id="go-入門"`) || !strings.Contains(res.ContentHTML, `id="go-入門-1"`) {
+				t.Errorf("RenderArticle() = %s, want unique heading ids", res.ContentHTML)
+			}
+		})
+
+		t.Run("同じ内容は同じHTMLを返す", func(t *testing.T) {
+			article := model.ArticleResponse{Content: "# キャッシュ\n\n本文"}
+			first, _ := articleUsecase.RenderArticle(article)
+			second, _ := articleUsecase.RenderArticle(article)
+			if first.ContentHTML != second.ContentHTML {
+				t.Errorf("RenderArticle() returned different HTML: %s / %s", first.ContentHTML, second.ContentHTML)
+			}
+		})
+
+		t.Run("複数の記事をまとめて変換する", func(t *testing.T) {
+			articles := []model.ArticleResponse{{Content: "**太字**"}, {Content: "*斜体*"}}
+			res, err := articleUsecase.RenderArticles(articles)
+			if err != nil {
+				t.Fatalf("RenderArticles() error = %v", err)
+			}
+			if len(res) != 2 || !strings.Contains(res[0].ContentHTML, "<strong>太字</strong>") || !strings.Contains(res[1].ContentHTML, "<em>斜体</em>") {
+				t.Errorf("RenderArticles() = %+v", res)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("危険なHTMLは除去される", func(t *testing.T) {
+			content := "<script>alert(1)</script>\n\n<a href=\"javascript:alert(1)\" onclick=\"alert(1)\">link</a>\n\n<img src=\"x.png\" onerror=\"alert(1)\">"
+			res, err := articleUsecase.RenderArticle(model.ArticleResponse{Content: content})
+			if err != nil {
+				t.Fatalf("RenderArticle() error = %v", err)
+			}
+			for _, unwanted := range []string{"<script", "javascript:", "onclick", "onerror"} {
+				if strings.Contains(res.ContentHTML, unwanted) {
+					t.Errorf("RenderArticle() = %s, should not contain %s", res.ContentHTML, unwanted)
+				}
+			}
+		})
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"go-react-app/repository"
 	"go-react-app/testutils"
 	"go-react-app/usecase"
+	"go-react-app/utils/markdown"
 	"go-react-app/validator"
 	"testing"
 
@@ -33,7 +34,7 @@ func setupArticleUsecaseTest() {
 		articleDb = testutils.SetupTestDB()
 		articleRepo = repository.NewArticleRepository(articleDb)
 		articleValidator = validator.NewArticleValidator()
-		articleUsecase = usecase.NewArticleUsecase(articleRepo, articleValidator)
+		articleUsecase = usecase.NewArticleUsecase(articleRepo, articleValidator, repository.NewSearchRepository(articleDb), markdown.NewRenderer(markdown.DefaultCacheSize), repository.NewLayoutRepository(articleDb))
 	}
 	
 	// テストユーザーを作成
@@ -1,26 +1,38 @@
 package usecase
 
 import (
+	"errors"
 	"go-react-app/model"
 	"go-react-app/repository"
+	"go-react-app/utils/markdown"
 	"go-react-app/validator"
+	"strconv"
 )
 
+// ErrInvalidArticleLayout は記事のページに使うレイアウトとして、存在しないまたは他のユーザーのレイアウトを指定した場合のエラー
+var ErrInvalidArticleLayout = errors.New("invalid article layout")
+
 type IArticleUsecase interface {
 	GetAllArticles(userId uint) ([]model.ArticleResponse, error)
 	GetArticleById(userId uint, articleId uint) (model.ArticleResponse, error)
 	CreateArticle(request model.ArticleRequest) (model.ArticleResponse, error)
 	UpdateArticle(request model.ArticleRequest, userId uint, articleId uint) (model.ArticleResponse, error)
 	DeleteArticle(userId uint, articleId uint) error
+	RenderArticle(article model.ArticleResponse) (model.ArticleResponse, error)
+	RenderArticles(articles []model.ArticleResponse) ([]model.ArticleResponse, error)
+	GetArticleSEO(userId uint, articleId uint) (model.ArticleSEOResponse, error)
 }
 
 type articleUsecase struct {
 	ar repository.IArticleRepository
 	av validator.IArticleValidator
+	sr repository.ISearchRepository
+	mr markdown.IRenderer
+	lr repository.ILayoutRepository
 }
 
-func NewArticleUsecase(ar repository.IArticleRepository, av validator.IArticleValidator) IArticleUsecase {
-	return &articleUsecase{ar, av}
+func NewArticleUsecase(ar repository.IArticleRepository, av validator.IArticleValidator, sr repository.ISearchRepository, mr markdown.IRenderer, lr repository.ILayoutRepository) IArticleUsecase {
+	return &articleUsecase{ar, av, sr, mr, lr}
 }
 
 func (au *articleUsecase) GetAllArticles(userId uint) ([]model.ArticleResponse, error) {
@@ -48,28 +60,122 @@ func (au *articleUsecase) CreateArticle(request model.ArticleRequest) (model.Art
 	if err := au.av.ValidateArticleRequest(request); err != nil {
 		return model.ArticleResponse{}, err
 	}
+	if err := au.validateLayout(request); err != nil {
+		return model.ArticleResponse{}, err
+	}
 	
 	article := request.ToModel()
 	if err := au.ar.CreateArticle(&article); err != nil {
 		return model.ArticleResponse{}, err
 	}
+	upsertSearchDocument(au.sr, article.ToSearchDocument())
 	
-	return article.ToResponse(), nil
+	return au.withSEOWarnings(request, article.ToResponse()), nil
 }
 
 func (au *articleUsecase) UpdateArticle(request model.ArticleRequest, userId uint, articleId uint) (model.ArticleResponse, error) {
 	if err := au.av.ValidateArticleRequest(request); err != nil {
 		return model.ArticleResponse{}, err
 	}
+	if err := au.validateLayout(request); err != nil {
+		return model.ArticleResponse{}, err
+	}
 	
 	article := request.ToModel()
 	if err := au.ar.UpdateArticle(&article, userId, articleId); err != nil {
 		return model.ArticleResponse{}, err
 	}
+	article.ID = articleId
+	article.UserId = userId
+	upsertSearchDocument(au.sr, article.ToSearchDocument())
 	
-	return article.ToResponse(), nil
+	return au.withSEOWarnings(request, article.ToResponse()), nil
 }
 
 func (au *articleUsecase) DeleteArticle(userId uint, articleId uint) error {
-	return au.ar.DeleteArticle(userId, articleId)
-}
\ No newline at end of file
+	if err := au.ar.DeleteArticle(userId, articleId); err != nil {
+		return err
+	}
+	deleteSearchDocument(au.sr, userId, model.SearchSourceArticle, strconv.FormatUint(uint64(articleId), 10))
+	return nil
+}
+
+// RenderArticle は記事本文のMarkdownをサニタイズ済みのHTMLに変換してContentHTMLに設定します
+func (au *articleUsecase) RenderArticle(article model.ArticleResponse) (model.ArticleResponse, error) {
+	html, err := au.mr.Render(article.Content)
+	if err != nil {
+		return model.ArticleResponse{}, err
+	}
+	article.ContentHTML = html
+	return article, nil
+}
+
+func (au *articleUsecase) RenderArticles(articles []model.ArticleResponse) ([]model.ArticleResponse, error) {
+	rendered := make([]model.ArticleResponse, len(articles))
+	for i, article := range articles {
+		res, err := au.RenderArticle(article)
+		if err != nil {
+			return nil, err
+		}
+		rendered[i] = res
+	}
+	return rendered, nil
+}
+
+// GetArticleSEO は記事のSEOメタデータを返します
+// メタディスクリプションが未設定の場合は、レンダリングした本文の抜粋を使用します
+func (au *articleUsecase) GetArticleSEO(userId uint, articleId uint) (model.ArticleSEOResponse, error) {
+	article := model.Article{}
+	if err := au.ar.GetArticleById(&article, userId, articleId); err != nil {
+		return model.ArticleSEOResponse{}, err
+	}
+
+	description := article.MetaDescription
+	if description == "" {
+		html, err := au.mr.Render(article.Content)
+		if err != nil {
+			return model.ArticleSEOResponse{}, err
+		}
+		description = markdown.Excerpt(html, model.ArticleMetaDescriptionRecommendedLength)
+	}
+
+	return model.ArticleSEOResponse{
+		Title:           article.Title,
+		MetaDescription: description,
+		OGImage:         article.OGImage,
+		CanonicalURL:    article.CanonicalURL,
+		NoIndex:         article.NoIndex,
+		Warnings:        au.av.SEOWarnings(articleToRequest(article)),
+	}, nil
+}
+
+// validateLayout は記事に指定したレイアウトがユーザーのものであることを確認します
+func (au *articleUsecase) validateLayout(request model.ArticleRequest) error {
+	if request.LayoutId == nil {
+		return nil
+	}
+	return requireLayout(au.lr, request.UserId, *request.LayoutId, ErrInvalidArticleLayout)
+}
+
+// withSEOWarnings は公開する記事のレスポンスにSEOの警告を付与します
+func (au *articleUsecase) withSEOWarnings(request model.ArticleRequest, res model.ArticleResponse) model.ArticleResponse {
+	if request.Published {
+		res.SEOWarnings = au.av.SEOWarnings(request)
+	}
+	return res
+}
+
+// articleToRequest はSEOの検証用に記事をリクエストの形式に変換します
+func articleToRequest(article model.Article) model.ArticleRequest {
+	return model.ArticleRequest{
+		Title:           article.Title,
+		Content:         article.Content,
+		Published:       article.Published,
+		Tags:            article.Tags,
+		MetaDescription: article.MetaDescription,
+		OGImage:         article.OGImage,
+		CanonicalURL:    article.CanonicalURL,
+		NoIndex:         article.NoIndex,
+		UserId:          article.UserId,
+	}
+}
@@ -0,0 +1,114 @@
+package book_import_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+const goodreadsCSV = `Book Id,Title,Author,Author l-f,Additional Authors,ISBN,ISBN13,My Rating,Average Rating,Publisher,Binding,Number of Pages,Year Published,Original Publication Year,Date Read,Date Added,Bookshelves,Bookshelves with positions,Exclusive Shelf
+1,Concurrency in Go,Katherine Cox-Buday,"Cox-Buday, Katherine",,"=""""","=""9784873118468""",0,4.1,O'Reilly,Paperback,238,2017,2017,,2024/01/01,,,to-read
+2,The Go Programming Language,Alan Donovan,"Donovan, Alan",,"=""0134190440""","=""9780134190440""",5,4.4,Addison-Wesley,Paperback,380,2015,2015,2024/02/10,2024/01/05,,,read
+3,Kindle Edition Only,Some Author,"Author, Some",,"=""""","=""""",4,3.9,,Kindle Edition,,,,,2024/01/06,,,currently-reading
+`
+
+func TestBookImportUsecase_CreateCSVImport(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("GoodreadsのCSVを読書の状態と評価とともに登録する", func(t *testing.T) {
+			setupBookImportUsecaseTest()
+
+			job, err := importUsecase.CreateCSVImport(importTestUser.ID, strings.NewReader(goodreadsCSV))
+			if err != nil {
+				t.Fatalf("CreateCSVImport() error = %v", err)
+			}
+			if job.Format != "goodreads" || job.Total != 3 || job.Imported != 3 {
+				t.Fatalf("CreateCSVImport() = %+v, want 3 goodreads books imported", job)
+			}
+			wantLookups := []string{"openbd,google", model.BookImportLookupCSV, model.BookImportLookupCSV}
+			for i, row := range job.Rows {
+				if row.Lookup != wantLookups[i] || row.Error != "" {
+					t.Errorf("rows[%d] = %+v, want lookup %s without error", i, row, wantLookups[i])
+				}
+			}
+
+			// プロバイダーで見つからない書籍はCSVのタイトル・著者で登録する
+			book, err := bookUsecase.GetBookById(importTestUser.ID, job.Rows[1].BookId)
+			if err != nil {
+				t.Fatalf("GetBookById() error = %v", err)
+			}
+			if book.Title != "The Go Programming Language" || book.Author != "Alan Donovan" || book.ISBN != "9780134190440" {
+				t.Errorf("CSVの値で登録した書籍 = %+v", book)
+			}
+			if book.Status != model.BookStatusFinished || book.Rating != 5 || book.FinishedAt != "2024-02-10" {
+				t.Errorf("読書記録 = %s rating %d finished %s, want finished 5 2024-02-10", book.Status, book.Rating, book.FinishedAt)
+			}
+			if reading, _ := bookUsecase.GetBookById(importTestUser.ID, job.Rows[2].BookId); reading.Status != model.BookStatusReading || reading.ISBN != "" {
+				t.Errorf("ISBNのない書籍 = %+v, want reading without ISBN", reading)
+			}
+		})
+
+		t.Run("同じCSVを再度登録するとすべて重複になる", func(t *testing.T) {
+			setupBookImportUsecaseTest()
+			if _, err := importUsecase.CreateCSVImport(importTestUser.ID, strings.NewReader(goodreadsCSV)); err != nil {
+				t.Fatalf("CreateCSVImport() error = %v", err)
+			}
+
+			job, err := importUsecase.CreateCSVImport(importTestUser.ID, strings.NewReader(goodreadsCSV))
+			if err != nil {
+				t.Fatalf("CreateCSVImport() error = %v", err)
+			}
+			if job.Duplicates != 3 || job.Imported != 0 {
+				t.Errorf("CreateCSVImport() = %+v, want 3 duplicates", job)
+			}
+			books, _ := bookUsecase.GetAllBooks(importTestUser.ID)
+			if len(books) != 3 {
+				t.Errorf("GetAllBooks() returned %d books, want 3", len(books))
+			}
+		})
+
+		t.Run("Shift_JISのブクログのCSVを登録する", func(t *testing.T) {
+			setupBookImportUsecaseTest()
+			csv, _ := japanese.ShiftJIS.NewEncoder().String(
+				"1,4873118468,9784873118468,0,4,読み終わった,,,,2024-01-01 10:00:00,2024-03-01 00:00:00,Go言語による並行処理,Katherine Cox-Buday,オライリー・ジャパン,2018\n",
+			)
+
+			job, err := importUsecase.CreateCSVImport(importTestUser.ID, strings.NewReader(csv))
+			if err != nil {
+				t.Fatalf("CreateCSVImport() error = %v", err)
+			}
+			if job.Format != "booklog" || job.Imported != 1 {
+				t.Fatalf("CreateCSVImport() = %+v, want 1 booklog book imported", job)
+			}
+			book, _ := bookUsecase.GetBookById(importTestUser.ID, job.Rows[0].BookId)
+			// 登録時に取得したページ数は読書記録の登録で失わない
+			if book.Status != model.BookStatusFinished || book.Rating != 4 || book.FinishedAt != "2024-03-01" || book.PageCount != 238 {
+				t.Errorf("読書記録 = %+v, want finished with rating 4 and 238 pages", book)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("形式を判別できないCSVはエラー", func(t *testing.T) {
+			setupBookImportUsecaseTest()
+			for _, csv := range []string{"name,price\napple,100\n", "", "Title,ISBN\n"} {
+				_, err := importUsecase.CreateCSVImport(importTestUser.ID, strings.NewReader(csv))
+				if !errors.Is(err, usecase.ErrInvalidBookImport) {
+					t.Errorf("CreateCSVImport(%q) error = %v, want ErrInvalidBookImport", csv, err)
+				}
+			}
+		})
+
+		t.Run("サイズが上限を超える場合はエラー", func(t *testing.T) {
+			setupBookImportUsecaseTest()
+			csv := "Title,ISBN\n" + strings.Repeat("a", model.BookImportMaxCSVSize)
+			_, err := importUsecase.CreateCSVImport(importTestUser.ID, strings.NewReader(csv))
+			if !errors.Is(err, usecase.ErrInvalidBookImport) {
+				t.Errorf("CreateCSVImport() error = %v, want ErrInvalidBookImport", err)
+			}
+		})
+	})
+}
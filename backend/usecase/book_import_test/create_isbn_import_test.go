@@ -0,0 +1,227 @@
+package book_import_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/validator"
+	"testing"
+)
+
+// panicMetadataUsecase は書籍の情報の取得でpanicするテスト用のユースケース
+type panicMetadataUsecase struct{}
+
+func (panicMetadataUsecase) LookupISBN(userId uint, isbn string) (model.BookMetadata, error) {
+	panic("unexpected metadata response")
+}
+
+func TestBookImportUsecase_CreateISBNImport(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("ISBNごとの登録・重複・見つからない・不正の結果を記録する", func(t *testing.T) {
+			setupBookImportUsecaseTest()
+
+			job, err := importUsecase.CreateISBNImport(model.BookISBNImportRequest{
+				ISBNs:  []string{"978-4-87311-846-8", "", "4873118468", "9784000000000", "978-4-87311-846-9"},
+				UserId: importTestUser.ID,
+			})
+			if err != nil {
+				t.Fatalf("CreateISBNImport() error = %v", err)
+			}
+			if job.Status != model.BookImportStatusCompleted || job.Source != model.BookImportSourceISBN {
+				t.Errorf("CreateISBNImport() status = %q, source = %q, want completed isbn", job.Status, job.Source)
+			}
+			if job.Total != 4 || job.Processed != 4 || job.Imported != 1 || job.Duplicates != 1 || job.NotFound != 1 || job.Failed != 1 {
+				t.Errorf("CreateISBNImport() counts = %+v, want 1 of each outcome", job)
+			}
+
+			want := []struct {
+				row     int
+				outcome string
+				isbn    string
+			}{
+				{1, model.BookImportOutcomeImported, "9784873118468"},
+				{3, model.BookImportOutcomeDuplicate, "9784873118468"},
+				{4, model.BookImportOutcomeNotFound, "9784000000000"},
+				{5, model.BookImportOutcomeFailed, ""},
+			}
+			if len(job.Rows) != len(want) {
+				t.Fatalf("CreateISBNImport() rows = %+v, want %d rows", job.Rows, len(want))
+			}
+			for i, w := range want {
+				if row := job.Rows[i]; row.Row != w.row || row.Outcome != w.outcome || row.ISBN != w.isbn {
+					t.Errorf("rows[%d] = %+v, want row %d %s %s", i, row, w.row, w.outcome, w.isbn)
+				}
+			}
+			if first := job.Rows[0]; first.Lookup != "openbd,google" || first.Title != "Go言語による並行処理" || first.BookId == 0 {
+				t.Errorf("rows[0] = %+v, want book imported from openbd and google", first)
+			}
+			if job.Rows[1].BookId != job.Rows[0].BookId {
+				t.Errorf("重複した行の書籍ID = %d, want %d", job.Rows[1].BookId, job.Rows[0].BookId)
+			}
+			// 登録済みの書籍は書籍の情報を取得しない
+			if lookups := openBD.Lookups(); len(lookups) != 2 {
+				t.Errorf("書籍の情報の取得 = %v, want 2 lookups", lookups)
+			}
+
+			// 優先するプロバイダーにない項目は他のプロバイダーの値で補う
+			book, err := bookUsecase.GetBookById(importTestUser.ID, job.Rows[0].BookId)
+			if err != nil {
+				t.Fatalf("GetBookById() error = %v", err)
+			}
+			if book.Publisher != "オライリー・ジャパン" || book.ImageURL != "https://books.google.com/cover.jpg" || book.PageCount != 238 {
+				t.Errorf("登録した書籍 = %+v, want merged metadata", book)
+			}
+
+			got, err := importUsecase.GetImportById(importTestUser.ID, job.ID)
+			if err != nil {
+				t.Fatalf("GetImportById() error = %v", err)
+			}
+			if len(got.Rows) != 4 || got.Rows[2].Outcome != model.BookImportOutcomeNotFound {
+				t.Errorf("GetImportById() rows = %+v, want saved rows", got.Rows)
+			}
+		})
+
+		t.Run("書籍の情報を取得できない場合は失敗として記録する", func(t *testing.T) {
+			setupBookImportUsecaseTest()
+			openBD.Err = errors.New("openBD request failed with status code: 503")
+			googleBooks.Err = errors.New("API request failed with status code: 429")
+
+			job, err := importUsecase.CreateISBNImport(model.BookISBNImportRequest{ISBNs: []string{"9784873118468"}, UserId: importTestUser.ID})
+			if err != nil {
+				t.Fatalf("CreateISBNImport() error = %v", err)
+			}
+			if job.Failed != 1 || job.Rows[0].Error == "" {
+				t.Errorf("CreateISBNImport() = %+v, want failed row with error", job)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("ISBNがない場合はエラー", func(t *testing.T) {
+			setupBookImportUsecaseTest()
+			for _, isbns := range [][]string{nil, {"", " "}} {
+				_, err := importUsecase.CreateISBNImport(model.BookISBNImportRequest{ISBNs: isbns, UserId: importTestUser.ID})
+				if !errors.Is(err, usecase.ErrInvalidBookImport) {
+					t.Errorf("CreateISBNImport(%q) error = %v, want ErrInvalidBookImport", isbns, err)
+				}
+			}
+		})
+
+		t.Run("件数が上限を超える場合はエラー", func(t *testing.T) {
+			setupBookImportUsecaseTest()
+			isbns := make([]string, model.BookImportMaxRows+1)
+			for i := range isbns {
+				isbns[i] = "9784873118468"
+			}
+			_, err := importUsecase.CreateISBNImport(model.BookISBNImportRequest{ISBNs: isbns, UserId: importTestUser.ID})
+			if !errors.Is(err, usecase.ErrInvalidBookImport) {
+				t.Errorf("CreateISBNImport() error = %v, want ErrInvalidBookImport", err)
+			}
+		})
+
+		t.Run("実行中のジョブがある場合は新しいジョブを登録しない", func(t *testing.T) {
+			setupBookImportUsecaseTest()
+			running := model.BookImportJob{Status: model.BookImportStatusRunning, Source: model.BookImportSourceISBN, Total: 1, UserId: importTestUser.ID}
+			importDb.Create(&running)
+
+			_, err := importUsecase.CreateISBNImport(model.BookISBNImportRequest{ISBNs: []string{"9784873118468"}, UserId: importTestUser.ID})
+			if !errors.Is(err, usecase.ErrBookImportInProgress) {
+				t.Errorf("CreateISBNImport() error = %v, want ErrBookImportInProgress", err)
+			}
+
+			var count int64
+			importDb.Model(&model.BookImportJob{}).Where("user_id = ?", importTestUser.ID).Count(&count)
+			if count != 1 {
+				t.Errorf("import jobs = %d, want 1", count)
+			}
+
+			// 他のユーザーのジョブは登録できる
+			other := testutils.CreateOtherUser(importDb)
+			if _, err := importUsecase.CreateISBNImport(model.BookISBNImportRequest{ISBNs: []string{"9784873118468"}, UserId: other.ID}); err != nil {
+				t.Errorf("CreateISBNImport() for other user error = %v", err)
+			}
+		})
+
+		t.Run("実行中にpanicした場合はジョブを失敗として記録する", func(t *testing.T) {
+			setupBookImportUsecaseTest()
+			panicUsecase := usecase.NewBookImportUsecase(
+				repository.NewBookImportRepository(importDb),
+				repository.NewBookRepository(importDb),
+				panicMetadataUsecase{},
+				bookUsecase,
+				validator.NewBookValidator(),
+				usecase.BookImportConfig{Synchronous: true},
+			)
+
+			job, err := panicUsecase.CreateISBNImport(model.BookISBNImportRequest{ISBNs: []string{"9784873118468"}, UserId: importTestUser.ID})
+			if err != nil {
+				t.Fatalf("CreateISBNImport() error = %v", err)
+			}
+			if job.Status != model.BookImportStatusFailed || job.Error == "" || job.FinishedAt == nil {
+				t.Errorf("CreateISBNImport() = %+v, want failed job", job)
+			}
+
+			// 失敗にしたジョブは実行中として扱わない
+			if _, err := importUsecase.CreateISBNImport(model.BookISBNImportRequest{ISBNs: []string{"9784873118468"}, UserId: importTestUser.ID}); err != nil {
+				t.Errorf("CreateISBNImport() after panic error = %v", err)
+			}
+		})
+
+		t.Run("他のユーザーのジョブは取得できない", func(t *testing.T) {
+			setupBookImportUsecaseTest()
+			job, err := importUsecase.CreateISBNImport(model.BookISBNImportRequest{ISBNs: []string{"9784873118468"}, UserId: importTestUser.ID})
+			if err != nil {
+				t.Fatalf("CreateISBNImport() error = %v", err)
+			}
+			other := testutils.CreateOtherUser(importDb)
+			if _, err := importUsecase.GetImportById(other.ID, job.ID); !errors.Is(err, usecase.ErrBookImportNotFound) {
+				t.Errorf("GetImportById() error = %v, want ErrBookImportNotFound", err)
+			}
+		})
+	})
+}
+
+func TestBookImportUsecase_FailInterruptedImports(t *testing.T) {
+	setupBookImportUsecaseTest()
+	pending := model.BookImportJob{Status: model.BookImportStatusPending, Source: model.BookImportSourceISBN, Total: 1, UserId: importTestUser.ID}
+	running := model.BookImportJob{Status: model.BookImportStatusRunning, Source: model.BookImportSourceCSV, Total: 1, UserId: importTestUser.ID}
+	completed := model.BookImportJob{Status: model.BookImportStatusCompleted, Source: model.BookImportSourceISBN, Total: 1, UserId: importTestUser.ID}
+	importDb.Create(&pending)
+	importDb.Create(&running)
+	importDb.Create(&completed)
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("待機中・実行中のジョブを失敗にする", func(t *testing.T) {
+			count, err := importUsecase.FailInterruptedImports()
+			if err != nil {
+				t.Fatalf("FailInterruptedImports() error = %v", err)
+			}
+			if count != 2 {
+				t.Errorf("FailInterruptedImports() = %d, want 2", count)
+			}
+
+			for _, id := range []uint{pending.ID, running.ID} {
+				job, _ := importUsecase.GetImportById(importTestUser.ID, id)
+				if job.Status != model.BookImportStatusFailed || job.Error == "" || job.FinishedAt == nil {
+					t.Errorf("job %d = %+v, want failed", id, job)
+				}
+			}
+			job, _ := importUsecase.GetImportById(importTestUser.ID, completed.ID)
+			if job.Status != model.BookImportStatusCompleted {
+				t.Errorf("completed job status = %s, want completed", job.Status)
+			}
+		})
+
+		t.Run("失敗にした後は新しいジョブを登録できる", func(t *testing.T) {
+			job, err := importUsecase.CreateISBNImport(model.BookISBNImportRequest{ISBNs: []string{"9784873118468"}, UserId: importTestUser.ID})
+			if err != nil {
+				t.Fatalf("CreateISBNImport() error = %v", err)
+			}
+			if job.Status != model.BookImportStatusCompleted {
+				t.Errorf("CreateISBNImport() status = %s, want completed", job.Status)
+			}
+		})
+	})
+}
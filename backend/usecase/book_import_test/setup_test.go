@@ -0,0 +1,51 @@
+package book_import_test
+
+import (
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/validator"
+
+	"gorm.io/gorm"
+)
+
+// テスト用の共通変数
+var (
+	importDb       *gorm.DB
+	importUsecase  usecase.IBookImportUsecase
+	bookUsecase    usecase.IBookUsecase
+	openBD         *testutils.FakeBookMetadataProvider
+	googleBooks    *testutils.FakeBookMetadataProvider
+	importTestUser model.User
+)
+
+// テスト前の共通セットアップ
+func setupBookImportUsecaseTest() {
+	if importDb != nil {
+		testutils.CleanupTestDB(importDb)
+		importDb.Exec("DELETE FROM books")
+		importDb.Exec("DELETE FROM book_import_jobs")
+	} else {
+		importDb = testutils.SetupTestDB()
+	}
+	// openBDには表紙とページ数のない書籍、Google Books APIには内容紹介と表紙のある書籍を登録する
+	openBD = testutils.NewFakeBookMetadataProvider(model.BookMetadataProviderOpenBD, model.BookMetadata{
+		ISBN: "9784873118468", Title: "Go言語による並行処理", Authors: []string{"Katherine Cox-Buday"}, Publisher: "オライリー・ジャパン",
+	})
+	googleBooks = testutils.NewFakeBookMetadataProvider(model.BookMetadataProviderGoogle, model.BookMetadata{
+		ISBN: "9784873118468", Title: "Concurrency in Go", Authors: []string{"Katherine Cox-Buday"}, ImageURL: "https://books.google.com/cover.jpg", PageCount: 238,
+	})
+	bookRepository := repository.NewBookRepository(importDb)
+	bookValidator := validator.NewBookValidator()
+	bookUsecase = usecase.NewBookUsecase(bookRepository, bookValidator, repository.NewSearchRepository(importDb))
+	importUsecase = usecase.NewBookImportUsecase(
+		repository.NewBookImportRepository(importDb),
+		bookRepository,
+		usecase.NewBookMetadataUsecase(openBD, googleBooks),
+		bookUsecase,
+		bookValidator,
+		usecase.BookImportConfig{Synchronous: true},
+	)
+	importTestUser = testutils.CreateTestUser(importDb)
+}
@@ -0,0 +1,295 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/utils/bookcsv"
+	"go-react-app/utils/isbn"
+	"go-react-app/validator"
+	"io"
+	"log"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidBookImport は一括登録のリクエストやCSVが不正な場合のエラー
+	ErrInvalidBookImport = errors.New("invalid book import")
+	// ErrBookImportNotFound は一括登録ジョブが見つからない場合のエラー
+	ErrBookImportNotFound = errors.New("book import not found")
+	// ErrBookImportInProgress はユーザーの一括登録ジョブが待機中または実行中の場合のエラー
+	ErrBookImportInProgress = errors.New("book import is already in progress")
+)
+
+// BookImportConfig は書籍の一括登録に関する設定
+type BookImportConfig struct {
+	Synchronous bool // trueの場合はジョブをリクエスト内で実行する（テスト用）
+}
+
+type IBookImportUsecase interface {
+	GetAllImports(userId uint) ([]model.BookImportJobResponse, error)
+	GetImportById(userId uint, importId uint) (model.BookImportJobResponse, error)
+	CreateISBNImport(request model.BookISBNImportRequest) (model.BookImportJobResponse, error)
+	CreateCSVImport(userId uint, body io.Reader) (model.BookImportJobResponse, error)
+	FailInterruptedImports() (int64, error)
+}
+
+type bookImportUsecase struct {
+	ir  repository.IBookImportRepository
+	br  repository.IBookRepository
+	bmu IBookMetadataUsecase
+	bu  IBookUsecase
+	bv  validator.IBookValidator
+	cfg BookImportConfig
+	mu  sync.Mutex // 同じユーザーのジョブを同時に登録しないよう、確認と登録をまとめて行う
+}
+
+func NewBookImportUsecase(ir repository.IBookImportRepository, br repository.IBookRepository, bmu IBookMetadataUsecase, bu IBookUsecase, bv validator.IBookValidator, cfg BookImportConfig) IBookImportUsecase {
+	return &bookImportUsecase{ir: ir, br: br, bmu: bmu, bu: bu, bv: bv, cfg: cfg}
+}
+
+func (iu *bookImportUsecase) GetAllImports(userId uint) ([]model.BookImportJobResponse, error) {
+	jobs, err := iu.ir.GetAllImports(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	resJobs := make([]model.BookImportJobResponse, len(jobs))
+	for i, job := range jobs {
+		resJobs[i] = job.ToResponse()
+	}
+	return resJobs, nil
+}
+
+func (iu *bookImportUsecase) GetImportById(userId uint, importId uint) (model.BookImportJobResponse, error) {
+	job, err := iu.ir.GetImportById(userId, importId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.BookImportJobResponse{}, ErrBookImportNotFound
+	}
+	if err != nil {
+		return model.BookImportJobResponse{}, err
+	}
+	return job.ToResponse(), nil
+}
+
+// CreateISBNImport はISBNの一覧を一括登録するジョブを登録し、バックグラウンドで実行します
+// 空のISBNは無視する。ジョブの進捗と1行ごとの結果はGetImportByIdで確認できます
+// 書籍の情報のプロバイダーへのリクエストが重ならないよう、待機中または実行中のジョブがある場合はErrBookImportInProgressを返す
+func (iu *bookImportUsecase) CreateISBNImport(request model.BookISBNImportRequest) (model.BookImportJobResponse, error) {
+	if err := iu.bv.ValidateBookISBNImportRequest(request); err != nil {
+		return model.BookImportJobResponse{}, fmt.Errorf("%w: %v", ErrInvalidBookImport, err)
+	}
+
+	entries := []model.BookImportEntry{}
+	for i, value := range request.ISBNs {
+		if value = strings.TrimSpace(value); value != "" {
+			entries = append(entries, model.BookImportEntry{Row: i + 1, ISBN: value})
+		}
+	}
+	if len(entries) == 0 {
+		return model.BookImportJobResponse{}, fmt.Errorf("%w: ISBNが指定されていません", ErrInvalidBookImport)
+	}
+	return iu.startImport(model.BookImportJob{Source: model.BookImportSourceISBN, UserId: request.UserId}, entries)
+}
+
+// CreateCSVImport は読書管理サービスから書き出したCSVを一括登録するジョブを登録し、バックグラウンドで実行します
+// 書籍の情報はISBNで書籍の情報のプロバイダーから取得し、見つからない場合はCSVのタイトル・著者で登録する
+// 待機中または実行中のジョブがある場合はErrBookImportInProgressを返す
+func (iu *bookImportUsecase) CreateCSVImport(userId uint, body io.Reader) (model.BookImportJobResponse, error) {
+	// 上限を1バイト超えて読み込み、サイズ超過を検出する
+	data, err := io.ReadAll(io.LimitReader(body, model.BookImportMaxCSVSize+1))
+	if err != nil {
+		return model.BookImportJobResponse{}, err
+	}
+	if len(data) > model.BookImportMaxCSVSize {
+		return model.BookImportJobResponse{}, fmt.Errorf("%w: CSVは%dMB以内にしてください", ErrInvalidBookImport, model.BookImportMaxCSVSize>>20)
+	}
+
+	entries, format, err := bookcsv.Parse(data)
+	if err != nil {
+		return model.BookImportJobResponse{}, fmt.Errorf("%w: %v", ErrInvalidBookImport, err)
+	}
+	if len(entries) == 0 {
+		return model.BookImportJobResponse{}, fmt.Errorf("%w: CSVに書籍が含まれていません", ErrInvalidBookImport)
+	}
+	if len(entries) > model.BookImportMaxRows {
+		return model.BookImportJobResponse{}, fmt.Errorf("%w: CSVの書籍は%d冊以内にしてください", ErrInvalidBookImport, model.BookImportMaxRows)
+	}
+	return iu.startImport(model.BookImportJob{Source: model.BookImportSourceCSV, Format: format, UserId: userId}, entries)
+}
+
+// startImport はジョブを登録して実行します
+func (iu *bookImportUsecase) startImport(job model.BookImportJob, entries []model.BookImportEntry) (model.BookImportJobResponse, error) {
+	job.Status = model.BookImportStatusPending
+	job.Total = len(entries)
+	if err := iu.createJob(&job); err != nil {
+		return model.BookImportJobResponse{}, err
+	}
+
+	if iu.cfg.Synchronous {
+		iu.runImport(job, entries)
+		if updated, err := iu.ir.GetImportById(job.UserId, job.ID); err == nil {
+			job = updated
+		}
+	} else {
+		go iu.runImport(job, entries)
+	}
+	return job.ToResponse(), nil
+}
+
+// createJob はユーザーに待機中または実行中のジョブがないことを確認してからジョブを登録します
+func (iu *bookImportUsecase) createJob(job *model.BookImportJob) error {
+	iu.mu.Lock()
+	defer iu.mu.Unlock()
+
+	active, err := iu.ir.HasActiveImport(job.UserId)
+	if err != nil {
+		return err
+	}
+	if active {
+		return ErrBookImportInProgress
+	}
+	return iu.ir.CreateImport(job)
+}
+
+// runImport は1冊ずつ登録し、1行ごとの結果をジョブに記録します
+// 途中でpanicした場合は、ジョブが実行中のまま残らないよう失敗として記録する
+func (iu *bookImportUsecase) runImport(job model.BookImportJob, entries []model.BookImportEntry) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("書籍の一括登録ジョブ(%d)が異常終了しました: %v\n%s", job.ID, r, debug.Stack())
+			finished := time.Now()
+			job.Status = model.BookImportStatusFailed
+			job.Error = fmt.Sprintf("予期しないエラーにより中断しました: %v", r)
+			job.FinishedAt = &finished
+			iu.saveJob(&job)
+		}
+	}()
+
+	started := time.Now()
+	job.Status = model.BookImportStatusRunning
+	job.StartedAt = &started
+	iu.saveJob(&job)
+
+	for _, entry := range entries {
+		job.AddRow(iu.importEntry(job.UserId, entry))
+		iu.saveJob(&job)
+	}
+
+	finished := time.Now()
+	job.Status = model.BookImportStatusCompleted
+	job.FinishedAt = &finished
+	iu.saveJob(&job)
+}
+
+// importEntry は1冊分の入力を登録します
+// 登録済みの確認は書籍の情報の取得より先に行い、ISBNのない書籍はタイトルと著者で確認する
+func (iu *bookImportUsecase) importEntry(userId uint, entry model.BookImportEntry) model.BookImportRow {
+	row := model.BookImportRow{Row: entry.Row, Input: entry.ISBN, Title: entry.Title}
+	request := model.BookRequest{Title: entry.Title, Author: entry.Author, UserId: userId}
+	if entry.ISBN != "" {
+		normalized, err := isbn.Normalize(entry.ISBN)
+		if err != nil && entry.Title == "" {
+			row.Outcome = model.BookImportOutcomeFailed
+			row.Error = "ISBNが正しくありません"
+			return row
+		}
+		// 電子書籍のASINなどISBNでない値の場合は、CSVのタイトル・著者のみで登録する
+		request.ISBN = normalized
+		row.ISBN = normalized
+	}
+
+	var existing model.Book
+	var err error
+	if request.ISBN != "" {
+		existing, err = iu.br.GetBookByISBN(userId, request.ISBN)
+	} else {
+		existing, err = iu.br.GetBookByTitle(userId, request.Title, request.Author)
+	}
+	if err == nil {
+		row.Outcome = model.BookImportOutcomeDuplicate
+		row.BookId = existing.ID
+		row.Title = existing.Title
+		return row
+	}
+
+	row.Lookup = model.BookImportLookupCSV
+	if request.ISBN != "" {
+		metadata, err := iu.bmu.LookupISBN(userId, request.ISBN)
+		switch {
+		case err == nil:
+			request = mergeBookRequest(metadata.ToBookRequest(), request)
+			row.Lookup = metadata.SourceNames()
+		case entry.Title != "":
+			// 見つからない場合や取得に失敗した場合はCSVの値で登録する
+		case errors.Is(err, ErrBookMetadataNotFound):
+			row.Outcome = model.BookImportOutcomeNotFound
+			return row
+		default:
+			row.Outcome = model.BookImportOutcomeFailed
+			row.Error = fmt.Sprintf("書籍の情報を取得できませんでした: %v", err)
+			return row
+		}
+	}
+
+	bookRes, err := iu.bu.CreateBook(request)
+	if errors.Is(err, ErrDuplicateBook) {
+		row.Outcome = model.BookImportOutcomeDuplicate
+		row.BookId = bookRes.ID
+		row.Title = bookRes.Title
+		row.Lookup = ""
+		return row
+	}
+	if err != nil {
+		row.Outcome = model.BookImportOutcomeFailed
+		row.Title = request.Title
+		row.Error = err.Error()
+		return row
+	}
+	row.Outcome = model.BookImportOutcomeImported
+	row.BookId = bookRes.ID
+	row.Title = bookRes.Title
+
+	// CSVの読書の状態と評価を読書記録として登録する（登録時に取得したページ数は残す）
+	if (entry.Status != "" && entry.Status != model.BookStatusWantToRead) || entry.Rating > 0 {
+		reading := model.BookReadingRequest{Status: entry.Status, FinishedAt: entry.FinishedAt, PageCount: bookRes.PageCount, Rating: entry.Rating}
+		if reading.Status == "" {
+			reading.Status = model.BookStatusWantToRead
+		}
+		if _, err := iu.bu.UpdateBookReading(reading, userId, bookRes.ID); err != nil {
+			row.Error = fmt.Sprintf("読書記録を登録できませんでした: %v", err)
+		}
+	}
+	return row
+}
+
+// mergeBookRequest はプロバイダーから取得した書籍の情報の空の項目をCSVの値で補います。ISBNは入力の値を使う
+func mergeBookRequest(found model.BookRequest, fallback model.BookRequest) model.BookRequest {
+	if found.Title == "" {
+		found.Title = fallback.Title
+	}
+	if found.Author == "" {
+		found.Author = fallback.Author
+	}
+	found.ISBN = fallback.ISBN
+	found.UserId = fallback.UserId
+	return found
+}
+
+// FailInterruptedImports はサーバーの停止により待機中または実行中のまま残ったジョブを失敗にします
+// 起動時に、ジョブを実行する前に呼び出す
+func (iu *bookImportUsecase) FailInterruptedImports() (int64, error) {
+	return iu.ir.FailInterruptedImports("サーバーの再起動により中断されました", time.Now())
+}
+
+func (iu *bookImportUsecase) saveJob(job *model.BookImportJob) {
+	if err := iu.ir.UpdateImport(job); err != nil {
+		log.Printf("書籍の一括登録ジョブ(%d)の更新に失敗しました: %v", job.ID, err)
+	}
+}
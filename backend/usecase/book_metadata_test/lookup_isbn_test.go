@@ -0,0 +1,98 @@
+package book_metadata_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"reflect"
+	"testing"
+)
+
+const testISBN = "9784873118468"
+
+func TestBookMetadataUsecase_LookupISBN(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("優先するプロバイダーの値に空の項目を他のプロバイダーの値で補う", func(t *testing.T) {
+			openBD := testutils.NewFakeBookMetadataProvider(model.BookMetadataProviderOpenBD, model.BookMetadata{
+				ISBN: testISBN, Title: "Go言語による並行処理", Authors: []string{"Katherine Cox-Buday"}, Publisher: "オライリー・ジャパン", PublishedDate: "2018-10",
+			})
+			ndl := testutils.NewFakeBookMetadataProvider(model.BookMetadataProviderNDL, model.BookMetadata{
+				ISBN: testISBN, Title: "Go言語による並行処理", Publisher: "オライリー・ジャパン", PageCount: 238,
+			})
+			google := testutils.NewFakeBookMetadataProvider(model.BookMetadataProviderGoogle, model.BookMetadata{
+				ISBN: testISBN, Title: "Concurrency in Go", Authors: []string{"Cox-Buday"}, PageCount: 240,
+				Description: "Go言語の並行処理について解説した書籍", ImageURL: "https://books.google.com/cover.jpg",
+			})
+
+			got, err := usecase.NewBookMetadataUsecase(openBD, ndl, google).LookupISBN(1, testISBN)
+			if err != nil {
+				t.Fatalf("LookupISBN() error = %v", err)
+			}
+			want := model.BookMetadata{
+				ISBN:          testISBN,
+				Title:         "Go言語による並行処理",
+				Authors:       []string{"Katherine Cox-Buday"},
+				Publisher:     "オライリー・ジャパン",
+				PublishedDate: "2018-10",
+				Description:   "Go言語の並行処理について解説した書籍",
+				ImageURL:      "https://books.google.com/cover.jpg",
+				PageCount:     238,
+				Sources:       []string{"openbd", "ndl", "google"},
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("LookupISBN() = %+v, want %+v", got, want)
+			}
+		})
+
+		t.Run("値を補わなかったプロバイダーは取得元に含めない", func(t *testing.T) {
+			openBD := testutils.NewFakeBookMetadataProvider(model.BookMetadataProviderOpenBD, model.BookMetadata{
+				ISBN: testISBN, Title: "Go言語による並行処理", Authors: []string{"Katherine Cox-Buday"}, PageCount: 238,
+			})
+			ndl := testutils.NewFakeBookMetadataProvider(model.BookMetadataProviderNDL, model.BookMetadata{ISBN: testISBN, Title: "Go言語による並行処理"})
+
+			got, err := usecase.NewBookMetadataUsecase(openBD, ndl).LookupISBN(1, testISBN)
+			if err != nil {
+				t.Fatalf("LookupISBN() error = %v", err)
+			}
+			if got.SourceNames() != "openbd" {
+				t.Errorf("LookupISBN() sources = %q, want openbd", got.SourceNames())
+			}
+		})
+
+		t.Run("失敗したプロバイダーがあっても他のプロバイダーで見つかれば返す", func(t *testing.T) {
+			openBD := testutils.NewFakeBookMetadataProvider(model.BookMetadataProviderOpenBD)
+			openBD.Err = errors.New("openBD request failed with status code: 503")
+			google := testutils.NewFakeBookMetadataProvider(model.BookMetadataProviderGoogle, model.BookMetadata{ISBN: testISBN, Title: "Concurrency in Go"})
+
+			got, err := usecase.NewBookMetadataUsecase(openBD, google).LookupISBN(1, testISBN)
+			if err != nil {
+				t.Fatalf("LookupISBN() error = %v", err)
+			}
+			if got.Title != "Concurrency in Go" || got.SourceNames() != "google" {
+				t.Errorf("LookupISBN() = %+v, want google result", got)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("どのプロバイダーにもない場合はErrBookMetadataNotFound", func(t *testing.T) {
+			providers := usecase.NewBookMetadataUsecase(
+				testutils.NewFakeBookMetadataProvider(model.BookMetadataProviderOpenBD),
+				testutils.NewFakeBookMetadataProvider(model.BookMetadataProviderGoogle),
+			)
+			if _, err := providers.LookupISBN(1, testISBN); !errors.Is(err, usecase.ErrBookMetadataNotFound) {
+				t.Errorf("LookupISBN() error = %v, want ErrBookMetadataNotFound", err)
+			}
+		})
+
+		t.Run("見つからず失敗したプロバイダーがある場合はそのエラーを返す", func(t *testing.T) {
+			google := testutils.NewFakeBookMetadataProvider(model.BookMetadataProviderGoogle)
+			google.Err = errors.New("API request failed with status code: 429")
+			_, err := usecase.NewBookMetadataUsecase(testutils.NewFakeBookMetadataProvider(model.BookMetadataProviderOpenBD), google).LookupISBN(1, testISBN)
+			if err == nil || errors.Is(err, usecase.ErrBookMetadataNotFound) || !errors.Is(err, google.Err) {
+				t.Errorf("LookupISBN() error = %v, want google error", err)
+			}
+		})
+	})
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"sync"
+)
+
+// ErrBookMetadataNotFound はどのプロバイダーにもISBNの書籍がない場合のエラー
+var ErrBookMetadataNotFound = errors.New("book metadata not found")
+
+type IBookMetadataUsecase interface {
+	// LookupISBN はハイフンのないISBN-13の書籍の情報をすべてのプロバイダーから取得し、1つにまとめます
+	LookupISBN(userId uint, isbn string) (model.BookMetadata, error)
+}
+
+type bookMetadataUsecase struct {
+	providers []repository.IBookMetadataProvider
+}
+
+// NewBookMetadataUsecase は優先する順に並べたプロバイダーから書籍の情報を取得するユースケースを作成します
+func NewBookMetadataUsecase(providers ...repository.IBookMetadataProvider) IBookMetadataUsecase {
+	return &bookMetadataUsecase{providers}
+}
+
+// LookupISBN はプロバイダーを並行して呼び出し、優先するプロバイダーの値に、表紙・出版社・ページ数など空の項目を他のプロバイダーの値で補います
+// いずれかのプロバイダーで見つかった場合は、他のプロバイダーのエラーを無視する
+// どのプロバイダーでも見つからない場合はErrBookMetadataNotFound、呼び出しに失敗したプロバイダーがある場合はそのエラーを返す
+func (mu *bookMetadataUsecase) LookupISBN(userId uint, isbn string) (model.BookMetadata, error) {
+	results := make([]model.BookMetadata, len(mu.providers))
+	errs := make([]error, len(mu.providers))
+	var wg sync.WaitGroup
+	for i, provider := range mu.providers {
+		wg.Add(1)
+		go func(i int, provider repository.IBookMetadataProvider) {
+			defer wg.Done()
+			results[i], errs[i] = provider.LookupISBN(userId, isbn)
+		}(i, provider)
+	}
+	wg.Wait()
+
+	var merged model.BookMetadata
+	found := false
+	var failures []error
+	for i, result := range results {
+		switch {
+		case errs[i] == nil:
+			merged.Merge(result)
+			found = true
+		case !errors.Is(errs[i], repository.ErrBookMetadataNotFound):
+			failures = append(failures, fmt.Errorf("%s: %w", mu.providers[i].Name(), errs[i]))
+		}
+	}
+	if found {
+		merged.ISBN = isbn
+		return merged, nil
+	}
+	if len(failures) > 0 {
+		return model.BookMetadata{}, errors.Join(failures...)
+	}
+	return model.BookMetadata{}, ErrBookMetadataNotFound
+}
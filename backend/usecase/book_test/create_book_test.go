@@ -0,0 +1,105 @@
+package book_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"testing"
+)
+
+func TestBookUsecase_CreateBook(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("ISBN-10はハイフンを除いたISBN-13に正規化する", func(t *testing.T) {
+			setupBookUsecaseTest()
+			book, err := bookUsecase.CreateBook(model.BookRequest{Title: "Go言語による並行処理", Author: "著者", ISBN: "4-87311-846-8", UserId: testUser.ID})
+			if err != nil {
+				t.Fatalf("CreateBook() error = %v", err)
+			}
+			if book.ISBN != "9784873118468" {
+				t.Errorf("CreateBook() isbn = %q, want %q", book.ISBN, "9784873118468")
+			}
+		})
+
+		t.Run("ISBNのない書籍は複数登録できる", func(t *testing.T) {
+			setupBookUsecaseTest()
+			createTestBook(t, "1冊目")
+			createTestBook(t, "2冊目")
+		})
+
+		t.Run("他のユーザーは同じISBNの書籍を登録できる", func(t *testing.T) {
+			setupBookUsecaseTest()
+			other := testutils.CreateOtherUser(bookDb)
+			if _, err := bookUsecase.CreateBook(model.BookRequest{Title: "本", Author: "著者", ISBN: "9784873118468", UserId: testUser.ID}); err != nil {
+				t.Fatalf("CreateBook() error = %v", err)
+			}
+			if _, err := bookUsecase.CreateBook(model.BookRequest{Title: "本", Author: "著者", ISBN: "9784873118468", UserId: other.ID}); err != nil {
+				t.Errorf("CreateBook() for other user error = %v", err)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("チェックディジットが正しくないISBNはエラー", func(t *testing.T) {
+			setupBookUsecaseTest()
+			_, err := bookUsecase.CreateBook(model.BookRequest{Title: "本", Author: "著者", ISBN: "978-4-87311-846-9", UserId: testUser.ID})
+			if !errors.Is(err, usecase.ErrInvalidBook) {
+				t.Errorf("CreateBook() error = %v, want ErrInvalidBook", err)
+			}
+		})
+
+		t.Run("同じISBNの書籍が登録済みの場合は登録済みの書籍を返す", func(t *testing.T) {
+			setupBookUsecaseTest()
+			existing, err := bookUsecase.CreateBook(model.BookRequest{Title: "登録済み", Author: "著者", ISBN: "9784873118468", UserId: testUser.ID})
+			if err != nil {
+				t.Fatalf("CreateBook() error = %v", err)
+			}
+
+			// ISBN-10で指定しても同じ書籍として扱う
+			book, err := bookUsecase.CreateBook(model.BookRequest{Title: "新規", Author: "著者", ISBN: "4873118468", UserId: testUser.ID})
+			if !errors.Is(err, usecase.ErrDuplicateBook) {
+				t.Fatalf("CreateBook() error = %v, want ErrDuplicateBook", err)
+			}
+			if book.ID != existing.ID || book.Title != "登録済み" {
+				t.Errorf("CreateBook() = %+v, want existing book %d", book, existing.ID)
+			}
+			books, _ := bookUsecase.GetAllBooks(testUser.ID)
+			if len(books) != 1 {
+				t.Errorf("GetAllBooks() returned %d books, want 1", len(books))
+			}
+		})
+	})
+}
+
+func TestBookUsecase_UpdateBook(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("自身のISBNのままであれば更新できる", func(t *testing.T) {
+			setupBookUsecaseTest()
+			book, _ := bookUsecase.CreateBook(model.BookRequest{Title: "本", Author: "著者", ISBN: "9784873118468", UserId: testUser.ID})
+
+			updated, err := bookUsecase.UpdateBook(model.BookRequest{Title: "改題", Author: "著者", ISBN: "978-4-87311-846-8"}, testUser.ID, book.ID)
+			if err != nil {
+				t.Fatalf("UpdateBook() error = %v", err)
+			}
+			if updated.Title != "改題" || updated.ISBN != "9784873118468" {
+				t.Errorf("UpdateBook() = %+v, want updated title with normalized ISBN", updated)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("他の書籍と同じISBNにはできない", func(t *testing.T) {
+			setupBookUsecaseTest()
+			existing, _ := bookUsecase.CreateBook(model.BookRequest{Title: "登録済み", Author: "著者", ISBN: "9784873118468", UserId: testUser.ID})
+			book := createTestBook(t, "本")
+
+			got, err := bookUsecase.UpdateBook(model.BookRequest{Title: "本", Author: "著者", ISBN: "9784873118468"}, testUser.ID, book.ID)
+			if !errors.Is(err, usecase.ErrDuplicateBook) {
+				t.Fatalf("UpdateBook() error = %v, want ErrDuplicateBook", err)
+			}
+			if got.ID != existing.ID {
+				t.Errorf("UpdateBook() = %+v, want existing book %d", got, existing.ID)
+			}
+		})
+	})
+}
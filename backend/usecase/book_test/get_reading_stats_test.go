@@ -0,0 +1,94 @@
+package book_test
+
+import (
+	"go-react-app/model"
+	"testing"
+)
+
+func TestBookUsecase_GetBooks(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		setupBookUsecaseTest()
+		createTestBook(t, "読みたい本")
+		createReadBook(t, "読書中の本", model.BookReadingRequest{Status: model.BookStatusReading, Rating: 3})
+		createReadBook(t, "2024年に読了", model.BookReadingRequest{Status: model.BookStatusFinished, FinishedAt: "2024-06-01", Rating: 5})
+		createReadBook(t, "2023年に読了", model.BookReadingRequest{Status: model.BookStatusFinished, FinishedAt: "2023-12-31", Rating: 4})
+
+		tests := []struct {
+			name   string
+			filter model.BookFilter
+			want   []string
+		}{
+			{"条件なし", model.BookFilter{}, []string{"読みたい本", "読書中の本", "2024年に読了", "2023年に読了"}},
+			{"状態", model.BookFilter{Status: model.BookStatusFinished}, []string{"2024年に読了", "2023年に読了"}},
+			{"評価の下限", model.BookFilter{MinRating: 4}, []string{"2024年に読了", "2023年に読了"}},
+			{"読了した年", model.BookFilter{Year: 2023}, []string{"2023年に読了"}},
+			{"組み合わせ", model.BookFilter{Status: model.BookStatusReading, MinRating: 4}, []string{}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				books, err := bookUsecase.GetBooks(testUser.ID, tt.filter)
+				if err != nil {
+					t.Fatalf("GetBooks() error = %v", err)
+				}
+				if len(books) != len(tt.want) {
+					t.Fatalf("GetBooks() returned %d books, want %d", len(books), len(tt.want))
+				}
+				for i, title := range tt.want {
+					if books[i].Title != title {
+						t.Errorf("GetBooks()[%d] = %s, want %s", i, books[i].Title, title)
+					}
+				}
+			})
+		}
+	})
+}
+
+func TestBookUsecase_GetReadingStats(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		setupBookUsecaseTest()
+		createTestBook(t, "読みたい本")
+		createReadBook(t, "読書中の本", model.BookReadingRequest{Status: model.BookStatusReading})
+		createReadBook(t, "1月に読了", model.BookReadingRequest{Status: model.BookStatusFinished, FinishedAt: "2024-01-20", PageCount: 300, Rating: 5})
+		createReadBook(t, "1月に読了（未評価）", model.BookReadingRequest{Status: model.BookStatusFinished, FinishedAt: "2024-01-31", PageCount: 200})
+		createReadBook(t, "3月に読了", model.BookReadingRequest{Status: model.BookStatusFinished, FinishedAt: "2024-03-05", Rating: 4})
+		createReadBook(t, "中断", model.BookReadingRequest{Status: model.BookStatusAbandoned, FinishedAt: "2024-04-01", PageCount: 500, Rating: 1})
+		createReadBook(t, "2023年に読了", model.BookReadingRequest{Status: model.BookStatusFinished, FinishedAt: "2023-11-11", PageCount: 150})
+
+		stats, err := bookUsecase.GetReadingStats(testUser.ID, 0)
+		if err != nil {
+			t.Fatalf("GetReadingStats() error = %v", err)
+		}
+		wantCounts := map[string]int{model.BookStatusWantToRead: 1, model.BookStatusReading: 1, model.BookStatusFinished: 4, model.BookStatusAbandoned: 1}
+		for status, want := range wantCounts {
+			if stats.StatusCounts[status] != want {
+				t.Errorf("GetReadingStats() status_counts[%s] = %d, want %d", status, stats.StatusCounts[status], want)
+			}
+		}
+		if len(stats.Years) != 2 || stats.Years[0].Year != 2024 || stats.Years[1].Year != 2023 {
+			t.Fatalf("GetReadingStats() years = %+v, want 2024 and 2023", stats.Years)
+		}
+
+		// 中断した本はページ数と評価に含めない
+		year := stats.Years[0]
+		if year.Finished != 3 || year.Abandoned != 1 || year.PagesRead != 500 {
+			t.Errorf("2024 = %+v, want 3 finished, 1 abandoned, 500 pages", year)
+		}
+		if year.AverageRating == nil || *year.AverageRating != 4.5 {
+			t.Errorf("2024 average rating = %v, want 4.5", year.AverageRating)
+		}
+		if year.Months[0] != 2 || year.Months[2] != 1 || year.Months[3] != 0 {
+			t.Errorf("2024 months = %v, want [2 0 1 0 ...]", year.Months)
+		}
+		if stats.Years[1].AverageRating != nil {
+			t.Errorf("2023 average rating = %v, want nil", *stats.Years[1].AverageRating)
+		}
+
+		stats, err = bookUsecase.GetReadingStats(testUser.ID, 2023)
+		if err != nil {
+			t.Fatalf("GetReadingStats(2023) error = %v", err)
+		}
+		if len(stats.Years) != 1 || stats.Years[0].Finished != 1 || stats.Years[0].PagesRead != 150 {
+			t.Errorf("GetReadingStats(2023) years = %+v, want only 2023", stats.Years)
+		}
+	})
+}
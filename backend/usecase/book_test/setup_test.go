@@ -0,0 +1,50 @@
+package book_test
+
+import (
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/validator"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// テスト用の共通変数
+var (
+	bookDb      *gorm.DB
+	bookUsecase usecase.IBookUsecase
+	testUser    model.User
+)
+
+// テスト前の共通セットアップ
+func setupBookUsecaseTest() {
+	if bookDb != nil {
+		testutils.CleanupTestDB(bookDb)
+		bookDb.Exec("DELETE FROM books")
+	} else {
+		bookDb = testutils.SetupTestDB()
+		bookUsecase = usecase.NewBookUsecase(repository.NewBookRepository(bookDb), validator.NewBookValidator(), repository.NewSearchRepository(bookDb))
+	}
+	testUser = testutils.CreateTestUser(bookDb)
+}
+
+// テスト用の書籍を登録するヘルパー関数
+func createTestBook(t *testing.T, title string) model.BookResponse {
+	book, err := bookUsecase.CreateBook(model.BookRequest{Title: title, Author: "著者", UserId: testUser.ID})
+	if err != nil {
+		t.Fatalf("テスト用の書籍の登録に失敗しました: %v", err)
+	}
+	return book
+}
+
+// テスト用の書籍を登録し、読書記録を設定するヘルパー関数
+func createReadBook(t *testing.T, title string, reading model.BookReadingRequest) model.BookResponse {
+	book := createTestBook(t, title)
+	updated, err := bookUsecase.UpdateBookReading(reading, testUser.ID, book.ID)
+	if err != nil {
+		t.Fatalf("テスト用の読書記録の設定に失敗しました: %v", err)
+	}
+	return updated
+}
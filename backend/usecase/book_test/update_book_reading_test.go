@@ -0,0 +1,111 @@
+package book_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"testing"
+	"time"
+)
+
+func TestBookUsecase_UpdateBookReading(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("登録した書籍は読みたいの状態になる", func(t *testing.T) {
+			setupBookUsecaseTest()
+			book := createTestBook(t, "Go言語による並行処理")
+			if book.Status != model.BookStatusWantToRead || book.Highlights == nil {
+				t.Errorf("CreateBook() = %+v, want want-to-read with empty highlights", book)
+			}
+		})
+
+		t.Run("ページ数から進捗を計算し、開始日を省略した場合は当日とする", func(t *testing.T) {
+			setupBookUsecaseTest()
+			book := createTestBook(t, "Go言語による並行処理")
+
+			updated, err := bookUsecase.UpdateBookReading(model.BookReadingRequest{
+				Status:          model.BookStatusReading,
+				PageCount:       320,
+				CurrentPage:     80,
+				ProgressPercent: 90,
+				Rating:          4,
+				Notes:           "第3章が分かりやすい",
+				Highlights:      []string{"並行性は並列性ではない"},
+			}, testUser.ID, book.ID)
+			if err != nil {
+				t.Fatalf("UpdateBookReading() error = %v", err)
+			}
+			if updated.Status != model.BookStatusReading || updated.ProgressPercent != 25 || updated.Rating != 4 {
+				t.Errorf("UpdateBookReading() = %+v, want reading at 25%%", updated)
+			}
+			if updated.StartedAt != time.Now().Format(model.BookDateLayout) || updated.FinishedAt != "" {
+				t.Errorf("UpdateBookReading() started_at = %q, finished_at = %q, want today and empty", updated.StartedAt, updated.FinishedAt)
+			}
+
+			got, err := bookUsecase.GetBookById(testUser.ID, book.ID)
+			if err != nil {
+				t.Fatalf("GetBookById() error = %v", err)
+			}
+			if got.Notes != "第3章が分かりやすい" || len(got.Highlights) != 1 || got.Highlights[0] != "並行性は並列性ではない" {
+				t.Errorf("GetBookById() = %+v, want saved notes and highlights", got)
+			}
+		})
+
+		t.Run("読了にすると進捗を100%にする", func(t *testing.T) {
+			setupBookUsecaseTest()
+			updated := createReadBook(t, "Go言語による並行処理", model.BookReadingRequest{
+				Status: model.BookStatusFinished, StartedAt: "2024-01-10", FinishedAt: "2024-02-01", PageCount: 320, CurrentPage: 100,
+			})
+			if updated.ProgressPercent != 100 || updated.CurrentPage != 320 || updated.StartedAt != "2024-01-10" || updated.FinishedAt != "2024-02-01" {
+				t.Errorf("UpdateBookReading() = %+v, want finished at 100%%", updated)
+			}
+		})
+
+		t.Run("書籍の情報を更新しても読書記録は変わらない", func(t *testing.T) {
+			setupBookUsecaseTest()
+			book := createReadBook(t, "Go言語による並行処理", model.BookReadingRequest{Status: model.BookStatusReading, Rating: 5})
+
+			updated, err := bookUsecase.UpdateBook(model.BookRequest{Title: "改題", Author: "著者", UserId: testUser.ID}, testUser.ID, book.ID)
+			if err != nil {
+				t.Fatalf("UpdateBook() error = %v", err)
+			}
+			if updated.Title != "改題" || updated.Status != model.BookStatusReading || updated.Rating != 5 {
+				t.Errorf("UpdateBook() = %+v, want the reading log kept", updated)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		setupBookUsecaseTest()
+		book := createTestBook(t, "Go言語による並行処理")
+
+		tests := []struct {
+			name    string
+			request model.BookReadingRequest
+		}{
+			{"状態がない", model.BookReadingRequest{}},
+			{"不明な状態", model.BookReadingRequest{Status: "read"}},
+			{"日付の形式が不正", model.BookReadingRequest{Status: model.BookStatusReading, StartedAt: "2024/01/10"}},
+			{"読みたい本に開始日", model.BookReadingRequest{Status: model.BookStatusWantToRead, StartedAt: "2024-01-10"}},
+			{"読書中の本に終了日", model.BookReadingRequest{Status: model.BookStatusReading, FinishedAt: "2024-01-10"}},
+			{"終了日が開始日より前", model.BookReadingRequest{Status: model.BookStatusFinished, StartedAt: "2024-02-01", FinishedAt: "2024-01-10"}},
+			{"現在のページがページ数を超える", model.BookReadingRequest{Status: model.BookStatusReading, PageCount: 100, CurrentPage: 101}},
+			{"進捗が100%を超える", model.BookReadingRequest{Status: model.BookStatusReading, ProgressPercent: 101}},
+			{"評価が5を超える", model.BookReadingRequest{Status: model.BookStatusFinished, Rating: 6}},
+			{"空のハイライト", model.BookReadingRequest{Status: model.BookStatusReading, Highlights: []string{""}}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if _, err := bookUsecase.UpdateBookReading(tt.request, testUser.ID, book.ID); !errors.Is(err, usecase.ErrInvalidBookReading) {
+					t.Errorf("UpdateBookReading() error = %v, want ErrInvalidBookReading", err)
+				}
+			})
+		}
+
+		t.Run("他のユーザーの書籍はErrBookNotFoundを返す", func(t *testing.T) {
+			_, err := bookUsecase.UpdateBookReading(model.BookReadingRequest{Status: model.BookStatusReading}, testUser.ID+1000, book.ID)
+			if !errors.Is(err, usecase.ErrBookNotFound) {
+				t.Errorf("UpdateBookReading() error = %v, want ErrBookNotFound", err)
+			}
+		})
+	})
+}
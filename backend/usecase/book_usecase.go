@@ -1,9 +1,29 @@
 package usecase
 
 import (
+	"errors"
+	"fmt"
 	"go-react-app/model"
 	"go-react-app/repository"
+	"go-react-app/utils/isbn"
 	"go-react-app/validator"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidBookReading は読書記録の入力が不正な場合のエラー
+	ErrInvalidBookReading = errors.New("invalid book reading")
+	// ErrBookNotFound は書籍が見つからない場合のエラー
+	ErrBookNotFound = errors.New("book not found")
+	// ErrInvalidBook は書籍の情報が不正な場合のエラー
+	ErrInvalidBook = errors.New("invalid book")
+	// ErrDuplicateBook は同じISBNの書籍が登録済みの場合のエラー。登録済みの書籍とともに返す
+	ErrDuplicateBook = errors.New("book with the same ISBN already exists")
 )
 
 type IBookUsecase interface {
@@ -12,15 +32,22 @@ type IBookUsecase interface {
 	CreateBook(request model.BookRequest) (model.BookResponse, error)
 	UpdateBook(request model.BookRequest, userId uint, bookId uint) (model.BookResponse, error)
 	DeleteBook(userId uint, bookId uint) error
+	// GetBooks は読書の状態・評価・読了した年で絞り込んだ書籍を返します
+	GetBooks(userId uint, filter model.BookFilter) ([]model.BookResponse, error)
+	// UpdateBookReading は書籍の読書記録（状態・期間・進捗・評価・メモ）を更新します
+	UpdateBookReading(request model.BookReadingRequest, userId uint, bookId uint) (model.BookResponse, error)
+	// GetReadingStats は読書の状態ごとの冊数と、年ごとの読書の集計を返します。yearが0の場合はすべての年
+	GetReadingStats(userId uint, year int) (model.ReadingStatsResponse, error)
 }
 
 type bookUsecase struct {
 	br repository.IBookRepository
 	bv validator.IBookValidator
+	sr repository.ISearchRepository
 }
 
-func NewBookUsecase(br repository.IBookRepository, bv validator.IBookValidator) IBookUsecase {
-	return &bookUsecase{br, bv}
+func NewBookUsecase(br repository.IBookRepository, bv validator.IBookValidator, sr repository.ISearchRepository) IBookUsecase {
+	return &bookUsecase{br, bv, sr}
 }
 
 func (bu *bookUsecase) GetAllBooks(userId uint) ([]model.BookResponse, error) {
@@ -44,32 +71,211 @@ func (bu *bookUsecase) GetBookById(userId uint, bookId uint) (model.BookResponse
 	return book.ToResponse(), nil
 }
 
+// CreateBook は書籍を登録します
+// 同じISBNの書籍が登録済みの場合は登録せず、登録済みの書籍とErrDuplicateBookを返す
 func (bu *bookUsecase) CreateBook(request model.BookRequest) (model.BookResponse, error) {
-	if err := bu.bv.ValidateBookRequest(request); err != nil {
+	book, err := bu.toBook(request)
+	if err != nil {
 		return model.BookResponse{}, err
 	}
-	
-	book := request.ToModel()
+	if existing, err := bu.findDuplicate(book, 0); err != nil {
+		return duplicateResponse(existing), err
+	}
+
 	if err := bu.br.CreateBook(&book); err != nil {
+		// 同時に登録された場合は一意制約で失敗するため、登録済みの書籍を探し直す
+		if existing, findErr := bu.findDuplicate(book, 0); errors.Is(findErr, ErrDuplicateBook) {
+			return existing.ToResponse(), ErrDuplicateBook
+		}
 		return model.BookResponse{}, err
 	}
-	
+	upsertSearchDocument(bu.sr, book.ToSearchDocument())
+
 	return book.ToResponse(), nil
 }
 
+// UpdateBook は書籍の情報を更新します。他の書籍と同じISBNにした場合はErrDuplicateBookを返す
 func (bu *bookUsecase) UpdateBook(request model.BookRequest, userId uint, bookId uint) (model.BookResponse, error) {
-	if err := bu.bv.ValidateBookRequest(request); err != nil {
+	request.UserId = userId
+	book, err := bu.toBook(request)
+	if err != nil {
 		return model.BookResponse{}, err
 	}
-	
-	book := request.ToModel()
+	if existing, err := bu.findDuplicate(book, bookId); err != nil {
+		return duplicateResponse(existing), err
+	}
+
 	if err := bu.br.UpdateBook(&book, userId, bookId); err != nil {
 		return model.BookResponse{}, err
 	}
-	
+	upsertSearchDocument(bu.sr, book.ToSearchDocument())
+
 	return book.ToResponse(), nil
 }
 
+// toBook はリクエストを検証し、ISBNをISBN-13に正規化した書籍を返します
+func (bu *bookUsecase) toBook(request model.BookRequest) (model.Book, error) {
+	if err := bu.bv.ValidateBookRequest(request); err != nil {
+		return model.Book{}, fmt.Errorf("%w: %v", ErrInvalidBook, err)
+	}
+	book := request.ToModel()
+	if book.ISBN != "" {
+		// 検証済みのため、正規化に失敗することはない
+		book.ISBN, _ = isbn.Normalize(book.ISBN)
+	}
+	return book, nil
+}
+
+// findDuplicate はexcludeId以外の同じISBNの書籍を探します。ある場合は書籍とErrDuplicateBookを返す
+func (bu *bookUsecase) findDuplicate(book model.Book, excludeId uint) (*model.Book, error) {
+	if book.ISBN == "" {
+		return nil, nil
+	}
+	existing, err := bu.br.GetBookByISBN(book.UserId, book.ISBN)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && existing.ID == excludeId) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &existing, ErrDuplicateBook
+}
+
 func (bu *bookUsecase) DeleteBook(userId uint, bookId uint) error {
-	return bu.br.DeleteBook(userId, bookId)
+	if err := bu.br.DeleteBook(userId, bookId); err != nil {
+		return err
+	}
+	deleteSearchDocument(bu.sr, userId, model.SearchSourceBook, strconv.FormatUint(uint64(bookId), 10))
+	return nil
+}
+
+func (bu *bookUsecase) GetBooks(userId uint, filter model.BookFilter) ([]model.BookResponse, error) {
+	books, err := bu.br.FindBooks(userId, filter)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]model.BookResponse, len(books))
+	for i, book := range books {
+		responses[i] = book.ToResponse()
+	}
+	return responses, nil
+}
+
+func (bu *bookUsecase) UpdateBookReading(request model.BookReadingRequest, userId uint, bookId uint) (model.BookResponse, error) {
+	if err := bu.bv.ValidateBookReadingRequest(request); err != nil {
+		return model.BookResponse{}, fmt.Errorf("%w: %v", ErrInvalidBookReading, err)
+	}
+
+	book := model.Book{
+		Status:          request.Status,
+		StartedAt:       parseBookDate(request.StartedAt),
+		FinishedAt:      parseBookDate(request.FinishedAt),
+		PageCount:       request.PageCount,
+		CurrentPage:     request.CurrentPage,
+		ProgressPercent: request.ProgressPercent,
+		Rating:          request.Rating,
+		Notes:           request.Notes,
+		Highlights:      request.Highlights,
+	}
+	// 日付を省略して読書中・読了・中断にした場合は当日とする
+	today := parseBookDate(time.Now().Format(model.BookDateLayout))
+	switch request.Status {
+	case model.BookStatusReading:
+		if book.StartedAt == nil {
+			book.StartedAt = today
+		}
+	case model.BookStatusFinished, model.BookStatusAbandoned:
+		if book.FinishedAt == nil {
+			book.FinishedAt = today
+		}
+	}
+	if book.PageCount > 0 && book.CurrentPage > 0 {
+		book.ProgressPercent = book.CurrentPage * 100 / book.PageCount
+	}
+	if book.Status == model.BookStatusFinished {
+		book.ProgressPercent = 100
+		if book.PageCount > 0 {
+			book.CurrentPage = book.PageCount
+		}
+	}
+
+	err := bu.br.UpdateBookReading(&book, userId, bookId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.BookResponse{}, ErrBookNotFound
+	}
+	if err != nil {
+		return model.BookResponse{}, err
+	}
+	return book.ToResponse(), nil
+}
+
+func (bu *bookUsecase) GetReadingStats(userId uint, year int) (model.ReadingStatsResponse, error) {
+	books, err := bu.br.GetAllBooks(userId)
+	if err != nil {
+		return model.ReadingStatsResponse{}, err
+	}
+
+	stats := model.ReadingStatsResponse{StatusCounts: map[string]int{}, Years: []model.YearlyReadingStats{}}
+	for _, status := range model.BookStatuses {
+		stats.StatusCounts[status] = 0
+	}
+	years := map[int]*model.YearlyReadingStats{}
+	ratings := map[int][]int{}
+	for _, book := range books {
+		stats.StatusCounts[book.Status]++
+		if book.FinishedAt == nil || (year != 0 && book.FinishedAt.Year() != year) {
+			continue
+		}
+		finishedYear := book.FinishedAt.Year()
+		yearly, ok := years[finishedYear]
+		if !ok {
+			yearly = &model.YearlyReadingStats{Year: finishedYear, Months: make([]int, 12)}
+			years[finishedYear] = yearly
+		}
+		switch book.Status {
+		case model.BookStatusFinished:
+			yearly.Finished++
+			yearly.PagesRead += book.PageCount
+			yearly.Months[book.FinishedAt.Month()-1]++
+			if book.Rating > 0 {
+				ratings[finishedYear] = append(ratings[finishedYear], book.Rating)
+			}
+		case model.BookStatusAbandoned:
+			yearly.Abandoned++
+		}
+	}
+
+	for finishedYear, yearly := range years {
+		if rated := ratings[finishedYear]; len(rated) > 0 {
+			sum := 0
+			for _, rating := range rated {
+				sum += rating
+			}
+			average := math.Round(float64(sum)*10/float64(len(rated))) / 10
+			yearly.AverageRating = &average
+		}
+		stats.Years = append(stats.Years, *yearly)
+	}
+	sort.Slice(stats.Years, func(i, j int) bool { return stats.Years[i].Year > stats.Years[j].Year })
+	return stats, nil
+}
+
+// duplicateResponse は重複した登録済みの書籍のレスポンスを返します。書籍がない場合はゼロ値
+func duplicateResponse(existing *model.Book) model.BookResponse {
+	if existing == nil {
+		return model.BookResponse{}
+	}
+	return existing.ToResponse()
+}
+
+// parseBookDate は検証済みのYYYY-MM-DDの日付を日時にします。空の場合はnil
+func parseBookDate(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	date, err := time.Parse(model.BookDateLayout, value)
+	if err != nil {
+		return nil
+	}
+	return &date
 }
@@ -0,0 +1,179 @@
+package component_data_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"testing"
+	"time"
+)
+
+func TestComponentDataUsecase_ResolveComponentData(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("最新記事は指定したタグの公開済みの記事を件数まで返す", func(t *testing.T) {
+			setupComponentDataUsecaseTest()
+			for i, article := range []model.Article{
+				{Title: "Goの記事1", Tags: "Go", Published: true},
+				{Title: "Goの記事2", Tags: "Go, Web", Published: true},
+				{Title: "Goの記事3", Tags: "Go", Published: true},
+				{Title: "Webの記事", Tags: "Web", Published: true},
+				{Title: "Goの下書き", Tags: "Go", Published: false},
+			} {
+				article.UserId = testUserId
+				article.UpdatedAt = time.Now().Add(time.Duration(i) * time.Minute)
+				dataDb.Create(&article)
+			}
+
+			res, err := dataUsecase.ResolveComponentData(testUserId, newDataComponent("latest-articles", `{"tag":"Go","limit":2}`))
+			if err != nil {
+				t.Fatalf("ResolveComponentData() error = %v", err)
+			}
+			if res.Source != model.DataSourceLatestArticles || len(res.Items) != 2 {
+				t.Fatalf("ResolveComponentData() = %+v, want 2 items", res)
+			}
+			if res.Items[0].Title != "Goの記事3" || res.Items[1].Title != "Goの記事2" {
+				t.Errorf("ResolveComponentData() items = %s, %s, want Goの記事3, Goの記事2", res.Items[0].Title, res.Items[1].Title)
+			}
+		})
+
+		t.Run("Qiitaのトレンドはいいねの多い順に返す", func(t *testing.T) {
+			setupComponentDataUsecaseTest()
+			qiitaMock.GetQiitaArticlesFunc = func(userId uint) ([]model.QiitaArticleResponse, error) {
+				return []model.QiitaArticleResponse{
+					{ID: "a", Title: "少ない", LikesCount: 1},
+					{ID: "b", Title: "多い", LikesCount: 30, Tags: []model.QiitaTag{{Name: "Go"}}},
+					{ID: "c", Title: "中くらい", LikesCount: 10},
+				}, nil
+			}
+
+			res, err := dataUsecase.ResolveComponentData(testUserId, newDataComponent("qiita-trending", `{"limit":2}`))
+			if err != nil {
+				t.Fatalf("ResolveComponentData() error = %v", err)
+			}
+			if len(res.Items) != 2 || res.Items[0].ID != "b" || res.Items[1].ID != "c" {
+				t.Errorf("ResolveComponentData() items = %+v, want [b c]", res.Items)
+			}
+			if len(res.Items[0].Tags) != 1 || res.Items[0].Tags[0] != "Go" {
+				t.Errorf("ResolveComponentData() tags = %v, want [Go]", res.Items[0].Tags)
+			}
+		})
+
+		t.Run("フィードは指定したフィードの記事を新しい順に返す", func(t *testing.T) {
+			setupComponentDataUsecaseTest()
+			now := time.Now()
+			var requested uint
+			feedMock.GetArticlesByFeedIDFunc = func(userId uint, feedID uint) ([]model.FeedArticleResponse, error) {
+				requested = feedID
+				return []model.FeedArticleResponse{
+					{ID: "old", Title: "古い記事", URL: "https://example.com/old", PublishedAt: now.Add(-time.Hour)},
+					{ID: "new", Title: "新しい記事", URL: "https://example.com/new", PublishedAt: now},
+				}, nil
+			}
+
+			res, err := dataUsecase.ResolveComponentData(testUserId, newDataComponent("feed", `{"feedId":3}`))
+			if err != nil {
+				t.Fatalf("ResolveComponentData() error = %v", err)
+			}
+			if requested != 3 {
+				t.Errorf("feed id = %d, want 3", requested)
+			}
+			if len(res.Items) != 2 || res.Items[0].ID != "new" || res.Items[0].URL != "https://example.com/new" {
+				t.Errorf("ResolveComponentData() items = %+v", res.Items)
+			}
+		})
+
+		t.Run("本棚は登録した本を返す", func(t *testing.T) {
+			setupComponentDataUsecaseTest()
+			dataDb.Create(&model.Book{Title: "Go言語による並行処理", Author: "Katherine Cox-Buday", ImageURL: "https://example.com/cover.png", UserId: testUserId})
+
+			res, err := dataUsecase.ResolveComponentData(testUserId, newDataComponent("bookshelf", ""))
+			if err != nil {
+				t.Fatalf("ResolveComponentData() error = %v", err)
+			}
+			if len(res.Items) != 1 || res.Items[0].Title != "Go言語による並行処理" || res.Items[0].ImageURL != "https://example.com/cover.png" {
+				t.Errorf("ResolveComponentData() items = %+v", res.Items)
+			}
+		})
+
+		t.Run("外部APIは変換の定義と件数を指定してカードを取得する", func(t *testing.T) {
+			setupComponentDataUsecaseTest()
+			var requestedMapping uint
+			var requestedLimit int
+			mappingMock.ResolveCardsFunc = func(userId uint, mappingId uint, limit int) ([]model.ComponentDataItem, error) {
+				requestedMapping, requestedLimit = mappingId, limit
+				return []model.ComponentDataItem{{ID: "1", Title: "golang/go", URL: "https://github.com/golang/go"}}, nil
+			}
+
+			res, err := dataUsecase.ResolveComponentData(testUserId, newDataComponent("external-api", `{"mappingId":7,"limit":3}`))
+			if err != nil {
+				t.Fatalf("ResolveComponentData() error = %v", err)
+			}
+			if requestedMapping != 7 || requestedLimit != 3 {
+				t.Errorf("ResolveCards() mappingId = %d, limit = %d, want 7, 3", requestedMapping, requestedLimit)
+			}
+			if res.Source != model.DataSourceExternalAPI || len(res.Items) != 1 || res.Items[0].Title != "golang/go" {
+				t.Errorf("ResolveComponentData() = %+v", res)
+			}
+		})
+
+		t.Run("同じコンポーネント・同じ設定の結果はキャッシュし、設定を変更すると取得し直す", func(t *testing.T) {
+			setupComponentDataUsecaseTest()
+			calls := 0
+			qiitaMock.GetQiitaArticlesFunc = func(userId uint) ([]model.QiitaArticleResponse, error) {
+				calls++
+				return []model.QiitaArticleResponse{{ID: "a", Title: "記事"}}, nil
+			}
+			component := newDataComponent("qiita-trending", `{"limit":5}`)
+
+			first, err := dataUsecase.ResolveComponentData(testUserId, component)
+			if err != nil {
+				t.Fatalf("ResolveComponentData() error = %v", err)
+			}
+			second, err := dataUsecase.ResolveComponentData(testUserId, component)
+			if err != nil {
+				t.Fatalf("ResolveComponentData() error = %v", err)
+			}
+			if calls != 1 || !second.FetchedAt.Equal(first.FetchedAt) {
+				t.Errorf("取得回数 = %d, want 1（キャッシュが使われていません）", calls)
+			}
+
+			component.Properties = `{"limit":3}`
+			if _, err := dataUsecase.ResolveComponentData(testUserId, component); err != nil {
+				t.Fatalf("ResolveComponentData() error = %v", err)
+			}
+			if calls != 2 {
+				t.Errorf("設定変更後の取得回数 = %d, want 2", calls)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("データを取得しない種類のコンポーネントはErrNoDataSourceを返す", func(t *testing.T) {
+			setupComponentDataUsecaseTest()
+
+			_, err := dataUsecase.ResolveComponentData(testUserId, newDataComponent("text", ""))
+			if !errors.Is(err, usecase.ErrNoDataSource) {
+				t.Errorf("ResolveComponentData() error = %v, want ErrNoDataSource", err)
+			}
+		})
+
+		t.Run("取得に失敗した結果はキャッシュしない", func(t *testing.T) {
+			setupComponentDataUsecaseTest()
+			calls := 0
+			feedMock.GetArticlesByFeedIDFunc = func(userId uint, feedID uint) ([]model.FeedArticleResponse, error) {
+				calls++
+				return nil, errors.New("フィードに接続できません")
+			}
+			component := newDataComponent("feed", `{"feedId":1}`)
+
+			for i := 0; i < 2; i++ {
+				if _, err := dataUsecase.ResolveComponentData(testUserId, component); err == nil {
+					t.Error("ResolveComponentData() エラーが返されませんでした")
+				}
+			}
+			if calls != 2 {
+				t.Errorf("取得回数 = %d, want 2", calls)
+			}
+		})
+	})
+}
@@ -0,0 +1,50 @@
+package component_data_test
+
+import (
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/validator"
+
+	"gorm.io/gorm"
+)
+
+// テスト用の共通変数
+var (
+	dataDb        *gorm.DB
+	qiitaMock     *testutils.MockQiitaUsecase
+	feedMock      *testutils.MockFeedArticleUsecase
+	mappingMock   *testutils.MockResponseMappingUsecase
+	dataUsecase   usecase.IComponentDataUsecase
+	testUserId    uint = 1 // テスト用ユーザーID
+	componentSeed uint = 0
+)
+
+// テスト前の共通セットアップ
+// キャッシュが前のテストの結果を返さないよう、テストごとにユースケースを作成し直す
+func setupComponentDataUsecaseTest() {
+	if dataDb == nil {
+		dataDb = testutils.SetupTestDB()
+	}
+	dataDb.Exec("DELETE FROM articles WHERE user_id = ?", testUserId)
+	dataDb.Exec("DELETE FROM books WHERE user_id = ?", testUserId)
+
+	qiitaMock = &testutils.MockQiitaUsecase{}
+	feedMock = &testutils.MockFeedArticleUsecase{}
+	mappingMock = &testutils.MockResponseMappingUsecase{}
+	dataUsecase = usecase.NewComponentDataUsecase(
+		repository.NewArticleRepository(dataDb),
+		feedMock,
+		qiitaMock,
+		usecase.NewBookUsecase(repository.NewBookRepository(dataDb), validator.NewBookValidator(), repository.NewSearchRepository(dataDb)),
+		mappingMock,
+		0,
+	)
+}
+
+// データを取得するコンポーネントを作成（保存はしない）
+func newDataComponent(componentType string, properties string) model.LayoutComponent {
+	componentSeed++
+	return model.LayoutComponent{ID: componentSeed, Name: "データ", Type: componentType, Properties: properties, UserId: testUserId}
+}
@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultComponentDataTTL はコンポーネントに表示するデータをキャッシュする期間の既定値
+const DefaultComponentDataTTL = 5 * time.Minute
+
+// ErrNoDataSource はデータの取得元を持たない種類のコンポーネントのデータを取得しようとした場合のエラー
+var ErrNoDataSource = errors.New("component has no data source")
+
+type IComponentDataUsecase interface {
+	// ResolveComponentData はコンポーネントの種類の取得元からデータを取得します
+	// 同じコンポーネント・同じ設定の結果はTTLの間キャッシュする
+	ResolveComponentData(userId uint, component model.LayoutComponent) (model.ComponentDataResponse, error)
+}
+
+type componentDataUsecase struct {
+	ar    repository.IArticleRepository
+	fau   IFeedArticleUsecase
+	qu    IQiitaUsecase
+	bu    IBookUsecase
+	rmu   IResponseMappingUsecase
+	cache *componentDataCache
+}
+
+// NewComponentDataUsecase はコンポーネントに表示するデータを既存のユースケースから取得するユースケースを作成します
+// ttlが0以下の場合はDefaultComponentDataTTLを使う
+func NewComponentDataUsecase(ar repository.IArticleRepository, fau IFeedArticleUsecase, qu IQiitaUsecase, bu IBookUsecase, rmu IResponseMappingUsecase, ttl time.Duration) IComponentDataUsecase {
+	if ttl <= 0 {
+		ttl = DefaultComponentDataTTL
+	}
+	return &componentDataUsecase{ar, fau, qu, bu, rmu, newComponentDataCache(ttl)}
+}
+
+func (cdu *componentDataUsecase) ResolveComponentData(userId uint, component model.LayoutComponent) (model.ComponentDataResponse, error) {
+	source := component.DataSource()
+	if source == "" {
+		return model.ComponentDataResponse{}, fmt.Errorf("%w: %s", ErrNoDataSource, component.Type)
+	}
+	// 設定を変更した場合は取得し直すため、propertiesをキーに含める
+	key := fmt.Sprintf("%d:%d:%s:%s", userId, component.ID, source, component.Properties)
+	if cached, ok := cdu.cache.get(key); ok {
+		return cached, nil
+	}
+
+	config := component.DataSourceConfig()
+	var items []model.ComponentDataItem
+	var err error
+	switch source {
+	case model.DataSourceLatestArticles:
+		items, err = cdu.latestArticles(userId, config)
+	case model.DataSourceFeed:
+		items, err = cdu.feedItems(userId, config)
+	case model.DataSourceQiitaTrending:
+		items, err = cdu.qiitaTrending(userId, config)
+	case model.DataSourceBookshelf:
+		items, err = cdu.bookshelf(userId, config)
+	case model.DataSourceExternalAPI:
+		items, err = cdu.rmu.ResolveCards(userId, config.MappingId, config.Limit)
+	default:
+		err = fmt.Errorf("%w: %s", ErrNoDataSource, source)
+	}
+	if err != nil {
+		return model.ComponentDataResponse{}, err
+	}
+
+	response := model.ComponentDataResponse{ComponentId: component.ID, Source: source, Items: items, FetchedAt: time.Now()}
+	cdu.cache.put(key, response)
+	return response, nil
+}
+
+func (cdu *componentDataUsecase) latestArticles(userId uint, config model.DataSourceConfig) ([]model.ComponentDataItem, error) {
+	articles := []model.Article{}
+	if err := cdu.ar.GetPublishedArticles(&articles, userId, config.Tag, config.Limit); err != nil {
+		return nil, err
+	}
+	items := make([]model.ComponentDataItem, len(articles))
+	for i, article := range articles {
+		items[i] = model.ComponentDataItem{
+			ID:       strconv.FormatUint(uint64(article.ID), 10),
+			Title:    article.Title,
+			Excerpt:  article.MetaDescription,
+			ImageURL: article.OGImage,
+			Date:     &article.CreatedAt,
+			Tags:     splitTags(article.Tags),
+		}
+	}
+	return items, nil
+}
+
+func (cdu *componentDataUsecase) feedItems(userId uint, config model.DataSourceConfig) ([]model.ComponentDataItem, error) {
+	articles, err := cdu.fau.GetArticlesByFeedID(userId, config.FeedId)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(articles, func(i, j int) bool { return articles[i].PublishedAt.After(articles[j].PublishedAt) })
+	items := make([]model.ComponentDataItem, 0, min(len(articles), config.Limit))
+	for _, article := range articles[:min(len(articles), config.Limit)] {
+		items = append(items, model.ComponentDataItem{
+			ID:      article.ID,
+			Title:   article.Title,
+			URL:     article.URL,
+			Excerpt: article.Summary,
+			Date:    &article.PublishedAt,
+			Tags:    article.Categories,
+		})
+	}
+	return items, nil
+}
+
+func (cdu *componentDataUsecase) qiitaTrending(userId uint, config model.DataSourceConfig) ([]model.ComponentDataItem, error) {
+	articles, err := cdu.qu.GetQiitaArticles(userId)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(articles, func(i, j int) bool { return articles[i].LikesCount > articles[j].LikesCount })
+	items := make([]model.ComponentDataItem, 0, min(len(articles), config.Limit))
+	for _, article := range articles[:min(len(articles), config.Limit)] {
+		tags := make([]string, len(article.Tags))
+		for i, tag := range article.Tags {
+			tags[i] = tag.Name
+		}
+		items = append(items, model.ComponentDataItem{
+			ID:    article.ID,
+			Title: article.Title,
+			URL:   article.URL,
+			Date:  &article.CreatedAt,
+			Tags:  tags,
+		})
+	}
+	return items, nil
+}
+
+func (cdu *componentDataUsecase) bookshelf(userId uint, config model.DataSourceConfig) ([]model.ComponentDataItem, error) {
+	books, err := cdu.bu.GetAllBooks(userId)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]model.ComponentDataItem, 0, min(len(books), config.Limit))
+	for _, book := range books[:min(len(books), config.Limit)] {
+		items = append(items, model.ComponentDataItem{
+			ID:       strconv.FormatUint(uint64(book.ID), 10),
+			Title:    book.Title,
+			Excerpt:  book.Author,
+			ImageURL: book.ImageURL,
+		})
+	}
+	return items, nil
+}
+
+// componentDataCache はコンポーネントに表示するデータを一定期間保持する、スレッドセーフなキャッシュです
+type componentDataCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]componentDataEntry
+}
+
+type componentDataEntry struct {
+	response  model.ComponentDataResponse
+	expiresAt time.Time
+}
+
+func newComponentDataCache(ttl time.Duration) *componentDataCache {
+	return &componentDataCache{ttl: ttl, entries: map[string]componentDataEntry{}}
+}
+
+func (c *componentDataCache) get(key string) (model.ComponentDataResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !time.Now().Before(entry.expiresAt) {
+		return model.ComponentDataResponse{}, false
+	}
+	return entry.response, true
+}
+
+// put は結果を保存し、期限が切れた結果を取り除きます
+func (c *componentDataCache) put(key string, response model.ComponentDataResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = componentDataEntry{response: response, expiresAt: now.Add(c.ttl)}
+}
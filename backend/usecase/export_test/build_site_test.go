@@ -0,0 +1,191 @@
+package export_test
+
+import (
+	"bytes"
+	"fmt"
+	"go-react-app/testutils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportUsecase_BuildSite(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("公開済みの記事をレイアウトに当てはめて書き出す", func(t *testing.T) {
+			setupExportUsecaseTest(t)
+			article := createExportArticle(t, "Goの基本", "# はじめに\n\n本文です", "Go, Web", true)
+			createExportArticle(t, "下書き", "非公開", "Go", false)
+			dir := filepath.Join(t.TempDir(), "site")
+
+			result, err := exportUsecase.BuildSite(exportTestUser.ID, exportLayout.ID, "", "https://blog.example.com/", dir, false)
+			if err != nil {
+				t.Fatalf("BuildSite() error = %v", err)
+			}
+			if result.Articles != 1 || result.Rendered != 1 {
+				t.Errorf("BuildSite() result = %+v, want 1 article rendered", result)
+			}
+
+			page := readSiteFile(t, dir, fmt.Sprintf("articles/%d/index.html", article.ID))
+			for _, want := range []string{
+				"<title>Goの基本 - gopher</title>",
+				"<h1>gopherのブログ</h1>",
+				`<main class="lc lc-article-card lc-main" style="--lc-col:1;--lc-span:12;--lc-row:3;--lc-rows:10;--lc-order:1">`,
+				"本文です",
+				`href="../../tags/Go/"`,
+				`href="../../assets/style.css"`,
+				fmt.Sprintf(`<link rel="canonical" href="https://blog.example.com/articles/%d/">`, article.ID),
+			} {
+				if !strings.Contains(page, want) {
+					t.Errorf("記事ページに %q が含まれていません:\n%s", want, page)
+				}
+			}
+			if strings.Contains(page, "<script>") {
+				t.Errorf("コンポーネントのスクリプトが除去されていません")
+			}
+			// コンポーネントはY座標の順に並べ、本文は記事カードの位置に差し込む
+			if strings.Index(page, "gopherのブログ") > strings.Index(page, "<p>本文です</p>") || strings.Index(page, "<p>本文です</p>") > strings.Index(page, "© gopher") {
+				t.Errorf("コンポーネントの並び順が正しくありません:\n%s", page)
+			}
+
+			index := readSiteFile(t, dir, "index.html")
+			if !strings.Contains(index, fmt.Sprintf(`href="./articles/%d/"`, article.ID)) || strings.Contains(index, "下書き") {
+				t.Errorf("トップページの記事一覧が正しくありません:\n%s", index)
+			}
+			if tag := readSiteFile(t, dir, "tags/Web/index.html"); !strings.Contains(tag, "Goの基本") {
+				t.Errorf("タグページに記事が含まれていません:\n%s", tag)
+			}
+			if feed := readSiteFile(t, dir, "feed.atom"); !strings.Contains(feed, fmt.Sprintf("https://blog.example.com/articles/%d/", article.ID)) {
+				t.Errorf("フィードに記事のURLが含まれていません:\n%s", feed)
+			}
+			for _, name := range []string{"feed.rss", "feed.json", "sitemap.xml", "robots.txt", "assets/style.css"} {
+				readSiteFile(t, dir, name)
+			}
+		})
+
+		t.Run("ディレクトリ名にできないタグのページはハッシュ値の名前で書き出す", func(t *testing.T) {
+			setupExportUsecaseTest(t)
+			createExportArticle(t, "ドットのタグ", "本文", "..", true)
+			dir := filepath.Join(t.TempDir(), "site")
+
+			if _, err := exportUsecase.BuildSite(exportTestUser.ID, exportLayout.ID, "", "https://blog.example.com", dir, false); err != nil {
+				t.Fatalf("BuildSite() error = %v", err)
+			}
+			matches, _ := filepath.Glob(filepath.Join(dir, "tags", "tag-*", "index.html"))
+			if len(matches) != 1 {
+				t.Fatalf("タグのページ = %v, want 1 page", matches)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "tags", "index.html")); !os.IsNotExist(err) {
+				t.Errorf("タグのページがtags直下に書き出されています: %v", err)
+			}
+		})
+
+		t.Run("変更された記事のみ生成し直す", func(t *testing.T) {
+			setupExportUsecaseTest(t)
+			first := createExportArticle(t, "記事1", "本文1", "Go", true)
+			second := createExportArticle(t, "記事2", "本文2", "Go", true)
+			dir := filepath.Join(t.TempDir(), "site")
+
+			if _, err := exportUsecase.BuildSite(exportTestUser.ID, exportLayout.ID, "", "https://blog.example.com", dir, false); err != nil {
+				t.Fatalf("BuildSite() error = %v", err)
+			}
+
+			result, err := exportUsecase.BuildSite(exportTestUser.ID, exportLayout.ID, "", "https://blog.example.com", dir, false)
+			if err != nil {
+				t.Fatalf("BuildSite() error = %v", err)
+			}
+			if result.Rendered != 0 || result.Skipped != 2 {
+				t.Errorf("変更がない場合 result = %+v, want rendered 0, skipped 2", result)
+			}
+
+			exportDb.Model(&first).Update("content", "更新後の本文")
+			exportDb.Model(&second).Update("published", false)
+			result, err = exportUsecase.BuildSite(exportTestUser.ID, exportLayout.ID, "", "https://blog.example.com", dir, false)
+			if err != nil {
+				t.Fatalf("BuildSite() error = %v", err)
+			}
+			if result.Rendered != 1 || result.Skipped != 0 || result.Removed != 1 {
+				t.Errorf("記事の更新後 result = %+v, want rendered 1, removed 1", result)
+			}
+			if page := readSiteFile(t, dir, fmt.Sprintf("articles/%d/index.html", first.ID)); !strings.Contains(page, "更新後の本文") {
+				t.Errorf("更新した記事が反映されていません")
+			}
+			if _, err := os.Stat(filepath.Join(dir, "articles", fmt.Sprint(second.ID))); !os.IsNotExist(err) {
+				t.Errorf("非公開にした記事のページが残っています")
+			}
+
+			result, err = exportUsecase.BuildSite(exportTestUser.ID, exportLayout.ID, "", "https://blog.example.com", dir, true)
+			if err != nil {
+				t.Fatalf("BuildSite() error = %v", err)
+			}
+			if result.Rendered != 1 || result.Skipped != 0 {
+				t.Errorf("fullの場合 result = %+v, want rendered 1", result)
+			}
+		})
+
+		t.Run("レイアウトが変更された場合はすべて生成し直す", func(t *testing.T) {
+			setupExportUsecaseTest(t)
+			createExportArticle(t, "記事1", "本文1", "", true)
+			createExportArticle(t, "記事2", "本文2", "", true)
+			dir := filepath.Join(t.TempDir(), "site")
+
+			if _, err := exportUsecase.BuildSite(exportTestUser.ID, exportLayout.ID, "", "https://blog.example.com", dir, false); err != nil {
+				t.Fatalf("BuildSite() error = %v", err)
+			}
+			exportDb.Exec("UPDATE layout_components SET content = ?, updated_at = ? WHERE type = ?", "<h1>新しいタイトル</h1>", "2099-01-01 00:00:00", "header")
+
+			result, err := exportUsecase.BuildSite(exportTestUser.ID, exportLayout.ID, "", "https://blog.example.com", dir, false)
+			if err != nil {
+				t.Fatalf("BuildSite() error = %v", err)
+			}
+			if result.Rendered != 2 || result.Skipped != 0 {
+				t.Errorf("BuildSite() result = %+v, want rendered 2", result)
+			}
+		})
+
+		t.Run("記事から参照されている画像をサイトに含める", func(t *testing.T) {
+			setupExportUsecaseTest(t)
+			key := fmt.Sprintf("%d/0a1b2c3d.png", exportTestUser.ID)
+			if err := exportStorage.Put(key, bytes.NewReader([]byte("png")), "image/png"); err != nil {
+				t.Fatalf("テスト画像の保存に失敗しました: %v", err)
+			}
+			article := createExportArticle(t, "画像付き", "![図](/uploads/"+key+")", "", true)
+			dir := filepath.Join(t.TempDir(), "site")
+
+			if _, err := exportUsecase.BuildSite(exportTestUser.ID, exportLayout.ID, "", "https://blog.example.com", dir, false); err != nil {
+				t.Fatalf("BuildSite() error = %v", err)
+			}
+			if got := readSiteFile(t, dir, "uploads/"+key); got != "png" {
+				t.Errorf("画像の内容 = %q, want %q", got, "png")
+			}
+			page := readSiteFile(t, dir, fmt.Sprintf("articles/%d/index.html", article.ID))
+			if !strings.Contains(page, `src="../../uploads/`+key+`"`) {
+				t.Errorf("画像のURLがサイト内の相対パスになっていません:\n%s", page)
+			}
+			if feed := readSiteFile(t, dir, "feed.atom"); !strings.Contains(feed, "https://blog.example.com/uploads/"+key) {
+				t.Errorf("フィードの画像のURLが絶対URLになっていません")
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("他のユーザーのレイアウトは使用できない", func(t *testing.T) {
+			setupExportUsecaseTest(t)
+			otherUser := testutils.CreateOtherUser(exportDb)
+
+			_, err := exportUsecase.BuildSite(otherUser.ID, exportLayout.ID, "", "https://blog.example.com", t.TempDir(), false)
+			if err == nil {
+				t.Error("BuildSite() error = nil, want error")
+			}
+		})
+
+		t.Run("公開先のURLが空の場合はエラー", func(t *testing.T) {
+			setupExportUsecaseTest(t)
+
+			_, err := exportUsecase.BuildSite(exportTestUser.ID, exportLayout.ID, "", "", t.TempDir(), false)
+			if err == nil {
+				t.Error("BuildSite() error = nil, want error")
+			}
+		})
+	})
+}
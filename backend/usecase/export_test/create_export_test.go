@@ -0,0 +1,180 @@
+package export_test
+
+import (
+	"archive/zip"
+	"errors"
+	"go-react-app/model"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"testing"
+)
+
+func TestExportUsecase_CreateExport(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("書き出しが完了しZIPファイルを取得できる", func(t *testing.T) {
+			setupExportUsecaseTest(t)
+			createExportArticle(t, "記事1", "本文1", "Go", true)
+
+			job, err := exportUsecase.CreateExport(model.ExportJobRequest{
+				LayoutId: exportLayout.ID,
+				BaseURL:  "https://blog.example.com",
+				UserId:   exportTestUser.ID,
+			})
+			if err != nil {
+				t.Fatalf("CreateExport() error = %v", err)
+			}
+			if job.Status != model.ExportStatusCompleted || job.Articles != 1 || job.Rendered != 1 {
+				t.Fatalf("CreateExport() = %+v, want completed job", job)
+			}
+			if job.DownloadURL == "" || job.StartedAt == nil || job.FinishedAt == nil {
+				t.Errorf("CreateExport() = %+v, want download url and timestamps", job)
+			}
+
+			path, err := exportUsecase.GetExportArchive(exportTestUser.ID, job.ID)
+			if err != nil {
+				t.Fatalf("GetExportArchive() error = %v", err)
+			}
+			archive, err := zip.OpenReader(path)
+			if err != nil {
+				t.Fatalf("ZIPファイルを開けません: %v", err)
+			}
+			defer archive.Close()
+			names := map[string]bool{}
+			for _, file := range archive.File {
+				names[file.Name] = true
+			}
+			if !names["index.html"] || !names["feed.atom"] || !names["tags/Go/index.html"] {
+				t.Errorf("ZIPファイルの内容が不足しています: %v", names)
+			}
+			if names[".manifest.json"] {
+				t.Errorf("ZIPファイルにマニフェストが含まれています")
+			}
+
+			// 2回目の書き出しは前回のビルド結果を利用する
+			second, err := exportUsecase.CreateExport(model.ExportJobRequest{
+				LayoutId: exportLayout.ID,
+				BaseURL:  "https://blog.example.com",
+				UserId:   exportTestUser.ID,
+			})
+			if err != nil {
+				t.Fatalf("CreateExport() error = %v", err)
+			}
+			if second.Rendered != 0 || second.Skipped != 1 {
+				t.Errorf("2回目の書き出し = %+v, want skipped 1", second)
+			}
+
+			jobs, err := exportUsecase.GetAllExports(exportTestUser.ID)
+			if err != nil || len(jobs) != 2 || jobs[0].ID != second.ID {
+				t.Errorf("GetAllExports() = %+v, err = %v", jobs, err)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("公開先のURLが不正な場合はエラー", func(t *testing.T) {
+			setupExportUsecaseTest(t)
+
+			_, err := exportUsecase.CreateExport(model.ExportJobRequest{
+				LayoutId: exportLayout.ID,
+				BaseURL:  "ftp://blog.example.com",
+				UserId:   exportTestUser.ID,
+			})
+			if !errors.Is(err, usecase.ErrInvalidExport) {
+				t.Errorf("CreateExport() error = %v, want ErrInvalidExport", err)
+			}
+		})
+
+		t.Run("他のユーザーのレイアウトは指定できない", func(t *testing.T) {
+			setupExportUsecaseTest(t)
+			otherUser := testutils.CreateOtherUser(exportDb)
+
+			_, err := exportUsecase.CreateExport(model.ExportJobRequest{
+				LayoutId: exportLayout.ID,
+				BaseURL:  "https://blog.example.com",
+				UserId:   otherUser.ID,
+			})
+			if !errors.Is(err, usecase.ErrInvalidExport) {
+				t.Errorf("CreateExport() error = %v, want ErrInvalidExport", err)
+			}
+		})
+
+		t.Run("完了していないジョブのZIPファイルは取得できない", func(t *testing.T) {
+			setupExportUsecaseTest(t)
+			job := model.ExportJob{Status: model.ExportStatusPending, BaseURL: "https://blog.example.com", LayoutId: exportLayout.ID, UserId: exportTestUser.ID}
+			exportDb.Create(&job)
+
+			_, err := exportUsecase.GetExportArchive(exportTestUser.ID, job.ID)
+			if !errors.Is(err, usecase.ErrExportNotReady) {
+				t.Errorf("GetExportArchive() error = %v, want ErrExportNotReady", err)
+			}
+		})
+
+		t.Run("実行中のジョブがある場合は新しいジョブを登録しない", func(t *testing.T) {
+			setupExportUsecaseTest(t)
+			running := model.ExportJob{Status: model.ExportStatusRunning, BaseURL: "https://blog.example.com", LayoutId: exportLayout.ID, UserId: exportTestUser.ID}
+			exportDb.Create(&running)
+
+			_, err := exportUsecase.CreateExport(model.ExportJobRequest{
+				LayoutId: exportLayout.ID,
+				BaseURL:  "https://blog.example.com",
+				UserId:   exportTestUser.ID,
+			})
+			if !errors.Is(err, usecase.ErrExportInProgress) {
+				t.Errorf("CreateExport() error = %v, want ErrExportInProgress", err)
+			}
+
+			var count int64
+			exportDb.Model(&model.ExportJob{}).Where("user_id = ?", exportTestUser.ID).Count(&count)
+			if count != 1 {
+				t.Errorf("export jobs = %d, want 1", count)
+			}
+		})
+	})
+}
+
+func TestExportUsecase_FailInterruptedExports(t *testing.T) {
+	setupExportUsecaseTest(t)
+	pending := model.ExportJob{Status: model.ExportStatusPending, BaseURL: "https://blog.example.com", LayoutId: exportLayout.ID, UserId: exportTestUser.ID}
+	running := model.ExportJob{Status: model.ExportStatusRunning, BaseURL: "https://blog.example.com", LayoutId: exportLayout.ID, UserId: exportTestUser.ID}
+	completed := model.ExportJob{Status: model.ExportStatusCompleted, BaseURL: "https://blog.example.com", LayoutId: exportLayout.ID, UserId: exportTestUser.ID}
+	exportDb.Create(&pending)
+	exportDb.Create(&running)
+	exportDb.Create(&completed)
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("待機中・実行中のジョブを失敗にする", func(t *testing.T) {
+			count, err := exportUsecase.FailInterruptedExports()
+			if err != nil {
+				t.Fatalf("FailInterruptedExports() error = %v", err)
+			}
+			if count != 2 {
+				t.Errorf("FailInterruptedExports() = %d, want 2", count)
+			}
+
+			for _, id := range []uint{pending.ID, running.ID} {
+				job, _ := exportUsecase.GetExportById(exportTestUser.ID, id)
+				if job.Status != model.ExportStatusFailed || job.Error == "" || job.FinishedAt == nil {
+					t.Errorf("job %d = %+v, want failed", id, job)
+				}
+			}
+			job, _ := exportUsecase.GetExportById(exportTestUser.ID, completed.ID)
+			if job.Status != model.ExportStatusCompleted {
+				t.Errorf("completed job status = %s, want completed", job.Status)
+			}
+		})
+
+		t.Run("失敗にした後は新しいジョブを登録できる", func(t *testing.T) {
+			res, err := exportUsecase.CreateExport(model.ExportJobRequest{
+				LayoutId: exportLayout.ID,
+				BaseURL:  "https://blog.example.com",
+				UserId:   exportTestUser.ID,
+			})
+			if err != nil {
+				t.Fatalf("CreateExport() error = %v", err)
+			}
+			if res.Status != model.ExportStatusCompleted {
+				t.Errorf("CreateExport() status = %s, want completed", res.Status)
+			}
+		})
+	})
+}
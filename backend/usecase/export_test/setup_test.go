@@ -0,0 +1,86 @@
+package export_test
+
+import (
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/utils/layoutrender"
+	"go-react-app/utils/markdown"
+	"go-react-app/utils/staticsite"
+	"go-react-app/utils/storage"
+	"go-react-app/validator"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// テスト用の共通変数
+var (
+	exportDb       *gorm.DB
+	exportUsecase  usecase.IExportUsecase
+	exportStorage  storage.IStorage
+	exportDir      string
+	exportTestUser model.User
+	exportLayout   model.Layout
+)
+
+// テスト前の共通セットアップ
+// 書き出し先とメディアの保存先はテストごとに一時ディレクトリを使用する
+func setupExportUsecaseTest(t *testing.T) {
+	if exportDb != nil {
+		testutils.CleanupTestDB(exportDb)
+		exportDb.Exec("DELETE FROM articles")
+		exportDb.Exec("DELETE FROM layout_components")
+		exportDb.Exec("DELETE FROM layouts")
+		exportDb.Exec("DELETE FROM export_jobs")
+	} else {
+		exportDb = testutils.SetupTestDB()
+	}
+
+	tempDir := t.TempDir()
+	exportDir = filepath.Join(tempDir, "exports")
+	exportStorage = storage.NewLocalStorage(filepath.Join(tempDir, "uploads"), "/uploads")
+	builder := staticsite.NewBuilder(markdown.NewRenderer(markdown.DefaultCacheSize), layoutrender.NewSiteRenderer(layoutrender.NewRenderer()), exportStorage, "/uploads")
+	exportUsecase = usecase.NewExportUsecase(
+		repository.NewExportRepository(exportDb),
+		validator.NewExportValidator(),
+		repository.NewUserRepository(exportDb),
+		repository.NewArticleRepository(exportDb),
+		repository.NewLayoutRepository(exportDb),
+		builder,
+		usecase.ExportConfig{Dir: exportDir, Synchronous: true},
+	)
+
+	exportTestUser = testutils.CreateTestUser(exportDb)
+	exportDb.Model(&exportTestUser).Update("username", "gopher")
+
+	exportLayout = model.Layout{Title: "ブログ", UserId: exportTestUser.ID}
+	exportDb.Create(&exportLayout)
+	components := []model.LayoutComponent{
+		{Name: "ヘッダー", Type: "header", Content: "<h1>gopherのブログ</h1><script>alert(1)</script>", Y: 0, Width: 12, Height: 2, UserId: exportTestUser.ID, LayoutId: &exportLayout.ID},
+		{Name: "本文", Type: "main", Y: 2, Width: 12, Height: 10, UserId: exportTestUser.ID, LayoutId: &exportLayout.ID},
+		{Name: "フッター", Type: "footer", Content: "<p>© gopher</p>", Y: 12, Width: 12, Height: 2, UserId: exportTestUser.ID, LayoutId: &exportLayout.ID},
+	}
+	exportDb.Create(&components)
+}
+
+// テスト用の記事を作成するヘルパー関数
+func createExportArticle(t *testing.T, title string, content string, tags string, published bool) model.Article {
+	article := model.Article{Title: title, Content: content, Tags: tags, Published: published, UserId: exportTestUser.ID}
+	if err := exportDb.Create(&article).Error; err != nil {
+		t.Fatalf("テスト記事の作成に失敗しました: %v", err)
+	}
+	return article
+}
+
+// 書き出したファイルの内容を読み込むヘルパー関数
+func readSiteFile(t *testing.T, dir string, name string) string {
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatalf("%sの読み込みに失敗しました: %v", name, err)
+	}
+	return string(data)
+}
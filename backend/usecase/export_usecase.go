@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/utils/staticsite"
+	"go-react-app/validator"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidExport は書き出しのリクエストが不正な場合のエラー
+	ErrInvalidExport = errors.New("invalid export request")
+	// ErrExportNotReady は書き出しが完了していないジョブのファイルを取得しようとした場合のエラー
+	ErrExportNotReady = errors.New("export is not ready")
+	// ErrExportInProgress はユーザーの書き出しジョブが待機中または実行中の場合のエラー
+	ErrExportInProgress = errors.New("export is already in progress")
+)
+
+// ExportConfig は静的サイトの書き出しに関する設定
+type ExportConfig struct {
+	Dir         string // ユーザーごとのビルド結果とZIPファイルを保存するディレクトリ
+	Synchronous bool   // trueの場合はジョブをリクエスト内で実行する（テスト用）
+}
+
+type IExportUsecase interface {
+	GetAllExports(userId uint) ([]model.ExportJobResponse, error)
+	GetExportById(userId uint, exportId uint) (model.ExportJobResponse, error)
+	CreateExport(request model.ExportJobRequest) (model.ExportJobResponse, error)
+	GetExportArchive(userId uint, exportId uint) (string, error)
+	BuildSite(userId uint, layoutId uint, title string, baseURL string, dir string, full bool) (staticsite.Result, error)
+	FailInterruptedExports() (int64, error)
+}
+
+type exportUsecase struct {
+	er  repository.IExportRepository
+	ev  validator.IExportValidator
+	ur  repository.IUserRepository
+	ar  repository.IArticleRepository
+	lr  repository.ILayoutRepository
+	sb  *staticsite.Builder
+	cfg ExportConfig
+	mu  sync.Mutex // 同じユーザーのジョブを同時に登録しないよう、確認と登録をまとめて行う
+}
+
+func NewExportUsecase(er repository.IExportRepository, ev validator.IExportValidator, ur repository.IUserRepository, ar repository.IArticleRepository, lr repository.ILayoutRepository, sb *staticsite.Builder, cfg ExportConfig) IExportUsecase {
+	return &exportUsecase{er: er, ev: ev, ur: ur, ar: ar, lr: lr, sb: sb, cfg: cfg}
+}
+
+func (eu *exportUsecase) GetAllExports(userId uint) ([]model.ExportJobResponse, error) {
+	jobs, err := eu.er.GetAllExports(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	resJobs := make([]model.ExportJobResponse, len(jobs))
+	for i, job := range jobs {
+		resJobs[i] = job.ToResponse()
+	}
+	return resJobs, nil
+}
+
+func (eu *exportUsecase) GetExportById(userId uint, exportId uint) (model.ExportJobResponse, error) {
+	job, err := eu.er.GetExportById(userId, exportId)
+	if err != nil {
+		return model.ExportJobResponse{}, err
+	}
+	return job.ToResponse(), nil
+}
+
+// CreateExport は書き出しジョブを登録し、バックグラウンドで実行します
+// ジョブの進捗はGetExportByIdで確認できます
+// ユーザーごとのビルド結果を共有するため、待機中または実行中のジョブがある場合はErrExportInProgressを返す
+func (eu *exportUsecase) CreateExport(request model.ExportJobRequest) (model.ExportJobResponse, error) {
+	if err := eu.ev.ValidateExportRequest(request); err != nil {
+		return model.ExportJobResponse{}, fmt.Errorf("%w: %v", ErrInvalidExport, err)
+	}
+	// 他のユーザーのレイアウトを指定できないよう、ジョブの登録前に所有者を確認する
+	if _, err := eu.lr.GetLayoutById(request.UserId, request.LayoutId); err != nil {
+		return model.ExportJobResponse{}, fmt.Errorf("%w: レイアウトが見つかりません", ErrInvalidExport)
+	}
+
+	job := model.ExportJob{
+		Status:   model.ExportStatusPending,
+		Title:    request.Title,
+		BaseURL:  request.BaseURL,
+		Full:     request.Full,
+		LayoutId: request.LayoutId,
+		UserId:   request.UserId,
+	}
+	if err := eu.createJob(&job); err != nil {
+		return model.ExportJobResponse{}, err
+	}
+
+	if eu.cfg.Synchronous {
+		eu.runExport(job)
+		if updated, err := eu.er.GetExportById(job.UserId, job.ID); err == nil {
+			job = updated
+		}
+	} else {
+		go eu.runExport(job)
+	}
+	return job.ToResponse(), nil
+}
+
+// GetExportArchive は完了したジョブのZIPファイルのパスを返します
+func (eu *exportUsecase) GetExportArchive(userId uint, exportId uint) (string, error) {
+	job, err := eu.er.GetExportById(userId, exportId)
+	if err != nil {
+		return "", err
+	}
+	if job.Status != model.ExportStatusCompleted || job.FilePath == "" {
+		return "", ErrExportNotReady
+	}
+	if _, err := os.Stat(job.FilePath); err != nil {
+		return "", ErrExportNotReady
+	}
+	return job.FilePath, nil
+}
+
+// BuildSite はユーザーの公開済み記事をレイアウトに当てはめ、静的サイトとしてdirに書き出します
+// fullがfalseの場合は、前回dirに書き出した内容からUpdatedAtが変わった記事のみ生成し直します
+// レイアウトに公開中のスナップショットがある場合は、編集中の状態ではなくスナップショットの状態で描画します
+func (eu *exportUsecase) BuildSite(userId uint, layoutId uint, title string, baseURL string, dir string, full bool) (staticsite.Result, error) {
+	user, err := eu.ur.GetUserById(userId)
+	if err != nil {
+		return staticsite.Result{}, err
+	}
+	layout, err := eu.lr.GetPublishedLayout(userId, layoutId)
+	if err != nil {
+		return staticsite.Result{}, err
+	}
+	articles := []model.Article{}
+	if err := eu.ar.GetPublishedArticles(&articles, userId, "", 0); err != nil {
+		return staticsite.Result{}, err
+	}
+
+	author := user.Username
+	if author == "" {
+		author = user.Email
+	}
+	if title == "" {
+		title = author
+	}
+	return eu.sb.Build(staticsite.Site{
+		Title:    title,
+		Author:   author,
+		BaseURL:  baseURL,
+		Layout:   layout,
+		Articles: articles,
+	}, dir, full)
+}
+
+// runExport はジョブを実行し、結果をジョブに記録します
+// ビルド結果はユーザーごとのディレクトリに残し、次回のジョブでインクリメンタルビルドに利用します
+func (eu *exportUsecase) runExport(job model.ExportJob) {
+	started := time.Now()
+	job.Status = model.ExportStatusRunning
+	job.StartedAt = &started
+	eu.saveJob(&job)
+
+	userDir := filepath.Join(eu.cfg.Dir, strconv.FormatUint(uint64(job.UserId), 10))
+	archive := filepath.Join(userDir, fmt.Sprintf("export-%d.zip", job.ID))
+	result, err := eu.BuildSite(job.UserId, job.LayoutId, job.Title, job.BaseURL, filepath.Join(userDir, "site"), job.Full)
+	if err == nil {
+		err = writeArchive(filepath.Join(userDir, "site"), archive)
+	}
+
+	finished := time.Now()
+	job.FinishedAt = &finished
+	if err != nil {
+		job.Status = model.ExportStatusFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = model.ExportStatusCompleted
+		job.Articles = result.Articles
+		job.Rendered = result.Rendered
+		job.Skipped = result.Skipped
+		job.Removed = result.Removed
+		job.FilePath = archive
+	}
+	eu.saveJob(&job)
+}
+
+// createJob はユーザーに待機中または実行中のジョブがないことを確認してからジョブを登録します
+func (eu *exportUsecase) createJob(job *model.ExportJob) error {
+	eu.mu.Lock()
+	defer eu.mu.Unlock()
+
+	active, err := eu.er.HasActiveExport(job.UserId)
+	if err != nil {
+		return err
+	}
+	if active {
+		return ErrExportInProgress
+	}
+	return eu.er.CreateExport(job)
+}
+
+// FailInterruptedExports はサーバーの停止により待機中または実行中のまま残ったジョブを失敗にします
+// 起動時に、ジョブを実行する前に呼び出す
+func (eu *exportUsecase) FailInterruptedExports() (int64, error) {
+	return eu.er.FailInterruptedExports("サーバーの再起動により中断されました", time.Now())
+}
+
+func (eu *exportUsecase) saveJob(job *model.ExportJob) {
+	if err := eu.er.UpdateExport(job); err != nil {
+		log.Printf("書き出しジョブ(%d)の更新に失敗しました: %v", job.ID, err)
+	}
+}
+
+// writeArchive はdirの内容をZIPファイルとしてpathに書き出します
+func writeArchive(dir string, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := staticsite.WriteZip(dir, file); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+	return file.Close()
+}
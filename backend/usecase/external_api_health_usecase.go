@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/utils/netguard"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// healthCheckConcurrency 同時に実行するヘルスチェックの最大数
+const healthCheckConcurrency = 4
+
+// maxHealthCheckErrorLength ヘルスチェックの結果に保存する異常の理由の最大文字数
+const maxHealthCheckErrorLength = 500
+
+// ErrExternalAPINotFound は外部APIが見つからない場合のエラー
+var ErrExternalAPINotFound = errors.New("external api not found")
+
+// IExternalAPIHealthUsecase は登録された外部APIのヘルスチェックと稼働状況を扱います
+type IExternalAPIHealthUsecase interface {
+	// GetExternalAPIStatus は直近windowDays日の稼働率と最後の異常を含む外部APIの稼働状況を返します
+	GetExternalAPIStatus(userId uint, apiId uint, windowDays int) (model.ExternalAPIStatusResponse, error)
+	// RunDueHealthChecks は前回から設定した間隔が経過した外部APIのヘルスチェックを実行し、実行した件数を返します
+	// 保存期間を過ぎた結果もあわせて削除する
+	RunDueHealthChecks(now time.Time) (int, error)
+}
+
+type externalAPIHealthUsecase struct {
+	ar repository.IExternalAPIRepository
+	hr repository.IExternalAPIHealthRepository
+	cr repository.IExternalAPICallRepository
+	sr repository.ISecretRepository
+}
+
+// NewExternalAPIHealthUsecase は外部APIのヘルスチェックのユースケースを作成します
+// ヘルスチェックは外部APIの呼び出しと同じく、保存された認証情報を付けてcrで送信する
+func NewExternalAPIHealthUsecase(ar repository.IExternalAPIRepository, hr repository.IExternalAPIHealthRepository, cr repository.IExternalAPICallRepository, sr repository.ISecretRepository) IExternalAPIHealthUsecase {
+	return &externalAPIHealthUsecase{ar, hr, cr, sr}
+}
+
+func (hu *externalAPIHealthUsecase) GetExternalAPIStatus(userId uint, apiId uint, windowDays int) (model.ExternalAPIStatusResponse, error) {
+	api := model.ExternalAPI{}
+	if err := hu.ar.GetExternalAPIById(&api, userId, apiId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ExternalAPIStatusResponse{}, ErrExternalAPINotFound
+		}
+		return model.ExternalAPIStatusResponse{}, err
+	}
+	if windowDays <= 0 {
+		windowDays = model.DefaultStatusWindowDays
+	}
+	windowDays = min(windowDays, model.MaxStatusWindowDays)
+
+	status := model.ExternalAPIStatusResponse{
+		ExternalAPIId: api.ID,
+		Enabled:       api.HealthCheckEnabled(),
+		Status:        model.ExternalAPIStatusUnknown,
+		WindowDays:    windowDays,
+		History:       []model.ExternalAPIHealthCheckResponse{},
+	}
+
+	checks := []model.ExternalAPIHealthCheck{}
+	if err := hu.hr.GetRecentHealthChecks(&checks, api.ID, model.StatusHistoryLimit); err != nil {
+		return model.ExternalAPIStatusResponse{}, err
+	}
+	for _, check := range checks {
+		status.History = append(status.History, check.ToResponse())
+	}
+	if len(checks) > 0 {
+		status.LastCheckedAt = &checks[0].CheckedAt
+		// ヘルスチェックをやめた外部APIは、過去の結果があっても稼働状況を判断しない
+		if status.Enabled {
+			status.Status = model.ExternalAPIStatusDown
+			if checks[0].Up {
+				status.Status = model.ExternalAPIStatusUp
+			}
+		}
+	}
+
+	summary, err := hu.hr.GetHealthSummary(api.ID, time.Now().AddDate(0, 0, -windowDays))
+	if err != nil {
+		return model.ExternalAPIStatusResponse{}, err
+	}
+	status.Checks = summary.Checks
+	if summary.Checks > 0 {
+		uptime := math.Round(float64(summary.UpChecks)*10000/float64(summary.Checks)) / 100
+		latency := int64(math.Round(summary.AvgLatencyMs))
+		status.UptimePercent = &uptime
+		status.AvgLatencyMs = &latency
+	}
+
+	lastFailure := model.ExternalAPIHealthCheck{}
+	err = hu.hr.GetLastFailure(&lastFailure, api.ID)
+	switch {
+	case err == nil:
+		failure := lastFailure.ToResponse()
+		status.LastFailure = &failure
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return model.ExternalAPIStatusResponse{}, err
+	}
+	return status, nil
+}
+
+func (hu *externalAPIHealthUsecase) RunDueHealthChecks(now time.Time) (int, error) {
+	if _, err := hu.hr.DeleteHealthChecksBefore(now.AddDate(0, 0, -model.MaxStatusWindowDays)); err != nil {
+		return 0, err
+	}
+
+	apis := []model.ExternalAPI{}
+	if err := hu.ar.GetHealthCheckTargets(&apis); err != nil {
+		return 0, err
+	}
+	lastChecked, err := hu.hr.GetLastCheckedTimes()
+	if err != nil {
+		return 0, err
+	}
+	var due []model.ExternalAPI
+	for _, api := range apis {
+		last, ok := lastChecked[api.ID]
+		if !ok || now.Sub(last) >= time.Duration(api.HealthCheckInterval())*time.Second {
+			due = append(due, api)
+		}
+	}
+
+	// 応答の遅い外部APIが他のヘルスチェックを待たせないよう、並行して実行する
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, healthCheckConcurrency)
+	for _, api := range due {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(api model.ExternalAPI) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			check := hu.checkExternalAPI(api, now)
+			if err := hu.hr.CreateHealthCheck(&check); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(api)
+	}
+	wg.Wait()
+	return len(due), firstErr
+}
+
+// checkExternalAPI はヘルスチェックのパスをGETで呼び出し、結果を返します
+// 接続できない場合やタイムアウトした場合も、異常の結果として返す
+func (hu *externalAPIHealthUsecase) checkExternalAPI(api model.ExternalAPI, now time.Time) model.ExternalAPIHealthCheck {
+	check := model.ExternalAPIHealthCheck{ExternalAPIId: api.ID, CheckedAt: now}
+	fail := func(format string, args ...any) model.ExternalAPIHealthCheck {
+		message := []rune(fmt.Sprintf(format, args...))
+		check.Error = string(message[:min(len(message), maxHealthCheckErrorLength)])
+		return check
+	}
+
+	target, err := buildHealthCheckURL(api)
+	if err != nil {
+		return fail("invalid health check url: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(api.Timeout())*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return fail("invalid health check url: %v", err)
+	}
+	credential, err := resolveCredential(hu.sr, api)
+	if err != nil {
+		return fail("credential is unavailable: %v", err)
+	}
+	setAuthHeader(req, api, credential)
+
+	start := time.Now()
+	response, err := hu.cr.Call(req)
+	check.LatencyMs = time.Since(start).Milliseconds()
+	switch {
+	case errors.Is(err, netguard.ErrBlockedAddress):
+		return fail("destination is not allowed: %v", err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return fail("no response within %d seconds", api.Timeout())
+	case err != nil:
+		return fail("%v", err)
+	}
+
+	check.StatusCode = response.StatusCode
+	check.LatencyMs = response.DurationMs
+	if !api.IsHealthyStatus(response.StatusCode) {
+		return fail("unexpected status %d", response.StatusCode)
+	}
+	check.Up = true
+	return check
+}
+
+// buildHealthCheckURL はベースURLにヘルスチェックのパスを付けたURLを返します
+// ベースURLのクエリパラメータはそのまま残し、外部APIに設定したクエリパラメータは付けない
+func buildHealthCheckURL(api model.ExternalAPI) (string, error) {
+	base, err := url.Parse(api.BaseURL)
+	if err != nil {
+		return "", err
+	}
+	query := base.RawQuery
+	base.RawQuery = ""
+	base.Fragment = ""
+	target := strings.TrimRight(base.String(), "/") + "/" + strings.TrimLeft(api.HealthCheckPath, "/")
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", err
+	}
+	if u.Host != base.Host {
+		return "", fmt.Errorf("health_check_path must not change the host")
+	}
+	u.RawQuery = query
+	return u.String(), nil
+}
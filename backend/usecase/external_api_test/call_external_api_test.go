@@ -0,0 +1,252 @@
+package external_api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 受け取ったリクエストをJSONで返すテスト用のサーバー
+func newEchoServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/text":
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("plain text"))
+			return
+		case "/v1/missing":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`))
+			return
+		case "/v1/slow":
+			time.Sleep(1500 * time.Millisecond)
+		}
+		body, _ := io.ReadAll(r.Body)
+		username, password, _ := r.BasicAuth()
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(w).Encode(map[string]string{
+			"method":        r.Method,
+			"path":          r.URL.EscapedPath(),
+			"query":         r.URL.RawQuery,
+			"api_key":       r.Header.Get("X-Custom-Key"),
+			"authorization": r.Header.Get("Authorization"),
+			"basic":         username + ":" + password,
+			"body":          string(body),
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// 応答の本文（テスト用のサーバーが返したリクエストの内容）を取り出すヘルパー関数
+func echoed(t *testing.T, res model.ExternalAPICallResponse) map[string]string {
+	var received map[string]string
+	if err := json.Unmarshal(res.Body, &received); err != nil {
+		t.Fatalf("応答の本文の解析に失敗しました: %v (text = %q)", err, res.Text)
+	}
+	return received
+}
+
+func TestExternalAPIUsecase_CallExternalAPI(t *testing.T) {
+	setupExternalAPIUsecaseTest()
+	server := newEchoServer(t)
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("パスとクエリパラメータにparamsを埋め込んで呼び出す", func(t *testing.T) {
+			api := createTestExternalAPI(t, model.ExternalAPI{
+				BaseURL:      server.URL + "/v1/",
+				PathTemplate: "/users/{user}/items",
+				QueryParams:  map[string]string{"per_page": "{limit}", "sort": "created"},
+			})
+
+			res, err := externalAPIUsecase.CallExternalAPI(testUser.ID, api.ID, model.ExternalAPICallRequest{
+				Params: map[string]string{"user": "go pher/1", "limit": "5"},
+				Query:  map[string]string{"sort": "likes"},
+			})
+			if err != nil {
+				t.Fatalf("CallExternalAPI() error = %v", err)
+			}
+			received := echoed(t, res)
+			if res.StatusCode != http.StatusOK || received["method"] != "GET" {
+				t.Errorf("CallExternalAPI() = %d %s, want 200 GET", res.StatusCode, received["method"])
+			}
+			if received["path"] != "/v1/users/go%20pher%2F1/items" {
+				t.Errorf("path = %s", received["path"])
+			}
+			if received["query"] != "per_page=5&sort=likes" {
+				t.Errorf("query = %s", received["query"])
+			}
+		})
+
+		t.Run("保存された認証方式で認証情報を送信する", func(t *testing.T) {
+			tests := []struct {
+				name string
+				api  model.ExternalAPI
+				key  string
+				want string
+			}{
+				{"APIキー", model.ExternalAPI{AuthType: model.ExternalAPIAuthAPIKey, AuthHeader: "X-Custom-Key", AuthCredential: "secret"}, "api_key", "secret"},
+				{"Bearer", model.ExternalAPI{AuthType: model.ExternalAPIAuthBearer, AuthCredential: "token"}, "authorization", "Bearer token"},
+				{"Basic", model.ExternalAPI{AuthType: model.ExternalAPIAuthBasic, AuthCredential: "user:pa:ss"}, "basic", "user:pa:ss"},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					tt.api.BaseURL = server.URL
+					api := createTestExternalAPI(t, tt.api)
+					if !api.HasCredential {
+						t.Error("CreateExternalAPI() has_credential = false, want true")
+					}
+
+					res, err := externalAPIUsecase.CallExternalAPI(testUser.ID, api.ID, model.ExternalAPICallRequest{})
+					if err != nil {
+						t.Fatalf("CallExternalAPI() error = %v", err)
+					}
+					if got := echoed(t, res)[tt.key]; got != tt.want {
+						t.Errorf("%s = %q, want %q", tt.key, got, tt.want)
+					}
+				})
+			}
+		})
+
+		t.Run("GET以外のメソッドでは本文を送信する", func(t *testing.T) {
+			api := createTestExternalAPI(t, model.ExternalAPI{BaseURL: server.URL, Method: "POST"})
+
+			res, err := externalAPIUsecase.CallExternalAPI(testUser.ID, api.ID, model.ExternalAPICallRequest{Body: json.RawMessage(`{"title":"Go"}`)})
+			if err != nil {
+				t.Fatalf("CallExternalAPI() error = %v", err)
+			}
+			if received := echoed(t, res); received["method"] != "POST" || received["body"] != `{"title":"Go"}` {
+				t.Errorf("CallExternalAPI() received = %+v", received)
+			}
+		})
+
+		t.Run("外部APIのエラーやJSON以外の応答もそのまま返す", func(t *testing.T) {
+			missing := createTestExternalAPI(t, model.ExternalAPI{BaseURL: server.URL, PathTemplate: "/v1/missing"})
+			res, err := externalAPIUsecase.CallExternalAPI(testUser.ID, missing.ID, model.ExternalAPICallRequest{})
+			if err != nil || res.StatusCode != http.StatusNotFound || string(res.Body) != `{"message":"not found"}` {
+				t.Errorf("CallExternalAPI() = %+v, %v", res, err)
+			}
+
+			text := createTestExternalAPI(t, model.ExternalAPI{BaseURL: server.URL, PathTemplate: "/v1/text"})
+			res, err = externalAPIUsecase.CallExternalAPI(testUser.ID, text.ID, model.ExternalAPICallRequest{})
+			if err != nil || res.Text != "plain text" || res.Body != nil {
+				t.Errorf("CallExternalAPI() = %+v, %v", res, err)
+			}
+		})
+
+		t.Run("認証情報を省略して更新すると保存済みの認証情報を使い続ける", func(t *testing.T) {
+			api := createTestExternalAPI(t, model.ExternalAPI{BaseURL: server.URL, AuthType: model.ExternalAPIAuthBearer, AuthCredential: "token"})
+
+			updated, err := externalAPIUsecase.UpdateExternalAPI(model.ExternalAPI{Name: "更新後", BaseURL: server.URL, AuthType: model.ExternalAPIAuthBearer}, testUser.ID, api.ID)
+			if err != nil {
+				t.Fatalf("UpdateExternalAPI() error = %v", err)
+			}
+			if !updated.HasCredential {
+				t.Error("UpdateExternalAPI() has_credential = false, want true")
+			}
+			res, _ := externalAPIUsecase.CallExternalAPI(testUser.ID, api.ID, model.ExternalAPICallRequest{})
+			if got := echoed(t, res)["authorization"]; got != "Bearer token" {
+				t.Errorf("authorization = %q, want Bearer token", got)
+			}
+		})
+
+		t.Run("認証情報は暗号化した秘密情報として保存し、認証なしに変更すると削除する", func(t *testing.T) {
+			api := createTestExternalAPI(t, model.ExternalAPI{BaseURL: server.URL, AuthType: model.ExternalAPIAuthBearer, AuthCredential: "plain-token"})
+
+			var secret model.Secret
+			if err := externalAPIDb.Where("user_id=? AND name=?", testUser.ID, model.ExternalAPISecretName(api.ID)).First(&secret).Error; err != nil {
+				t.Fatalf("認証情報が秘密情報として保存されていません: %v", err)
+			}
+			if strings.Contains(string(secret.Ciphertext), "plain-token") {
+				t.Error("認証情報が暗号化されていません")
+			}
+
+			updated, err := externalAPIUsecase.UpdateExternalAPI(model.ExternalAPI{Name: "認証なし", BaseURL: server.URL}, testUser.ID, api.ID)
+			if err != nil {
+				t.Fatalf("UpdateExternalAPI() error = %v", err)
+			}
+			if updated.HasCredential {
+				t.Error("UpdateExternalAPI() has_credential = true, want false")
+			}
+			res, _ := externalAPIUsecase.CallExternalAPI(testUser.ID, api.ID, model.ExternalAPICallRequest{})
+			if got := echoed(t, res)["authorization"]; got != "" {
+				t.Errorf("authorization = %q, want empty", got)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("パスの置き換えに必要なparamsがない場合はErrInvalidExternalAPICallを返す", func(t *testing.T) {
+			api := createTestExternalAPI(t, model.ExternalAPI{BaseURL: server.URL, PathTemplate: "/items/{id}"})
+
+			_, err := externalAPIUsecase.CallExternalAPI(testUser.ID, api.ID, model.ExternalAPICallRequest{})
+			if !errors.Is(err, usecase.ErrInvalidExternalAPICall) {
+				t.Errorf("CallExternalAPI() error = %v, want ErrInvalidExternalAPICall", err)
+			}
+		})
+
+		t.Run("内部ネットワークへの呼び出しはErrExternalAPIBlockedを返す", func(t *testing.T) {
+			for _, baseURL := range []string{server.URL, "http://localhost:8080", "http://169.254.169.254/latest", "http://[::ffff:10.0.0.1]"} {
+				api := createTestExternalAPI(t, model.ExternalAPI{BaseURL: baseURL})
+
+				_, err := guardedUsecase.CallExternalAPI(testUser.ID, api.ID, model.ExternalAPICallRequest{})
+				if !errors.Is(err, usecase.ErrExternalAPIBlocked) {
+					t.Errorf("CallExternalAPI(%s) error = %v, want ErrExternalAPIBlocked", baseURL, err)
+				}
+			}
+		})
+
+		t.Run("APIごとのタイムアウトまでに応答がない場合はErrExternalAPITimeoutを返す", func(t *testing.T) {
+			api := createTestExternalAPI(t, model.ExternalAPI{BaseURL: server.URL, PathTemplate: "/v1/slow", TimeoutSeconds: 1})
+
+			_, err := externalAPIUsecase.CallExternalAPI(testUser.ID, api.ID, model.ExternalAPICallRequest{})
+			if !errors.Is(err, usecase.ErrExternalAPITimeout) {
+				t.Errorf("CallExternalAPI() error = %v, want ErrExternalAPITimeout", err)
+			}
+		})
+
+		t.Run("マスターキーが設定されていない場合、認証方式のある外部APIは認証なしで呼び出さずErrSecretStoreDisabledを返す", func(t *testing.T) {
+			disabledUsecase, _ := newSecretDisabledUsecases()
+			withCredential := createTestExternalAPI(t, model.ExternalAPI{BaseURL: server.URL, AuthType: model.ExternalAPIAuthBearer, AuthCredential: "token"})
+			// 認証情報が保存されていなくても、認証方式があれば呼び出さない
+			withoutCredential := createTestExternalAPI(t, model.ExternalAPI{BaseURL: server.URL, AuthType: model.ExternalAPIAuthBearer})
+
+			for _, api := range []model.ExternalAPIResponse{withCredential, withoutCredential} {
+				_, err := disabledUsecase.CallExternalAPI(testUser.ID, api.ID, model.ExternalAPICallRequest{})
+				if !errors.Is(err, usecase.ErrSecretStoreDisabled) {
+					t.Errorf("CallExternalAPI(%d) error = %v, want ErrSecretStoreDisabled", api.ID, err)
+				}
+			}
+
+			// 認証なしの外部APIはそのまま呼び出せる
+			public := createTestExternalAPI(t, model.ExternalAPI{BaseURL: server.URL})
+			if _, err := disabledUsecase.CallExternalAPI(testUser.ID, public.ID, model.ExternalAPICallRequest{}); err != nil {
+				t.Errorf("CallExternalAPI() error = %v, want nil", err)
+			}
+		})
+
+		t.Run("不正な設定の外部APIは登録できない", func(t *testing.T) {
+			for _, api := range []model.ExternalAPI{
+				{Name: "スキームなし", BaseURL: "example.com/api"},
+				{Name: "ファイル", BaseURL: "file:///etc/passwd"},
+				{Name: "メソッド", BaseURL: "https://example.com", Method: "TRACE"},
+				{Name: "ホストを変えるパス", BaseURL: "https://example.com", PathTemplate: "//evil.example.com/x"},
+				{Name: "認証方式", BaseURL: "https://example.com", AuthType: "digest"},
+				{Name: "タイムアウト", BaseURL: "https://example.com", TimeoutSeconds: 120},
+			} {
+				api.UserId = testUser.ID
+				if _, err := externalAPIUsecase.CreateExternalAPI(api); err == nil {
+					t.Errorf("CreateExternalAPI(%s) error = nil, want validation error", api.Name)
+				}
+			}
+		})
+	})
+}
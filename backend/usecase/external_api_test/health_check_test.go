@@ -0,0 +1,207 @@
+package external_api_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ヘルスチェック用のテスト用のサーバー。/downは503を返す
+func newHealthServer(t *testing.T) (*httptest.Server, *[]string) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		if r.URL.Path == "/v1/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &authorizations
+}
+
+// healthChecks は外部APIのヘルスチェックの結果を古い順に返します
+func healthChecks(t *testing.T, apiId uint) []model.ExternalAPIHealthCheck {
+	var checks []model.ExternalAPIHealthCheck
+	if err := externalAPIDb.Where("external_api_id = ?", apiId).Order("checked_at, id").Find(&checks).Error; err != nil {
+		t.Fatalf("ヘルスチェックの結果の取得に失敗しました: %v", err)
+	}
+	return checks
+}
+
+func TestExternalAPIHealthUsecase_RunDueHealthChecks(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("設定した間隔が経過した外部APIのみヘルスチェックする", func(t *testing.T) {
+			setupExternalAPIUsecaseTest()
+			server, authorizations := newHealthServer(t)
+			healthy := createTestExternalAPI(t, model.ExternalAPI{BaseURL: server.URL + "/v1", HealthCheckPath: "/health",
+				AuthType: model.ExternalAPIAuthBearer, AuthCredential: "token"})
+			down := createTestExternalAPI(t, model.ExternalAPI{BaseURL: server.URL + "/v1", HealthCheckPath: "down", HealthCheckIntervalSeconds: 60})
+			expected503 := createTestExternalAPI(t, model.ExternalAPI{BaseURL: server.URL + "/v1", HealthCheckPath: "/down", HealthCheckStatus: 503})
+			unchecked := createTestExternalAPI(t, model.ExternalAPI{BaseURL: server.URL + "/v1"})
+
+			now := time.Now().Truncate(time.Second)
+			if checked, err := healthUsecase.RunDueHealthChecks(now); err != nil || checked != 3 {
+				t.Fatalf("RunDueHealthChecks() = %d, %v, want 3", checked, err)
+			}
+			if got := healthChecks(t, healthy.ID); len(got) != 1 || !got[0].Up || got[0].StatusCode != 200 {
+				t.Errorf("healthy checks = %+v, want a single up check", got)
+			}
+			if got := healthChecks(t, down.ID); len(got) != 1 || got[0].Up || got[0].StatusCode != 503 || got[0].Error != "unexpected status 503" {
+				t.Errorf("down checks = %+v, want a single down check", got)
+			}
+			if got := healthChecks(t, expected503.ID); len(got) != 1 || !got[0].Up {
+				t.Errorf("expected503 checks = %+v, want a single up check", got)
+			}
+			if got := healthChecks(t, unchecked.ID); len(got) != 0 {
+				t.Errorf("unchecked checks = %+v, want none", got)
+			}
+			if !strings.Contains(strings.Join(*authorizations, ","), "Bearer token") {
+				t.Errorf("Authorization headers = %v, want the saved credential", *authorizations)
+			}
+
+			// 間隔が経過していない外部APIはヘルスチェックしない
+			if checked, err := healthUsecase.RunDueHealthChecks(now.Add(30 * time.Second)); err != nil || checked != 0 {
+				t.Errorf("RunDueHealthChecks(+30s) = %d, %v, want 0", checked, err)
+			}
+			if checked, err := healthUsecase.RunDueHealthChecks(now.Add(60 * time.Second)); err != nil || checked != 1 {
+				t.Errorf("RunDueHealthChecks(+60s) = %d, %v, want 1", checked, err)
+			}
+			if checked, err := healthUsecase.RunDueHealthChecks(now.Add(time.Duration(model.DefaultHealthCheckInterval) * time.Second)); err != nil || checked != 3 {
+				t.Errorf("RunDueHealthChecks(+default interval) = %d, %v, want 3", checked, err)
+			}
+		})
+
+		t.Run("保存期間を過ぎた結果を削除する", func(t *testing.T) {
+			setupExternalAPIUsecaseTest()
+			server, _ := newHealthServer(t)
+			api := createTestExternalAPI(t, model.ExternalAPI{BaseURL: server.URL, HealthCheckPath: "/health"})
+			now := time.Now()
+			externalAPIDb.Create(&model.ExternalAPIHealthCheck{ExternalAPIId: api.ID, Up: false, CheckedAt: now.AddDate(0, 0, -model.MaxStatusWindowDays-1)})
+
+			if _, err := healthUsecase.RunDueHealthChecks(now); err != nil {
+				t.Fatalf("RunDueHealthChecks() error = %v", err)
+			}
+			if got := healthChecks(t, api.ID); len(got) != 1 || !got[0].Up {
+				t.Errorf("checks = %+v, want only the new check", got)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("接続できない外部APIは異常として記録する", func(t *testing.T) {
+			setupExternalAPIUsecaseTest()
+			server, _ := newHealthServer(t)
+			blocked := createTestExternalAPI(t, model.ExternalAPI{BaseURL: server.URL, HealthCheckPath: "/health"})
+			closed := httptest.NewServer(http.NotFoundHandler())
+			closed.Close()
+			unavailable := createTestExternalAPI(t, model.ExternalAPI{BaseURL: closed.URL, HealthCheckPath: "/health"})
+
+			// 本番と同じく内部ネットワークへの接続を拒否する
+			if _, err := guardedHealthUsecase.RunDueHealthChecks(time.Now()); err != nil {
+				t.Fatalf("RunDueHealthChecks() error = %v", err)
+			}
+			if got := healthChecks(t, blocked.ID); len(got) != 1 || got[0].Up || !strings.Contains(got[0].Error, "not allowed") {
+				t.Errorf("blocked checks = %+v, want a down check", got)
+			}
+			if got := healthChecks(t, unavailable.ID); len(got) != 1 || got[0].Up || got[0].StatusCode != 0 {
+				t.Errorf("unavailable checks = %+v, want a down check without status", got)
+			}
+		})
+
+		t.Run("マスターキーが設定されていない場合、認証方式のある外部APIは呼び出さずに異常として記録する", func(t *testing.T) {
+			setupExternalAPIUsecaseTest()
+			server, authorizations := newHealthServer(t)
+			api := createTestExternalAPI(t, model.ExternalAPI{BaseURL: server.URL, HealthCheckPath: "/health",
+				AuthType: model.ExternalAPIAuthBearer, AuthCredential: "token"})
+			_, disabledHealthUsecase := newSecretDisabledUsecases()
+
+			if _, err := disabledHealthUsecase.RunDueHealthChecks(time.Now()); err != nil {
+				t.Fatalf("RunDueHealthChecks() error = %v", err)
+			}
+			if got := healthChecks(t, api.ID); len(got) != 1 || got[0].Up || !strings.Contains(got[0].Error, "credential is unavailable") {
+				t.Errorf("checks = %+v, want a down check", got)
+			}
+			if len(*authorizations) != 0 {
+				t.Errorf("requests = %d, want none", len(*authorizations))
+			}
+		})
+	})
+}
+
+func TestExternalAPIHealthUsecase_GetExternalAPIStatus(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("稼働率・平均の応答時間・最後の異常を返す", func(t *testing.T) {
+			setupExternalAPIUsecaseTest()
+			api := createTestExternalAPI(t, model.ExternalAPI{BaseURL: "https://api.example.com", HealthCheckPath: "/health"})
+			now := time.Now()
+			externalAPIDb.Create(&model.ExternalAPIHealthCheck{ExternalAPIId: api.ID, Up: false, StatusCode: 500, Error: "unexpected status 500", CheckedAt: now.AddDate(0, 0, -10)})
+			externalAPIDb.Create(&model.ExternalAPIHealthCheck{ExternalAPIId: api.ID, Up: true, StatusCode: 200, LatencyMs: 100, CheckedAt: now.Add(-3 * time.Hour)})
+			externalAPIDb.Create(&model.ExternalAPIHealthCheck{ExternalAPIId: api.ID, Up: false, StatusCode: 503, LatencyMs: 300, Error: "unexpected status 503", CheckedAt: now.Add(-2 * time.Hour)})
+			externalAPIDb.Create(&model.ExternalAPIHealthCheck{ExternalAPIId: api.ID, Up: true, StatusCode: 200, LatencyMs: 100, CheckedAt: now.Add(-1 * time.Hour)})
+			externalAPIDb.Create(&model.ExternalAPIHealthCheck{ExternalAPIId: api.ID, Up: true, StatusCode: 200, LatencyMs: 100, CheckedAt: now.Add(-30 * time.Minute)})
+
+			status, err := healthUsecase.GetExternalAPIStatus(testUser.ID, api.ID, 0)
+			if err != nil {
+				t.Fatalf("GetExternalAPIStatus() error = %v", err)
+			}
+			if !status.Enabled || status.Status != model.ExternalAPIStatusUp || status.WindowDays != model.DefaultStatusWindowDays {
+				t.Errorf("GetExternalAPIStatus() = %+v, want enabled and up", status)
+			}
+			// 10日前の異常は既定の7日間の集計に含めない
+			if status.Checks != 4 || status.UptimePercent == nil || *status.UptimePercent != 75 || status.AvgLatencyMs == nil || *status.AvgLatencyMs != 150 {
+				t.Errorf("GetExternalAPIStatus() checks = %d, uptime = %v, latency = %v, want 4, 75, 150", status.Checks, status.UptimePercent, status.AvgLatencyMs)
+			}
+			if status.LastFailure == nil || status.LastFailure.StatusCode != 503 {
+				t.Errorf("GetExternalAPIStatus() last failure = %+v, want the 503 check", status.LastFailure)
+			}
+			if len(status.History) != 5 || !status.History[0].CheckedAt.Equal(*status.LastCheckedAt) || status.History[4].StatusCode != 500 {
+				t.Errorf("GetExternalAPIStatus() history = %+v, want 5 checks newest first", status.History)
+			}
+
+			status, err = healthUsecase.GetExternalAPIStatus(testUser.ID, api.ID, 30)
+			if err != nil {
+				t.Fatalf("GetExternalAPIStatus(30) error = %v", err)
+			}
+			if status.Checks != 5 || *status.UptimePercent != 60 {
+				t.Errorf("GetExternalAPIStatus(30) checks = %d, uptime = %v, want 5, 60", status.Checks, *status.UptimePercent)
+			}
+		})
+
+		t.Run("ヘルスチェックが未設定の場合は不明を返す", func(t *testing.T) {
+			setupExternalAPIUsecaseTest()
+			api := createTestExternalAPI(t, model.ExternalAPI{BaseURL: "https://api.example.com"})
+
+			status, err := healthUsecase.GetExternalAPIStatus(testUser.ID, api.ID, 7)
+			if err != nil {
+				t.Fatalf("GetExternalAPIStatus() error = %v", err)
+			}
+			if status.Enabled || status.Status != model.ExternalAPIStatusUnknown || status.UptimePercent != nil || status.LastFailure != nil || len(status.History) != 0 {
+				t.Errorf("GetExternalAPIStatus() = %+v, want unknown status", status)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		setupExternalAPIUsecaseTest()
+		api := createTestExternalAPI(t, model.ExternalAPI{BaseURL: "https://api.example.com", HealthCheckPath: "/health"})
+
+		if _, err := healthUsecase.GetExternalAPIStatus(testUser.ID+1000, api.ID, 7); !errors.Is(err, usecase.ErrExternalAPINotFound) {
+			t.Errorf("GetExternalAPIStatus() error = %v, want ErrExternalAPINotFound", err)
+		}
+
+		for _, path := range []string{"/items/{id}", "https://other.example.com/health", "/health?full=1"} {
+			_, err := externalAPIUsecase.CreateExternalAPI(model.ExternalAPI{Name: "不正", BaseURL: "https://api.example.com", HealthCheckPath: path, UserId: testUser.ID})
+			if err == nil {
+				t.Errorf("CreateExternalAPI(health_check_path=%q) error = nil, want a validation error", path)
+			}
+		}
+	})
+}
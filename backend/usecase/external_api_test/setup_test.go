@@ -0,0 +1,74 @@
+package external_api_test
+
+import (
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/utils/netguard"
+	"go-react-app/validator"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// テスト用の共通変数
+var (
+	externalAPIDb *gorm.DB
+	// テスト用のサーバー（127.0.0.1）に接続するため、内部ネットワークへの接続を許可したユースケース
+	externalAPIUsecase usecase.IExternalAPIUsecase
+	// 本番と同じく内部ネットワークへの接続を拒否するユースケース
+	guardedUsecase usecase.IExternalAPIUsecase
+	// ヘルスチェックのユースケース（内部ネットワークへの接続を許可したものと拒否するもの）
+	healthUsecase        usecase.IExternalAPIHealthUsecase
+	guardedHealthUsecase usecase.IExternalAPIHealthUsecase
+	testUser             model.User
+)
+
+// テスト前の共通セットアップ
+func setupExternalAPIUsecaseTest() {
+	if externalAPIDb != nil {
+		testutils.CleanupTestDB(externalAPIDb)
+		externalAPIDb.Exec("DELETE FROM external_apis")
+		externalAPIDb.Exec("DELETE FROM secrets")
+		externalAPIDb.Exec("DELETE FROM external_api_health_checks")
+	} else {
+		externalAPIDb = testutils.SetupTestDB()
+		externalAPIRepo := repository.NewExternalAPIRepository(externalAPIDb)
+		secretRepo := repository.NewSecretRepository(externalAPIDb, testutils.NewTestKeyring())
+		externalAPIUsecase = usecase.NewExternalAPIUsecase(externalAPIRepo, validator.NewExternalAPIValidator(),
+			repository.NewExternalAPICallRepository(netguard.Guard{AllowPrivate: true}), secretRepo)
+		guardedUsecase = usecase.NewExternalAPIUsecase(externalAPIRepo, validator.NewExternalAPIValidator(),
+			repository.NewExternalAPICallRepository(netguard.Guard{}), secretRepo)
+		healthRepo := repository.NewExternalAPIHealthRepository(externalAPIDb)
+		healthUsecase = usecase.NewExternalAPIHealthUsecase(externalAPIRepo, healthRepo,
+			repository.NewExternalAPICallRepository(netguard.Guard{AllowPrivate: true}), secretRepo)
+		guardedHealthUsecase = usecase.NewExternalAPIHealthUsecase(externalAPIRepo, healthRepo,
+			repository.NewExternalAPICallRepository(netguard.Guard{}), secretRepo)
+	}
+
+	testUser = testutils.CreateTestUser(externalAPIDb)
+}
+
+// マスターキーが設定されていない（秘密情報を復号できない）状態のユースケースを作成するヘルパー関数
+// テスト用のサーバーに接続するため、内部ネットワークへの接続を許可する
+func newSecretDisabledUsecases() (usecase.IExternalAPIUsecase, usecase.IExternalAPIHealthUsecase) {
+	externalAPIRepo := repository.NewExternalAPIRepository(externalAPIDb)
+	callRepo := repository.NewExternalAPICallRepository(netguard.Guard{AllowPrivate: true})
+	secretRepo := repository.NewSecretRepository(externalAPIDb, nil)
+	return usecase.NewExternalAPIUsecase(externalAPIRepo, validator.NewExternalAPIValidator(), callRepo, secretRepo),
+		usecase.NewExternalAPIHealthUsecase(externalAPIRepo, repository.NewExternalAPIHealthRepository(externalAPIDb), callRepo, secretRepo)
+}
+
+// テスト用の外部APIを登録するヘルパー関数
+func createTestExternalAPI(t *testing.T, api model.ExternalAPI) model.ExternalAPIResponse {
+	if api.Name == "" {
+		api.Name = "テストAPI"
+	}
+	api.UserId = testUser.ID
+	res, err := externalAPIUsecase.CreateExternalAPI(api)
+	if err != nil {
+		t.Fatalf("テスト用の外部APIの登録に失敗しました: %v", err)
+	}
+	return res
+}
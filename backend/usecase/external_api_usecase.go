@@ -1,26 +1,58 @@
 package usecase
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"go-react-app/model"
 	"go-react-app/repository"
+	"go-react-app/utils/netguard"
 	"go-react-app/validator"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidExternalAPICall は呼び出しのパラメータが不足・不正な場合のエラー
+	ErrInvalidExternalAPICall = errors.New("invalid external api call")
+	// ErrExternalAPIBlocked は接続先が内部ネットワークなどの許可されていないアドレスの場合のエラー
+	ErrExternalAPIBlocked = errors.New("external api destination is not allowed")
+	// ErrExternalAPITimeout は外部APIがタイムアウトまでに応答しなかった場合のエラー
+	ErrExternalAPITimeout = errors.New("external api timed out")
+	// ErrExternalAPIUnavailable は外部APIに接続できなかった場合のエラー
+	ErrExternalAPIUnavailable = errors.New("external api is unavailable")
 )
 
+// templateParam パスとクエリパラメータの{name}の形式の置き換え箇所
+var templateParam = regexp.MustCompile(`\{([A-Za-z0-9_]+)\}`)
+
 type IExternalAPIUsecase interface {
 	GetAllExternalAPIs(userId uint) ([]model.ExternalAPIResponse, error)
 	GetExternalAPIById(userId uint, apiId uint) (model.ExternalAPIResponse, error)
 	CreateExternalAPI(api model.ExternalAPI) (model.ExternalAPIResponse, error)
 	UpdateExternalAPI(api model.ExternalAPI, userId uint, apiId uint) (model.ExternalAPIResponse, error)
 	DeleteExternalAPI(userId uint, apiId uint) error
+	CallExternalAPI(userId uint, apiId uint, request model.ExternalAPICallRequest) (model.ExternalAPICallResponse, error)
 }
 
 type externalAPIUsecase struct {
 	ar repository.IExternalAPIRepository
 	av validator.IExternalAPIValidator
+	cr repository.IExternalAPICallRepository
+	sr repository.ISecretRepository
 }
 
-func NewExternalAPIUsecase(ar repository.IExternalAPIRepository, av validator.IExternalAPIValidator) IExternalAPIUsecase {
-	return &externalAPIUsecase{ar, av}
+// NewExternalAPIUsecase は外部APIのユースケースを作成します
+// 外部APIの認証情報はsrに秘密情報として暗号化して保存し、呼び出しのたびに復号する
+func NewExternalAPIUsecase(ar repository.IExternalAPIRepository, av validator.IExternalAPIValidator, cr repository.IExternalAPICallRepository, sr repository.ISecretRepository) IExternalAPIUsecase {
+	return &externalAPIUsecase{ar, av, cr, sr}
 }
 
 func (au *externalAPIUsecase) GetAllExternalAPIs(userId uint) ([]model.ExternalAPIResponse, error) {
@@ -28,17 +60,13 @@ func (au *externalAPIUsecase) GetAllExternalAPIs(userId uint) ([]model.ExternalA
 	if err := au.ar.GetAllExternalAPIs(&apis, userId); err != nil {
 		return nil, err
 	}
+	credentials, err := au.credentialNames(userId)
+	if err != nil {
+		return nil, err
+	}
 	resApis := []model.ExternalAPIResponse{}
 	for _, v := range apis {
-		a := model.ExternalAPIResponse{
-			ID:          v.ID,
-			Name:        v.Name,
-			BaseURL:     v.BaseURL,
-			Description: v.Description,
-			CreatedAt:   v.CreatedAt,
-			UpdatedAt:   v.UpdatedAt,
-		}
-		resApis = append(resApis, a)
+		resApis = append(resApis, v.ToResponse(credentials[model.ExternalAPISecretName(v.ID)]))
 	}
 	return resApis, nil
 }
@@ -48,15 +76,7 @@ func (au *externalAPIUsecase) GetExternalAPIById(userId uint, apiId uint) (model
 	if err := au.ar.GetExternalAPIById(&api, userId, apiId); err != nil {
 		return model.ExternalAPIResponse{}, err
 	}
-	resApi := model.ExternalAPIResponse{
-		ID:          api.ID,
-		Name:        api.Name,
-		BaseURL:     api.BaseURL,
-		Description: api.Description,
-		CreatedAt:   api.CreatedAt,
-		UpdatedAt:   api.UpdatedAt,
-	}
-	return resApi, nil
+	return au.toResponse(userId, api)
 }
 
 func (au *externalAPIUsecase) CreateExternalAPI(api model.ExternalAPI) (model.ExternalAPIResponse, error) {
@@ -66,17 +86,18 @@ func (au *externalAPIUsecase) CreateExternalAPI(api model.ExternalAPI) (model.Ex
 	if err := au.ar.CreateExternalAPI(&api); err != nil {
 		return model.ExternalAPIResponse{}, err
 	}
-	resApi := model.ExternalAPIResponse{
-		ID:          api.ID,
-		Name:        api.Name,
-		BaseURL:     api.BaseURL,
-		Description: api.Description,
-		CreatedAt:   api.CreatedAt,
-		UpdatedAt:   api.UpdatedAt,
+	if api.AuthType != model.ExternalAPIAuthNone && api.AuthCredential != "" {
+		if _, err := au.sr.PutSecret(api.UserId, model.ExternalAPISecretName(api.ID), api.AuthCredential); err != nil {
+			// 認証情報を保存できない外部APIを残さない
+			au.ar.DeleteExternalAPI(api.UserId, api.ID)
+			return model.ExternalAPIResponse{}, err
+		}
 	}
-	return resApi, nil
+	return au.toResponse(api.UserId, api)
 }
 
+// UpdateExternalAPI は外部APIを更新します
+// 認証情報は読み出せないため、空の場合は保存済みの値を変更しない。認証なしに変更した場合は削除する
 func (au *externalAPIUsecase) UpdateExternalAPI(api model.ExternalAPI, userId uint, apiId uint) (model.ExternalAPIResponse, error) {
 	if err := au.av.ExternalAPIValidate(api); err != nil {
 		return model.ExternalAPIResponse{}, err
@@ -84,20 +105,188 @@ func (au *externalAPIUsecase) UpdateExternalAPI(api model.ExternalAPI, userId ui
 	if err := au.ar.UpdateExternalAPI(&api, userId, apiId); err != nil {
 		return model.ExternalAPIResponse{}, err
 	}
-	resApi := model.ExternalAPIResponse{
-		ID:          api.ID,
-		Name:        api.Name,
-		BaseURL:     api.BaseURL,
-		Description: api.Description,
-		CreatedAt:   api.CreatedAt,
-		UpdatedAt:   api.UpdatedAt,
+	secretName := model.ExternalAPISecretName(apiId)
+	switch {
+	case api.AuthType == model.ExternalAPIAuthNone:
+		if err := au.sr.DeleteSecret(userId, secretName); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ExternalAPIResponse{}, err
+		}
+	case api.AuthCredential != "":
+		if _, err := au.sr.PutSecret(userId, secretName, api.AuthCredential); err != nil {
+			return model.ExternalAPIResponse{}, err
+		}
 	}
-	return resApi, nil
+	return au.toResponse(userId, api)
 }
 
 func (au *externalAPIUsecase) DeleteExternalAPI(userId uint, apiId uint) error {
 	if err := au.ar.DeleteExternalAPI(userId, apiId); err != nil {
 		return err
 	}
+	if err := au.sr.DeleteSecret(userId, model.ExternalAPISecretName(apiId)); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
 	return nil
 }
+
+// toResponse は認証情報が保存されているかを含めたレスポンスを返します
+func (au *externalAPIUsecase) toResponse(userId uint, api model.ExternalAPI) (model.ExternalAPIResponse, error) {
+	credentials, err := au.credentialNames(userId)
+	if err != nil {
+		return model.ExternalAPIResponse{}, err
+	}
+	return api.ToResponse(credentials[model.ExternalAPISecretName(api.ID)]), nil
+}
+
+// credentialNames はユーザーが保存した秘密情報の名前の集合を返します
+func (au *externalAPIUsecase) credentialNames(userId uint) (map[string]bool, error) {
+	secrets, err := au.sr.GetSecrets(userId)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(secrets))
+	for _, secret := range secrets {
+		names[secret.Name] = true
+	}
+	return names, nil
+}
+
+// CallExternalAPI は登録された外部APIを、保存された認証情報とメソッド・パス・クエリパラメータで呼び出します
+// 外部APIのタイムアウトはAPIごとの設定に従い、内部ネットワークへの接続は拒否する
+func (au *externalAPIUsecase) CallExternalAPI(userId uint, apiId uint, request model.ExternalAPICallRequest) (model.ExternalAPICallResponse, error) {
+	api := model.ExternalAPI{}
+	if err := au.ar.GetExternalAPIById(&api, userId, apiId); err != nil {
+		return model.ExternalAPICallResponse{}, err
+	}
+
+	target, err := buildCallURL(api, request)
+	if err != nil {
+		return model.ExternalAPICallResponse{}, fmt.Errorf("%w: %v", ErrInvalidExternalAPICall, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(api.Timeout())*time.Second)
+	defer cancel()
+
+	method := api.CallMethod()
+	var body io.Reader
+	if len(request.Body) > 0 && method != http.MethodGet {
+		body = bytes.NewReader(request.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return model.ExternalAPICallResponse{}, fmt.Errorf("%w: %v", ErrInvalidExternalAPICall, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	credential, err := resolveCredential(au.sr, api)
+	if err != nil {
+		return model.ExternalAPICallResponse{}, err
+	}
+	setAuthHeader(req, api, credential)
+
+	response, err := au.cr.Call(req)
+	switch {
+	case err == nil:
+		return response, nil
+	case errors.Is(err, netguard.ErrBlockedAddress):
+		return model.ExternalAPICallResponse{}, fmt.Errorf("%w: %v", ErrExternalAPIBlocked, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return model.ExternalAPICallResponse{}, fmt.Errorf("%w: no response within %d seconds", ErrExternalAPITimeout, api.Timeout())
+	default:
+		return model.ExternalAPICallResponse{}, fmt.Errorf("%w: %v", ErrExternalAPIUnavailable, err)
+	}
+}
+
+// buildCallURL はベースURLにパスとクエリパラメータを付けた呼び出し先のURLを返します
+// パスの{name}はパスとしてエスケープした値で置き換える
+func buildCallURL(api model.ExternalAPI, request model.ExternalAPICallRequest) (string, error) {
+	base, err := url.Parse(api.BaseURL)
+	if err != nil {
+		return "", err
+	}
+
+	path, err := expandTemplate(api.PathTemplate, request.Params, url.PathEscape)
+	if err != nil {
+		return "", err
+	}
+	query := base.Query()
+	base.RawQuery = ""
+	base.Fragment = ""
+	target := base.String()
+	if path != "" {
+		target = strings.TrimRight(target, "/") + "/" + strings.TrimLeft(path, "/")
+	}
+	// エスケープ済みのパスを保つため、文字列として連結してから解析し直す
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", err
+	}
+	if u.Host != base.Host {
+		return "", fmt.Errorf("path_template must not change the host")
+	}
+
+	for key, value := range api.QueryParams {
+		expanded, err := expandTemplate(value, request.Params, func(s string) string { return s })
+		if err != nil {
+			return "", err
+		}
+		query.Set(key, expanded)
+	}
+	for key, value := range request.Query {
+		query.Set(key, value)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// expandTemplate は{name}をparamsの値で置き換えます。値が指定されていない置き換え箇所がある場合はエラー
+func expandTemplate(template string, params map[string]string, escape func(string) string) (string, error) {
+	var missing []string
+	expanded := templateParam.ReplaceAllStringFunc(template, func(match string) string {
+		name := match[1 : len(match)-1]
+		value, ok := params[name]
+		if !ok {
+			missing = append(missing, name)
+			return match
+		}
+		return escape(value)
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing params: %s", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+// resolveCredential は外部APIに保存された認証情報を復号して返します
+// 認証方式が設定されているのに秘密情報を復号できない場合は、認証なしで呼び出さずErrSecretStoreDisabledを返す
+func resolveCredential(sr repository.ISecretRepository, api model.ExternalAPI) (string, error) {
+	if api.AuthType == model.ExternalAPIAuthNone {
+		return "", nil
+	}
+	if !sr.Enabled() {
+		return "", ErrSecretStoreDisabled
+	}
+	return sr.ResolveSecret(api.UserId, model.ExternalAPISecretName(api.ID))
+}
+
+// setAuthHeader は外部APIの認証方式に合わせて認証情報をヘッダーに設定します
+func setAuthHeader(req *http.Request, api model.ExternalAPI, credential string) {
+	if credential == "" {
+		return
+	}
+	switch api.AuthType {
+	case model.ExternalAPIAuthAPIKey:
+		header := api.AuthHeader
+		if header == "" {
+			header = model.DefaultExternalAPIAuthHeader
+		}
+		req.Header.Set(header, credential)
+	case model.ExternalAPIAuthBearer:
+		req.Header.Set("Authorization", "Bearer "+credential)
+	case model.ExternalAPIAuthBasic:
+		username, password, _ := strings.Cut(credential, ":")
+		req.SetBasicAuth(username, password)
+	}
+}
@@ -39,6 +39,40 @@ func TestFeedArticleUsecase_GetArticlesByFeedID(t *testing.T) {
 				t.Errorf("GetArticlesByFeedID() response mismatch: got %+v, want %+v", responses[0], expectedFirst)
 			}
 		})
+		
+		t.Run("取得した記事が検索インデックスに保存される", func(t *testing.T) {
+			// テスト実行
+			_, err := feedArticleUc.GetArticlesByFeedID(testUserId, 1)
+			if err != nil {
+				t.Fatalf("GetArticlesByFeedID() error = %v", err)
+			}
+			
+			// 検証
+			for _, article := range testArticles[:2] {
+				doc, ok := mockSearchRepo.documents[model.SearchSourceFeedArticle+":"+article.SearchSourceID()]
+				if !ok {
+					t.Errorf("記事 %s が検索インデックスに保存されていません", article.ID)
+					continue
+				}
+				if doc.Title != article.Title || doc.UserId != testUserId {
+					t.Errorf("検索ドキュメントが期待値と一致しません: got %+v", doc)
+				}
+			}
+		})
+		
+		t.Run("更新間隔内に再度取得しても検索インデックスは更新しない", func(t *testing.T) {
+			mockSearchRepo.upserts = 0
+			
+			// テスト実行
+			if _, err := feedArticleUc.GetArticlesByFeedID(testUserId, 1); err != nil {
+				t.Fatalf("GetArticlesByFeedID() error = %v", err)
+			}
+			
+			// 検証
+			if mockSearchRepo.upserts != 0 {
+				t.Errorf("検索インデックスが %d 件更新されました、want 0", mockSearchRepo.upserts)
+			}
+		})
 	})
 	
 	t.Run("異常系", func(t *testing.T) {
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"go-react-app/model"
 	"go-react-app/usecase"
+	"go-react-app/utils/search"
+	"time"
 )
 
 // モックリポジトリの定義
@@ -51,9 +53,70 @@ func (m *mockFeedArticleRepository) GetAllArticles(userId uint) ([]model.FeedArt
 	return m.allArticles, nil
 }
 
+// モックフィードリポジトリの定義（フィードの最終取得日時のみを扱う）
+type mockFeedRepository struct {
+	lastFetched map[uint]time.Time
+}
+
+func (m *mockFeedRepository) GetAllFeeds(feeds *[]model.Feed, userId uint) error { return nil }
+
+func (m *mockFeedRepository) GetFeedById(feed *model.Feed, userId uint, feedId uint) error {
+	return nil
+}
+
+func (m *mockFeedRepository) CreateFeed(feed *model.Feed) error { return nil }
+
+func (m *mockFeedRepository) UpdateFeed(feed *model.Feed, userId uint, feedId uint) error {
+	return nil
+}
+
+func (m *mockFeedRepository) DeleteFeed(userId uint, feedId uint) error { return nil }
+
+func (m *mockFeedRepository) MarkFeedFetched(userId uint, feedId uint, fetchedAt time.Time, interval time.Duration) (bool, error) {
+	if last, ok := m.lastFetched[feedId]; ok && fetchedAt.Sub(last) < interval {
+		return false, nil
+	}
+	m.lastFetched[feedId] = fetchedAt
+	return true, nil
+}
+
+// モック検索リポジトリの定義（インデックスされたドキュメントと登録回数を記録する）
+type mockSearchRepository struct {
+	documents map[string]model.SearchDocument
+	upserts   int
+}
+
+func (m *mockSearchRepository) UpsertDocument(document *model.SearchDocument) error {
+	m.documents[document.SourceType+":"+document.SourceID] = *document
+	m.upserts++
+	return nil
+}
+
+func (m *mockSearchRepository) UpsertDocuments(documents []model.SearchDocument) error {
+	for i := range documents {
+		m.UpsertDocument(&documents[i])
+	}
+	return nil
+}
+
+func (m *mockSearchRepository) DeleteDocument(userId uint, sourceType string, sourceId string) error {
+	delete(m.documents, sourceType+":"+sourceId)
+	return nil
+}
+
+func (m *mockSearchRepository) DeleteFeedDocuments(userId uint, feedId uint) error {
+	return nil
+}
+
+func (m *mockSearchRepository) SearchDocuments(userId uint, phrases []search.Phrase, limit int) ([]model.SearchDocument, int64, error) {
+	return nil, 0, nil
+}
+
 // テスト用変数
 var (
 	mockRepo         *mockFeedArticleRepository
+	mockFeedRepo     *mockFeedRepository
+	mockSearchRepo   *mockSearchRepository
 	feedArticleUc    usecase.IFeedArticleUsecase
 )
 
@@ -69,5 +132,8 @@ func setupFeedArticleTest() {
 		getUserArticleErr: false,
 	}
 	
-	feedArticleUc = usecase.NewFeedArticleUsecase(mockRepo)
+	mockFeedRepo = &mockFeedRepository{lastFetched: map[uint]time.Time{}}
+	mockSearchRepo = &mockSearchRepository{documents: map[string]model.SearchDocument{}}
+	
+	feedArticleUc = usecase.NewFeedArticleUsecase(mockRepo, mockFeedRepo, mockSearchRepo)
 }
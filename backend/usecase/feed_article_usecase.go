@@ -3,8 +3,14 @@ package usecase
 import (
 	"go-react-app/model"
 	"go-react-app/repository"
+	"log"
+	"time"
 )
 
+// feedIndexInterval はフィード記事を全文検索インデックスに登録し直す間隔
+// 記事は表示のたびにフィードから取得するため、表示ごとではなくこの間隔でフィードを更新したときだけ登録する
+const feedIndexInterval = 30 * time.Minute
+
 type IFeedArticleUsecase interface {
 	GetArticlesByFeedID(userId uint, feedID uint) ([]model.FeedArticleResponse, error)
 	GetArticleByID(userId uint, feedID uint, articleID string) (model.FeedArticleResponse, error)
@@ -17,6 +23,7 @@ func (fau *feedArticleUsecase) GetAllArticles(userId uint) ([]model.FeedArticleR
 	if err != nil {
 		return nil, err
 	}
+	fau.indexArticles(userId, articles)
 
 	var response []model.FeedArticleResponse
 	for _, article := range articles {
@@ -36,10 +43,40 @@ func (fau *feedArticleUsecase) GetAllArticles(userId uint) ([]model.FeedArticleR
 }
 type feedArticleUsecase struct {
 	far repository.IFeedArticleRepository
+	fr  repository.IFeedRepository
+	sr  repository.ISearchRepository
+}
+
+func NewFeedArticleUsecase(far repository.IFeedArticleRepository, fr repository.IFeedRepository, sr repository.ISearchRepository) IFeedArticleUsecase {
+	return &feedArticleUsecase{far, fr, sr}
 }
 
-func NewFeedArticleUsecase(far repository.IFeedArticleRepository) IFeedArticleUsecase {
-	return &feedArticleUsecase{far}
+// indexArticles は取得したフィード記事を、前回の登録からfeedIndexInterval以上経過したフィードの分だけ全文検索インデックスに保存します
+// フィードごとに1つのトランザクションで登録し、失敗してもログに記録するだけにします
+func (fau *feedArticleUsecase) indexArticles(userId uint, articles []model.FeedArticle) {
+	documents := map[uint][]model.SearchDocument{}
+	var feedIds []uint
+	for _, article := range articles {
+		if _, ok := documents[article.FeedID]; !ok {
+			feedIds = append(feedIds, article.FeedID)
+		}
+		documents[article.FeedID] = append(documents[article.FeedID], article.ToSearchDocument(userId))
+	}
+
+	now := time.Now()
+	for _, feedId := range feedIds {
+		refreshed, err := fau.fr.MarkFeedFetched(userId, feedId, now, feedIndexInterval)
+		if err != nil {
+			log.Printf("フィード(%d)の取得日時の更新に失敗しました: %v", feedId, err)
+			continue
+		}
+		if !refreshed {
+			continue
+		}
+		if err := fau.sr.UpsertDocuments(documents[feedId]); err != nil {
+			log.Printf("フィード(%d)の記事の検索インデックスの更新に失敗しました: %v", feedId, err)
+		}
+	}
 }
 
 func (fau *feedArticleUsecase) GetArticlesByFeedID(userId uint, feedID uint) ([]model.FeedArticleResponse, error) {
@@ -48,6 +85,7 @@ func (fau *feedArticleUsecase) GetArticlesByFeedID(userId uint, feedID uint) ([]
 	if err != nil {
 		return nil, err
 	}
+	fau.indexArticles(userId, articles)
 
 	var response []model.FeedArticleResponse
 	for _, article := range articles {
@@ -2,6 +2,7 @@ package feed_test
 
 import (
 	"go-react-app/model"
+	"go-react-app/repository"
 	"testing"
 )
 
@@ -33,6 +34,29 @@ func TestFeedUsecase_DeleteFeed(t *testing.T) {
 				t.Log("データベースからフィードが削除されていることを確認")
 			}
 		})
+
+		t.Run("削除したフィードの記事を検索インデックスから削除する", func(t *testing.T) {
+			feed := createTestFeed("Indexed Feed", "https://example.com/indexed", feedTestUser.ID)
+			remaining := createTestFeed("Remaining Feed", "https://example.com/remaining", feedTestUser.ID)
+			searchRepo := repository.NewSearchRepository(feedDB)
+			documents := []model.SearchDocument{
+				(&model.FeedArticle{ID: "a1", FeedID: feed.ID, Title: "削除されるフィードの記事"}).ToSearchDocument(feedTestUser.ID),
+				(&model.FeedArticle{ID: "a1", FeedID: remaining.ID, Title: "残るフィードの記事"}).ToSearchDocument(feedTestUser.ID),
+			}
+			if err := searchRepo.UpsertDocuments(documents); err != nil {
+				t.Fatalf("UpsertDocuments() error = %v", err)
+			}
+
+			if err := feedUsecase.DeleteFeed(feedTestUser.ID, feed.ID); err != nil {
+				t.Fatalf("DeleteFeed() error = %v", err)
+			}
+
+			var sourceIds []string
+			feedDB.Model(&model.SearchDocument{}).Where("source_type = ?", model.SearchSourceFeedArticle).Pluck("source_id", &sourceIds)
+			if len(sourceIds) != 1 || sourceIds[0] != documents[1].SourceID {
+				t.Errorf("検索インデックスに残ったフィード記事 = %v, want [%s]", sourceIds, documents[1].SourceID)
+			}
+		})
 	})
 
 	t.Run("異常系", func(t *testing.T) {
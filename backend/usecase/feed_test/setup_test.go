@@ -32,7 +32,7 @@ func setupFeedTest() {
 		feedDB = testutils.SetupTestDB()
 		feedRepo = repository.NewFeedRepository(feedDB)
 		feedValidator = validator.NewFeedValidator()
-		feedUsecase = usecase.NewFeedUsecase(feedRepo, feedValidator)
+		feedUsecase = usecase.NewFeedUsecase(feedRepo, feedValidator, repository.NewSearchRepository(feedDB))
 	}
 	
 	// テストユーザーを作成
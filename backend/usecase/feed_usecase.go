@@ -4,6 +4,7 @@ import (
 	"go-react-app/model"
 	"go-react-app/repository"
 	"go-react-app/validator"
+	"log"
 )
 
 type IFeedUsecase interface {
@@ -17,10 +18,11 @@ type IFeedUsecase interface {
 type feedUsecase struct {
 	fr repository.IFeedRepository
 	fv validator.IFeedValidator
+	sr repository.ISearchRepository
 }
 
-func NewFeedUsecase(fr repository.IFeedRepository, fv validator.IFeedValidator) IFeedUsecase {
-	return &feedUsecase{fr, fv}
+func NewFeedUsecase(fr repository.IFeedRepository, fv validator.IFeedValidator, sr repository.ISearchRepository) IFeedUsecase {
+	return &feedUsecase{fr, fv, sr}
 }
 
 func (fu *feedUsecase) GetAllFeeds(userId uint) ([]model.FeedResponse, error) {
@@ -95,5 +97,9 @@ func (fu *feedUsecase) DeleteFeed(userId uint, feedId uint) error {
 	if err := fu.fr.DeleteFeed(userId, feedId); err != nil {
 		return err
 	}
+	// 削除したフィードの記事が検索結果に残らないようにする
+	if err := fu.sr.DeleteFeedDocuments(userId, feedId); err != nil {
+		log.Printf("フィード%dの検索インデックスの削除に失敗しました: %v", feedId, err)
+	}
 	return nil
 }
@@ -7,8 +7,8 @@ import (
 )
 
 type IGoogleBookUsecase interface {
-	SearchBooks(request model.GoogleBookSearchRequest) (model.GoogleBookSearchResponse, error)
-	GetBookByID(id string) (model.GoogleBook, error)
+	SearchBooks(userId uint, request model.GoogleBookSearchRequest) (model.GoogleBookSearchResponse, error)
+	GetBookByID(userId uint, id string) (model.GoogleBook, error)
 }
 
 type googleBookUsecase struct {
@@ -20,14 +20,14 @@ func NewGoogleBookUsecase(gbr repository.IGoogleBookRepository, bv validator.IBo
 	return &googleBookUsecase{gbr, bv}
 }
 
-func (gbu *googleBookUsecase) SearchBooks(request model.GoogleBookSearchRequest) (model.GoogleBookSearchResponse, error) {
+func (gbu *googleBookUsecase) SearchBooks(userId uint, request model.GoogleBookSearchRequest) (model.GoogleBookSearchResponse, error) {
 	if err := gbu.bv.ValidateGoogleBookSearchRequest(request); err != nil {
 		return model.GoogleBookSearchResponse{}, err
 	}
 	
-	return gbu.gbr.SearchBooks(request.Query, request.MaxResults)
+	return gbu.gbr.SearchBooks(userId, request.Query, request.MaxResults)
 }
 
-func (gbu *googleBookUsecase) GetBookByID(id string) (model.GoogleBook, error) {
-	return gbu.gbr.GetBookByID(id)
+func (gbu *googleBookUsecase) GetBookByID(userId uint, id string) (model.GoogleBook, error) {
+	return gbu.gbr.GetBookByID(userId, id)
 }
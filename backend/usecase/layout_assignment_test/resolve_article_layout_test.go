@@ -0,0 +1,93 @@
+package layout_assignment_test
+
+import (
+	"go-react-app/model"
+	"testing"
+)
+
+func TestLayoutAssignmentUsecase_ResolveArticleLayout(t *testing.T) {
+	setupLayoutAssignmentUsecaseTest()
+
+	defaultLayout := createTestLayout(t, testUserId)
+	goLayout := createTestLayout(t, testUserId)
+	webLayout := createTestLayout(t, testUserId)
+	articleLayout := createTestLayout(t, testUserId)
+	assign := func(tag string, layoutId uint) {
+		t.Helper()
+		assignmentDb.Create(&model.LayoutAssignment{UserId: testUserId, Tag: tag, LayoutId: layoutId})
+	}
+	assign("", defaultLayout.ID)
+	assign("Go", goLayout.ID)
+	assign("Web", webLayout.ID)
+
+	t.Run("正常系", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			article    model.Article
+			wantSource string
+			wantTag    string
+			wantLayout uint
+		}{
+			{"記事の指定を最優先する", model.Article{Tags: "Go", LayoutId: &articleLayout.ID}, model.LayoutSourceArticle, "", articleLayout.ID},
+			{"記事に先に付けたタグの指定を優先する", model.Article{Tags: "Web, Go"}, model.LayoutSourceTag, "Web", webLayout.ID},
+			{"指定のあるタグがない場合はユーザーの既定を使う", model.Article{Tags: "Rust"}, model.LayoutSourceDefault, "", defaultLayout.ID},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				resolved, err := assignmentUsecase.ResolveArticleLayout(testUserId, tt.article)
+				if err != nil {
+					t.Fatalf("ResolveArticleLayout() error = %v", err)
+				}
+				if resolved.Source != tt.wantSource || resolved.Tag != tt.wantTag || resolved.Layout == nil || resolved.Layout.ID != tt.wantLayout {
+					t.Errorf("ResolveArticleLayout() = %+v, want %s %q layout %d", resolved, tt.wantSource, tt.wantTag, tt.wantLayout)
+				}
+				if resolved.Layout != nil && len(resolved.Layout.Components) != 1 {
+					t.Errorf("ResolveArticleLayout() components = %+v, want 1", resolved.Layout.Components)
+				}
+			})
+		}
+
+		t.Run("公開中のスナップショットがある場合はその状態を返す", func(t *testing.T) {
+			layout := createTestLayout(t, testUserId)
+			layout.Title = "公開中のタイトル"
+			snapshot, err := model.NewLayoutSnapshot(layout, "公開")
+			if err != nil {
+				t.Fatalf("スナップショットの作成に失敗しました: %v", err)
+			}
+			assignmentDb.Create(&snapshot)
+			// 公開後に編集中の状態を変更しても、公開中の状態を返す
+			assignmentDb.Model(&layout).Updates(map[string]interface{}{"live_snapshot_id": snapshot.ID, "title": "編集中のタイトル"})
+
+			resolved, err := assignmentUsecase.ResolveArticleLayout(testUserId, model.Article{LayoutId: &layout.ID})
+			if err != nil {
+				t.Fatalf("ResolveArticleLayout() error = %v", err)
+			}
+			if resolved.Layout == nil || resolved.Layout.Title != "公開中のタイトル" {
+				t.Errorf("ResolveArticleLayout() layout = %+v, want snapshot title", resolved.Layout)
+			}
+		})
+
+		t.Run("削除されたレイアウトの指定は飛ばして次の候補を使う", func(t *testing.T) {
+			deleted := createTestLayout(t, testUserId)
+			assignmentDb.Delete(&deleted)
+
+			resolved, err := assignmentUsecase.ResolveArticleLayout(testUserId, model.Article{Tags: "Go", LayoutId: &deleted.ID})
+			if err != nil {
+				t.Fatalf("ResolveArticleLayout() error = %v", err)
+			}
+			if resolved.Source != model.LayoutSourceTag || resolved.Layout == nil || resolved.Layout.ID != goLayout.ID {
+				t.Errorf("ResolveArticleLayout() = %+v, want tag layout %d", resolved, goLayout.ID)
+			}
+		})
+
+		t.Run("指定がない場合はレイアウトなしを返す", func(t *testing.T) {
+			resolved, err := assignmentUsecase.ResolveArticleLayout(otherUserId, model.Article{Tags: "Go"})
+			if err != nil {
+				t.Fatalf("ResolveArticleLayout() error = %v", err)
+			}
+			if resolved.Source != "" || resolved.Layout != nil {
+				t.Errorf("ResolveArticleLayout() = %+v, want empty", resolved)
+			}
+		})
+	})
+}
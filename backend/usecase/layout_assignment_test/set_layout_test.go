@@ -0,0 +1,97 @@
+package layout_assignment_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"testing"
+)
+
+func TestLayoutAssignmentUsecase_SetLayout(t *testing.T) {
+	setupLayoutAssignmentUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("既定とタグのレイアウトを設定して一覧で取得できる", func(t *testing.T) {
+			first := createTestLayout(t, testUserId)
+			second := createTestLayout(t, testUserId)
+
+			if _, err := assignmentUsecase.SetDefaultLayout(testUserId, model.LayoutAssignmentRequest{LayoutId: first.ID}); err != nil {
+				t.Fatalf("SetDefaultLayout() error = %v", err)
+			}
+			res, err := assignmentUsecase.SetTagLayout(testUserId, " Go ", model.LayoutAssignmentRequest{LayoutId: second.ID})
+			if err != nil {
+				t.Fatalf("SetTagLayout() error = %v", err)
+			}
+			if res.Tag != "Go" || res.LayoutId != second.ID {
+				t.Errorf("SetTagLayout() = %+v, want tag Go, layout %d", res, second.ID)
+			}
+
+			assignments, err := assignmentUsecase.GetAssignments(testUserId)
+			if err != nil {
+				t.Fatalf("GetAssignments() error = %v", err)
+			}
+			if assignments.Default == nil || assignments.Default.LayoutId != first.ID {
+				t.Errorf("GetAssignments() default = %+v, want layout %d", assignments.Default, first.ID)
+			}
+			if len(assignments.Tags) != 1 || assignments.Tags[0].Tag != "Go" {
+				t.Errorf("GetAssignments() tags = %+v", assignments.Tags)
+			}
+		})
+
+		t.Run("同じタグに設定し直すとレイアウトを置き換える", func(t *testing.T) {
+			first := createTestLayout(t, testUserId)
+			second := createTestLayout(t, testUserId)
+
+			if _, err := assignmentUsecase.SetTagLayout(testUserId, "Web", model.LayoutAssignmentRequest{LayoutId: first.ID}); err != nil {
+				t.Fatalf("SetTagLayout() error = %v", err)
+			}
+			if _, err := assignmentUsecase.SetTagLayout(testUserId, "Web", model.LayoutAssignmentRequest{LayoutId: second.ID}); err != nil {
+				t.Fatalf("SetTagLayout() error = %v", err)
+			}
+
+			var assignments []model.LayoutAssignment
+			assignmentDb.Where("user_id=? AND tag=?", testUserId, "Web").Find(&assignments)
+			if len(assignments) != 1 || assignments[0].LayoutId != second.ID {
+				t.Errorf("保存された指定 = %+v, want layout %d", assignments, second.ID)
+			}
+		})
+
+		t.Run("指定を解除できる", func(t *testing.T) {
+			layout := createTestLayout(t, testUserId)
+			if _, err := assignmentUsecase.SetTagLayout(testUserId, "解除", model.LayoutAssignmentRequest{LayoutId: layout.ID}); err != nil {
+				t.Fatalf("SetTagLayout() error = %v", err)
+			}
+
+			if err := assignmentUsecase.DeleteTagLayout(testUserId, "解除"); err != nil {
+				t.Fatalf("DeleteTagLayout() error = %v", err)
+			}
+			if err := assignmentUsecase.DeleteTagLayout(testUserId, "解除"); err == nil {
+				t.Error("DeleteTagLayout() 解除済みの指定でエラーになりませんでした")
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("他のユーザーのレイアウトはErrInvalidLayoutAssignmentを返す", func(t *testing.T) {
+			layout := createTestLayout(t, otherUserId)
+
+			_, err := assignmentUsecase.SetDefaultLayout(testUserId, model.LayoutAssignmentRequest{LayoutId: layout.ID})
+			if !errors.Is(err, usecase.ErrInvalidLayoutAssignment) {
+				t.Errorf("SetDefaultLayout() error = %v, want ErrInvalidLayoutAssignment", err)
+			}
+		})
+
+		t.Run("レイアウトIDやタグが不正な場合はErrInvalidLayoutAssignmentを返す", func(t *testing.T) {
+			layout := createTestLayout(t, testUserId)
+
+			if _, err := assignmentUsecase.SetDefaultLayout(testUserId, model.LayoutAssignmentRequest{}); !errors.Is(err, usecase.ErrInvalidLayoutAssignment) {
+				t.Errorf("SetDefaultLayout() error = %v, want ErrInvalidLayoutAssignment", err)
+			}
+			for _, tag := range []string{"", "Go,Web"} {
+				if _, err := assignmentUsecase.SetTagLayout(testUserId, tag, model.LayoutAssignmentRequest{LayoutId: layout.ID}); !errors.Is(err, usecase.ErrInvalidLayoutAssignment) {
+					t.Errorf("SetTagLayout(%q) error = %v, want ErrInvalidLayoutAssignment", tag, err)
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,62 @@
+package layout_assignment_test
+
+import (
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/validator"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// テスト用の共通変数
+var (
+	assignmentDb      *gorm.DB
+	assignmentUsecase usecase.ILayoutAssignmentUsecase
+	testUserId        uint = 1
+	otherUserId       uint = 2
+)
+
+// テスト前の共通セットアップ
+func setupLayoutAssignmentUsecaseTest() {
+	// テストごとにデータベースをクリーンアップ
+	if assignmentDb != nil {
+		testutils.CleanupTestDB(assignmentDb)
+	} else {
+		// 初回のみデータベース接続を作成
+		assignmentDb = testutils.SetupTestDB()
+		assignmentUsecase = usecase.NewLayoutAssignmentUsecase(
+			repository.NewLayoutAssignmentRepository(assignmentDb),
+			repository.NewLayoutRepository(assignmentDb),
+			validator.NewLayoutAssignmentValidator(),
+		)
+	}
+
+	// 既存のテストデータを明示的に削除（念のため）
+	assignmentDb.Exec("DELETE FROM layout_assignments")
+	assignmentDb.Exec("DELETE FROM layout_snapshots")
+	assignmentDb.Exec("DELETE FROM layout_components")
+	assignmentDb.Exec("DELETE FROM layouts")
+}
+
+// テスト用にヘッダーを配置したレイアウトを作成
+func createTestLayout(t *testing.T, userId uint) model.Layout {
+	layout := model.Layout{
+		Title:       fmt.Sprintf("記事のレイアウト %d", time.Now().UnixNano()),
+		GridColumns: 12,
+		UserId:      userId,
+	}
+	if err := assignmentDb.Create(&layout).Error; err != nil {
+		t.Fatalf("テストレイアウトの作成に失敗しました: %v", err)
+	}
+	header := model.LayoutComponent{Name: "ヘッダー", Type: "header", X: 0, Y: 0, Width: 12, Height: 2, UserId: userId, LayoutId: &layout.ID}
+	if err := assignmentDb.Create(&header).Error; err != nil {
+		t.Fatalf("テストコンポーネントの作成に失敗しました: %v", err)
+	}
+	layout.Components = []model.LayoutComponent{header}
+	return layout
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/validator"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidLayoutAssignment はレイアウトの指定が不正な場合（他のユーザーのレイアウトを含む）のエラー
+var ErrInvalidLayoutAssignment = errors.New("invalid layout assignment")
+
+type ILayoutAssignmentUsecase interface {
+	GetAssignments(userId uint) (model.LayoutAssignmentsResponse, error)
+	SetDefaultLayout(userId uint, request model.LayoutAssignmentRequest) (model.LayoutAssignmentResponse, error)
+	DeleteDefaultLayout(userId uint) error
+	SetTagLayout(userId uint, tag string, request model.LayoutAssignmentRequest) (model.LayoutAssignmentResponse, error)
+	DeleteTagLayout(userId uint, tag string) error
+	// ResolveArticleLayout は記事のページに使うレイアウトを、記事の指定 → タグの指定 → ユーザーの既定の順に決定します
+	ResolveArticleLayout(userId uint, article model.Article) (model.ResolvedLayout, error)
+}
+
+type layoutAssignmentUsecase struct {
+	lar repository.ILayoutAssignmentRepository
+	lr  repository.ILayoutRepository
+	lav validator.ILayoutAssignmentValidator
+}
+
+func NewLayoutAssignmentUsecase(lar repository.ILayoutAssignmentRepository, lr repository.ILayoutRepository, lav validator.ILayoutAssignmentValidator) ILayoutAssignmentUsecase {
+	return &layoutAssignmentUsecase{lar, lr, lav}
+}
+
+func (lau *layoutAssignmentUsecase) GetAssignments(userId uint) (model.LayoutAssignmentsResponse, error) {
+	assignments, err := lau.lar.GetAssignments(userId)
+	if err != nil {
+		return model.LayoutAssignmentsResponse{}, err
+	}
+
+	response := model.LayoutAssignmentsResponse{Tags: []model.LayoutAssignmentResponse{}}
+	for _, assignment := range assignments {
+		res := assignment.ToResponse()
+		if assignment.Tag == "" {
+			response.Default = &res
+			continue
+		}
+		response.Tags = append(response.Tags, res)
+	}
+	return response, nil
+}
+
+func (lau *layoutAssignmentUsecase) SetDefaultLayout(userId uint, request model.LayoutAssignmentRequest) (model.LayoutAssignmentResponse, error) {
+	return lau.saveAssignment(userId, "", request)
+}
+
+func (lau *layoutAssignmentUsecase) DeleteDefaultLayout(userId uint) error {
+	return lau.lar.DeleteAssignment(userId, "")
+}
+
+func (lau *layoutAssignmentUsecase) SetTagLayout(userId uint, tag string, request model.LayoutAssignmentRequest) (model.LayoutAssignmentResponse, error) {
+	tag = strings.TrimSpace(tag)
+	if err := lau.lav.ValidateTag(tag); err != nil {
+		return model.LayoutAssignmentResponse{}, fmt.Errorf("%w: %v", ErrInvalidLayoutAssignment, err)
+	}
+	return lau.saveAssignment(userId, tag, request)
+}
+
+func (lau *layoutAssignmentUsecase) DeleteTagLayout(userId uint, tag string) error {
+	tag = strings.TrimSpace(tag)
+	if err := lau.lav.ValidateTag(tag); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLayoutAssignment, err)
+	}
+	return lau.lar.DeleteAssignment(userId, tag)
+}
+
+// saveAssignment はレイアウトがユーザーのものであることを確認してから指定を保存します
+func (lau *layoutAssignmentUsecase) saveAssignment(userId uint, tag string, request model.LayoutAssignmentRequest) (model.LayoutAssignmentResponse, error) {
+	if err := lau.lav.ValidateLayoutAssignmentRequest(request); err != nil {
+		return model.LayoutAssignmentResponse{}, fmt.Errorf("%w: %v", ErrInvalidLayoutAssignment, err)
+	}
+	if err := requireLayout(lau.lr, userId, request.LayoutId, ErrInvalidLayoutAssignment); err != nil {
+		return model.LayoutAssignmentResponse{}, err
+	}
+
+	assignment := model.LayoutAssignment{UserId: userId, Tag: tag, LayoutId: request.LayoutId}
+	if err := lau.lar.SaveAssignment(&assignment); err != nil {
+		return model.LayoutAssignmentResponse{}, err
+	}
+	return assignment.ToResponse(), nil
+}
+
+func (lau *layoutAssignmentUsecase) ResolveArticleLayout(userId uint, article model.Article) (model.ResolvedLayout, error) {
+	if article.LayoutId != nil {
+		layout, err := lau.publishedLayout(userId, *article.LayoutId)
+		if err != nil || layout != nil {
+			return model.ResolvedLayout{Source: model.LayoutSourceArticle, Layout: layout}, err
+		}
+	}
+
+	assignments, err := lau.lar.GetAssignments(userId)
+	if err != nil {
+		return model.ResolvedLayout{}, err
+	}
+	var defaultLayoutId *uint
+	tagLayoutIds := make(map[string]uint, len(assignments))
+	for _, assignment := range assignments {
+		if assignment.Tag == "" {
+			defaultLayoutId = &assignment.LayoutId
+			continue
+		}
+		tagLayoutIds[assignment.Tag] = assignment.LayoutId
+	}
+
+	// 複数のタグにレイアウトが指定されている場合は、記事に先に付けたタグを優先する
+	for _, tag := range splitTags(article.Tags) {
+		layoutId, ok := tagLayoutIds[tag]
+		if !ok {
+			continue
+		}
+		layout, err := lau.publishedLayout(userId, layoutId)
+		if err != nil || layout != nil {
+			return model.ResolvedLayout{Source: model.LayoutSourceTag, Tag: tag, Layout: layout}, err
+		}
+	}
+
+	if defaultLayoutId != nil {
+		layout, err := lau.publishedLayout(userId, *defaultLayoutId)
+		if err != nil || layout != nil {
+			return model.ResolvedLayout{Source: model.LayoutSourceDefault, Layout: layout}, err
+		}
+	}
+	return model.ResolvedLayout{}, nil
+}
+
+// publishedLayout は公開中の状態のレイアウトを、子コンポーネントを親のchildrenに入れた形で返します
+// 削除されたレイアウトを指定していた場合は、次の候補に進むためnilを返す
+func (lau *layoutAssignmentUsecase) publishedLayout(userId uint, layoutId uint) (*model.LayoutResponse, error) {
+	layout, err := lau.lr.GetPublishedLayout(userId, layoutId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	response := breakpointResponse(layout, "")
+	return &response, nil
+}
+
+// requireLayout はレイアウトがユーザーのものであることを確認します
+// 存在しない場合はinvalidでラップしたエラーを返す
+func requireLayout(lr repository.ILayoutRepository, userId uint, layoutId uint, invalid error) error {
+	if _, err := lr.GetLayoutById(userId, layoutId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: layout does not exist", invalid)
+		}
+		return err
+	}
+	return nil
+}
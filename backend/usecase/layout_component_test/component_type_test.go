@@ -0,0 +1,69 @@
+package layout_component_test
+
+import (
+	"encoding/json"
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"testing"
+)
+
+func TestLayoutComponentUsecase_ComponentTypes(t *testing.T) {
+	setupLayoutComponentUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("登録されているコンポーネントの種類を取得できる", func(t *testing.T) {
+			types := componentUsecase.GetComponentTypes()
+			if len(types) != len(model.ComponentTypes) {
+				t.Errorf("GetComponentTypes() returned %d types, want %d", len(types), len(model.ComponentTypes))
+			}
+		})
+
+		t.Run("サイズを省略すると種類の既定のサイズで作成される", func(t *testing.T) {
+			created, err := componentUsecase.CreateLayoutComponent(model.LayoutComponentRequest{
+				Name:   generateUniqueName(),
+				Type:   "header",
+				UserId: testUserId,
+			})
+			if err != nil {
+				t.Fatalf("CreateLayoutComponent() error = %v", err)
+			}
+
+			headerType, _ := model.FindComponentType("header")
+			if created.Width != headerType.DefaultWidth || created.Height != headerType.DefaultHeight {
+				t.Errorf("CreateLayoutComponent() size = %dx%d, want %dx%d", created.Width, created.Height, headerType.DefaultWidth, headerType.DefaultHeight)
+			}
+		})
+
+		t.Run("文字列として送られたプロパティはJSONとして保存される", func(t *testing.T) {
+			created, err := componentUsecase.CreateLayoutComponent(model.LayoutComponentRequest{
+				Name:       generateUniqueName(),
+				Type:       "header",
+				UserId:     testUserId,
+				Properties: json.RawMessage(`"{\"title\":\"My Blog\"}"`),
+			})
+			if err != nil {
+				t.Fatalf("CreateLayoutComponent() error = %v", err)
+			}
+			if string(created.Properties) != `{"title":"My Blog"}` {
+				t.Errorf("CreateLayoutComponent() properties = %s, want %s", created.Properties, `{"title":"My Blog"}`)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("定義に適合しないプロパティの場合はErrInvalidLayoutComponentを返す", func(t *testing.T) {
+			component := createTestComponent(t, generateUniqueName())
+
+			_, err := componentUsecase.UpdateLayoutComponent(model.LayoutComponentRequest{
+				Name:       component.Name,
+				Type:       "header",
+				UserId:     testUserId,
+				Properties: json.RawMessage(`{"backgroundColor": "red"}`),
+			}, testUserId, component.ID)
+			if !errors.Is(err, usecase.ErrInvalidLayoutComponent) {
+				t.Errorf("UpdateLayoutComponent() error = %v, want ErrInvalidLayoutComponent", err)
+			}
+		})
+	})
+}
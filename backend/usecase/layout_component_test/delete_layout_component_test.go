@@ -16,7 +16,7 @@ func TestLayoutComponentUsecase_DeleteLayoutComponent(t *testing.T) {
 			t.Logf("コンポーネント削除: ID=%d", component.ID)
 
 			// テスト実行
-			err := componentUsecase.DeleteLayoutComponent(testUserId, component.ID)
+			err := componentUsecase.DeleteLayoutComponent(testUserId, component.ID, "")
 
 			// 検証
 			if err != nil {
@@ -40,7 +40,7 @@ func TestLayoutComponentUsecase_DeleteLayoutComponent(t *testing.T) {
 			nonExistComponentId := uint(9999)
 
 			// テスト実行
-			err := componentUsecase.DeleteLayoutComponent(testUserId, nonExistComponentId)
+			err := componentUsecase.DeleteLayoutComponent(testUserId, nonExistComponentId, "")
 
 			// 検証
 			if err == nil {
@@ -58,7 +58,7 @@ func TestLayoutComponentUsecase_DeleteLayoutComponent(t *testing.T) {
 			otherUserId := uint(999)
 
 			// テスト実行
-			err := componentUsecase.DeleteLayoutComponent(otherUserId, component.ID)
+			err := componentUsecase.DeleteLayoutComponent(otherUserId, component.ID, "")
 
 			// 検証
 			if err == nil {
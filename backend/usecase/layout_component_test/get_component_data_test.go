@@ -0,0 +1,64 @@
+package layout_component_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"testing"
+)
+
+func TestLayoutComponentUsecase_GetComponentData(t *testing.T) {
+	setupLayoutComponentUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("本棚のコンポーネントは登録した本を返す", func(t *testing.T) {
+			componentDb.Exec("DELETE FROM books WHERE user_id = ?", testUserId)
+			componentDb.Create(&model.Book{Title: "プログラミング言語Go", Author: "Alan A. A. Donovan", UserId: testUserId})
+			component, err := componentUsecase.CreateLayoutComponent(model.LayoutComponentRequest{
+				Name:   generateUniqueName(),
+				Type:   "bookshelf",
+				UserId: testUserId,
+			})
+			if err != nil {
+				t.Fatalf("CreateLayoutComponent() error = %v", err)
+			}
+
+			res, err := componentUsecase.GetComponentData(testUserId, component.ID)
+			if err != nil {
+				t.Fatalf("GetComponentData() error = %v", err)
+			}
+			if res.ComponentId != component.ID || res.Source != model.DataSourceBookshelf || len(res.Items) != 1 || res.Items[0].Title != "プログラミング言語Go" {
+				t.Errorf("GetComponentData() = %+v", res)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("データを取得しない種類のコンポーネントはErrNoDataSourceを返す", func(t *testing.T) {
+			component := createTestComponent(t, generateUniqueName())
+
+			if _, err := componentUsecase.GetComponentData(testUserId, component.ID); !errors.Is(err, usecase.ErrNoDataSource) {
+				t.Errorf("GetComponentData() error = %v, want ErrNoDataSource", err)
+			}
+		})
+
+		t.Run("他のユーザーのコンポーネントはエラーを返す", func(t *testing.T) {
+			component := createTestComponent(t, generateUniqueName())
+
+			if _, err := componentUsecase.GetComponentData(testUserId+1, component.ID); err == nil {
+				t.Error("GetComponentData() エラーが返されませんでした")
+			}
+		})
+
+		t.Run("フィードのIDを指定しない場合は作成できない", func(t *testing.T) {
+			_, err := componentUsecase.CreateLayoutComponent(model.LayoutComponentRequest{
+				Name:   generateUniqueName(),
+				Type:   "feed",
+				UserId: testUserId,
+			})
+			if err == nil {
+				t.Error("CreateLayoutComponent() エラーが返されませんでした")
+			}
+		})
+	})
+}
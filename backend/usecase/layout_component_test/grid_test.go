@@ -0,0 +1,128 @@
+package layout_component_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"testing"
+)
+
+// グリッドの設定を持つレイアウトを作成するヘルパー関数
+func createGridLayout(t *testing.T, collisionMode string) uint {
+	layout := model.Layout{
+		Title:         "Grid Layout",
+		GridColumns:   12,
+		RowHeight:     30,
+		CanvasWidth:   1200,
+		CollisionMode: collisionMode,
+		UserId:        testUserId,
+	}
+	if err := componentDb.Create(&layout).Error; err != nil {
+		t.Fatalf("テストレイアウトの作成に失敗しました: %v", err)
+	}
+	return layout.ID
+}
+
+// コンポーネントをレイアウトに割り当てるヘルパー関数
+func assignComponent(t *testing.T, layoutId uint, position model.PositionRequest) model.LayoutComponentResponse {
+	component := createTestComponent(t, generateUniqueName())
+	err := componentUsecase.AssignToLayout(testUserId, component.ID, model.AssignLayoutRequest{
+		LayoutId: layoutId,
+		Position: position,
+	})
+	if err != nil {
+		t.Fatalf("コンポーネントの割り当てに失敗しました: %v", err)
+	}
+	return component
+}
+
+func findComponent(t *testing.T, componentId uint) model.LayoutComponent {
+	var component model.LayoutComponent
+	if err := componentDb.First(&component, componentId).Error; err != nil {
+		t.Fatalf("コンポーネントの取得に失敗しました: %v", err)
+	}
+	return component
+}
+
+func TestLayoutComponentUsecase_GridPlacement(t *testing.T) {
+	setupLayoutComponentUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("ピクセルで指定した位置はグリッドに合わせて保存される", func(t *testing.T) {
+			layoutId := createGridLayout(t, model.CollisionModePush)
+
+			// 1列100px・1行30px
+			component := assignComponent(t, layoutId, model.PositionRequest{X: 240, Y: 95, Width: 310, Height: 50, Unit: model.PositionUnitPixel})
+
+			saved := findComponent(t, component.ID)
+			if saved.X != 2 || saved.Y != 3 || saved.Width != 3 || saved.Height != 2 {
+				t.Errorf("AssignToLayout() = (%d,%d,%d,%d), want (2,3,3,2)", saved.X, saved.Y, saved.Width, saved.Height)
+			}
+		})
+
+		t.Run("グリッドの右端からはみ出す位置は左に寄せられる", func(t *testing.T) {
+			layoutId := createGridLayout(t, model.CollisionModePush)
+
+			component := assignComponent(t, layoutId, model.PositionRequest{X: 10, Y: 0, Width: 4, Height: 2})
+
+			saved := findComponent(t, component.ID)
+			if saved.X != 8 || saved.Width != 4 {
+				t.Errorf("AssignToLayout() x = %d, width = %d, want 8, 4", saved.X, saved.Width)
+			}
+		})
+
+		t.Run("重なったコンポーネントは下に押し出される", func(t *testing.T) {
+			layoutId := createGridLayout(t, model.CollisionModePush)
+			upper := assignComponent(t, layoutId, model.PositionRequest{X: 0, Y: 0, Width: 4, Height: 2})
+			lower := assignComponent(t, layoutId, model.PositionRequest{X: 0, Y: 2, Width: 4, Height: 2})
+			side := assignComponent(t, layoutId, model.PositionRequest{X: 6, Y: 0, Width: 4, Height: 2})
+
+			// 先頭に高さ3のコンポーネントを配置すると、下の2つが連鎖して押し出される
+			moved := assignComponent(t, layoutId, model.PositionRequest{X: 0, Y: 0, Width: 4, Height: 3})
+
+			if saved := findComponent(t, moved.ID); saved.Y != 0 {
+				t.Errorf("moved component y = %d, want 0", saved.Y)
+			}
+			if saved := findComponent(t, upper.ID); saved.Y != 3 {
+				t.Errorf("upper component y = %d, want 3", saved.Y)
+			}
+			if saved := findComponent(t, lower.ID); saved.Y != 5 {
+				t.Errorf("lower component y = %d, want 5", saved.Y)
+			}
+			if saved := findComponent(t, side.ID); saved.Y != 0 {
+				t.Errorf("side component y = %d, want 0", saved.Y)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("重なりを拒否するレイアウトで重なる位置はErrPositionConflictを返す", func(t *testing.T) {
+			layoutId := createGridLayout(t, model.CollisionModeReject)
+			existing := assignComponent(t, layoutId, model.PositionRequest{X: 0, Y: 0, Width: 4, Height: 2})
+			component := assignComponent(t, layoutId, model.PositionRequest{X: 4, Y: 0, Width: 4, Height: 2})
+
+			err := componentUsecase.UpdatePosition(testUserId, component.ID, model.PositionRequest{X: 2, Y: 1})
+			if !errors.Is(err, usecase.ErrPositionConflict) {
+				t.Fatalf("UpdatePosition() error = %v, want ErrPositionConflict", err)
+			}
+
+			// どちらのコンポーネントも変更されていないことを確認
+			if saved := findComponent(t, component.ID); saved.X != 4 || saved.Y != 0 {
+				t.Errorf("component position = (%d,%d), want (4,0)", saved.X, saved.Y)
+			}
+			if saved := findComponent(t, existing.ID); saved.X != 0 || saved.Y != 0 {
+				t.Errorf("existing component position = (%d,%d), want (0,0)", saved.X, saved.Y)
+			}
+		})
+
+		t.Run("負の座標はErrInvalidPositionを返す", func(t *testing.T) {
+			layoutId := createGridLayout(t, model.CollisionModePush)
+			component := assignComponent(t, layoutId, model.PositionRequest{X: 0, Y: 0, Width: 4, Height: 2})
+
+			err := componentUsecase.UpdatePosition(testUserId, component.ID, model.PositionRequest{X: -1, Y: 0})
+			if !errors.Is(err, usecase.ErrInvalidPosition) {
+				t.Errorf("UpdatePosition() error = %v, want ErrInvalidPosition", err)
+			}
+		})
+	})
+}
@@ -0,0 +1,144 @@
+package layout_component_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"testing"
+)
+
+// 種類を指定してコンポーネントをレイアウトに割り当てるヘルパー関数
+func assignTypedComponent(t *testing.T, layoutId uint, componentType string, position model.PositionRequest) model.LayoutComponentResponse {
+	component, err := componentUsecase.CreateLayoutComponent(model.LayoutComponentRequest{
+		Name:   generateUniqueName(),
+		Type:   componentType,
+		UserId: testUserId,
+	})
+	if err != nil {
+		t.Fatalf("テストコンポーネントの作成に失敗しました: %v", err)
+	}
+	err = componentUsecase.AssignToLayout(testUserId, component.ID, model.AssignLayoutRequest{LayoutId: layoutId, Position: position})
+	if err != nil {
+		t.Fatalf("コンポーネントの割り当てに失敗しました: %v", err)
+	}
+	return component
+}
+
+// 親の中の(2,1)にコンポーネントを配置するヘルパー関数
+// 親と重なっていても、重なりは同じ親を持つコンポーネントの間でのみ判定する
+func nestComponent(t *testing.T, layoutId uint, parentId uint) model.LayoutComponentResponse {
+	child := assignComponent(t, layoutId, model.PositionRequest{X: 0, Y: 0, Width: 2, Height: 2})
+	if err := componentUsecase.SetParent(testUserId, child.ID, model.ParentRequest{ParentId: &parentId}); err != nil {
+		t.Fatalf("SetParent() error = %v", err)
+	}
+	if err := componentUsecase.UpdatePosition(testUserId, child.ID, model.PositionRequest{X: 2, Y: 1, Width: 2, Height: 2}); err != nil {
+		t.Fatalf("UpdatePosition() error = %v", err)
+	}
+	return child
+}
+
+func TestLayoutComponentUsecase_SetParent(t *testing.T) {
+	setupLayoutComponentUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("親を設定すると位置が親からの相対位置になる", func(t *testing.T) {
+			layoutId := createGridLayout(t, model.CollisionModePush)
+			sidebar := assignTypedComponent(t, layoutId, "sidebar", model.PositionRequest{X: 6, Y: 2, Width: 6, Height: 6})
+			child := nestComponent(t, layoutId, sidebar.ID)
+
+			saved := findComponent(t, child.ID)
+			if saved.ParentId == nil || *saved.ParentId != sidebar.ID {
+				t.Fatalf("SetParent() parent = %v, want %d", saved.ParentId, sidebar.ID)
+			}
+			if saved.X != 2 || saved.Y != 1 {
+				t.Errorf("position = (%d,%d), want (2,1)", saved.X, saved.Y)
+			}
+
+			// 親を外すとレイアウト上の位置に戻る
+			if err := componentUsecase.SetParent(testUserId, child.ID, model.ParentRequest{}); err != nil {
+				t.Fatalf("SetParent() error = %v", err)
+			}
+			if saved := findComponent(t, child.ID); saved.ParentId != nil || saved.X != 8 || saved.Y != 3 {
+				t.Errorf("SetParent() = parent %v, position (%d,%d), want nil, (8,3)", saved.ParentId, saved.X, saved.Y)
+			}
+		})
+
+		t.Run("親を削除すると子は親の位置を引き継いで親の親に付け替えられる", func(t *testing.T) {
+			layoutId := createGridLayout(t, model.CollisionModePush)
+			sidebar := assignTypedComponent(t, layoutId, "sidebar", model.PositionRequest{X: 6, Y: 2, Width: 6, Height: 6})
+			child := nestComponent(t, layoutId, sidebar.ID)
+
+			if err := componentUsecase.DeleteLayoutComponent(testUserId, sidebar.ID, model.ChildrenReparent); err != nil {
+				t.Fatalf("DeleteLayoutComponent() error = %v", err)
+			}
+			saved := findComponent(t, child.ID)
+			if saved.ParentId != nil || saved.X != 8 || saved.Y != 3 {
+				t.Errorf("child = parent %v, position (%d,%d), want nil, (8,3)", saved.ParentId, saved.X, saved.Y)
+			}
+		})
+
+		t.Run("子も削除する指定では子孫もまとめて削除する", func(t *testing.T) {
+			layoutId := createGridLayout(t, model.CollisionModePush)
+			sidebar := assignTypedComponent(t, layoutId, "sidebar", model.PositionRequest{X: 6, Y: 2, Width: 6, Height: 6})
+			child := nestComponent(t, layoutId, sidebar.ID)
+
+			if err := componentUsecase.DeleteLayoutComponent(testUserId, sidebar.ID, model.ChildrenDelete); err != nil {
+				t.Fatalf("DeleteLayoutComponent() error = %v", err)
+			}
+			var count int64
+			componentDb.Model(&model.LayoutComponent{}).Where("id IN ?", []uint{sidebar.ID, child.ID}).Count(&count)
+			if count != 0 {
+				t.Errorf("残っているコンポーネント = %d, want 0", count)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("子孫のコンポーネントを親にするとErrInvalidParentを返す", func(t *testing.T) {
+			layoutId := createGridLayout(t, model.CollisionModePush)
+			outer := assignTypedComponent(t, layoutId, "sidebar", model.PositionRequest{X: 0, Y: 0, Width: 6, Height: 6})
+			inner := assignTypedComponent(t, layoutId, "sidebar", model.PositionRequest{X: 6, Y: 0, Width: 4, Height: 4})
+			if err := componentUsecase.SetParent(testUserId, inner.ID, model.ParentRequest{ParentId: &outer.ID}); err != nil {
+				t.Fatalf("SetParent() error = %v", err)
+			}
+
+			err := componentUsecase.SetParent(testUserId, outer.ID, model.ParentRequest{ParentId: &inner.ID})
+			if !errors.Is(err, usecase.ErrInvalidParent) {
+				t.Errorf("SetParent() error = %v, want ErrInvalidParent", err)
+			}
+			if err := componentUsecase.SetParent(testUserId, outer.ID, model.ParentRequest{ParentId: &outer.ID}); !errors.Is(err, usecase.ErrInvalidParent) {
+				t.Errorf("SetParent() self error = %v, want ErrInvalidParent", err)
+			}
+		})
+
+		t.Run("子を持てない種類のコンポーネントを親にするとErrInvalidParentを返す", func(t *testing.T) {
+			layoutId := createGridLayout(t, model.CollisionModePush)
+			text := assignComponent(t, layoutId, model.PositionRequest{X: 0, Y: 0, Width: 6, Height: 6})
+			child := assignComponent(t, layoutId, model.PositionRequest{X: 6, Y: 0, Width: 2, Height: 2})
+
+			err := componentUsecase.SetParent(testUserId, child.ID, model.ParentRequest{ParentId: &text.ID})
+			if !errors.Is(err, usecase.ErrInvalidParent) {
+				t.Errorf("SetParent() error = %v, want ErrInvalidParent", err)
+			}
+		})
+
+		t.Run("別のレイアウトのコンポーネントを親にするとErrInvalidParentを返す", func(t *testing.T) {
+			sidebar := assignTypedComponent(t, createGridLayout(t, model.CollisionModePush), "sidebar", model.PositionRequest{X: 0, Y: 0, Width: 6, Height: 6})
+			child := assignComponent(t, createGridLayout(t, model.CollisionModePush), model.PositionRequest{X: 0, Y: 0, Width: 2, Height: 2})
+
+			err := componentUsecase.SetParent(testUserId, child.ID, model.ParentRequest{ParentId: &sidebar.ID})
+			if !errors.Is(err, usecase.ErrInvalidParent) {
+				t.Errorf("SetParent() error = %v, want ErrInvalidParent", err)
+			}
+		})
+
+		t.Run("子の扱いの指定が不正な場合はErrInvalidLayoutComponentを返す", func(t *testing.T) {
+			component := createTestComponent(t, generateUniqueName())
+
+			err := componentUsecase.DeleteLayoutComponent(testUserId, component.ID, "keep")
+			if !errors.Is(err, usecase.ErrInvalidLayoutComponent) {
+				t.Errorf("DeleteLayoutComponent() error = %v, want ErrInvalidLayoutComponent", err)
+			}
+		})
+	})
+}
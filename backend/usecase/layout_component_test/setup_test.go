@@ -30,7 +30,15 @@ func setupLayoutComponentUsecaseTest() {
 		componentDb = testutils.SetupTestDB()
 		componentRepo = repository.NewLayoutComponentRepository(componentDb)
 		componentValidator = validator.NewLayoutComponentValidator()
-		componentUsecase = usecase.NewLayoutComponentUsecase(componentRepo, componentValidator)
+		componentData := usecase.NewComponentDataUsecase(
+			repository.NewArticleRepository(componentDb),
+			&testutils.MockFeedArticleUsecase{},
+			&testutils.MockQiitaUsecase{},
+			usecase.NewBookUsecase(repository.NewBookRepository(componentDb), validator.NewBookValidator(), repository.NewSearchRepository(componentDb)),
+			&testutils.MockResponseMappingUsecase{},
+			0,
+		)
+		componentUsecase = usecase.NewLayoutComponentUsecase(componentRepo, repository.NewLayoutRepository(componentDb), componentValidator, componentData)
 	}
 
 	// 既存のテストコンポーネントを明示的に削除（念のため）
@@ -1,9 +1,29 @@
 package usecase
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"go-react-app/model"
 	"go-react-app/repository"
+	"go-react-app/utils/layoutgrid"
 	"go-react-app/validator"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidLayoutComponent はコンポーネントの種類・サイズ・プロパティが定義に適合しない場合のエラー
+	ErrInvalidLayoutComponent = errors.New("invalid layout component")
+	// ErrInvalidPosition は位置情報が不正な場合のエラー
+	ErrInvalidPosition = errors.New("invalid position")
+	// ErrPositionConflict は重なりを拒否するレイアウトで、他のコンポーネントと重なる位置が指定された場合のエラー
+	ErrPositionConflict = errors.New("position overlaps other components")
+	// ErrInvalidParent は親コンポーネントの指定が不正な場合（別のレイアウト・循環・入れ子が深すぎるなど）のエラー
+	ErrInvalidParent = errors.New("invalid parent component")
 )
 
 type ILayoutComponentUsecase interface {
@@ -11,20 +31,25 @@ type ILayoutComponentUsecase interface {
 	GetLayoutComponentById(userId uint, componentId uint) (model.LayoutComponentResponse, error)
 	CreateLayoutComponent(request model.LayoutComponentRequest) (model.LayoutComponentResponse, error)
 	UpdateLayoutComponent(request model.LayoutComponentRequest, userId uint, componentId uint) (model.LayoutComponentResponse, error)
-	DeleteLayoutComponent(userId uint, componentId uint) error
+	DeleteLayoutComponent(userId uint, componentId uint, children string) error
 	
 	AssignToLayout(userId uint, componentId uint, request model.AssignLayoutRequest) error
 	RemoveFromLayout(userId uint, componentId uint) error
 	UpdatePosition(userId uint, componentId uint, position model.PositionRequest) error
+	SetParent(userId uint, componentId uint, request model.ParentRequest) error
+	GetComponentData(userId uint, componentId uint) (model.ComponentDataResponse, error)
+	GetComponentTypes() []model.ComponentType
 }
 
 type layoutComponentUsecase struct {
 	lcr repository.ILayoutComponentRepository
+	lr  repository.ILayoutRepository
 	lcv validator.ILayoutComponentValidator
+	cdu IComponentDataUsecase
 }
 
-func NewLayoutComponentUsecase(lcr repository.ILayoutComponentRepository, lcv validator.ILayoutComponentValidator) ILayoutComponentUsecase {
-	return &layoutComponentUsecase{lcr, lcv}
+func NewLayoutComponentUsecase(lcr repository.ILayoutComponentRepository, lr repository.ILayoutRepository, lcv validator.ILayoutComponentValidator, cdu IComponentDataUsecase) ILayoutComponentUsecase {
+	return &layoutComponentUsecase{lcr, lr, lcv, cdu}
 }
 
 func (lcu *layoutComponentUsecase) GetAllLayoutComponents(userId uint) ([]model.LayoutComponentResponse, error) {
@@ -49,8 +74,18 @@ func (lcu *layoutComponentUsecase) GetLayoutComponentById(userId uint, component
 }
 
 func (lcu *layoutComponentUsecase) CreateLayoutComponent(request model.LayoutComponentRequest) (model.LayoutComponentResponse, error) {
+	request.Properties = normalizeProperties(request.Properties)
 	if err := lcu.lcv.ValidateLayoutComponentRequest(request); err != nil {
-		return model.LayoutComponentResponse{}, err
+		return model.LayoutComponentResponse{}, fmt.Errorf("%w: %v", ErrInvalidLayoutComponent, err)
+	}
+	// サイズが指定されていない場合は種類の既定のサイズで作成する
+	if componentType, ok := model.FindComponentType(request.Type); ok {
+		if request.Width == 0 {
+			request.Width = componentType.DefaultWidth
+		}
+		if request.Height == 0 {
+			request.Height = componentType.DefaultHeight
+		}
 	}
 	
 	component := request.ToModel()
@@ -62,8 +97,9 @@ func (lcu *layoutComponentUsecase) CreateLayoutComponent(request model.LayoutCom
 }
 
 func (lcu *layoutComponentUsecase) UpdateLayoutComponent(request model.LayoutComponentRequest, userId uint, componentId uint) (model.LayoutComponentResponse, error) {
+	request.Properties = normalizeProperties(request.Properties)
 	if err := lcu.lcv.ValidateLayoutComponentRequest(request); err != nil {
-		return model.LayoutComponentResponse{}, err
+		return model.LayoutComponentResponse{}, fmt.Errorf("%w: %v", ErrInvalidLayoutComponent, err)
 	}
 	
 	component := request.ToModel()
@@ -74,12 +110,44 @@ func (lcu *layoutComponentUsecase) UpdateLayoutComponent(request model.LayoutCom
 	return component.ToResponse(), nil
 }
 
-func (lcu *layoutComponentUsecase) DeleteLayoutComponent(userId uint, componentId uint) error {
-	return lcu.lcr.DeleteLayoutComponent(userId, componentId)
+// DeleteLayoutComponent はコンポーネントを削除します
+// 子コンポーネントは、childrenがdeleteの場合は一緒に削除し、それ以外の場合は削除するコンポーネントの親に付け替える
+func (lcu *layoutComponentUsecase) DeleteLayoutComponent(userId uint, componentId uint, children string) error {
+	switch children {
+	case "", model.ChildrenReparent:
+		return lcu.lcr.DeleteLayoutComponent(userId, componentId)
+	case model.ChildrenDelete:
+		return lcu.lcr.DeleteLayoutComponentTree(userId, componentId)
+	}
+	return fmt.Errorf("%w: childrenには%sまたは%sを指定してください", ErrInvalidLayoutComponent, model.ChildrenReparent, model.ChildrenDelete)
 }
 
 func (lcu *layoutComponentUsecase) AssignToLayout(userId uint, componentId uint, request model.AssignLayoutRequest) error {
-	return lcu.lcr.AssignToLayout(componentId, request.LayoutId, userId, request.Position)
+	if err := lcu.lcv.ValidateAssignLayoutRequest(request); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPosition, err)
+	}
+	component, err := lcu.lcr.GetLayoutComponentById(userId, componentId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("layout component does not exist")
+		}
+		return err
+	}
+	// 別のレイアウトに移動する場合、ブレークポイントごとの位置は移動元のレイアウトに合わせたものなので破棄し、レイアウトの直下に配置する
+	if component.LayoutId == nil || *component.LayoutId != request.LayoutId {
+		if component.LayoutId != nil {
+			source, err := lcu.lr.GetLayoutById(userId, *component.LayoutId)
+			if err != nil {
+				return err
+			}
+			if len(source.Descendants(component.ID)) > 0 {
+				return fmt.Errorf("%w: 子コンポーネントを持つコンポーネントは別のレイアウトに移動できません", ErrInvalidParent)
+			}
+		}
+		component.Breakpoints = ""
+		component.ParentId = nil
+	}
+	return lcu.place(userId, request.LayoutId, component, request.Position)
 }
 
 func (lcu *layoutComponentUsecase) RemoveFromLayout(userId uint, componentId uint) error {
@@ -87,5 +155,140 @@ func (lcu *layoutComponentUsecase) RemoveFromLayout(userId uint, componentId uin
 }
 
 func (lcu *layoutComponentUsecase) UpdatePosition(userId uint, componentId uint, position model.PositionRequest) error {
-	return lcu.lcr.UpdatePosition(componentId, userId, position)
+	if err := lcu.lcv.ValidatePositionRequest(position); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPosition, err)
+	}
+	component, err := lcu.lcr.GetLayoutComponentById(userId, componentId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("layout component does not exist")
+		}
+		return err
+	}
+	// レイアウトに割り当てられていない場合はエラー
+	if component.LayoutId == nil {
+		return errors.New("component is not assigned to any layout")
+	}
+	return lcu.place(userId, *component.LayoutId, component, position)
+}
+
+// SetParent はコンポーネントの親を変更します（parent_idがnullの場合はレイアウトの直下に移動する）
+// 親は同じレイアウトの、子を持てる種類のコンポーネントに限る。レイアウト上の位置を保つよう新しい親を基準にした位置に変換し、
+// 新しい親の子との重なりはレイアウトの設定に従って処理する
+func (lcu *layoutComponentUsecase) SetParent(userId uint, componentId uint, request model.ParentRequest) error {
+	component, err := lcu.lcr.GetLayoutComponentById(userId, componentId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("layout component does not exist")
+		}
+		return err
+	}
+	if component.LayoutId == nil {
+		return fmt.Errorf("%w: レイアウトに割り当てられていないコンポーネントです", ErrInvalidParent)
+	}
+	layout, err := lcu.lr.GetLayoutById(userId, *component.LayoutId)
+	if err != nil {
+		return err
+	}
+
+	if request.ParentId != nil {
+		if err := validateParent(layout, component, *request.ParentId); err != nil {
+			return err
+		}
+	}
+
+	previous := component.ParentId
+	component.MoveOrigin(func(breakpoint string) model.Geometry {
+		return layout.Origin(previous, breakpoint)
+	}, func(breakpoint string) model.Geometry {
+		return layout.Origin(request.ParentId, breakpoint)
+	})
+	component.ParentId = request.ParentId
+	return lcu.place(userId, layout.ID, component, model.PositionRequest{
+		X: component.X, Y: component.Y, Width: component.Width, Height: component.Height, Unit: model.PositionUnitGrid,
+	})
+}
+
+// validateParent はparentIdのコンポーネントをcomponentの親にできるかを検証します
+func validateParent(layout model.Layout, component model.LayoutComponent, parentId uint) error {
+	var parent *model.LayoutComponent
+	for i := range layout.Components {
+		if layout.Components[i].ID == parentId {
+			parent = &layout.Components[i]
+		}
+	}
+	if parent == nil {
+		return fmt.Errorf("%w: 親コンポーネント%dは同じレイアウトにありません", ErrInvalidParent, parentId)
+	}
+	if parent.ID == component.ID || slices.Contains(layout.Descendants(component.ID), parent.ID) {
+		return fmt.Errorf("%w: 自身または子孫のコンポーネントは親にできません", ErrInvalidParent)
+	}
+	if componentType, ok := model.FindComponentType(parent.Type); !ok || !componentType.Container {
+		return fmt.Errorf("%w: %sは子コンポーネントを持てません", ErrInvalidParent, parent.Name)
+	}
+	if layout.Depth(parent.ID)+layout.SubtreeHeight(component.ID) > model.MaxComponentDepth {
+		return fmt.Errorf("%w: コンポーネントの入れ子は%d階層までです", ErrInvalidParent, model.MaxComponentDepth)
+	}
+	return nil
+}
+
+// place はコンポーネントをレイアウトのグリッドに合わせて配置し、重なりをレイアウトの設定に従って処理します
+// 押し出されたコンポーネントも含めて1つのトランザクションで保存する
+func (lcu *layoutComponentUsecase) place(userId uint, layoutId uint, component model.LayoutComponent, position model.PositionRequest) error {
+	layout, err := lcu.lr.GetLayoutById(userId, layoutId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("layout does not exist")
+		}
+		return err
+	}
+
+	settings := layout.GridSettings()
+	component = layoutgrid.Snap(settings, component, position)
+
+	changed := []model.LayoutComponent{component}
+	switch settings.CollisionMode {
+	case model.CollisionModeReject:
+		if overlapping := layoutgrid.Overlapping(component, layout.Components); len(overlapping) > 0 {
+			names := make([]string, len(overlapping))
+			for i, other := range overlapping {
+				names[i] = other.Name
+			}
+			return fmt.Errorf("%w: %s", ErrPositionConflict, strings.Join(names, ", "))
+		}
+	default:
+		changed = append(changed, layoutgrid.PushDown(component, layout.Components)...)
+	}
+	return lcu.lcr.SavePositions(userId, layoutId, changed)
+}
+
+// GetComponentData はデータを取得する種類のコンポーネントに表示するデータを返します
+func (lcu *layoutComponentUsecase) GetComponentData(userId uint, componentId uint) (model.ComponentDataResponse, error) {
+	component, err := lcu.lcr.GetLayoutComponentById(userId, componentId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ComponentDataResponse{}, errors.New("layout component does not exist")
+		}
+		return model.ComponentDataResponse{}, err
+	}
+	return lcu.cdu.ResolveComponentData(userId, component)
+}
+
+// GetComponentTypes は利用できるコンポーネントの種類の一覧を返します
+func (lcu *layoutComponentUsecase) GetComponentTypes() []model.ComponentType {
+	return model.ComponentTypes
+}
+
+// normalizeProperties はpropertiesを保存する形式に揃えます
+// フロントエンドはpropertiesをJSON文字列として扱うことがあるため、文字列の場合は中身のJSONを取り出す
+func normalizeProperties(properties json.RawMessage) json.RawMessage {
+	trimmed := bytes.TrimSpace(properties)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil
+	}
+	var encoded string
+	if trimmed[0] == '"' && json.Unmarshal(trimmed, &encoded) == nil {
+		return normalizeProperties(json.RawMessage(encoded))
+	}
+	return trimmed
 }
@@ -0,0 +1,78 @@
+package layout_snapshot_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"strings"
+	"testing"
+)
+
+func TestLayoutSnapshotUsecase_CreateSnapshot(t *testing.T) {
+	setupLayoutSnapshotUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("レイアウトの現在の状態を保存できる", func(t *testing.T) {
+			layout, components := createTestLayout(t)
+
+			snapshot := createTestSnapshot(t, layout.ID, "初版")
+
+			if snapshot.ID == 0 {
+				t.Error("CreateSnapshot() IDが設定されていません")
+			}
+			if snapshot.Label != "初版" || snapshot.Title != layout.Title || snapshot.Version != layout.Version {
+				t.Errorf("CreateSnapshot() = %+v, want label=初版 title=%s version=%d", snapshot, layout.Title, layout.Version)
+			}
+			if snapshot.ComponentCount != len(components) || snapshot.Data == nil || len(snapshot.Data.Components) != len(components) {
+				t.Fatalf("CreateSnapshot() コンポーネント数 = %d, want %d", snapshot.ComponentCount, len(components))
+			}
+			if snapshot.Data.Grid.CanvasWidth != 1200 {
+				t.Errorf("CreateSnapshot() canvas_width = %d, want 1200", snapshot.Data.Grid.CanvasWidth)
+			}
+		})
+
+		t.Run("一覧は新しい順でデータを含まない", func(t *testing.T) {
+			layout, _ := createTestLayout(t)
+			first := createTestSnapshot(t, layout.ID, "1回目")
+			second := createTestSnapshot(t, layout.ID, "2回目")
+
+			snapshots, err := snapshotUsecase.GetSnapshots(testUserId, layout.ID)
+			if err != nil {
+				t.Fatalf("GetSnapshots() error = %v", err)
+			}
+			if len(snapshots) != 2 || snapshots[0].ID != second.ID || snapshots[1].ID != first.ID {
+				t.Fatalf("GetSnapshots() = %+v, want [%d, %d]", snapshots, second.ID, first.ID)
+			}
+			if snapshots[0].Data != nil {
+				t.Error("GetSnapshots() 一覧にデータが含まれています")
+			}
+
+			snapshot, err := snapshotUsecase.GetSnapshotById(testUserId, layout.ID, first.ID)
+			if err != nil {
+				t.Fatalf("GetSnapshotById() error = %v", err)
+			}
+			if snapshot.Data == nil || snapshot.Label != "1回目" {
+				t.Errorf("GetSnapshotById() = %+v", snapshot)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("ラベルが長すぎる場合はエラー", func(t *testing.T) {
+			layout, _ := createTestLayout(t)
+
+			_, err := snapshotUsecase.CreateSnapshot(testUserId, layout.ID, model.LayoutSnapshotRequest{Label: strings.Repeat("あ", 101)})
+			if !errors.Is(err, usecase.ErrInvalidSnapshot) {
+				t.Errorf("CreateSnapshot() error = %v, want ErrInvalidSnapshot", err)
+			}
+		})
+
+		t.Run("他のユーザーのレイアウトは保存できない", func(t *testing.T) {
+			layout, _ := createTestLayout(t)
+
+			if _, err := snapshotUsecase.CreateSnapshot(testUserId+1, layout.ID, model.LayoutSnapshotRequest{}); err == nil {
+				t.Error("CreateSnapshot() エラーが返されませんでした")
+			}
+		})
+	})
+}
@@ -0,0 +1,77 @@
+package layout_snapshot_test
+
+import (
+	"go-react-app/model"
+	"testing"
+)
+
+func TestLayoutSnapshotUsecase_DiffSnapshot(t *testing.T) {
+	setupLayoutSnapshotUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("現在のレイアウトとの差分を取得できる", func(t *testing.T) {
+			layout, components := createTestLayout(t)
+			snapshot := createTestSnapshot(t, layout.ID, "")
+
+			// タイトルの変更・本文の移動・ヘッダーの削除・フッターの追加
+			snapshotDb.Model(&layout).Update("title", "新しいタイトル")
+			snapshotDb.Model(&components[1]).Updates(map[string]interface{}{"y": 0})
+			snapshotDb.Delete(&components[0])
+			footer := model.LayoutComponent{Name: "フッター", Type: "footer", X: 0, Y: 10, Width: 12, Height: 2, UserId: testUserId, LayoutId: &layout.ID}
+			snapshotDb.Create(&footer)
+
+			diff, err := snapshotUsecase.DiffSnapshot(testUserId, layout.ID, snapshot.ID, nil)
+			if err != nil {
+				t.Fatalf("DiffSnapshot() error = %v", err)
+			}
+			if diff.FromSnapshotId != snapshot.ID || diff.ToSnapshotId != nil {
+				t.Errorf("DiffSnapshot() from=%d to=%v", diff.FromSnapshotId, diff.ToSnapshotId)
+			}
+			if len(diff.Changes) != 1 || diff.Changes[0].Field != "title" || diff.Changes[0].To != "新しいタイトル" {
+				t.Errorf("DiffSnapshot() changes = %+v", diff.Changes)
+			}
+			if len(diff.Added) != 1 || diff.Added[0].ID != footer.ID {
+				t.Errorf("DiffSnapshot() added = %+v", diff.Added)
+			}
+			if len(diff.Removed) != 1 || diff.Removed[0].ID != components[0].ID {
+				t.Errorf("DiffSnapshot() removed = %+v", diff.Removed)
+			}
+			if len(diff.Changed) != 1 || diff.Changed[0].ComponentId != components[1].ID {
+				t.Fatalf("DiffSnapshot() changed = %+v", diff.Changed)
+			}
+			if changes := diff.Changed[0].Changes; len(changes) != 1 || changes[0].Field != "y" || changes[0].From != 2 || changes[0].To != 0 {
+				t.Errorf("DiffSnapshot() component changes = %+v", changes)
+			}
+		})
+
+		t.Run("スナップショット同士の差分を取得できる", func(t *testing.T) {
+			layout, _ := createTestLayout(t)
+			from := createTestSnapshot(t, layout.ID, "")
+			snapshotDb.Model(&layout).Update("grid_columns", 24)
+			to := createTestSnapshot(t, layout.ID, "")
+			// 比較先のスナップショットより後の変更は含まない
+			snapshotDb.Model(&layout).Update("title", "変更後")
+
+			diff, err := snapshotUsecase.DiffSnapshot(testUserId, layout.ID, from.ID, &to.ID)
+			if err != nil {
+				t.Fatalf("DiffSnapshot() error = %v", err)
+			}
+			if len(diff.Changes) != 1 || diff.Changes[0].Field != "grid_columns" {
+				t.Errorf("DiffSnapshot() changes = %+v", diff.Changes)
+			}
+			if len(diff.Added)+len(diff.Removed)+len(diff.Changed) != 0 {
+				t.Errorf("DiffSnapshot() コンポーネントの差分が返されました: %+v", diff)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("存在しないスナップショットはエラー", func(t *testing.T) {
+			layout, _ := createTestLayout(t)
+
+			if _, err := snapshotUsecase.DiffSnapshot(testUserId, layout.ID, 9999, nil); err == nil {
+				t.Error("DiffSnapshot() エラーが返されませんでした")
+			}
+		})
+	})
+}
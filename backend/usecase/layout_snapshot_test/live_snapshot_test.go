@@ -0,0 +1,76 @@
+package layout_snapshot_test
+
+import (
+	"testing"
+)
+
+func TestLayoutSnapshotUsecase_SetLiveSnapshot(t *testing.T) {
+	setupLayoutSnapshotUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("公開中のスナップショットは編集しても公開される状態が変わらない", func(t *testing.T) {
+			layout, components := createTestLayout(t)
+			snapshot := createTestSnapshot(t, layout.ID, "公開版")
+
+			res, err := snapshotUsecase.SetLiveSnapshot(testUserId, layout.ID, snapshot.ID)
+			if err != nil {
+				t.Fatalf("SetLiveSnapshot() error = %v", err)
+			}
+			if res.LiveSnapshotId == nil || *res.LiveSnapshotId != snapshot.ID {
+				t.Fatalf("SetLiveSnapshot() live_snapshot_id = %v, want %d", res.LiveSnapshotId, snapshot.ID)
+			}
+
+			title := layout.Title
+			snapshotDb.Model(&layout).Update("title", "編集中")
+			snapshotDb.Model(&components[1]).Update("y", 20)
+
+			published, err := layoutRepo.GetPublishedLayout(testUserId, layout.ID)
+			if err != nil {
+				t.Fatalf("GetPublishedLayout() error = %v", err)
+			}
+			if published.Title != title || len(published.Components) != 2 || published.Components[1].Y != 2 {
+				t.Errorf("GetPublishedLayout() = %+v, want スナップショットの状態", published)
+			}
+
+			snapshots, _ := snapshotUsecase.GetSnapshots(testUserId, layout.ID)
+			if len(snapshots) != 1 || !snapshots[0].Live {
+				t.Errorf("GetSnapshots() live = %+v", snapshots)
+			}
+		})
+
+		t.Run("公開を解除すると編集中の状態が公開される", func(t *testing.T) {
+			layout, _ := createTestLayout(t)
+			snapshot := createTestSnapshot(t, layout.ID, "")
+			if _, err := snapshotUsecase.SetLiveSnapshot(testUserId, layout.ID, snapshot.ID); err != nil {
+				t.Fatalf("SetLiveSnapshot() error = %v", err)
+			}
+			snapshotDb.Model(&layout).Update("title", "編集中")
+
+			res, err := snapshotUsecase.ClearLiveSnapshot(testUserId, layout.ID)
+			if err != nil {
+				t.Fatalf("ClearLiveSnapshot() error = %v", err)
+			}
+			if res.LiveSnapshotId != nil {
+				t.Errorf("ClearLiveSnapshot() live_snapshot_id = %v, want nil", *res.LiveSnapshotId)
+			}
+
+			published, err := layoutRepo.GetPublishedLayout(testUserId, layout.ID)
+			if err != nil {
+				t.Fatalf("GetPublishedLayout() error = %v", err)
+			}
+			if published.Title != "編集中" {
+				t.Errorf("GetPublishedLayout() title = %s, want 編集中", published.Title)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("存在しないスナップショットは公開できない", func(t *testing.T) {
+			layout, _ := createTestLayout(t)
+
+			if _, err := snapshotUsecase.SetLiveSnapshot(testUserId, layout.ID, 9999); err == nil {
+				t.Error("SetLiveSnapshot() エラーが返されませんでした")
+			}
+		})
+	})
+}
@@ -0,0 +1,76 @@
+package layout_snapshot_test
+
+import (
+	"go-react-app/model"
+	"testing"
+)
+
+func TestLayoutSnapshotUsecase_RollbackSnapshot(t *testing.T) {
+	setupLayoutSnapshotUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("スナップショットの状態に戻し直前の状態を自動で保存する", func(t *testing.T) {
+			layout, components := createTestLayout(t)
+			snapshot := createTestSnapshot(t, layout.ID, "初版")
+			title, version := layout.Title, layout.Version
+
+			snapshotDb.Model(&layout).Updates(map[string]interface{}{"title": "変更後", "grid_columns": 24})
+			snapshotDb.Model(&components[1]).Updates(map[string]interface{}{"x": 4, "width": 6})
+			snapshotDb.Delete(&components[0])
+			extra := model.LayoutComponent{Name: "追加", Type: "text", X: 0, Y: 20, Width: 4, Height: 2, UserId: testUserId, LayoutId: &layout.ID}
+			snapshotDb.Create(&extra)
+
+			res, err := snapshotUsecase.RollbackSnapshot(testUserId, layout.ID, snapshot.ID)
+			if err != nil {
+				t.Fatalf("RollbackSnapshot() error = %v", err)
+			}
+			if res.Title != title || res.Grid.Columns != 12 {
+				t.Errorf("RollbackSnapshot() title=%s columns=%d, want %s 12", res.Title, res.Grid.Columns, title)
+			}
+			if res.Version != version+1 {
+				t.Errorf("RollbackSnapshot() version = %d, want %d", res.Version, version+1)
+			}
+			if len(res.Components) != 2 {
+				t.Fatalf("RollbackSnapshot() コンポーネント数 = %d, want 2", len(res.Components))
+			}
+
+			// 既存のコンポーネントは同じIDのまま戻る
+			main := findComponent(t, components[1].ID)
+			if main.X != 0 || main.Width != 8 {
+				t.Errorf("RollbackSnapshot() 本文 x=%d width=%d, want 0 8", main.X, main.Width)
+			}
+			// 削除されたコンポーネントは作り直される
+			var header model.LayoutComponent
+			if err := snapshotDb.Where("layout_id = ? AND name = ?", layout.ID, "ヘッダー").First(&header).Error; err != nil {
+				t.Fatalf("RollbackSnapshot() ヘッダーが戻っていません: %v", err)
+			}
+			if header.Width != 12 || header.Content != "<h1>タイトル</h1>" {
+				t.Errorf("RollbackSnapshot() ヘッダー = %+v", header)
+			}
+			// スナップショットにないコンポーネントはレイアウトから外される
+			if detached := findComponent(t, extra.ID); detached.LayoutId != nil {
+				t.Errorf("RollbackSnapshot() 追加したコンポーネントがレイアウトに残っています")
+			}
+
+			snapshots, err := snapshotUsecase.GetSnapshots(testUserId, layout.ID)
+			if err != nil {
+				t.Fatalf("GetSnapshots() error = %v", err)
+			}
+			if len(snapshots) != 2 || snapshots[0].Title != "変更後" || snapshots[0].ComponentCount != 2 {
+				t.Errorf("RollbackSnapshot() 自動保存 = %+v", snapshots)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("他のレイアウトのスナップショットには戻せない", func(t *testing.T) {
+			layout, _ := createTestLayout(t)
+			other, _ := createTestLayout(t)
+			snapshot := createTestSnapshot(t, other.ID, "")
+
+			if _, err := snapshotUsecase.RollbackSnapshot(testUserId, layout.ID, snapshot.ID); err == nil {
+				t.Error("RollbackSnapshot() エラーが返されませんでした")
+			}
+		})
+	})
+}
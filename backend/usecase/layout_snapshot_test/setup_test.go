@@ -0,0 +1,85 @@
+package layout_snapshot_test
+
+import (
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/validator"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// テスト用の共通変数
+var (
+	snapshotDb      *gorm.DB
+	layoutRepo      repository.ILayoutRepository
+	snapshotRepo    repository.ILayoutSnapshotRepository
+	snapshotUsecase usecase.ILayoutSnapshotUsecase
+	testUserId      uint = 1 // テスト用ユーザーID
+)
+
+// テスト前の共通セットアップ
+func setupLayoutSnapshotUsecaseTest() {
+	// テストごとにデータベースをクリーンアップ
+	if snapshotDb != nil {
+		testutils.CleanupTestDB(snapshotDb)
+	} else {
+		// 初回のみデータベース接続を作成
+		snapshotDb = testutils.SetupTestDB()
+		layoutRepo = repository.NewLayoutRepository(snapshotDb)
+		snapshotRepo = repository.NewLayoutSnapshotRepository(snapshotDb)
+		snapshotUsecase = usecase.NewLayoutSnapshotUsecase(snapshotRepo, layoutRepo, validator.NewLayoutSnapshotValidator())
+	}
+
+	// 既存のテストデータを明示的に削除（念のため）
+	snapshotDb.Exec("DELETE FROM layout_snapshots WHERE user_id = ?", testUserId)
+	snapshotDb.Exec("DELETE FROM layout_components WHERE user_id = ?", testUserId)
+	snapshotDb.Exec("DELETE FROM layouts WHERE user_id = ?", testUserId)
+}
+
+// テスト用にコンポーネントを配置したレイアウトを作成
+func createTestLayout(t *testing.T) (model.Layout, []model.LayoutComponent) {
+	layout := model.Layout{
+		Title:       fmt.Sprintf("スナップショットのテスト %d", time.Now().UnixNano()),
+		GridColumns: 12,
+		RowHeight:   30,
+		CanvasWidth: 1200,
+		UserId:      testUserId,
+	}
+	if err := snapshotDb.Create(&layout).Error; err != nil {
+		t.Fatalf("テストレイアウトの作成に失敗しました: %v", err)
+	}
+	components := []model.LayoutComponent{
+		{Name: "ヘッダー", Type: "header", Content: "<h1>タイトル</h1>", X: 0, Y: 0, Width: 12, Height: 2},
+		{Name: "本文", Type: "main", Content: "本文", X: 0, Y: 2, Width: 8, Height: 10},
+	}
+	for i := range components {
+		components[i].UserId = testUserId
+		components[i].LayoutId = &layout.ID
+	}
+	if err := snapshotDb.Create(&components).Error; err != nil {
+		t.Fatalf("テストコンポーネントの作成に失敗しました: %v", err)
+	}
+	return layout, components
+}
+
+// テスト用のスナップショットを作成
+func createTestSnapshot(t *testing.T, layoutId uint, label string) model.LayoutSnapshotResponse {
+	snapshot, err := snapshotUsecase.CreateSnapshot(testUserId, layoutId, model.LayoutSnapshotRequest{Label: label})
+	if err != nil {
+		t.Fatalf("テストスナップショットの作成に失敗しました: %v", err)
+	}
+	return snapshot
+}
+
+func findComponent(t *testing.T, componentId uint) model.LayoutComponent {
+	var component model.LayoutComponent
+	if err := snapshotDb.First(&component, componentId).Error; err != nil {
+		t.Fatalf("コンポーネントの取得に失敗しました: %v", err)
+	}
+	return component
+}
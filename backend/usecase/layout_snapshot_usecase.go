@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/validator"
+	"reflect"
+)
+
+// rollbackBackupLabel ロールバックの直前に自動で保存するスナップショットのラベル
+const rollbackBackupLabel = "ロールバック前の自動保存"
+
+// ErrInvalidSnapshot はスナップショットのリクエストが不正な場合のエラー
+var ErrInvalidSnapshot = errors.New("invalid snapshot request")
+
+type ILayoutSnapshotUsecase interface {
+	GetSnapshots(userId uint, layoutId uint) ([]model.LayoutSnapshotResponse, error)
+	GetSnapshotById(userId uint, layoutId uint, snapshotId uint) (model.LayoutSnapshotResponse, error)
+	CreateSnapshot(userId uint, layoutId uint, request model.LayoutSnapshotRequest) (model.LayoutSnapshotResponse, error)
+	DiffSnapshot(userId uint, layoutId uint, snapshotId uint, toSnapshotId *uint) (model.LayoutDiffResponse, error)
+	RollbackSnapshot(userId uint, layoutId uint, snapshotId uint) (model.LayoutResponse, error)
+	SetLiveSnapshot(userId uint, layoutId uint, snapshotId uint) (model.LayoutResponse, error)
+	ClearLiveSnapshot(userId uint, layoutId uint) (model.LayoutResponse, error)
+}
+
+type layoutSnapshotUsecase struct {
+	sr repository.ILayoutSnapshotRepository
+	lr repository.ILayoutRepository
+	sv validator.ILayoutSnapshotValidator
+}
+
+func NewLayoutSnapshotUsecase(sr repository.ILayoutSnapshotRepository, lr repository.ILayoutRepository, sv validator.ILayoutSnapshotValidator) ILayoutSnapshotUsecase {
+	return &layoutSnapshotUsecase{sr, lr, sv}
+}
+
+func (su *layoutSnapshotUsecase) GetSnapshots(userId uint, layoutId uint) ([]model.LayoutSnapshotResponse, error) {
+	layout, err := su.lr.GetLayoutById(userId, layoutId)
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := su.sr.GetSnapshots(userId, layoutId)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.LayoutSnapshotResponse, len(snapshots))
+	for i, snapshot := range snapshots {
+		if responses[i], err = snapshot.ToResponse(isLive(layout, snapshot.ID), false); err != nil {
+			return nil, err
+		}
+	}
+	return responses, nil
+}
+
+func (su *layoutSnapshotUsecase) GetSnapshotById(userId uint, layoutId uint, snapshotId uint) (model.LayoutSnapshotResponse, error) {
+	layout, err := su.lr.GetLayoutById(userId, layoutId)
+	if err != nil {
+		return model.LayoutSnapshotResponse{}, err
+	}
+	snapshot, err := su.sr.GetSnapshotById(userId, layoutId, snapshotId)
+	if err != nil {
+		return model.LayoutSnapshotResponse{}, err
+	}
+	return snapshot.ToResponse(isLive(layout, snapshot.ID), true)
+}
+
+// CreateSnapshot はレイアウトの現在の状態をスナップショットとして保存します
+func (su *layoutSnapshotUsecase) CreateSnapshot(userId uint, layoutId uint, request model.LayoutSnapshotRequest) (model.LayoutSnapshotResponse, error) {
+	if err := su.sv.ValidateLayoutSnapshotRequest(request); err != nil {
+		return model.LayoutSnapshotResponse{}, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	layout, err := su.lr.GetLayoutById(userId, layoutId)
+	if err != nil {
+		return model.LayoutSnapshotResponse{}, err
+	}
+	snapshot, err := su.saveSnapshot(layout, request.Label)
+	if err != nil {
+		return model.LayoutSnapshotResponse{}, err
+	}
+	return snapshot.ToResponse(false, true)
+}
+
+// DiffSnapshot はスナップショットと、別のスナップショット（toSnapshotIdがnilの場合は現在のレイアウト）との差分を返します
+func (su *layoutSnapshotUsecase) DiffSnapshot(userId uint, layoutId uint, snapshotId uint, toSnapshotId *uint) (model.LayoutDiffResponse, error) {
+	from, err := su.snapshotData(userId, layoutId, snapshotId)
+	if err != nil {
+		return model.LayoutDiffResponse{}, err
+	}
+
+	var to model.SnapshotData
+	if toSnapshotId != nil {
+		if to, err = su.snapshotData(userId, layoutId, *toSnapshotId); err != nil {
+			return model.LayoutDiffResponse{}, err
+		}
+	} else {
+		layout, err := su.lr.GetLayoutById(userId, layoutId)
+		if err != nil {
+			return model.LayoutDiffResponse{}, err
+		}
+		to = model.NewSnapshotData(layout)
+	}
+
+	diff := diffSnapshotData(from, to)
+	diff.FromSnapshotId = snapshotId
+	diff.ToSnapshotId = toSnapshotId
+	return diff, nil
+}
+
+// RollbackSnapshot はレイアウトをスナップショットの状態に戻します
+// 戻す前の状態は自動でスナップショットとして保存するため、ロールバック自体も元に戻せる
+func (su *layoutSnapshotUsecase) RollbackSnapshot(userId uint, layoutId uint, snapshotId uint) (model.LayoutResponse, error) {
+	data, err := su.snapshotData(userId, layoutId, snapshotId)
+	if err != nil {
+		return model.LayoutResponse{}, err
+	}
+	layout, err := su.lr.GetLayoutById(userId, layoutId)
+	if err != nil {
+		return model.LayoutResponse{}, err
+	}
+	if _, err := su.saveSnapshot(layout, rollbackBackupLabel); err != nil {
+		return model.LayoutResponse{}, err
+	}
+	if err := su.sr.RestoreSnapshot(userId, layoutId, data); err != nil {
+		return model.LayoutResponse{}, err
+	}
+	return su.layoutResponse(userId, layoutId)
+}
+
+// SetLiveSnapshot はスナップショットを公開中のバージョンにします
+// 公開中のスナップショットがある間は、レイアウトを編集しても公開されるページ（静的サイトの書き出しなど）は変わらない
+func (su *layoutSnapshotUsecase) SetLiveSnapshot(userId uint, layoutId uint, snapshotId uint) (model.LayoutResponse, error) {
+	if _, err := su.sr.GetSnapshotById(userId, layoutId, snapshotId); err != nil {
+		return model.LayoutResponse{}, err
+	}
+	if err := su.sr.SetLiveSnapshot(userId, layoutId, &snapshotId); err != nil {
+		return model.LayoutResponse{}, err
+	}
+	return su.layoutResponse(userId, layoutId)
+}
+
+// ClearLiveSnapshot は公開中のスナップショットを解除し、編集中の状態を公開するようにします
+func (su *layoutSnapshotUsecase) ClearLiveSnapshot(userId uint, layoutId uint) (model.LayoutResponse, error) {
+	if err := su.sr.SetLiveSnapshot(userId, layoutId, nil); err != nil {
+		return model.LayoutResponse{}, err
+	}
+	return su.layoutResponse(userId, layoutId)
+}
+
+func (su *layoutSnapshotUsecase) saveSnapshot(layout model.Layout, label string) (model.LayoutSnapshot, error) {
+	snapshot, err := model.NewLayoutSnapshot(layout, label)
+	if err != nil {
+		return model.LayoutSnapshot{}, err
+	}
+	if err := su.sr.CreateSnapshot(&snapshot); err != nil {
+		return model.LayoutSnapshot{}, err
+	}
+	return snapshot, nil
+}
+
+func (su *layoutSnapshotUsecase) snapshotData(userId uint, layoutId uint, snapshotId uint) (model.SnapshotData, error) {
+	snapshot, err := su.sr.GetSnapshotById(userId, layoutId, snapshotId)
+	if err != nil {
+		return model.SnapshotData{}, err
+	}
+	return snapshot.ParseData()
+}
+
+func (su *layoutSnapshotUsecase) layoutResponse(userId uint, layoutId uint) (model.LayoutResponse, error) {
+	layout, err := su.lr.GetLayoutById(userId, layoutId)
+	if err != nil {
+		return model.LayoutResponse{}, err
+	}
+	return layout.ToResponse(), nil
+}
+
+func isLive(layout model.Layout, snapshotId uint) bool {
+	return layout.LiveSnapshotId != nil && *layout.LiveSnapshotId == snapshotId
+}
+
+// diffSnapshotData はfromからtoへの変更を、レイアウトの項目・追加・削除・変更されたコンポーネントに分けて返します
+func diffSnapshotData(from model.SnapshotData, to model.SnapshotData) model.LayoutDiffResponse {
+	diff := model.LayoutDiffResponse{
+		Changes: []model.FieldChange{},
+		Added:   []model.SnapshotComponent{},
+		Removed: []model.SnapshotComponent{},
+		Changed: []model.ComponentChange{},
+	}
+	diff.Changes = appendChange(diff.Changes, "title", from.Title, to.Title)
+	diff.Changes = appendChange(diff.Changes, "grid_columns", from.Grid.Columns, to.Grid.Columns)
+	diff.Changes = appendChange(diff.Changes, "row_height", from.Grid.RowHeight, to.Grid.RowHeight)
+	diff.Changes = appendChange(diff.Changes, "canvas_width", from.Grid.CanvasWidth, to.Grid.CanvasWidth)
+	diff.Changes = appendChange(diff.Changes, "collision_mode", from.Grid.CollisionMode, to.Grid.CollisionMode)
+
+	before := make(map[uint]model.SnapshotComponent, len(from.Components))
+	for _, component := range from.Components {
+		before[component.ID] = component
+	}
+	for _, after := range to.Components {
+		previous, ok := before[after.ID]
+		if !ok {
+			diff.Added = append(diff.Added, after)
+			continue
+		}
+		delete(before, after.ID)
+
+		changes := []model.FieldChange{}
+		changes = appendChange(changes, "name", previous.Name, after.Name)
+		changes = appendChange(changes, "type", previous.Type, after.Type)
+		changes = appendChange(changes, "content", previous.Content, after.Content)
+		if string(previous.Properties) != string(after.Properties) {
+			changes = append(changes, model.FieldChange{Field: "properties", From: previous.Properties, To: after.Properties})
+		}
+		changes = appendChange(changes, "x", previous.X, after.X)
+		changes = appendChange(changes, "y", previous.Y, after.Y)
+		changes = appendChange(changes, "width", previous.Width, after.Width)
+		changes = appendChange(changes, "height", previous.Height, after.Height)
+		if !reflect.DeepEqual(previous.Breakpoints, after.Breakpoints) {
+			changes = append(changes, model.FieldChange{Field: "breakpoints", From: previous.Breakpoints, To: after.Breakpoints})
+		}
+		if !reflect.DeepEqual(previous.ParentId, after.ParentId) {
+			changes = append(changes, model.FieldChange{Field: "parent_id", From: previous.ParentId, To: after.ParentId})
+		}
+		changes = appendChange(changes, "z_index", previous.ZIndex, after.ZIndex)
+		if len(changes) > 0 {
+			diff.Changed = append(diff.Changed, model.ComponentChange{ComponentId: after.ID, Name: after.Name, Changes: changes})
+		}
+	}
+	// fromの順序を保って削除されたコンポーネントを返す
+	for _, component := range from.Components {
+		if _, removed := before[component.ID]; removed {
+			diff.Removed = append(diff.Removed, component)
+		}
+	}
+	return diff
+}
+
+func appendChange[T comparable](changes []model.FieldChange, field string, from T, to T) []model.FieldChange {
+	if from == to {
+		return changes
+	}
+	return append(changes, model.FieldChange{Field: field, From: from, To: to})
+}
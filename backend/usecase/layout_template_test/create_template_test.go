@@ -0,0 +1,135 @@
+package layout_template_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"go-react-app/validator"
+	"testing"
+)
+
+func TestLayoutTemplateUsecase_CreateTemplate(t *testing.T) {
+	setupLayoutTemplateUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("レイアウトの構成を本文を除いて公開する", func(t *testing.T) {
+			layout, components := createTestLayout(t)
+
+			template, err := templateUsecase.CreateTemplate(model.LayoutTemplateRequest{
+				LayoutId:    layout.ID,
+				Name:        "2カラム",
+				Description: "サイドバーのある構成",
+				UserId:      testUserId,
+			})
+			if err != nil {
+				t.Fatalf("CreateTemplate() error = %v", err)
+			}
+			if template.ID == 0 || template.Name != "2カラム" || !template.Own {
+				t.Errorf("CreateTemplate() = %+v", template)
+			}
+			if template.Grid.Columns != 12 || template.Grid.RowHeight != 40 || template.ComponentCount != len(components) {
+				t.Errorf("CreateTemplate() grid=%+v count=%d", template.Grid, template.ComponentCount)
+			}
+			// IDは元のコンポーネントのIDではなく、テンプレート内の連番に置き換えられる
+			for i, component := range template.Data.Components {
+				if component.Content != "" || component.ID != uint(i+1) {
+					t.Errorf("CreateTemplate() 本文または元のIDが公開されています: %+v", component)
+				}
+			}
+			// タイトルやリンクは公開したユーザーの内容のため取り除き、見た目の設定だけを引き継ぐ
+			if string(template.Data.Components[0].Properties) != `{"backgroundColor":"#333333"}` {
+				t.Errorf("CreateTemplate() properties = %s", template.Data.Components[0].Properties)
+			}
+		})
+
+		t.Run("画像のURLを公開せず、取得元を選び直す必要があるコンポーネントは含めない", func(t *testing.T) {
+			layout := createDataLayout(t)
+
+			template, err := templateUsecase.CreateTemplate(model.LayoutTemplateRequest{LayoutId: layout.ID, Name: "データ", UserId: testUserId})
+			if err != nil {
+				t.Fatalf("CreateTemplate() error = %v", err)
+			}
+			// フィードと外部APIは取得元のIDが必須のため、取り除くと種類の定義に適合しなくなる
+			expected := map[string]string{"image": "", "latest-articles": `{"limit":5}`}
+			if len(template.Data.Components) != len(expected) {
+				t.Fatalf("CreateTemplate() components = %+v", template.Data.Components)
+			}
+			for _, component := range template.Data.Components {
+				want, ok := expected[component.Type]
+				if !ok || string(component.Properties) != want {
+					t.Errorf("CreateTemplate() %s properties = %s, want %q", component.Type, component.Properties, want)
+				}
+			}
+		})
+
+		t.Run("テンプレートから作成したコンポーネントは種類の定義に適合する", func(t *testing.T) {
+			layout := createDataLayout(t)
+			template := createTestTemplate(t, layout.ID, "データの検証")
+
+			res, err := templateUsecase.InstantiateTemplate(otherUserId, template.ID, model.InstantiateTemplateRequest{})
+			if err != nil {
+				t.Fatalf("InstantiateTemplate() error = %v", err)
+			}
+			if len(res.Components) != 2 {
+				t.Fatalf("InstantiateTemplate() components = %+v", res.Components)
+			}
+			componentValidator := validator.NewLayoutComponentValidator()
+			for _, component := range res.Components {
+				err := componentValidator.ValidateLayoutComponentRequest(model.LayoutComponentRequest{
+					Name:       component.Name,
+					Type:       component.Type,
+					Properties: component.Properties,
+					Width:      component.Width,
+					Height:     component.Height,
+				})
+				if err != nil {
+					t.Errorf("%sのコンポーネントが定義に適合しません: %v", component.Type, err)
+				}
+			}
+		})
+
+		t.Run("ギャラリーには他のユーザーのテンプレートも表示される", func(t *testing.T) {
+			layout, _ := createTestLayout(t)
+			template := createTestTemplate(t, layout.ID, "ギャラリー")
+
+			templates, err := templateUsecase.GetAllTemplates(otherUserId)
+			if err != nil {
+				t.Fatalf("GetAllTemplates() error = %v", err)
+			}
+			if len(templates) == 0 || templates[0].ID != template.ID {
+				t.Fatalf("GetAllTemplates() = %+v", templates)
+			}
+			if templates[0].Own || templates[0].Data != nil {
+				t.Errorf("GetAllTemplates() own=%v data=%v", templates[0].Own, templates[0].Data)
+			}
+
+			detail, err := templateUsecase.GetTemplateById(otherUserId, template.ID)
+			if err != nil {
+				t.Fatalf("GetTemplateById() error = %v", err)
+			}
+			if detail.Data == nil || len(detail.Data.Components) != 2 {
+				t.Errorf("GetTemplateById() data = %+v", detail.Data)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("テンプレート名がない場合はエラー", func(t *testing.T) {
+			layout, _ := createTestLayout(t)
+
+			_, err := templateUsecase.CreateTemplate(model.LayoutTemplateRequest{LayoutId: layout.ID, UserId: testUserId})
+			if !errors.Is(err, usecase.ErrInvalidLayoutTemplate) {
+				t.Errorf("CreateTemplate() error = %v, want ErrInvalidLayoutTemplate", err)
+			}
+		})
+
+		t.Run("他のユーザーのレイアウトは公開できない", func(t *testing.T) {
+			layout, _ := createTestLayout(t)
+
+			_, err := templateUsecase.CreateTemplate(model.LayoutTemplateRequest{LayoutId: layout.ID, Name: "無断", UserId: otherUserId})
+			if err == nil {
+				t.Error("CreateTemplate() エラーが返されませんでした")
+			}
+		})
+	})
+}
@@ -0,0 +1,34 @@
+package layout_template_test
+
+import (
+	"testing"
+)
+
+func TestLayoutTemplateUsecase_DeleteTemplate(t *testing.T) {
+	setupLayoutTemplateUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("公開したテンプレートを削除できる", func(t *testing.T) {
+			layout, _ := createTestLayout(t)
+			template := createTestTemplate(t, layout.ID, "削除")
+
+			if err := templateUsecase.DeleteTemplate(testUserId, template.ID); err != nil {
+				t.Fatalf("DeleteTemplate() error = %v", err)
+			}
+			if _, err := templateUsecase.GetTemplateById(testUserId, template.ID); err == nil {
+				t.Error("DeleteTemplate() テンプレートが削除されていません")
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("他のユーザーのテンプレートは削除できない", func(t *testing.T) {
+			layout, _ := createTestLayout(t)
+			template := createTestTemplate(t, layout.ID, "他人")
+
+			if err := templateUsecase.DeleteTemplate(otherUserId, template.ID); err == nil {
+				t.Error("DeleteTemplate() エラーが返されませんでした")
+			}
+		})
+	})
+}
@@ -0,0 +1,65 @@
+package layout_template_test
+
+import (
+	"go-react-app/model"
+	"testing"
+)
+
+func TestLayoutTemplateUsecase_InstantiateTemplate(t *testing.T) {
+	setupLayoutTemplateUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("他のユーザーがテンプレートからレイアウトを作成できる", func(t *testing.T) {
+			layout, components := createTestLayout(t)
+			template := createTestTemplate(t, layout.ID, "2カラム")
+
+			res, err := templateUsecase.InstantiateTemplate(otherUserId, template.ID, model.InstantiateTemplateRequest{Title: "新しいブログ"})
+			if err != nil {
+				t.Fatalf("InstantiateTemplate() error = %v", err)
+			}
+			if res.ID == layout.ID || res.Title != "新しいブログ" || res.Grid.RowHeight != 40 {
+				t.Errorf("InstantiateTemplate() = %+v", res)
+			}
+			if len(res.Components) != len(components) {
+				t.Fatalf("InstantiateTemplate() コンポーネント数 = %d, want %d", len(res.Components), len(components))
+			}
+			for i, component := range res.Components {
+				if component.Content != "" {
+					t.Errorf("InstantiateTemplate() content = %q, want 空のプレースホルダー", component.Content)
+				}
+				if component.Type != components[i].Type || component.X != components[i].X || component.Width != components[i].Width {
+					t.Errorf("InstantiateTemplate() component = %+v, want %+v", component, components[i])
+				}
+			}
+
+			var created model.Layout
+			if err := templateDb.Preload("Components").First(&created, res.ID).Error; err != nil {
+				t.Fatalf("作成したレイアウトの取得に失敗しました: %v", err)
+			}
+			if created.UserId != otherUserId || len(created.Components) != 2 || created.Components[0].UserId != otherUserId {
+				t.Errorf("作成したレイアウトの所有者が正しくありません: %+v", created)
+			}
+		})
+
+		t.Run("タイトルを省略するとテンプレート名になる", func(t *testing.T) {
+			layout, _ := createTestLayout(t)
+			template := createTestTemplate(t, layout.ID, "シンプル")
+
+			res, err := templateUsecase.InstantiateTemplate(otherUserId, template.ID, model.InstantiateTemplateRequest{})
+			if err != nil {
+				t.Fatalf("InstantiateTemplate() error = %v", err)
+			}
+			if res.Title != "シンプル" {
+				t.Errorf("InstantiateTemplate() title = %s, want シンプル", res.Title)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("存在しないテンプレートはエラー", func(t *testing.T) {
+			if _, err := templateUsecase.InstantiateTemplate(otherUserId, 9999, model.InstantiateTemplateRequest{}); err == nil {
+				t.Error("InstantiateTemplate() エラーが返されませんでした")
+			}
+		})
+	})
+}
@@ -0,0 +1,103 @@
+package layout_template_test
+
+import (
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/validator"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// テスト用の共通変数
+var (
+	templateDb      *gorm.DB
+	templateUsecase usecase.ILayoutTemplateUsecase
+	testUserId      uint = 1 // テンプレートを公開するユーザー
+	otherUserId     uint = 2 // テンプレートを使うユーザー
+)
+
+// テスト前の共通セットアップ
+func setupLayoutTemplateUsecaseTest() {
+	// テストごとにデータベースをクリーンアップ
+	if templateDb != nil {
+		testutils.CleanupTestDB(templateDb)
+	} else {
+		// 初回のみデータベース接続を作成
+		templateDb = testutils.SetupTestDB()
+		templateUsecase = usecase.NewLayoutTemplateUsecase(
+			repository.NewLayoutTemplateRepository(templateDb),
+			repository.NewLayoutRepository(templateDb),
+			validator.NewLayoutTemplateValidator(),
+		)
+	}
+
+	// 既存のテストデータを明示的に削除（念のため）
+	templateDb.Exec("DELETE FROM layout_templates")
+	templateDb.Exec("DELETE FROM layout_components")
+	templateDb.Exec("DELETE FROM layouts")
+}
+
+// テスト用にコンポーネントを配置したレイアウトを作成
+func createTestLayout(t *testing.T) (model.Layout, []model.LayoutComponent) {
+	layout := model.Layout{
+		Title:       fmt.Sprintf("テンプレートのテスト %d", time.Now().UnixNano()),
+		GridColumns: 12,
+		RowHeight:   40,
+		UserId:      testUserId,
+	}
+	if err := templateDb.Create(&layout).Error; err != nil {
+		t.Fatalf("テストレイアウトの作成に失敗しました: %v", err)
+	}
+	components := []model.LayoutComponent{
+		{Name: "ヘッダー", Type: "header", Content: "<h1>私のブログ</h1>", Properties: `{"title":"私のブログ","links":[{"label":"About","url":"/about"}],"backgroundColor":"#333333"}`, X: 0, Y: 0, Width: 12, Height: 2},
+		{Name: "サイドバー", Type: "sidebar", Content: "自己紹介", X: 9, Y: 2, Width: 3, Height: 8},
+	}
+	for i := range components {
+		components[i].UserId = testUserId
+		components[i].LayoutId = &layout.ID
+	}
+	if err := templateDb.Create(&components).Error; err != nil {
+		t.Fatalf("テストコンポーネントの作成に失敗しました: %v", err)
+	}
+	return layout, components
+}
+
+// テスト用に画像やデータを表示するコンポーネントを配置したレイアウトを作成
+func createDataLayout(t *testing.T) model.Layout {
+	layout := model.Layout{Title: fmt.Sprintf("データを表示するレイアウト %d", time.Now().UnixNano()), GridColumns: 12, RowHeight: 40, UserId: testUserId}
+	if err := templateDb.Create(&layout).Error; err != nil {
+		t.Fatalf("テストレイアウトの作成に失敗しました: %v", err)
+	}
+	components := []model.LayoutComponent{
+		{Name: "画像", Type: "image", Properties: `{"url":"/uploads/1/photo.png","alt":"自宅","link":"https://example.com"}`, X: 0, Y: 0, Width: 4, Height: 4},
+		{Name: "フィード", Type: "feed", Properties: `{"feedId":3,"limit":5}`, X: 4, Y: 0, Width: 3, Height: 6},
+		{Name: "外部API", Type: "external-api", Properties: `{"mappingId":7}`, X: 7, Y: 0, Width: 3, Height: 6},
+		{Name: "最新記事", Type: "latest-articles", Properties: `{"tag":"日記","limit":5}`, X: 0, Y: 6, Width: 3, Height: 6},
+	}
+	for i := range components {
+		components[i].UserId = testUserId
+		components[i].LayoutId = &layout.ID
+	}
+	if err := templateDb.Create(&components).Error; err != nil {
+		t.Fatalf("テストコンポーネントの作成に失敗しました: %v", err)
+	}
+	return layout
+}
+
+// テスト用のテンプレートを公開
+func createTestTemplate(t *testing.T, layoutId uint, name string) model.LayoutTemplateResponse {
+	template, err := templateUsecase.CreateTemplate(model.LayoutTemplateRequest{
+		LayoutId: layoutId,
+		Name:     name,
+		UserId:   testUserId,
+	})
+	if err != nil {
+		t.Fatalf("テストテンプレートの作成に失敗しました: %v", err)
+	}
+	return template
+}
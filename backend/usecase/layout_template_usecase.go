@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/validator"
+)
+
+// ErrInvalidLayoutTemplate はテンプレートのリクエストが不正な場合のエラー
+var ErrInvalidLayoutTemplate = errors.New("invalid layout template request")
+
+type ILayoutTemplateUsecase interface {
+	GetAllTemplates(userId uint) ([]model.LayoutTemplateResponse, error)
+	GetTemplateById(userId uint, templateId uint) (model.LayoutTemplateResponse, error)
+	CreateTemplate(request model.LayoutTemplateRequest) (model.LayoutTemplateResponse, error)
+	DeleteTemplate(userId uint, templateId uint) error
+	InstantiateTemplate(userId uint, templateId uint, request model.InstantiateTemplateRequest) (model.LayoutResponse, error)
+}
+
+type layoutTemplateUsecase struct {
+	tr repository.ILayoutTemplateRepository
+	lr repository.ILayoutRepository
+	tv validator.ILayoutTemplateValidator
+}
+
+func NewLayoutTemplateUsecase(tr repository.ILayoutTemplateRepository, lr repository.ILayoutRepository, tv validator.ILayoutTemplateValidator) ILayoutTemplateUsecase {
+	return &layoutTemplateUsecase{tr, lr, tv}
+}
+
+// GetAllTemplates はテンプレートのギャラリー（すべてのユーザーが公開したテンプレート）を返します
+func (tu *layoutTemplateUsecase) GetAllTemplates(userId uint) ([]model.LayoutTemplateResponse, error) {
+	templates, err := tu.tr.GetAllTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.LayoutTemplateResponse, len(templates))
+	for i, template := range templates {
+		if responses[i], err = template.ToResponse(userId, false); err != nil {
+			return nil, err
+		}
+	}
+	return responses, nil
+}
+
+func (tu *layoutTemplateUsecase) GetTemplateById(userId uint, templateId uint) (model.LayoutTemplateResponse, error) {
+	template, err := tu.tr.GetTemplateById(templateId)
+	if err != nil {
+		return model.LayoutTemplateResponse{}, err
+	}
+	return template.ToResponse(userId, true)
+}
+
+// CreateTemplate はユーザーのレイアウトの構成をテンプレートとして公開します
+func (tu *layoutTemplateUsecase) CreateTemplate(request model.LayoutTemplateRequest) (model.LayoutTemplateResponse, error) {
+	if err := tu.tv.ValidateLayoutTemplateRequest(request); err != nil {
+		return model.LayoutTemplateResponse{}, fmt.Errorf("%w: %v", ErrInvalidLayoutTemplate, err)
+	}
+	layout, err := tu.lr.GetLayoutById(request.UserId, request.LayoutId)
+	if err != nil {
+		return model.LayoutTemplateResponse{}, err
+	}
+
+	template, err := model.NewLayoutTemplate(layout, request.Name, request.Description)
+	if err != nil {
+		return model.LayoutTemplateResponse{}, err
+	}
+	if err := tu.tr.CreateTemplate(&template); err != nil {
+		return model.LayoutTemplateResponse{}, err
+	}
+	return template.ToResponse(request.UserId, true)
+}
+
+func (tu *layoutTemplateUsecase) DeleteTemplate(userId uint, templateId uint) error {
+	return tu.tr.DeleteTemplate(userId, templateId)
+}
+
+// InstantiateTemplate はテンプレートの構成からログインユーザーの新しいレイアウトを作成します
+// コンポーネントの本文はテンプレートを公開したときに空に戻されているため、プレースホルダーの状態で作成される
+func (tu *layoutTemplateUsecase) InstantiateTemplate(userId uint, templateId uint, request model.InstantiateTemplateRequest) (model.LayoutResponse, error) {
+	template, err := tu.tr.GetTemplateById(templateId)
+	if err != nil {
+		return model.LayoutResponse{}, err
+	}
+	data, err := template.ParseData()
+	if err != nil {
+		return model.LayoutResponse{}, err
+	}
+
+	title := request.Title
+	if title == "" {
+		title = template.Name
+	}
+	layout := data.NewLayout(title, userId)
+	if err := tu.lr.CreateLayoutTree(&layout, data.ParentIndexes()); err != nil {
+		return model.LayoutResponse{}, err
+	}
+	return layout.ToResponse(), nil
+}
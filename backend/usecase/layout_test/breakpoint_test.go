@@ -0,0 +1,165 @@
+package layout_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"strings"
+	"testing"
+)
+
+// タブレットでサイドバーを本文の下に移動したレイアウトを作成
+func createResponsiveLayout(t *testing.T) (model.Layout, []model.LayoutComponent) {
+	layout, components := createArrangedLayout(t, model.CollisionModePush)
+	_, err := layoutUsecase.UpdateArrangement(testUserId, layout.ID, model.ArrangementRequest{
+		Version:    layout.Version,
+		Breakpoint: model.BreakpointTablet,
+		Components: []model.ComponentPosition{
+			{ComponentId: components[1].ID, PositionRequest: model.PositionRequest{X: 0, Y: 2, Width: 12, Height: 10}},
+			{ComponentId: components[2].ID, PositionRequest: model.PositionRequest{X: 0, Y: 12, Width: 6, Height: 6}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateArrangement() error = %v", err)
+	}
+	return layout, components
+}
+
+func TestLayoutUsecase_Breakpoints(t *testing.T) {
+	setupLayoutUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("タブレットの配置を更新してもデスクトップの位置は変わらない", func(t *testing.T) {
+			_, components := createResponsiveLayout(t)
+			header, sidebar := components[0], components[2]
+
+			saved := findComponent(t, sidebar.ID)
+			if saved.X != 8 || saved.Y != 2 || saved.Width != 4 {
+				t.Errorf("desktop sidebar = (%d,%d,%d), want (8,2,4)", saved.X, saved.Y, saved.Width)
+			}
+			want := model.Geometry{X: 0, Y: 12, Width: 6, Height: 6}
+			if got := saved.GeometryAt(model.BreakpointTablet); got != want {
+				t.Errorf("tablet sidebar = %+v, want %+v", got, want)
+			}
+			// モバイルは上書きしていないため、タブレットの位置を引き継ぐ
+			if got := saved.GeometryAt(model.BreakpointMobile); got != want {
+				t.Errorf("mobile sidebar = %+v, want %+v", got, want)
+			}
+			if saved := findComponent(t, header.ID); saved.Breakpoints != "" {
+				t.Errorf("header breakpoints = %q, want empty", saved.Breakpoints)
+			}
+		})
+
+		t.Run("ブレークポイントを指定するとその位置でレイアウトを返す", func(t *testing.T) {
+			layout, components := createResponsiveLayout(t)
+
+			res, err := layoutUsecase.GetLayoutById(testUserId, layout.ID, model.BreakpointMobile)
+			if err != nil {
+				t.Fatalf("GetLayoutById() error = %v", err)
+			}
+			if res.Breakpoint != model.BreakpointMobile {
+				t.Errorf("GetLayoutById() breakpoint = %q, want %q", res.Breakpoint, model.BreakpointMobile)
+			}
+			for _, component := range res.Components {
+				if component.ID == components[2].ID && (component.X != 0 || component.Y != 12) {
+					t.Errorf("mobile sidebar = (%d,%d), want (0,12)", component.X, component.Y)
+				}
+			}
+
+			res, err = layoutUsecase.GetLayoutById(testUserId, layout.ID, "")
+			if err != nil {
+				t.Fatalf("GetLayoutById() error = %v", err)
+			}
+			for _, component := range res.Components {
+				if component.ID == components[2].ID && (component.X != 8 || component.Breakpoints == nil || component.Breakpoints.Tablet == nil) {
+					t.Errorf("desktop sidebar = %+v, want x 8 with tablet override", component)
+				}
+			}
+		})
+
+		t.Run("上書きがある場合は画面幅に応じて配置を切り替えるCSS変数を出力する", func(t *testing.T) {
+			layout, _ := createResponsiveLayout(t)
+
+			html, err := layoutUsecase.RenderLayout(testUserId, layout.ID, "")
+			if err != nil {
+				t.Fatalf("RenderLayout() error = %v", err)
+			}
+			page := string(html)
+			for _, want := range []string{
+				`class="layout-grid lc-responsive"`,
+				"--lc-tablet-col:1;--lc-tablet-span:6;--lc-tablet-row:13;--lc-tablet-rows:6",
+				"--lc-mobile-col:1;--lc-mobile-span:6;--lc-mobile-row:13;--lc-mobile-rows:6",
+			} {
+				if !strings.Contains(page, want) {
+					t.Errorf("プレビューに %q が含まれていません", want)
+				}
+			}
+		})
+
+		t.Run("ブレークポイントを指定するとその配置で固定して描画する", func(t *testing.T) {
+			layout, _ := createResponsiveLayout(t)
+
+			html, err := layoutUsecase.RenderLayout(testUserId, layout.ID, model.BreakpointTablet)
+			if err != nil {
+				t.Fatalf("RenderLayout() error = %v", err)
+			}
+			page := string(html)
+			if !strings.Contains(page, `class="layout-grid lc-fixed"`) || !strings.Contains(page, "--lc-col:1;--lc-span:6;--lc-row:13;--lc-rows:6") {
+				t.Error("タブレットの配置で描画されていません")
+			}
+			if strings.Contains(page, "--lc-tablet-col:") {
+				t.Error("固定の描画にブレークポイントごとのCSS変数が含まれています")
+			}
+		})
+
+		t.Run("書き出したJSONを読み込むとタブレット・モバイルの位置を引き継ぐ", func(t *testing.T) {
+			layout, _ := createResponsiveLayout(t)
+
+			document, err := layoutUsecase.ExportLayout(testUserId, layout.ID)
+			if err != nil {
+				t.Fatalf("ExportLayout() error = %v", err)
+			}
+			imported, err := layoutUsecase.ImportLayout(testUserId, document)
+			if err != nil {
+				t.Fatalf("ImportLayout() error = %v", err)
+			}
+			overrides := 0
+			for _, component := range imported.Layout.Components {
+				if component.Breakpoints != nil && component.Breakpoints.Tablet != nil {
+					overrides++
+				}
+			}
+			if overrides != 2 {
+				t.Errorf("imported components with tablet overrides = %d, want 2", overrides)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("対応していないブレークポイントはErrInvalidBreakpointを返す", func(t *testing.T) {
+			layout, _ := createArrangedLayout(t, model.CollisionModePush)
+
+			if _, err := layoutUsecase.GetLayoutById(testUserId, layout.ID, "watch"); !errors.Is(err, usecase.ErrInvalidBreakpoint) {
+				t.Errorf("GetLayoutById() error = %v, want ErrInvalidBreakpoint", err)
+			}
+			if _, err := layoutUsecase.RenderLayout(testUserId, layout.ID, "watch"); !errors.Is(err, usecase.ErrInvalidBreakpoint) {
+				t.Errorf("RenderLayout() error = %v, want ErrInvalidBreakpoint", err)
+			}
+		})
+
+		t.Run("配置の更新で対応していないブレークポイントはErrInvalidArrangementを返す", func(t *testing.T) {
+			layout, components := createArrangedLayout(t, model.CollisionModePush)
+
+			_, err := layoutUsecase.UpdateArrangement(testUserId, layout.ID, model.ArrangementRequest{
+				Version:    layout.Version,
+				Breakpoint: "watch",
+				Components: []model.ComponentPosition{
+					{ComponentId: components[0].ID, PositionRequest: model.PositionRequest{X: 0, Y: 0, Width: 12, Height: 2}},
+				},
+			})
+			if !errors.Is(err, usecase.ErrInvalidArrangement) {
+				t.Errorf("UpdateArrangement() error = %v, want ErrInvalidArrangement", err)
+			}
+		})
+	})
+}
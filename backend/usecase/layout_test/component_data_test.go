@@ -0,0 +1,58 @@
+package layout_test
+
+import (
+	"go-react-app/model"
+	"strings"
+	"testing"
+)
+
+func TestLayoutUsecase_RenderComponentData(t *testing.T) {
+	setupLayoutUsecaseTest()
+	layoutDb.Exec("DELETE FROM articles WHERE user_id = ?", testUserId)
+
+	layout := createTestLayout(t, "データを表示するレイアウト")
+	components := []model.LayoutComponent{
+		{Name: "Goの記事", Type: "latest-articles", Properties: `{"tag":"Go"}`, X: 0, Y: 0, Width: 4, Height: 6},
+		{Name: "Qiita", Type: "qiita-trending", X: 4, Y: 0, Width: 4, Height: 6},
+		{Name: "購読中のフィード", Type: "feed", Properties: `{"feedId":1}`, X: 8, Y: 0, Width: 4, Height: 6},
+	}
+	for i := range components {
+		components[i].UserId = testUserId
+		components[i].LayoutId = &layout.ID
+	}
+	layoutDb.Create(&components)
+	layoutDb.Create(&model.Article{Title: "Goの公開記事", Tags: "Go", Published: true, UserId: testUserId})
+	layoutDb.Create(&model.Article{Title: "Webの公開記事", Tags: "Web", Published: true, UserId: testUserId})
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("データを取得するコンポーネントは取得元のデータを表示する", func(t *testing.T) {
+			html, err := layoutUsecase.RenderLayout(testUserId, layout.ID, "")
+			if err != nil {
+				t.Fatalf("RenderLayout() error = %v", err)
+			}
+			page := string(html)
+			for _, want := range []string{
+				`<section class="lc lc-data lc-latest-articles"`,
+				"Goの公開記事",
+				`<a href="https://qiita.com/items/q1">Qiitaの記事</a>`,
+			} {
+				if !strings.Contains(page, want) {
+					t.Errorf("プレビューに %q が含まれていません", want)
+				}
+			}
+			if strings.Contains(page, "Webの公開記事") {
+				t.Error("タグが一致しない記事がプレビューに含まれています")
+			}
+		})
+
+		t.Run("取得に失敗したコンポーネントはその旨を表示し、プレビュー全体は描画する", func(t *testing.T) {
+			html, err := layoutUsecase.RenderLayout(testUserId, layout.ID, "")
+			if err != nil {
+				t.Fatalf("RenderLayout() error = %v", err)
+			}
+			if !strings.Contains(string(html), "データを取得できませんでした") {
+				t.Error("取得に失敗したことがプレビューに表示されていません")
+			}
+		})
+	})
+}
@@ -0,0 +1,53 @@
+package layout_test
+
+import (
+	"testing"
+)
+
+func TestLayoutUsecase_DuplicateLayout(t *testing.T) {
+	setupLayoutUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("レイアウトとコンポーネントを複製する", func(t *testing.T) {
+			layout, components := createArrangedLayout(t, "")
+
+			res, err := layoutUsecase.DuplicateLayout(testUserId, layout.ID)
+			if err != nil {
+				t.Fatalf("DuplicateLayout() error = %v", err)
+			}
+			if res.ID == layout.ID || res.Title != layout.Title+" のコピー" {
+				t.Errorf("DuplicateLayout() id=%d title=%s", res.ID, res.Title)
+			}
+			if res.Grid.Columns != 12 || res.Grid.CanvasWidth != 1200 || res.Version != 1 {
+				t.Errorf("DuplicateLayout() grid=%+v version=%d", res.Grid, res.Version)
+			}
+			if len(res.Components) != len(components) {
+				t.Fatalf("DuplicateLayout() コンポーネント数 = %d, want %d", len(res.Components), len(components))
+			}
+			for i, component := range res.Components {
+				original := components[i]
+				if component.ID == original.ID {
+					t.Errorf("DuplicateLayout() コンポーネント%dが複製されていません", original.ID)
+				}
+				if component.Name != original.Name || component.X != original.X || component.Y != original.Y || component.Width != original.Width {
+					t.Errorf("DuplicateLayout() component = %+v, want %+v", component, original)
+				}
+			}
+
+			// 複製元のコンポーネントはそのまま残る
+			if saved := findComponent(t, components[0].ID); saved.LayoutId == nil || *saved.LayoutId != layout.ID {
+				t.Errorf("複製元のコンポーネントのレイアウトが変わっています")
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("他のユーザーのレイアウトは複製できない", func(t *testing.T) {
+			layout, _ := createArrangedLayout(t, "")
+
+			if _, err := layoutUsecase.DuplicateLayout(testUserId+1, layout.ID); err == nil {
+				t.Error("DuplicateLayout() エラーが返されませんでした")
+			}
+		})
+	})
+}
@@ -0,0 +1,72 @@
+package layout_test
+
+import (
+	"encoding/json"
+	"go-react-app/model"
+	"testing"
+)
+
+func TestLayoutUsecase_ExportLayout(t *testing.T) {
+	setupLayoutUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("書き出したJSONを読み込むと同じ構成のレイアウトになる", func(t *testing.T) {
+			layout, components := createArrangedLayout(t, model.CollisionModeReject)
+
+			document, err := layoutUsecase.ExportLayout(testUserId, layout.ID)
+			if err != nil {
+				t.Fatalf("ExportLayout() error = %v", err)
+			}
+			if document.SchemaVersion != model.LayoutDocumentVersion || document.ExportedAt.IsZero() {
+				t.Errorf("ExportLayout() schema_version=%d exported_at=%v", document.SchemaVersion, document.ExportedAt)
+			}
+			if document.Layout.Title != layout.Title || document.Layout.Grid.CollisionMode != model.CollisionModeReject || len(document.Layout.Components) != len(components) {
+				t.Fatalf("ExportLayout() layout = %+v", document.Layout)
+			}
+
+			// 他の環境に持ち込むことを想定してJSONを経由する
+			encoded, err := json.Marshal(document)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			var decoded model.LayoutDocument
+			if err := json.Unmarshal(encoded, &decoded); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+
+			res, err := layoutUsecase.ImportLayout(testUserId, decoded)
+			if err != nil {
+				t.Fatalf("ImportLayout() error = %v", err)
+			}
+			if res.Layout.ID == layout.ID || res.Layout.Title != layout.Title+" (2)" {
+				t.Errorf("ImportLayout() id=%d title=%s", res.Layout.ID, res.Layout.Title)
+			}
+			if len(res.Conflicts) != 1 || res.Conflicts[0].Action != model.ImportActionRenamed || res.Conflicts[0].Index != -1 {
+				t.Errorf("ImportLayout() conflicts = %+v", res.Conflicts)
+			}
+			if len(res.IdMap) != len(components) {
+				t.Fatalf("ImportLayout() id_map = %v", res.IdMap)
+			}
+			for _, original := range components {
+				newId, ok := res.IdMap[original.ID]
+				if !ok || newId == original.ID {
+					t.Fatalf("ImportLayout() id_map[%d] = %d", original.ID, newId)
+				}
+				imported := findComponent(t, newId)
+				if imported.Name != original.Name || imported.X != original.X || imported.Y != original.Y || imported.Width != original.Width || *imported.LayoutId != res.Layout.ID {
+					t.Errorf("ImportLayout() component = %+v, want %+v", imported, original)
+				}
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("他のユーザーのレイアウトは書き出せない", func(t *testing.T) {
+			layout, _ := createArrangedLayout(t, "")
+
+			if _, err := layoutUsecase.ExportLayout(testUserId+1, layout.ID); err == nil {
+				t.Error("ExportLayout() エラーが返されませんでした")
+			}
+		})
+	})
+}
@@ -77,7 +77,7 @@ func TestLayoutUsecase_GetLayoutById(t *testing.T) {
 			expectedLayout := createTestLayout(t, generateUniqueTitle())
 
 			// テスト実行
-			layout, err := layoutUsecase.GetLayoutById(testUserId, expectedLayout.ID)
+			layout, err := layoutUsecase.GetLayoutById(testUserId, expectedLayout.ID, "")
 
 			// 検証
 			if err != nil {
@@ -100,7 +100,7 @@ func TestLayoutUsecase_GetLayoutById(t *testing.T) {
 			nonExistLayoutId := uint(9999)
 
 			// テスト実行
-			_, err := layoutUsecase.GetLayoutById(testUserId, nonExistLayoutId)
+			_, err := layoutUsecase.GetLayoutById(testUserId, nonExistLayoutId, "")
 
 			// 検証
 			if err == nil {
@@ -116,7 +116,7 @@ func TestLayoutUsecase_GetLayoutById(t *testing.T) {
 			otherUserId := uint(999)
 
 			// テスト実行
-			_, err := layoutUsecase.GetLayoutById(otherUserId, layout.ID)
+			_, err := layoutUsecase.GetLayoutById(otherUserId, layout.ID, "")
 
 			// 検証
 			if err == nil {
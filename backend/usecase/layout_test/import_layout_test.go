@@ -0,0 +1,112 @@
+package layout_test
+
+import (
+	"encoding/json"
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"testing"
+)
+
+// 読み込みのテスト用のレイアウトのJSONを作成
+func newTestDocument(collisionMode string, components ...model.SnapshotComponent) model.LayoutDocument {
+	return model.LayoutDocument{
+		SchemaVersion: model.LayoutDocumentVersion,
+		Layout: model.SnapshotData{
+			Title:      generateUniqueTitle(),
+			Grid:       model.GridSettings{Columns: 12, RowHeight: 30, CanvasWidth: 1200, CollisionMode: collisionMode},
+			Components: components,
+		},
+	}
+}
+
+func TestLayoutUsecase_ImportLayout(t *testing.T) {
+	setupLayoutUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("不正なコンポーネントとIDが重複するコンポーネントは読み込まずに報告する", func(t *testing.T) {
+			document := newTestDocument(model.CollisionModePush,
+				model.SnapshotComponent{ID: 10, Name: "ヘッダー", Type: "header", X: 0, Y: 0, Width: 12, Height: 2},
+				model.SnapshotComponent{ID: 11, Name: "カルーセル", Type: "carousel", X: 0, Y: 2, Width: 12, Height: 4},
+				model.SnapshotComponent{ID: 12, Name: "本文", Type: "main", Properties: json.RawMessage(`{"unknown":true}`), X: 0, Y: 2, Width: 8, Height: 10},
+				model.SnapshotComponent{ID: 10, Name: "重複", Type: "text", X: 0, Y: 20, Width: 4, Height: 2},
+				model.SnapshotComponent{ID: 13, Name: "フッター", Type: "footer", X: 0, Y: 30, Width: 12, Height: 3},
+			)
+
+			res, err := layoutUsecase.ImportLayout(testUserId, document)
+			if err != nil {
+				t.Fatalf("ImportLayout() error = %v", err)
+			}
+			if len(res.Layout.Components) != 2 || len(res.IdMap) != 2 || res.IdMap[10] == 0 || res.IdMap[13] == 0 {
+				t.Errorf("ImportLayout() components=%d id_map=%v", len(res.Layout.Components), res.IdMap)
+			}
+			if len(res.Conflicts) != 3 {
+				t.Fatalf("ImportLayout() conflicts = %+v", res.Conflicts)
+			}
+			for i, index := range []int{1, 2, 3} {
+				if res.Conflicts[i].Index != index || res.Conflicts[i].Action != model.ImportActionSkipped {
+					t.Errorf("ImportLayout() conflicts[%d] = %+v, want skipped index %d", i, res.Conflicts[i], index)
+				}
+			}
+		})
+
+		t.Run("重なりを押し出すレイアウトでは重なるコンポーネントを下に移動して報告する", func(t *testing.T) {
+			document := newTestDocument(model.CollisionModePush,
+				model.SnapshotComponent{ID: 1, Name: "本文", Type: "main", X: 0, Y: 2, Width: 8, Height: 10},
+				model.SnapshotComponent{ID: 2, Name: "ヘッダー", Type: "header", X: 0, Y: 0, Width: 12, Height: 4},
+			)
+
+			res, err := layoutUsecase.ImportLayout(testUserId, document)
+			if err != nil {
+				t.Fatalf("ImportLayout() error = %v", err)
+			}
+			if main := findComponent(t, res.IdMap[1]); main.Y != 4 {
+				t.Errorf("本文 y = %d, want 4", main.Y)
+			}
+			if len(res.Conflicts) != 1 || res.Conflicts[0].Action != model.ImportActionMoved || res.Conflicts[0].ComponentId != 1 {
+				t.Errorf("ImportLayout() conflicts = %+v", res.Conflicts)
+			}
+		})
+
+		t.Run("重なりを拒否するレイアウトでは後のコンポーネントを読み込まない", func(t *testing.T) {
+			document := newTestDocument(model.CollisionModeReject,
+				model.SnapshotComponent{ID: 1, Name: "本文", Type: "main", X: 0, Y: 0, Width: 8, Height: 10},
+				model.SnapshotComponent{ID: 2, Name: "サイドバー", Type: "sidebar", X: 6, Y: 0, Width: 4, Height: 6},
+			)
+
+			res, err := layoutUsecase.ImportLayout(testUserId, document)
+			if err != nil {
+				t.Fatalf("ImportLayout() error = %v", err)
+			}
+			if len(res.IdMap) != 1 || len(res.Conflicts) != 1 || res.Conflicts[0].ComponentId != 2 || res.Conflicts[0].Action != model.ImportActionSkipped {
+				t.Errorf("ImportLayout() id_map=%v conflicts=%+v", res.IdMap, res.Conflicts)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("対応していないschema_versionはErrInvalidLayoutDocumentを返し何も作成しない", func(t *testing.T) {
+			document := newTestDocument("", model.SnapshotComponent{ID: 1, Name: "ヘッダー", Type: "header", Width: 12, Height: 2})
+			document.SchemaVersion = model.LayoutDocumentVersion + 1
+
+			_, err := layoutUsecase.ImportLayout(testUserId, document)
+			if !errors.Is(err, usecase.ErrInvalidLayoutDocument) {
+				t.Fatalf("ImportLayout() error = %v, want ErrInvalidLayoutDocument", err)
+			}
+			var count int64
+			layoutDb.Model(&model.Layout{}).Where("title = ?", document.Layout.Title).Count(&count)
+			if count != 0 {
+				t.Errorf("ImportLayout() レイアウトが作成されています")
+			}
+		})
+
+		t.Run("レイアウトのタイトルがない場合はErrInvalidLayoutDocument", func(t *testing.T) {
+			document := newTestDocument("")
+			document.Layout.Title = ""
+
+			if _, err := layoutUsecase.ImportLayout(testUserId, document); !errors.Is(err, usecase.ErrInvalidLayoutDocument) {
+				t.Errorf("ImportLayout() error = %v, want ErrInvalidLayoutDocument", err)
+			}
+		})
+	})
+}
@@ -0,0 +1,148 @@
+package layout_test
+
+import (
+	"go-react-app/model"
+	"strings"
+	"testing"
+)
+
+// サイドバーの中にバナーとリンクを配置したレイアウトを作成
+// 重なり順はバナーをリンクより手前にする
+func createNestedLayout(t *testing.T) (model.Layout, model.LayoutComponent, []model.LayoutComponent) {
+	layout, components := createArrangedLayout(t, model.CollisionModePush)
+	sidebar := components[2]
+	children := []model.LayoutComponent{
+		{Name: "バナー", Type: "text", X: 0, Y: 0, Width: 2, Height: 2, ZIndex: 1},
+		{Name: "リンク", Type: "text", X: 2, Y: 2, Width: 2, Height: 2},
+	}
+	for i := range children {
+		children[i].UserId = testUserId
+		children[i].LayoutId = &layout.ID
+		children[i].ParentId = &sidebar.ID
+	}
+	if err := layoutDb.Create(&children).Error; err != nil {
+		t.Fatalf("テストコンポーネントの作成に失敗しました: %v", err)
+	}
+	return layout, sidebar, children
+}
+
+func TestLayoutUsecase_NestedComponents(t *testing.T) {
+	setupLayoutUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("子コンポーネントは親のchildrenに重なり順で含める", func(t *testing.T) {
+			layout, sidebar, children := createNestedLayout(t)
+
+			res, err := layoutUsecase.GetLayoutById(testUserId, layout.ID, "")
+			if err != nil {
+				t.Fatalf("GetLayoutById() error = %v", err)
+			}
+			if len(res.Components) != 3 {
+				t.Fatalf("GetLayoutById() ルートのコンポーネント数 = %d, want 3", len(res.Components))
+			}
+			for _, component := range res.Components {
+				if component.ID != sidebar.ID {
+					continue
+				}
+				if len(component.Children) != 2 || component.Children[0].ID != children[1].ID || component.Children[1].ID != children[0].ID {
+					t.Errorf("sidebar children = %+v, want [リンク, バナー]", component.Children)
+				}
+			}
+		})
+
+		t.Run("子コンポーネントは親の位置からの相対位置と重なり順で描画する", func(t *testing.T) {
+			layout, _, _ := createNestedLayout(t)
+
+			html, err := layoutUsecase.RenderLayout(testUserId, layout.ID, "")
+			if err != nil {
+				t.Fatalf("RenderLayout() error = %v", err)
+			}
+			page := string(html)
+			for _, want := range []string{
+				"--lc-col:9;--lc-span:4;--lc-row:3;--lc-rows:6;--lc-order:2;--lc-z:3",
+				"--lc-col:9;--lc-span:2;--lc-row:3;--lc-rows:2;--lc-order:3;--lc-z:5",
+				"--lc-col:11;--lc-span:2;--lc-row:5;--lc-rows:2;--lc-order:4;--lc-z:4",
+			} {
+				if !strings.Contains(page, want) {
+					t.Errorf("プレビューに %q が含まれていません", want)
+				}
+			}
+		})
+
+		t.Run("複製したレイアウトは親子関係を引き継ぐ", func(t *testing.T) {
+			layout, _, _ := createNestedLayout(t)
+
+			res, err := layoutUsecase.DuplicateLayout(testUserId, layout.ID)
+			if err != nil {
+				t.Fatalf("DuplicateLayout() error = %v", err)
+			}
+			ids := make(map[string]uint)
+			for _, component := range res.Components {
+				ids[component.Name] = component.ID
+			}
+			for _, name := range []string{"バナー", "リンク"} {
+				saved := findComponent(t, ids[name])
+				if saved.ParentId == nil || *saved.ParentId != ids["サイドバー"] {
+					t.Errorf("%s parent = %v, want %d", name, saved.ParentId, ids["サイドバー"])
+				}
+			}
+		})
+
+		t.Run("読み込んだレイアウトは親子関係を引き継ぐ", func(t *testing.T) {
+			layout, _, _ := createNestedLayout(t)
+			document, err := layoutUsecase.ExportLayout(testUserId, layout.ID)
+			if err != nil {
+				t.Fatalf("ExportLayout() error = %v", err)
+			}
+
+			res, err := layoutUsecase.ImportLayout(testUserId, document)
+			if err != nil {
+				t.Fatalf("ImportLayout() error = %v", err)
+			}
+			for _, conflict := range res.Conflicts {
+				if conflict.Index >= 0 {
+					t.Errorf("ImportLayout() conflict = %+v", conflict)
+				}
+			}
+			for _, source := range document.Layout.Components {
+				if source.ParentId == nil {
+					continue
+				}
+				saved := findComponent(t, res.IdMap[source.ID])
+				if saved.ParentId == nil || *saved.ParentId != res.IdMap[*source.ParentId] {
+					t.Errorf("%s parent = %v, want %d", source.Name, saved.ParentId, res.IdMap[*source.ParentId])
+				}
+			}
+		})
+
+		t.Run("親にできないコンポーネントの子は親を外して読み込み、読み込まなかった親の子は読み込まない", func(t *testing.T) {
+			main, sidebar := uint(1), uint(2)
+			document := newTestDocument(model.CollisionModePush,
+				model.SnapshotComponent{ID: 1, Name: "本文", Type: "main", X: 0, Y: 0, Width: 8, Height: 10},
+				model.SnapshotComponent{ID: 2, Name: "サイドバー", Type: "sidebar", Properties: []byte(`{"unknown":true}`), X: 8, Y: 0, Width: 4, Height: 6},
+				model.SnapshotComponent{ID: 3, Name: "注記", Type: "text", ParentId: &main, X: 0, Y: 12, Width: 2, Height: 2},
+				model.SnapshotComponent{ID: 4, Name: "リンク", Type: "text", ParentId: &sidebar, X: 0, Y: 0, Width: 2, Height: 2},
+			)
+
+			res, err := layoutUsecase.ImportLayout(testUserId, document)
+			if err != nil {
+				t.Fatalf("ImportLayout() error = %v", err)
+			}
+			want := []struct {
+				index  int
+				action string
+			}{{1, model.ImportActionSkipped}, {2, model.ImportActionDetached}, {3, model.ImportActionSkipped}}
+			if len(res.Conflicts) != len(want) {
+				t.Fatalf("ImportLayout() conflicts = %+v", res.Conflicts)
+			}
+			for i, w := range want {
+				if res.Conflicts[i].Index != w.index || res.Conflicts[i].Action != w.action {
+					t.Errorf("ImportLayout() conflicts[%d] = %+v, want %s index %d", i, res.Conflicts[i], w.action, w.index)
+				}
+			}
+			if saved := findComponent(t, res.IdMap[3]); saved.ParentId != nil {
+				t.Errorf("注記 parent = %v, want nil", *saved.ParentId)
+			}
+		})
+	})
+}
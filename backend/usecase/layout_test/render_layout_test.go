@@ -0,0 +1,97 @@
+package layout_test
+
+import (
+	"go-react-app/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLayoutUsecase_RenderLayout(t *testing.T) {
+	setupLayoutUsecaseTest()
+	layoutDb.Exec("DELETE FROM articles WHERE user_id = ?", testUserId)
+
+	layout := createTestLayout(t, "プレビュー用レイアウト")
+	components := []model.LayoutComponent{
+		{Name: "フッター", Type: "footer", Content: "<p>© gopher</p>", X: 0, Y: 14, Width: 12, Height: 2},
+		{Name: "ヘッダー", Type: "header", Content: `<h1 onclick="alert(1)">gopherのブログ</h1><script>alert(1)</script>`, X: 0, Y: 0, Width: 12, Height: 2},
+		{Name: "記事一覧", Type: "main", X: 0, Y: 2, Width: 8, Height: 10},
+		{Name: "最近の記事", Type: "sidebar", X: 8, Y: 2, Width: 4, Height: 6},
+		{Name: "カレンダー", Type: "calendar", X: 8, Y: 8, Width: 4, Height: 4},
+		{Name: "バナー", Type: "banner", Content: "<p>お知らせ</p>", X: 0, Y: 12, Width: 12, Height: 2},
+	}
+	for i := range components {
+		components[i].UserId = testUserId
+		components[i].LayoutId = &layout.ID
+	}
+	layoutDb.Create(&components)
+
+	published := model.Article{Title: "公開記事", Content: "# 見出し\n\n記事の本文", Tags: "Go, Web", Published: true, UserId: testUserId}
+	layoutDb.Create(&published)
+	layoutDb.Create(&model.Article{Title: "下書きの記事", Content: "本文", Published: false, UserId: testUserId})
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("コンポーネントの種類ごとのテンプレートで描画する", func(t *testing.T) {
+			html, err := layoutUsecase.RenderLayout(testUserId, layout.ID, "")
+			if err != nil {
+				t.Fatalf("RenderLayout() error = %v", err)
+			}
+			page := string(html)
+
+			for _, want := range []string{
+				`<header class="lc lc-header"`,
+				`<section class="lc lc-article-card"`,
+				`<aside class="lc lc-sidebar"`,
+				`<section class="lc lc-calendar"`,
+				`<footer class="lc lc-footer"`,
+				`<div class="lc lc-banner"`,
+				`<h2 class="lc-card-title">公開記事</h2>`,
+				`<p class="lc-card-excerpt">見出し 記事の本文</p>`,
+				`<span class="lc-tag">Web</span>`,
+				time.Now().Format("2006年1月"),
+				`<meta name="robots" content="noindex">`,
+				"@media (max-width: 768px)",
+			} {
+				if !strings.Contains(page, want) {
+					t.Errorf("プレビューに %q が含まれていません", want)
+				}
+			}
+			if strings.Contains(page, "下書きの記事") {
+				t.Error("下書きの記事がプレビューに含まれています")
+			}
+			if strings.Contains(page, "<script>") || strings.Contains(page, "onclick") {
+				t.Error("コンポーネントの内容がサニタイズされていません")
+			}
+		})
+
+		t.Run("エディタのグリッドの位置を上から順に配置する", func(t *testing.T) {
+			html, err := layoutUsecase.RenderLayout(testUserId, layout.ID, "")
+			if err != nil {
+				t.Fatalf("RenderLayout() error = %v", err)
+			}
+			page := string(html)
+
+			if !strings.Contains(page, `<aside class="lc lc-sidebar" style="--lc-col:9;--lc-span:4;--lc-row:3;--lc-rows:6;--lc-order:2">`) {
+				t.Errorf("サイドバーの位置が正しくありません:\n%s", page)
+			}
+			order := []string{"lc-header", "lc-article-card", "lc-sidebar", "lc-calendar", "lc-banner", "lc-footer"}
+			last := -1
+			for _, class := range order {
+				index := strings.Index(page, `class="lc `+class)
+				if index < last {
+					t.Errorf("%sの位置が正しくありません", class)
+				}
+				last = index
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("他のユーザーのレイアウトは描画できない", func(t *testing.T) {
+			_, err := layoutUsecase.RenderLayout(testUserId+1, layout.ID, "")
+			if err == nil {
+				t.Error("RenderLayout() error = nil, want error")
+			}
+		})
+	})
+}
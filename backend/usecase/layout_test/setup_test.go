@@ -1,10 +1,13 @@
 package layout_test
 
 import (
+	"errors"
 	"go-react-app/model"
 	"go-react-app/repository"
 	"go-react-app/testutils"
 	"go-react-app/usecase"
+	"go-react-app/utils/layoutrender"
+	"go-react-app/utils/markdown"
 	"go-react-app/validator"
 	"testing"
 
@@ -30,7 +33,27 @@ func setupLayoutUsecaseTest() {
 		layoutDb = testutils.SetupTestDB()
 		layoutRepo = repository.NewLayoutRepository(layoutDb)
 		layoutValidator = validator.NewLayoutValidator()
-		layoutUsecase = usecase.NewLayoutUsecase(layoutRepo, layoutValidator)
+		layoutUsecase = usecase.NewLayoutUsecase(
+			layoutRepo,
+			repository.NewLayoutSnapshotRepository(layoutDb),
+			layoutValidator,
+			validator.NewLayoutComponentValidator(),
+			repository.NewArticleRepository(layoutDb),
+			markdown.NewRenderer(markdown.DefaultCacheSize),
+			layoutrender.NewRenderer(),
+			usecase.NewComponentDataUsecase(
+				repository.NewArticleRepository(layoutDb),
+				&testutils.MockFeedArticleUsecase{GetArticlesByFeedIDFunc: func(userId uint, feedID uint) ([]model.FeedArticleResponse, error) {
+					return nil, errors.New("フィードに接続できません")
+				}},
+				&testutils.MockQiitaUsecase{GetQiitaArticlesFunc: func(userId uint) ([]model.QiitaArticleResponse, error) {
+					return []model.QiitaArticleResponse{{ID: "q1", Title: "Qiitaの記事", URL: "https://qiita.com/items/q1", LikesCount: 10}}, nil
+				}},
+				usecase.NewBookUsecase(repository.NewBookRepository(layoutDb), validator.NewBookValidator(), repository.NewSearchRepository(layoutDb)),
+				&testutils.MockResponseMappingUsecase{},
+				0,
+			),
+		)
 	}
 
 	// 既存のテストレイアウトを明示的に削除（念のため）
@@ -0,0 +1,198 @@
+package layout_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"testing"
+)
+
+// 配置のテスト用にグリッドの設定とコンポーネントを持つレイアウトを作成
+func createArrangedLayout(t *testing.T, collisionMode string) (model.Layout, []model.LayoutComponent) {
+	layout := model.Layout{Title: generateUniqueTitle(), GridColumns: 12, RowHeight: 30, CanvasWidth: 1200, CollisionMode: collisionMode, UserId: testUserId}
+	if err := layoutDb.Create(&layout).Error; err != nil {
+		t.Fatalf("テストレイアウトの作成に失敗しました: %v", err)
+	}
+	components := []model.LayoutComponent{
+		{Name: "ヘッダー", Type: "header", X: 0, Y: 0, Width: 12, Height: 2},
+		{Name: "本文", Type: "main", X: 0, Y: 2, Width: 8, Height: 10},
+		{Name: "サイドバー", Type: "sidebar", X: 8, Y: 2, Width: 4, Height: 6},
+	}
+	for i := range components {
+		components[i].UserId = testUserId
+		components[i].LayoutId = &layout.ID
+	}
+	if err := layoutDb.Create(&components).Error; err != nil {
+		t.Fatalf("テストコンポーネントの作成に失敗しました: %v", err)
+	}
+	return layout, components
+}
+
+func findComponent(t *testing.T, componentId uint) model.LayoutComponent {
+	var component model.LayoutComponent
+	if err := layoutDb.First(&component, componentId).Error; err != nil {
+		t.Fatalf("コンポーネントの取得に失敗しました: %v", err)
+	}
+	return component
+}
+
+func TestLayoutUsecase_UpdateArrangement(t *testing.T) {
+	setupLayoutUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("すべてのコンポーネントの位置を一括で更新しバージョンを上げる", func(t *testing.T) {
+			layout, components := createArrangedLayout(t, model.CollisionModeReject)
+			header, main, sidebar := components[0], components[1], components[2]
+
+			// サイドバーを左、本文を右に入れ替える
+			res, err := layoutUsecase.UpdateArrangement(testUserId, layout.ID, model.ArrangementRequest{
+				Version: layout.Version,
+				Components: []model.ComponentPosition{
+					{ComponentId: sidebar.ID, PositionRequest: model.PositionRequest{X: 0, Y: 2, Width: 4, Height: 6}},
+					{ComponentId: main.ID, PositionRequest: model.PositionRequest{X: 4, Y: 2, Width: 8, Height: 10}},
+				},
+			})
+			if err != nil {
+				t.Fatalf("UpdateArrangement() error = %v", err)
+			}
+			if res.Version != layout.Version+1 {
+				t.Errorf("UpdateArrangement() version = %d, want %d", res.Version, layout.Version+1)
+			}
+			if len(res.Components) != 3 {
+				t.Errorf("UpdateArrangement() returned %d components, want 3", len(res.Components))
+			}
+
+			if saved := findComponent(t, sidebar.ID); saved.X != 0 || saved.Y != 2 {
+				t.Errorf("sidebar position = (%d,%d), want (0,2)", saved.X, saved.Y)
+			}
+			if saved := findComponent(t, main.ID); saved.X != 4 || saved.Y != 2 {
+				t.Errorf("main position = (%d,%d), want (4,2)", saved.X, saved.Y)
+			}
+			if saved := findComponent(t, header.ID); saved.X != 0 || saved.Y != 0 {
+				t.Errorf("header position = (%d,%d), want (0,0)", saved.X, saved.Y)
+			}
+		})
+
+		t.Run("指定しなかったコンポーネントは重なる場合に下に押し出される", func(t *testing.T) {
+			layout, components := createArrangedLayout(t, model.CollisionModePush)
+			header, main := components[0], components[1]
+
+			// ヘッダーの高さを4にすると、本文とサイドバーが押し出される
+			_, err := layoutUsecase.UpdateArrangement(testUserId, layout.ID, model.ArrangementRequest{
+				Version: layout.Version,
+				Components: []model.ComponentPosition{
+					{ComponentId: header.ID, PositionRequest: model.PositionRequest{X: 0, Y: 0, Width: 12, Height: 4}},
+				},
+			})
+			if err != nil {
+				t.Fatalf("UpdateArrangement() error = %v", err)
+			}
+			if saved := findComponent(t, main.ID); saved.Y != 4 {
+				t.Errorf("main y = %d, want 4", saved.Y)
+			}
+		})
+
+		t.Run("スナップショットを指定すると配置後の状態を保存する", func(t *testing.T) {
+			layout, components := createArrangedLayout(t, model.CollisionModePush)
+
+			res, err := layoutUsecase.UpdateArrangement(testUserId, layout.ID, model.ArrangementRequest{
+				Version:       layout.Version,
+				Snapshot:      true,
+				SnapshotLabel: "配置変更",
+				Components: []model.ComponentPosition{
+					{ComponentId: components[0].ID, PositionRequest: model.PositionRequest{X: 0, Y: 0, Width: 12, Height: 3}},
+				},
+			})
+			if err != nil {
+				t.Fatalf("UpdateArrangement() error = %v", err)
+			}
+
+			var snapshots []model.LayoutSnapshot
+			layoutDb.Where("layout_id = ?", layout.ID).Find(&snapshots)
+			if len(snapshots) != 1 || snapshots[0].Label != "配置変更" || snapshots[0].Version != res.Version {
+				t.Fatalf("snapshots = %+v, want 1 snapshot of version %d", snapshots, res.Version)
+			}
+			data, err := snapshots[0].ParseData()
+			if err != nil {
+				t.Fatalf("ParseData() error = %v", err)
+			}
+			if len(data.Components) != 3 || data.Components[0].Height != 3 {
+				t.Errorf("snapshot components = %+v", data.Components)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("バージョンが古い場合はErrLayoutVersionConflictを返し何も更新しない", func(t *testing.T) {
+			layout, components := createArrangedLayout(t, model.CollisionModePush)
+			header := components[0]
+			request := model.ArrangementRequest{
+				Version: layout.Version,
+				Components: []model.ComponentPosition{
+					{ComponentId: header.ID, PositionRequest: model.PositionRequest{X: 0, Y: 0, Width: 12, Height: 3}},
+				},
+			}
+
+			// 1回目の更新でバージョンが上がる
+			if _, err := layoutUsecase.UpdateArrangement(testUserId, layout.ID, request); err != nil {
+				t.Fatalf("UpdateArrangement() error = %v", err)
+			}
+
+			// 同じバージョンで再度更新すると競合する
+			request.Components[0].Height = 5
+			_, err := layoutUsecase.UpdateArrangement(testUserId, layout.ID, request)
+			if !errors.Is(err, usecase.ErrLayoutVersionConflict) {
+				t.Fatalf("UpdateArrangement() error = %v, want ErrLayoutVersionConflict", err)
+			}
+			if saved := findComponent(t, header.ID); saved.Height != 3 {
+				t.Errorf("header height = %d, want 3", saved.Height)
+			}
+		})
+
+		t.Run("重なりを拒否するレイアウトで重なる配置はErrPositionConflictを返す", func(t *testing.T) {
+			layout, components := createArrangedLayout(t, model.CollisionModeReject)
+			main := components[1]
+
+			_, err := layoutUsecase.UpdateArrangement(testUserId, layout.ID, model.ArrangementRequest{
+				Version: layout.Version,
+				Components: []model.ComponentPosition{
+					{ComponentId: main.ID, PositionRequest: model.PositionRequest{X: 0, Y: 1}},
+				},
+			})
+			if !errors.Is(err, usecase.ErrPositionConflict) {
+				t.Errorf("UpdateArrangement() error = %v, want ErrPositionConflict", err)
+			}
+		})
+
+		t.Run("他のレイアウトのコンポーネントを含む場合はErrInvalidArrangementを返す", func(t *testing.T) {
+			layout, _ := createArrangedLayout(t, model.CollisionModePush)
+			_, otherComponents := createArrangedLayout(t, model.CollisionModePush)
+
+			_, err := layoutUsecase.UpdateArrangement(testUserId, layout.ID, model.ArrangementRequest{
+				Version: layout.Version,
+				Components: []model.ComponentPosition{
+					{ComponentId: otherComponents[0].ID, PositionRequest: model.PositionRequest{X: 0, Y: 20}},
+				},
+			})
+			if !errors.Is(err, usecase.ErrInvalidArrangement) {
+				t.Errorf("UpdateArrangement() error = %v, want ErrInvalidArrangement", err)
+			}
+		})
+
+		t.Run("同じコンポーネントが重複している場合はErrInvalidArrangementを返す", func(t *testing.T) {
+			layout, components := createArrangedLayout(t, model.CollisionModePush)
+			header := components[0]
+
+			_, err := layoutUsecase.UpdateArrangement(testUserId, layout.ID, model.ArrangementRequest{
+				Version: layout.Version,
+				Components: []model.ComponentPosition{
+					{ComponentId: header.ID, PositionRequest: model.PositionRequest{X: 0, Y: 0}},
+					{ComponentId: header.ID, PositionRequest: model.PositionRequest{X: 0, Y: 20}},
+				},
+			})
+			if !errors.Is(err, usecase.ErrInvalidArrangement) {
+				t.Errorf("UpdateArrangement() error = %v, want ErrInvalidArrangement", err)
+			}
+		})
+	})
+}
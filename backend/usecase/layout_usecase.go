@@ -1,26 +1,59 @@
 package usecase
 
 import (
+	"errors"
+	"fmt"
 	"go-react-app/model"
 	"go-react-app/repository"
+	"go-react-app/utils/layoutgrid"
+	"go-react-app/utils/layoutrender"
+	"go-react-app/utils/markdown"
 	"go-react-app/validator"
+	"log"
+	"sort"
+	"time"
 )
 
+var (
+	// ErrInvalidArrangement はレイアウトの一括配置のリクエストが不正な場合のエラー
+	ErrInvalidArrangement = errors.New("invalid arrangement")
+	// ErrLayoutVersionConflict はレイアウトが他の編集で更新されていた場合のエラー
+	ErrLayoutVersionConflict = repository.ErrLayoutVersionConflict
+	// ErrInvalidLayoutDocument は読み込むレイアウトのJSONの形式またはレイアウトの設定が不正な場合のエラー
+	ErrInvalidLayoutDocument = errors.New("invalid layout document")
+	// ErrInvalidBreakpoint は対応していないブレークポイントが指定された場合のエラー
+	ErrInvalidBreakpoint = errors.New("invalid breakpoint")
+)
+
+// layoutPreviewCards プレビューの記事カードに表示する記事の最大件数
+const layoutPreviewCards = 10
+
 type ILayoutUsecase interface {
 	GetAllLayouts(userId uint) ([]model.LayoutResponse, error)
-	GetLayoutById(userId uint, layoutId uint) (model.LayoutResponse, error)
+	GetLayoutById(userId uint, layoutId uint, breakpoint string) (model.LayoutResponse, error)
 	CreateLayout(request model.LayoutRequest) (model.LayoutResponse, error)
 	UpdateLayout(request model.LayoutRequest, userId uint, layoutId uint) (model.LayoutResponse, error)
 	DeleteLayout(userId uint, layoutId uint) error
+	RenderLayout(userId uint, layoutId uint, breakpoint string) ([]byte, error)
+	UpdateArrangement(userId uint, layoutId uint, request model.ArrangementRequest) (model.LayoutResponse, error)
+	DuplicateLayout(userId uint, layoutId uint) (model.LayoutResponse, error)
+	ExportLayout(userId uint, layoutId uint) (model.LayoutDocument, error)
+	ImportLayout(userId uint, document model.LayoutDocument) (model.LayoutImportResponse, error)
 }
 
 type layoutUsecase struct {
-	lr repository.ILayoutRepository
-	lv validator.ILayoutValidator
+	lr  repository.ILayoutRepository
+	sr  repository.ILayoutSnapshotRepository
+	lv  validator.ILayoutValidator
+	lcv validator.ILayoutComponentValidator
+	ar  repository.IArticleRepository
+	mr  markdown.IRenderer
+	rr  layoutrender.IRenderer
+	cdu IComponentDataUsecase
 }
 
-func NewLayoutUsecase(lr repository.ILayoutRepository, lv validator.ILayoutValidator) ILayoutUsecase {
-	return &layoutUsecase{lr, lv}
+func NewLayoutUsecase(lr repository.ILayoutRepository, sr repository.ILayoutSnapshotRepository, lv validator.ILayoutValidator, lcv validator.ILayoutComponentValidator, ar repository.IArticleRepository, mr markdown.IRenderer, rr layoutrender.IRenderer, cdu IComponentDataUsecase) ILayoutUsecase {
+	return &layoutUsecase{lr, sr, lv, lcv, ar, mr, rr, cdu}
 }
 
 func (lu *layoutUsecase) GetAllLayouts(userId uint) ([]model.LayoutResponse, error) {
@@ -36,12 +69,28 @@ func (lu *layoutUsecase) GetAllLayouts(userId uint) ([]model.LayoutResponse, err
 	return responses, nil
 }
 
-func (lu *layoutUsecase) GetLayoutById(userId uint, layoutId uint) (model.LayoutResponse, error) {
+// GetLayoutById はレイアウトを、コンポーネントの位置とサイズをブレークポイントでの値にして返します
+func (lu *layoutUsecase) GetLayoutById(userId uint, layoutId uint, breakpoint string) (model.LayoutResponse, error) {
+	if err := lu.lv.ValidateBreakpoint(breakpoint); err != nil {
+		return model.LayoutResponse{}, fmt.Errorf("%w: %v", ErrInvalidBreakpoint, err)
+	}
 	layout, err := lu.lr.GetLayoutById(userId, layoutId)
 	if err != nil {
 		return model.LayoutResponse{}, err
 	}
-	return layout.ToResponse(), nil
+	return breakpointResponse(layout, breakpoint), nil
+}
+
+// breakpointResponse はコンポーネントの位置とサイズをブレークポイントでの値にし、子コンポーネントを親のchildrenに入れたレスポンスを返します
+func breakpointResponse(layout model.Layout, breakpoint string) model.LayoutResponse {
+	if breakpoint == "" {
+		breakpoint = model.BreakpointDesktop
+	}
+	resolved := layout.AtBreakpoint(breakpoint)
+	response := resolved.ToResponse()
+	response.Breakpoint = breakpoint
+	response.Components = model.BuildComponentTree(response.Components)
+	return response
 }
 
 func (lu *layoutUsecase) CreateLayout(request model.LayoutRequest) (model.LayoutResponse, error) {
@@ -73,3 +122,457 @@ func (lu *layoutUsecase) UpdateLayout(request model.LayoutRequest, userId uint,
 func (lu *layoutUsecase) DeleteLayout(userId uint, layoutId uint) error {
 	return lu.lr.DeleteLayout(userId, layoutId)
 }
+
+// DuplicateLayout はレイアウトとそのコンポーネントをすべて複製した新しいレイアウトを作成します
+// スナップショットと公開中のバージョンは複製せず、新しいレイアウトはバージョン1から始まる
+func (lu *layoutUsecase) DuplicateLayout(userId uint, layoutId uint) (model.LayoutResponse, error) {
+	layout, err := lu.lr.GetLayoutById(userId, layoutId)
+	if err != nil {
+		return model.LayoutResponse{}, err
+	}
+
+	data := model.NewSnapshotData(layout)
+	duplicate := data.NewLayout(layout.Title+" のコピー", userId)
+	if err := lu.lr.CreateLayoutTree(&duplicate, data.ParentIndexes()); err != nil {
+		return model.LayoutResponse{}, err
+	}
+	return duplicate.ToResponse(), nil
+}
+
+// ExportLayout はレイアウトとそのコンポーネントを、他の環境で読み込めるJSONとして書き出します
+func (lu *layoutUsecase) ExportLayout(userId uint, layoutId uint) (model.LayoutDocument, error) {
+	layout, err := lu.lr.GetLayoutById(userId, layoutId)
+	if err != nil {
+		return model.LayoutDocument{}, err
+	}
+	return model.NewLayoutDocument(layout, time.Now()), nil
+}
+
+// ImportLayout は書き出したJSONから新しいレイアウトを作成します
+// コンポーネントは1つずつ検証し、不正なもの・IDが重複するもの・重なりを拒否するレイアウトで重なるものは読み込まずに結果で報告する
+// 読み込むコンポーネントはレイアウトと一緒に1回で保存するため、途中で失敗して一部だけが作成されることはない
+func (lu *layoutUsecase) ImportLayout(userId uint, document model.LayoutDocument) (model.LayoutImportResponse, error) {
+	if err := lu.lv.ValidateLayoutDocument(document); err != nil {
+		return model.LayoutImportResponse{}, fmt.Errorf("%w: %v", ErrInvalidLayoutDocument, err)
+	}
+	conflicts := []model.ImportConflict{}
+
+	existing, err := lu.lr.GetAllLayouts(userId)
+	if err != nil {
+		return model.LayoutImportResponse{}, err
+	}
+	title := uniqueLayoutTitle(document.Layout.Title, existing)
+	if title != document.Layout.Title {
+		conflicts = append(conflicts, model.ImportConflict{
+			Index:   -1,
+			Action:  model.ImportActionRenamed,
+			Message: fmt.Sprintf("同じタイトルのレイアウトがあるため「%s」として読み込みました", title),
+		})
+	}
+
+	layout := model.Layout{
+		Title:         title,
+		GridColumns:   document.Layout.Grid.Columns,
+		RowHeight:     document.Layout.Grid.RowHeight,
+		CanvasWidth:   document.Layout.Grid.CanvasWidth,
+		CollisionMode: document.Layout.Grid.CollisionMode,
+		UserId:        userId,
+	}
+	settings := layout.GridSettings()
+	breakpointSettings := make(map[string]model.GridSettings, len(model.Breakpoints)-1)
+	for _, breakpoint := range model.Breakpoints[1:] {
+		resolved := layout.AtBreakpoint(breakpoint)
+		breakpointSettings[breakpoint] = resolved.GridSettings()
+	}
+
+	// 読み込むコンポーネントと書き出し元のID・位置の対応
+	type candidate struct {
+		index     int
+		sourceId  uint
+		component model.LayoutComponent
+	}
+	candidates := []candidate{}
+	seen := make(map[uint]bool)
+	// 親を先に読み込むため、浅いコンポーネントから順に処理する
+	parents, detached, order := resolveImportParents(document.Layout.Components)
+	for i, message := range detached {
+		conflicts = append(conflicts, model.ImportConflict{Index: i, ComponentId: document.Layout.Components[i].ID, Action: model.ImportActionDetached, Message: message})
+	}
+	accepted := make(map[int]bool, len(order))
+	for _, i := range order {
+		source := document.Layout.Components[i]
+		skip := func(message string) {
+			conflicts = append(conflicts, model.ImportConflict{Index: i, ComponentId: source.ID, Action: model.ImportActionSkipped, Message: message})
+		}
+		if source.ID != 0 && seen[source.ID] {
+			skip(fmt.Sprintf("コンポーネントID %d が重複しています", source.ID))
+			continue
+		}
+		parent, hasParent := parents[i]
+		if hasParent && !accepted[parent] {
+			skip(fmt.Sprintf("親コンポーネント %d を読み込まなかったため読み込みません", document.Layout.Components[parent].ID))
+			continue
+		}
+
+		request := model.LayoutComponentRequest{
+			Name:       source.Name,
+			Type:       source.Type,
+			Content:    source.Content,
+			Properties: normalizeProperties(source.Properties),
+			X:          source.X,
+			Y:          source.Y,
+			Width:      source.Width,
+			Height:     source.Height,
+			UserId:     userId,
+		}
+		if err := lu.lcv.ValidateLayoutComponentRequest(request); err != nil {
+			skip(err.Error())
+			continue
+		}
+		if err := lu.lcv.ValidatePositionRequest(model.PositionRequest{X: source.X, Y: source.Y, Width: source.Width, Height: source.Height}); err != nil {
+			skip(err.Error())
+			continue
+		}
+		var overrides model.BreakpointGeometry
+		if source.Breakpoints != nil {
+			overrides = *source.Breakpoints
+		}
+		if err := lu.validateBreakpointOverrides(overrides); err != nil {
+			skip(err.Error())
+			continue
+		}
+		if componentType, ok := model.FindComponentType(request.Type); ok {
+			if request.Width == 0 {
+				request.Width = componentType.DefaultWidth
+			}
+			if request.Height == 0 {
+				request.Height = componentType.DefaultHeight
+			}
+		}
+
+		component := request.ToModel()
+		// 重なりは同じ親を持つコンポーネントの間で判定するため、保存するまでは書き出し元の親のIDを入れておく
+		if hasParent {
+			parentId := document.Layout.Components[parent].ID
+			component.ParentId = &parentId
+		}
+		component = layoutgrid.Snap(settings, component, model.PositionRequest{X: component.X, Y: component.Y, Width: component.Width, Height: component.Height, Unit: model.PositionUnitGrid})
+		// タブレット・モバイルの位置はそのブレークポイントのグリッドに合わせる（重なりは描画時に解消されるため判定しない）
+		for _, breakpoint := range model.Breakpoints[1:] {
+			if geometry := overrides.At(breakpoint); geometry != nil {
+				snapped := layoutgrid.Snap(breakpointSettings[breakpoint], component, model.PositionRequest{X: geometry.X, Y: geometry.Y, Width: geometry.Width, Height: geometry.Height, Unit: model.PositionUnitGrid})
+				component.SetGeometry(breakpoint, model.Geometry{X: snapped.X, Y: snapped.Y, Width: snapped.Width, Height: snapped.Height})
+			}
+		}
+		if settings.CollisionMode == model.CollisionModeReject {
+			// 保存前のコンポーネントはIDがないため、Overlappingではなく並べた中の最初の重なりで判定する
+			placed := make([]model.LayoutComponent, 0, len(candidates)+1)
+			for _, accepted := range candidates {
+				placed = append(placed, accepted.component)
+			}
+			if blocker, _, found := layoutgrid.FirstOverlap(append(placed, component)); found {
+				skip(fmt.Sprintf("%s と重なっています", blocker.Name))
+				continue
+			}
+		}
+		if source.ID != 0 {
+			seen[source.ID] = true
+		}
+		accepted[i] = true
+		candidates = append(candidates, candidate{i, source.ID, component})
+	}
+
+	// 重なりを押し出すレイアウトでは、上にあるものから順に重ならない位置に移動する
+	if settings.CollisionMode != model.CollisionModeReject {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			a, b := candidates[i].component, candidates[j].component
+			if a.Y != b.Y {
+				return a.Y < b.Y
+			}
+			return a.X < b.X
+		})
+		components := make([]model.LayoutComponent, len(candidates))
+		for i, accepted := range candidates {
+			components[i] = accepted.component
+		}
+		for i, settled := range layoutgrid.Settle(components) {
+			if settled.Y != candidates[i].component.Y {
+				conflicts = append(conflicts, model.ImportConflict{
+					Index:       candidates[i].index,
+					ComponentId: candidates[i].sourceId,
+					Action:      model.ImportActionMoved,
+					Message:     fmt.Sprintf("他のコンポーネントと重なるためy=%dからy=%dに移動しました", candidates[i].component.Y, settled.Y),
+				})
+				candidates[i].component = settled
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].index < candidates[j].index })
+
+	layout.Components = make([]model.LayoutComponent, len(candidates))
+	positions := make(map[int]int, len(candidates))
+	for i, accepted := range candidates {
+		layout.Components[i] = accepted.component
+		layout.Components[i].ParentId = nil
+		positions[accepted.index] = i
+	}
+	componentParents := make(map[int]int)
+	for i, accepted := range candidates {
+		if parent, ok := parents[accepted.index]; ok {
+			componentParents[i] = positions[parent]
+		}
+	}
+	if err := lu.lr.CreateLayoutTree(&layout, componentParents); err != nil {
+		return model.LayoutImportResponse{}, err
+	}
+
+	idMap := make(map[uint]uint)
+	for i, accepted := range candidates {
+		if accepted.sourceId != 0 {
+			idMap[accepted.sourceId] = layout.Components[i].ID
+		}
+	}
+	sort.SliceStable(conflicts, func(i, j int) bool { return conflicts[i].Index < conflicts[j].Index })
+	return model.LayoutImportResponse{Layout: layout.ToResponse(), IdMap: idMap, Conflicts: conflicts}, nil
+}
+
+// resolveImportParents は読み込むコンポーネントの親子関係を、Components内の位置で子から親への対応として返します
+// 親が見つからない・子を持てない種類・循環している・入れ子が深すぎる場合は親を外し、その理由をdetachedで返す
+// orderは親が子より先になるよう、浅いコンポーネントから順に並べた位置
+func resolveImportParents(components []model.SnapshotComponent) (parents map[int]int, detached map[int]string, order []int) {
+	indexes := make(map[uint]int, len(components))
+	for i, component := range components {
+		if _, duplicated := indexes[component.ID]; component.ID != 0 && !duplicated {
+			indexes[component.ID] = i
+		}
+	}
+	parents = make(map[int]int)
+	detached = make(map[int]string)
+	for i, component := range components {
+		if component.ParentId == nil {
+			continue
+		}
+		parent, ok := indexes[*component.ParentId]
+		if !ok || parent == i {
+			detached[i] = fmt.Sprintf("親コンポーネント %d がないため、レイアウトの直下に読み込みました", *component.ParentId)
+			continue
+		}
+		if componentType, ok := model.FindComponentType(components[parent].Type); !ok || !componentType.Container {
+			detached[i] = fmt.Sprintf("%s は子コンポーネントを持てないため、レイアウトの直下に読み込みました", components[parent].Name)
+			continue
+		}
+		parents[i] = parent
+	}
+
+	depth := func(i int) int {
+		d := 1
+		for node, ok := parents[i]; ok && d <= len(components); node, ok = parents[node] {
+			if node == i {
+				return -1
+			}
+			d++
+		}
+		return d
+	}
+	depths := make([]int, len(components))
+	for i := range components {
+		switch d := depth(i); {
+		case d < 0 || d > len(components):
+			delete(parents, i)
+			detached[i] = "親子関係が循環しているため、レイアウトの直下に読み込みました"
+		case d > model.MaxComponentDepth:
+			delete(parents, i)
+			detached[i] = fmt.Sprintf("コンポーネントの入れ子は%d階層までのため、レイアウトの直下に読み込みました", model.MaxComponentDepth)
+		}
+	}
+	order = make([]int, len(components))
+	for i := range components {
+		depths[i] = depth(i)
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return depths[order[a]] < depths[order[b]] })
+	return parents, detached, order
+}
+
+// uniqueLayoutTitle は既存のレイアウトと重ならないタイトルを返します（重なる場合は「タイトル (2)」のように番号を付ける）
+func uniqueLayoutTitle(title string, layouts []model.Layout) string {
+	taken := make(map[string]bool, len(layouts))
+	for _, layout := range layouts {
+		taken[layout.Title] = true
+	}
+	candidate := title
+	for n := 2; taken[candidate]; n++ {
+		candidate = fmt.Sprintf("%s (%d)", title, n)
+	}
+	return candidate
+}
+
+// UpdateArrangement はレイアウトのコンポーネントの位置を一括で更新します
+// 指定されなかったコンポーネントは現在の位置のまま、グリッドへの吸着と重なりの処理の対象になる
+// リクエストのバージョンが現在のバージョンと異なる場合は、他の編集を上書きしないようErrLayoutVersionConflictを返す
+// snapshotが指定された場合は、更新後の状態をスナップショットとして保存する
+// breakpointがtablet・mobileの場合はそのブレークポイントの位置を上書きし、位置が変わらなかったコンポーネントは大きいブレークポイントの値を引き継いだままにする
+func (lu *layoutUsecase) UpdateArrangement(userId uint, layoutId uint, request model.ArrangementRequest) (model.LayoutResponse, error) {
+	if err := lu.lv.ValidateArrangementRequest(request); err != nil {
+		return model.LayoutResponse{}, fmt.Errorf("%w: %v", ErrInvalidArrangement, err)
+	}
+	layout, err := lu.lr.GetLayoutById(userId, layoutId)
+	if err != nil {
+		return model.LayoutResponse{}, err
+	}
+	if layout.Version != request.Version {
+		return model.LayoutResponse{}, fmt.Errorf("%w: current version is %d", ErrLayoutVersionConflict, layout.Version)
+	}
+
+	breakpoint := request.Breakpoint
+	if breakpoint == "" {
+		breakpoint = model.BreakpointDesktop
+	}
+	saved := make(map[uint]model.LayoutComponent, len(layout.Components))
+	for _, component := range layout.Components {
+		saved[component.ID] = component
+	}
+	resolved := layout.AtBreakpoint(breakpoint)
+	current := make(map[uint]model.LayoutComponent, len(resolved.Components))
+	for _, component := range resolved.Components {
+		current[component.ID] = component
+	}
+	settings := resolved.GridSettings()
+	requested := make([]model.LayoutComponent, 0, len(request.Components))
+	for _, position := range request.Components {
+		component, ok := current[position.ComponentId]
+		if !ok {
+			return model.LayoutResponse{}, fmt.Errorf("%w: コンポーネント%dはこのレイアウトに含まれていません", ErrInvalidArrangement, position.ComponentId)
+		}
+		requested = append(requested, layoutgrid.Snap(settings, component, position.PositionRequest))
+		delete(current, position.ComponentId)
+	}
+	others := make([]model.LayoutComponent, 0, len(current))
+	for _, component := range current {
+		others = append(others, component)
+	}
+	layoutgrid.SortByPosition(requested)
+	layoutgrid.SortByPosition(others)
+
+	// 指定されたコンポーネントの位置を優先し、残りのコンポーネントはその後に配置する
+	arranged := append(requested, others...)
+	if settings.CollisionMode == model.CollisionModeReject {
+		if a, b, found := layoutgrid.FirstOverlap(arranged); found {
+			return model.LayoutResponse{}, fmt.Errorf("%w: %s, %s", ErrPositionConflict, a.Name, b.Name)
+		}
+	} else {
+		arranged = layoutgrid.Settle(arranged)
+	}
+
+	// ブレークポイントでの位置を保存する値に戻す（指定されたコンポーネントと位置が変わったコンポーネントのみ上書きする）
+	for i, component := range arranged {
+		original := saved[component.ID]
+		geometry := model.Geometry{X: component.X, Y: component.Y, Width: component.Width, Height: component.Height}
+		if i < len(requested) || geometry != original.GeometryAt(breakpoint) {
+			original.SetGeometry(breakpoint, geometry)
+		}
+		arranged[i] = original
+	}
+
+	if err := lu.lr.UpdateArrangement(userId, layoutId, request.Version, arranged); err != nil {
+		return model.LayoutResponse{}, err
+	}
+	if layout, err = lu.lr.GetLayoutById(userId, layoutId); err != nil {
+		return model.LayoutResponse{}, err
+	}
+	if request.Snapshot {
+		snapshot, err := model.NewLayoutSnapshot(layout, request.SnapshotLabel)
+		if err != nil {
+			return model.LayoutResponse{}, err
+		}
+		if err := lu.sr.CreateSnapshot(&snapshot); err != nil {
+			return model.LayoutResponse{}, err
+		}
+	}
+	return breakpointResponse(layout, breakpoint), nil
+}
+
+// RenderLayout はレイアウトをHTMLとして描画したプレビューを返します
+// 記事カードとカレンダーにはユーザーの最近の公開記事を、データを取得するコンポーネントには取得元のデータを表示します
+// breakpointを指定した場合はそのブレークポイントでの配置を、省略した場合は画面幅に応じて配置が変わるページを描画する
+func (lu *layoutUsecase) RenderLayout(userId uint, layoutId uint, breakpoint string) ([]byte, error) {
+	if err := lu.lv.ValidateBreakpoint(breakpoint); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBreakpoint, err)
+	}
+	layout, err := lu.lr.GetLayoutById(userId, layoutId)
+	if err != nil {
+		return nil, err
+	}
+	articles := []model.Article{}
+	if err := lu.ar.GetPublishedArticles(&articles, userId, "", layoutPreviewCards); err != nil {
+		return nil, err
+	}
+
+	page := layoutrender.Page{
+		SiteTitle:   layout.Title,
+		NoIndex:     true,
+		InlineStyle: true,
+		Breakpoint:  breakpoint,
+		Cards:       make([]layoutrender.Card, 0, len(articles)),
+	}
+	for _, article := range articles {
+		excerpt := article.MetaDescription
+		if excerpt == "" {
+			html, err := lu.mr.Render(article.Content)
+			if err != nil {
+				return nil, err
+			}
+			excerpt = markdown.Excerpt(html, markdown.DefaultExcerptLength)
+		}
+		page.Cards = append(page.Cards, layoutrender.Card{
+			Title:    article.Title,
+			Excerpt:  excerpt,
+			ImageURL: article.OGImage,
+			Date:     article.CreatedAt,
+			Tags:     splitTags(article.Tags),
+		})
+		page.Highlights = append(page.Highlights, article.CreatedAt)
+	}
+	page.Data = lu.resolveComponentData(userId, layout)
+	return lu.rr.RenderPage(layout, page)
+}
+
+// resolveComponentData はデータを取得するコンポーネントに表示する項目を取得します
+// 取得元の障害でプレビュー全体が表示できなくならないよう、取得に失敗したコンポーネントはその旨を表示する
+func (lu *layoutUsecase) resolveComponentData(userId uint, layout model.Layout) map[uint]layoutrender.ComponentData {
+	data := make(map[uint]layoutrender.ComponentData)
+	for _, component := range layout.Components {
+		if component.DataSource() == "" {
+			continue
+		}
+		resolved, err := lu.cdu.ResolveComponentData(userId, component)
+		if err != nil {
+			log.Printf("コンポーネント%dのデータの取得に失敗しました: %v", component.ID, err)
+			data[component.ID] = layoutrender.ComponentData{Failed: true}
+			continue
+		}
+		cards := make([]layoutrender.Card, len(resolved.Items))
+		for i, item := range resolved.Items {
+			cards[i] = layoutrender.Card{Title: item.Title, URL: item.URL, Excerpt: item.Excerpt, ImageURL: item.ImageURL, Tags: item.Tags}
+			if item.Date != nil {
+				cards[i].Date = *item.Date
+			}
+		}
+		data[component.ID] = layoutrender.ComponentData{Cards: cards}
+	}
+	return data
+}
+
+// validateBreakpointOverrides はタブレット・モバイルで上書きする位置とサイズを検証します
+func (lu *layoutUsecase) validateBreakpointOverrides(overrides model.BreakpointGeometry) error {
+	for _, breakpoint := range model.Breakpoints[1:] {
+		geometry := overrides.At(breakpoint)
+		if geometry == nil {
+			continue
+		}
+		if err := lu.lcv.ValidatePositionRequest(model.PositionRequest{X: geometry.X, Y: geometry.Y, Width: geometry.Width, Height: geometry.Height}); err != nil {
+			return fmt.Errorf("%s: %v", breakpoint, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package media_test
+
+import (
+	"bytes"
+	"go-react-app/utils/storage"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// 圧縮の効かないノイズ画像を作成するヘルパー関数
+func createNoisePNG(t *testing.T, width int, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{uint8(rand.Intn(256)), uint8(rand.Intn(256)), uint8(rand.Intn(256)), 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("テスト画像の作成に失敗しました: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// slowStorage は保存に時間のかかるストレージ
+type slowStorage struct {
+	storage.IStorage
+}
+
+func (s slowStorage) Put(key string, body io.Reader, contentType string) error {
+	time.Sleep(20 * time.Millisecond)
+	return s.IStorage.Put(key, body, contentType)
+}
@@ -0,0 +1,107 @@
+package media_test
+
+import (
+	"encoding/json"
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"strings"
+	"testing"
+)
+
+func TestMediaUsecase_DeleteMedia(t *testing.T) {
+	setupMediaUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("参照されていないメディアを削除する", func(t *testing.T) {
+			media := uploadTestMedia(t, mediaTestUser.ID)
+
+			references, err := mediaUsecase.DeleteMedia(mediaTestUser.ID, media.ID)
+			if err != nil || len(references) != 0 {
+				t.Fatalf("DeleteMedia() = %v, %v", references, err)
+			}
+
+			if _, err := mediaUsecase.GetMediaById(mediaTestUser.ID, media.ID); err == nil {
+				t.Error("DeleteMedia() did not delete the record")
+			}
+			if _, err := mediaUsecase.OpenFile(strings.TrimPrefix(media.URL, "/uploads/")); err == nil {
+				t.Error("DeleteMedia() did not delete the file")
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("記事から参照されているメディアは削除できない", func(t *testing.T) {
+			media := uploadTestMedia(t, mediaTestUser.ID)
+			article := model.Article{Title: "画像付きの記事", Content: "![cover](http://localhost:8080" + media.URL + ")", UserId: mediaTestUser.ID}
+			mediaDb.Create(&article)
+
+			references, err := mediaUsecase.DeleteMedia(mediaTestUser.ID, media.ID)
+			if !errors.Is(err, usecase.ErrMediaInUse) {
+				t.Fatalf("DeleteMedia() error = %v, want ErrMediaInUse", err)
+			}
+			if len(references) != 1 || references[0].Type != model.MediaReferenceArticle || references[0].ID != article.ID {
+				t.Errorf("DeleteMedia() references = %+v", references)
+			}
+			if _, err := mediaUsecase.GetMediaById(mediaTestUser.ID, media.ID); err != nil {
+				t.Errorf("DeleteMedia() deleted referenced media: %v", err)
+			}
+		})
+
+		t.Run("公開中のスナップショットだけから参照されているメディアは削除できない", func(t *testing.T) {
+			media := uploadTestMedia(t, mediaTestUser.ID)
+			// 編集中のレイアウトからは画像を外したが、公開中のスナップショットにはまだ含まれている
+			layout := model.Layout{Title: "公開中のレイアウト", UserId: mediaTestUser.ID}
+			mediaDb.Create(&layout)
+			snapshot := model.LayoutSnapshot{LayoutId: layout.ID, Version: 1, Data: snapshotDataWithImage(t, media.URL), UserId: mediaTestUser.ID}
+			mediaDb.Create(&snapshot)
+			mediaDb.Model(&layout).Update("live_snapshot_id", snapshot.ID)
+
+			references, err := mediaUsecase.DeleteMedia(mediaTestUser.ID, media.ID)
+			if !errors.Is(err, usecase.ErrMediaInUse) {
+				t.Fatalf("DeleteMedia() error = %v, want ErrMediaInUse", err)
+			}
+			if len(references) != 1 || references[0].Type != model.MediaReferenceLayoutSnapshot || references[0].ID != snapshot.ID {
+				t.Errorf("DeleteMedia() references = %+v", references)
+			}
+			if _, err := mediaUsecase.OpenFile(strings.TrimPrefix(media.URL, "/uploads/")); err != nil {
+				t.Errorf("DeleteMedia() deleted the file of referenced media: %v", err)
+			}
+		})
+
+		t.Run("テンプレートから参照されているメディアは削除できない", func(t *testing.T) {
+			media := uploadTestMedia(t, mediaTestUser.ID)
+			template := model.LayoutTemplate{Name: "背景画像のあるテンプレート", Data: snapshotDataWithImage(t, media.URL), UserId: mediaTestUser.ID}
+			mediaDb.Create(&template)
+
+			references, err := mediaUsecase.DeleteMedia(mediaTestUser.ID, media.ID)
+			if !errors.Is(err, usecase.ErrMediaInUse) {
+				t.Fatalf("DeleteMedia() error = %v, want ErrMediaInUse", err)
+			}
+			if len(references) != 1 || references[0].Type != model.MediaReferenceLayoutTemplate || references[0].ID != template.ID {
+				t.Errorf("DeleteMedia() references = %+v", references)
+			}
+		})
+
+		t.Run("他のユーザーのメディアは削除できない", func(t *testing.T) {
+			media := uploadTestMedia(t, mediaTestUser.ID)
+
+			if _, err := mediaUsecase.DeleteMedia(mediaOtherUser.ID, media.ID); err == nil {
+				t.Error("DeleteMedia() should fail for other user's media")
+			}
+		})
+	})
+}
+
+// snapshotDataWithImage は画像コンポーネントを含むスナップショットのJSONを作成するヘルパー関数
+func snapshotDataWithImage(t *testing.T, url string) string {
+	properties, _ := json.Marshal(map[string]string{"url": url})
+	data, err := json.Marshal(model.SnapshotData{
+		Title:      "画像のあるレイアウト",
+		Components: []model.SnapshotComponent{{ID: 1, Name: "画像", Type: "image", Properties: properties, Width: 4, Height: 4}},
+	})
+	if err != nil {
+		t.Fatalf("スナップショットの作成に失敗しました: %v", err)
+	}
+	return string(data)
+}
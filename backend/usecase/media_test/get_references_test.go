@@ -0,0 +1,62 @@
+package media_test
+
+import (
+	"go-react-app/model"
+	"testing"
+)
+
+func TestMediaUsecase_GetReferences(t *testing.T) {
+	setupMediaUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("記事・レイアウトコンポーネント・書籍からの参照を返す", func(t *testing.T) {
+			media := uploadTestMedia(t, mediaTestUser.ID)
+			mediaDb.Create(&model.Article{Title: "記事", Content: "<img src=\"" + media.ThumbnailURL + "\">", UserId: mediaTestUser.ID})
+			mediaDb.Create(&model.LayoutComponent{Name: "ヘッダー", Type: "image", Content: media.URL, UserId: mediaTestUser.ID})
+			mediaDb.Create(&model.Book{Title: "書籍", Author: "著者", ImageURL: media.URL, UserId: mediaTestUser.ID})
+			// 他のユーザーのデータは参照として扱わない
+			mediaDb.Create(&model.Article{Title: "他人の記事", Content: media.URL, UserId: mediaOtherUser.ID})
+
+			references, err := mediaUsecase.GetReferences(mediaTestUser.ID, media.ID)
+			if err != nil {
+				t.Fatalf("GetReferences() error = %v", err)
+			}
+
+			types := map[string]bool{}
+			for _, ref := range references {
+				types[ref.Type] = true
+			}
+			if len(references) != 3 || !types[model.MediaReferenceArticle] || !types[model.MediaReferenceLayoutComponent] || !types[model.MediaReferenceBook] {
+				t.Errorf("GetReferences() = %+v", references)
+			}
+		})
+
+		t.Run("OGP画像に指定した記事からの参照を返す", func(t *testing.T) {
+			media := uploadTestMedia(t, mediaTestUser.ID)
+			article := model.Article{Title: "OGP画像の記事", Content: "本文", OGImage: media.URL, UserId: mediaTestUser.ID}
+			mediaDb.Create(&article)
+
+			references, err := mediaUsecase.GetReferences(mediaTestUser.ID, media.ID)
+			if err != nil {
+				t.Fatalf("GetReferences() error = %v", err)
+			}
+			if len(references) != 1 || references[0].Type != model.MediaReferenceArticle || references[0].ID != article.ID {
+				t.Errorf("GetReferences() = %+v, want article %d", references, article.ID)
+			}
+		})
+
+		t.Run("画像コンポーネントの設定からの参照を返す", func(t *testing.T) {
+			media := uploadTestMedia(t, mediaTestUser.ID)
+			component := model.LayoutComponent{Name: "画像", Type: "image", Properties: `{"url":"` + media.URL + `","alt":"画像"}`, UserId: mediaTestUser.ID}
+			mediaDb.Create(&component)
+
+			references, err := mediaUsecase.GetReferences(mediaTestUser.ID, media.ID)
+			if err != nil {
+				t.Fatalf("GetReferences() error = %v", err)
+			}
+			if len(references) != 1 || references[0].Type != model.MediaReferenceLayoutComponent || references[0].ID != component.ID {
+				t.Errorf("GetReferences() = %+v, want layout component %d", references, component.ID)
+			}
+		})
+	})
+}
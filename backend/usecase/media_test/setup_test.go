@@ -0,0 +1,86 @@
+package media_test
+
+import (
+	"bytes"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/utils/storage"
+	"go-react-app/validator"
+	"image"
+	"image/color"
+	"image/png"
+	"log"
+	"os"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+const (
+	testMaxUploadSize = 1 << 20
+	testUserQuota     = 2 << 20
+)
+
+// テスト用の共通変数
+var (
+	mediaDb        *gorm.DB
+	mediaDir       string
+	mediaRepo      repository.IMediaRepository
+	mediaStorage   storage.IStorage
+	mediaUsecase   usecase.IMediaUsecase
+	mediaTestUser  model.User
+	mediaOtherUser model.User
+)
+
+// テスト前の共通セットアップ
+func setupMediaUsecaseTest() {
+	if mediaDb != nil {
+		testutils.CleanupTestDB(mediaDb)
+		mediaDb.Exec("DELETE FROM media")
+	} else {
+		// 初回のみデータベース接続と保存先ディレクトリを作成
+		mediaDb = testutils.SetupTestDB()
+		dir, err := os.MkdirTemp("", "media_test")
+		if err != nil {
+			log.Fatalf("一時ディレクトリの作成に失敗しました: %v", err)
+		}
+		mediaDir = dir
+		mediaRepo = repository.NewMediaRepository(mediaDb)
+		mediaStorage = storage.NewLocalStorage(mediaDir, "/uploads")
+		mediaUsecase = usecase.NewMediaUsecase(
+			mediaRepo,
+			validator.NewMediaValidator(testMaxUploadSize),
+			mediaStorage,
+			usecase.MediaConfig{MaxUploadSize: testMaxUploadSize, UserQuota: testUserQuota},
+		)
+	}
+
+	mediaTestUser = testutils.CreateTestUser(mediaDb)
+	mediaOtherUser = testutils.CreateOtherUser(mediaDb)
+}
+
+// テスト用のPNG画像を作成するヘルパー関数
+func createTestPNG(t *testing.T, width int, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 128, 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("テスト画像の作成に失敗しました: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// テスト用のメディアをアップロードするヘルパー関数
+func uploadTestMedia(t *testing.T, userId uint) model.MediaResponse {
+	res, err := mediaUsecase.UploadMedia(userId, "test.png", bytes.NewReader(createTestPNG(t, 640, 480)))
+	if err != nil {
+		t.Fatalf("テストメディアのアップロードに失敗しました: %v", err)
+	}
+	return res
+}
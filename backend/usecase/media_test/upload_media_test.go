@@ -0,0 +1,123 @@
+package media_test
+
+import (
+	"bytes"
+	"errors"
+	"go-react-app/usecase"
+	"go-react-app/validator"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMediaUsecase_UploadMedia(t *testing.T) {
+	setupMediaUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("画像とサムネイルを保存する", func(t *testing.T) {
+			res, err := mediaUsecase.UploadMedia(mediaTestUser.ID, "../cover.png", bytes.NewReader(createTestPNG(t, 640, 480)))
+			if err != nil {
+				t.Fatalf("UploadMedia() error = %v", err)
+			}
+
+			if res.FileName != "cover.png" || res.MimeType != "image/png" || res.Width != 640 || res.Height != 480 {
+				t.Errorf("UploadMedia() = %+v", res)
+			}
+			if !strings.HasPrefix(res.URL, "/uploads/") || !strings.Contains(res.ThumbnailURL, "_thumb") {
+				t.Errorf("UploadMedia() url = %s, thumbnail_url = %s", res.URL, res.ThumbnailURL)
+			}
+
+			// 保存したファイルを読み出せることを確認
+			file, err := mediaUsecase.OpenFile(strings.TrimPrefix(res.URL, "/uploads/"))
+			if err != nil {
+				t.Fatalf("OpenFile() error = %v", err)
+			}
+			defer file.Close()
+			data, _ := io.ReadAll(file)
+			if len(data) == 0 {
+				t.Error("OpenFile() returned empty file")
+			}
+		})
+
+		t.Run("一覧に使用容量を含める", func(t *testing.T) {
+			list, err := mediaUsecase.GetAllMedia(mediaTestUser.ID)
+			if err != nil {
+				t.Fatalf("GetAllMedia() error = %v", err)
+			}
+			if len(list.Items) != 1 || list.UsedBytes <= 0 || list.Quota != testUserQuota {
+				t.Errorf("GetAllMedia() = %+v", list)
+			}
+
+			otherList, _ := mediaUsecase.GetAllMedia(mediaOtherUser.ID)
+			if len(otherList.Items) != 0 || otherList.UsedBytes != 0 {
+				t.Errorf("GetAllMedia() for other user = %+v", otherList)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("画像以外のファイルは拒否する", func(t *testing.T) {
+			// 拡張子を偽装しても内容から判定する
+			_, err := mediaUsecase.UploadMedia(mediaTestUser.ID, "evil.png", strings.NewReader("<html><script>alert(1)</script></html>"))
+			if !errors.Is(err, usecase.ErrInvalidMedia) {
+				t.Errorf("UploadMedia() error = %v, want ErrInvalidMedia", err)
+			}
+		})
+
+		t.Run("サイズ上限を超えるファイルは拒否する", func(t *testing.T) {
+			data := append(createTestPNG(t, 10, 10), make([]byte, testMaxUploadSize)...)
+			_, err := mediaUsecase.UploadMedia(mediaTestUser.ID, "large.png", bytes.NewReader(data))
+			if !errors.Is(err, usecase.ErrMediaTooLarge) {
+				t.Errorf("UploadMedia() error = %v, want ErrMediaTooLarge", err)
+			}
+		})
+
+		t.Run("壊れた画像は拒否する", func(t *testing.T) {
+			data := createTestPNG(t, 10, 10)[:40]
+			_, err := mediaUsecase.UploadMedia(mediaTestUser.ID, "broken.png", bytes.NewReader(data))
+			if !errors.Is(err, usecase.ErrInvalidMedia) {
+				t.Errorf("UploadMedia() error = %v, want ErrInvalidMedia", err)
+			}
+		})
+
+		t.Run("容量の上限を超える場合は拒否する", func(t *testing.T) {
+			// 圧縮の効かない画像で容量を使い切る
+			var err error
+			for i := 0; i < 10 && err == nil; i++ {
+				_, err = mediaUsecase.UploadMedia(mediaOtherUser.ID, "noise.png", bytes.NewReader(createNoisePNG(t, 400, 400)))
+			}
+			if !errors.Is(err, usecase.ErrMediaQuotaExceeded) {
+				t.Errorf("UploadMedia() error = %v, want ErrMediaQuotaExceeded", err)
+			}
+		})
+
+		t.Run("同時にアップロードしても容量の上限を超えない", func(t *testing.T) {
+			// 保存に時間のかかるストレージで、容量の確認から登録までの間に他のアップロードが入る状況を作る
+			slowUsecase := usecase.NewMediaUsecase(
+				mediaRepo,
+				validator.NewMediaValidator(testMaxUploadSize),
+				slowStorage{mediaStorage},
+				usecase.MediaConfig{MaxUploadSize: testMaxUploadSize, UserQuota: testUserQuota},
+			)
+			image := createNoisePNG(t, 400, 400)
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					slowUsecase.UploadMedia(mediaTestUser.ID, "noise.png", bytes.NewReader(image))
+				}()
+			}
+			wg.Wait()
+
+			list, err := mediaUsecase.GetAllMedia(mediaTestUser.ID)
+			if err != nil {
+				t.Fatalf("GetAllMedia() error = %v", err)
+			}
+			if list.UsedBytes > testUserQuota {
+				t.Errorf("使用容量 = %d, want <= %d", list.UsedBytes, testUserQuota)
+			}
+		})
+	})
+}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/utils/imaging"
+	"go-react-app/utils/storage"
+	"go-react-app/validator"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrInvalidMedia はアップロードされたファイルが受け付けられない場合のエラー
+	ErrInvalidMedia = errors.New("invalid media")
+	// ErrMediaTooLarge はファイルが1ファイルあたりの最大サイズを超える場合のエラー
+	ErrMediaTooLarge = errors.New("media file too large")
+	// ErrMediaQuotaExceeded はユーザーの容量上限を超える場合のエラー
+	ErrMediaQuotaExceeded = errors.New("media quota exceeded")
+	// ErrMediaInUse は記事などから参照されているメディアを削除しようとした場合のエラー
+	ErrMediaInUse = errors.New("media is still referenced")
+)
+
+// MediaConfig はメディアのアップロードに関する設定
+type MediaConfig struct {
+	MaxUploadSize int64 // 1ファイルあたりの最大サイズ（バイト）
+	UserQuota     int64 // ユーザーあたりの合計容量の上限（バイト）
+}
+
+type IMediaUsecase interface {
+	GetAllMedia(userId uint) (model.MediaListResponse, error)
+	GetMediaById(userId uint, mediaId uint) (model.MediaResponse, error)
+	UploadMedia(userId uint, fileName string, body io.Reader) (model.MediaResponse, error)
+	GetReferences(userId uint, mediaId uint) ([]model.MediaReference, error)
+	DeleteMedia(userId uint, mediaId uint) ([]model.MediaReference, error)
+	OpenFile(key string) (io.ReadCloser, error)
+}
+
+type mediaUsecase struct {
+	mr  repository.IMediaRepository
+	mv  validator.IMediaValidator
+	st  storage.IStorage
+	cfg MediaConfig
+	// 同じユーザーのアップロードで容量の確認と登録が入れ違わないよう、ユーザーごとに直列化する
+	uploadLocks sync.Map // map[uint]*sync.Mutex
+}
+
+func NewMediaUsecase(mr repository.IMediaRepository, mv validator.IMediaValidator, st storage.IStorage, cfg MediaConfig) IMediaUsecase {
+	return &mediaUsecase{mr: mr, mv: mv, st: st, cfg: cfg}
+}
+
+func (mu *mediaUsecase) GetAllMedia(userId uint) (model.MediaListResponse, error) {
+	media, err := mu.mr.GetAllMedia(userId)
+	if err != nil {
+		return model.MediaListResponse{}, err
+	}
+	used, err := mu.mr.GetTotalSize(userId)
+	if err != nil {
+		return model.MediaListResponse{}, err
+	}
+
+	items := make([]model.MediaResponse, len(media))
+	for i, m := range media {
+		items[i] = m.ToResponse()
+	}
+	return model.MediaListResponse{Items: items, UsedBytes: used, Quota: mu.cfg.UserQuota}, nil
+}
+
+func (mu *mediaUsecase) GetMediaById(userId uint, mediaId uint) (model.MediaResponse, error) {
+	media, err := mu.mr.GetMediaById(userId, mediaId)
+	if err != nil {
+		return model.MediaResponse{}, err
+	}
+	return media.ToResponse(), nil
+}
+
+func (mu *mediaUsecase) UploadMedia(userId uint, fileName string, body io.Reader) (model.MediaResponse, error) {
+	// 上限を1バイト超えて読み込み、サイズ超過を検出する
+	data, err := io.ReadAll(io.LimitReader(body, mu.cfg.MaxUploadSize+1))
+	if err != nil {
+		return model.MediaResponse{}, err
+	}
+	if int64(len(data)) > mu.cfg.MaxUploadSize {
+		return model.MediaResponse{}, fmt.Errorf("%w: ファイルサイズは%dバイト以下にしてください", ErrMediaTooLarge, mu.cfg.MaxUploadSize)
+	}
+
+	request := model.MediaUploadRequest{
+		FileName: filepath.Base(fileName),
+		MimeType: imaging.DetectContentType(data),
+		Size:     int64(len(data)),
+		UserId:   userId,
+	}
+	if err := mu.mv.ValidateUploadRequest(request); err != nil {
+		return model.MediaResponse{}, fmt.Errorf("%w: %v", ErrInvalidMedia, err)
+	}
+
+	thumb, thumbType, width, height, err := imaging.Thumbnail(data, request.MimeType)
+	if err != nil {
+		return model.MediaResponse{}, fmt.Errorf("%w: %v", ErrInvalidMedia, err)
+	}
+
+	// 容量の確認から登録までを同じユーザーの他のアップロードと並行させない
+	lock := mu.uploadLock(userId)
+	lock.Lock()
+	defer lock.Unlock()
+
+	used, err := mu.mr.GetTotalSize(userId)
+	if err != nil {
+		return model.MediaResponse{}, err
+	}
+	if used+request.Size+int64(len(thumb)) > mu.cfg.UserQuota {
+		return model.MediaResponse{}, ErrMediaQuotaExceeded
+	}
+
+	name, err := randomName()
+	if err != nil {
+		return model.MediaResponse{}, err
+	}
+	key := fmt.Sprintf("%d/%s%s", userId, name, imaging.SupportedTypes[request.MimeType])
+	thumbKey := fmt.Sprintf("%d/%s_thumb%s", userId, name, imaging.SupportedTypes[thumbType])
+
+	if err := mu.st.Put(key, bytes.NewReader(data), request.MimeType); err != nil {
+		return model.MediaResponse{}, err
+	}
+	if err := mu.st.Put(thumbKey, bytes.NewReader(thumb), thumbType); err != nil {
+		mu.removeFiles(key)
+		return model.MediaResponse{}, err
+	}
+
+	media := model.Media{
+		FileName:     request.FileName,
+		MimeType:     request.MimeType,
+		Size:         request.Size + int64(len(thumb)),
+		Width:        width,
+		Height:       height,
+		StorageKey:   key,
+		ThumbnailKey: thumbKey,
+		URL:          mu.st.URL(key),
+		ThumbnailURL: mu.st.URL(thumbKey),
+		UserId:       userId,
+	}
+	if err := mu.mr.CreateMedia(&media); err != nil {
+		mu.removeFiles(key, thumbKey)
+		return model.MediaResponse{}, err
+	}
+	return media.ToResponse(), nil
+}
+
+// uploadLock はユーザーのアップロードを直列化するロックを返します
+func (mu *mediaUsecase) uploadLock(userId uint) *sync.Mutex {
+	lock, _ := mu.uploadLocks.LoadOrStore(userId, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+func (mu *mediaUsecase) GetReferences(userId uint, mediaId uint) ([]model.MediaReference, error) {
+	media, err := mu.mr.GetMediaById(userId, mediaId)
+	if err != nil {
+		return nil, err
+	}
+	return mu.mr.FindReferences(userId, referenceKey(media))
+}
+
+// DeleteMedia はメディアを削除します
+// 記事・レイアウトコンポーネント・書籍・レイアウトのスナップショット・テンプレートから参照されている場合は削除せず、参照元の一覧とErrMediaInUseを返します
+func (mu *mediaUsecase) DeleteMedia(userId uint, mediaId uint) ([]model.MediaReference, error) {
+	media, err := mu.mr.GetMediaById(userId, mediaId)
+	if err != nil {
+		return nil, err
+	}
+	references, err := mu.mr.FindReferences(userId, referenceKey(media))
+	if err != nil {
+		return nil, err
+	}
+	if len(references) > 0 {
+		return references, ErrMediaInUse
+	}
+
+	if err := mu.mr.DeleteMedia(userId, mediaId); err != nil {
+		return nil, err
+	}
+	mu.removeFiles(media.StorageKey, media.ThumbnailKey)
+	return nil, nil
+}
+
+func (mu *mediaUsecase) OpenFile(key string) (io.ReadCloser, error) {
+	return mu.st.Get(key)
+}
+
+// removeFiles はストレージからファイルを削除します
+// 不要になったファイルの後始末のため、削除に失敗してもエラーはログに記録するだけにします
+func (mu *mediaUsecase) removeFiles(keys ...string) {
+	for _, key := range keys {
+		if strings.TrimSpace(key) == "" {
+			continue
+		}
+		if err := mu.st.Delete(key); err != nil {
+			log.Printf("メディアファイルの削除に失敗しました (%s): %v", key, err)
+		}
+	}
+}
+
+// referenceKey は参照の検索に使う文字列を返します
+// 拡張子を除いたキーは本体とサムネイルに共通し、公開URLのホスト名にも依存しません
+func referenceKey(media model.Media) string {
+	return strings.TrimSuffix(media.StorageKey, filepath.Ext(media.StorageKey))
+}
+
+func randomName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
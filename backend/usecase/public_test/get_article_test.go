@@ -0,0 +1,64 @@
+package public_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"strings"
+	"testing"
+)
+
+func TestPublicUsecase_GetArticle(t *testing.T) {
+	setupPublicUsecaseTest()
+
+	layout := model.Layout{Title: "Goの記事", GridColumns: 12, UserId: publicTestUser.ID}
+	publicDb.Create(&layout)
+	header := model.LayoutComponent{Name: "ヘッダー", Type: "header", Width: 12, Height: 2, UserId: publicTestUser.ID, LayoutId: &layout.ID}
+	publicDb.Create(&header)
+	publicDb.Create(&model.LayoutAssignment{UserId: publicTestUser.ID, Tag: "Go", LayoutId: layout.ID})
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("レンダリングした本文とタグのレイアウトを返す", func(t *testing.T) {
+			article := createPublicArticle(t, "Go入門", "**Go**の基本", "入門, Go", true)
+
+			res, err := publicUsecase.GetArticle("gopher", article.ID)
+			if err != nil {
+				t.Fatalf("GetArticle() error = %v", err)
+			}
+			if res.Article.Title != "Go入門" || !strings.Contains(res.Article.ContentHTML, "<strong>Go</strong>") {
+				t.Errorf("GetArticle() article = %+v", res.Article)
+			}
+			if res.Layout.Source != model.LayoutSourceTag || res.Layout.Tag != "Go" || res.Layout.Layout == nil || res.Layout.Layout.ID != layout.ID {
+				t.Fatalf("GetArticle() layout = %+v, want tag Go layout %d", res.Layout, layout.ID)
+			}
+			if len(res.Layout.Layout.Components) != 1 || res.Layout.Layout.Components[0].ID != header.ID {
+				t.Errorf("GetArticle() components = %+v", res.Layout.Layout.Components)
+			}
+		})
+
+		t.Run("レイアウトの指定がない記事はレイアウトなしで返す", func(t *testing.T) {
+			article := createPublicArticle(t, "Web開発", "本文", "Web", true)
+
+			res, err := publicUsecase.GetArticle("gopher", article.ID)
+			if err != nil {
+				t.Fatalf("GetArticle() error = %v", err)
+			}
+			if res.Layout.Source != "" || res.Layout.Layout != nil {
+				t.Errorf("GetArticle() layout = %+v, want empty", res.Layout)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("非公開の記事や存在しないユーザー名はErrPublicArticleNotFoundを返す", func(t *testing.T) {
+			draft := createPublicArticle(t, "下書き", "本文", "Go", false)
+
+			if _, err := publicUsecase.GetArticle("gopher", draft.ID); !errors.Is(err, usecase.ErrPublicArticleNotFound) {
+				t.Errorf("GetArticle() draft error = %v, want ErrPublicArticleNotFound", err)
+			}
+			if _, err := publicUsecase.GetArticle("unknown", draft.ID); !errors.Is(err, usecase.ErrPublicArticleNotFound) {
+				t.Errorf("GetArticle() unknown user error = %v, want ErrPublicArticleNotFound", err)
+			}
+		})
+	})
+}
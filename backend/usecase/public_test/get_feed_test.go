@@ -0,0 +1,87 @@
+package public_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"go-react-app/usecase"
+	"strings"
+	"testing"
+)
+
+func TestPublicUsecase_GetFeed(t *testing.T) {
+	setupPublicUsecaseTest()
+
+	createPublicArticle(t, "Go入門", "# はじめに\n\n**Go**の基本", "Go, 入門", true)
+	createPublicArticle(t, "Web開発", "本文", "Web,Go", true)
+	createPublicArticle(t, "下書き", "非公開の本文", "Go", false)
+	createPublicArticle(t, "Golang以外", "本文", "Golang", true)
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("公開済みの記事のみを含める", func(t *testing.T) {
+			feed, err := publicUsecase.GetFeed("gopher", "", "https://blog.example.com/")
+			if err != nil {
+				t.Fatalf("GetFeed() error = %v", err)
+			}
+			if len(feed.Items) != 3 {
+				t.Fatalf("GetFeed() got %d items, want 3", len(feed.Items))
+			}
+			for _, item := range feed.Items {
+				if item.Title == "下書き" {
+					t.Error("GetFeed() should not include unpublished articles")
+				}
+				if !strings.HasPrefix(item.Link, "https://blog.example.com/gopher/articles/") {
+					t.Errorf("GetFeed() item link = %s", item.Link)
+				}
+			}
+		})
+
+		t.Run("レンダリング済みの本文を含める", func(t *testing.T) {
+			feed, _ := publicUsecase.GetFeed("gopher", "入門", "https://blog.example.com")
+			if len(feed.Items) != 1 {
+				t.Fatalf("GetFeed() got %d items, want 1", len(feed.Items))
+			}
+			item := feed.Items[0]
+			if !strings.Contains(item.Content, "<strong>Go</strong>") || item.Summary != "はじめに Goの基本" {
+				t.Errorf("GetFeed() content = %s, summary = %s", item.Content, item.Summary)
+			}
+			if len(item.Tags) != 2 || item.Tags[0] != "Go" || item.Tags[1] != "入門" {
+				t.Errorf("GetFeed() tags = %v", item.Tags)
+			}
+		})
+
+		t.Run("タグで絞り込む（部分一致はしない）", func(t *testing.T) {
+			feed, _ := publicUsecase.GetFeed("gopher", "Go", "https://blog.example.com")
+			if len(feed.Items) != 2 {
+				t.Errorf("GetFeed() got %d items, want 2", len(feed.Items))
+			}
+		})
+
+		t.Run("各形式で出力できる", func(t *testing.T) {
+			feed, _ := publicUsecase.GetFeed("gopher", "", "https://blog.example.com")
+			feed.FeedURL = "https://blog.example.com/public/gopher/feed.atom"
+
+			atom, err := feed.Atom()
+			if err != nil || xml.Unmarshal(atom, new(interface{})) != nil || !strings.Contains(string(atom), `<feed xmlns="http://www.w3.org/2005/Atom">`) {
+				t.Errorf("Atom() = %s, %v", atom, err)
+			}
+			rss, err := feed.RSS()
+			if err != nil || !strings.Contains(string(rss), "<content:encoded>") {
+				t.Errorf("RSS() = %s, %v", rss, err)
+			}
+			jsonFeed, err := feed.JSON()
+			var parsed map[string]interface{}
+			if err != nil || json.Unmarshal(jsonFeed, &parsed) != nil || parsed["version"] != "https://jsonfeed.org/version/1.1" {
+				t.Errorf("JSON() = %s, %v", jsonFeed, err)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("存在しないユーザー名はErrPublicArticleNotFoundを返す", func(t *testing.T) {
+			if _, err := publicUsecase.GetFeed("unknown", "", "https://blog.example.com"); !errors.Is(err, usecase.ErrPublicArticleNotFound) {
+				t.Errorf("GetFeed() error = %v, want ErrPublicArticleNotFound", err)
+			}
+		})
+	})
+}
@@ -0,0 +1,53 @@
+package public_test
+
+import (
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/utils/markdown"
+	"go-react-app/validator"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// テスト用の共通変数
+var (
+	publicDb       *gorm.DB
+	publicUsecase  usecase.IPublicUsecase
+	publicTestUser model.User
+)
+
+// テスト前の共通セットアップ
+func setupPublicUsecaseTest() {
+	if publicDb != nil {
+		testutils.CleanupTestDB(publicDb)
+		publicDb.Exec("DELETE FROM articles")
+		publicDb.Exec("DELETE FROM layout_assignments")
+	} else {
+		publicDb = testutils.SetupTestDB()
+		publicUsecase = usecase.NewPublicUsecase(
+			repository.NewUserRepository(publicDb),
+			repository.NewArticleRepository(publicDb),
+			markdown.NewRenderer(markdown.DefaultCacheSize),
+			usecase.NewLayoutAssignmentUsecase(
+				repository.NewLayoutAssignmentRepository(publicDb),
+				repository.NewLayoutRepository(publicDb),
+				validator.NewLayoutAssignmentValidator(),
+			),
+		)
+	}
+
+	publicTestUser = testutils.CreateTestUser(publicDb)
+	publicDb.Model(&publicTestUser).Update("username", "gopher")
+}
+
+// テスト用の記事を作成するヘルパー関数
+func createPublicArticle(t *testing.T, title string, content string, tags string, published bool) model.Article {
+	article := model.Article{Title: title, Content: content, Tags: tags, Published: published, UserId: publicTestUser.ID}
+	if err := publicDb.Create(&article).Error; err != nil {
+		t.Fatalf("テスト記事の作成に失敗しました: %v", err)
+	}
+	return article
+}
@@ -0,0 +1,95 @@
+package public_test
+
+import (
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/testutils"
+	"strings"
+	"testing"
+)
+
+func TestPublicUsecase_Sitemap(t *testing.T) {
+	setupPublicUsecaseTest()
+
+	indexed := createPublicArticle(t, "公開記事", "本文", "", true)
+	createPublicArticle(t, "下書き", "本文", "", false)
+	noindex := model.Article{Title: "noindex", Published: true, NoIndex: true, UserId: publicTestUser.ID}
+	publicDb.Create(&noindex)
+	external := model.Article{Title: "転載", Published: true, CanonicalURL: "https://other.example.com/post", UserId: publicTestUser.ID}
+	publicDb.Create(&external)
+	canonical := model.Article{Title: "正規URL", Published: true, CanonicalURL: "https://blog.example.com/gopher/go", UserId: publicTestUser.ID}
+	publicDb.Create(&canonical)
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("インデックス対象の記事のみを含める", func(t *testing.T) {
+			urls, err := publicUsecase.GetSitemap("gopher", 1, "https://blog.example.com")
+			if err != nil {
+				t.Fatalf("GetSitemap() error = %v", err)
+			}
+
+			locs := []string{}
+			for _, u := range urls {
+				locs = append(locs, u.Loc)
+			}
+			expected := []string{
+				"https://blog.example.com/gopher/",
+				fmt.Sprintf("https://blog.example.com/gopher/articles/%d", indexed.ID),
+				"https://blog.example.com/gopher/go",
+			}
+			if strings.Join(locs, " ") != strings.Join(expected, " ") {
+				t.Errorf("GetSitemap() = %v, want %v", locs, expected)
+			}
+		})
+
+		t.Run("記事が多い場合はサイトマップを分割する", func(t *testing.T) {
+			articles := make([]model.Article, 1000)
+			for i := range articles {
+				articles[i] = model.Article{Title: fmt.Sprintf("記事%d", i), Published: true, UserId: publicTestUser.ID}
+			}
+			publicDb.CreateInBatches(&articles, 200)
+
+			sitemaps, err := publicUsecase.GetSitemapIndex("gopher", "https://blog.example.com", "https://api.example.com")
+			if err != nil {
+				t.Fatalf("GetSitemapIndex() error = %v", err)
+			}
+			if len(sitemaps) != 2 || sitemaps[1].Loc != "https://api.example.com/public/gopher/sitemaps/2.xml" {
+				t.Fatalf("GetSitemapIndex() = %+v", sitemaps)
+			}
+
+			second, err := publicUsecase.GetSitemap("gopher", 2, "https://blog.example.com")
+			if err != nil || len(second) != 3 {
+				t.Errorf("GetSitemap(2) got %d urls, err = %v, want 3", len(second), err)
+			}
+		})
+
+		t.Run("robots.txtに公開記事のあるユーザーのサイトマップの場所を含める", func(t *testing.T) {
+			// 公開記事のないユーザーのサイトマップは含めない
+			other := testutils.CreateOtherUser(publicDb)
+			publicDb.Model(&other).Update("username", "drafter")
+			publicDb.Create(&model.Article{Title: "下書き", Published: false, UserId: other.ID})
+
+			robots, err := publicUsecase.GetRobots("https://api.example.com")
+			if err != nil {
+				t.Fatalf("GetRobots() error = %v", err)
+			}
+			expected := "User-agent: *\nDisallow:\n\nSitemap: https://api.example.com/public/gopher/sitemap.xml\n"
+			if robots != expected {
+				t.Errorf("GetRobots() = %q, want %q", robots, expected)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("存在しないページはエラーを返す", func(t *testing.T) {
+			if _, err := publicUsecase.GetSitemap("gopher", 99, "https://blog.example.com"); err == nil {
+				t.Error("GetSitemap() should fail for out of range page")
+			}
+		})
+
+		t.Run("存在しないユーザー名はエラーを返す", func(t *testing.T) {
+			if _, err := publicUsecase.GetSitemapIndex("unknown", "https://blog.example.com", "https://api.example.com"); err == nil {
+				t.Error("GetSitemapIndex() should fail for unknown username")
+			}
+		})
+	})
+}
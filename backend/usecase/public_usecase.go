@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/utils/feedgen"
+	"go-react-app/utils/markdown"
+	"go-react-app/utils/sitemap"
+	"net/url"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// publicFeedLimit フィードに含める記事の最大件数
+	publicFeedLimit = 50
+	// sitemapPageSize 1つのサイトマップに含める記事の件数
+	sitemapPageSize = 1000
+)
+
+// ErrPublicArticleNotFound はユーザー・公開済みの記事・サイトマップのページが存在しない場合のエラー
+var ErrPublicArticleNotFound = errors.New("public article not found")
+
+type IPublicUsecase interface {
+	GetFeed(username string, tag string, baseURL string) (feedgen.Feed, error)
+	GetSitemapIndex(username string, baseURL string, apiURL string) ([]sitemap.Sitemap, error)
+	GetSitemap(username string, page int, baseURL string) ([]sitemap.URL, error)
+	GetRobots(apiURL string) (string, error)
+	GetArticle(username string, articleId uint) (model.PublicArticleResponse, error)
+}
+
+type publicUsecase struct {
+	ur  repository.IUserRepository
+	ar  repository.IArticleRepository
+	mr  markdown.IRenderer
+	lau ILayoutAssignmentUsecase
+}
+
+func NewPublicUsecase(ur repository.IUserRepository, ar repository.IArticleRepository, mr markdown.IRenderer, lau ILayoutAssignmentUsecase) IPublicUsecase {
+	return &publicUsecase{ur, ar, mr, lau}
+}
+
+// GetFeed はユーザーの公開済み記事からフィードを作成します
+// baseURLは記事のページ（/{username}/articles/{id}）を配信する公開サイトのURLです
+func (pu *publicUsecase) GetFeed(username string, tag string, baseURL string) (feedgen.Feed, error) {
+	user, err := pu.ur.GetUserByUsername(username)
+	if err != nil {
+		return feedgen.Feed{}, publicNotFound(err)
+	}
+
+	articles := []model.Article{}
+	if err := pu.ar.GetPublishedArticles(&articles, user.ID, tag, publicFeedLimit); err != nil {
+		return feedgen.Feed{}, err
+	}
+
+	siteURL := userSiteURL(baseURL, user.Username)
+	feed := feedgen.Feed{
+		Title:   user.Username,
+		Link:    siteURL,
+		Author:  user.Username,
+		Updated: user.UpdatedAt,
+		Items:   make([]feedgen.Item, 0, len(articles)),
+	}
+	if tag != "" {
+		feed.Title = fmt.Sprintf("%s - %s", user.Username, tag)
+	}
+
+	for _, article := range articles {
+		html, err := pu.mr.Render(article.Content)
+		if err != nil {
+			return feedgen.Feed{}, err
+		}
+		link := articleURL(siteURL, article.ID)
+		feed.Items = append(feed.Items, feedgen.Item{
+			ID:        link,
+			Title:     article.Title,
+			Link:      link,
+			Summary:   markdown.Excerpt(html, markdown.DefaultExcerptLength),
+			Content:   html,
+			Tags:      splitTags(article.Tags),
+			Published: article.CreatedAt,
+			Updated:   article.UpdatedAt,
+		})
+	}
+	return feed, nil
+}
+
+// GetSitemapIndex はユーザーのサイトマップインデックスに含めるサイトマップの一覧を返します
+// 記事はsitemapPageSize件ごとに1つのサイトマップに分割されます
+// 記事のURLはbaseURL（公開サイト）、サイトマップのURLはapiURL（このAPI）から生成します
+func (pu *publicUsecase) GetSitemapIndex(username string, baseURL string, apiURL string) ([]sitemap.Sitemap, error) {
+	user, pages, err := pu.sitemapPages(username, baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	sitemaps := make([]sitemap.Sitemap, len(pages))
+	for i, urls := range pages {
+		sitemaps[i] = sitemap.Sitemap{
+			Loc:     fmt.Sprintf("%s/public/%s/sitemaps/%d.xml", strings.TrimRight(apiURL, "/"), user.Username, i+1),
+			LastMod: sitemap.LastModified(urls),
+		}
+	}
+	return sitemaps, nil
+}
+
+// GetSitemap は指定されたページ（1始まり）のサイトマップに含めるURLを返します
+func (pu *publicUsecase) GetSitemap(username string, page int, baseURL string) ([]sitemap.URL, error) {
+	_, pages, err := pu.sitemapPages(username, baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if page < 1 || page > len(pages) {
+		return nil, fmt.Errorf("%w: sitemap page %d does not exist", ErrPublicArticleNotFound, page)
+	}
+	return pages[page-1], nil
+}
+
+// GetRobots はサイトのルートに配置するrobots.txtを返します
+// インデックス対象の公開記事があるユーザーごとに、apiURL（このAPI）のサイトマップインデックスの場所を含める
+func (pu *publicUsecase) GetRobots(apiURL string) (string, error) {
+	usernames, err := pu.ur.GetPublishingUsernames()
+	if err != nil {
+		return "", err
+	}
+
+	base := strings.TrimRight(apiURL, "/")
+	var b strings.Builder
+	b.WriteString("User-agent: *\nDisallow:\n")
+	if len(usernames) > 0 {
+		b.WriteString("\n")
+	}
+	for _, username := range usernames {
+		fmt.Fprintf(&b, "Sitemap: %s/public/%s/sitemap.xml\n", base, url.PathEscape(username))
+	}
+	return b.String(), nil
+}
+
+// GetArticle は公開済みの記事を、レンダリングした本文と記事のページに使うレイアウトとともに返します
+func (pu *publicUsecase) GetArticle(username string, articleId uint) (model.PublicArticleResponse, error) {
+	user, err := pu.ur.GetUserByUsername(username)
+	if err != nil {
+		return model.PublicArticleResponse{}, publicNotFound(err)
+	}
+
+	article := model.Article{}
+	if err := pu.ar.GetPublishedArticleById(&article, user.ID, articleId); err != nil {
+		return model.PublicArticleResponse{}, publicNotFound(err)
+	}
+
+	res := article.ToResponse()
+	if res.ContentHTML, err = pu.mr.Render(article.Content); err != nil {
+		return model.PublicArticleResponse{}, err
+	}
+	layout, err := pu.lau.ResolveArticleLayout(user.ID, article)
+	if err != nil {
+		return model.PublicArticleResponse{}, err
+	}
+	return model.PublicArticleResponse{Article: res, Layout: layout}, nil
+}
+
+// publicNotFound はレコードが存在しないエラーをErrPublicArticleNotFoundでラップします
+func publicNotFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: %v", ErrPublicArticleNotFound, err)
+	}
+	return err
+}
+
+// sitemapPages はサイトマップに含めるURLをページごとに分割して返します
+// トップページは常に先頭のページに含めるため、記事がない場合も1ページになります
+func (pu *publicUsecase) sitemapPages(username string, baseURL string) (*model.User, [][]sitemap.URL, error) {
+	user, err := pu.ur.GetUserByUsername(username)
+	if err != nil {
+		return nil, nil, publicNotFound(err)
+	}
+
+	articles := []model.Article{}
+	if err := pu.ar.GetIndexableArticles(&articles, user.ID); err != nil {
+		return nil, nil, err
+	}
+
+	siteURL := userSiteURL(baseURL, user.Username)
+	urls := make([]sitemap.URL, 0, len(articles)+1)
+	urls = append(urls, sitemap.URL{Loc: siteURL + "/"})
+	for _, article := range articles {
+		loc := articleURL(siteURL, article.ID)
+		if article.CanonicalURL != "" {
+			// 他のサイトを正規URLとする記事は、このサイトのサイトマップには含めない
+			if !strings.HasPrefix(article.CanonicalURL, strings.TrimRight(baseURL, "/")+"/") {
+				continue
+			}
+			loc = article.CanonicalURL
+		}
+		urls = append(urls, sitemap.URL{Loc: loc, LastMod: article.UpdatedAt})
+	}
+	urls[0].LastMod = sitemap.LastModified(urls)
+
+	pages := [][]sitemap.URL{}
+	for start := 0; start < len(urls); start += sitemapPageSize {
+		end := min(start+sitemapPageSize, len(urls))
+		pages = append(pages, urls[start:end])
+	}
+	return user, pages, nil
+}
+
+// userSiteURL は公開サイトのユーザーのブログのトップページのURLを返します
+func userSiteURL(baseURL string, username string) string {
+	return strings.TrimRight(baseURL, "/") + "/" + username
+}
+
+// articleURL は公開サイトの記事のページのURLを返します
+func articleURL(siteURL string, articleId uint) string {
+	return fmt.Sprintf("%s/articles/%d", siteURL, articleId)
+}
+
+// splitTags はカンマ区切りのタグを分割します
+func splitTags(tags string) []string {
+	result := []string{}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
@@ -6,8 +6,8 @@ import (
 )
 
 type IQiitaUsecase interface {
-	GetQiitaArticles() ([]model.QiitaArticleResponse, error)
-	GetQiitaArticleByID(id string) (model.QiitaArticleResponse, error)
+	GetQiitaArticles(userId uint) ([]model.QiitaArticleResponse, error)
+	GetQiitaArticleByID(userId uint, id string) (model.QiitaArticleResponse, error)
 }
 
 type qiitaUsecase struct {
@@ -18,8 +18,8 @@ func NewQiitaUsecase(qr repository.IQiitaRepository) IQiitaUsecase {
 	return &qiitaUsecase{qr}
 }
 
-func (qu *qiitaUsecase) GetQiitaArticles() ([]model.QiitaArticleResponse, error) {
-	articles, err := qu.qr.GetQiitaArticles()
+func (qu *qiitaUsecase) GetQiitaArticles(userId uint) ([]model.QiitaArticleResponse, error) {
+	articles, err := qu.qr.GetQiitaArticles(userId)
 	if err != nil {
 		return nil, err
 	}
@@ -40,8 +40,8 @@ func (qu *qiitaUsecase) GetQiitaArticles() ([]model.QiitaArticleResponse, error)
 	return response, nil
 }
 
-func (qu *qiitaUsecase) GetQiitaArticleByID(id string) (model.QiitaArticleResponse, error) {
-	article, err := qu.qr.GetQiitaArticleByID(id)
+func (qu *qiitaUsecase) GetQiitaArticleByID(userId uint, id string) (model.QiitaArticleResponse, error) {
+	article, err := qu.qr.GetQiitaArticleByID(userId, id)
 	if err != nil {
 		return model.QiitaArticleResponse{}, err
 	}
@@ -0,0 +1,114 @@
+package response_mapping_test
+
+import (
+	"errors"
+	"go-react-app/usecase"
+	"testing"
+)
+
+func TestResponseMappingUsecase_CreateResponseMapping(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("定義を保存し、取得・更新・削除できる", func(t *testing.T) {
+			setupResponseMappingUsecaseTest(t)
+
+			created, err := mappingUsecase.CreateResponseMapping(newMappingRequest())
+			if err != nil {
+				t.Fatalf("CreateResponseMapping() error = %v", err)
+			}
+			if created.ID == 0 || created.Fields.Title != "{{ full_name }}" || created.Params["resource"] != "search" {
+				t.Errorf("CreateResponseMapping() = %+v", created)
+			}
+
+			request := newMappingRequest()
+			request.Name = "更新後"
+			request.Params = map[string]string{"resource": "repos"}
+			request.Fields.Tags = ""
+			if _, err := mappingUsecase.UpdateResponseMapping(request, testUser.ID, created.ID); err != nil {
+				t.Fatalf("UpdateResponseMapping() error = %v", err)
+			}
+			got, err := mappingUsecase.GetResponseMappingById(testUser.ID, created.ID)
+			if err != nil {
+				t.Fatalf("GetResponseMappingById() error = %v", err)
+			}
+			if got.Name != "更新後" || got.Params["resource"] != "repos" || got.Fields.Tags != "" || got.Fields.Title != "{{ full_name }}" {
+				t.Errorf("GetResponseMappingById() = %+v, want updated mapping", got)
+			}
+
+			if err := mappingUsecase.DeleteResponseMapping(testUser.ID, created.ID); err != nil {
+				t.Fatalf("DeleteResponseMapping() error = %v", err)
+			}
+			if _, err := mappingUsecase.GetResponseMappingById(testUser.ID, created.ID); !errors.Is(err, usecase.ErrResponseMappingNotFound) {
+				t.Errorf("GetResponseMappingById() error = %v, want ErrResponseMappingNotFound", err)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		setupResponseMappingUsecaseTest(t)
+
+		cases := map[string]func() error{
+			"名前が空": func() error {
+				request := newMappingRequest()
+				request.Name = ""
+				_, err := mappingUsecase.CreateResponseMapping(request)
+				return err
+			},
+			"パス式の構文が不正": func() error {
+				request := newMappingRequest()
+				request.ItemsPath = "$.items["
+				_, err := mappingUsecase.CreateResponseMapping(request)
+				return err
+			},
+			"テンプレートが閉じていない": func() error {
+				request := newMappingRequest()
+				request.Fields.URL = "https://example.com/{{ id"
+				_, err := mappingUsecase.CreateResponseMapping(request)
+				return err
+			},
+			"タイトルのテンプレートがない": func() error {
+				request := newMappingRequest()
+				request.Fields.Title = ""
+				_, err := mappingUsecase.CreateResponseMapping(request)
+				return err
+			},
+			"タグのテンプレートに固定の文字列を含む": func() error {
+				request := newMappingRequest()
+				request.Fields.Tags = "tag-{{ topics }}"
+				_, err := mappingUsecase.CreateResponseMapping(request)
+				return err
+			},
+			"paramsの名前に記号を含む": func() error {
+				request := newMappingRequest()
+				request.Params = map[string]string{"a-b": "x"}
+				_, err := mappingUsecase.CreateResponseMapping(request)
+				return err
+			},
+			"他のユーザーの外部API": func() error {
+				request := newMappingRequest()
+				request.UserId = otherUser.ID
+				_, err := mappingUsecase.CreateResponseMapping(request)
+				return err
+			},
+		}
+		for name, call := range cases {
+			t.Run(name, func(t *testing.T) {
+				if err := call(); !errors.Is(err, usecase.ErrInvalidResponseMapping) {
+					t.Errorf("CreateResponseMapping() error = %v, want ErrInvalidResponseMapping", err)
+				}
+			})
+		}
+
+		t.Run("他のユーザーの定義は更新・削除できない", func(t *testing.T) {
+			created, err := mappingUsecase.CreateResponseMapping(newMappingRequest())
+			if err != nil {
+				t.Fatalf("CreateResponseMapping() error = %v", err)
+			}
+			if err := mappingUsecase.DeleteResponseMapping(otherUser.ID, created.ID); !errors.Is(err, usecase.ErrResponseMappingNotFound) {
+				t.Errorf("DeleteResponseMapping() error = %v, want ErrResponseMappingNotFound", err)
+			}
+			if _, err := mappingUsecase.GetResponseMappingById(otherUser.ID, created.ID); !errors.Is(err, usecase.ErrResponseMappingNotFound) {
+				t.Errorf("GetResponseMappingById() error = %v, want ErrResponseMappingNotFound", err)
+			}
+		})
+	})
+}
@@ -0,0 +1,173 @@
+package response_mapping_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"strings"
+	"testing"
+	"time"
+)
+
+// findError は指定した項目・フィールドの変換できなかった箇所を探します
+func findError(errs []model.MappingError, item int, field string) *model.MappingError {
+	for i, e := range errs {
+		if e.Item != nil && *e.Item == item && e.Field == field {
+			return &errs[i]
+		}
+	}
+	return nil
+}
+
+func TestResponseMappingUsecase_PreviewResponseMapping(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("項目をカードに変換し、型の合わない箇所をエラーとして返す", func(t *testing.T) {
+			setupResponseMappingUsecaseTest(t)
+
+			preview, err := mappingUsecase.PreviewResponseMapping(newMappingRequest())
+			if err != nil {
+				t.Fatalf("PreviewResponseMapping() error = %v", err)
+			}
+			if preview.StatusCode != 200 || preview.Total != 3 {
+				t.Errorf("PreviewResponseMapping() status = %d, total = %d, want 200, 3", preview.StatusCode, preview.Total)
+			}
+			// タイトルがオブジェクトの項目はカードに含めない
+			if len(preview.Cards) != 2 {
+				t.Fatalf("PreviewResponseMapping() cards = %+v, want 2 cards", preview.Cards)
+			}
+
+			first := preview.Cards[0]
+			if first.ID != "repo-1" || first.Title != "golang/go" || first.URL != "https://github.com/golang/go" ||
+				first.Excerpt != "The Go programming language" || first.ImageURL != "https://example.com/go.png" {
+				t.Errorf("first card = %+v", first)
+			}
+			if first.Date == nil || !first.Date.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+				t.Errorf("first card date = %v, want 2024-05-01T10:00:00Z", first.Date)
+			}
+			if len(first.Tags) != 2 || first.Tags[0] != "go" || first.Tags[1] != "language" {
+				t.Errorf("first card tags = %v, want [go language]", first.Tags)
+			}
+
+			// UNIX時間の日付と、配列でないタグ
+			last := preview.Cards[1]
+			if last.ID != "repo-3" || last.Date == nil || last.Date.Unix() != 1714557600 || len(last.Tags) != 1 || last.Tags[0] != "web" {
+				t.Errorf("last card = %+v", last)
+			}
+
+			for _, field := range []string{"title", "url", "date"} {
+				e := findError(preview.Errors, 1, field)
+				if e == nil {
+					t.Errorf("PreviewResponseMapping() errors = %+v, want an error for item 1 %s", preview.Errors, field)
+				}
+			}
+			if e := findError(preview.Errors, 1, "title"); e != nil && !strings.Contains(e.Message, "object") {
+				t.Errorf("title error = %q, want it to mention the object type", e.Message)
+			}
+		})
+
+		t.Run("一致する項目がない場合はitems_pathのエラーを返す", func(t *testing.T) {
+			setupResponseMappingUsecaseTest(t)
+			request := newMappingRequest()
+			request.ItemsPath = "$.results"
+
+			preview, err := mappingUsecase.PreviewResponseMapping(request)
+			if err != nil {
+				t.Fatalf("PreviewResponseMapping() error = %v", err)
+			}
+			if len(preview.Cards) != 0 || len(preview.Errors) != 1 || preview.Errors[0].Field != "items_path" {
+				t.Errorf("PreviewResponseMapping() = %+v, want a single items_path error", preview)
+			}
+		})
+
+		t.Run("パス式が複数の値に一致する場合はエラーを返す", func(t *testing.T) {
+			setupResponseMappingUsecaseTest(t)
+			request := newMappingRequest()
+			request.Fields.Excerpt = "{{ $.items[*].id }}"
+
+			preview, err := mappingUsecase.PreviewResponseMapping(request)
+			if err != nil {
+				t.Fatalf("PreviewResponseMapping() error = %v", err)
+			}
+			if e := findError(preview.Errors, 0, "excerpt"); e == nil || !strings.Contains(e.Message, "3 values") {
+				t.Errorf("PreviewResponseMapping() errors = %+v, want a multiple values error", preview.Errors)
+			}
+			if len(preview.Cards) != 2 || preview.Cards[0].Excerpt != "" {
+				t.Errorf("PreviewResponseMapping() cards = %+v, want cards without excerpt", preview.Cards)
+			}
+		})
+
+		t.Run("JSONでない応答とエラーのステータスコードはエラーとして返す", func(t *testing.T) {
+			setupResponseMappingUsecaseTest(t)
+			for resource, status := range map[string]int{"text": 200, "missing": 404} {
+				request := newMappingRequest()
+				request.Params = map[string]string{"resource": resource}
+
+				preview, err := mappingUsecase.PreviewResponseMapping(request)
+				if err != nil {
+					t.Fatalf("PreviewResponseMapping(%s) error = %v", resource, err)
+				}
+				if preview.StatusCode != status || len(preview.Cards) != 0 || len(preview.Errors) != 1 || preview.Errors[0].Item != nil {
+					t.Errorf("PreviewResponseMapping(%s) = %+v, want a response error", resource, preview)
+				}
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("名前がなくてもプレビューできるが、定義が不正な場合はエラー", func(t *testing.T) {
+			setupResponseMappingUsecaseTest(t)
+			request := newMappingRequest()
+			request.Name = ""
+			request.Fields.Date = "{{ updated_at"
+
+			if _, err := mappingUsecase.PreviewResponseMapping(request); !errors.Is(err, usecase.ErrInvalidResponseMapping) {
+				t.Errorf("PreviewResponseMapping() error = %v, want ErrInvalidResponseMapping", err)
+			}
+		})
+
+		t.Run("パスのパラメータが足りない場合は外部APIの呼び出しのエラー", func(t *testing.T) {
+			setupResponseMappingUsecaseTest(t)
+			request := newMappingRequest()
+			request.Params = nil
+
+			if _, err := mappingUsecase.PreviewResponseMapping(request); !errors.Is(err, usecase.ErrInvalidExternalAPICall) {
+				t.Errorf("PreviewResponseMapping() error = %v, want ErrInvalidExternalAPICall", err)
+			}
+		})
+	})
+}
+
+func TestResponseMappingUsecase_ResolveCards(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		setupResponseMappingUsecaseTest(t)
+		created, err := mappingUsecase.CreateResponseMapping(newMappingRequest())
+		if err != nil {
+			t.Fatalf("CreateResponseMapping() error = %v", err)
+		}
+
+		cards, err := mappingUsecase.ResolveCards(testUser.ID, created.ID, 1)
+		if err != nil {
+			t.Fatalf("ResolveCards() error = %v", err)
+		}
+		if len(cards) != 1 || cards[0].Title != "golang/go" {
+			t.Errorf("ResolveCards() = %+v, want [golang/go]", cards)
+		}
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		setupResponseMappingUsecaseTest(t)
+		request := newMappingRequest()
+		request.Params = map[string]string{"resource": "missing"}
+		created, err := mappingUsecase.CreateResponseMapping(request)
+		if err != nil {
+			t.Fatalf("CreateResponseMapping() error = %v", err)
+		}
+
+		if _, err := mappingUsecase.ResolveCards(testUser.ID, created.ID, 5); !errors.Is(err, usecase.ErrExternalAPIResponse) {
+			t.Errorf("ResolveCards() error = %v, want ErrExternalAPIResponse", err)
+		}
+		if _, err := mappingUsecase.ResolveCards(otherUser.ID, created.ID, 5); !errors.Is(err, usecase.ErrResponseMappingNotFound) {
+			t.Errorf("ResolveCards() error = %v, want ErrResponseMappingNotFound", err)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package response_mapping_test
+
+import (
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/utils/netguard"
+	"go-react-app/validator"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// テスト用の共通変数
+var (
+	mappingDb      *gorm.DB
+	mappingUsecase usecase.IResponseMappingUsecase
+	testUser       model.User
+	otherUser      model.User
+	testAPI        model.ExternalAPIResponse
+)
+
+// テスト用の外部API（GitHubの検索APIに似た応答を返す）
+func newItemsServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/text":
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("plain text"))
+			return
+		case "/missing":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"total_count": 3,
+			"items": [
+				{"id": 1, "full_name": "golang/go", "html_url": "https://github.com/golang/go", "description": "The Go programming language",
+				 "owner": {"avatar_url": "https://example.com/go.png"}, "updated_at": "2024-05-01T10:00:00Z", "topics": ["go", "language"]},
+				{"id": 2, "full_name": {"name": "broken"}, "html_url": "javascript:alert(1)", "updated_at": "昨日", "topics": []},
+				{"id": 3, "full_name": "labstack/echo", "html_url": "https://github.com/labstack/echo", "updated_at": 1714557600, "topics": "web"}
+			]
+		}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// テスト前の共通セットアップ
+// テスト用のサーバー（127.0.0.1）に接続するため、内部ネットワークへの接続を許可する
+func setupResponseMappingUsecaseTest(t *testing.T) {
+	if mappingDb != nil {
+		testutils.CleanupTestDB(mappingDb)
+		mappingDb.Exec("DELETE FROM response_mappings")
+		mappingDb.Exec("DELETE FROM external_apis")
+		mappingDb.Exec("DELETE FROM secrets")
+	} else {
+		mappingDb = testutils.SetupTestDB()
+		externalAPIRepo := repository.NewExternalAPIRepository(mappingDb)
+		externalAPIUsecase := usecase.NewExternalAPIUsecase(externalAPIRepo, validator.NewExternalAPIValidator(),
+			repository.NewExternalAPICallRepository(netguard.Guard{AllowPrivate: true}),
+			repository.NewSecretRepository(mappingDb, testutils.NewTestKeyring()))
+		mappingUsecase = usecase.NewResponseMappingUsecase(repository.NewResponseMappingRepository(mappingDb), externalAPIRepo,
+			externalAPIUsecase, validator.NewResponseMappingValidator())
+	}
+
+	testUser = testutils.CreateTestUser(mappingDb)
+	otherUser = testutils.CreateOtherUser(mappingDb)
+
+	server := newItemsServer(t)
+	api := model.ExternalAPI{
+		Name:         "テストAPI",
+		BaseURL:      server.URL,
+		Method:       "GET",
+		PathTemplate: "/{resource}",
+		UserId:       testUser.ID,
+	}
+	if err := repository.NewExternalAPIRepository(mappingDb).CreateExternalAPI(&api); err != nil {
+		t.Fatalf("テスト用の外部APIの登録に失敗しました: %v", err)
+	}
+	testAPI = api.ToResponse(false)
+}
+
+// テスト用の変換の定義のリクエストを作成するヘルパー関数
+func newMappingRequest() model.ResponseMappingRequest {
+	return model.ResponseMappingRequest{
+		Name:          "リポジトリ",
+		ExternalAPIId: testAPI.ID,
+		Params:        map[string]string{"resource": "search"},
+		ItemsPath:     "$.items[*]",
+		Fields: model.CardTemplate{
+			ID:       "repo-{{ id }}",
+			Title:    "{{ full_name }}",
+			URL:      "{{ html_url }}",
+			Excerpt:  "{{ description }}",
+			ImageURL: "{{ owner.avatar_url }}",
+			Date:     "{{ updated_at }}",
+			Tags:     "{{ topics }}",
+		},
+		UserId: testUser.ID,
+	}
+}
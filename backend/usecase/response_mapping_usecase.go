@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/utils/jsonmap"
+	"go-react-app/validator"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// maxMappingErrors プレビューで返す変換できなかった箇所の最大件数
+const maxMappingErrors = 50
+
+var (
+	// ErrInvalidResponseMapping は変換の定義が不正な場合（他のユーザーの外部APIを含む）のエラー
+	ErrInvalidResponseMapping = errors.New("invalid response mapping")
+	// ErrResponseMappingNotFound は変換の定義が見つからない場合のエラー
+	ErrResponseMappingNotFound = errors.New("response mapping not found")
+	// ErrExternalAPIResponse は外部APIの応答がエラーのステータスコード、またはJSONでない場合のエラー
+	ErrExternalAPIResponse = errors.New("external api returned an unusable response")
+)
+
+// dateLayouts カードの日付として受け付ける文字列の形式
+var dateLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+// IResponseMappingUsecase は外部APIの応答（JSON）をカードの一覧に変換する定義を管理します
+type IResponseMappingUsecase interface {
+	GetAllResponseMappings(userId uint) ([]model.ResponseMappingResponse, error)
+	GetResponseMappingById(userId uint, mappingId uint) (model.ResponseMappingResponse, error)
+	CreateResponseMapping(request model.ResponseMappingRequest) (model.ResponseMappingResponse, error)
+	UpdateResponseMapping(request model.ResponseMappingRequest, userId uint, mappingId uint) (model.ResponseMappingResponse, error)
+	DeleteResponseMapping(userId uint, mappingId uint) error
+	// PreviewResponseMapping は外部APIを呼び出し、保存前の定義で変換した結果と変換できなかった箇所を返します
+	PreviewResponseMapping(request model.ResponseMappingRequest) (model.ResponseMappingPreviewResponse, error)
+	// ResolveCards は保存した定義で外部APIの応答を変換し、先頭からlimit件のカードを返します
+	// 変換できなかった項目は表示に使えるカードのみ返す
+	ResolveCards(userId uint, mappingId uint, limit int) ([]model.ComponentDataItem, error)
+}
+
+type responseMappingUsecase struct {
+	rmr repository.IResponseMappingRepository
+	ar  repository.IExternalAPIRepository
+	au  IExternalAPIUsecase
+	rmv validator.IResponseMappingValidator
+}
+
+func NewResponseMappingUsecase(rmr repository.IResponseMappingRepository, ar repository.IExternalAPIRepository, au IExternalAPIUsecase, rmv validator.IResponseMappingValidator) IResponseMappingUsecase {
+	return &responseMappingUsecase{rmr, ar, au, rmv}
+}
+
+func (rmu *responseMappingUsecase) GetAllResponseMappings(userId uint) ([]model.ResponseMappingResponse, error) {
+	mappings := []model.ResponseMapping{}
+	if err := rmu.rmr.GetAllResponseMappings(&mappings, userId); err != nil {
+		return nil, err
+	}
+	resMappings := []model.ResponseMappingResponse{}
+	for _, mapping := range mappings {
+		resMappings = append(resMappings, mapping.ToResponse())
+	}
+	return resMappings, nil
+}
+
+func (rmu *responseMappingUsecase) GetResponseMappingById(userId uint, mappingId uint) (model.ResponseMappingResponse, error) {
+	mapping, err := rmu.getMapping(userId, mappingId)
+	if err != nil {
+		return model.ResponseMappingResponse{}, err
+	}
+	return mapping.ToResponse(), nil
+}
+
+func (rmu *responseMappingUsecase) CreateResponseMapping(request model.ResponseMappingRequest) (model.ResponseMappingResponse, error) {
+	if err := rmu.validate(request, rmu.rmv.ValidateResponseMappingRequest); err != nil {
+		return model.ResponseMappingResponse{}, err
+	}
+	mapping := request.ToModel()
+	if err := rmu.rmr.CreateResponseMapping(&mapping); err != nil {
+		return model.ResponseMappingResponse{}, err
+	}
+	return mapping.ToResponse(), nil
+}
+
+func (rmu *responseMappingUsecase) UpdateResponseMapping(request model.ResponseMappingRequest, userId uint, mappingId uint) (model.ResponseMappingResponse, error) {
+	request.UserId = userId
+	if err := rmu.validate(request, rmu.rmv.ValidateResponseMappingRequest); err != nil {
+		return model.ResponseMappingResponse{}, err
+	}
+	mapping := request.ToModel()
+	err := rmu.rmr.UpdateResponseMapping(&mapping, userId, mappingId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.ResponseMappingResponse{}, ErrResponseMappingNotFound
+	}
+	if err != nil {
+		return model.ResponseMappingResponse{}, err
+	}
+	return mapping.ToResponse(), nil
+}
+
+func (rmu *responseMappingUsecase) DeleteResponseMapping(userId uint, mappingId uint) error {
+	err := rmu.rmr.DeleteResponseMapping(userId, mappingId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrResponseMappingNotFound
+	}
+	return err
+}
+
+func (rmu *responseMappingUsecase) PreviewResponseMapping(request model.ResponseMappingRequest) (model.ResponseMappingPreviewResponse, error) {
+	if err := rmu.validate(request, rmu.rmv.ValidateMappingDefinition); err != nil {
+		return model.ResponseMappingPreviewResponse{}, err
+	}
+	mapping := request.ToModel()
+
+	callRes, err := rmu.au.CallExternalAPI(mapping.UserId, mapping.ExternalAPIId, model.ExternalAPICallRequest{Params: mapping.Params})
+	if err != nil {
+		return model.ResponseMappingPreviewResponse{}, err
+	}
+	preview := model.ResponseMappingPreviewResponse{StatusCode: callRes.StatusCode, Cards: []model.ComponentDataItem{}, Errors: []model.MappingError{}}
+	root, err := decodeCallResponse(callRes)
+	if err != nil {
+		preview.Errors = append(preview.Errors, model.MappingError{Message: err.Error()})
+		return preview, nil
+	}
+
+	cards, total, mappingErrors := mapCards(mapping, root, model.MaxDataLimit)
+	preview.Total = total
+	preview.Cards = append(preview.Cards, cards...)
+	preview.Errors = append(preview.Errors, mappingErrors[:min(len(mappingErrors), maxMappingErrors)]...)
+	return preview, nil
+}
+
+func (rmu *responseMappingUsecase) ResolveCards(userId uint, mappingId uint, limit int) ([]model.ComponentDataItem, error) {
+	mapping, err := rmu.getMapping(userId, mappingId)
+	if err != nil {
+		return nil, err
+	}
+	callRes, err := rmu.au.CallExternalAPI(userId, mapping.ExternalAPIId, model.ExternalAPICallRequest{Params: mapping.Params})
+	if err != nil {
+		return nil, err
+	}
+	root, err := decodeCallResponse(callRes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrExternalAPIResponse, err)
+	}
+	cards, _, _ := mapCards(mapping, root, limit)
+	return cards, nil
+}
+
+func (rmu *responseMappingUsecase) getMapping(userId uint, mappingId uint) (model.ResponseMapping, error) {
+	mapping := model.ResponseMapping{}
+	err := rmu.rmr.GetResponseMappingById(&mapping, userId, mappingId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.ResponseMapping{}, ErrResponseMappingNotFound
+	}
+	return mapping, err
+}
+
+// validate は定義を検証し、外部APIがユーザーのものであることを確認します
+func (rmu *responseMappingUsecase) validate(request model.ResponseMappingRequest, validate func(model.ResponseMappingRequest) error) error {
+	if err := validate(request); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidResponseMapping, err)
+	}
+	api := model.ExternalAPI{}
+	if err := rmu.ar.GetExternalAPIById(&api, request.UserId, request.ExternalAPIId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: external api does not exist", ErrInvalidResponseMapping)
+		}
+		return err
+	}
+	return nil
+}
+
+// decodeCallResponse は外部APIの応答の本文をJSONとしてデコードします
+func decodeCallResponse(callRes model.ExternalAPICallResponse) (any, error) {
+	if callRes.StatusCode < 200 || callRes.StatusCode >= 300 {
+		return nil, fmt.Errorf("external api returned status %d", callRes.StatusCode)
+	}
+	if callRes.Body == nil {
+		return nil, fmt.Errorf("external api response is not JSON (content type: %s)", callRes.ContentType)
+	}
+	return jsonmap.Decode(callRes.Body)
+}
+
+// mapCards は応答からItemsPathで項目を取り出し、先頭からlimit件をカードに変換します
+// タイトルが空になった項目はカードに含めず、変換できなかった箇所とともに返す
+func mapCards(mapping model.ResponseMapping, root any, limit int) ([]model.ComponentDataItem, int, []model.MappingError) {
+	// 保存時に検証済みのため、解析に失敗することはない
+	itemsPath, _ := jsonmap.ParsePath(mapping.ItemsPath)
+	items := itemsPath.Select(root, root)
+	// "$.items"のように配列そのものを指定した場合は要素を項目とする
+	if len(items) == 1 {
+		if array, ok := items[0].([]any); ok {
+			items = array
+		}
+	}
+
+	var mappingErrors []model.MappingError
+	if len(items) == 0 {
+		mappingErrors = append(mappingErrors, model.MappingError{Field: "items_path", Message: fmt.Sprintf("%s matched no items", mapping.ItemsPath)})
+	}
+	cards := []model.ComponentDataItem{}
+	for i, item := range items {
+		if len(cards) >= limit {
+			break
+		}
+		card, fieldErrors := mapCard(mapping.Fields, root, item)
+		for _, fieldError := range fieldErrors {
+			index := i
+			fieldError.Item = &index
+			mappingErrors = append(mappingErrors, fieldError)
+		}
+		if card.Title == "" {
+			continue
+		}
+		if card.ID == "" {
+			card.ID = strconv.Itoa(i)
+		}
+		cards = append(cards, card)
+	}
+	return cards, len(items), mappingErrors
+}
+
+// mapCard は1件の項目をテンプレートでカードに変換します。変換できなかった項目は空のままにする
+func mapCard(fields model.CardTemplate, root any, item any) (model.ComponentDataItem, []model.MappingError) {
+	var card model.ComponentDataItem
+	var mappingErrors []model.MappingError
+	fail := func(field string, err error) {
+		mappingErrors = append(mappingErrors, model.MappingError{Field: field, Message: err.Error()})
+	}
+
+	render := func(field string, text string, target *string, isURL bool) bool {
+		template, _ := jsonmap.ParseTemplate(text)
+		value, err := template.Render(root, item)
+		if err != nil {
+			fail(field, err)
+			return false
+		}
+		if isURL && value != "" && !isHTTPURL(value) {
+			fail(field, fmt.Errorf("%q is not an absolute http(s) URL", value))
+			return false
+		}
+		*target = strings.TrimSpace(value)
+		return true
+	}
+	render("id", fields.ID, &card.ID, false)
+	if render("title", fields.Title, &card.Title, false) && card.Title == "" {
+		fail("title", errors.New("title is empty"))
+	}
+	render("url", fields.URL, &card.URL, true)
+	render("excerpt", fields.Excerpt, &card.Excerpt, false)
+	render("image_url", fields.ImageURL, &card.ImageURL, true)
+
+	if fields.Date != "" {
+		template, _ := jsonmap.ParseTemplate(fields.Date)
+		value, err := template.Value(root, item)
+		if err == nil {
+			var date time.Time
+			date, err = parseCardDate(value)
+			if err == nil && !date.IsZero() {
+				card.Date = &date
+			}
+		}
+		if err != nil {
+			fail("date", err)
+		}
+	}
+	if fields.Tags != "" {
+		template, _ := jsonmap.ParseTemplate(fields.Tags)
+		tags, err := template.Strings(root, item)
+		if err != nil {
+			fail("tags", err)
+		}
+		card.Tags = tags
+	}
+	return card, mappingErrors
+}
+
+// parseCardDate は日付の文字列、またはUNIX時間（秒）を日時に変換します。値がない場合はゼロ値
+func parseCardDate(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case nil:
+		return time.Time{}, nil
+	case string:
+		if v == "" {
+			return time.Time{}, nil
+		}
+		for _, layout := range dateLayouts {
+			if date, err := time.Parse(layout, v); err == nil {
+				return date, nil
+			}
+		}
+		if seconds, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.Unix(seconds, 0).UTC(), nil
+		}
+		return time.Time{}, fmt.Errorf("cannot parse %q as a date", v)
+	case json.Number:
+		return parseCardDate(v.String())
+	default:
+		return time.Time{}, fmt.Errorf("expected a date string or unix time but got %s", jsonmap.TypeName(value))
+	}
+}
+
+// isHTTPURL はhttpまたはhttpsの絶対URLかを返します
+func isHTTPURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,179 @@
+package search_test
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/usecase"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSearchUsecase_Search(t *testing.T) {
+	setupSearchUsecaseTest()
+
+	article := createTestArticle(t, searchTestUser.ID, "Go言語の並行処理入門", "ゴルーチンとチャネルを使った並行処理について解説します。", "Go,並行処理")
+	createTestArticle(t, searchTestUser.ID, "Reactの基礎", "コンポーネントと状態管理の入門記事です。並行処理は扱いません。", "React")
+	createTestArticle(t, searchOtherUser.ID, "他人の並行処理メモ", "並行処理", "Go")
+
+	book, err := bookUsecase.CreateBook(model.BookRequest{
+		Title:       "プログラミング言語Go",
+		Author:      "Alan A. A. Donovan",
+		Description: "Go言語の定番入門書",
+		UserId:      searchTestUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("テスト書籍の作成に失敗しました: %v", err)
+	}
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("日本語のキーワードで記事を検索できる", func(t *testing.T) {
+			res, err := searchUsecase.Search(searchTestUser.ID, "並行処理", 0)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if res.Total != 2 {
+				t.Fatalf("Search() got %d results, want 2: %+v", res.Total, res.Items)
+			}
+			// タイトルにマッチした記事が先頭に来る
+			if res.Items[0].SourceID != strconv.FormatUint(uint64(article.ID), 10) {
+				t.Errorf("Search() top result = %+v, want article %d", res.Items[0], article.ID)
+			}
+			if !strings.Contains(res.Items[0].Title, "<mark>並行処理</mark>") {
+				t.Errorf("Search() title is not highlighted: %s", res.Items[0].Title)
+			}
+			if !strings.Contains(res.Items[0].Snippet, "<mark>並行処理</mark>") {
+				t.Errorf("Search() snippet is not highlighted: %s", res.Items[0].Snippet)
+			}
+		})
+
+		t.Run("1文字のキーワードでも検索できる", func(t *testing.T) {
+			res, err := searchUsecase.Search(searchTestUser.ID, "状", 0)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if res.Total != 1 {
+				t.Errorf("Search() got %d results, want 1: %+v", res.Total, res.Items)
+			}
+		})
+
+		t.Run("書籍の著者で検索できる", func(t *testing.T) {
+			res, err := searchUsecase.Search(searchTestUser.ID, "donovan", 0)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if res.Total != 1 || res.Items[0].SourceType != model.SearchSourceBook ||
+				res.Items[0].SourceID != strconv.FormatUint(uint64(book.ID), 10) {
+				t.Errorf("Search() got %+v, want book %d", res.Items, book.ID)
+			}
+		})
+
+		t.Run("英単語は前方一致で検索できる", func(t *testing.T) {
+			res, err := searchUsecase.Search(searchTestUser.ID, "reac", 0)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if res.Total != 1 {
+				t.Errorf("Search() got %d results, want 1: %+v", res.Total, res.Items)
+			}
+		})
+
+		t.Run("削除した記事は検索結果に含まれない", func(t *testing.T) {
+			target := createTestArticle(t, searchTestUser.ID, "削除予定の記事", "この記事は削除されます", "")
+			if err := articleUsecase.DeleteArticle(searchTestUser.ID, target.ID); err != nil {
+				t.Fatalf("記事の削除に失敗しました: %v", err)
+			}
+
+			res, err := searchUsecase.Search(searchTestUser.ID, "削除予定", 0)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if res.Total != 0 {
+				t.Errorf("Search() got %d results, want 0: %+v", res.Total, res.Items)
+			}
+		})
+
+		t.Run("更新した内容で検索できる", func(t *testing.T) {
+			target := createTestArticle(t, searchTestUser.ID, "更新前のタイトル", "本文", "")
+			_, err := articleUsecase.UpdateArticle(model.ArticleRequest{
+				Title:   "全文検索の実装",
+				Content: "本文",
+				UserId:  searchTestUser.ID,
+			}, searchTestUser.ID, target.ID)
+			if err != nil {
+				t.Fatalf("記事の更新に失敗しました: %v", err)
+			}
+
+			res, err := searchUsecase.Search(searchTestUser.ID, "全文検索", 0)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if res.Total != 1 {
+				t.Errorf("Search() got %d results, want 1: %+v", res.Total, res.Items)
+			}
+
+			res, _ = searchUsecase.Search(searchTestUser.ID, "更新前", 0)
+			if res.Total != 0 {
+				t.Errorf("Search() got %d results for old title, want 0", res.Total)
+			}
+		})
+
+		t.Run("古い記事でも関連度が高ければ上位に含まれる", func(t *testing.T) {
+			old := createTestArticle(t, searchTestUser.ID, "分散合意アルゴリズム", "Raftの解説", "分散合意")
+			for i := 0; i < 210; i++ {
+				createTestArticle(t, searchTestUser.ID, "メモ"+strconv.Itoa(i), "分散合意について少し触れたメモ", "")
+			}
+
+			res, err := searchUsecase.Search(searchTestUser.ID, "分散合意", 10)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if res.Total != 211 {
+				t.Errorf("Search() total = %d, want 211", res.Total)
+			}
+			if len(res.Items) != 10 {
+				t.Fatalf("Search() got %d items, want 10", len(res.Items))
+			}
+			if res.Items[0].SourceID != strconv.FormatUint(uint64(old.ID), 10) {
+				t.Errorf("Search() top result = %+v, want article %d", res.Items[0], old.ID)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("空のキーワードはエラーを返す", func(t *testing.T) {
+			if _, err := searchUsecase.Search(searchTestUser.ID, "", 0); !errors.Is(err, usecase.ErrInvalidSearchQuery) {
+				t.Errorf("Search() error = %v, want ErrInvalidSearchQuery", err)
+			}
+		})
+	})
+}
+
+func TestSearchUsecase_Reindex(t *testing.T) {
+	setupSearchUsecaseTest()
+
+	// インデックスを経由せずに直接作成した記事
+	article := model.Article{Title: "インデックス再構築のテスト", Content: "本文", UserId: searchTestUser.ID}
+	searchDb.Create(&article)
+
+	res, _ := searchUsecase.Search(searchTestUser.ID, "再構築", 0)
+	if res.Total != 0 {
+		t.Fatalf("再構築前に検索結果が返されました: %+v", res.Items)
+	}
+
+	count, err := searchUsecase.Reindex(searchTestUser.ID)
+	if err != nil {
+		t.Fatalf("Reindex() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Reindex() indexed %d documents, want 1", count)
+	}
+
+	res, err = searchUsecase.Search(searchTestUser.ID, "再構築", 0)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if res.Total != 1 {
+		t.Errorf("Search() got %d results, want 1", res.Total)
+	}
+}
@@ -0,0 +1,55 @@
+package search_test
+
+import (
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/utils/markdown"
+	"go-react-app/validator"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// テスト用の共通変数
+var (
+	searchDb        *gorm.DB
+	articleUsecase  usecase.IArticleUsecase
+	bookUsecase     usecase.IBookUsecase
+	searchUsecase   usecase.ISearchUsecase
+	searchTestUser  model.User
+	searchOtherUser model.User
+)
+
+// テスト前の共通セットアップ
+func setupSearchUsecaseTest() {
+	if searchDb == nil {
+		// 初回のみデータベース接続を作成
+		searchDb = testutils.SetupTestDB()
+		searchRepo := repository.NewSearchRepository(searchDb)
+		articleRepo := repository.NewArticleRepository(searchDb)
+		bookRepo := repository.NewBookRepository(searchDb)
+		feedArticleRepo := repository.NewFeedArticleRepository(repository.NewFeedRepository(searchDb))
+		articleUsecase = usecase.NewArticleUsecase(articleRepo, validator.NewArticleValidator(), searchRepo, markdown.NewRenderer(markdown.DefaultCacheSize), repository.NewLayoutRepository(searchDb))
+		bookUsecase = usecase.NewBookUsecase(bookRepo, validator.NewBookValidator(), searchRepo)
+		searchUsecase = usecase.NewSearchUsecase(searchRepo, articleRepo, bookRepo, feedArticleRepo, validator.NewSearchValidator())
+	}
+
+	searchTestUser = testutils.CreateTestUser(searchDb)
+	searchOtherUser = testutils.CreateOtherUser(searchDb)
+}
+
+// テスト用の記事を作成するヘルパー関数
+func createTestArticle(t *testing.T, userId uint, title string, content string, tags string) model.ArticleResponse {
+	article, err := articleUsecase.CreateArticle(model.ArticleRequest{
+		Title:   title,
+		Content: content,
+		Tags:    tags,
+		UserId:  userId,
+	})
+	if err != nil {
+		t.Fatalf("テスト記事の作成に失敗しました: %v", err)
+	}
+	return article
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/utils/search"
+	"go-react-app/validator"
+	"log"
+	"sort"
+)
+
+const (
+	// searchDefaultLimit 検索結果の既定件数
+	searchDefaultLimit = 20
+	// searchMaxLimit 検索結果の最大件数
+	searchMaxLimit = 100
+	// searchCandidateLimit データベースで関連度の高い順に絞り込んだ候補の最大件数
+	searchCandidateLimit = 200
+)
+
+// ErrInvalidSearchQuery は検索キーワードが不正な場合のエラー
+var ErrInvalidSearchQuery = errors.New("invalid search query")
+
+type ISearchUsecase interface {
+	Search(userId uint, query string, limit int) (model.SearchResponse, error)
+	Reindex(userId uint) (int, error)
+}
+
+type searchUsecase struct {
+	sr  repository.ISearchRepository
+	ar  repository.IArticleRepository
+	br  repository.IBookRepository
+	far repository.IFeedArticleRepository
+	sv  validator.ISearchValidator
+}
+
+func NewSearchUsecase(
+	sr repository.ISearchRepository,
+	ar repository.IArticleRepository,
+	br repository.IBookRepository,
+	far repository.IFeedArticleRepository,
+	sv validator.ISearchValidator,
+) ISearchUsecase {
+	return &searchUsecase{sr, ar, br, far, sv}
+}
+
+func (su *searchUsecase) Search(userId uint, query string, limit int) (model.SearchResponse, error) {
+	if err := su.sv.ValidateSearchQuery(query); err != nil {
+		return model.SearchResponse{}, fmt.Errorf("%w: %v", ErrInvalidSearchQuery, err)
+	}
+	if limit <= 0 {
+		limit = searchDefaultLimit
+	}
+	if limit > searchMaxLimit {
+		limit = searchMaxLimit
+	}
+
+	documents, total, err := su.sr.SearchDocuments(userId, search.QueryPhrases(query), searchCandidateLimit)
+	if err != nil {
+		return model.SearchResponse{}, err
+	}
+
+	terms := search.QueryTerms(query)
+	results := make([]model.SearchResult, 0, len(documents))
+	for _, doc := range documents {
+		body := search.PlainText(doc.Body)
+		results = append(results, model.SearchResult{
+			SourceType: doc.SourceType,
+			SourceID:   doc.SourceID,
+			Title:      search.Highlight(doc.Title, terms),
+			Snippet:    search.Snippet(body, terms),
+			URL:        doc.URL,
+			Score:      search.Score(doc.Title, doc.Tags, body, terms),
+			UpdatedAt:  doc.UpdatedAt,
+		})
+	}
+
+	// 候補を本文の長さも考慮した関連度の高い順、同点の場合は更新日時の新しい順に並べ直す
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].UpdatedAt.After(results[j].UpdatedAt)
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return model.SearchResponse{Query: query, Total: int(total), Items: results}, nil
+}
+
+// Reindex はユーザーの記事・書籍・フィード記事を全文検索インデックスに登録し直します
+func (su *searchUsecase) Reindex(userId uint) (int, error) {
+	count := 0
+
+	articles := []model.Article{}
+	if err := su.ar.GetAllArticles(&articles, userId); err != nil {
+		return count, err
+	}
+	for _, article := range articles {
+		doc := article.ToSearchDocument()
+		if err := su.sr.UpsertDocument(&doc); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	books, err := su.br.GetAllBooks(userId)
+	if err != nil {
+		return count, err
+	}
+	for _, book := range books {
+		doc := book.ToSearchDocument()
+		if err := su.sr.UpsertDocument(&doc); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	feedArticles, err := su.far.GetAllArticles(userId)
+	if err != nil {
+		return count, err
+	}
+	for _, feedArticle := range feedArticles {
+		doc := feedArticle.ToSearchDocument(userId)
+		if err := su.sr.UpsertDocument(&doc); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// upsertSearchDocument は検索インデックスを更新します
+// インデックスの更新に失敗しても元の操作は成功しているため、エラーはログに記録するだけにします
+func upsertSearchDocument(sr repository.ISearchRepository, doc model.SearchDocument) {
+	if err := sr.UpsertDocument(&doc); err != nil {
+		log.Printf("検索インデックスの更新に失敗しました (%s:%s): %v", doc.SourceType, doc.SourceID, err)
+	}
+}
+
+// deleteSearchDocument は検索インデックスからドキュメントを削除します
+func deleteSearchDocument(sr repository.ISearchRepository, userId uint, sourceType string, sourceId string) {
+	if err := sr.DeleteDocument(userId, sourceType, sourceId); err != nil {
+		log.Printf("検索インデックスの削除に失敗しました (%s:%s): %v", sourceType, sourceId, err)
+	}
+}
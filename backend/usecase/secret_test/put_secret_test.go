@@ -0,0 +1,116 @@
+package secret_test
+
+import (
+	"bytes"
+	"errors"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/usecase"
+	"go-react-app/validator"
+	"testing"
+)
+
+func TestSecretUsecase_PutSecret(t *testing.T) {
+	setupSecretUsecaseTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("値を暗号化して保存し、ユーザーごとに復号できる", func(t *testing.T) {
+			res, err := secretUsecase.PutSecret(testUser.ID, model.SecretNameQiitaAccessToken, model.SecretRequest{Value: "token-1"})
+			if err != nil {
+				t.Fatalf("PutSecret() error = %v", err)
+			}
+			if res.Name != model.SecretNameQiitaAccessToken {
+				t.Errorf("PutSecret() name = %s", res.Name)
+			}
+
+			var stored model.Secret
+			secretDb.Where("user_id=? AND name=?", testUser.ID, model.SecretNameQiitaAccessToken).First(&stored)
+			if stored.KeyId != "v1" || len(stored.WrappedKey) == 0 || bytes.Contains(stored.Ciphertext, []byte("token-1")) {
+				t.Errorf("保存された秘密情報が暗号化されていません: key_id=%s", stored.KeyId)
+			}
+
+			if value, _ := secretRepo.ResolveSecret(testUser.ID, model.SecretNameQiitaAccessToken); value != "token-1" {
+				t.Errorf("ResolveSecret() = %q, want token-1", value)
+			}
+			if value, _ := secretRepo.ResolveSecret(otherUser.ID, model.SecretNameQiitaAccessToken); value != "" {
+				t.Errorf("ResolveSecret() for other user = %q, want empty", value)
+			}
+		})
+
+		t.Run("同じ名前で保存すると値を置き換える", func(t *testing.T) {
+			secretUsecase.PutSecret(testUser.ID, model.SecretNameGoogleBooksAPIKey, model.SecretRequest{Value: "old"})
+			if _, err := secretUsecase.PutSecret(testUser.ID, model.SecretNameGoogleBooksAPIKey, model.SecretRequest{Value: "new"}); err != nil {
+				t.Fatalf("PutSecret() error = %v", err)
+			}
+
+			if value, _ := secretRepo.ResolveSecret(testUser.ID, model.SecretNameGoogleBooksAPIKey); value != "new" {
+				t.Errorf("ResolveSecret() = %q, want new", value)
+			}
+			secrets, _ := secretUsecase.GetSecrets(testUser.ID)
+			if len(secrets) != 2 || secrets[0].Name != model.SecretNameGoogleBooksAPIKey || secrets[1].Name != model.SecretNameQiitaAccessToken {
+				t.Errorf("GetSecrets() = %+v", secrets)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("別の行に暗号文を移しても復号できない", func(t *testing.T) {
+			secretUsecase.PutSecret(otherUser.ID, model.SecretNameQiitaAccessToken, model.SecretRequest{Value: "other"})
+			var source model.Secret
+			secretDb.Where("user_id=? AND name=?", testUser.ID, model.SecretNameQiitaAccessToken).First(&source)
+			secretDb.Model(&model.Secret{}).Where("user_id=? AND name=?", otherUser.ID, model.SecretNameQiitaAccessToken).
+				Updates(map[string]interface{}{"key_id": source.KeyId, "wrapped_key": source.WrappedKey, "ciphertext": source.Ciphertext})
+
+			if _, err := secretRepo.ResolveSecret(otherUser.ID, model.SecretNameQiitaAccessToken); err == nil {
+				t.Error("ResolveSecret() error = nil, want decryption error")
+			}
+		})
+
+		t.Run("不正な名前や空の値はErrInvalidSecretを返す", func(t *testing.T) {
+			for _, tt := range []struct {
+				name  string
+				value string
+			}{
+				{"external_api:1", "value"},
+				{"Qiita Token", "value"},
+				{"", "value"},
+				{model.SecretNameQiitaAccessToken, ""},
+			} {
+				if _, err := secretUsecase.PutSecret(testUser.ID, tt.name, model.SecretRequest{Value: tt.value}); !errors.Is(err, usecase.ErrInvalidSecret) {
+					t.Errorf("PutSecret(%q, %q) error = %v, want ErrInvalidSecret", tt.name, tt.value, err)
+				}
+			}
+		})
+
+		t.Run("マスターキーが設定されていない場合はErrSecretStoreDisabledを返す", func(t *testing.T) {
+			disabled := usecase.NewSecretUsecase(repository.NewSecretRepository(secretDb, nil), validator.NewSecretValidator())
+
+			_, err := disabled.PutSecret(testUser.ID, model.SecretNameQiitaAccessToken, model.SecretRequest{Value: "token"})
+			if !errors.Is(err, usecase.ErrSecretStoreDisabled) {
+				t.Errorf("PutSecret() error = %v, want ErrSecretStoreDisabled", err)
+			}
+		})
+
+		t.Run("マスターキーが設定されていない場合、保存済みの秘密情報の復号はErrSecretStoreDisabledを返す", func(t *testing.T) {
+			if _, err := secretUsecase.PutSecret(testUser.ID, model.SecretNameGoogleBooksAPIKey, model.SecretRequest{Value: "key"}); err != nil {
+				t.Fatalf("PutSecret() error = %v", err)
+			}
+			disabledRepo := repository.NewSecretRepository(secretDb, nil)
+
+			if _, err := disabledRepo.ResolveSecret(testUser.ID, model.SecretNameGoogleBooksAPIKey); !errors.Is(err, usecase.ErrSecretStoreDisabled) {
+				t.Errorf("ResolveSecret() error = %v, want ErrSecretStoreDisabled", err)
+			}
+			// 保存されていない秘密情報は空文字を返す
+			if value, err := disabledRepo.ResolveSecret(otherUser.ID, model.SecretNameGoogleBooksAPIKey); err != nil || value != "" {
+				t.Errorf("ResolveSecret() = %q, %v, want empty", value, err)
+			}
+		})
+
+		t.Run("保存されていない秘密情報の削除はErrSecretNotFoundを返す", func(t *testing.T) {
+			err := secretUsecase.DeleteSecret(testUser.ID, "missing")
+			if !errors.Is(err, usecase.ErrSecretNotFound) {
+				t.Errorf("DeleteSecret() error = %v, want ErrSecretNotFound", err)
+			}
+		})
+	})
+}
@@ -0,0 +1,73 @@
+package secret_test
+
+import (
+	"errors"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/utils/secretbox"
+	"go-react-app/validator"
+	"testing"
+)
+
+func TestSecretUsecase_RotateSecrets(t *testing.T) {
+	setupSecretUsecaseTest()
+
+	// v1のマスターキーで保存する
+	for i := 0; i < 3; i++ {
+		if _, err := secretUsecase.PutSecret(testUser.ID, fmt.Sprintf("token_%d", i), model.SecretRequest{Value: fmt.Sprintf("value-%d", i)}); err != nil {
+			t.Fatalf("PutSecret() error = %v", err)
+		}
+	}
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("データキーを新しいマスターキーで暗号化し直し、値は変えない", func(t *testing.T) {
+			var before model.Secret
+			secretDb.Where("user_id=? AND name=?", testUser.ID, "token_0").First(&before)
+
+			rotatedRepo := repository.NewSecretRepository(secretDb, testutils.NewTestKeyring("v2", "v1"))
+			rotated, err := usecase.NewSecretUsecase(rotatedRepo, validator.NewSecretValidator()).RotateSecrets()
+			if err != nil {
+				t.Fatalf("RotateSecrets() error = %v", err)
+			}
+			if rotated != 3 {
+				t.Errorf("RotateSecrets() = %d, want 3", rotated)
+			}
+
+			var after model.Secret
+			secretDb.Where("user_id=? AND name=?", testUser.ID, "token_0").First(&after)
+			if after.KeyId != "v2" || string(after.Ciphertext) != string(before.Ciphertext) {
+				t.Errorf("RotateSecrets() key_id = %s, ciphertext changed = %v", after.KeyId, string(after.Ciphertext) != string(before.Ciphertext))
+			}
+
+			// 古いマスターキーを削除しても復号できる
+			newOnlyRepo := repository.NewSecretRepository(secretDb, testutils.NewTestKeyring("v2"))
+			for i := 0; i < 3; i++ {
+				value, err := newOnlyRepo.ResolveSecret(testUser.ID, fmt.Sprintf("token_%d", i))
+				if err != nil || value != fmt.Sprintf("value-%d", i) {
+					t.Errorf("ResolveSecret(token_%d) = %q, %v", i, value, err)
+				}
+			}
+
+			// すべて暗号化し直した後は何もしない
+			if rotated, _ := rotatedRepo.RotateSecrets(10); rotated != 0 {
+				t.Errorf("RotateSecrets() second run = %d, want 0", rotated)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("キーリングにないマスターキーの秘密情報はErrUnknownKeyを返す", func(t *testing.T) {
+			unknownRepo := repository.NewSecretRepository(secretDb, testutils.NewTestKeyring("v3"))
+
+			if _, err := unknownRepo.ResolveSecret(testUser.ID, "token_0"); !errors.Is(err, secretbox.ErrUnknownKey) {
+				t.Errorf("ResolveSecret() error = %v, want ErrUnknownKey", err)
+			}
+			if _, err := unknownRepo.RotateSecrets(10); !errors.Is(err, secretbox.ErrUnknownKey) {
+				t.Errorf("RotateSecrets() error = %v, want ErrUnknownKey", err)
+			}
+		})
+	})
+}
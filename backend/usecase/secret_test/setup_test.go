@@ -0,0 +1,35 @@
+package secret_test
+
+import (
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/testutils"
+	"go-react-app/usecase"
+	"go-react-app/validator"
+
+	"gorm.io/gorm"
+)
+
+// テスト用の共通変数
+var (
+	secretDb      *gorm.DB
+	secretRepo    repository.ISecretRepository
+	secretUsecase usecase.ISecretUsecase
+	testUser      model.User
+	otherUser     model.User
+)
+
+// テスト前の共通セットアップ
+func setupSecretUsecaseTest() {
+	if secretDb != nil {
+		testutils.CleanupTestDB(secretDb)
+		secretDb.Exec("DELETE FROM secrets")
+	} else {
+		secretDb = testutils.SetupTestDB()
+		secretRepo = repository.NewSecretRepository(secretDb, testutils.NewTestKeyring("v1"))
+		secretUsecase = usecase.NewSecretUsecase(secretRepo, validator.NewSecretValidator())
+	}
+
+	testUser = testutils.CreateTestUser(secretDb)
+	otherUser = testutils.CreateOtherUser(secretDb)
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/repository"
+	"go-react-app/validator"
+
+	"gorm.io/gorm"
+)
+
+// secretRotationBatchSize マスターキーのローテーションで一度に暗号化し直す秘密情報の件数
+const secretRotationBatchSize = 100
+
+var (
+	// ErrInvalidSecret は秘密情報の名前または値が不正な場合のエラー
+	ErrInvalidSecret = errors.New("invalid secret")
+	// ErrSecretNotFound は秘密情報が保存されていない場合のエラー
+	ErrSecretNotFound = errors.New("secret not found")
+	// ErrSecretStoreDisabled はマスターキーが設定されていないため秘密情報を保存できない場合のエラー
+	ErrSecretStoreDisabled = repository.ErrSecretStoreDisabled
+)
+
+// ISecretUsecase は連携サービスの認証情報などの秘密情報を管理します
+// 値は保存のみでき、APIから読み出すことはできない
+type ISecretUsecase interface {
+	GetSecrets(userId uint) ([]model.SecretResponse, error)
+	PutSecret(userId uint, name string, request model.SecretRequest) (model.SecretResponse, error)
+	DeleteSecret(userId uint, name string) error
+	// RotateSecrets はすべてのユーザーの秘密情報のデータキーを現在のマスターキーで暗号化し直し、件数を返します
+	RotateSecrets() (int, error)
+}
+
+type secretUsecase struct {
+	sr repository.ISecretRepository
+	sv validator.ISecretValidator
+}
+
+func NewSecretUsecase(sr repository.ISecretRepository, sv validator.ISecretValidator) ISecretUsecase {
+	return &secretUsecase{sr, sv}
+}
+
+func (su *secretUsecase) GetSecrets(userId uint) ([]model.SecretResponse, error) {
+	secrets, err := su.sr.GetSecrets(userId)
+	if err != nil {
+		return nil, err
+	}
+	resSecrets := []model.SecretResponse{}
+	for _, secret := range secrets {
+		resSecrets = append(resSecrets, secret.ToResponse())
+	}
+	return resSecrets, nil
+}
+
+func (su *secretUsecase) PutSecret(userId uint, name string, request model.SecretRequest) (model.SecretResponse, error) {
+	if err := su.sv.ValidateSecretName(name); err != nil {
+		return model.SecretResponse{}, fmt.Errorf("%w: %v", ErrInvalidSecret, err)
+	}
+	if err := su.sv.ValidateSecretRequest(request); err != nil {
+		return model.SecretResponse{}, fmt.Errorf("%w: %v", ErrInvalidSecret, err)
+	}
+	secret, err := su.sr.PutSecret(userId, name, request.Value)
+	if err != nil {
+		return model.SecretResponse{}, err
+	}
+	return secret.ToResponse(), nil
+}
+
+func (su *secretUsecase) DeleteSecret(userId uint, name string) error {
+	if err := su.sv.ValidateSecretName(name); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSecret, err)
+	}
+	err := su.sr.DeleteSecret(userId, name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	return err
+}
+
+func (su *secretUsecase) RotateSecrets() (int, error) {
+	return su.sr.RotateSecrets(secretRotationBatchSize)
+}
@@ -0,0 +1,58 @@
+package user_test
+
+import (
+	"go-react-app/model"
+	"testing"
+)
+
+func TestUserUsecase_UpdateUsername(t *testing.T) {
+	setupUserUsecaseTest()
+
+	// ユーザー名を設定していないユーザーを複数作成できることも確認する
+	first, err := userUsecase.SignUp(model.UserSignupRequest{Email: generateUniqueEmail(), Password: "password123"})
+	if err != nil {
+		t.Fatalf("テストユーザーの登録に失敗しました: %v", err)
+	}
+	second, err := userUsecase.SignUp(model.UserSignupRequest{Email: generateUniqueEmail(), Password: "password123"})
+	if err != nil {
+		t.Fatalf("テストユーザーの登録に失敗しました: %v", err)
+	}
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("ユーザー名を設定できる", func(t *testing.T) {
+			userRes, err := userUsecase.UpdateUsername(first.ID, model.UsernameRequest{Username: "gopher"})
+			if err != nil {
+				t.Fatalf("UpdateUsername() error = %v", err)
+			}
+			if userRes.Username != "gopher" {
+				t.Errorf("UpdateUsername() = %+v, want username=gopher", userRes)
+			}
+		})
+
+		t.Run("登録時にユーザー名を指定できる", func(t *testing.T) {
+			userRes, err := userUsecase.SignUp(model.UserSignupRequest{Email: generateUniqueEmail(), Password: "password123", Username: "signup-user"})
+			if err != nil {
+				t.Fatalf("SignUp() error = %v", err)
+			}
+			if userRes.Username != "signup-user" {
+				t.Errorf("SignUp() = %+v, want username=signup-user", userRes)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("使用中のユーザー名は設定できない", func(t *testing.T) {
+			if _, err := userUsecase.UpdateUsername(second.ID, model.UsernameRequest{Username: "gopher"}); err == nil {
+				t.Error("UpdateUsername() should fail for duplicated username")
+			}
+		})
+
+		t.Run("使用できない文字を含むユーザー名は設定できない", func(t *testing.T) {
+			for _, username := range []string{"", "ab", "Gopher", "go pher", "../admin", "-gopher"} {
+				if _, err := userUsecase.UpdateUsername(second.ID, model.UsernameRequest{Username: username}); err == nil {
+					t.Errorf("UpdateUsername(%q) should fail", username)
+				}
+			}
+		})
+	})
+}
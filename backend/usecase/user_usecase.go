@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"fmt"
 	"go-react-app/model"
 	"go-react-app/repository"
 	"go-react-app/validator"
@@ -14,6 +15,7 @@ import (
 type IUserUsecase interface {
 	SignUp(req model.UserSignupRequest) (*model.UserResponse, error)
 	Login(req model.UserLoginRequest) (string, error)
+	UpdateUsername(userId uint, req model.UsernameRequest) (*model.UserResponse, error)
 }
 
 type userUsecase struct {
@@ -76,4 +78,22 @@ func (uu *userUsecase) Login(req model.UserLoginRequest) (string, error) {
 	})
 	
 	return token.SignedString([]byte(os.Getenv("SECRET")))
-}
\ No newline at end of file
+}
+
+func (uu *userUsecase) UpdateUsername(userId uint, req model.UsernameRequest) (*model.UserResponse, error) {
+	if err := uu.uv.UsernameValidate(req.Username); err != nil {
+		return nil, err
+	}
+
+	// 他のユーザーが使用中のユーザー名は設定できない
+	if existing, err := uu.ur.GetUserByUsername(req.Username); err == nil && existing.ID != userId {
+		return nil, fmt.Errorf("ユーザー名 %s は既に使用されています", req.Username)
+	}
+
+	user, err := uu.ur.UpdateUsername(userId, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	response := user.ToUserResponse()
+	return &response, nil
+}
@@ -0,0 +1,247 @@
+// Package bookcsv はGoodreads・ブクログ・読書メーターなどの読書管理サービスから書き出したCSVを、書籍の一括登録の入力に変換します
+package bookcsv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"go-react-app/model"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// CSVの形式
+const (
+	FormatGoodreads = "goodreads" // Goodreadsの「Export Library」
+	FormatBooklog   = "booklog"   // ブクログのエクスポート（ヘッダー行なし、Shift_JIS）
+	FormatBookmeter = "bookmeter" // 読書メーターなど、日本語のヘッダー行のあるCSV
+	FormatGeneric   = "generic"   // 英語のヘッダー行のあるCSV
+)
+
+// ErrUnsupported はCSVの形式を判別できない場合のエラー
+var ErrUnsupported = errors.New("unsupported CSV format")
+
+// ヘッダー行の列名（小文字）。先に書いた列名を優先する
+var (
+	isbnHeaders     = []string{"isbn13", "13桁isbn", "isbn", "isbn/asin", "isbn-13", "isbn-10"}
+	titleHeaders    = []string{"title", "タイトル", "書名", "書籍名"}
+	authorHeaders   = []string{"author", "著者", "著者名", "作者名"}
+	statusHeaders   = []string{"exclusive shelf", "読書状況", "ステータス", "status"}
+	ratingHeaders   = []string{"my rating", "評価", "rating"}
+	finishedHeaders = []string{"date read", "読了日", "finished"}
+)
+
+// ブクログのエクスポートの列の位置
+const (
+	booklogISBN     = 2
+	booklogRating   = 4
+	booklogStatus   = 5
+	booklogFinished = 10
+	booklogTitle    = 11
+	booklogAuthor   = 12
+	booklogColumns  = 13
+)
+
+// statuses 読書管理サービスの読書の状態・本棚の名前と読書の状態の対応
+var statuses = map[string]string{
+	"to-read":           model.BookStatusWantToRead,
+	"読みたい":              model.BookStatusWantToRead,
+	"読みたい本":             model.BookStatusWantToRead,
+	"積読":                model.BookStatusWantToRead,
+	"積読本":               model.BookStatusWantToRead,
+	"currently-reading": model.BookStatusReading,
+	"いま読んでる":            model.BookStatusReading,
+	"読んでる本":             model.BookStatusReading,
+	"読書中":               model.BookStatusReading,
+	"read":              model.BookStatusFinished,
+	"読み終わった":            model.BookStatusFinished,
+	"読んだ本":              model.BookStatusFinished,
+	"読了":                model.BookStatusFinished,
+	"did-not-finish":    model.BookStatusAbandoned,
+	"中断":                model.BookStatusAbandoned,
+}
+
+// dateLayouts 読了日として受け付ける日付の形式
+var dateLayouts = []string{"2006/01/02", "2006-01-02", "2006/1/2", "2006-01-02 15:04:05", "2006/01/02 15:04:05"}
+
+// Parse はCSVを判別した形式で読み込み、1冊ごとの入力を返します
+// UTF-8として不正な場合はShift_JISとして読み込む。ISBNとタイトルのどちらもない行は含めない
+func Parse(data []byte) ([]model.BookImportEntry, string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		decoded, err := japanese.ShiftJIS.NewDecoder().Bytes(data)
+		if err != nil {
+			return nil, "", ErrUnsupported
+		}
+		data = decoded
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return []model.BookImportEntry{}, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	var records [][]string
+	columns, format := headerColumns(header)
+	if format == "" {
+		// ヘッダー行のないブクログのエクスポートは、1行目からデータとして読み込む
+		if !isBooklogRecord(header) {
+			return nil, "", ErrUnsupported
+		}
+		columns = map[string]int{
+			"isbn": booklogISBN, "title": booklogTitle, "author": booklogAuthor,
+			"status": booklogStatus, "rating": booklogRating, "finished": booklogFinished,
+		}
+		format = FormatBooklog
+		records = append(records, header)
+	}
+	rest, err := reader.ReadAll()
+	if err != nil {
+		return nil, "", err
+	}
+	records = append(records, rest...)
+
+	entries := []model.BookImportEntry{}
+	for i, record := range records {
+		if entry, ok := toEntry(record, columns); ok {
+			entry.Row = i + 1
+			entries = append(entries, entry)
+		}
+	}
+	return entries, format, nil
+}
+
+// headerColumns はヘッダー行から列の位置と形式を判別します。ヘッダー行でない場合は空の形式を返す
+func headerColumns(header []string) (map[string]int, string) {
+	names := make([]string, len(header))
+	for i, name := range header {
+		names[i] = strings.ToLower(strings.TrimSpace(name))
+	}
+	columns := map[string]int{}
+	for key, candidates := range map[string][]string{
+		"isbn": isbnHeaders, "title": titleHeaders, "author": authorHeaders,
+		"status": statusHeaders, "rating": ratingHeaders, "finished": finishedHeaders,
+	} {
+		if i := findColumn(names, candidates); i >= 0 {
+			columns[key] = i
+		}
+	}
+	// GoodreadsのISBN13が空の場合はISBN（ISBN-10）の列を使う
+	if i, ok := columns["isbn"]; ok {
+		others := make([]string, len(names))
+		copy(others, names)
+		others[i] = ""
+		if j := findColumn(others, isbnHeaders); j >= 0 {
+			columns["isbn_alt"] = j
+		}
+	}
+	_, hasISBN := columns["isbn"]
+	_, hasTitle := columns["title"]
+	switch {
+	case !hasISBN && !hasTitle:
+		return nil, ""
+	case findColumn(names, []string{"exclusive shelf"}) >= 0:
+		return columns, FormatGoodreads
+	case hasNonASCII(names):
+		return columns, FormatBookmeter
+	default:
+		return columns, FormatGeneric
+	}
+}
+
+func hasNonASCII(names []string) bool {
+	for _, name := range names {
+		for _, r := range name {
+			if r >= utf8.RuneSelf {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func findColumn(names []string, candidates []string) int {
+	for _, candidate := range candidates {
+		for i, name := range names {
+			if name == candidate {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// isBooklogRecord はブクログのエクスポートの行であるかを判別します（1列目はサービスID、3列目は13桁のISBNまたは空）
+func isBooklogRecord(record []string) bool {
+	if len(record) < booklogColumns {
+		return false
+	}
+	if _, err := strconv.Atoi(strings.TrimSpace(record[0])); err != nil {
+		return false
+	}
+	isbn := cleanISBN(record[booklogISBN])
+	return isbn == "" || len(isbn) == 13
+}
+
+// toEntry は1行を1冊分の入力にします。ISBNとタイトルのどちらもない場合はfalse
+func toEntry(record []string, columns map[string]int) (model.BookImportEntry, bool) {
+	value := func(key string) string {
+		i, ok := columns[key]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	entry := model.BookImportEntry{
+		ISBN:       cleanISBN(value("isbn")),
+		Title:      value("title"),
+		Author:     value("author"),
+		Status:     statuses[strings.ToLower(value("status"))],
+		FinishedAt: parseDate(value("finished")),
+	}
+	if entry.ISBN == "" {
+		entry.ISBN = cleanISBN(value("isbn_alt"))
+	}
+	if entry.ISBN == "" && entry.Title == "" {
+		return model.BookImportEntry{}, false
+	}
+	if rating, err := strconv.Atoi(value("rating")); err == nil && rating >= 1 && rating <= model.BookRatingMax {
+		entry.Rating = rating
+	}
+	// 読了日のみある場合は読了とする
+	if entry.Status == "" && entry.FinishedAt != "" {
+		entry.Status = model.BookStatusFinished
+	}
+	if entry.Status != model.BookStatusFinished && entry.Status != model.BookStatusAbandoned {
+		entry.FinishedAt = ""
+	}
+	return entry, true
+}
+
+// cleanISBN はGoodreadsの="..."の形式の値から記号を除きます
+func cleanISBN(value string) string {
+	return strings.Trim(strings.TrimSpace(value), `="`)
+}
+
+// parseDate は読了日をYYYY-MM-DDにします。日付として読めない場合は空文字列
+func parseDate(value string) string {
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date.Format(model.BookDateLayout)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package feedgen
+
+import (
+	"time"
+)
+
+const generatorName = "blog-cms"
+
+// Feed はAtom・RSS・JSON Feedに共通するフィードの内容
+type Feed struct {
+	Title       string
+	Description string
+	Link        string // サイトのURL
+	FeedURL     string // このフィード自身のURL
+	Author      string
+	Updated     time.Time
+	Items       []Item
+}
+
+// Item はフィードに含める記事
+type Item struct {
+	ID        string
+	Title     string
+	Link      string
+	Summary   string
+	Content   string // レンダリング済みのHTML
+	Tags      []string
+	Published time.Time
+	Updated   time.Time
+}
+
+// LastModified はフィード内で最も新しい更新日時を返します
+func (f *Feed) LastModified() time.Time {
+	latest := f.Updated
+	for _, item := range f.Items {
+		if item.Updated.After(latest) {
+			latest = item.Updated
+		}
+	}
+	return latest
+}
@@ -0,0 +1,81 @@
+package feedgen
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+type rssFeed struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	XmlnsAtom string     `xml:"xmlns:atom,attr"`
+	XmlnsCont string     `xml:"xmlns:content,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	AtomLink      rssSelf   `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Generator     string    `xml:"generator"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssSelf struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title          string   `xml:"title"`
+	Link           string   `xml:"link"`
+	GUID           rssGUID  `xml:"guid"`
+	PubDate        string   `xml:"pubDate"`
+	Description    string   `xml:"description,omitempty"`
+	ContentEncoded string   `xml:"content:encoded"`
+	Categories     []string `xml:"category"`
+}
+
+// RSS はフィードをRSS 2.0形式で出力します
+// 本文のHTMLはcontent:encoded要素に格納します
+func (f *Feed) RSS() ([]byte, error) {
+	description := f.Description
+	if description == "" {
+		description = f.Title
+	}
+
+	feed := rssFeed{
+		Version:   "2.0",
+		XmlnsAtom: "http://www.w3.org/2005/Atom",
+		XmlnsCont: "http://purl.org/rss/1.0/modules/content/",
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link,
+			Description:   description,
+			AtomLink:      rssSelf{Href: f.FeedURL, Rel: "self", Type: "application/rss+xml"},
+			LastBuildDate: f.LastModified().UTC().Format(time.RFC1123Z),
+			Generator:     generatorName,
+		},
+	}
+
+	for _, item := range f.Items {
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:          item.Title,
+			Link:           item.Link,
+			GUID:           rssGUID{IsPermaLink: false, Value: item.ID},
+			PubDate:        item.Published.UTC().Format(time.RFC1123Z),
+			Description:    item.Summary,
+			ContentEncoded: item.Content,
+			Categories:     item.Tags,
+		})
+	}
+	return marshalXML(feed)
+}
@@ -0,0 +1,84 @@
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// ThumbnailMaxSize はサムネイルの長辺の最大ピクセル数
+	ThumbnailMaxSize = 320
+
+	// MaxPixels はデコードを許可する画像の最大画素数（解凍爆弾対策）
+	MaxPixels = 50_000_000
+)
+
+// SupportedTypes はアップロードを許可する画像のMIMEタイプ
+var SupportedTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// DetectContentType はファイルの先頭バイトからMIMEタイプを判定します
+// クライアントが送信したContent-Typeや拡張子は信用しません
+func DetectContentType(data []byte) string {
+	return http.DetectContentType(data)
+}
+
+// Thumbnail は画像を縮小したサムネイルを作成し、元画像の幅・高さと共に返します
+// 透過を保持するためPNG・GIFはPNGで、それ以外はJPEGでエンコードします
+func Thumbnail(data []byte, contentType string) (thumb []byte, thumbType string, width int, height int, err error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", 0, 0, err
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, "", 0, 0, fmt.Errorf("image is too large: %dx%d", config.Width, config.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", 0, 0, err
+	}
+	bounds := src.Bounds()
+	width, height = bounds.Dx(), bounds.Dy()
+
+	tw, th := fit(width, height, ThumbnailMaxSize)
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	switch contentType {
+	case "image/png", "image/gif":
+		err = png.Encode(&buf, dst)
+		thumbType = "image/png"
+	default:
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+		thumbType = "image/jpeg"
+	}
+	if err != nil {
+		return nil, "", 0, 0, err
+	}
+	return buf.Bytes(), thumbType, width, height, nil
+}
+
+// fit は縦横比を保ったまま長辺がmaxSize以下になるサイズを返します
+func fit(width int, height int, maxSize int) (int, int) {
+	if width <= maxSize && height <= maxSize {
+		return max(width, 1), max(height, 1)
+	}
+	if width >= height {
+		return maxSize, max(height*maxSize/width, 1)
+	}
+	return max(width*maxSize/height, 1), maxSize
+}
@@ -0,0 +1,85 @@
+// Package isbn はISBN-10・ISBN-13のチェックディジットの検証と、ハイフンを除いたISBN-13への正規化を行います
+package isbn
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalid はISBNの桁数・文字・チェックディジットが不正な場合のエラー
+var ErrInvalid = errors.New("invalid ISBN")
+
+// Normalize はISBN-10またはISBN-13を、ハイフンと空白を除いたISBN-13にします
+// ISBN-10は978を付けてチェックディジットを計算し直す
+func Normalize(value string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		switch r {
+		case '-', ' ', '‐', '‑', '‒', '–', '−':
+			return -1
+		case 'x':
+			return 'X'
+		}
+		return r
+	}, strings.TrimSpace(value))
+
+	switch len(digits) {
+	case 10:
+		if !validISBN10(digits) {
+			return "", ErrInvalid
+		}
+		isbn13 := "978" + digits[:9]
+		return isbn13 + string(checkDigit13(isbn13)), nil
+	case 13:
+		if !allDigits(digits) || checkDigit13(digits[:12]) != digits[12] {
+			return "", ErrInvalid
+		}
+		if !strings.HasPrefix(digits, "978") && !strings.HasPrefix(digits, "979") {
+			return "", ErrInvalid
+		}
+		return digits, nil
+	default:
+		return "", ErrInvalid
+	}
+}
+
+// validISBN10 はISBN-10のチェックディジット（末尾のXは10）を検証します
+func validISBN10(digits string) bool {
+	if !allDigits(digits[:9]) {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(digits[i]-'0') * (10 - i)
+	}
+	switch last := digits[9]; {
+	case last == 'X':
+		sum += 10
+	case '0' <= last && last <= '9':
+		sum += int(last - '0')
+	default:
+		return false
+	}
+	return sum%11 == 0
+}
+
+// checkDigit13 はISBN-13の先頭12桁からチェックディジットを計算します
+func checkDigit13(digits string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(digits[i]-'0') * weight
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
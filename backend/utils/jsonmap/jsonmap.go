@@ -0,0 +1,355 @@
+// Package jsonmap はJSONPath風のパス式と、パス式を埋め込む小さなテンプレートでJSONから値を取り出します
+//
+// パス式は次の要素を組み合わせて書く
+//
+//	$          応答全体（先頭のみ）
+//	@          テンプレートを適用している項目（先頭のみ。省略した場合も同じ）
+//	.name      オブジェクトのキー（先頭の"."は省略できる）
+//	["name"]   記号を含むキー
+//	[0], [-1]  配列の要素（負の数は末尾から数える）
+//	[*], .*    配列のすべての要素、またはオブジェクトのすべての値
+//
+// テンプレートは文字列に{{ パス式 }}を埋め込んで書く（例: "https://example.com/items/{{ id }}"）
+package jsonmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// segmentKind パス式の要素の種類
+type segmentKind int
+
+const (
+	segmentKey segmentKind = iota
+	segmentIndex
+	segmentWildcard
+)
+
+type segment struct {
+	kind  segmentKind
+	key   string
+	index int
+}
+
+// Path は解析済みのパス式です
+type Path struct {
+	expr     string
+	fromRoot bool
+	segments []segment
+}
+
+// TypeError はパス式で取り出した値の型が、使用する箇所で期待する型と異なる場合のエラー
+type TypeError struct {
+	Expr    string
+	Message string
+}
+
+func (e *TypeError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Expr, e.Message)
+}
+
+// Decode はJSONを数値の精度を保ったまま（json.Numberとして）デコードします
+func Decode(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// ParsePath はパス式を解析します
+func ParsePath(expr string) (Path, error) {
+	p := Path{expr: strings.TrimSpace(expr)}
+	rest := p.expr
+	if rest == "" {
+		return Path{}, errors.New("path is empty")
+	}
+	switch rest[0] {
+	case '$':
+		p.fromRoot = true
+		rest = rest[1:]
+	case '@':
+		rest = rest[1:]
+	case '.', '[':
+	default:
+		// 先頭のキーの"."は省略できる
+		rest = "." + rest
+	}
+
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			if strings.HasPrefix(rest, "*") {
+				p.segments = append(p.segments, segment{kind: segmentWildcard})
+				rest = rest[1:]
+				continue
+			}
+			n := 0
+			for n < len(rest) && isKeyChar(rest[n]) {
+				n++
+			}
+			if n == 0 {
+				return Path{}, fmt.Errorf("%s: key is expected after '.'", p.expr)
+			}
+			p.segments = append(p.segments, segment{kind: segmentKey, key: rest[:n]})
+			rest = rest[n:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return Path{}, fmt.Errorf("%s: missing ']'", p.expr)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			switch {
+			case inner == "*":
+				p.segments = append(p.segments, segment{kind: segmentWildcard})
+			case len(inner) >= 2 && (inner[0] == '"' || inner[0] == '\'') && inner[len(inner)-1] == inner[0]:
+				p.segments = append(p.segments, segment{kind: segmentKey, key: inner[1 : len(inner)-1]})
+			default:
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return Path{}, fmt.Errorf("%s: invalid index [%s]", p.expr, inner)
+				}
+				p.segments = append(p.segments, segment{kind: segmentIndex, index: index})
+			}
+		default:
+			return Path{}, fmt.Errorf("%s: unexpected character %q", p.expr, rest[0])
+		}
+	}
+	return p, nil
+}
+
+// isKeyChar は"."に続けて書けるキーの文字かを返します
+func isKeyChar(c byte) bool {
+	return c == '_' || c == '-' || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || c >= 0x80
+}
+
+// String はパス式の元の文字列を返します
+func (p Path) String() string {
+	return p.expr
+}
+
+// Select はパス式に一致する値を返します。一致する値がない場合は空のスライス
+// rootは応答全体、currentはテンプレートを適用している項目（"$"で始まらないパス式の起点）
+func (p Path) Select(root any, current any) []any {
+	values := []any{current}
+	if p.fromRoot {
+		values = []any{root}
+	}
+	for _, seg := range p.segments {
+		var next []any
+		for _, value := range values {
+			switch seg.kind {
+			case segmentKey:
+				if object, ok := value.(map[string]any); ok {
+					if child, ok := object[seg.key]; ok {
+						next = append(next, child)
+					}
+				}
+			case segmentIndex:
+				if array, ok := value.([]any); ok {
+					index := seg.index
+					if index < 0 {
+						index += len(array)
+					}
+					if 0 <= index && index < len(array) {
+						next = append(next, array[index])
+					}
+				}
+			case segmentWildcard:
+				switch v := value.(type) {
+				case []any:
+					next = append(next, v...)
+				case map[string]any:
+					// オブジェクトの値は順序を持たないため、キーの順に並べる
+					for _, key := range sortedKeys(v) {
+						next = append(next, v[key])
+					}
+				}
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+// Template はパス式を埋め込んだ解析済みのテンプレートです
+type Template struct {
+	parts []templatePart
+}
+
+// templatePart はテンプレートの固定の文字列、またはパス式の部分
+type templatePart struct {
+	literal string
+	path    *Path
+}
+
+// ParseTemplate はテンプレートを解析します
+func ParseTemplate(text string) (Template, error) {
+	var t Template
+	rest := text
+	for rest != "" {
+		start := strings.Index(rest, "{{")
+		if start < 0 {
+			t.parts = append(t.parts, templatePart{literal: rest})
+			break
+		}
+		if start > 0 {
+			t.parts = append(t.parts, templatePart{literal: rest[:start]})
+		}
+		end := strings.Index(rest[start:], "}}")
+		if end < 0 {
+			return Template{}, fmt.Errorf("missing '}}' in template %q", text)
+		}
+		path, err := ParsePath(rest[start+2 : start+end])
+		if err != nil {
+			return Template{}, err
+		}
+		t.parts = append(t.parts, templatePart{path: &path})
+		rest = rest[start+end+2:]
+	}
+	return t, nil
+}
+
+// IsEmpty はテンプレートが空かを返します
+func (t Template) IsEmpty() bool {
+	return len(t.parts) == 0
+}
+
+// IsSingleExpression はテンプレートが固定の文字列を含まない、1つのパス式のみかを返します
+func (t Template) IsSingleExpression() bool {
+	return len(t.parts) == 1 && t.parts[0].path != nil
+}
+
+// Render はパス式を値で置き換えた文字列を返します
+// パス式に一致する値がない場合とnullは空文字列に置き換え、値が複数ある場合やオブジェクト・配列の場合はTypeErrorを返す
+func (t Template) Render(root any, current any) (string, error) {
+	var b strings.Builder
+	for _, part := range t.parts {
+		if part.path == nil {
+			b.WriteString(part.literal)
+			continue
+		}
+		values := part.path.Select(root, current)
+		switch len(values) {
+		case 0:
+			continue
+		case 1:
+			s, err := scalarString(part.path.expr, values[0])
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(s)
+		default:
+			return "", &TypeError{Expr: part.path.expr, Message: fmt.Sprintf("expected a single value but got %d values", len(values))}
+		}
+	}
+	return b.String(), nil
+}
+
+// Strings はパス式に一致するすべての値を文字列のリストとして返します
+// 配列の値は要素に展開する。テンプレートが1つのパス式のみでない場合は描画した文字列を1件として返す
+func (t Template) Strings(root any, current any) ([]string, error) {
+	if !t.IsSingleExpression() {
+		s, err := t.Render(root, current)
+		if err != nil || s == "" {
+			return nil, err
+		}
+		return []string{s}, nil
+	}
+	path := t.parts[0].path
+	var result []string
+	for _, value := range path.Select(root, current) {
+		elements := []any{value}
+		if array, ok := value.([]any); ok {
+			elements = array
+		}
+		for _, element := range elements {
+			s, err := scalarString(path.expr, element)
+			if err != nil {
+				return nil, err
+			}
+			if s != "" {
+				result = append(result, s)
+			}
+		}
+	}
+	return result, nil
+}
+
+// Value はテンプレートが1つのパス式のみの場合に、一致した値をそのまま返します（日付を数値で受け取る場合など）
+// それ以外の場合は描画した文字列を返す
+func (t Template) Value(root any, current any) (any, error) {
+	if !t.IsSingleExpression() {
+		return t.Render(root, current)
+	}
+	path := t.parts[0].path
+	values := path.Select(root, current)
+	switch len(values) {
+	case 0:
+		return nil, nil
+	case 1:
+		if _, err := scalarString(path.expr, values[0]); err != nil {
+			return nil, err
+		}
+		return values[0], nil
+	default:
+		return nil, &TypeError{Expr: path.expr, Message: fmt.Sprintf("expected a single value but got %d values", len(values))}
+	}
+}
+
+// scalarString は文字列・数値・真偽値を文字列にします。nullは空文字列
+func scalarString(expr string, value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", &TypeError{Expr: expr, Message: fmt.Sprintf("expected a string, number or boolean but got %s", TypeName(value))}
+	}
+}
+
+// TypeName はJSONの値の型の名前を返します
+func TypeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case json.Number, float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,158 @@
+// Package layoutgrid はレイアウト上のコンポーネントの位置をグリッドに合わせ、重なりを検出・解消します
+package layoutgrid
+
+import (
+	"go-react-app/model"
+	"sort"
+)
+
+// Snap は位置情報をレイアウトのグリッドに合わせてコンポーネントに反映します
+// 幅と高さが0の場合は現在の値を維持する。グリッドを使わないレイアウト（Columnsが0）では指定された値をそのまま使う
+func Snap(settings model.GridSettings, component model.LayoutComponent, position model.PositionRequest) model.LayoutComponent {
+	x, y, w, h := position.X, position.Y, position.Width, position.Height
+	if settings.Columns > 0 && position.Unit == model.PositionUnitPixel {
+		cellWidth := float64(settings.CanvasWidth) / float64(settings.Columns)
+		x = round(float64(x) / cellWidth)
+		y = round(float64(y) / float64(settings.RowHeight))
+		w = round(float64(w) / cellWidth)
+		h = round(float64(h) / float64(settings.RowHeight))
+		// 1セルに満たないサイズは切り捨てずに1セルとして扱う
+		if position.Width > 0 {
+			w = max(w, 1)
+		}
+		if position.Height > 0 {
+			h = max(h, 1)
+		}
+	}
+	if w == 0 {
+		w = component.Width
+	}
+	if h == 0 {
+		h = component.Height
+	}
+
+	if settings.Columns > 0 {
+		w, h = clampSize(settings, component.Type, w, h)
+		// 右端からはみ出す場合はグリッドに収まるよう左に寄せる
+		x = min(x, settings.Columns-w)
+	}
+
+	component.X, component.Y, component.Width, component.Height = x, y, w, h
+	return component
+}
+
+// clampSize はサイズをコンポーネントの種類の上下限とグリッドの列数に収めます
+func clampSize(settings model.GridSettings, componentType string, w int, h int) (int, int) {
+	minW, minH, maxW, maxH := 1, 1, settings.Columns, 0
+	if definition, ok := model.FindComponentType(componentType); ok {
+		minW, minH = max(definition.MinWidth, 1), max(definition.MinHeight, 1)
+		if definition.MaxWidth > 0 {
+			maxW = min(definition.MaxWidth, settings.Columns)
+		}
+		maxH = definition.MaxHeight
+	}
+	w = min(max(w, minW), maxW)
+	h = max(h, minH)
+	if maxH > 0 {
+		h = min(h, maxH)
+	}
+	return w, h
+}
+
+// Overlaps は2つのコンポーネントが重なっているかを返します
+func Overlaps(a model.LayoutComponent, b model.LayoutComponent) bool {
+	return a.X < b.X+b.Width && b.X < a.X+a.Width &&
+		a.Y < b.Y+b.Height && b.Y < a.Y+a.Height
+}
+
+// Siblings は2つのコンポーネントが同じ親を持つ（または両方ともレイアウトの直下にある）かを返します
+// 子コンポーネントの位置は親を基準にするため、重なりは同じ親を持つコンポーネントの間でのみ判定する
+func Siblings(a model.LayoutComponent, b model.LayoutComponent) bool {
+	if a.ParentId == nil || b.ParentId == nil {
+		return a.ParentId == nil && b.ParentId == nil
+	}
+	return *a.ParentId == *b.ParentId
+}
+
+// Overlapping はcomponentと重なる、同じ親を持つコンポーネントを返します（component自身は除く）
+func Overlapping(component model.LayoutComponent, others []model.LayoutComponent) []model.LayoutComponent {
+	var overlapping []model.LayoutComponent
+	for _, other := range others {
+		if other.ID != component.ID && Siblings(component, other) && Overlaps(component, other) {
+			overlapping = append(overlapping, other)
+		}
+	}
+	return overlapping
+}
+
+// PushDown はcomponentを固定し、重なるコンポーネントを重ならなくなるまで下に移動します
+// 戻り値は位置が変わったコンポーネント
+func PushDown(component model.LayoutComponent, others []model.LayoutComponent) []model.LayoutComponent {
+	pending := make([]model.LayoutComponent, 0, len(others))
+	originalY := make(map[uint]int, len(others))
+	for _, other := range others {
+		if other.ID != component.ID {
+			pending = append(pending, other)
+			originalY[other.ID] = other.Y
+		}
+	}
+	SortByPosition(pending)
+
+	var moved []model.LayoutComponent
+	for _, item := range Settle(append([]model.LayoutComponent{component}, pending...))[1:] {
+		if item.Y != originalY[item.ID] {
+			moved = append(moved, item)
+		}
+	}
+	return moved
+}
+
+// Settle はコンポーネントを先頭から順に配置し、先に配置した同じ親を持つコンポーネントと重なる場合は重ならなくなるまで下に移動します
+// 押し出されたコンポーネントがさらに後のコンポーネントを押し出すため、上にあるものから順に渡す
+func Settle(components []model.LayoutComponent) []model.LayoutComponent {
+	placed := make([]model.LayoutComponent, 0, len(components))
+	for _, item := range components {
+		for {
+			blocker, found := firstOverlap(item, placed)
+			if !found {
+				break
+			}
+			item.Y = blocker.Y + max(blocker.Height, 1)
+		}
+		placed = append(placed, item)
+	}
+	return placed
+}
+
+// SortByPosition はコンポーネントを上から順（同じ行は左から順）に並べ替えます
+func SortByPosition(components []model.LayoutComponent) {
+	sort.SliceStable(components, func(i, j int) bool {
+		if components[i].Y != components[j].Y {
+			return components[i].Y < components[j].Y
+		}
+		return components[i].X < components[j].X
+	})
+}
+
+// FirstOverlap は互いに重なっている、同じ親を持つ最初のコンポーネントの組を返します
+func FirstOverlap(components []model.LayoutComponent) (model.LayoutComponent, model.LayoutComponent, bool) {
+	for i := range components {
+		if other, found := firstOverlap(components[i], components[i+1:]); found {
+			return components[i], other, true
+		}
+	}
+	return model.LayoutComponent{}, model.LayoutComponent{}, false
+}
+
+func firstOverlap(component model.LayoutComponent, placed []model.LayoutComponent) (model.LayoutComponent, bool) {
+	for _, other := range placed {
+		if Siblings(component, other) && Overlaps(component, other) {
+			return other, true
+		}
+	}
+	return model.LayoutComponent{}, false
+}
+
+func round(value float64) int {
+	return int(value + 0.5)
+}
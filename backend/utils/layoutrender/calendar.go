@@ -0,0 +1,43 @@
+package layoutrender
+
+import "time"
+
+// calendar はカレンダーコンポーネントに表示する1か月分の日付
+type calendar struct {
+	Month time.Time
+	Weeks [][]calendarDay
+}
+
+// calendarDay はカレンダーの1日。Dayが0の場合は前後の月の空欄
+type calendarDay struct {
+	Day         int
+	Highlighted bool
+}
+
+// newCalendar はmonthを含む月のカレンダーを日曜始まりで作成します
+func newCalendar(month time.Time, highlights []time.Time) calendar {
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	days := first.AddDate(0, 1, -1).Day()
+
+	highlighted := map[int]bool{}
+	for _, h := range highlights {
+		h = h.In(month.Location())
+		if h.Year() == first.Year() && h.Month() == first.Month() {
+			highlighted[h.Day()] = true
+		}
+	}
+
+	weeks := [][]calendarDay{}
+	week := make([]calendarDay, int(first.Weekday()), 7)
+	for day := 1; day <= days; day++ {
+		week = append(week, calendarDay{Day: day, Highlighted: highlighted[day]})
+		if len(week) == 7 {
+			weeks = append(weeks, week)
+			week = make([]calendarDay, 0, 7)
+		}
+	}
+	if len(week) > 0 {
+		weeks = append(weeks, append(week, make([]calendarDay, 7-len(week))...))
+	}
+	return calendar{Month: first, Weeks: weeks}
+}
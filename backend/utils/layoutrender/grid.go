@@ -0,0 +1,178 @@
+package layoutrender
+
+import (
+	"fmt"
+	"go-react-app/model"
+	"html/template"
+	"sort"
+)
+
+// placement はグリッド上のコンポーネントの位置（列・行は1始まり）
+type placement struct {
+	Component model.LayoutComponent
+	Index     int // layout.Components内の位置
+	Col       int
+	Span      int
+	Row       int
+	Rows      int
+	Order     int
+	Z         int // 重なり順（1始まり）。入れ子や重なり順のないレイアウトでは0
+}
+
+func (p placement) style() template.CSS {
+	style := fmt.Sprintf("--lc-col:%d;--lc-span:%d;--lc-row:%d;--lc-rows:%d;--lc-order:%d",
+		p.Col, p.Span, p.Row, p.Rows, p.Order)
+	if p.Z > 0 {
+		style += fmt.Sprintf(";--lc-z:%d", p.Z)
+	}
+	return template.CSS(style)
+}
+
+// breakpointStyle はブレークポイントでの位置を、そのブレークポイント用のCSS変数として返します
+func (p placement) breakpointStyle(breakpoint string) string {
+	return fmt.Sprintf(";--lc-%[1]s-col:%[2]d;--lc-%[1]s-span:%[3]d;--lc-%[1]s-row:%[4]d;--lc-%[1]s-rows:%[5]d;--lc-%[1]s-order:%[6]d",
+		breakpoint, p.Col, p.Span, p.Row, p.Rows, p.Order)
+}
+
+// responsiveStyles はタブレット・モバイルでの位置を、コンポーネントごと（layout.Componentsと同じ順）のCSS変数として返します
+func responsiveStyles(layout model.Layout) []string {
+	styles := make([]string, len(layout.Components))
+	for _, breakpoint := range model.Breakpoints[1:] {
+		resolved := layout.AtBreakpoint(breakpoint)
+		for _, placed := range newGrid(resolved).arrange(resolved.Components) {
+			styles[placed.Index] += placed.breakpointStyle(breakpoint)
+		}
+	}
+	return styles
+}
+
+// grid はページを描画するグリッド
+// レイアウトにグリッドが設定されていない場合はレイアウトエディタの既定の列数を使う
+type grid struct {
+	model.GridSettings
+	// free はグリッドが設定されていないレイアウトか（位置がピクセルで保存されている可能性がある）
+	free bool
+}
+
+func newGrid(layout model.Layout) grid {
+	g := grid{GridSettings: layout.GridSettings()}
+	if g.Columns == 0 {
+		g.Columns = model.DefaultGridColumns
+		g.free = true
+	}
+	return g
+}
+
+// style はグリッドのコンテナに設定するスタイルを返します
+func (g grid) style() template.CSS {
+	return template.CSS(fmt.Sprintf("--lc-columns:%d;--lc-row-height:%dpx;--lc-canvas-width:%dpx", g.Columns, g.RowHeight, g.CanvasWidth))
+}
+
+// fullWidthStyle はすべてのコンポーネントの下に全幅で配置するスタイルを返します
+func (g grid) fullWidthStyle(order int) template.CSS {
+	return template.CSS(fmt.Sprintf("--lc-col:1;--lc-span:%d;--lc-row:auto;--lc-rows:1;--lc-order:%d", g.Columns, order))
+}
+
+// arrange はコンポーネントの位置をグリッドの列・行に変換し、上から順（同じ行は左から順、同じ位置は奥から順）に並べます
+// 子コンポーネントは親からの相対位置をレイアウト上の位置に変換して配置する
+func (g grid) arrange(components []model.LayoutComponent) []placement {
+	tree := model.Layout{Components: components}
+	stack := stacking(components)
+	placements := make([]placement, len(components))
+	for i, component := range components {
+		positioned := component
+		origin := tree.Origin(component.ParentId, model.BreakpointDesktop)
+		positioned.X += origin.X
+		positioned.Y += origin.Y
+		placements[i] = g.place(positioned)
+		placements[i].Component = component
+		placements[i].Index = i
+		placements[i].Z = stack[i]
+	}
+	sort.SliceStable(placements, func(i, j int) bool {
+		if placements[i].Row != placements[j].Row {
+			return placements[i].Row < placements[j].Row
+		}
+		if placements[i].Col != placements[j].Col {
+			return placements[i].Col < placements[j].Col
+		}
+		return placements[i].Z < placements[j].Z
+	})
+	for i := range placements {
+		placements[i].Order = i
+	}
+	return placements
+}
+
+// stacking はコンポーネントの重なり順を、componentsと同じ順で返します
+// 親は子より奥にし、同じ親を持つコンポーネントはZIndexの小さい順に奥から重ねる
+// 入れ子も重なり順も設定されていない場合はすべて0を返す
+func stacking(components []model.LayoutComponent) []int {
+	stack := make([]int, len(components))
+	positions := make(map[uint]int, len(components))
+	layered := false
+	for i, component := range components {
+		positions[component.ID] = i
+		layered = layered || component.ParentId != nil || component.ZIndex != 0
+	}
+	if !layered {
+		return stack
+	}
+
+	children := make(map[int][]int)
+	var roots []int
+	for i, component := range components {
+		if component.ParentId != nil {
+			if parent, ok := positions[*component.ParentId]; ok && parent != i {
+				children[parent] = append(children[parent], i)
+				continue
+			}
+		}
+		roots = append(roots, i)
+	}
+	z := 0
+	var visit func(nodes []int)
+	visit = func(nodes []int) {
+		sort.SliceStable(nodes, func(i, j int) bool { return components[nodes[i]].ZIndex < components[nodes[j]].ZIndex })
+		for _, i := range nodes {
+			if stack[i] != 0 {
+				continue
+			}
+			z++
+			stack[i] = z
+			visit(children[i])
+		}
+	}
+	visit(roots)
+	// 親子関係が循環していてたどれなかったコンポーネントは最も手前に重ねる
+	for i := range stack {
+		if stack[i] == 0 {
+			z++
+			stack[i] = z
+		}
+	}
+	return stack
+}
+
+// place はコンポーネントの位置をグリッドの列・行に変換します
+// レイアウトエディタはグリッドの単位で保存するが、グリッドが設定されていないレイアウトで
+// グリッドに収まらない値はキャンバス上のピクセルとみなして変換する
+func (g grid) place(component model.LayoutComponent) placement {
+	x, y, w, h := max(component.X, 0), max(component.Y, 0), component.Width, component.Height
+	if w <= 0 {
+		w = 2
+	}
+	if h <= 0 {
+		h = 2
+	}
+	if g.free && x+w > g.Columns {
+		x = x * g.Columns / g.CanvasWidth
+		w = (w*g.Columns + g.CanvasWidth/2) / g.CanvasWidth
+		y = y / g.RowHeight
+		h = (h + g.RowHeight - 1) / g.RowHeight
+	}
+
+	col := min(x, g.Columns-1)
+	span := min(max(w, 1), g.Columns-col)
+	return placement{Component: component, Col: col + 1, Span: span, Row: y + 1, Rows: max(h, 1)}
+}
@@ -0,0 +1,197 @@
+package layoutrender
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/utils/markdown"
+	"html/template"
+	"strings"
+	"time"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// MainComponentTypes はページ本文を差し込むコンポーネントの種類
+// レイアウトエディタでは記事カードの種類を"main"として保存している
+var MainComponentTypes = map[string]bool{"main": true, "article-card": true}
+
+// componentTemplates はコンポーネントの種類と描画に使うテンプレートの対応
+// 登録されていない種類は内容のHTMLをそのまま表示する汎用のテンプレートで描画する
+var componentTemplates = map[string]string{
+	"header":       "header.html",
+	"sidebar":      "sidebar.html",
+	"footer":       "footer.html",
+	"main":         "article-card.html",
+	"article-card": "article-card.html",
+	"calendar":     "calendar.html",
+
+	"latest-articles": "data-list.html",
+	"feed":            "data-list.html",
+	"qiita-trending":  "data-list.html",
+	"bookshelf":       "data-list.html",
+	"external-api":    "data-list.html",
+}
+
+// Page はレイアウトで描画する1ページ分の内容
+type Page struct {
+	SiteTitle    string
+	Title        string
+	Description  string
+	CanonicalURL string
+	OGImage      string
+	NoIndex      bool
+	Root         string                 // サイトのルートへの相対パス（静的サイトの書き出し時に使用）
+	Body         template.HTML          // 本文（サニタイズ済みのHTML）。空の場合は記事カードの一覧を表示する
+	Cards        []Card                 // 記事カード・サイドバーに表示する記事
+	Month        time.Time              // カレンダーに表示する月（ゼロ値の場合は現在の月）
+	Highlights   []time.Time            // カレンダーで強調表示する日付
+	InlineStyle  bool                   // trueの場合はスタイルシートを<style>として埋め込む（プレビュー用）
+	Feeds        bool                   // trueの場合はフィードへのリンクを出力する
+	Breakpoint   string                 // 指定した場合はそのブレークポイントでの配置で固定して描画する（プレビュー用）。空の場合は画面幅に応じて配置を変える
+	Data         map[uint]ComponentData // データを取得するコンポーネントに表示する項目（コンポーネントのIDごと）
+}
+
+// ComponentData はデータを取得するコンポーネントに表示する項目
+type ComponentData struct {
+	Cards  []Card
+	Failed bool // 取得に失敗した場合はtrue
+}
+
+// Card は記事カードに表示する記事
+type Card struct {
+	Title    string
+	URL      string
+	Excerpt  string
+	ImageURL string
+	Date     time.Time
+	Tags     []string
+}
+
+type IRenderer interface {
+	// RenderPage はレイアウトにページの内容を当てはめたHTML文書を返します
+	RenderPage(layout model.Layout, page Page) ([]byte, error)
+	// StyleSheet はRenderPageで出力したHTMLに適用するスタイルシートを返します
+	StyleSheet() string
+}
+
+type renderer struct {
+	templates *template.Template
+	style     string
+}
+
+// NewRenderer はコンポーネントの種類ごとのテンプレートでレイアウトを描画するレンダラーを作成します
+func NewRenderer() IRenderer {
+	style, err := templateFS.ReadFile("templates/style.css")
+	if err != nil {
+		panic(err)
+	}
+	return &renderer{
+		templates: template.Must(template.New("").ParseFS(templateFS, "templates/*.html")),
+		style:     string(style),
+	}
+}
+
+func (r *renderer) StyleSheet() string {
+	return r.style
+}
+
+// componentData はコンポーネントのテンプレートに渡す値
+type componentData struct {
+	Component model.LayoutComponent
+	Class     string
+	Content   template.HTML
+	Style     template.CSS
+	Main      bool
+	Page      *Page
+	Calendar  calendar
+	Data      ComponentData
+}
+
+func (r *renderer) RenderPage(layout model.Layout, page Page) ([]byte, error) {
+	if page.Month.IsZero() {
+		page.Month = time.Now()
+	}
+	cal := newCalendar(page.Month, page.Highlights)
+
+	// ブレークポイントを指定した場合はその配置で固定し、指定しない場合はタブレット・モバイルの配置が
+	// 設定されていればCSS変数とメディアクエリで切り替え、設定されていなければ狭い画面で1カラムにする
+	class := "layout-grid"
+	var responsive []string
+	if page.Breakpoint != "" {
+		layout = layout.AtBreakpoint(page.Breakpoint)
+		class += " lc-fixed"
+	} else if layout.HasBreakpointOverrides() {
+		responsive = responsiveStyles(layout)
+		class += " lc-responsive"
+	}
+
+	g := newGrid(layout)
+	var body bytes.Buffer
+	fmt.Fprintf(&body, `<div class="%s" style="%s">`, class, template.HTMLEscapeString(string(g.style())))
+	hasMain := false
+	for _, placed := range g.arrange(layout.Components) {
+		component := placed.Component
+		style := placed.style()
+		if responsive != nil {
+			style += template.CSS(responsive[placed.Index])
+		}
+		data := componentData{
+			Component: component,
+			Class:     classToken(component.Type),
+			Content:   template.HTML(strings.TrimSpace(markdown.SanitizeHTML(component.Content))),
+			Style:     style,
+			Page:      &page,
+			Calendar:  cal,
+			Data:      page.Data[component.ID],
+		}
+		// 本文は最初の記事カードに差し込み、2つ目以降は記事カードの一覧として表示する
+		if MainComponentTypes[component.Type] && !hasMain && page.Body != "" {
+			hasMain = true
+			data.Main = true
+		}
+
+		name, ok := componentTemplates[component.Type]
+		if !ok {
+			name = "default.html"
+		}
+		if err := r.templates.ExecuteTemplate(&body, name, data); err != nil {
+			return nil, fmt.Errorf("failed to render component %d: %w", component.ID, err)
+		}
+		body.WriteString("\n")
+	}
+	// 本文を表示する場所がレイアウトにない場合は末尾に追加する
+	if !hasMain && page.Body != "" {
+		data := componentData{Main: true, Page: &page, Style: g.fullWidthStyle(len(layout.Components))}
+		if err := r.templates.ExecuteTemplate(&body, "article-card.html", data); err != nil {
+			return nil, err
+		}
+	}
+	body.WriteString(`</div>`)
+
+	var doc bytes.Buffer
+	err := r.templates.ExecuteTemplate(&doc, "document.html", map[string]interface{}{
+		"Page":  page,
+		"Body":  template.HTML(body.String()),
+		"Style": template.CSS(r.style + "\n" + markdown.StyleSheet()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render page: %w", err)
+	}
+	return doc.Bytes(), nil
+}
+
+// classToken はコンポーネントの種類をCSSのクラス名に使える文字列に変換します
+func classToken(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		}
+		return '-'
+	}, s)
+}
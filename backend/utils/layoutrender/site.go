@@ -0,0 +1,46 @@
+package layoutrender
+
+import (
+	"go-react-app/model"
+	"go-react-app/utils/staticsite"
+	"time"
+)
+
+// siteRenderer は静的サイトの書き出しでレイアウトを使うためのPageRenderer
+type siteRenderer struct {
+	r IRenderer
+}
+
+// NewSiteRenderer はrendererを静的サイトの書き出しで使うPageRendererを作成します
+func NewSiteRenderer(r IRenderer) staticsite.PageRenderer {
+	return &siteRenderer{r}
+}
+
+// Name はテンプレートを変更した際にインクリメンタルビルドで全ページを生成し直すため、版を含めます
+func (sr *siteRenderer) Name() string {
+	return "layout-1"
+}
+
+func (sr *siteRenderer) RenderPage(layout model.Layout, page staticsite.Page) ([]byte, error) {
+	month := time.Now()
+	if len(page.Dates) > 0 {
+		month = page.Dates[0]
+	}
+	return sr.r.RenderPage(layout, Page{
+		SiteTitle:    page.SiteTitle,
+		Title:        page.Title,
+		Description:  page.Description,
+		CanonicalURL: page.CanonicalURL,
+		OGImage:      page.OGImage,
+		NoIndex:      page.NoIndex,
+		Root:         page.Root,
+		Body:         page.Body,
+		Month:        month,
+		Highlights:   page.Dates,
+		Feeds:        true,
+	})
+}
+
+func (sr *siteRenderer) StyleSheet(layout model.Layout) string {
+	return sr.r.StyleSheet()
+}
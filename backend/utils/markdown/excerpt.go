@@ -0,0 +1,30 @@
+package markdown
+
+import (
+	"go-react-app/utils/search"
+	"regexp"
+	"strings"
+)
+
+// DefaultExcerptLength は抜粋の既定の最大文字数
+const DefaultExcerptLength = 160
+
+var (
+	headingAnchorPattern = regexp.MustCompile(`<a[^>]*class="heading-anchor"[^>]*>#</a>`)
+	blockBoundaryPattern = regexp.MustCompile(`(?i)</?(p|h[1-6]|li|ul|ol|pre|blockquote|div|table|tr|td|th|br|hr)\b[^>]*>`)
+	inlineTagPattern     = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Excerpt はレンダリング済みのHTMLからタグを除いた先頭maxRunes文字を返します
+// ブロック要素の境界は空白に置き換え、インライン要素のタグは単に取り除きます
+func Excerpt(html string, maxRunes int) string {
+	html = headingAnchorPattern.ReplaceAllString(html, "")
+	html = blockBoundaryPattern.ReplaceAllString(html, " ")
+	html = inlineTagPattern.ReplaceAllString(html, "")
+
+	runes := []rune(search.PlainText(html))
+	if len(runes) <= maxRunes {
+		return string(runes)
+	}
+	return strings.TrimSpace(string(runes[:maxRunes])) + "…"
+}
@@ -0,0 +1,80 @@
+package markdown
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// headingIDs は日本語の見出しでも読みやすいIDを生成します
+// 英数字・かな・漢字はそのまま残し、空白はハイフンに置き換えます
+type headingIDs struct {
+	used map[string]bool
+}
+
+func newHeadingIDs() parser.IDs {
+	return &headingIDs{used: map[string]bool{}}
+}
+
+func (h *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	var b strings.Builder
+	lastHyphen := false
+	for _, r := range strings.ToLower(string(value)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			b.WriteRune(r)
+			lastHyphen = false
+		case (unicode.IsSpace(r) || r == '-') && !lastHyphen && b.Len() > 0:
+			b.WriteRune('-')
+			lastHyphen = true
+		}
+	}
+
+	id := strings.TrimSuffix(b.String(), "-")
+	if id == "" {
+		id = "heading"
+	}
+
+	unique := id
+	for i := 1; h.used[unique]; i++ {
+		unique = id + "-" + strconv.Itoa(i)
+	}
+	h.used[unique] = true
+	return []byte(unique)
+}
+
+func (h *headingIDs) Put(value []byte) {
+	h.used[string(value)] = true
+}
+
+// headingAnchorTransformer は見出しの末尾に自身へのリンク（アンカー）を追加します
+func headingAnchorTransformer() util.PrioritizedValue {
+	return util.Prioritized(anchorTransformer{}, 999)
+}
+
+type anchorTransformer struct{}
+
+func (anchorTransformer) Transform(node *ast.Document, reader text.Reader, pc parser.Context) {
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		id, ok := heading.AttributeString("id")
+		if !ok {
+			return ast.WalkSkipChildren, nil
+		}
+
+		link := ast.NewLink()
+		link.Destination = append([]byte("#"), id.([]byte)...)
+		link.SetAttributeString("class", []byte("heading-anchor"))
+		link.AppendChild(link, ast.NewString([]byte("#")))
+		heading.AppendChild(heading, link)
+		return ast.WalkSkipChildren, nil
+	})
+}
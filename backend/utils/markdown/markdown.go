@@ -0,0 +1,94 @@
+package markdown
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+)
+
+const (
+	// DefaultCacheSize はレンダリング結果をキャッシュする既定の件数
+	DefaultCacheSize = 512
+
+	// highlightStyle はシンタックスハイライトのスタイル名
+	highlightStyle = "github"
+)
+
+// IRenderer はMarkdownをサニタイズ済みのHTMLに変換します
+type IRenderer interface {
+	Render(content string) (string, error)
+}
+
+type renderer struct {
+	md    goldmark.Markdown
+	cache *lruCache
+}
+
+// NewRenderer はCommonMark + GFM（テーブル・タスクリスト・脚注）、見出しアンカー、
+// シンタックスハイライトに対応したレンダラーを作成します
+// レンダリング結果はコンテンツのハッシュをキーとしてcacheSize件までキャッシュされます
+func NewRenderer(cacheSize int) IRenderer {
+	md := goldmark.New(
+		goldmark.WithExtensions(
+			extension.GFM,
+			extension.Footnote,
+			highlighting.NewHighlighting(
+				highlighting.WithStyle(highlightStyle),
+				highlighting.WithFormatOptions(html.WithClasses(true)),
+			),
+		),
+		goldmark.WithParserOptions(
+			parser.WithAutoHeadingID(),
+			parser.WithASTTransformers(headingAnchorTransformer()),
+		),
+		goldmark.WithRendererOptions(
+			// 生のHTMLも一旦出力し、後段のサニタイザーで安全な要素だけを残す
+			gmhtml.WithUnsafe(),
+		),
+	)
+	return &renderer{md: md, cache: newLRUCache(cacheSize)}
+}
+
+func (r *renderer) Render(content string) (string, error) {
+	key := ContentHash(content)
+	if html, ok := r.cache.get(key); ok {
+		return html, nil
+	}
+
+	var buf bytes.Buffer
+	if err := r.md.Convert([]byte(content), &buf, parser.WithContext(newParserContext())); err != nil {
+		return "", err
+	}
+
+	html := sanitize(buf.String())
+	r.cache.put(key, html)
+	return html, nil
+}
+
+// ContentHash はコンテンツのSHA-256ハッシュ（16進数）を返します
+func ContentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func newParserContext() parser.Context {
+	return parser.NewContext(parser.WithIDs(newHeadingIDs()))
+}
+
+// StyleSheet はシンタックスハイライト用のCSSを返します
+func StyleSheet() string {
+	var buf bytes.Buffer
+	formatter := html.New(html.WithClasses(true))
+	if err := formatter.WriteCSS(&buf, styles.Get(highlightStyle)); err != nil {
+		return ""
+	}
+	return buf.String()
+}
@@ -0,0 +1,41 @@
+package markdown
+
+import (
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+var (
+	classPattern = regexp.MustCompile(`^[a-zA-Z0-9_\- ]+$`)
+	idPattern    = regexp.MustCompile(`^[\p{L}\p{N}_\-:]+$`)
+	policy       = newPolicy()
+)
+
+// newPolicy はユーザー生成コンテンツ向けの厳格なサニタイズポリシーを作成します
+// スクリプト・スタイル・イベントハンドラーはすべて除去し、
+// 見出しアンカー・脚注・タスクリスト・シンタックスハイライトに必要な属性のみ許可します
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+
+	p.AllowAttrs("id").Matching(idPattern).OnElements("h1", "h2", "h3", "h4", "h5", "h6", "li", "sup")
+	p.AllowAttrs("class").Matching(classPattern).OnElements("a", "div", "pre", "code", "span")
+	p.AllowAttrs("role").Matching(regexp.MustCompile(`^doc-[a-z]+$`)).OnElements("a", "div")
+
+	// タスクリストのチェックボックス（無効化されたもののみ）
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").Matching(regexp.MustCompile(`^$`)).OnElements("input")
+	return p
+}
+
+func sanitize(html string) string {
+	return policy.Sanitize(html)
+}
+
+// SanitizeHTML はユーザーが入力したHTML（レイアウトコンポーネントの内容など）を
+// Markdownのレンダリング結果と同じポリシーでサニタイズします
+func SanitizeHTML(html string) string {
+	return sanitize(html)
+}
@@ -0,0 +1,146 @@
+// Package netguard はユーザーが指定したURLへサーバーからリクエストする際に、
+// 内部ネットワークへの接続（SSRF）を防ぐHTTPクライアントを提供します
+package netguard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrBlockedAddress は接続先が内部ネットワークなどの許可されていないアドレスの場合のエラー
+var ErrBlockedAddress = errors.New("destination address is not allowed")
+
+// maxRedirects リダイレクトをたどる最大回数
+const maxRedirects = 5
+
+// redirectSafeHeaders 別のホストへのリダイレクトでも引き継ぐヘッダー
+var redirectSafeHeaders = map[string]bool{
+	"Accept":          true,
+	"Accept-Encoding": true,
+	"Content-Type":    true,
+	"User-Agent":      true,
+}
+
+// blockedPrefixes 接続を許可しないアドレスの範囲（プライベート・ループバック・リンクローカル・予約済みなど）
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("127.0.0.0/8"),
+	netip.MustParsePrefix("169.254.0.0/16"), // クラウドのメタデータサーバーを含む
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("224.0.0.0/4"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("::/128"),
+	netip.MustParsePrefix("::1/128"),
+	netip.MustParsePrefix("64:ff9b::/96"), // IPv4に変換されるNAT64のアドレス
+	netip.MustParsePrefix("fc00::/7"),
+	netip.MustParsePrefix("fe80::/10"),
+	netip.MustParsePrefix("ff00::/8"),
+}
+
+// Guard は接続先のアドレスを検証します
+// AllowPrivateはテストなどでローカルのサーバーに接続する場合のみ有効にする
+type Guard struct {
+	AllowPrivate bool
+}
+
+// IsBlocked はアドレスが接続を許可しない範囲に含まれるかを返します
+func IsBlocked(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckURL はURLのスキームとホストを検証します
+// ホスト名の場合は名前解決の結果を接続時に検証するため、ここではIPアドレスとlocalhostのみを判定する
+func (g Guard) CheckURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: unsupported scheme %q", ErrBlockedAddress, u.Scheme)
+	}
+	host := u.Hostname()
+	if host == "" {
+		return fmt.Errorf("%w: host is empty", ErrBlockedAddress)
+	}
+	if g.AllowPrivate {
+		return nil
+	}
+	if lower := strings.ToLower(strings.TrimSuffix(host, ".")); lower == "localhost" || strings.HasSuffix(lower, ".localhost") {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && IsBlocked(addr) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+	}
+	return nil
+}
+
+// Client は接続直前に名前解決後のアドレスを検証するHTTPクライアントを返します
+// 検証を接続時に行うため、DNSの応答を途中で変える攻撃（DNSリバインディング）やリダイレクトでも内部ネットワークに接続しない
+// タイムアウトはリクエストごとにcontextで指定する
+func (g Guard) Client() *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   g.control,
+	}
+	transport := &http.Transport{
+		// 環境変数のプロキシを経由すると接続先の検証が意味をなさないため使わない
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          20,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			if req.URL.Host != via[0].URL.Host {
+				// APIキーなどの独自ヘッダーの認証情報を別のホストに送らない（Authorizationは標準ライブラリが取り除く）
+				for name := range req.Header {
+					if !redirectSafeHeaders[name] {
+						req.Header.Del(name)
+					}
+				}
+			}
+			return g.CheckURL(req.URL)
+		},
+	}
+}
+
+// control は接続するアドレス（名前解決後のIPアドレスとポート）を検証します
+func (g Guard) control(network string, address string, _ syscall.RawConn) error {
+	if g.AllowPrivate {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, address)
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || IsBlocked(addr) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package search
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+const (
+	// SnippetRadius はスニペットとしてマッチ箇所の前後に含める文字数
+	SnippetRadius = 60
+
+	highlightOpen  = "<mark>"
+	highlightClose = "</mark>"
+)
+
+var (
+	htmlTagPattern    = regexp.MustCompile(`<[^>]*>`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// PlainText はHTMLタグを除去し、空白を正規化したテキストを返します
+func PlainText(text string) string {
+	text = htmlTagPattern.ReplaceAllString(text, " ")
+	text = html.UnescapeString(text)
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(text, " "))
+}
+
+// Highlight はテキスト中の検索語を<mark>で囲みます（テキストはHTMLエスケープされます）
+func Highlight(text string, terms []string) string {
+	runes := []rune(text)
+	marks := matchRanges(runes, terms)
+
+	var b strings.Builder
+	pos := 0
+	for _, m := range marks {
+		b.WriteString(html.EscapeString(string(runes[pos:m[0]])))
+		b.WriteString(highlightOpen)
+		b.WriteString(html.EscapeString(string(runes[m[0]:m[1]])))
+		b.WriteString(highlightClose)
+		pos = m[1]
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:])))
+	return b.String()
+}
+
+// Snippet は最初にマッチした箇所の周辺を切り出し、検索語をハイライトしたスニペットを返します
+func Snippet(text string, terms []string) string {
+	runes := []rune(PlainText(text))
+	marks := matchRanges(runes, terms)
+
+	start := 0
+	if len(marks) > 0 {
+		start = marks[0][0] - SnippetRadius
+		if start < 0 {
+			start = 0
+		}
+	}
+	end := start + SnippetRadius*2
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	snippet := Highlight(string(runes[start:end]), terms)
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// Score はドキュメントの各フィールドに含まれる検索語の出現回数から関連度を計算します
+// タイトルへのマッチはタグ・本文よりも高く評価します
+func Score(title, tags, body string, terms []string) float64 {
+	titleHits := countMatches(title, terms)
+	tagHits := countMatches(tags, terms)
+	bodyHits := countMatches(body, terms)
+
+	score := float64(titleHits)*3 + float64(tagHits)*2 + float64(bodyHits)
+	// 長い本文ほど偶然マッチしやすいため、本文長で緩やかに正規化する
+	length := len([]rune(body))
+	if length > 0 {
+		score = score / (1 + float64(length)/2000)
+	}
+	return score
+}
+
+func countMatches(text string, terms []string) int {
+	return len(matchRanges([]rune(text), terms))
+}
+
+// matchRanges は検索語にマッチする範囲（rune単位、重複なし、昇順）を返します
+func matchRanges(runes []rune, terms []string) [][2]int {
+	lower := []rune(strings.ToLower(string(runes)))
+	if len(lower) != len(runes) {
+		// 小文字化で文字数が変わる場合は元の文字列で比較する
+		lower = runes
+	}
+
+	var ranges [][2]int
+	for i := 0; i < len(lower); {
+		matched := 0
+		for _, term := range terms {
+			t := []rune(strings.ToLower(term))
+			if len(t) == 0 || len(t) <= matched || i+len(t) > len(lower) {
+				continue
+			}
+			if string(lower[i:i+len(t)]) == string(t) {
+				matched = len(t)
+			}
+		}
+		if matched > 0 {
+			ranges = append(ranges, [2]int{i, i + matched})
+			i += matched
+			continue
+		}
+		i++
+	}
+	return ranges
+}
@@ -0,0 +1,143 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenize はテキストを検索インデックス用のトークンに分割します
+// 漢字・ひらがな・カタカナの連続はバイグラムに分割し、1文字での前方一致検索が
+// できるよう末尾の1文字もユニグラムとして加えます
+// それ以外の英数字の連続は小文字化した単語として扱います
+func Tokenize(text string) []string {
+	var tokens []string
+	for _, run := range splitRuns(text) {
+		tokens = append(tokens, run.tokens()...)
+		if run.cjk && len(run.runes) > 1 {
+			tokens = append(tokens, string(run.runes[len(run.runes)-1]))
+		}
+	}
+	return tokens
+}
+
+// TokenString は全文検索インデックスに保存するためのスペース区切りトークン列を返します
+func TokenString(text string) string {
+	return strings.Join(Tokenize(text), " ")
+}
+
+// Phrase は検索クエリ中の連続した文字列1つ分のトークン列です
+// Prefix が true の場合は唯一のトークンを前方一致で検索します
+type Phrase struct {
+	Tokens []string
+	Prefix bool
+}
+
+// QueryPhrases は検索クエリを連続した文字列ごとのフレーズに分割します
+// フレーズ同士はAND条件として扱います
+func QueryPhrases(query string) []Phrase {
+	var phrases []Phrase
+	for _, run := range splitRuns(query) {
+		tokens := run.tokens()
+		phrases = append(phrases, Phrase{
+			Tokens: tokens,
+			Prefix: !run.cjk || len(run.runes) == 1,
+		})
+	}
+	return phrases
+}
+
+// MatchExpression はSQLite FTS（FTS4/FTS5共通）のMATCH式を組み立てます
+func MatchExpression(phrases []Phrase) string {
+	parts := make([]string, 0, len(phrases))
+	for _, p := range phrases {
+		if p.Prefix {
+			parts = append(parts, p.Tokens[0]+"*")
+			continue
+		}
+		parts = append(parts, `"`+strings.Join(p.Tokens, " ")+`"`)
+	}
+	return strings.Join(parts, " ")
+}
+
+// TSQueryExpression はPostgreSQLのto_tsquery('simple', ...)に渡す式を組み立てます
+func TSQueryExpression(phrases []Phrase) string {
+	parts := make([]string, 0, len(phrases))
+	for _, p := range phrases {
+		if p.Prefix {
+			parts = append(parts, p.Tokens[0]+":*")
+			continue
+		}
+		parts = append(parts, "("+strings.Join(p.Tokens, " <-> ")+")")
+	}
+	return strings.Join(parts, " & ")
+}
+
+// QueryTerms はハイライト用に検索クエリの語（連続した文字列）を返します
+func QueryTerms(query string) []string {
+	var terms []string
+	for _, run := range splitRuns(query) {
+		terms = append(terms, string(run.runes))
+	}
+	return terms
+}
+
+type textRun struct {
+	runes []rune
+	cjk   bool
+}
+
+func (r textRun) tokens() []string {
+	if !r.cjk {
+		return []string{strings.ToLower(string(r.runes))}
+	}
+	if len(r.runes) == 1 {
+		return []string{string(r.runes)}
+	}
+	tokens := make([]string, 0, len(r.runes)-1)
+	for i := 0; i < len(r.runes)-1; i++ {
+		tokens = append(tokens, string(r.runes[i:i+2]))
+	}
+	return tokens
+}
+
+// splitRuns はテキストをCJK文字の連続とそれ以外の英数字の連続に分割します
+func splitRuns(text string) []textRun {
+	var runs []textRun
+	var current []rune
+	currentCJK := false
+
+	flush := func() {
+		if len(current) > 0 {
+			runs = append(runs, textRun{runes: current, cjk: currentCJK})
+			current = nil
+		}
+	}
+
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			if !currentCJK {
+				flush()
+			}
+			currentCJK = true
+			current = append(current, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if currentCJK {
+				flush()
+			}
+			currentCJK = false
+			current = append(current, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return runs
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		r == 'ー' || r == '々'
+}
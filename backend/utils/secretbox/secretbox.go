@@ -0,0 +1,167 @@
+// Package secretbox は秘密情報をエンベロープ暗号化（AES-GCM）で暗号化・復号します
+//
+// 秘密情報ごとにランダムなデータキーを作成して値を暗号化し、データキーをマスターキーで暗号化して保存する
+// マスターキーを入れ替える場合は、データキーのみ新しいマスターキーで暗号化し直せばよく、値を暗号化し直す必要はない
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// KeySize マスターキーとデータキーのバイト数（AES-256）
+const KeySize = 32
+
+var (
+	// ErrUnknownKey は暗号化に使ったマスターキーがキーリングにない場合のエラー
+	ErrUnknownKey = errors.New("master key is not in the keyring")
+	// ErrDecrypt は復号に失敗した（改ざんされた・別の秘密情報の値と入れ替えられた）場合のエラー
+	ErrDecrypt = errors.New("failed to decrypt secret")
+)
+
+// keyIdPattern マスターキーのIDに使える文字
+var keyIdPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+// Sealed は暗号化した秘密情報
+type Sealed struct {
+	KeyId      string // データキーの暗号化に使ったマスターキーのID
+	WrappedKey []byte // マスターキーで暗号化したデータキー（nonceを先頭に含む）
+	Ciphertext []byte // データキーで暗号化した値（nonceを先頭に含む）
+}
+
+// Keyring はマスターキーの一覧です
+// 新しい秘密情報は現在のマスターキーで暗号化し、それ以外のマスターキーは復号とローテーションにのみ使う
+type Keyring struct {
+	current string
+	keys    map[string][]byte
+}
+
+// ParseKeyring は"ID:base64で表したキー"をカンマで区切った設定からキーリングを作成します
+// 先頭のキーが現在のマスターキーになる。ローテーションする場合は新しいキーを先頭に追加する
+//
+//	v2:<32バイトのキーのbase64>,v1:<32バイトのキーのbase64>
+func ParseKeyring(spec string) (*Keyring, error) {
+	k := &Keyring{keys: map[string][]byte{}}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok || !keyIdPattern.MatchString(id) {
+			return nil, fmt.Errorf("invalid master key entry: each entry must be \"id:base64key\"")
+		}
+		if _, exists := k.keys[id]; exists {
+			return nil, fmt.Errorf("duplicate master key id: %s", id)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != KeySize {
+			return nil, fmt.Errorf("master key %s must be %d bytes encoded in base64", id, KeySize)
+		}
+		if k.current == "" {
+			k.current = id
+		}
+		k.keys[id] = key
+	}
+	if k.current == "" {
+		return nil, fmt.Errorf("no master key is configured")
+	}
+	return k, nil
+}
+
+// CurrentKeyId は新しい秘密情報の暗号化に使うマスターキーのIDを返します
+func (k *Keyring) CurrentKeyId() string {
+	return k.current
+}
+
+// Seal は値を新しいデータキーで暗号化し、データキーを現在のマスターキーで暗号化します
+// aadには秘密情報の持ち主と名前など、値と結び付ける情報を指定する（復号時に同じ値が必要）
+func (k *Keyring) Seal(plaintext []byte, aad []byte) (Sealed, error) {
+	dataKey := make([]byte, KeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return Sealed{}, err
+	}
+	ciphertext, err := seal(dataKey, plaintext, aad)
+	if err != nil {
+		return Sealed{}, err
+	}
+	wrappedKey, err := seal(k.keys[k.current], dataKey, []byte(k.current))
+	if err != nil {
+		return Sealed{}, err
+	}
+	return Sealed{KeyId: k.current, WrappedKey: wrappedKey, Ciphertext: ciphertext}, nil
+}
+
+// Open は暗号化した秘密情報を復号します
+func (k *Keyring) Open(sealed Sealed, aad []byte) ([]byte, error) {
+	dataKey, err := k.unwrap(sealed)
+	if err != nil {
+		return nil, err
+	}
+	return open(dataKey, sealed.Ciphertext, aad)
+}
+
+// Rewrap はデータキーを現在のマスターキーで暗号化し直します。値は暗号化し直さない
+func (k *Keyring) Rewrap(sealed Sealed) (Sealed, error) {
+	dataKey, err := k.unwrap(sealed)
+	if err != nil {
+		return Sealed{}, err
+	}
+	wrappedKey, err := seal(k.keys[k.current], dataKey, []byte(k.current))
+	if err != nil {
+		return Sealed{}, err
+	}
+	return Sealed{KeyId: k.current, WrappedKey: wrappedKey, Ciphertext: sealed.Ciphertext}, nil
+}
+
+// unwrap はマスターキーで暗号化したデータキーを復号します
+func (k *Keyring) unwrap(sealed Sealed) ([]byte, error) {
+	masterKey, ok := k.keys[sealed.KeyId]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, sealed.KeyId)
+	}
+	return open(masterKey, sealed.WrappedKey, []byte(sealed.KeyId))
+}
+
+// seal はAES-GCMで暗号化し、ランダムなnonceを先頭に付けて返します
+func seal(key []byte, plaintext []byte, aad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// open は先頭にnonceを付けたAES-GCMの暗号文を復号します
+func open(key []byte, data []byte, aad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], aad)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,95 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+const (
+	xmlns = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+	// MaxURLs は1つのサイトマップに含められるURLの上限（sitemaps.orgの仕様）
+	MaxURLs = 50000
+)
+
+// URL はサイトマップに含めるページ
+type URL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+// Sitemap はサイトマップインデックスに含めるサイトマップ
+type Sitemap struct {
+	Loc     string
+	LastMod time.Time
+}
+
+type urlSet struct {
+	XMLName xml.Name   `xml:"urlset"`
+	Xmlns   string     `xml:"xmlns,attr"`
+	URLs    []urlEntry `xml:"url"`
+}
+
+type urlEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name   `xml:"sitemapindex"`
+	Xmlns    string     `xml:"xmlns,attr"`
+	Sitemaps []urlEntry `xml:"sitemap"`
+}
+
+// EncodeURLSet はURLの一覧をsitemap.xml形式で出力します
+func EncodeURLSet(urls []URL) ([]byte, error) {
+	set := urlSet{Xmlns: xmlns, URLs: make([]urlEntry, 0, len(urls))}
+	for _, u := range urls {
+		set.URLs = append(set.URLs, urlEntry{Loc: u.Loc, LastMod: formatLastMod(u.LastMod)})
+	}
+	return marshal(set)
+}
+
+// EncodeIndex はサイトマップの一覧をサイトマップインデックス形式で出力します
+func EncodeIndex(sitemaps []Sitemap) ([]byte, error) {
+	index := sitemapIndex{Xmlns: xmlns, Sitemaps: make([]urlEntry, 0, len(sitemaps))}
+	for _, s := range sitemaps {
+		index.Sitemaps = append(index.Sitemaps, urlEntry{Loc: s.Loc, LastMod: formatLastMod(s.LastMod)})
+	}
+	return marshal(index)
+}
+
+func formatLastMod(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func marshal(v interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// LastModified はURLの一覧で最も新しい更新日時を返します
+func LastModified(urls []URL) (latest time.Time) {
+	for _, u := range urls {
+		if u.LastMod.After(latest) {
+			latest = u.LastMod
+		}
+	}
+	return latest
+}
+
+// IndexLastModified はサイトマップの一覧で最も新しい更新日時を返します
+func IndexLastModified(sitemaps []Sitemap) (latest time.Time) {
+	for _, s := range sitemaps {
+		if s.LastMod.After(latest) {
+			latest = s.LastMod
+		}
+	}
+	return latest
+}
@@ -0,0 +1,451 @@
+package staticsite
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/utils/feedgen"
+	"go-react-app/utils/markdown"
+	"go-react-app/utils/sitemap"
+	"go-react-app/utils/storage"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	articleBodyTemplate = template.Must(template.New("article").Parse(`<article>
+<h1>{{.Title}}</h1>
+<p class="meta"><time datetime="{{.Published.Format "2006-01-02T15:04:05Z07:00"}}">{{.Published.Format "2006-01-02"}}</time>
+{{- if .Tags}}<span class="tags">{{range .Tags}} <a href="{{$.Root}}tags/{{.Path}}/">{{.Name}}</a>{{end}}</span>{{end}}</p>
+<div class="article-body">{{.HTML}}</div>
+</article>
+`))
+
+	listBodyTemplate = template.Must(template.New("list").Parse(`{{if .Heading}}<h1>{{.Heading}}</h1>
+{{end}}<ul class="article-list">
+{{- range .Entries}}
+<li><a href="{{$.Root}}articles/{{.ID}}/">{{.Title}}</a> <time class="meta" datetime="{{.Published.Format "2006-01-02T15:04:05Z07:00"}}">{{.Published.Format "2006-01-02"}}</time>
+{{- if .Summary}}<p>{{.Summary}}</p>{{end}}</li>
+{{- end}}
+</ul>
+`))
+)
+
+// entry はビルド中の1記事分の情報
+type entry struct {
+	Article   model.Article
+	ID        uint
+	Title     string
+	Summary   string
+	HTML      template.HTML
+	Tags      []tagLink
+	Published time.Time
+	URL       string
+}
+
+type tagLink struct {
+	Name string
+	Path string
+}
+
+// Builder は公開済みの記事をレイアウトに当てはめて静的サイトを書き出します
+type Builder struct {
+	md           markdown.IRenderer
+	pages        PageRenderer
+	media        storage.IStorage
+	mediaPattern *regexp.Regexp
+	mediaPrefix  string
+}
+
+// NewBuilder はBuilderを作成します
+// mediaにストレージを指定すると、記事から参照されているアップロード画像をサイトに含めます
+// mediaBaseURLはアップロード画像の公開URLのプレフィックス（"/uploads"など）です
+func NewBuilder(md markdown.IRenderer, pages PageRenderer, media storage.IStorage, mediaBaseURL string) *Builder {
+	b := &Builder{md: md, pages: pages, media: media}
+	// 外部のURL（S3など）で配信されている画像はそのまま参照できるため、サイト内のパスの場合のみコピーする
+	if media != nil && strings.HasPrefix(mediaBaseURL, "/") {
+		b.mediaPrefix = strings.TrimRight(mediaBaseURL, "/") + "/"
+		b.mediaPattern = regexp.MustCompile(regexp.QuoteMeta(b.mediaPrefix) + `([0-9]+/[0-9a-f]+(?:_thumb)?\.[a-z]+)`)
+	}
+	return b
+}
+
+// Build はsiteをdirに書き出します
+// fullがfalseの場合は前回のビルド結果を元に、UpdatedAtが変わっていない記事のページの生成を省略します
+// トップページ・タグページ・フィードなど複数の記事に依存するファイルは毎回生成し直します
+func (b *Builder) Build(site Site, dir string, full bool) (Result, error) {
+	if strings.TrimSpace(site.BaseURL) == "" {
+		return Result{}, errors.New("base URL is required")
+	}
+	site.BaseURL = strings.TrimRight(site.BaseURL, "/")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return Result{}, err
+	}
+
+	key := siteKey(site, b.pages)
+	previous := loadManifest(dir)
+	rebuildAll := full || previous == nil || previous.SiteKey != key
+	next := &manifest{Version: manifestVersion, SiteKey: key, Articles: map[uint]time.Time{}, BuiltAt: time.Now()}
+	result := Result{Articles: len(site.Articles), BuiltAt: next.BuiltAt}
+
+	entries, err := b.entries(site)
+	if err != nil {
+		return Result{}, err
+	}
+	mediaKeys := map[string]bool{}
+
+	for _, e := range entries {
+		next.Articles[e.ID] = e.Article.UpdatedAt
+		b.collectMedia(mediaKeys, string(e.HTML), e.Article.OGImage)
+
+		page := filepath.Join("articles", strconv.FormatUint(uint64(e.ID), 10), "index.html")
+		if !rebuildAll && previous.unchanged(e.Article) && fileExists(filepath.Join(dir, page)) {
+			result.Skipped++
+			continue
+		}
+		if err := b.writeArticle(dir, page, site, e); err != nil {
+			return Result{}, err
+		}
+		result.Rendered++
+	}
+
+	if previous != nil {
+		for id := range previous.Articles {
+			if _, ok := next.Articles[id]; ok {
+				continue
+			}
+			if err := os.RemoveAll(filepath.Join(dir, "articles", strconv.FormatUint(uint64(id), 10))); err != nil {
+				return Result{}, err
+			}
+			result.Removed++
+		}
+	}
+
+	if err := b.writeIndexes(dir, site, entries); err != nil {
+		return Result{}, err
+	}
+	if err := b.writeFeeds(dir, site, entries); err != nil {
+		return Result{}, err
+	}
+	if err := b.copyMedia(dir, mediaKeys); err != nil {
+		return Result{}, err
+	}
+	if err := next.save(dir); err != nil {
+		return Result{}, err
+	}
+	return result, nil
+}
+
+// entries は記事をレンダリングし、新しい順に並べて返します
+func (b *Builder) entries(site Site) ([]entry, error) {
+	entries := make([]entry, 0, len(site.Articles))
+	for _, article := range site.Articles {
+		html, err := b.md.Render(article.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render article %d: %w", article.ID, err)
+		}
+		summary := article.MetaDescription
+		if summary == "" {
+			summary = markdown.Excerpt(html, markdown.DefaultExcerptLength)
+		}
+		tags := []tagLink{}
+		for _, tag := range splitTags(article.Tags) {
+			tags = append(tags, tagLink{Name: tag, Path: tagPath(tag)})
+		}
+		entries = append(entries, entry{
+			Article:   article,
+			ID:        article.ID,
+			Title:     article.Title,
+			Summary:   summary,
+			HTML:      template.HTML(html),
+			Tags:      tags,
+			Published: article.CreatedAt,
+			URL:       fmt.Sprintf("%s/articles/%d/", site.BaseURL, article.ID),
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Published.After(entries[j].Published)
+	})
+	return entries, nil
+}
+
+func (b *Builder) writeArticle(dir string, name string, site Site, e entry) error {
+	const root = "../../"
+	var body bytes.Buffer
+	data := struct {
+		entry
+		Root string
+	}{e, root}
+	data.HTML = template.HTML(b.localizeMedia(string(e.HTML), root))
+	if err := articleBodyTemplate.Execute(&body, data); err != nil {
+		return err
+	}
+
+	canonical := e.URL
+	if e.Article.CanonicalURL != "" {
+		canonical = e.Article.CanonicalURL
+	}
+	return b.writePage(dir, name, site, Page{
+		Title:        e.Title,
+		Description:  e.Summary,
+		CanonicalURL: canonical,
+		OGImage:      b.absoluteMedia(e.Article.OGImage, site.BaseURL),
+		NoIndex:      e.Article.NoIndex,
+		Body:         template.HTML(body.String()),
+		Root:         root,
+		Dates:        []time.Time{e.Published},
+	})
+}
+
+// writeIndexes はトップページとタグごとの記事一覧ページを生成します
+// 削除されたタグのページが残らないよう、タグページは毎回すべて作り直します
+func (b *Builder) writeIndexes(dir string, site Site, entries []entry) error {
+	if err := b.writeList(dir, "index.html", site, "", "./", entries); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(filepath.Join(dir, "tags")); err != nil {
+		return err
+	}
+	byTag := map[string][]entry{}
+	tagNames := map[string]string{}
+	for _, e := range entries {
+		for _, tag := range e.Tags {
+			byTag[tag.Path] = append(byTag[tag.Path], e)
+			tagNames[tag.Path] = tag.Name
+		}
+	}
+	for path, tagged := range byTag {
+		name := filepath.Join("tags", path, "index.html")
+		if err := b.writeList(dir, name, site, tagNames[path], "../../", tagged); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Builder) writeList(dir string, name string, site Site, heading string, root string, entries []entry) error {
+	var body bytes.Buffer
+	data := map[string]interface{}{"Heading": heading, "Root": root, "Entries": entries}
+	if err := listBodyTemplate.Execute(&body, data); err != nil {
+		return err
+	}
+
+	canonical := site.BaseURL + "/"
+	if heading != "" {
+		canonical = fmt.Sprintf("%s/tags/%s/", site.BaseURL, tagPath(heading))
+	}
+	dates := make([]time.Time, len(entries))
+	for i, e := range entries {
+		dates[i] = e.Published
+	}
+	return b.writePage(dir, name, site, Page{
+		Title:        heading,
+		CanonicalURL: canonical,
+		Body:         template.HTML(body.String()),
+		Root:         root,
+		Dates:        dates,
+	})
+}
+
+func (b *Builder) writePage(dir string, name string, site Site, page Page) error {
+	page.SiteTitle = site.Title
+	html, err := b.pages.RenderPage(site.Layout, page)
+	if err != nil {
+		return err
+	}
+	return writeFile(dir, name, html)
+}
+
+// writeFeeds はフィード・サイトマップ・robots.txt・スタイルシートを生成します
+func (b *Builder) writeFeeds(dir string, site Site, entries []entry) error {
+	feed := feedgen.Feed{
+		Title:  site.Title,
+		Link:   site.BaseURL + "/",
+		Author: site.Author,
+		Items:  make([]feedgen.Item, 0, len(entries)),
+	}
+	urls := []sitemap.URL{{Loc: site.BaseURL + "/"}}
+	for _, e := range entries {
+		feed.Items = append(feed.Items, feedgen.Item{
+			ID:        e.URL,
+			Title:     e.Title,
+			Link:      e.URL,
+			Summary:   e.Summary,
+			Content:   b.absoluteMedia(string(e.HTML), site.BaseURL),
+			Tags:      tagNames(e.Tags),
+			Published: e.Published,
+			Updated:   e.Article.UpdatedAt,
+		})
+		if e.Article.NoIndex || e.Article.CanonicalURL != "" {
+			continue
+		}
+		urls = append(urls, sitemap.URL{Loc: e.URL, LastMod: e.Article.UpdatedAt})
+	}
+	feed.Updated = feed.LastModified()
+	urls[0].LastMod = sitemap.LastModified(urls)
+	if len(urls) > sitemap.MaxURLs {
+		urls = urls[:sitemap.MaxURLs]
+	}
+
+	feedFile := func(name string, encode func(*feedgen.Feed) ([]byte, error)) func() ([]byte, error) {
+		return func() ([]byte, error) {
+			f := feed
+			f.FeedURL = site.BaseURL + "/" + name
+			return encode(&f)
+		}
+	}
+	files := map[string]func() ([]byte, error){
+		"feed.atom": feedFile("feed.atom", (*feedgen.Feed).Atom),
+		"feed.rss":  feedFile("feed.rss", (*feedgen.Feed).RSS),
+		"feed.json": feedFile("feed.json", (*feedgen.Feed).JSON),
+		"sitemap.xml": func() ([]byte, error) {
+			return sitemap.EncodeURLSet(urls)
+		},
+		"robots.txt": func() ([]byte, error) {
+			return []byte(fmt.Sprintf("User-agent: *\nAllow: /\n\nSitemap: %s/sitemap.xml\n", site.BaseURL)), nil
+		},
+		filepath.Join("assets", "style.css"): func() ([]byte, error) {
+			return []byte(b.pages.StyleSheet(site.Layout) + "\n" + markdown.StyleSheet()), nil
+		},
+	}
+	for name, generate := range files {
+		data, err := generate()
+		if err != nil {
+			return fmt.Errorf("failed to generate %s: %w", name, err)
+		}
+		if err := writeFile(dir, name, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectMedia はHTMLなどから参照されているアップロード画像のキーを集めます
+func (b *Builder) collectMedia(keys map[string]bool, contents ...string) {
+	if b.mediaPattern == nil {
+		return
+	}
+	for _, content := range contents {
+		for _, match := range b.mediaPattern.FindAllStringSubmatch(content, -1) {
+			keys[match[1]] = true
+		}
+	}
+}
+
+// copyMedia は参照されているアップロード画像をサイトのuploadsディレクトリにコピーします
+// キーはアップロードごとに一意で内容が変わらないため、既にコピー済みのファイルは省略します
+func (b *Builder) copyMedia(dir string, keys map[string]bool) error {
+	for key := range keys {
+		dest := filepath.Join(dir, "uploads", filepath.FromSlash(key))
+		if fileExists(dest) {
+			continue
+		}
+		src, err := b.media.Get(key)
+		if errors.Is(err, storage.ErrNotFound) {
+			// 削除済みの画像へのリンクは壊れたままになるが、サイト全体の生成は続ける
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		err = copyTo(dest, src)
+		src.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// localizeMedia はアップロード画像のURLをサイト内の相対パスに置き換えます
+func (b *Builder) localizeMedia(html string, root string) string {
+	if b.mediaPattern == nil {
+		return html
+	}
+	return b.mediaPattern.ReplaceAllString(html, root+"uploads/$1")
+}
+
+// absoluteMedia はアップロード画像のURLを公開先の絶対URLに置き換えます
+func (b *Builder) absoluteMedia(content string, baseURL string) string {
+	if b.mediaPattern == nil {
+		return content
+	}
+	return b.mediaPattern.ReplaceAllString(content, baseURL+"/uploads/$1")
+}
+
+func writeFile(dir string, name string, data []byte) error {
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func copyTo(path string, src io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	dst, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.Remove(path)
+		return err
+	}
+	return dst.Close()
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// splitTags はカンマ区切りのタグを分割します
+func splitTags(tags string) []string {
+	result := []string{}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+func tagNames(tags []tagLink) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
+
+// tagPath はタグをディレクトリ名として使える文字列に変換します
+// 「..」のように変換後が空になるタグは、tags直下に書き出さないようハッシュ値の名前にする
+func tagPath(tag string) string {
+	path := strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', '?', '#', '%', ':', '*', '"', '<', '>', '|':
+			return '-'
+		}
+		return r
+	}, tag)
+	path = strings.TrimLeft(path, ".")
+	if path == "" {
+		sum := sha256.Sum256([]byte(tag))
+		return "tag-" + hex.EncodeToString(sum[:6])
+	}
+	return path
+}
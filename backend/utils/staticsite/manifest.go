@@ -0,0 +1,62 @@
+package staticsite
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-react-app/model"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	manifestFile    = ".manifest.json"
+	manifestVersion = 1
+)
+
+// manifest は前回のビルド内容を記録し、インクリメンタルビルドの判定に使用します
+type manifest struct {
+	Version  int                `json:"version"`
+	SiteKey  string             `json:"site_key"`
+	Articles map[uint]time.Time `json:"articles"`
+	BuiltAt  time.Time          `json:"built_at"`
+}
+
+func loadManifest(dir string) *manifest {
+	data, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return nil
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil || m.Version != manifestVersion {
+		return nil
+	}
+	return &m
+}
+
+func (m *manifest) save(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, manifestFile), data, 0o644)
+}
+
+// siteKey は全ページに影響する設定（レイアウト・レンダラー・公開URLなど）を表す文字列を返します
+// 前回のビルドと異なる場合はすべての記事ページを再生成します
+func siteKey(site Site, renderer PageRenderer) string {
+	latest := site.Layout.UpdatedAt
+	for _, component := range site.Layout.Components {
+		if component.UpdatedAt.After(latest) {
+			latest = component.UpdatedAt
+		}
+	}
+	return fmt.Sprintf("%s|%s|%s|%d|%d|%d",
+		renderer.Name(), site.BaseURL, site.Title, site.Layout.ID, len(site.Layout.Components), latest.UnixNano())
+}
+
+// unchanged は記事が前回のビルドから変更されていないかを判定します
+func (m *manifest) unchanged(article model.Article) bool {
+	builtUpdatedAt, ok := m.Articles[article.ID]
+	return ok && builtUpdatedAt.Equal(article.UpdatedAt)
+}
@@ -0,0 +1,46 @@
+package staticsite
+
+import (
+	"go-react-app/model"
+	"html/template"
+	"time"
+)
+
+// Site は静的サイトとして書き出す内容
+type Site struct {
+	Title    string
+	Author   string
+	BaseURL  string // 公開先のURL（フィード・サイトマップ・canonicalの生成に使用）
+	Layout   model.Layout
+	Articles []model.Article // 公開済みの記事
+}
+
+// Page はレイアウトに埋め込む1ページ分の内容
+type Page struct {
+	SiteTitle    string
+	Title        string
+	Description  string
+	CanonicalURL string
+	OGImage      string
+	NoIndex      bool
+	Body         template.HTML // ページ本文（サニタイズ済みのHTML）
+	Root         string        // サイトのルートへの相対パス（"./"、"../../" など）
+	Dates        []time.Time   // ページに含まれる記事の公開日（新しい順）
+}
+
+// PageRenderer はページをレイアウトに当てはめてHTMLを出力します
+type PageRenderer interface {
+	// Name はレンダラーの識別子。変更するとインクリメンタルビルドで全ページが再生成されます
+	Name() string
+	RenderPage(layout model.Layout, page Page) ([]byte, error)
+	StyleSheet(layout model.Layout) string
+}
+
+// Result はビルドの結果
+type Result struct {
+	Articles int       `json:"articles"` // サイトに含まれる記事数
+	Rendered int       `json:"rendered"` // 再生成した記事ページ数
+	Skipped  int       `json:"skipped"`  // 変更がないためスキップした記事ページ数
+	Removed  int       `json:"removed"`  // 削除・非公開になったため取り除いた記事ページ数
+	BuiltAt  time.Time `json:"built_at"`
+}
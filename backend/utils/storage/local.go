@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type localStorage struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalStorage はdir以下にファイルを保存するストレージを作成します
+// 保存したファイルはbaseURL + "/" + キーで公開されます
+func NewLocalStorage(dir string, baseURL string) IStorage {
+	return &localStorage{dir: dir, baseURL: baseURL}
+}
+
+func (ls *localStorage) Put(key string, body io.Reader, contentType string) error {
+	path, err := ls.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// 書き込み途中のファイルが公開されないよう、一時ファイルに書いてから置き換える
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (ls *localStorage) Get(key string) (io.ReadCloser, error) {
+	path, err := ls.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+func (ls *localStorage) Delete(key string) error {
+	path, err := ls.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (ls *localStorage) URL(key string) string {
+	return joinURL(ls.baseURL, key)
+}
+
+// path はキーを保存先ディレクトリ配下のパスに変換します
+// ディレクトリの外を指すキー（../ など）は拒否します
+func (ls *localStorage) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if cleaned == "/" || strings.Contains(key, "..") {
+		return "", fmt.Errorf("invalid storage key: %s", key)
+	}
+	return filepath.Join(ls.dir, cleaned), nil
+}
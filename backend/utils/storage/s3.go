@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// S3Client はS3互換ストレージの操作に必要な最小限のインターフェース
+// AWS SDKやMinIOクライアントなどをこのインターフェースに合わせてラップして利用します
+type S3Client interface {
+	PutObject(ctx context.Context, bucket string, key string, body io.Reader, contentType string) error
+	GetObject(ctx context.Context, bucket string, key string) (io.ReadCloser, error)
+	DeleteObject(ctx context.Context, bucket string, key string) error
+}
+
+type s3Storage struct {
+	client  S3Client
+	bucket  string
+	baseURL string
+}
+
+// NewS3Storage はS3互換のオブジェクトストレージにファイルを保存するストレージを作成します
+// baseURLにはバケットの公開URL（またはCDNのURL）を指定します
+func NewS3Storage(client S3Client, bucket string, baseURL string) IStorage {
+	return &s3Storage{client: client, bucket: bucket, baseURL: baseURL}
+}
+
+func (ss *s3Storage) Put(key string, body io.Reader, contentType string) error {
+	return ss.client.PutObject(context.Background(), ss.bucket, key, body, contentType)
+}
+
+func (ss *s3Storage) Get(key string) (io.ReadCloser, error) {
+	return ss.client.GetObject(context.Background(), ss.bucket, key)
+}
+
+func (ss *s3Storage) Delete(key string) error {
+	return ss.client.DeleteObject(context.Background(), ss.bucket, key)
+}
+
+func (ss *s3Storage) URL(key string) string {
+	return joinURL(ss.baseURL, key)
+}
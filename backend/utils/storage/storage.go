@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrNotFound は指定されたキーのオブジェクトが存在しない場合のエラー
+var ErrNotFound = errors.New("object not found")
+
+// IStorage はアップロードされたファイルの保存先を抽象化します
+// ローカルディスクやS3互換のオブジェクトストレージを差し替えて利用できます
+type IStorage interface {
+	Put(key string, body io.Reader, contentType string) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+	URL(key string) string
+}
+
+// joinURL はベースURLとキーを連結して公開URLを作成します
+func joinURL(baseURL string, key string) string {
+	return strings.TrimRight(baseURL, "/") + "/" + strings.TrimLeft(key, "/")
+}
@@ -1,13 +1,18 @@
 package validator
 
 import (
+	"errors"
+	"fmt"
 	"go-react-app/model"
+	"net/url"
+	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
 type IArticleValidator interface {
 	ValidateArticleRequest(article model.ArticleRequest) error
+	SEOWarnings(article model.ArticleRequest) []string
 }
 
 type articleValidator struct{}
@@ -19,5 +24,60 @@ func NewArticleValidator() IArticleValidator {
 func (av *articleValidator) ValidateArticleRequest(article model.ArticleRequest) error {
 	return validation.ValidateStruct(&article,
 		validation.Field(&article.Title, validation.Required.Error("タイトルは必須です")),
+		validation.Field(
+			&article.MetaDescription,
+			validation.RuneLength(0, model.ArticleMetaDescriptionMaxLength).Error(
+				fmt.Sprintf("メタディスクリプションは%d文字以内で入力してください", model.ArticleMetaDescriptionMaxLength),
+			),
+		),
+		validation.Field(&article.OGImage, validation.By(validateImageURL)),
+		validation.Field(&article.CanonicalURL, validation.By(validateAbsoluteURL)),
 	)
 }
+
+// SEOWarnings は公開前に確認すべきSEO項目の警告を返します
+// 警告は保存を妨げず、不足している項目を利用者に知らせるためのものです
+func (av *articleValidator) SEOWarnings(article model.ArticleRequest) []string {
+	warnings := []string{}
+	if strings.TrimSpace(article.MetaDescription) == "" {
+		warnings = append(warnings, "メタディスクリプションが設定されていません（本文の抜粋が使用されます）")
+	} else if len([]rune(article.MetaDescription)) > model.ArticleMetaDescriptionRecommendedLength {
+		warnings = append(warnings, fmt.Sprintf("メタディスクリプションが%d文字を超えているため、検索結果で省略される可能性があります", model.ArticleMetaDescriptionRecommendedLength))
+	}
+	if len([]rune(article.Title)) > model.ArticleTitleRecommendedLength {
+		warnings = append(warnings, fmt.Sprintf("タイトルが%d文字を超えているため、検索結果で省略される可能性があります", model.ArticleTitleRecommendedLength))
+	}
+	if strings.TrimSpace(article.OGImage) == "" {
+		warnings = append(warnings, "OG画像が設定されていません")
+	}
+	if strings.TrimSpace(article.Tags) == "" {
+		warnings = append(warnings, "タグが設定されていません")
+	}
+	if article.NoIndex {
+		warnings = append(warnings, "noindexが設定されているため、検索エンジンに表示されません")
+	}
+	return warnings
+}
+
+// validateImageURL は画像URLが絶対URL（http/https）またはサイト内のパスであることを検証します
+// メディアライブラリのURL（/uploads/...）をそのまま指定できるようにしています
+func validateImageURL(value interface{}) error {
+	s := value.(string)
+	if s == "" || strings.HasPrefix(s, "/") && !strings.HasPrefix(s, "//") {
+		return nil
+	}
+	return validateAbsoluteURL(s)
+}
+
+// validateAbsoluteURL はhttpまたはhttpsの絶対URLであることを検証します
+func validateAbsoluteURL(value interface{}) error {
+	s := value.(string)
+	if s == "" {
+		return nil
+	}
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("http(s)から始まる有効なURLを入力してください")
+	}
+	return nil
+}
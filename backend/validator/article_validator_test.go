@@ -58,6 +58,44 @@ func TestArticleValidate(t *testing.T) {
 			},
 			hasError: false, // コンテンツは必須ではない
 		},
+		{
+			name: "Valid SEO fields",
+			request: model.ArticleRequest{
+				Title:           "Valid Title",
+				MetaDescription: "記事の概要",
+				OGImage:         "/uploads/1/cover.png",
+				CanonicalURL:    "https://example.com/gopher/articles/1",
+				UserId:          user.ID,
+			},
+			hasError: false,
+		},
+		{
+			name: "Too long meta description",
+			request: model.ArticleRequest{
+				Title:           "Valid Title",
+				MetaDescription: generateLongTitle(model.ArticleMetaDescriptionMaxLength + 1),
+				UserId:          user.ID,
+			},
+			hasError: true,
+		},
+		{
+			name: "Relative canonical URL",
+			request: model.ArticleRequest{
+				Title:        "Valid Title",
+				CanonicalURL: "/gopher/articles/1",
+				UserId:       user.ID,
+			},
+			hasError: true,
+		},
+		{
+			name: "Invalid OG image scheme",
+			request: model.ArticleRequest{
+				Title:   "Valid Title",
+				OGImage: "javascript:alert(1)",
+				UserId:  user.ID,
+			},
+			hasError: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -70,6 +108,43 @@ func TestArticleValidate(t *testing.T) {
 	}
 }
 
+func TestArticleSEOWarnings(t *testing.T) {
+	validator := NewArticleValidator()
+
+	t.Run("不足している項目を警告する", func(t *testing.T) {
+		warnings := validator.SEOWarnings(model.ArticleRequest{Title: "Title", Published: true})
+		if len(warnings) != 3 {
+			t.Errorf("SEOWarnings() = %v, want 3 warnings (description, og image, tags)", warnings)
+		}
+	})
+
+	t.Run("すべて設定されていれば警告しない", func(t *testing.T) {
+		warnings := validator.SEOWarnings(model.ArticleRequest{
+			Title:           "Title",
+			Tags:            "Go",
+			MetaDescription: "概要",
+			OGImage:         "/uploads/1/cover.png",
+			Published:       true,
+		})
+		if len(warnings) != 0 {
+			t.Errorf("SEOWarnings() = %v, want no warnings", warnings)
+		}
+	})
+
+	t.Run("長すぎるタイトルとnoindexを警告する", func(t *testing.T) {
+		warnings := validator.SEOWarnings(model.ArticleRequest{
+			Title:           generateLongTitle(model.ArticleTitleRecommendedLength + 1),
+			Tags:            "Go",
+			MetaDescription: "概要",
+			OGImage:         "/uploads/1/cover.png",
+			NoIndex:         true,
+		})
+		if len(warnings) != 2 {
+			t.Errorf("SEOWarnings() = %v, want 2 warnings", warnings)
+		}
+	})
+}
+
 // テスト用の長いタイトルを生成
 func generateLongTitle(length int) string {
 	return strings.Repeat("a", length)
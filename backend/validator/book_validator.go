@@ -1,8 +1,11 @@
 package validator
 
 import (
+	"errors"
 	"fmt"
 	"go-react-app/model"
+	"go-react-app/utils/isbn"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
@@ -10,6 +13,9 @@ import (
 type IBookValidator interface {
 	ValidateBookRequest(book model.BookRequest) error
 	ValidateGoogleBookSearchRequest(request model.GoogleBookSearchRequest) error
+	ValidateBookReadingRequest(request model.BookReadingRequest) error
+	// ValidateBookISBNImportRequest はISBNの件数のみを検証します。ISBNが不正な場合は一括登録の結果として報告する
+	ValidateBookISBNImportRequest(request model.BookISBNImportRequest) error
 }
 
 type bookValidator struct{}
@@ -34,6 +40,28 @@ func (bv *bookValidator) ValidateBookRequest(book model.BookRequest) error {
 				fmt.Sprintf("limited max %d char", model.BookAuthorMaxLength),
 			),
 		),
+		validation.Field(
+			&book.ISBN,
+			validation.By(func(value interface{}) error {
+				if value.(string) == "" {
+					return nil
+				}
+				if _, err := isbn.Normalize(value.(string)); err != nil {
+					return errors.New("isbn must be a valid ISBN-10 or ISBN-13")
+				}
+				return nil
+			}),
+		),
+		validation.Field(
+			&book.Publisher,
+			validation.RuneLength(0, model.BookPublisherMaxLength).Error(
+				fmt.Sprintf("limited max %d char", model.BookPublisherMaxLength),
+			),
+		),
+		validation.Field(
+			&book.PageCount,
+			validation.Min(0).Error("page_count must not be negative"),
+		),
 	)
 }
 
@@ -45,3 +73,87 @@ func (bv *bookValidator) ValidateGoogleBookSearchRequest(request model.GoogleBoo
 		),
 	)
 }
+
+func (bv *bookValidator) ValidateBookISBNImportRequest(request model.BookISBNImportRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(
+			&request.ISBNs,
+			validation.Required.Error("isbns is required"),
+			validation.Length(1, model.BookImportMaxRows).Error(fmt.Sprintf("limited max %d isbns", model.BookImportMaxRows)),
+		),
+	)
+}
+
+func (bv *bookValidator) ValidateBookReadingRequest(request model.BookReadingRequest) error {
+	statuses := make([]interface{}, len(model.BookStatuses))
+	for i, status := range model.BookStatuses {
+		statuses[i] = status
+	}
+	// 読みたい本には開始日を、読了・中断以外の本には終了日を設定できない
+	hasStarted := request.Status != model.BookStatusWantToRead
+	hasFinished := request.Status == model.BookStatusFinished || request.Status == model.BookStatusAbandoned
+	return validation.ValidateStruct(&request,
+		validation.Field(
+			&request.Status,
+			validation.Required.Error("status is required"),
+			validation.In(statuses...).Error("status must be one of want-to-read, reading, finished, abandoned"),
+		),
+		validation.Field(
+			&request.StartedAt,
+			validation.Date(model.BookDateLayout).Error("started_at must be YYYY-MM-DD"),
+			validation.When(!hasStarted, validation.Empty.Error("started_at can not be set for want-to-read")),
+		),
+		validation.Field(
+			&request.FinishedAt,
+			validation.Date(model.BookDateLayout).Error("finished_at must be YYYY-MM-DD"),
+			validation.When(!hasFinished, validation.Empty.Error("finished_at can be set only for finished or abandoned")),
+			validation.By(func(value interface{}) error {
+				return validateReadingPeriod(request.StartedAt, value.(string))
+			}),
+		),
+		validation.Field(
+			&request.PageCount,
+			validation.Min(0).Error("page_count must not be negative"),
+		),
+		validation.Field(
+			&request.CurrentPage,
+			validation.Min(0).Error("current_page must not be negative"),
+			validation.When(request.PageCount > 0, validation.Max(request.PageCount).Error("current_page must not exceed page_count")),
+		),
+		validation.Field(
+			&request.ProgressPercent,
+			validation.Min(0).Error("progress_percent must be between 0 and 100"),
+			validation.Max(100).Error("progress_percent must be between 0 and 100"),
+		),
+		validation.Field(
+			&request.Rating,
+			validation.Min(0).Error(fmt.Sprintf("rating must be between 1 and %d", model.BookRatingMax)),
+			validation.Max(model.BookRatingMax).Error(fmt.Sprintf("rating must be between 1 and %d", model.BookRatingMax)),
+		),
+		validation.Field(
+			&request.Notes,
+			validation.RuneLength(0, model.BookNotesMaxLength).Error(fmt.Sprintf("limited max %d char", model.BookNotesMaxLength)),
+		),
+		validation.Field(
+			&request.Highlights,
+			validation.Length(0, model.BookHighlightsMaxCount).Error(fmt.Sprintf("limited max %d highlights", model.BookHighlightsMaxCount)),
+			validation.Each(
+				validation.Required.Error("highlight must not be empty"),
+				validation.RuneLength(1, model.BookHighlightMaxLength).Error(fmt.Sprintf("limited max %d char", model.BookHighlightMaxLength)),
+			),
+		),
+	)
+}
+
+// validateReadingPeriod は読書を終了した日が開始した日より前でないことを検証します
+func validateReadingPeriod(startedAt string, finishedAt string) error {
+	if startedAt == "" || finishedAt == "" {
+		return nil
+	}
+	started, err1 := time.Parse(model.BookDateLayout, startedAt)
+	finished, err2 := time.Parse(model.BookDateLayout, finishedAt)
+	if err1 == nil && err2 == nil && finished.Before(started) {
+		return errors.New("finished_at must not be before started_at")
+	}
+	return nil
+}
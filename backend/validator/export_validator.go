@@ -0,0 +1,37 @@
+package validator
+
+import (
+	"fmt"
+	"go-react-app/model"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const exportTitleMaxLength = 100
+
+type IExportValidator interface {
+	ValidateExportRequest(request model.ExportJobRequest) error
+}
+
+type exportValidator struct{}
+
+func NewExportValidator() IExportValidator {
+	return &exportValidator{}
+}
+
+func (ev *exportValidator) ValidateExportRequest(request model.ExportJobRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.LayoutId, validation.Required.Error("レイアウトIDは必須です")),
+		validation.Field(
+			&request.Title,
+			validation.RuneLength(0, exportTitleMaxLength).Error(
+				fmt.Sprintf("サイトのタイトルは%d文字以内で入力してください", exportTitleMaxLength),
+			),
+		),
+		validation.Field(
+			&request.BaseURL,
+			validation.Required.Error("公開先のURLは必須です"),
+			validation.By(validateAbsoluteURL),
+		),
+	)
+}
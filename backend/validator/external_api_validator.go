@@ -1,7 +1,11 @@
 package validator
 
 import (
+	"errors"
+	"fmt"
 	"go-react-app/model"
+	"net/url"
+	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
@@ -23,5 +27,99 @@ func (av *externalAPIValidator) ExternalAPIValidate(api model.ExternalAPI) error
 			validation.Required.Error("name is required"),
 			validation.RuneLength(1, 50).Error("limited max 50 char"),
 		),
+		validation.Field(
+			&api.BaseURL,
+			validation.Required.Error("base_url is required"),
+			validation.By(validateBaseURL),
+		),
+		validation.Field(
+			&api.Method,
+			validation.In("", "GET", "POST", "PUT", "PATCH", "DELETE").Error("method must be one of GET, POST, PUT, PATCH, DELETE"),
+		),
+		validation.Field(
+			&api.PathTemplate,
+			validation.RuneLength(0, 500).Error("limited max 500 char"),
+			validation.By(validatePathTemplate),
+		),
+		validation.Field(
+			&api.TimeoutSeconds,
+			validation.Min(0).Error("timeout_seconds must not be negative"),
+			validation.Max(model.MaxExternalAPITimeout).Error("timeout_seconds is too large"),
+		),
+		validation.Field(
+			&api.AuthType,
+			validation.In(model.ExternalAPIAuthNone, model.ExternalAPIAuthAPIKey, model.ExternalAPIAuthBearer, model.ExternalAPIAuthBasic).Error("auth_type must be one of api_key, bearer, basic"),
+		),
+		validation.Field(
+			&api.AuthHeader,
+			validation.RuneLength(0, 100).Error("limited max 100 char"),
+			validation.By(validateHeaderName),
+		),
+		validation.Field(
+			&api.AuthCredential,
+			validation.Length(0, 4096).Error("limited max 4096 char"),
+		),
+		validation.Field(
+			&api.HealthCheckPath,
+			validation.RuneLength(0, 500).Error("limited max 500 char"),
+			validation.By(validateHealthCheckPath),
+		),
+		validation.Field(
+			&api.HealthCheckStatus,
+			validation.When(api.HealthCheckStatus != 0,
+				validation.Min(100).Error("health_check_status must be a valid HTTP status code"),
+				validation.Max(599).Error("health_check_status must be a valid HTTP status code"),
+			),
+		),
+		validation.Field(
+			&api.HealthCheckIntervalSeconds,
+			validation.When(api.HealthCheckIntervalSeconds != 0,
+				validation.Min(model.MinHealthCheckInterval).Error(fmt.Sprintf("health_check_interval_seconds must be at least %d", model.MinHealthCheckInterval)),
+				validation.Max(model.MaxHealthCheckInterval).Error(fmt.Sprintf("health_check_interval_seconds must be at most %d", model.MaxHealthCheckInterval)),
+			),
+		),
 	)
 }
+
+// validateBaseURL はhttpまたはhttpsの絶対URLであることを検証します
+// 内部ネットワークへの接続は呼び出し時に拒否する
+func validateBaseURL(value interface{}) error {
+	u, err := url.Parse(value.(string))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("base_url must be an absolute http(s) URL")
+	}
+	if u.User != nil {
+		return errors.New("base_url must not contain credentials (use auth_type instead)")
+	}
+	return nil
+}
+
+// validatePathTemplate はパスがホストを変えられない相対パスであることを検証します
+func validatePathTemplate(value interface{}) error {
+	path := value.(string)
+	if strings.Contains(path, "://") || strings.HasPrefix(path, "//") || strings.ContainsAny(path, "?#") {
+		return errors.New("path_template must be a path without scheme, host, query or fragment")
+	}
+	return nil
+}
+
+// validateHealthCheckPath はヘルスチェックのパスが、呼び出し時の値で置き換える{name}を含まない相対パスであることを検証します
+func validateHealthCheckPath(value interface{}) error {
+	if err := validatePathTemplate(value); err != nil {
+		return errors.New("health_check_path must be a path without scheme, host, query or fragment")
+	}
+	if strings.ContainsAny(value.(string), "{}") {
+		return errors.New("health_check_path must not contain {params}")
+	}
+	return nil
+}
+
+// validateHeaderName はHTTPヘッダーの名前に使える文字のみであることを検証します
+func validateHeaderName(value interface{}) error {
+	for _, r := range value.(string) {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return errors.New("auth_header contains invalid characters")
+		}
+	}
+	return nil
+}
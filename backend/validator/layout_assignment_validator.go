@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"go-react-app/model"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type ILayoutAssignmentValidator interface {
+	ValidateLayoutAssignmentRequest(request model.LayoutAssignmentRequest) error
+	ValidateTag(tag string) error
+}
+
+type layoutAssignmentValidator struct{}
+
+func NewLayoutAssignmentValidator() ILayoutAssignmentValidator {
+	return &layoutAssignmentValidator{}
+}
+
+func (av *layoutAssignmentValidator) ValidateLayoutAssignmentRequest(request model.LayoutAssignmentRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.LayoutId, validation.Required.Error("レイアウトIDは必須です")),
+	)
+}
+
+// ValidateTag はレイアウトを指定するタグを検証します
+// 記事のタグはカンマ区切りのため、カンマを含むタグは指定できない
+func (av *layoutAssignmentValidator) ValidateTag(tag string) error {
+	return validation.Validate(tag,
+		validation.Required.Error("タグは必須です"),
+		validation.RuneLength(1, 100).Error("タグは100文字以内で入力してください"),
+		validation.By(func(value interface{}) error {
+			if strings.Contains(value.(string), ",") {
+				return validation.NewError("validation_tag_comma", "タグにカンマは使用できません")
+			}
+			return nil
+		}),
+	)
+}
@@ -0,0 +1,75 @@
+package layout_component_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLayoutComponentValidator_ValidateComponentType(t *testing.T) {
+	setupLayoutComponentValidatorTest()
+
+	t.Run("正常系", func(t *testing.T) {
+		t.Run("定義に適合するプロパティの場合はエラーを返さない", func(t *testing.T) {
+			request := createValidLayoutComponentRequest()
+			request.Type = "header"
+			request.Width = 12
+			request.Height = 2
+			request.Properties = json.RawMessage(`{"title": "My Blog", "links": [{"label": "ホーム", "url": "/"}], "backgroundColor": "#ffffff"}`)
+
+			if err := layoutComponentValidator.ValidateLayoutComponentRequest(request); err != nil {
+				t.Errorf("ValidateLayoutComponentRequest() error = %v, want nil", err)
+			}
+		})
+
+		t.Run("サイズが0の場合は範囲を検証しない", func(t *testing.T) {
+			request := createValidLayoutComponentRequest()
+			request.Type = "header"
+			request.Width = 0
+			request.Height = 0
+
+			if err := layoutComponentValidator.ValidateLayoutComponentRequest(request); err != nil {
+				t.Errorf("ValidateLayoutComponentRequest() error = %v, want nil", err)
+			}
+		})
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			typ        string
+			width      int
+			height     int
+			properties string
+			wantErr    string
+		}{
+			{"登録されていないタイプ", "marquee", 0, 0, "", "対応していないタイプです"},
+			{"幅が最小値より小さい", "header", 2, 2, "", "幅は4以上にしてください"},
+			{"高さが最大値より大きい", "header", 12, 7, "", "高さは6以下にしてください"},
+			{"色の形式が正しくない", "header", 12, 2, `{"backgroundColor": "white"}`, "/backgroundColor"},
+			{"定義されていないプロパティ", "header", 12, 2, `{"unknown": true}`, "プロパティがheaderの定義に適合しません"},
+			{"リンクの必須項目がない", "header", 12, 2, `{"links": [{"label": "ホーム"}]}`, "/links/0"},
+			{"JSONとして不正", "header", 12, 2, `{"title":`, "有効なJSON"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name+"の場合はエラーを返す", func(t *testing.T) {
+				request := createValidLayoutComponentRequest()
+				request.Type = tt.typ
+				request.Width = tt.width
+				request.Height = tt.height
+				if tt.properties != "" {
+					request.Properties = json.RawMessage(tt.properties)
+				}
+
+				err := layoutComponentValidator.ValidateLayoutComponentRequest(request)
+				if err == nil {
+					t.Fatalf("ValidateLayoutComponentRequest() error = nil, want error containing %q", tt.wantErr)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ValidateLayoutComponentRequest() error = %v, want error containing %q", err, tt.wantErr)
+				}
+			})
+		}
+	})
+}
@@ -36,11 +36,9 @@ func TestLayoutComponentValidator_ValidatePositionRequest(t *testing.T) {
 			}
 		})
 
-		t.Run("負の座標でも有効", func(t *testing.T) {
-			// 負の座標を持つリクエスト
+		t.Run("ピクセル単位の指定も有効", func(t *testing.T) {
 			request := createValidPositionRequest()
-			request.X = -10
-			request.Y = -20
+			request.Unit = "px"
 
 			// テスト実行
 			err := layoutComponentValidator.ValidatePositionRequest(request)
@@ -52,8 +50,59 @@ func TestLayoutComponentValidator_ValidatePositionRequest(t *testing.T) {
 		})
 	})
 
-	// 現在の実装では位置情報に対するバリデーションは行われていないため、
-	// 異常系のテストケースは追加していません。
-	// もし将来的に位置情報のバリデーションが追加された場合は、
-	// ここに異常系のテストケースを追加してください。
+	t.Run("異常系", func(t *testing.T) {
+		t.Run("負の座標の場合はエラーを返す", func(t *testing.T) {
+			// 負の座標を持つリクエスト
+			request := createValidPositionRequest()
+			request.X = -10
+			request.Y = -20
+
+			// テスト実行
+			err := layoutComponentValidator.ValidatePositionRequest(request)
+
+			// 検証
+			if err == nil {
+				t.Error("ValidatePositionRequest() error = nil, want error for negative position")
+			}
+		})
+
+		t.Run("負のサイズの場合はエラーを返す", func(t *testing.T) {
+			request := createValidPositionRequest()
+			request.Width = -1
+
+			// テスト実行
+			err := layoutComponentValidator.ValidatePositionRequest(request)
+
+			// 検証
+			if err == nil {
+				t.Error("ValidatePositionRequest() error = nil, want error for negative width")
+			}
+		})
+
+		t.Run("対応していない単位の場合はエラーを返す", func(t *testing.T) {
+			request := createValidPositionRequest()
+			request.Unit = "em"
+
+			// テスト実行
+			err := layoutComponentValidator.ValidatePositionRequest(request)
+
+			// 検証
+			if err == nil {
+				t.Error("ValidatePositionRequest() error = nil, want error for unknown unit")
+			}
+		})
+
+		t.Run("割り当て時の位置情報も検証する", func(t *testing.T) {
+			request := createValidAssignLayoutRequest()
+			request.Position.Y = -1
+
+			// テスト実行
+			err := layoutComponentValidator.ValidateAssignLayoutRequest(request)
+
+			// 検証
+			if err == nil {
+				t.Error("ValidateAssignLayoutRequest() error = nil, want error for negative position")
+			}
+		})
+	})
 }
@@ -1,9 +1,14 @@
 package validator
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"go-react-app/model"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/santhosh-tekuri/jsonschema/v5"
 )
 
 type ILayoutComponentValidator interface {
@@ -12,26 +17,129 @@ type ILayoutComponentValidator interface {
 	ValidatePositionRequest(position model.PositionRequest) error
 }
 
-type layoutComponentValidator struct{}
+// maxZIndex は重なり順に指定できる値の絶対値の上限
+const maxZIndex = 1000
 
+type layoutComponentValidator struct {
+	schemas map[string]*jsonschema.Schema
+}
+
+// NewLayoutComponentValidator は登録されているコンポーネントの種類のJSON Schemaをコンパイルしてバリデーターを作成します
 func NewLayoutComponentValidator() ILayoutComponentValidator {
-	return &layoutComponentValidator{}
+	schemas := make(map[string]*jsonschema.Schema, len(model.ComponentTypes))
+	for _, componentType := range model.ComponentTypes {
+		url := "component-types/" + componentType.Type + ".json"
+		compiler := jsonschema.NewCompiler()
+		if err := compiler.AddResource(url, bytes.NewReader(componentType.Schema)); err != nil {
+			panic(fmt.Sprintf("invalid schema for component type %s: %v", componentType.Type, err))
+		}
+		schemas[componentType.Type] = compiler.MustCompile(url)
+	}
+	return &layoutComponentValidator{schemas}
 }
 
 func (lcv *layoutComponentValidator) ValidateLayoutComponentRequest(component model.LayoutComponentRequest) error {
+	componentType, known := model.FindComponentType(component.Type)
 	return validation.ValidateStruct(&component,
 		validation.Field(&component.Name, validation.Required.Error("名前は必須です")),
-		validation.Field(&component.Type, validation.Required.Error("タイプは必須です")),
+		validation.Field(
+			&component.Type,
+			validation.Required.Error("タイプは必須です"),
+			validation.By(func(value interface{}) error {
+				if !known {
+					return fmt.Errorf("対応していないタイプです: %s", value)
+				}
+				return nil
+			}),
+		),
+		// サイズは0の場合に種類の既定値が使われるため、指定された場合のみ範囲を検証する
+		validation.Field(&component.Width, validation.When(known && component.Width != 0,
+			validation.By(sizeRule("幅", componentType.MinWidth, componentType.MaxWidth)),
+		)),
+		validation.Field(&component.Height, validation.When(known && component.Height != 0,
+			validation.By(sizeRule("高さ", componentType.MinHeight, componentType.MaxHeight)),
+		)),
+		validation.Field(&component.ZIndex,
+			validation.Min(-maxZIndex).Error(fmt.Sprintf("重なり順は%d以上%d以下にしてください", -maxZIndex, maxZIndex)),
+			validation.Max(maxZIndex).Error(fmt.Sprintf("重なり順は%d以上%d以下にしてください", -maxZIndex, maxZIndex)),
+		),
+		validation.Field(&component.Properties, validation.When(known,
+			validation.By(func(value interface{}) error {
+				return lcv.validateProperties(componentType.Type, value.(json.RawMessage))
+			}),
+		)),
 	)
 }
 
 func (lcv *layoutComponentValidator) ValidateAssignLayoutRequest(request model.AssignLayoutRequest) error {
 	return validation.ValidateStruct(&request,
 		validation.Field(&request.LayoutId, validation.Required.Error("レイアウトIDは必須です")),
+		validation.Field(&request.Position, validation.By(func(value interface{}) error {
+			return lcv.ValidatePositionRequest(value.(model.PositionRequest))
+		})),
 	)
 }
 
+// ValidatePositionRequest は位置とサイズが負の値でないことを検証します（幅と高さの0は現在の値を維持する）
 func (lcv *layoutComponentValidator) ValidatePositionRequest(position model.PositionRequest) error {
-	// 位置情報のバリデーションが必要な場合はここに追加
+	return validatePosition(position)
+}
+
+// validatePosition は位置情報のルール（レイアウトの一括配置でも使う）で検証します
+func validatePosition(position model.PositionRequest) error {
+	return validation.ValidateStruct(&position,
+		validation.Field(&position.X, validation.Min(0).Error("X座標は0以上にしてください")),
+		validation.Field(&position.Y, validation.Min(0).Error("Y座標は0以上にしてください")),
+		validation.Field(&position.Width, validation.Min(0).Error("幅は0以上にしてください")),
+		validation.Field(&position.Height, validation.Min(0).Error("高さは0以上にしてください")),
+		validation.Field(&position.Unit,
+			validation.In(model.PositionUnitGrid, model.PositionUnitPixel).Error("単位はgridまたはpxを指定してください"),
+		),
+	)
+}
+
+// validateProperties はpropertiesが種類のJSON Schemaに適合するかを検証します
+// 未設定の場合は、必須の項目がある種類（フィードなど）で省略できないよう空のオブジェクトとして検証する
+func (lcv *layoutComponentValidator) validateProperties(componentType string, properties json.RawMessage) error {
+	if len(properties) == 0 {
+		properties = json.RawMessage(`{}`)
+	}
+	var value interface{}
+	if err := json.Unmarshal(properties, &value); err != nil {
+		return errors.New("プロパティは有効なJSONで入力してください")
+	}
+	if err := lcv.schemas[componentType].Validate(value); err != nil {
+		var validationErr *jsonschema.ValidationError
+		if errors.As(err, &validationErr) {
+			return fmt.Errorf("プロパティが%sの定義に適合しません: %s", componentType, describeSchemaError(validationErr))
+		}
+		return err
+	}
 	return nil
 }
+
+// describeSchemaError はJSON Schemaの検証エラーから、最初に見つかった具体的な原因を返します
+func describeSchemaError(err *jsonschema.ValidationError) string {
+	for len(err.Causes) > 0 {
+		err = err.Causes[0]
+	}
+	location := err.InstanceLocation
+	if location == "" {
+		location = "/"
+	}
+	return fmt.Sprintf("%s: %s", location, err.Message)
+}
+
+// sizeRule はサイズがmin以上max以下であることを検証するルールを返します（maxが0の場合は上限なし）
+func sizeRule(label string, min int, max int) validation.RuleFunc {
+	return func(value interface{}) error {
+		size := value.(int)
+		if size < min {
+			return fmt.Errorf("%sは%d以上にしてください", label, min)
+		}
+		if max > 0 && size > max {
+			return fmt.Errorf("%sは%d以下にしてください", label, max)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,26 @@
+package validator
+
+import (
+	"go-react-app/model"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type ILayoutSnapshotValidator interface {
+	ValidateLayoutSnapshotRequest(request model.LayoutSnapshotRequest) error
+}
+
+type layoutSnapshotValidator struct{}
+
+func NewLayoutSnapshotValidator() ILayoutSnapshotValidator {
+	return &layoutSnapshotValidator{}
+}
+
+func (sv *layoutSnapshotValidator) ValidateLayoutSnapshotRequest(request model.LayoutSnapshotRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.Label, snapshotLabelRule),
+	)
+}
+
+// snapshotLabelRule はスナップショットのラベルのルール（レイアウトの一括配置で保存する場合にも使う）
+var snapshotLabelRule = validation.RuneLength(0, 100).Error("ラベルは100文字以内で入力してください")
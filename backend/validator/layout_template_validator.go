@@ -0,0 +1,30 @@
+package validator
+
+import (
+	"go-react-app/model"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type ILayoutTemplateValidator interface {
+	ValidateLayoutTemplateRequest(request model.LayoutTemplateRequest) error
+}
+
+type layoutTemplateValidator struct{}
+
+func NewLayoutTemplateValidator() ILayoutTemplateValidator {
+	return &layoutTemplateValidator{}
+}
+
+func (tv *layoutTemplateValidator) ValidateLayoutTemplateRequest(request model.LayoutTemplateRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.LayoutId, validation.Required.Error("レイアウトIDは必須です")),
+		validation.Field(&request.Name,
+			validation.Required.Error("テンプレート名は必須です"),
+			validation.RuneLength(1, 100).Error("テンプレート名は100文字以内で入力してください"),
+		),
+		validation.Field(&request.Description,
+			validation.RuneLength(0, 500).Error("説明は500文字以内で入力してください"),
+		),
+	)
+}
@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"fmt"
 	"go-react-app/model"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -8,6 +9,9 @@ import (
 
 type ILayoutValidator interface {
 	ValidateLayoutRequest(layout model.LayoutRequest) error
+	ValidateArrangementRequest(request model.ArrangementRequest) error
+	ValidateLayoutDocument(document model.LayoutDocument) error
+	ValidateBreakpoint(breakpoint string) error
 }
 
 type layoutValidator struct{}
@@ -19,5 +23,84 @@ func NewLayoutValidator() ILayoutValidator {
 func (lv *layoutValidator) ValidateLayoutRequest(layout model.LayoutRequest) error {
 	return validation.ValidateStruct(&layout,
 		validation.Field(&layout.Title, validation.Required.Error("タイトルは必須です")),
+		// グリッドの設定は0の場合に既定値が使われるため、指定された場合のみ範囲を検証する
+		validation.Field(&layout.GridColumns,
+			validation.Min(0).Error("グリッドの列数は0（グリッドを使わない）以上48以下にしてください"),
+			validation.Max(48).Error("グリッドの列数は0（グリッドを使わない）以上48以下にしてください"),
+		),
+		validation.Field(&layout.RowHeight, validation.When(layout.RowHeight != 0,
+			validation.Min(10).Error("行の高さは10px以上200px以下にしてください"),
+			validation.Max(200).Error("行の高さは10px以上200px以下にしてください"),
+		)),
+		validation.Field(&layout.CanvasWidth, validation.When(layout.CanvasWidth != 0,
+			validation.Min(320).Error("キャンバスの幅は320px以上3840px以下にしてください"),
+			validation.Max(3840).Error("キャンバスの幅は320px以上3840px以下にしてください"),
+		)),
+		validation.Field(&layout.CollisionMode,
+			validation.In(model.CollisionModePush, model.CollisionModeReject).Error("重なりの扱いはpushまたはrejectを指定してください"),
+		),
 	)
 }
+
+func (lv *layoutValidator) ValidateArrangementRequest(request model.ArrangementRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.Version, validation.Required.Error("バージョンは必須です")),
+		validation.Field(&request.Breakpoint, breakpointRule),
+		validation.Field(&request.Components,
+			validation.Required.Error("コンポーネントの位置は必須です"),
+			validation.By(func(value interface{}) error {
+				seen := make(map[uint]bool)
+				for i, component := range value.([]model.ComponentPosition) {
+					if component.ComponentId == 0 {
+						return fmt.Errorf("%d番目のコンポーネントIDは必須です", i+1)
+					}
+					if seen[component.ComponentId] {
+						return fmt.Errorf("コンポーネント%dが重複しています", component.ComponentId)
+					}
+					seen[component.ComponentId] = true
+					if err := validatePosition(component.PositionRequest); err != nil {
+						return fmt.Errorf("コンポーネント%dの位置が不正です: %v", component.ComponentId, err)
+					}
+				}
+				return nil
+			}),
+		),
+		validation.Field(&request.SnapshotLabel, snapshotLabelRule),
+	)
+}
+
+// ValidateLayoutDocument は読み込むレイアウトのJSONの形式とレイアウト自体の設定を検証します
+// コンポーネントは1つずつ検証し、不正なものだけを読み込まないようにするためここでは件数のみ検証する
+func (lv *layoutValidator) ValidateLayoutDocument(document model.LayoutDocument) error {
+	if err := validation.ValidateStruct(&document,
+		validation.Field(&document.SchemaVersion,
+			validation.Required.Error("schema_versionは必須です"),
+			validation.In(model.LayoutDocumentVersion).Error(fmt.Sprintf("対応していないschema_versionです（対応しているバージョン: %d）", model.LayoutDocumentVersion)),
+		),
+	); err != nil {
+		return err
+	}
+	if err := validation.Validate(document.Layout.Components,
+		validation.Length(0, maxDocumentComponents).Error(fmt.Sprintf("コンポーネントは%d個以下にしてください", maxDocumentComponents)),
+	); err != nil {
+		return validation.Errors{"components": err}
+	}
+	return lv.ValidateLayoutRequest(model.LayoutRequest{
+		Title:         document.Layout.Title,
+		GridColumns:   document.Layout.Grid.Columns,
+		RowHeight:     document.Layout.Grid.RowHeight,
+		CanvasWidth:   document.Layout.Grid.CanvasWidth,
+		CollisionMode: document.Layout.Grid.CollisionMode,
+	})
+}
+
+// maxDocumentComponents 1つのレイアウトのJSONから読み込むコンポーネントの最大数
+const maxDocumentComponents = 200
+
+// ValidateBreakpoint はブレークポイントの名前を検証します（空の場合はdesktopとして扱う）
+func (lv *layoutValidator) ValidateBreakpoint(breakpoint string) error {
+	return validation.Validate(breakpoint, breakpointRule)
+}
+
+var breakpointRule = validation.In(model.BreakpointDesktop, model.BreakpointTablet, model.BreakpointMobile).
+	Error("ブレークポイントはdesktop・tablet・mobileのいずれかを指定してください")
@@ -0,0 +1,59 @@
+package layout_validator_test
+
+import (
+	"go-react-app/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayoutValidator_ValidateLayoutRequest_Grid(t *testing.T) {
+	setupLayoutValidatorTest()
+
+	t.Run("グリッドの設定が範囲内の場合は有効", func(t *testing.T) {
+		request := model.LayoutRequest{
+			Title:         "グリッドのレイアウト",
+			GridColumns:   12,
+			RowHeight:     30,
+			CanvasWidth:   1200,
+			CollisionMode: model.CollisionModeReject,
+			UserId:        1,
+		}
+
+		// テスト実行
+		err := layoutValidator.ValidateLayoutRequest(request)
+
+		// 検証
+		assert.NoError(t, err, "有効なグリッドの設定でエラーが発生しました")
+	})
+
+	t.Run("グリッドの設定を省略した場合も有効", func(t *testing.T) {
+		request := model.LayoutRequest{Title: "グリッドなしのレイアウト", UserId: 1}
+
+		// テスト実行
+		err := layoutValidator.ValidateLayoutRequest(request)
+
+		// 検証
+		assert.NoError(t, err, "グリッドの設定の省略でエラーが発生しました")
+	})
+
+	tests := []struct {
+		name    string
+		request model.LayoutRequest
+	}{
+		{"負の列数", model.LayoutRequest{Title: "レイアウト", GridColumns: -1}},
+		{"多すぎる列数", model.LayoutRequest{Title: "レイアウト", GridColumns: 49}},
+		{"小さすぎる行の高さ", model.LayoutRequest{Title: "レイアウト", RowHeight: 5}},
+		{"大きすぎるキャンバスの幅", model.LayoutRequest{Title: "レイアウト", CanvasWidth: 5000}},
+		{"対応していない重なりの扱い", model.LayoutRequest{Title: "レイアウト", CollisionMode: "overlap"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"の場合はエラーを返す", func(t *testing.T) {
+			// テスト実行
+			err := layoutValidator.ValidateLayoutRequest(tt.request)
+
+			// 検証
+			assert.Error(t, err)
+		})
+	}
+}
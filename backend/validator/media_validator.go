@@ -0,0 +1,52 @@
+package validator
+
+import (
+	"fmt"
+	"go-react-app/model"
+	"go-react-app/utils/imaging"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const mediaFileNameMaxLength = 255
+
+type IMediaValidator interface {
+	ValidateUploadRequest(request model.MediaUploadRequest) error
+}
+
+type mediaValidator struct {
+	maxUploadSize int64
+}
+
+// NewMediaValidator はmaxUploadSizeバイトまでのアップロードを許可するバリデーターを作成します
+func NewMediaValidator(maxUploadSize int64) IMediaValidator {
+	return &mediaValidator{maxUploadSize}
+}
+
+func (mv *mediaValidator) ValidateUploadRequest(request model.MediaUploadRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(
+			&request.FileName,
+			validation.Required.Error("ファイル名は必須です"),
+			validation.RuneLength(1, mediaFileNameMaxLength).Error(
+				fmt.Sprintf("ファイル名は%d文字以内にしてください", mediaFileNameMaxLength),
+			),
+		),
+		validation.Field(
+			&request.MimeType,
+			validation.By(func(value interface{}) error {
+				if _, ok := imaging.SupportedTypes[value.(string)]; !ok {
+					return fmt.Errorf("対応していないファイル形式です: %s", value)
+				}
+				return nil
+			}),
+		),
+		validation.Field(
+			&request.Size,
+			validation.Required.Error("ファイルが空です"),
+			validation.Max(mv.maxUploadSize).Error(
+				fmt.Sprintf("ファイルサイズは%dバイト以下にしてください", mv.maxUploadSize),
+			),
+		),
+	)
+}
@@ -0,0 +1,96 @@
+package validator
+
+import (
+	"errors"
+	"go-react-app/model"
+	"go-react-app/utils/jsonmap"
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// mappingParamPattern 外部APIの呼び出しに渡すparamsの名前に使える文字（パスの{name}と同じ）
+var mappingParamPattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+type IResponseMappingValidator interface {
+	ValidateResponseMappingRequest(request model.ResponseMappingRequest) error
+	// ValidateMappingDefinition は名前以外の、プレビューに必要な項目のみを検証します
+	ValidateMappingDefinition(request model.ResponseMappingRequest) error
+}
+
+type responseMappingValidator struct{}
+
+func NewResponseMappingValidator() IResponseMappingValidator {
+	return &responseMappingValidator{}
+}
+
+func (rv *responseMappingValidator) ValidateResponseMappingRequest(request model.ResponseMappingRequest) error {
+	if err := validation.Validate(request.Name,
+		validation.Required.Error("名前は必須です"),
+		validation.RuneLength(1, 100).Error("名前は100文字以内で入力してください"),
+	); err != nil {
+		return validation.Errors{"name": err}
+	}
+	return rv.ValidateMappingDefinition(request)
+}
+
+func (rv *responseMappingValidator) ValidateMappingDefinition(request model.ResponseMappingRequest) error {
+	fields := request.Fields
+	return validation.Errors{
+		"external_api_id": validation.Validate(request.ExternalAPIId, validation.Required.Error("外部APIのIDは必須です")),
+		"params":          validateMappingParams(request.Params),
+		"items_path": validation.Validate(request.ItemsPath,
+			validation.Required.Error("項目の配列のパス式は必須です"),
+			validation.RuneLength(1, 500).Error("パス式は500文字以内で入力してください"),
+			validation.By(func(value interface{}) error {
+				_, err := jsonmap.ParsePath(value.(string))
+				return err
+			}),
+		),
+		"fields": validation.Errors{
+			"id":        validateCardTemplate(fields.ID),
+			"title":     validation.Validate(fields.Title, validation.Required.Error("タイトルのテンプレートは必須です"), validation.By(cardTemplateRule)),
+			"url":       validateCardTemplate(fields.URL),
+			"excerpt":   validateCardTemplate(fields.Excerpt),
+			"image_url": validateCardTemplate(fields.ImageURL),
+			"date":      validateCardTemplate(fields.Date),
+			"tags": validation.Validate(fields.Tags, validation.By(cardTemplateRule), validation.By(func(value interface{}) error {
+				template, _ := jsonmap.ParseTemplate(value.(string))
+				if !template.IsEmpty() && !template.IsSingleExpression() {
+					return errors.New("タグのテンプレートには1つのパス式のみを指定してください")
+				}
+				return nil
+			})),
+		}.Filter(),
+	}.Filter()
+}
+
+// validateMappingParams は外部APIの呼び出しに渡すparamsを検証します
+func validateMappingParams(params map[string]string) error {
+	if len(params) > 20 {
+		return errors.New("paramsは20件以内で指定してください")
+	}
+	for name, value := range params {
+		if !mappingParamPattern.MatchString(name) {
+			return errors.New("paramsの名前には半角英数字とアンダースコアのみ使用できます")
+		}
+		if len(value) > 500 {
+			return errors.New("paramsの値は500文字以内で入力してください")
+		}
+	}
+	return nil
+}
+
+func validateCardTemplate(template string) error {
+	return validation.Validate(template, validation.By(cardTemplateRule))
+}
+
+// cardTemplateRule はカードの項目のテンプレートの長さと構文を検証します
+func cardTemplateRule(value interface{}) error {
+	text := value.(string)
+	if len([]rune(text)) > 500 {
+		return errors.New("テンプレートは500文字以内で入力してください")
+	}
+	_, err := jsonmap.ParseTemplate(text)
+	return err
+}
@@ -0,0 +1,24 @@
+package validator
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const searchQueryMaxLength = 100
+
+type ISearchValidator interface {
+	ValidateSearchQuery(query string) error
+}
+
+type searchValidator struct{}
+
+func NewSearchValidator() ISearchValidator {
+	return &searchValidator{}
+}
+
+func (sv *searchValidator) ValidateSearchQuery(query string) error {
+	return validation.Validate(query,
+		validation.Required.Error("検索キーワードは必須です"),
+		validation.RuneLength(1, searchQueryMaxLength).Error("検索キーワードは100文字以内で入力してください"),
+	)
+}
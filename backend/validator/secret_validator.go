@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"go-react-app/model"
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// secretNamePattern 秘密情報の名前に使える文字
+// 外部APIの認証情報（external_api:<ID>）は外部APIのAPIで管理するため":"は使えない
+var secretNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+type ISecretValidator interface {
+	ValidateSecretName(name string) error
+	ValidateSecretRequest(request model.SecretRequest) error
+}
+
+type secretValidator struct{}
+
+func NewSecretValidator() ISecretValidator {
+	return &secretValidator{}
+}
+
+func (sv *secretValidator) ValidateSecretName(name string) error {
+	return validation.Validate(name,
+		validation.Required.Error("名前は必須です"),
+		validation.Length(1, 100).Error("名前は100文字以内で入力してください"),
+		validation.Match(secretNamePattern).Error("名前には半角英小文字・数字・アンダースコアのみ使用できます"),
+	)
+}
+
+func (sv *secretValidator) ValidateSecretRequest(request model.SecretRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.Value,
+			validation.Required.Error("値は必須です"),
+			validation.Length(1, 4096).Error("値は4096文字以内で入力してください"),
+		),
+	)
+}
@@ -3,13 +3,18 @@ package validator
 import (
 	"fmt"
 	"go-react-app/model"
+	"regexp"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 )
 
+// usernamePattern ユーザー名に使用できる文字（英小文字・数字・ハイフン・アンダースコア）
+var usernamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
 type IUserValidator interface {
 	UserValidate(user model.User) error
+	UsernameValidate(username string) error
 }
 
 type userValidator struct{}
@@ -35,5 +40,25 @@ func (uv *userValidator) UserValidate(user model.User) error {
 				fmt.Sprintf("パスワードは%d文字から%d文字の間である必要があります", 
 				model.UserPasswordMinLength, model.UserPasswordMaxLength)),
 		),
+		validation.Field(
+			&user.Username,
+			validation.By(func(value interface{}) error {
+				// ユーザー名は任意項目のため、指定された場合のみ検証する
+				if value.(string) == "" {
+					return nil
+				}
+				return uv.UsernameValidate(value.(string))
+			}),
+		),
 	)
-}
\ No newline at end of file
+}
+
+func (uv *userValidator) UsernameValidate(username string) error {
+	return validation.Validate(username,
+		validation.Required.Error("ユーザー名は必須です"),
+		validation.RuneLength(model.UserUsernameMinLength, model.UserUsernameMaxLength).Error(
+			fmt.Sprintf("ユーザー名は%d文字から%d文字の間である必要があります",
+				model.UserUsernameMinLength, model.UserUsernameMaxLength)),
+		validation.Match(usernamePattern).Error("ユーザー名には英小文字・数字・ハイフン・アンダースコアのみ使用できます"),
+	)
+}